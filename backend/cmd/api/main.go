@@ -6,32 +6,36 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/gin-contrib/cors"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/go-redis/redis/v8"
 
 	"microbridge/backend/config"
+	"microbridge/backend/internal/ai/features"
+	aiModels "microbridge/backend/internal/ai/models"
+	aiServices "microbridge/backend/internal/ai/services"
+	appstatus "microbridge/backend/internal/core/applications"
+	"microbridge/backend/internal/core/behavior"
+	"microbridge/backend/internal/core/interviews"
+	jobstatus "microbridge/backend/internal/core/jobs"
+	"microbridge/backend/internal/core/matching"
+	"microbridge/backend/internal/core/privacy"
+	"microbridge/backend/internal/core/skills"
 	"microbridge/backend/internal/database"
 	"microbridge/backend/internal/repository"
 	"microbridge/backend/internal/services"
+	"microbridge/backend/internal/shared/cache"
+	"microbridge/backend/internal/shared/monitoring"
+	"microbridge/backend/internal/shared/storage"
 	"microbridge/backend/internal/transport/http/handlers"
 	"microbridge/backend/internal/transport/http/middleware"
+	"microbridge/backend/internal/transport/http/routes"
 	"microbridge/backend/pkg/jwt"
 	pkglogger "microbridge/backend/pkg/logger"
 )
 
-type Application struct {
-	config       *config.Config
-	logger       *pkglogger.Logger
-	db           database.Database
-	jwtService   *jwt.Service
-	userService  services.UserService
-	emailService services.EmailService
-}
-
 func main() {
 	// Load configuration
 	cfg, err := config.LoadConfig()
@@ -78,24 +82,264 @@ func main() {
 		cfg.JWT.RefreshExpiry,
 	)
 
+	// Initialize Redis
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Host + ":" + cfg.Redis.Port,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer redisClient.Close()
+
+	// Feature definitions shared by training and inference
+	featureRegistry := features.DefaultRegistry()
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db.DB())
+	jobRepo := repository.NewJobRepository(db.DB())
+	trainingRepo := repository.NewTrainingRepository(db.DB(), featureRegistry)
+	ensembleWeightRepo := repository.NewEnsembleWeightRepository(db.DB())
+	driftReferenceRepo := repository.NewDriftReferenceRepository(db.DB())
+	banditDecisionRepo := repository.NewBanditDecisionRepository(db.DB())
+	behaviorRepo := repository.NewBehaviorRepository(db.DB())
+	privacyRepo := repository.NewPrivacyRepository(db.DB())
+	applicationRepo := repository.NewApplicationRepository(db.DB())
+	reviewRepo := repository.NewReviewRepository(db.DB())
+	interviewRepo := repository.NewInterviewRepository(db.DB())
+	offerRepo := repository.NewOfferRepository(db.DB())
+	applicationBulkRepo := repository.NewApplicationBulkRepository(db.DB())
+	fileRepo := repository.NewFileRepository(db.DB())
+
+	// Cached model features are dropped when a profile or posting changes
+	entityLoader := aiServices.NewRepositoryEntityLoader(userRepo, jobRepo)
+	featureStore := features.NewStore(featureRegistry, features.NewRedisCache(redisClient), entityLoader, nil)
 
 	// Initialize services
 	emailService := services.NewEmailService()
-	userService := services.NewUserService(userRepo, jwtService, emailService)
+	userService := services.NewUserService(userRepo, jwtService, emailService, featureStore)
+	skillDictionary := skills.DefaultDictionary()
+	resumeService := services.NewResumeService(userRepo, skillDictionary, featureStore)
+	notificationService := services.NewNotificationService(db.DB())
+	// Job status changes are announced to the parties that didn't make them
+	jobEvents := jobstatus.NewEmitter()
+	jobEvents.Subscribe(services.JobStatusNotifier(notificationService))
+	jobService := services.NewJobService(jobRepo, userRepo, skillDictionary, jobEvents, featureStore)
+	jobLifecycleService := services.NewJobLifecycleService(jobRepo, userRepo, reviewRepo, notificationService, jobEvents)
+	applicationService := services.NewApplicationService(applicationRepo, jobRepo, userRepo)
+	reviewService := services.NewReviewService(reviewRepo, userRepo, jobRepo, jobEvents)
+	applicationBulkService := services.NewApplicationBulkService(applicationRepo, applicationBulkRepo, jobRepo, userRepo, notificationService, cfg.Applications.BulkSyncLimit)
+	interviewService := services.NewInterviewService(interviewRepo, applicationRepo, jobRepo, userRepo, notificationService)
+	offerService := services.NewOfferService(offerRepo, applicationRepo, jobRepo, userRepo, notificationService, jobEvents)
+
+	// Uploaded files go to the configured object store under content-addressed keys
+	storageProvider, err := storage.New(storage.Config{
+		Provider:        cfg.Storage.Provider,
+		LocalPath:       cfg.Storage.LocalPath,
+		DownloadURL:     strings.TrimSuffix(cfg.Storage.PublicURL, "/") + "/api/v1/downloads",
+		URLSigningKey:   cfg.Storage.URLSigningKey,
+		Bucket:          cfg.Storage.BucketName,
+		Region:          cfg.Storage.Region,
+		Endpoint:        cfg.Storage.Endpoint,
+		AccessKeyID:     cfg.Storage.AccessKeyID,
+		SecretAccessKey: cfg.Storage.SecretAccessKey,
+		PathStyle:       cfg.Storage.PathStyle,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to set up file storage")
+		os.Exit(1)
+	}
+	fileService := services.NewFileService(fileRepo, userRepo, applicationRepo, storage.NewUploader(storageProvider, cfg.Storage.MaxFileSize), cfg.Storage.URLExpiry)
+
+	// Initialize AI services
+	ncfService := aiServices.NewNCFService(&aiModels.NCFConfig{EmbeddingDim: 64, HiddenLayers: []int{128, 64}})
+	gnnService := aiServices.NewGNNService(&aiModels.GNNConfig{NodeEmbeddingDim: 64, NumLayers: 2})
+	rlService := aiServices.NewRLService(&aiModels.RLConfig{StateSpaceDim: 32, ActionSpaceDim: 5, MemorySize: 10000})
+	hybridService := aiServices.NewHybridMatchingService(
+		ncfService,
+		gnnService,
+		rlService,
+		nil, // LLM explanations are not served by this binary yet
+		matching.NewMatchingAlgorithm(),
+	)
+	hybridService.SetEntityLoader(entityLoader)
+	hybridService.SetFeatureStore(featureStore)
+	ncfService.SetFeatureStore(featureStore)
+
+	// Features of recent students and open postings are precomputed off the request path
+	var materializeJob *features.MaterializeJob
+	if cfg.Matching.FeatureMaterializeInterval > 0 {
+		materializeJob = features.NewMaterializeJob(featureStore, aiServices.NewRepositoryMaterializeSource(userRepo, jobRepo), features.MaterializeConfig{
+			Interval: cfg.Matching.FeatureMaterializeInterval,
+			MaxUsers: cfg.Matching.FeatureMaterializeUsers,
+			MaxJobs:  cfg.Matching.FeatureMaterializeJobs,
+		})
+		materializeJob.Start(ctx)
+	}
+
+	// Ensemble weights learned per segment survive restarts
+	weightLearner := aiServices.NewEnsembleWeightLearner(nil, ensembleWeightRepo)
+	if err := weightLearner.Load(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to load ensemble weights, starting from defaults")
+	}
+	hybridService.SetEnsembleWeightLearner(weightLearner)
+
+	// A few recommendation slots explore jobs the ensemble ranks lower
+	if cfg.Matching.ExplorationSlots > 0 {
+		banditService, err := aiServices.NewBanditService(&aiModels.BanditConfig{
+			Alpha:            cfg.Matching.ExplorationAlpha,
+			ExplorationSlots: cfg.Matching.ExplorationSlots,
+			RewardWindow:     cfg.Matching.ExplorationRewardWindow,
+		}, banditDecisionRepo)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to set up exploration")
+			os.Exit(1)
+		}
+		hybridService.SetBanditService(banditService)
+	}
+	batchService := aiServices.NewBatchInferenceService(hybridService, entityLoader)
+
+	// Drift alerts go to Prometheus and to the configured administrators
+	driftMonitor := aiServices.NewDriftMonitor(
+		&aiModels.DriftConfig{
+			CheckInterval: cfg.Monitoring.DriftCheckInterval,
+			Thresholds: aiModels.DriftThresholds{
+				PSIWarning: cfg.Monitoring.DriftPSIWarning,
+				PSIAlert:   cfg.Monitoring.DriftPSIAlert,
+				KLAlert:    cfg.Monitoring.DriftKLAlert,
+			},
+		},
+		monitoring.GetMetrics(),
+		services.NewAdminDriftNotifier(notificationService, cfg.Monitoring.AlertAdminUserIDs),
+		driftReferenceRepo,
+	)
+	if err := driftMonitor.Load(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to load drift references, rebuilding them from traffic")
+	}
+	hybridService.SetDriftMonitor(driftMonitor)
+	driftMonitor.Start(ctx)
+
+	// Training runs retrain the same model instances the hybrid service serves
+	trainer := aiServices.NewTrainingOrchestrator(ncfService, gnnService, rlService, trainingRepo, 10)
+	trainer.Start(ctx)
+
+	// Behavior events are buffered and written in batches off the request path
+	behaviorConfig := behavior.DefaultConfig()
+	behaviorConfig.BatchSize = cfg.Behavior.BatchSize
+	behaviorConfig.FlushInterval = cfg.Behavior.FlushInterval
+	var behaviorBuffer behavior.Buffer = behavior.NewRedisStreamBuffer(redisClient, cfg.Behavior.ConsumerName, int64(cfg.Behavior.BufferSize), cfg.Behavior.ClaimIdle)
+	var behaviorDedupe behavior.Deduplicator = behavior.NewRedisDeduplicator(redisClient)
+	if cfg.Behavior.IngestionBuffer == "memory" {
+		behaviorBuffer = behavior.NewMemoryBuffer(cfg.Behavior.BufferSize)
+		behaviorDedupe = behavior.NewMemoryDeduplicator()
+	}
+	behaviorPipeline := behavior.NewPipeline(
+		behaviorConfig,
+		behaviorRepo,
+		behaviorBuffer,
+		behaviorDedupe,
+		behavior.NewRepositoryUpdater(behaviorRepo, cfg.Behavior.SessionTimeout, hybridService),
+		monitoring.GetMetrics(),
+	)
+	behaviorPipeline.Start(ctx)
+
+	// Signup cohorts are recomputed on a schedule and served from the stored aggregates
+	cohortAggregator := behavior.NewCohortAggregator(
+		behaviorRepo,
+		cfg.Behavior.CohortRefreshInterval,
+		cfg.Behavior.CohortLookbackWeeks,
+		cfg.Behavior.CohortMinSize,
+	)
+	cohortAggregator.Start(ctx)
+
+	// Conversion funnels are computed on request; stage transitions also feed
+	// Prometheus on the one instance configured to export them
+	funnelService := behavior.NewFunnelService(behaviorRepo, cfg.Behavior.FunnelWindow)
+	var funnelExporter *behavior.FunnelMetricsExporter
+	if cfg.Behavior.FunnelMetricsInterval > 0 {
+		funnelExporter = behavior.NewFunnelMetricsExporter(behaviorRepo, monitoring.GetMetrics(), cfg.Behavior.FunnelMetricsInterval)
+		funnelExporter.Start(ctx)
+	}
+
+	// Expired behavior data is deleted on a schedule; exports and erasures are on demand
+	var retentionJob *behavior.RetentionJob
+	if cfg.Behavior.RetentionInterval > 0 {
+		retentionJob = behavior.NewRetentionJob(behaviorRepo, cfg.Behavior.RetentionPeriod, cfg.Behavior.RetentionInterval)
+		retentionJob.Start(ctx)
+	}
 
-	app := &Application{
-		config:       cfg,
-		logger:       log,
-		db:           db,
-		jwtService:   jwtService,
-		userService:  userService,
-		emailService: emailService,
+	// Large bulk updates are queued; workers claim them one at a time
+	var bulkWorker *appstatus.BulkWorker
+	if cfg.Applications.BulkWorkerInterval > 0 {
+		bulkWorker = appstatus.NewBulkWorker(applicationBulkRepo, applicationBulkService, cfg.Applications.BulkWorkerInterval, cfg.Applications.BulkStaleAfter)
+		bulkWorker.Start(ctx)
 	}
 
+	// Interview reminders are claimed atomically, so every instance may run the job
+	var reminderJob *interviews.ReminderJob
+	if cfg.Interviews.ReminderInterval > 0 {
+		reminderJob = interviews.NewReminderJob(interviewRepo, interviewService, cfg.Interviews.ReminderLead, cfg.Interviews.ReminderInterval)
+		reminderJob.Start(ctx)
+	}
+
+	// Job deadlines only change jobs still in the status they were found in and
+	// claim reminders atomically, so every instance may run the scheduler
+	var deadlineJob *jobstatus.DeadlineJob
+	if cfg.Jobs.DeadlineInterval > 0 {
+		deadlineJob = jobstatus.NewDeadlineJob(jobRepo, jobLifecycleService, jobstatus.DeadlineConfig{
+			Interval:             cfg.Jobs.DeadlineInterval,
+			BatchSize:            cfg.Jobs.DeadlineBatchSize,
+			CloseExpiredPostings: cfg.Jobs.CloseExpiredPostings,
+			ExpiredPostingGrace:  cfg.Jobs.ExpiredPostingGrace,
+			CompleteEndedReviews: cfg.Jobs.CompleteEndedReviews,
+			StaleDraftAge:        cfg.Jobs.StaleDraftAge,
+			ApplicationReminder:  cfg.Jobs.ApplicationReminder,
+			ReviewReminderLead:   cfg.Jobs.ReviewReminderLead,
+		})
+		deadlineJob.Start(ctx)
+	}
+	privacyService := privacy.NewService(privacyRepo, storageProvider)
+
+	// Behavioral insights read the same events and enrich hybrid matches
+	behaviorService := services.NewUserBehaviorService(
+		behaviorRepo,
+		userRepo,
+		jobRepo,
+		cache.NewCacheLayer(redisClient),
+		hybridService,
+		behaviorPipeline,
+	)
+
 	// Setup router
-	router := app.setupRouter()
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, log)
+	router := routes.NewRouter(
+		routes.Config{
+			Environment:  cfg.Server.Environment,
+			AllowOrigins: []string{"http://localhost:3000", "http://localhost:3001"},
+		},
+		authMiddleware,
+		jobRepo,
+		routes.Handlers{
+			User:            handlers.NewUserHandler(userService),
+			Resume:          handlers.NewResumeHandler(resumeService),
+			Job:             handlers.NewJobHandler(jobService),
+			JobLifecycle:    handlers.NewJobLifecycleHandler(jobLifecycleService),
+			Application:     handlers.NewApplicationHandler(applicationService),
+			ApplicationBulk: handlers.NewApplicationBulkHandler(applicationBulkService),
+			Interview:       handlers.NewInterviewHandler(interviewService),
+			Offer:           handlers.NewOfferHandler(offerService),
+			Matching:        handlers.NewMatchingHandler(hybridService),
+			Review:          handlers.NewReviewHandler(reviewService),
+			Notification:    handlers.NewNotificationHandler(notificationService),
+			Behavior:        handlers.NewBehaviorHandler(behaviorService, hybridService),
+			BehaviorEvents:  handlers.NewBehaviorEventHandler(behaviorPipeline),
+			Inference:       handlers.NewInferenceHandler(batchService),
+			Training:        handlers.NewTrainingHandler(trainer),
+			EnsembleWeight:  handlers.NewEnsembleWeightHandler(hybridService),
+			Cohort:          handlers.NewCohortHandler(cohortAggregator),
+			Funnel:          handlers.NewFunnelHandler(funnelService),
+			Privacy:         handlers.NewPrivacyHandler(privacyService),
+			File:            handlers.NewFileHandler(fileService),
+		},
+	)
 
 	// Create server
 	srv := &http.Server{
@@ -133,76 +377,29 @@ func main() {
 		os.Exit(1)
 	}
 
-
-	log.Info().Msg("Server stopped")
-}
-
-func (app *Application) setupRouter() *gin.Engine {
-	if app.config.Server.Environment == "production" {
-		gin.SetMode(gin.ReleaseMode)
+	trainer.Stop()
+	if materializeJob != nil {
+		materializeJob.Stop()
+	}
+	driftMonitor.Stop()
+	behaviorPipeline.Stop()
+	cohortAggregator.Stop()
+	if funnelExporter != nil {
+		funnelExporter.Stop()
+	}
+	if retentionJob != nil {
+		retentionJob.Stop()
+	}
+	if reminderJob != nil {
+		reminderJob.Stop()
+	}
+	if deadlineJob != nil {
+		deadlineJob.Stop()
+	}
+	if bulkWorker != nil {
+		bulkWorker.Stop()
 	}
 
-	r := gin.New()
-	r.Use(gin.Logger())
-	r.Use(gin.Recovery())
-
-	// Add metrics endpoint
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
-
-	// CORS configuration
-	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"http://localhost:3000", "http://localhost:3001"}
-	config.AllowCredentials = true
-	config.AddAllowHeaders("Authorization")
-	r.Use(cors.New(config))
-
-	// Health check
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":      "healthy",
-			"timestamp":   time.Now(),
-			"environment": app.config.Server.Environment,
-			"version":     "v1.0.0",
-		})
-	})
-
-	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(app.jwtService, app.logger)
-
-	// Initialize handlers
-	userHandler := handlers.NewUserHandler(app.userService)
 
-	// API routes
-	api := r.Group("/api/v1")
-	
-	// Public authentication routes
-	auth := api.Group("/auth")
-	{
-		auth.POST("/register", userHandler.Register)
-		auth.POST("/login", userHandler.Login)
-		auth.POST("/refresh", userHandler.RefreshToken)
-		auth.GET("/verify-email", userHandler.VerifyEmail)
-		auth.POST("/forgot-password", userHandler.ForgotPassword)
-		auth.POST("/reset-password", userHandler.ResetPassword)
-	}
-
-	// Protected user routes
-	users := api.Group("/users")
-	users.Use(authMiddleware.RequireAuth())
-	{
-		users.GET("/profile", userHandler.GetProfile)
-		users.PUT("/profile", userHandler.UpdateProfile)
-		users.GET("/:id", userHandler.GetUser)
-	}
-
-	// Admin routes (placeholder)
-	admin := api.Group("/admin")
-	admin.Use(authMiddleware.RequireAuth())
-	admin.Use(authMiddleware.RequireRole("admin"))
-	{
-		admin.GET("/users", userHandler.ListUsers)
-		admin.DELETE("/users/:id", userHandler.DeleteUser)
-	}
-
-	return r
+	log.Info().Msg("Server stopped")
 }
@@ -4,18 +4,25 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Redis    RedisConfig
-	Email    EmailConfig
-	Storage  StorageConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	JWT          JWTConfig
+	Redis        RedisConfig
+	Email        EmailConfig
+	Storage      StorageConfig
+	Monitoring   MonitoringConfig
+	Matching     MatchingConfig
+	Behavior     BehaviorConfig
+	Interviews   InterviewConfig
+	Applications ApplicationConfig
+	Jobs         JobConfig
 }
 
 type ServerConfig struct {
@@ -64,6 +71,78 @@ type StorageConfig struct {
 	BucketName  string
 	Region      string
 	MaxFileSize int64
+
+	LocalPath     string        // Directory the local provider writes to
+	PublicURL     string        // Base URL clients reach the API on, for local download links
+	URLSigningKey string        // Signs local download links
+	URLExpiry     time.Duration // How long download links stay valid
+
+	Endpoint        string // S3-compatible endpoint such as MinIO; empty uses the provider's default
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // Address the bucket in the path, as most S3 stand-ins expect
+}
+
+type MonitoringConfig struct {
+	DriftCheckInterval time.Duration
+	DriftPSIWarning    float64
+	DriftPSIAlert      float64
+	DriftKLAlert       float64
+	AlertAdminUserIDs  []string // Administrators who receive model alerts
+}
+
+type MatchingConfig struct {
+	ExplorationSlots        int           // Recommendation slots the contextual bandit fills; 0 disables exploration
+	ExplorationAlpha        float64       // UCB exploration bonus
+	ExplorationRewardWindow time.Duration // How long a view, save or apply still counts for an explored job
+
+	FeatureMaterializeInterval time.Duration // How often user and job features are precomputed into the cache; 0 disables
+	FeatureMaterializeUsers    int           // Newest users whose features are kept warm
+	FeatureMaterializeJobs     int           // Newest posted jobs whose features are kept warm
+}
+
+type BehaviorConfig struct {
+	IngestionBuffer string // "memory" or "redis"
+	BufferSize      int
+	BatchSize       int
+	FlushInterval   time.Duration
+	ConsumerName    string        // Stable per instance so unacknowledged Redis events are redelivered to it
+	ClaimIdle       time.Duration // Redis events left unacknowledged this long are taken over by another instance
+	SessionTimeout  time.Duration // Inactivity gap that ends a user session
+
+	CohortRefreshInterval time.Duration
+	CohortLookbackWeeks   int // Signup weeks recomputed on each refresh
+	CohortMinSize         int // Smaller cohorts are withheld from reports
+
+	FunnelWindow          time.Duration // Default reporting window for conversion funnels
+	// How often funnel transitions feed Prometheus; 0 (the default) disables. Every
+	// exporter adds to the same counters, so set it on exactly one instance.
+	FunnelMetricsInterval time.Duration
+
+	RetentionPeriod   time.Duration // Actions, searches and sessions older than this are deleted
+	RetentionInterval time.Duration // How often expired behavior data is deleted; 0 disables
+}
+
+type ApplicationConfig struct {
+	BulkSyncLimit      int           // Bulk updates larger than this are queued for the bulk worker
+	BulkWorkerInterval time.Duration // How often queued bulk updates are picked up; 0 disables
+	BulkStaleAfter     time.Duration // Bulk updates running longer than this are picked up again
+}
+
+type InterviewConfig struct {
+	ReminderLead     time.Duration // How long before an interview both sides are reminded
+	ReminderInterval time.Duration // How often due reminders are sent; 0 disables
+}
+
+type JobConfig struct {
+	DeadlineInterval     time.Duration // How often job deadlines are handled; 0 disables
+	DeadlineBatchSize    int           // Jobs handled per run for each kind of deadline
+	CloseExpiredPostings bool          // Archive posted jobs after their application deadline and grace period
+	ExpiredPostingGrace  time.Duration // How long past the deadline a posting stays open for offers
+	CompleteEndedReviews bool          // Complete jobs whose review period ended without both reviews
+	StaleDraftAge        time.Duration // Drafts untouched this long are archived; 0 disables
+	ApplicationReminder  time.Duration // Employers are reminded of applications unreviewed this long; 0 disables
+	ReviewReminderLead   time.Duration // How long before a review period ends the parties are reminded; 0 disables
 }
 
 func LoadConfig() (*Config, error) {
@@ -121,12 +200,84 @@ func LoadConfig() (*Config, error) {
 			BucketName:  getEnv("STORAGE_BUCKET", "microbridge-files"),
 			Region:      getEnv("STORAGE_REGION", "us-east-1"),
 			MaxFileSize: int64(getIntEnv("MAX_FILE_SIZE_MB", 10)) * 1024 * 1024, // Convert MB to bytes
+
+			LocalPath:     getEnv("STORAGE_LOCAL_PATH", "./uploads"),
+			PublicURL:     getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+			URLSigningKey: getEnv("STORAGE_URL_SIGNING_KEY", getEnv("JWT_SECRET", "your-secret-key-change-in-production")),
+			URLExpiry:     getDurationEnv("STORAGE_URL_EXPIRY", 15*time.Minute),
+
+			Endpoint:        getEnv("STORAGE_ENDPOINT", ""),
+			AccessKeyID:     getEnv("STORAGE_ACCESS_KEY_ID", ""),
+			SecretAccessKey: getEnv("STORAGE_SECRET_ACCESS_KEY", ""),
+			PathStyle:       getBoolEnv("STORAGE_PATH_STYLE", false),
+		},
+		Monitoring: MonitoringConfig{
+			DriftCheckInterval: getDurationEnv("DRIFT_CHECK_INTERVAL", 5*time.Minute),
+			DriftPSIWarning:    getFloatEnv("DRIFT_PSI_WARNING", 0.1),
+			DriftPSIAlert:      getFloatEnv("DRIFT_PSI_ALERT", 0.25),
+			DriftKLAlert:       getFloatEnv("DRIFT_KL_ALERT", 0.2),
+			AlertAdminUserIDs:  getListEnv("ALERT_ADMIN_USER_IDS"),
+		},
+		Matching: MatchingConfig{
+			ExplorationSlots:        getIntEnv("MATCHING_EXPLORATION_SLOTS", 2),
+			ExplorationAlpha:        getFloatEnv("MATCHING_EXPLORATION_ALPHA", 1.0),
+			ExplorationRewardWindow: getDurationEnv("MATCHING_EXPLORATION_REWARD_WINDOW", 72*time.Hour),
+
+			FeatureMaterializeInterval: getDurationEnv("MATCHING_FEATURE_MATERIALIZE_INTERVAL", 10*time.Minute),
+			FeatureMaterializeUsers:    getIntEnv("MATCHING_FEATURE_MATERIALIZE_USERS", 1000),
+			FeatureMaterializeJobs:     getIntEnv("MATCHING_FEATURE_MATERIALIZE_JOBS", 1000),
+		},
+		Behavior: BehaviorConfig{
+			IngestionBuffer: getEnv("BEHAVIOR_INGESTION_BUFFER", "redis"),
+			BufferSize:      getIntEnv("BEHAVIOR_BUFFER_SIZE", 50000),
+			BatchSize:       getIntEnv("BEHAVIOR_BATCH_SIZE", 200),
+			FlushInterval:   getDurationEnv("BEHAVIOR_FLUSH_INTERVAL", time.Second),
+			ConsumerName:    getEnv("BEHAVIOR_CONSUMER_NAME", hostname()),
+			ClaimIdle:       getDurationEnv("BEHAVIOR_CLAIM_IDLE", 5*time.Minute),
+			SessionTimeout:  getDurationEnv("BEHAVIOR_SESSION_TIMEOUT", 30*time.Minute),
+
+			CohortRefreshInterval: getDurationEnv("BEHAVIOR_COHORT_REFRESH_INTERVAL", 6*time.Hour),
+			CohortLookbackWeeks:   getIntEnv("BEHAVIOR_COHORT_LOOKBACK_WEEKS", 26),
+			CohortMinSize:         getIntEnv("BEHAVIOR_COHORT_MIN_SIZE", 5),
+
+			FunnelWindow:          getDurationEnv("BEHAVIOR_FUNNEL_WINDOW", 30*24*time.Hour),
+			FunnelMetricsInterval: getDurationEnv("BEHAVIOR_FUNNEL_METRICS_INTERVAL", 0),
+
+			RetentionPeriod:   getDurationEnv("BEHAVIOR_RETENTION_PERIOD", 365*24*time.Hour),
+			RetentionInterval: getDurationEnv("BEHAVIOR_RETENTION_INTERVAL", 24*time.Hour),
+		},
+		Applications: ApplicationConfig{
+			BulkSyncLimit:      getIntEnv("APPLICATION_BULK_SYNC_LIMIT", 50),
+			BulkWorkerInterval: getDurationEnv("APPLICATION_BULK_WORKER_INTERVAL", 5*time.Second),
+			BulkStaleAfter:     getDurationEnv("APPLICATION_BULK_STALE_AFTER", 15*time.Minute),
+		},
+		Interviews: InterviewConfig{
+			ReminderLead:     getDurationEnv("INTERVIEW_REMINDER_LEAD", 24*time.Hour),
+			ReminderInterval: getDurationEnv("INTERVIEW_REMINDER_INTERVAL", 15*time.Minute),
+		},
+		Jobs: JobConfig{
+			DeadlineInterval:     getDurationEnv("JOB_DEADLINE_INTERVAL", 15*time.Minute),
+			DeadlineBatchSize:    getIntEnv("JOB_DEADLINE_BATCH_SIZE", 100),
+			CloseExpiredPostings: getBoolEnv("JOB_CLOSE_EXPIRED_POSTINGS", true),
+			ExpiredPostingGrace:  getDurationEnv("JOB_EXPIRED_POSTING_GRACE", 30*24*time.Hour),
+			CompleteEndedReviews: getBoolEnv("JOB_COMPLETE_ENDED_REVIEWS", true),
+			StaleDraftAge:        getDurationEnv("JOB_STALE_DRAFT_AGE", 90*24*time.Hour),
+			ApplicationReminder:  getDurationEnv("JOB_APPLICATION_REMINDER", 72*time.Hour),
+			ReviewReminderLead:   getDurationEnv("JOB_REVIEW_REMINDER_LEAD", 72*time.Hour),
 		},
 	}
 
 	return config, nil
 }
 
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "api"
+	}
+	return name
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -151,3 +302,22 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getListEnv(key string) []string {
+	var values []string
+	for _, part := range strings.Split(os.Getenv(key), ",") {
+		if value := strings.TrimSpace(part); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
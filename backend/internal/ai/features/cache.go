@@ -0,0 +1,94 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrCacheMiss is returned by Cache implementations when a key is absent or expired
+var ErrCacheMiss = errors.New("feature cache miss")
+
+// Cache stores serialized feature values with a TTL
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// RedisCache stores features in Redis so all API instances serve the same values
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a Redis-backed feature cache
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	return data, err
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// MemoryCache is an in-process Cache for tests and single-instance deployments
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an in-process feature cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, exists := c.entries[key]
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = memoryCacheEntry{value: value, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
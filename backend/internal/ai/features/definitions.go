@@ -0,0 +1,268 @@
+package features
+
+import (
+	"strings"
+
+	"microbridge/backend/internal/models"
+)
+
+// SkillFeaturePrefix prefixes per-skill level features. The GNN reads skills from
+// feature keys with this prefix.
+const SkillFeaturePrefix = "skill_"
+
+var experienceLevels = map[string]float64{
+	"entry":        0.2,
+	"intermediate": 0.4,
+	"advanced":     0.6,
+	"senior":       0.8,
+	"expert":       1.0,
+}
+
+// DefaultRegistry returns the registry with the standard MicroBridge features
+func DefaultRegistry() *Registry {
+	registry := NewRegistry()
+	for _, definition := range defaultDefinitions() {
+		if err := registry.Register(definition); err != nil {
+			panic(err) // Static definitions; a failure here is a programming error
+		}
+	}
+	return registry
+}
+
+func defaultDefinitions() []*Definition {
+	return []*Definition{
+		// User features
+		{
+			Name:        "user_experience_level",
+			Entity:      EntityUser,
+			Version:     1,
+			Description: "Experience level mapped to 0.2 (entry) through 1.0 (expert)",
+			Compute: Scalar("user_experience_level", func(in Input) float64 {
+				return ExperienceLevelScore(in.User.ExperienceLevel)
+			}),
+		},
+		{
+			Name:        "user_skill_count",
+			Entity:      EntityUser,
+			Version:     1,
+			Description: "Number of skills on the profile",
+			Compute: Scalar("user_skill_count", func(in Input) float64 {
+				return float64(len(in.User.Skills))
+			}),
+		},
+		{
+			Name:        "user_avg_skill_level",
+			Entity:      EntityUser,
+			Version:     1,
+			Description: "Mean skill level normalised to 0-1",
+			Compute: Scalar("user_avg_skill_level", func(in Input) float64 {
+				if len(in.User.Skills) == 0 {
+					return 0.0
+				}
+				total := 0
+				for _, skill := range in.User.Skills {
+					total += skill.Level
+				}
+				return float64(total) / float64(len(in.User.Skills)) / 5.0
+			}),
+		},
+		{
+			Name:        "user_verified_skill_ratio",
+			Entity:      EntityUser,
+			Version:     1,
+			Description: "Share of skills that are verified",
+			Compute: Scalar("user_verified_skill_ratio", func(in Input) float64 {
+				if len(in.User.Skills) == 0 {
+					return 0.0
+				}
+				verified := 0
+				for _, skill := range in.User.Skills {
+					if skill.Verified {
+						verified++
+					}
+				}
+				return float64(verified) / float64(len(in.User.Skills))
+			}),
+		},
+		{
+			Name:        "user_level",
+			Entity:      EntityUser,
+			Version:     1,
+			Description: "Gamification level",
+			Compute: Scalar("user_level", func(in Input) float64 {
+				return float64(in.User.Level)
+			}),
+		},
+		{
+			Name:        "user_skill_levels",
+			Entity:      EntityUser,
+			Version:     1,
+			Description: "Per-skill level normalised to 0-1, keyed skill_<name>",
+			Compute: func(in Input) map[string]float64 {
+				values := make(map[string]float64, len(in.User.Skills))
+				for _, skill := range in.User.Skills {
+					values[SkillKey(skill.Name)] = float64(skill.Level) / 5.0
+				}
+				return values
+			},
+		},
+
+		// Job features
+		{
+			Name:        "job_experience_level",
+			Entity:      EntityJob,
+			Version:     1,
+			Description: "Required experience level mapped to 0.2 (entry) through 1.0 (expert)",
+			Compute: Scalar("job_experience_level", func(in Input) float64 {
+				return ExperienceLevelScore(in.Job.ExperienceLevel)
+			}),
+		},
+		{
+			Name:        "job_skill_count",
+			Entity:      EntityJob,
+			Version:     1,
+			Description: "Number of skills listed on the job",
+			Compute: Scalar("job_skill_count", func(in Input) float64 {
+				return float64(len(in.Job.Skills))
+			}),
+		},
+		{
+			Name:        "job_required_skill_ratio",
+			Entity:      EntityJob,
+			Version:     1,
+			Description: "Share of listed skills that are required rather than nice-to-have",
+			Compute: Scalar("job_required_skill_ratio", func(in Input) float64 {
+				if len(in.Job.Skills) == 0 {
+					return 0.0
+				}
+				required := 0
+				for _, skill := range in.Job.Skills {
+					if skill.IsRequired {
+						required++
+					}
+				}
+				return float64(required) / float64(len(in.Job.Skills))
+			}),
+		},
+		{
+			Name:        "job_is_remote",
+			Entity:      EntityJob,
+			Version:     1,
+			Description: "1 when the job can be done remotely",
+			Compute: Scalar("job_is_remote", func(in Input) float64 {
+				return boolFeature(in.Job.IsRemote)
+			}),
+		},
+		{
+			Name:        "job_duration_weeks",
+			Entity:      EntityJob,
+			Version:     1,
+			Description: "Job duration in weeks",
+			Compute: Scalar("job_duration_weeks", func(in Input) float64 {
+				return float64(in.Job.Duration)
+			}),
+		},
+		{
+			Name:        "job_skill_levels",
+			Entity:      EntityJob,
+			Version:     1,
+			Description: "Per-skill required level normalised to 0-1, keyed skill_<name>",
+			Compute: func(in Input) map[string]float64 {
+				values := make(map[string]float64, len(in.Job.Skills))
+				for _, skill := range in.Job.Skills {
+					values[SkillKey(skill.Name)] = float64(skill.Level) / 5.0
+				}
+				return values
+			},
+		},
+
+		// Pair features
+		{
+			Name:        "pair_skill_coverage",
+			Entity:      EntityPair,
+			Version:     1,
+			Description: "Importance-weighted share of job skills the user has",
+			Compute: Scalar("pair_skill_coverage", func(in Input) float64 {
+				covered, total := 0.0, 0.0
+				for _, skill := range in.Job.Skills {
+					weight := skillImportance(skill)
+					total += weight
+					if in.User.GetSkillByName(skill.Name) != nil {
+						covered += weight
+					}
+				}
+				if total == 0 {
+					return 0.0
+				}
+				return covered / total
+			}),
+		},
+		{
+			Name:        "pair_skill_level_gap",
+			Entity:      EntityPair,
+			Version:     1,
+			Description: "Mean shortfall between required and held skill level, normalised to 0-1",
+			Compute: Scalar("pair_skill_level_gap", func(in Input) float64 {
+				if len(in.Job.Skills) == 0 {
+					return 0.0
+				}
+				gap := 0.0
+				for _, skill := range in.Job.Skills {
+					held := 0
+					if userSkill := in.User.GetSkillByName(skill.Name); userSkill != nil {
+						held = userSkill.Level
+					}
+					if shortfall := skill.Level - held; shortfall > 0 {
+						gap += float64(shortfall) / 5.0
+					}
+				}
+				return gap / float64(len(in.Job.Skills))
+			}),
+		},
+		{
+			Name:        "pair_experience_gap",
+			Entity:      EntityPair,
+			Version:     1,
+			Description: "User experience level minus required level; negative means under-qualified",
+			Compute: Scalar("pair_experience_gap", func(in Input) float64 {
+				return ExperienceLevelScore(in.User.ExperienceLevel) - ExperienceLevelScore(in.Job.ExperienceLevel)
+			}),
+		},
+		{
+			Name:        "pair_location_match",
+			Entity:      EntityPair,
+			Version:     1,
+			Description: "1 when the job is remote or in the user's location",
+			Compute: Scalar("pair_location_match", func(in Input) float64 {
+				return boolFeature(in.Job.IsRemote || (in.User.Location != "" && strings.EqualFold(in.User.Location, in.Job.Location)))
+			}),
+		},
+	}
+}
+
+// ExperienceLevelScore maps an experience level to 0-1
+func ExperienceLevelScore(level string) float64 {
+	return experienceLevels[strings.ToLower(level)]
+}
+
+// SkillKey returns the feature key for a skill name
+func SkillKey(name string) string {
+	return SkillFeaturePrefix + strings.ToLower(strings.TrimSpace(name))
+}
+
+func skillImportance(skill models.RequiredSkill) float64 {
+	if skill.Importance > 0 {
+		return skill.Importance
+	}
+	if skill.IsRequired {
+		return 1.0
+	}
+	return 0.5
+}
+
+func boolFeature(value bool) float64 {
+	if value {
+		return 1.0
+	}
+	return 0.0
+}
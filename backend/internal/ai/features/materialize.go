@@ -0,0 +1,115 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+// MaterializeSource lists the users and jobs whose features are kept warm
+type MaterializeSource interface {
+	MaterializeUsers(ctx context.Context, limit int) ([]*models.User, error)
+	MaterializeJobs(ctx context.Context, limit int) ([]*models.Job, error)
+}
+
+// MaterializeConfig holds materialization schedule settings
+type MaterializeConfig struct {
+	Interval time.Duration
+	MaxUsers int
+	MaxJobs  int
+}
+
+// MaterializeJob periodically recomputes user and job features into the cache so
+// scoring rarely computes them on the request path. Pairs are left to the request
+// path; there are too many to precompute.
+type MaterializeJob struct {
+	store  *Store
+	source MaterializeSource
+	config MaterializeConfig
+
+	mu        sync.Mutex
+	running   bool
+	stop      context.CancelFunc
+	loopGroup sync.WaitGroup
+}
+
+// NewMaterializeJob creates a job that refreshes cached features on every interval
+func NewMaterializeJob(store *Store, source MaterializeSource, config MaterializeConfig) *MaterializeJob {
+	if config.MaxUsers <= 0 {
+		config.MaxUsers = 1000
+	}
+	if config.MaxJobs <= 0 {
+		config.MaxJobs = 1000
+	}
+
+	return &MaterializeJob{
+		store:  store,
+		source: source,
+		config: config,
+	}
+}
+
+// Start materializes immediately and then on every interval
+func (j *MaterializeJob) Start(ctx context.Context) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	j.stop = cancel
+	j.running = true
+
+	j.loopGroup.Add(1)
+	go func() {
+		defer j.loopGroup.Done()
+
+		ticker := time.NewTicker(j.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := j.Run(ctx); err != nil && ctx.Err() == nil {
+				fmt.Printf("Failed to materialize features: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts periodic materialization
+func (j *MaterializeJob) Stop() {
+	j.mu.Lock()
+	if !j.running {
+		j.mu.Unlock()
+		return
+	}
+	j.running = false
+	j.stop()
+	j.mu.Unlock()
+
+	j.loopGroup.Wait()
+}
+
+// Run loads the users and jobs from the source and caches their features. It
+// returns the number of entries written.
+func (j *MaterializeJob) Run(ctx context.Context) (int, error) {
+	users, err := j.source.MaterializeUsers(ctx, j.config.MaxUsers)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	jobs, err := j.source.MaterializeJobs(ctx, j.config.MaxJobs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	return j.store.Materialize(ctx, users, jobs, false)
+}
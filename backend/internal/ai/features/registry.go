@@ -0,0 +1,155 @@
+package features
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	aiModels "microbridge/backend/internal/ai/models"
+	"microbridge/backend/internal/models"
+)
+
+// Entity identifies what a feature describes
+type Entity string
+
+const (
+	EntityUser Entity = "user"
+	EntityJob  Entity = "job"
+	EntityPair Entity = "pair"
+)
+
+// Input carries the entities a feature is computed from. Pair features read both.
+type Input struct {
+	User *models.User
+	Job  *models.Job
+}
+
+// Definition is a named, versioned feature. Compute usually returns one value keyed
+// by Name; families such as per-skill levels return one key per member.
+// Bump Version whenever Compute changes so cached values are recomputed.
+type Definition struct {
+	Name        string
+	Entity      Entity
+	Version     int
+	Description string
+	Compute     func(in Input) map[string]float64
+}
+
+// Scalar wraps a single-valued computation as a Compute function
+func Scalar(name string, fn func(in Input) float64) func(in Input) map[string]float64 {
+	return func(in Input) map[string]float64 {
+		return map[string]float64{name: fn(in)}
+	}
+}
+
+// Registry holds the feature definitions shared by training and inference
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[string]*Definition
+	order       []string
+	version     string
+}
+
+// NewRegistry creates an empty feature registry
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: make(map[string]*Definition),
+	}
+}
+
+// Register adds a feature definition
+func (r *Registry) Register(definition *Definition) error {
+	if definition.Name == "" || definition.Compute == nil {
+		return fmt.Errorf("feature definition requires a name and compute function")
+	}
+	switch definition.Entity {
+	case EntityUser, EntityJob, EntityPair:
+	default:
+		return fmt.Errorf("feature %s has unknown entity %q", definition.Name, definition.Entity)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.definitions[definition.Name]; exists {
+		return fmt.Errorf("feature %s is already registered", definition.Name)
+	}
+	r.definitions[definition.Name] = definition
+	r.order = append(r.order, definition.Name)
+	r.version = ""
+	return nil
+}
+
+// Definitions returns the registered definitions for an entity, in registration order
+func (r *Registry) Definitions(entity Entity) []*Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	definitions := make([]*Definition, 0, len(r.order))
+	for _, name := range r.order {
+		if definition := r.definitions[name]; definition.Entity == entity {
+			definitions = append(definitions, definition)
+		}
+	}
+	return definitions
+}
+
+// Version fingerprints the registered names and versions. It changes whenever a
+// feature is added or re-versioned and namespaces cached values.
+func (r *Registry) Version() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.version != "" {
+		return r.version
+	}
+
+	keys := make([]string, 0, len(r.definitions))
+	for name, definition := range r.definitions {
+		keys = append(keys, fmt.Sprintf("%s:%s@%d", definition.Entity, name, definition.Version))
+	}
+	sort.Strings(keys)
+
+	sum := sha256.Sum256([]byte(strings.Join(keys, ",")))
+	r.version = hex.EncodeToString(sum[:])[:12]
+	return r.version
+}
+
+// ComputeUser computes all user features
+func (r *Registry) ComputeUser(user *models.User) map[string]float64 {
+	return r.compute(EntityUser, Input{User: user})
+}
+
+// ComputeJob computes all job features
+func (r *Registry) ComputeJob(job *models.Job) map[string]float64 {
+	return r.compute(EntityJob, Input{Job: job})
+}
+
+// ComputePair computes all user-job interaction features
+func (r *Registry) ComputePair(user *models.User, job *models.Job) map[string]float64 {
+	return r.compute(EntityPair, Input{User: user, Job: job})
+}
+
+// ComputeVector computes the full feature vector for a user-job pair without caching
+func (r *Registry) ComputeVector(user *models.User, job *models.Job) *aiModels.FeatureVector {
+	return &aiModels.FeatureVector{
+		Version:         r.Version(),
+		UserFeatures:    r.ComputeUser(user),
+		JobFeatures:     r.ComputeJob(job),
+		ContextFeatures: make(map[string]float64),
+		Interactions:    r.ComputePair(user, job),
+	}
+}
+
+func (r *Registry) compute(entity Entity, in Input) map[string]float64 {
+	values := make(map[string]float64)
+	for _, definition := range r.Definitions(entity) {
+		for key, value := range definition.Compute(in) {
+			values[key] = value
+		}
+	}
+	return values
+}
@@ -0,0 +1,306 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	aiModels "microbridge/backend/internal/ai/models"
+	"microbridge/backend/internal/models"
+)
+
+// EntityLoader fetches users and jobs when features are requested by ID
+type EntityLoader interface {
+	LoadUser(ctx context.Context, userID string) (*models.User, error)
+	LoadJob(ctx context.Context, jobID string) (*models.Job, error)
+}
+
+// StoreConfig holds feature store cache settings
+type StoreConfig struct {
+	KeyPrefix string        `json:"key_prefix"`
+	UserTTL   time.Duration `json:"user_ttl"`
+	JobTTL    time.Duration `json:"job_ttl"`
+	PairTTL   time.Duration `json:"pair_ttl"`
+}
+
+// StoreStats reports cache effectiveness
+type StoreStats struct {
+	Hits        int64  `json:"hits"`
+	Misses      int64  `json:"misses"`
+	CacheErrors int64  `json:"cache_errors"`
+	Version     string `json:"version"`
+}
+
+type cachedFeatures struct {
+	Values     map[string]float64 `json:"values"`
+	ComputedAt time.Time          `json:"computed_at"`
+}
+
+// Store computes features from the registry and caches them, so training and
+// inference read identical values for the same entities.
+type Store struct {
+	registry *Registry
+	cache    Cache
+	loader   EntityLoader
+	config   *StoreConfig
+
+	hits        int64
+	misses      int64
+	cacheErrors int64
+}
+
+// NewStore creates a feature store. cache and loader may be nil; without a cache
+// every request computes, and without a loader only entity-based lookups work.
+func NewStore(registry *Registry, cache Cache, loader EntityLoader, config *StoreConfig) *Store {
+	if config == nil {
+		config = &StoreConfig{}
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "features"
+	}
+	if config.UserTTL <= 0 {
+		config.UserTTL = 15 * time.Minute
+	}
+	if config.JobTTL <= 0 {
+		config.JobTTL = 30 * time.Minute
+	}
+	if config.PairTTL <= 0 {
+		config.PairTTL = 10 * time.Minute
+	}
+
+	return &Store{
+		registry: registry,
+		cache:    cache,
+		loader:   loader,
+		config:   config,
+	}
+}
+
+// Registry returns the feature definitions backing the store
+func (s *Store) Registry() *Registry {
+	return s.registry
+}
+
+// UserFeatures returns cached or freshly computed features for a user
+func (s *Store) UserFeatures(ctx context.Context, user *models.User) map[string]float64 {
+	return s.getOrCompute(ctx, s.userKey(user.ID), s.config.UserTTL, func() map[string]float64 {
+		return s.registry.ComputeUser(user)
+	})
+}
+
+// JobFeatures returns cached or freshly computed features for a job
+func (s *Store) JobFeatures(ctx context.Context, job *models.Job) map[string]float64 {
+	return s.getOrCompute(ctx, s.jobKey(job.ID), s.config.JobTTL, func() map[string]float64 {
+		return s.registry.ComputeJob(job)
+	})
+}
+
+// PairFeatures returns cached or freshly computed interaction features
+func (s *Store) PairFeatures(ctx context.Context, user *models.User, job *models.Job) map[string]float64 {
+	return s.getOrCompute(ctx, s.pairKey(user.ID, job.ID), s.config.PairTTL, func() map[string]float64 {
+		return s.registry.ComputePair(user, job)
+	})
+}
+
+// FeatureVector assembles the full feature vector for loaded entities
+func (s *Store) FeatureVector(ctx context.Context, user *models.User, job *models.Job) *aiModels.FeatureVector {
+	return &aiModels.FeatureVector{
+		Version:         s.registry.Version(),
+		UserFeatures:    s.UserFeatures(ctx, user),
+		JobFeatures:     s.JobFeatures(ctx, job),
+		ContextFeatures: make(map[string]float64),
+		Interactions:    s.PairFeatures(ctx, user, job),
+	}
+}
+
+// Flatten merges a feature vector into one map. Per-skill features exist on both
+// sides, so they are qualified as user_skill_* and job_skill_*.
+func Flatten(vector *aiModels.FeatureVector) map[string]float64 {
+	flat := make(map[string]float64, len(vector.UserFeatures)+len(vector.JobFeatures)+len(vector.Interactions)+len(vector.ContextFeatures))
+	for key, value := range vector.UserFeatures {
+		if strings.HasPrefix(key, SkillFeaturePrefix) {
+			key = "user_" + key
+		}
+		flat[key] = value
+	}
+	for key, value := range vector.JobFeatures {
+		if strings.HasPrefix(key, SkillFeaturePrefix) {
+			key = "job_" + key
+		}
+		flat[key] = value
+	}
+	for key, value := range vector.Interactions {
+		flat[key] = value
+	}
+	for key, value := range vector.ContextFeatures {
+		flat[key] = value
+	}
+	return flat
+}
+
+// FeatureVectorByID serves a feature vector from cache, loading entities only
+// for the parts that are missing
+func (s *Store) FeatureVectorByID(ctx context.Context, userID, jobID string) (*aiModels.FeatureVector, error) {
+	userFeatures, userHit := s.lookup(ctx, s.userKey(userID))
+	jobFeatures, jobHit := s.lookup(ctx, s.jobKey(jobID))
+	pairFeatures, pairHit := s.lookup(ctx, s.pairKey(userID, jobID))
+
+	if userHit && jobHit && pairHit {
+		return &aiModels.FeatureVector{
+			Version:         s.registry.Version(),
+			UserFeatures:    userFeatures,
+			JobFeatures:     jobFeatures,
+			ContextFeatures: make(map[string]float64),
+			Interactions:    pairFeatures,
+		}, nil
+	}
+
+	if s.loader == nil {
+		return nil, fmt.Errorf("features for %s/%s are not cached and no entity loader is configured", userID, jobID)
+	}
+
+	user, err := s.loader.LoadUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user %s: %w", userID, err)
+	}
+	job, err := s.loader.LoadJob(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job %s: %w", jobID, err)
+	}
+
+	return s.FeatureVector(ctx, user, job), nil
+}
+
+// Materialize computes and caches features for every given user and job, plus
+// every pair when withPairs is set. It returns the number of entries written.
+func (s *Store) Materialize(ctx context.Context, users []*models.User, jobs []*models.Job, withPairs bool) (int, error) {
+	written := 0
+	write := func(key string, ttl time.Duration, values map[string]float64) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.store(ctx, key, ttl, values)
+		written++
+		return nil
+	}
+
+	for _, user := range users {
+		if err := write(s.userKey(user.ID), s.config.UserTTL, s.registry.ComputeUser(user)); err != nil {
+			return written, err
+		}
+	}
+	for _, job := range jobs {
+		if err := write(s.jobKey(job.ID), s.config.JobTTL, s.registry.ComputeJob(job)); err != nil {
+			return written, err
+		}
+	}
+	if withPairs {
+		for _, user := range users {
+			for _, job := range jobs {
+				if err := write(s.pairKey(user.ID, job.ID), s.config.PairTTL, s.registry.ComputePair(user, job)); err != nil {
+					return written, err
+				}
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// InvalidateUser drops cached user features. Pair entries expire on their own
+// shorter TTL because they cannot be enumerated cheaply.
+func (s *Store) InvalidateUser(ctx context.Context, userID string) error {
+	if s.cache == nil {
+		return nil
+	}
+	return s.cache.Delete(ctx, s.userKey(userID))
+}
+
+// InvalidateJob drops cached job features
+func (s *Store) InvalidateJob(ctx context.Context, jobID string) error {
+	if s.cache == nil {
+		return nil
+	}
+	return s.cache.Delete(ctx, s.jobKey(jobID))
+}
+
+// GetStats returns cache hit and miss counters
+func (s *Store) GetStats() *StoreStats {
+	return &StoreStats{
+		Hits:        atomic.LoadInt64(&s.hits),
+		Misses:      atomic.LoadInt64(&s.misses),
+		CacheErrors: atomic.LoadInt64(&s.cacheErrors),
+		Version:     s.registry.Version(),
+	}
+}
+
+// Private methods
+
+func (s *Store) getOrCompute(ctx context.Context, key string, ttl time.Duration, compute func() map[string]float64) map[string]float64 {
+	if values, hit := s.lookup(ctx, key); hit {
+		return values
+	}
+
+	values := compute()
+	s.store(ctx, key, ttl, values)
+	return values
+}
+
+// lookup never fails the caller: cache errors degrade to a miss
+func (s *Store) lookup(ctx context.Context, key string) (map[string]float64, bool) {
+	if s.cache == nil {
+		atomic.AddInt64(&s.misses, 1)
+		return nil, false
+	}
+
+	data, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			atomic.AddInt64(&s.cacheErrors, 1)
+		}
+		atomic.AddInt64(&s.misses, 1)
+		return nil, false
+	}
+
+	var cached cachedFeatures
+	if err := json.Unmarshal(data, &cached); err != nil {
+		atomic.AddInt64(&s.cacheErrors, 1)
+		atomic.AddInt64(&s.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddInt64(&s.hits, 1)
+	return cached.Values, true
+}
+
+func (s *Store) store(ctx context.Context, key string, ttl time.Duration, values map[string]float64) {
+	if s.cache == nil {
+		return
+	}
+
+	data, err := json.Marshal(cachedFeatures{Values: values, ComputedAt: time.Now()})
+	if err != nil {
+		atomic.AddInt64(&s.cacheErrors, 1)
+		return
+	}
+	if err := s.cache.Set(ctx, key, data, ttl); err != nil {
+		atomic.AddInt64(&s.cacheErrors, 1)
+	}
+}
+
+// Keys embed the registry version so a feature change never serves stale values
+func (s *Store) userKey(userID string) string {
+	return fmt.Sprintf("%s:%s:user:%s", s.config.KeyPrefix, s.registry.Version(), userID)
+}
+
+func (s *Store) jobKey(jobID string) string {
+	return fmt.Sprintf("%s:%s:job:%s", s.config.KeyPrefix, s.registry.Version(), jobID)
+}
+
+func (s *Store) pairKey(userID, jobID string) string {
+	return fmt.Sprintf("%s:%s:pair:%s:%s", s.config.KeyPrefix, s.registry.Version(), userID, jobID)
+}
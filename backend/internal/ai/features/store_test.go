@@ -0,0 +1,232 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"microbridge/backend/internal/models"
+)
+
+type countingLoader struct {
+	users map[string]*models.User
+	jobs  map[string]*models.Job
+	loads int
+}
+
+func (l *countingLoader) LoadUser(ctx context.Context, userID string) (*models.User, error) {
+	l.loads++
+	if user, ok := l.users[userID]; ok {
+		return user, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+func (l *countingLoader) LoadJob(ctx context.Context, jobID string) (*models.Job, error) {
+	l.loads++
+	if job, ok := l.jobs[jobID]; ok {
+		return job, nil
+	}
+	return nil, errors.New("job not found")
+}
+
+func createTestEntities() (*models.User, *models.Job) {
+	user := &models.User{
+		ID:              "user1",
+		ExperienceLevel: "advanced",
+		Location:        "Hong Kong",
+		Skills: models.SkillsArray{
+			{Name: "Go", Level: 4, Verified: true},
+			{Name: "SQL", Level: 2},
+		},
+	}
+	job := &models.Job{
+		ID:              "job1",
+		ExperienceLevel: "intermediate",
+		Location:        "Hong Kong",
+		Skills: models.RequiredSkillsArray{
+			{Name: "go", Level: 3, IsRequired: true, Importance: 0.8},
+			{Name: "React", Level: 3, Importance: 0.2},
+		},
+	}
+	return user, job
+}
+
+func TestRegistry_ComputeVector(t *testing.T) {
+	registry := DefaultRegistry()
+	user, job := createTestEntities()
+
+	vector := registry.ComputeVector(user, job)
+
+	if vector.Version != registry.Version() {
+		t.Errorf("Expected version %s, got %s", registry.Version(), vector.Version)
+	}
+	if vector.UserFeatures["skill_go"] != 0.8 {
+		t.Errorf("Expected skill_go 0.8, got %f", vector.UserFeatures["skill_go"])
+	}
+	if vector.JobFeatures["skill_react"] != 0.6 {
+		t.Errorf("Expected job skill_react 0.6, got %f", vector.JobFeatures["skill_react"])
+	}
+	if coverage := vector.Interactions["pair_skill_coverage"]; coverage != 0.8 {
+		t.Errorf("Expected importance-weighted coverage 0.8, got %f", coverage)
+	}
+	if vector.Interactions["pair_location_match"] != 1.0 {
+		t.Error("Expected location match for same city")
+	}
+
+	// Registering a feature changes the version
+	before := registry.Version()
+	err := registry.Register(&Definition{
+		Name:    "user_has_bio",
+		Entity:  EntityUser,
+		Version: 1,
+		Compute: Scalar("user_has_bio", func(in Input) float64 { return boolFeature(in.User.Bio != "") }),
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if registry.Version() == before {
+		t.Error("Expected version to change after registering a feature")
+	}
+
+	if err := registry.Register(&Definition{Name: "user_has_bio", Entity: EntityUser, Compute: Scalar("x", nil)}); err == nil {
+		t.Error("Expected error for duplicate feature name")
+	}
+}
+
+func TestStore_CachesAndServesByID(t *testing.T) {
+	user, job := createTestEntities()
+	loader := &countingLoader{
+		users: map[string]*models.User{user.ID: user},
+		jobs:  map[string]*models.Job{job.ID: job},
+	}
+	store := NewStore(DefaultRegistry(), NewMemoryCache(), loader, nil)
+	ctx := context.Background()
+
+	// First lookup misses and loads both entities
+	first, err := store.FeatureVectorByID(ctx, user.ID, job.ID)
+	if err != nil {
+		t.Fatalf("FeatureVectorByID failed: %v", err)
+	}
+	if loader.loads != 2 {
+		t.Errorf("Expected 2 entity loads, got %d", loader.loads)
+	}
+
+	// Second lookup is served entirely from cache
+	second, err := store.FeatureVectorByID(ctx, user.ID, job.ID)
+	if err != nil {
+		t.Fatalf("FeatureVectorByID failed: %v", err)
+	}
+	if loader.loads != 2 {
+		t.Errorf("Expected cached lookup not to load entities, got %d loads", loader.loads)
+	}
+	if first.Interactions["pair_skill_level_gap"] != second.Interactions["pair_skill_level_gap"] {
+		t.Error("Expected cached features to match computed features")
+	}
+
+	// Training path computes identical values without the cache
+	training := store.Registry().ComputeVector(user, job)
+	for key, value := range training.UserFeatures {
+		if second.UserFeatures[key] != value {
+			t.Errorf("Train/serve skew on %s: %f vs %f", key, value, second.UserFeatures[key])
+		}
+	}
+
+	stats := store.GetStats()
+	if stats.Hits != 3 {
+		t.Errorf("Expected 3 cache hits, got %d", stats.Hits)
+	}
+
+	if err := store.InvalidateUser(ctx, user.ID); err != nil {
+		t.Fatalf("InvalidateUser failed: %v", err)
+	}
+	if _, err := store.FeatureVectorByID(ctx, user.ID, job.ID); err != nil {
+		t.Fatalf("FeatureVectorByID failed: %v", err)
+	}
+	if loader.loads != 4 {
+		t.Errorf("Expected invalidation to force a reload, got %d loads", loader.loads)
+	}
+}
+
+func TestStore_Materialize(t *testing.T) {
+	user, job := createTestEntities()
+	store := NewStore(DefaultRegistry(), NewMemoryCache(), nil, nil)
+	ctx := context.Background()
+
+	written, err := store.Materialize(ctx, []*models.User{user}, []*models.Job{job}, true)
+	if err != nil {
+		t.Fatalf("Materialize failed: %v", err)
+	}
+	if written != 3 {
+		t.Errorf("Expected 3 entries written, got %d", written)
+	}
+
+	// Without a loader, only materialized pairs can be served by ID
+	if _, err := store.FeatureVectorByID(ctx, user.ID, job.ID); err != nil {
+		t.Errorf("Expected materialized pair to be served, got: %v", err)
+	}
+	if _, err := store.FeatureVectorByID(ctx, user.ID, "unknown"); err == nil {
+		t.Error("Expected error for uncached pair without loader")
+	}
+}
+
+type staticSource struct {
+	users []*models.User
+	jobs  []*models.Job
+	err   error
+}
+
+func (s *staticSource) MaterializeUsers(ctx context.Context, limit int) ([]*models.User, error) {
+	return s.users, s.err
+}
+
+func (s *staticSource) MaterializeJobs(ctx context.Context, limit int) ([]*models.Job, error) {
+	return s.jobs, nil
+}
+
+func TestMaterializeJob_Run(t *testing.T) {
+	user, job := createTestEntities()
+	loader := &countingLoader{
+		users: map[string]*models.User{user.ID: user},
+		jobs:  map[string]*models.Job{job.ID: job},
+	}
+	store := NewStore(DefaultRegistry(), NewMemoryCache(), loader, nil)
+	ctx := context.Background()
+
+	materializer := NewMaterializeJob(store, &staticSource{users: []*models.User{user}, jobs: []*models.Job{job}}, MaterializeConfig{})
+	written, err := materializer.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if written != 2 {
+		t.Errorf("Expected user and job entries without pairs, got %d", written)
+	}
+
+	// The user and job parts are served from cache; only the pair needs the loader
+	if _, err := store.FeatureVectorByID(ctx, user.ID, job.ID); err != nil {
+		t.Fatalf("FeatureVectorByID failed: %v", err)
+	}
+	if stats := store.GetStats(); stats.Misses != 2 {
+		t.Errorf("Expected only the pair lookups to miss, got %d misses", stats.Misses)
+	}
+
+	failing := NewMaterializeJob(store, &staticSource{err: errors.New("database down")}, MaterializeConfig{})
+	if _, err := failing.Run(ctx); err == nil {
+		t.Error("Expected a source error to fail the run")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	user, job := createTestEntities()
+	flat := Flatten(DefaultRegistry().ComputeVector(user, job))
+
+	if flat["user_skill_go"] != 0.8 || flat["job_skill_go"] != 0.6 {
+		t.Errorf("Expected per-skill features qualified by side, got user %f job %f", flat["user_skill_go"], flat["job_skill_go"])
+	}
+	if _, exists := flat["skill_go"]; exists {
+		t.Error("Expected no unqualified skill features")
+	}
+	if flat["pair_location_match"] != 1.0 {
+		t.Error("Expected interaction features to be kept")
+	}
+}
@@ -54,6 +54,24 @@ type RLFeedback struct {
 	CreatedAt     time.Time              `json:"created_at" bson:"created_at"`
 }
 
+// BanditDecision represents a logged exploratory slot decision with its propensity
+type BanditDecision struct {
+	ID             string     `json:"id" bson:"_id"`
+	UserID         string     `json:"user_id" bson:"user_id"`
+	JobID          string     `json:"job_id" bson:"job_id"`
+	Policy         string     `json:"policy" bson:"policy"`
+	Slot           int        `json:"slot" bson:"slot"`
+	CandidateCount int        `json:"candidate_count" bson:"candidate_count"`
+	Propensity     float64    `json:"propensity" bson:"propensity"` // Probability the policy chose this job for this slot
+	Score          float64    `json:"score" bson:"score"`           // UCB score at decision time
+	Context        []float64  `json:"context" bson:"context"`
+	Reward         float64    `json:"reward" bson:"reward"`
+	RewardEvent    string     `json:"reward_event,omitempty" bson:"reward_event,omitempty"` // "view", "save", "apply"
+	ModelVersion   string     `json:"model_version" bson:"model_version"`
+	CreatedAt      time.Time  `json:"created_at" bson:"created_at"`
+	RewardedAt     *time.Time `json:"rewarded_at,omitempty" bson:"rewarded_at,omitempty"`
+}
+
 // UserBehaviorPattern represents learned user behavior patterns
 type UserBehaviorPattern struct {
 	UserID              string                 `json:"user_id" bson:"user_id"`
@@ -120,6 +138,7 @@ type InferenceResult struct {
 
 // FeatureVector represents extracted features for ML models
 type FeatureVector struct {
+	Version      string             `json:"version,omitempty"` // Feature set version that produced the values
 	UserFeatures map[string]float64 `json:"user_features"`
 	JobFeatures  map[string]float64 `json:"job_features"`
 	ContextFeatures map[string]float64 `json:"context_features"`
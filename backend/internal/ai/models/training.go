@@ -56,6 +56,69 @@ type RLConfig struct {
 	TargetUpdateFreq  int     `json:"target_update_freq" yaml:"target_update_freq"`
 }
 
+// BanditConfig represents contextual bandit configuration for exploratory slots
+type BanditConfig struct {
+	Algorithm        string        `json:"algorithm" yaml:"algorithm"` // "linucb"
+	ContextDim       int           `json:"context_dim" yaml:"context_dim"`
+	Alpha            float64       `json:"alpha" yaml:"alpha"`             // UCB exploration bonus
+	Temperature      float64       `json:"temperature" yaml:"temperature"` // Softmax temperature over UCB scores
+	RegularizationL2 float64       `json:"regularization_l2" yaml:"regularization_l2"`
+	ExplorationSlots int           `json:"exploration_slots" yaml:"exploration_slots"`
+	RewardWindow     time.Duration `json:"reward_window" yaml:"reward_window"`
+	DecisionLogSize  int           `json:"decision_log_size" yaml:"decision_log_size"`
+}
+
+// DriftThresholds decide when a drift statistic raises a warning or an alert
+type DriftThresholds struct {
+	PSIWarning float64 `json:"psi_warning" yaml:"psi_warning"`
+	PSIAlert   float64 `json:"psi_alert" yaml:"psi_alert"`
+	KLAlert    float64 `json:"kl_alert" yaml:"kl_alert"`
+}
+
+// DriftConfig represents score and feature distribution drift monitoring configuration
+type DriftConfig struct {
+	Bins             int                        `json:"bins" yaml:"bins"`
+	ReferenceSize    int                        `json:"reference_size" yaml:"reference_size"`
+	WindowSize       int                        `json:"window_size" yaml:"window_size"`
+	MinWindowSamples int                        `json:"min_window_samples" yaml:"min_window_samples"`
+	Thresholds       DriftThresholds            `json:"thresholds" yaml:"thresholds"`
+	MetricThresholds map[string]DriftThresholds `json:"metric_thresholds" yaml:"metric_thresholds"` // Per-metric overrides
+	TrackedFeatures  []string                   `json:"tracked_features" yaml:"tracked_features"`
+	CheckInterval    time.Duration              `json:"check_interval" yaml:"check_interval"`
+	AlertCooldown    time.Duration              `json:"alert_cooldown" yaml:"alert_cooldown"`
+}
+
+// DriftReference is the frozen reference sample of one drift metric
+type DriftReference struct {
+	Metric    string    `json:"metric"`
+	Values    []float64 `json:"values"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EnsembleWeightConfig represents online learning of per-segment ensemble weights
+type EnsembleWeightConfig struct {
+	DefaultWeights  map[string]float64 `json:"default_weights" yaml:"default_weights"`
+	LearningRate    float64            `json:"learning_rate" yaml:"learning_rate"`         // Initial rate, decays with updates
+	MinLearningRate float64            `json:"min_learning_rate" yaml:"min_learning_rate"` // Floor so segments keep adapting
+	MaxLearningRate float64            `json:"max_learning_rate" yaml:"max_learning_rate"` // Hard cap on any single update
+	MinWeight       float64            `json:"min_weight" yaml:"min_weight"`
+	MaxWeight       float64            `json:"max_weight" yaml:"max_weight"`
+	MinObservations int64              `json:"min_observations" yaml:"min_observations"` // Updates before a segment is served
+	NewUserWindow   time.Duration      `json:"new_user_window" yaml:"new_user_window"`
+	ServedCacheSize int                `json:"served_cache_size" yaml:"served_cache_size"`
+}
+
+// SegmentWeights are the learned ensemble weights for one user segment
+type SegmentWeights struct {
+	Segment   string             `json:"segment"`
+	Weights   map[string]float64 `json:"weights"`
+	Updates   int64              `json:"updates"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// EpochCallback receives per-epoch training progress
+type EpochCallback func(epoch int, loss float64, validation *ValidationMetrics)
+
 // TrainingJob represents a training job instance
 type TrainingJob struct {
 	ID               string                 `json:"id" bson:"_id"`
@@ -111,6 +174,7 @@ type ModelArtifact struct {
 	FileSize     int64                  `json:"file_size" bson:"file_size"`
 	Checksum     string                 `json:"checksum" bson:"checksum"`
 	Metadata     map[string]interface{} `json:"metadata" bson:"metadata"`
+	Weights      []byte                 `json:"-" bson:"weights"` // serialized model weights
 	IsActive     bool                   `json:"is_active" bson:"is_active"`
 	CreatedAt    time.Time              `json:"created_at" bson:"created_at"`
 }
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"microbridge/backend/internal/core/matching"
+)
+
+// ModelContribution is one model's share of the ensemble score
+type ModelContribution struct {
+	Model        string  `json:"model"`
+	Score        float64 `json:"score"`
+	Weight       float64 `json:"weight"`       // Normalized over the models that produced a valid score
+	Contribution float64 `json:"contribution"` // Score * Weight, sums to the final score
+	Share        float64 `json:"share"`        // Contribution / final score
+}
+
+// MatchAttribution explains what drove a hybrid match score
+type MatchAttribution struct {
+	FinalScore      float64                         `json:"final_score"`
+	Models          []ModelContribution             `json:"models"`
+	BasicComponents []matching.ComponentAttribution `json:"basic_components,omitempty"`
+	FallbackUsed    bool                            `json:"fallback_used"`
+}
+
+// Facts renders the attribution as short statements for grounding LLM prompts
+func (a *MatchAttribution) Facts() []string {
+	if a == nil {
+		return nil
+	}
+
+	var facts []string
+	if a.FallbackUsed {
+		facts = append(facts, fmt.Sprintf("No learned model was available; the score of %.1f%% comes from the rule-based algorithm alone", a.FinalScore*100))
+	}
+	for _, model := range a.Models {
+		facts = append(facts, fmt.Sprintf("%s model scored %.1f%% with weight %.2f, contributing %.1f points (%.0f%% of the final score)",
+			modelDisplayName(model.Model), model.Score*100, model.Weight, model.Contribution*100, model.Share*100))
+	}
+	for _, component := range a.BasicComponents {
+		switch {
+		case component.Contribution > 0:
+			facts = append(facts, fmt.Sprintf("%s scored %.1f%% and adds %.1f points to the rule-based score",
+				component.Component, component.Score*100, component.Contribution*100))
+		case component.Score < 0.5 && component.MarginalEffect > 0:
+			facts = append(facts, fmt.Sprintf("%s scored only %.1f%%; each 10-point improvement would raise the rule-based score by %.1f points",
+				component.Component, component.Score*100, component.MarginalEffect*10))
+		}
+	}
+
+	return facts
+}
+
+// Private methods
+
+// attributeMatch mirrors ensembleScores so the attribution always adds up to
+// the score the user actually sees
+func (s *HybridMatchingService) attributeMatch(scores, weights map[string]float64, finalScore float64, basicScore *matching.MatchScore) *MatchAttribution {
+	attribution := &MatchAttribution{FinalScore: finalScore}
+
+	if basicScore != nil && s.basicAlgorithm != nil {
+		attribution.BasicComponents = s.basicAlgorithm.AttributeComponents(basicScore)
+	}
+
+	totalWeight := 0.0
+	for model, score := range scores {
+		weight := weights[model]
+		if weight > 0 && !math.IsNaN(score) && score >= 0 {
+			totalWeight += weight
+		}
+	}
+
+	if totalWeight == 0 {
+		if finalScore > 0 {
+			attribution.FallbackUsed = true
+			attribution.Models = []ModelContribution{{
+				Model: "basic", Score: scores["basic"], Weight: 1.0, Contribution: finalScore, Share: 1.0,
+			}}
+		}
+		return attribution
+	}
+
+	for model, score := range scores {
+		weight := weights[model]
+		if weight <= 0 || math.IsNaN(score) || score < 0 {
+			continue
+		}
+
+		contribution := ModelContribution{
+			Model:        model,
+			Score:        score,
+			Weight:       weight / totalWeight,
+			Contribution: score * weight / totalWeight,
+		}
+		if finalScore > 0 {
+			contribution.Share = contribution.Contribution / finalScore
+		}
+		attribution.Models = append(attribution.Models, contribution)
+	}
+
+	sort.Slice(attribution.Models, func(i, j int) bool {
+		if attribution.Models[i].Contribution != attribution.Models[j].Contribution {
+			return attribution.Models[i].Contribution > attribution.Models[j].Contribution
+		}
+		return attribution.Models[i].Model < attribution.Models[j].Model
+	})
+
+	return attribution
+}
+
+// Utility functions
+
+func modelDisplayName(model string) string {
+	switch model {
+	case "basic":
+		return "Rule-based matching"
+	case "ncf":
+		return "Collaborative filtering"
+	case "gnn":
+		return "Skill graph"
+	case "rl":
+		return "Engagement learning"
+	default:
+		return model
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"microbridge/backend/internal/core/matching"
+)
+
+func TestHybridMatchingService_AttributeMatch(t *testing.T) {
+	hybridService := createTestHybridService()
+
+	scores := map[string]float64{"basic": 0.3, "ncf": 0.8, "gnn": 0.6, "rl": 0.4}
+	weights := map[string]float64{"basic": 0.1, "ncf": 0.5, "gnn": 0.2, "rl": 0.0}
+	finalScore, _ := hybridService.ensembleScores(scores, weights)
+
+	attribution := hybridService.attributeMatch(scores, weights, finalScore, nil)
+
+	if len(attribution.Models) != 3 {
+		t.Fatalf("Expected 3 contributing models (rl has zero weight), got %d", len(attribution.Models))
+	}
+	if attribution.Models[0].Model != "ncf" {
+		t.Errorf("Expected ncf to contribute most, got %s", attribution.Models[0].Model)
+	}
+
+	sum, shares := 0.0, 0.0
+	for _, model := range attribution.Models {
+		sum += model.Contribution
+		shares += model.Share
+	}
+	if abs(sum-finalScore) > 1e-9 {
+		t.Errorf("Contributions should sum to final score %f, got %f", finalScore, sum)
+	}
+	if abs(shares-1.0) > 1e-9 {
+		t.Errorf("Shares should sum to 1, got %f", shares)
+	}
+
+	// No valid model scores falls back to the basic algorithm
+	fallback := hybridService.attributeMatch(map[string]float64{"basic": 0.5}, map[string]float64{}, 0.5, nil)
+	if !fallback.FallbackUsed || len(fallback.Models) != 1 || fallback.Models[0].Share != 1.0 {
+		t.Errorf("Expected single basic contribution on fallback, got %+v", fallback)
+	}
+}
+
+func TestMatchingAlgorithm_AttributeComponents(t *testing.T) {
+	algorithm := matching.NewMatchingAlgorithm()
+	breakdown := map[string]float64{
+		"skills":          0.9,
+		"experience":      0.7,
+		"location":        1.0,
+		"availability":    0.2,
+		"learning":        0.5,
+		"interest":        0.8,
+		"career_fit":      0.6,
+		"time_commitment": 0.0,
+	}
+
+	components := algorithm.AttributeComponents(&matching.MatchScore{Breakdown: breakdown})
+	if len(components) != len(breakdown) {
+		t.Fatalf("Expected %d components, got %d", len(breakdown), len(components))
+	}
+
+	byName := make(map[string]matching.ComponentAttribution)
+	for i, component := range components {
+		byName[component.Component] = component
+		if i > 0 && component.Contribution > components[i-1].Contribution {
+			t.Error("Components should be sorted by contribution")
+		}
+	}
+
+	if byName["learning"].Direction != "both" {
+		t.Errorf("Expected learning to feed both directions, got %s", byName["learning"].Direction)
+	}
+	if byName["time_commitment"].Contribution != 0 {
+		t.Errorf("Zero-scored component should contribute nothing, got %f", byName["time_commitment"].Contribution)
+	}
+	if byName["skills"].Contribution <= byName["availability"].Contribution {
+		t.Error("Expected strong skills to contribute more than weak availability")
+	}
+
+	// Marginal effect should match a finite-difference estimate of the total score
+	total := func(b map[string]float64) float64 {
+		u := 0.35*b["skills"] + 0.25*b["experience"] + 0.20*b["location"] + 0.15*b["availability"] + 0.05*b["learning"]
+		j := 0.40*b["interest"] + 0.30*b["career_fit"] + 0.20*b["time_commitment"] + 0.10*b["learning"]
+		return 2 * u * j / (u + j)
+	}
+	bumped := make(map[string]float64)
+	for k, v := range breakdown {
+		bumped[k] = v
+	}
+	bumped["learning"] += 1e-6
+	numeric := (total(bumped) - total(breakdown)) / 1e-6
+	if abs(numeric-byName["learning"].MarginalEffect) > 1e-4 {
+		t.Errorf("Expected marginal effect ~%f, got %f", numeric, byName["learning"].MarginalEffect)
+	}
+
+	facts := (&MatchAttribution{BasicComponents: components}).Facts()
+	if len(facts) == 0 || !strings.Contains(strings.Join(facts, "\n"), "skills") {
+		t.Errorf("Expected facts to mention skills, got %v", facts)
+	}
+}
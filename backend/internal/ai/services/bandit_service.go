@@ -0,0 +1,506 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"microbridge/backend/internal/ai/models"
+	coreModels "microbridge/backend/internal/models"
+)
+
+// BanditContextDim is the length of the context vectors BuildBanditContext builds
+const BanditContextDim = 8
+
+// Reward events fed back from behavior tracking
+const (
+	BanditEventView  = "view"
+	BanditEventSave  = "save"
+	BanditEventApply = "apply"
+)
+
+// BanditDecisionStore persists every exploration decision with its context,
+// propensity and reward, so offline policy evaluation sees all replicas and restarts
+type BanditDecisionStore interface {
+	SaveBanditDecisions(ctx context.Context, decisions []*models.BanditDecision) error
+	RecordBanditReward(ctx context.Context, decision *models.BanditDecision) error
+	ListBanditDecisions(ctx context.Context, since time.Time, limit int) ([]*models.BanditDecision, error)
+}
+
+// BanditService implements a LinUCB contextual bandit for exploratory recommendation slots.
+// Jobs are chosen by sampling from a softmax over UCB scores so every decision has a
+// known propensity that can be used for offline policy evaluation.
+type BanditService struct {
+	mu               sync.RWMutex
+	config           *models.BanditConfig
+	aInverse         [][]float64 // (lambda*I + sum x x^T)^-1, kept up to date with Sherman-Morrison
+	bVector          []float64   // sum r x
+	pending          map[string]*models.BanditDecision
+	decisionLog      []*models.BanditDecision
+	store            BanditDecisionStore
+	rng              *rand.Rand
+	modelVersion     string
+	totalDecisions   int64
+	rewardedCount    int64
+	cumulativeReward float64
+}
+
+// BanditCandidate is a job competing for an exploratory slot
+type BanditCandidate struct {
+	JobID   string
+	Context []float64
+}
+
+// BanditStats summarizes bandit activity
+type BanditStats struct {
+	TotalDecisions int64     `json:"total_decisions"`
+	RewardedCount  int64     `json:"rewarded_count"`
+	PendingCount   int       `json:"pending_count"`
+	AverageReward  float64   `json:"average_reward"`
+	Theta          []float64 `json:"theta"`
+	ModelVersion   string    `json:"model_version"`
+}
+
+// NewBanditService creates a new contextual bandit service. The context dimension
+// must match BuildBanditContext, otherwise every selection would fail. Decisions are
+// logged to store; without one the last DecisionLogSize are kept in memory.
+func NewBanditService(config *models.BanditConfig, store BanditDecisionStore) (*BanditService, error) {
+	cfg := *config
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = "linucb"
+	}
+	if cfg.ContextDim <= 0 {
+		cfg.ContextDim = BanditContextDim
+	}
+	if cfg.ContextDim != BanditContextDim {
+		return nil, fmt.Errorf("bandit context dimension %d does not match the %d features BuildBanditContext builds", cfg.ContextDim, BanditContextDim)
+	}
+	if cfg.Alpha <= 0 {
+		cfg.Alpha = 1.0
+	}
+	if cfg.Temperature <= 0 {
+		cfg.Temperature = 0.1
+	}
+	if cfg.RegularizationL2 <= 0 {
+		cfg.RegularizationL2 = 1.0
+	}
+	if cfg.RewardWindow <= 0 {
+		cfg.RewardWindow = 72 * time.Hour
+	}
+	if cfg.DecisionLogSize <= 0 {
+		cfg.DecisionLogSize = 10000
+	}
+
+	service := &BanditService{
+		config:       &cfg,
+		bVector:      make([]float64, cfg.ContextDim),
+		pending:      make(map[string]*models.BanditDecision),
+		store:        store,
+		rng:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		modelVersion: fmt.Sprintf("bandit_v%d", time.Now().Unix()),
+	}
+
+	service.initializeModel()
+
+	return service, nil
+}
+
+// SelectExploratory picks jobs for the given number of exploratory slots and logs each
+// decision. The returned decisions are copies, so rewards recorded later don't change them.
+func (s *BanditService) SelectExploratory(ctx context.Context, userID string, candidates []*BanditCandidate, slots int) ([]*models.BanditDecision, error) {
+	if slots <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	for _, candidate := range candidates {
+		if len(candidate.Context) != s.config.ContextDim {
+			return nil, fmt.Errorf("candidate %s has context dimension %d, expected %d", candidate.JobID, len(candidate.Context), s.config.ContextDim)
+		}
+	}
+
+	s.mu.Lock()
+	decisions := s.selectExploratory(userID, candidates, slots)
+	s.mu.Unlock()
+
+	if s.store != nil {
+		if err := s.store.SaveBanditDecisions(ctx, decisions); err != nil {
+			fmt.Printf("Failed to log bandit decisions: %v\n", err)
+		}
+	}
+
+	return decisions, nil
+}
+
+// selectExploratory samples the decisions; callers must hold s.mu
+func (s *BanditService) selectExploratory(userID string, candidates []*BanditCandidate, slots int) []*models.BanditDecision {
+	now := time.Now()
+	s.expireDecisions(now)
+
+	theta := s.theta()
+	scores := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		scores[i] = s.ucbScore(theta, candidate.Context)
+	}
+
+	remaining := make([]int, len(candidates))
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	var decisions []*models.BanditDecision
+	for slot := 0; slot < slots && len(remaining) > 0; slot++ {
+		probabilities := s.selectionProbabilities(scores, remaining)
+		pick := s.sample(probabilities)
+		index := remaining[pick]
+		candidate := candidates[index]
+
+		decision := &models.BanditDecision{
+			ID:             uuid.New().String(),
+			UserID:         userID,
+			JobID:          candidate.JobID,
+			Policy:         s.config.Algorithm,
+			Slot:           slot,
+			CandidateCount: len(remaining),
+			Propensity:     probabilities[pick],
+			Score:          scores[index],
+			Context:        append([]float64(nil), candidate.Context...),
+			ModelVersion:   s.modelVersion,
+			CreatedAt:      now,
+		}
+
+		// A pair shown again closes its earlier decision first, so a reward is
+		// credited to the latest one and the earlier one is learned, not lost
+		key := s.pendingKey(userID, candidate.JobID)
+		if previous, exists := s.pending[key]; exists {
+			s.resolveDecision(key, previous)
+		}
+		s.pending[key] = decision
+		if s.store == nil {
+			s.appendDecisionLog(decision)
+		}
+		s.totalDecisions++
+		decisions = append(decisions, copyDecision(decision))
+
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+
+	return decisions
+}
+
+// RecordReward credits a view, save or apply event to the pending decision for the user-job pair.
+// Later, stronger events only add the difference so a pair is never counted more than once.
+func (s *BanditService) RecordReward(ctx context.Context, userID, jobID, event string) error {
+	reward, err := s.rewardForEvent(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	rewarded := s.recordReward(userID, jobID, event, reward)
+	s.mu.Unlock()
+
+	if rewarded != nil && s.store != nil {
+		if err := s.store.RecordBanditReward(ctx, rewarded); err != nil {
+			return fmt.Errorf("failed to log bandit reward: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordReward credits the pending decision and returns a copy of it, or nil when
+// nothing changed; callers must hold s.mu
+func (s *BanditService) recordReward(userID, jobID, event string, reward float64) *models.BanditDecision {
+	now := time.Now()
+	s.expireDecisions(now)
+
+	decision, exists := s.pending[s.pendingKey(userID, jobID)]
+	if !exists {
+		// Job was not shown in an exploratory slot; nothing to learn
+		return nil
+	}
+
+	if decision.RewardedAt == nil {
+		s.updateCovariance(decision.Context)
+		s.rewardedCount++
+	} else if reward <= decision.Reward {
+		return nil
+	}
+
+	delta := reward - decision.Reward
+	for i, x := range decision.Context {
+		s.bVector[i] += delta * x
+	}
+	s.cumulativeReward += delta
+
+	decision.Reward = reward
+	decision.RewardEvent = event
+	decision.RewardedAt = &now
+
+	return copyDecision(decision)
+}
+
+// PredictReward returns the expected reward for a context under the current model
+func (s *BanditService) PredictReward(contextVector []float64) (float64, error) {
+	if len(contextVector) != s.config.ContextDim {
+		return 0, fmt.Errorf("context dimension %d, expected %d", len(contextVector), s.config.ContextDim)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return dotProduct(s.theta(), contextVector), nil
+}
+
+// GetDecisionLog returns logged decisions created since the given time, for offline
+// evaluation. With a store the log covers every replica; limit caps the decisions read
+// from it.
+func (s *BanditService) GetDecisionLog(ctx context.Context, since time.Time, limit int) ([]*models.BanditDecision, error) {
+	if s.store != nil {
+		return s.store.ListBanditDecisions(ctx, since, limit)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var decisions []*models.BanditDecision
+	for _, decision := range s.decisionLog {
+		if decision.CreatedAt.Before(since) {
+			continue
+		}
+		decisions = append(decisions, copyDecision(decision))
+		if limit > 0 && len(decisions) == limit {
+			break
+		}
+	}
+
+	return decisions, nil
+}
+
+// GetStats returns a snapshot of bandit activity
+func (s *BanditService) GetStats() *BanditStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := &BanditStats{
+		TotalDecisions: s.totalDecisions,
+		RewardedCount:  s.rewardedCount,
+		PendingCount:   len(s.pending),
+		Theta:          s.theta(),
+		ModelVersion:   s.modelVersion,
+	}
+	if s.totalDecisions > 0 {
+		stats.AverageReward = s.cumulativeReward / float64(s.totalDecisions)
+	}
+
+	return stats
+}
+
+// ExplorationSlots returns the configured number of exploratory slots
+func (s *BanditService) ExplorationSlots() int {
+	return s.config.ExplorationSlots
+}
+
+// BuildBanditContext builds the bandit context vector for a user-job pair
+func BuildBanditContext(user *coreModels.User, job *coreModels.Job, modelScore float64) []float64 {
+	userSkills := make(map[string]bool, len(user.Skills))
+	for _, skill := range user.Skills {
+		userSkills[strings.ToLower(skill.Name)] = true
+	}
+
+	matched, required, requiredMatched := 0, 0, 0
+	for _, skill := range job.Skills {
+		has := userSkills[strings.ToLower(skill.Name)]
+		if has {
+			matched++
+		}
+		if skill.IsRequired {
+			required++
+			if has {
+				requiredMatched++
+			}
+		}
+	}
+
+	skillOverlap := 0.0
+	if len(job.Skills) > 0 {
+		skillOverlap = float64(matched) / float64(len(job.Skills))
+	}
+	requiredCoverage := 1.0
+	if required > 0 {
+		requiredCoverage = float64(requiredMatched) / float64(required)
+	}
+
+	interestMatch := 0.0
+	category := strings.ToLower(job.Category)
+	for _, interest := range user.Interests {
+		lower := strings.ToLower(interest)
+		if category != "" && (strings.Contains(category, lower) || strings.Contains(lower, category)) {
+			interestMatch = 1.0
+			break
+		}
+	}
+
+	hoursFit := 0.0
+	if job.Duration > 0 && user.Availability.HoursPerWeek > 0 {
+		hoursFit = math.Min(1.0, float64(user.Availability.HoursPerWeek)/float64(job.Duration))
+	}
+
+	return []float64{
+		1.0, // Bias term
+		skillOverlap,
+		requiredCoverage,
+		boolToFloat(user.ExperienceLevel == job.ExperienceLevel),
+		boolToFloat(job.IsRemote || user.Location == job.Location),
+		hoursFit,
+		interestMatch,
+		math.Max(0, math.Min(1, modelScore)),
+	}
+}
+
+// Private methods
+
+func (s *BanditService) initializeModel() {
+	dim := s.config.ContextDim
+	s.aInverse = make([][]float64, dim)
+	for i := range s.aInverse {
+		s.aInverse[i] = make([]float64, dim)
+		s.aInverse[i][i] = 1.0 / s.config.RegularizationL2
+	}
+}
+
+func (s *BanditService) theta() []float64 {
+	return matVec(s.aInverse, s.bVector)
+}
+
+func (s *BanditService) ucbScore(theta, x []float64) float64 {
+	variance := dotProduct(x, matVec(s.aInverse, x))
+	return dotProduct(theta, x) + s.config.Alpha*math.Sqrt(math.Max(0, variance))
+}
+
+// updateCovariance applies the Sherman-Morrison rank-one update for A += x x^T
+func (s *BanditService) updateCovariance(x []float64) {
+	ax := matVec(s.aInverse, x)
+	denominator := 1.0 + dotProduct(x, ax)
+	for i := range s.aInverse {
+		for j := range s.aInverse[i] {
+			s.aInverse[i][j] -= ax[i] * ax[j] / denominator
+		}
+	}
+}
+
+func (s *BanditService) selectionProbabilities(scores []float64, remaining []int) []float64 {
+	probabilities := make([]float64, len(remaining))
+	maxScore := math.Inf(-1)
+	for _, index := range remaining {
+		maxScore = math.Max(maxScore, scores[index])
+	}
+
+	sum := 0.0
+	for i, index := range remaining {
+		probabilities[i] = math.Exp((scores[index] - maxScore) / s.config.Temperature)
+		sum += probabilities[i]
+	}
+	for i := range probabilities {
+		probabilities[i] /= sum
+	}
+
+	return probabilities
+}
+
+func (s *BanditService) sample(probabilities []float64) int {
+	r := s.rng.Float64()
+	cumulative := 0.0
+	for i, p := range probabilities {
+		cumulative += p
+		if r < cumulative {
+			return i
+		}
+	}
+	return len(probabilities) - 1
+}
+
+// expireDecisions closes pending decisions whose reward window has passed.
+// Decisions that never received an event are learned as zero reward.
+func (s *BanditService) expireDecisions(now time.Time) {
+	for key, decision := range s.pending {
+		if now.Sub(decision.CreatedAt) >= s.config.RewardWindow {
+			s.resolveDecision(key, decision)
+		}
+	}
+}
+
+// resolveDecision closes a pending decision, learning it as zero reward when it
+// never received an event
+func (s *BanditService) resolveDecision(key string, decision *models.BanditDecision) {
+	if decision.RewardedAt == nil {
+		s.updateCovariance(decision.Context)
+	}
+	delete(s.pending, key)
+}
+
+func (s *BanditService) appendDecisionLog(decision *models.BanditDecision) {
+	s.decisionLog = append(s.decisionLog, decision)
+	if overflow := len(s.decisionLog) - s.config.DecisionLogSize; overflow > 0 {
+		s.decisionLog = s.decisionLog[overflow:]
+	}
+}
+
+func (s *BanditService) rewardForEvent(event string) (float64, error) {
+	switch event {
+	case BanditEventView:
+		return 0.1, nil
+	case BanditEventSave:
+		return 0.4, nil
+	case BanditEventApply:
+		return 1.0, nil
+	default:
+		return 0, fmt.Errorf("unsupported bandit reward event: %s", event)
+	}
+}
+
+func (s *BanditService) pendingKey(userID, jobID string) string {
+	return userID + ":" + jobID
+}
+
+// Utility functions
+
+// copyDecision detaches a decision from the one the bandit keeps updating
+func copyDecision(decision *models.BanditDecision) *models.BanditDecision {
+	copied := *decision
+	copied.Context = append([]float64(nil), decision.Context...)
+	if decision.RewardedAt != nil {
+		rewardedAt := *decision.RewardedAt
+		copied.RewardedAt = &rewardedAt
+	}
+	return &copied
+}
+
+func dotProduct(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func matVec(m [][]float64, v []float64) []float64 {
+	result := make([]float64, len(m))
+	for i, row := range m {
+		result[i] = dotProduct(row, v)
+	}
+	return result
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1.0
+	}
+	return 0.0
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/ai/models"
+)
+
+func newTestBanditCandidates(n int) []*BanditCandidate {
+	candidates := make([]*BanditCandidate, n)
+	for i := 0; i < n; i++ {
+		candidates[i] = &BanditCandidate{
+			JobID:   fmt.Sprintf("job_%d", i),
+			Context: []float64{1, float64(i) / float64(n), 0.5, 1, 1, 0.8, 0, 0.6},
+		}
+	}
+	return candidates
+}
+
+func newTestBanditService(t *testing.T, config *models.BanditConfig) *BanditService {
+	t.Helper()
+	service, err := NewBanditService(config, nil)
+	if err != nil {
+		t.Fatalf("NewBanditService failed: %v", err)
+	}
+	return service
+}
+
+func TestNewBanditService_RejectsContextDimension(t *testing.T) {
+	if _, err := NewBanditService(&models.BanditConfig{ContextDim: BanditContextDim + 1}, nil); err == nil {
+		t.Error("Expected a context dimension BuildBanditContext can't fill to be rejected")
+	}
+}
+
+func TestBanditService_SelectExploratory(t *testing.T) {
+	service := newTestBanditService(t, &models.BanditConfig{ExplorationSlots: 2})
+	ctx := context.Background()
+
+	decisions, err := service.SelectExploratory(ctx, "user1", newTestBanditCandidates(5), 3)
+	if err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+
+	if len(decisions) != 3 {
+		t.Fatalf("Expected 3 decisions, got %d", len(decisions))
+	}
+
+	seen := make(map[string]bool)
+	for i, decision := range decisions {
+		if seen[decision.JobID] {
+			t.Errorf("Job %s selected twice", decision.JobID)
+		}
+		seen[decision.JobID] = true
+
+		if decision.Propensity <= 0 || decision.Propensity > 1 {
+			t.Errorf("Propensity should be in (0, 1], got %f", decision.Propensity)
+		}
+		if decision.Slot != i {
+			t.Errorf("Expected slot %d, got %d", i, decision.Slot)
+		}
+		if decision.CandidateCount != 5-i {
+			t.Errorf("Expected %d remaining candidates, got %d", 5-i, decision.CandidateCount)
+		}
+	}
+
+	if logged, _ := service.GetDecisionLog(ctx, time.Time{}, 0); len(logged) != 3 {
+		t.Errorf("Expected 3 logged decisions, got %d", len(logged))
+	}
+
+	// Mismatched context dimensions are rejected
+	_, err = service.SelectExploratory(ctx, "user1", []*BanditCandidate{{JobID: "bad", Context: []float64{1}}}, 1)
+	if err == nil {
+		t.Error("Expected error for wrong context dimension")
+	}
+}
+
+func TestBanditService_RecordReward(t *testing.T) {
+	service := newTestBanditService(t, &models.BanditConfig{})
+	ctx := context.Background()
+	candidate := newTestBanditCandidates(1)[0]
+
+	decisions, err := service.SelectExploratory(ctx, "user1", []*BanditCandidate{candidate}, 1)
+	if err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+
+	before, _ := service.PredictReward(candidate.Context)
+
+	for _, event := range []string{BanditEventView, BanditEventApply, BanditEventSave} {
+		if err := service.RecordReward(ctx, "user1", candidate.JobID, event); err != nil {
+			t.Fatalf("Reward %s failed: %v", event, err)
+		}
+	}
+
+	after, _ := service.PredictReward(candidate.Context)
+	if after <= before {
+		t.Errorf("Expected predicted reward to increase, before %f after %f", before, after)
+	}
+
+	// The pair is credited once with its strongest event
+	stats := service.GetStats()
+	if stats.RewardedCount != 1 {
+		t.Errorf("Expected 1 rewarded decision, got %d", stats.RewardedCount)
+	}
+	if math.Abs(stats.AverageReward-1.0) > 1e-9 {
+		t.Errorf("Expected average reward 1.0, got %f", stats.AverageReward)
+	}
+
+	// Events for jobs that were not explored are ignored
+	if err := service.RecordReward(ctx, "user1", "unknown_job", BanditEventApply); err != nil {
+		t.Errorf("Expected no error for unexplored job, got: %v", err)
+	}
+
+	if err := service.RecordReward(ctx, "user1", candidate.JobID, "dismiss"); err == nil {
+		t.Error("Expected error for unsupported event")
+	}
+
+	// Decisions already handed out are copies the reward doesn't touch
+	if decisions[0].RewardedAt != nil || decisions[0].Reward != 0 {
+		t.Errorf("Expected the returned decision to stay unrewarded, got %+v", decisions[0])
+	}
+}
+
+func TestBanditService_ExpiredDecisionsLearnZeroReward(t *testing.T) {
+	service := newTestBanditService(t, &models.BanditConfig{RewardWindow: time.Nanosecond})
+	ctx := context.Background()
+	candidates := newTestBanditCandidates(2)
+
+	if _, err := service.SelectExploratory(ctx, "user1", candidates, 2); err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	// Rewards arriving after the window are not credited
+	if err := service.RecordReward(ctx, "user1", candidates[0].JobID, BanditEventApply); err != nil {
+		t.Fatalf("Reward failed: %v", err)
+	}
+
+	stats := service.GetStats()
+	if stats.PendingCount != 0 {
+		t.Errorf("Expected no pending decisions, got %d", stats.PendingCount)
+	}
+	if stats.RewardedCount != 0 {
+		t.Errorf("Expected no rewarded decisions, got %d", stats.RewardedCount)
+	}
+}
+
+func TestBanditService_ReshownPairResolvesEarlierDecision(t *testing.T) {
+	service := newTestBanditService(t, &models.BanditConfig{})
+	ctx := context.Background()
+	candidate := newTestBanditCandidates(1)[0]
+
+	first, err := service.SelectExploratory(ctx, "user1", []*BanditCandidate{candidate}, 1)
+	if err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+	second, err := service.SelectExploratory(ctx, "user1", []*BanditCandidate{candidate}, 1)
+	if err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+
+	// The earlier decision was closed as unrewarded, not silently replaced
+	before, _ := service.PredictReward(candidate.Context)
+	fresh := newTestBanditService(t, &models.BanditConfig{})
+	if _, err := fresh.SelectExploratory(ctx, "user1", []*BanditCandidate{candidate}, 1); err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+	if service.aInverse[0][0] >= fresh.aInverse[0][0] {
+		t.Error("Expected the resolved decision to be learned as zero reward")
+	}
+
+	if err := service.RecordReward(ctx, "user1", candidate.JobID, BanditEventApply); err != nil {
+		t.Fatalf("Reward failed: %v", err)
+	}
+	if after, _ := service.PredictReward(candidate.Context); after <= before {
+		t.Errorf("Expected the reward to be credited, before %f after %f", before, after)
+	}
+
+	stats := service.GetStats()
+	if stats.PendingCount != 1 || stats.RewardedCount != 1 || stats.TotalDecisions != 2 {
+		t.Errorf("Expected one pending rewarded decision out of two, got %+v", stats)
+	}
+	if first[0].ID == second[0].ID {
+		t.Error("Expected distinct decision IDs")
+	}
+}
+
+type memoryBanditDecisionStore struct {
+	decisions map[string]*models.BanditDecision
+}
+
+func (s *memoryBanditDecisionStore) SaveBanditDecisions(ctx context.Context, decisions []*models.BanditDecision) error {
+	for _, decision := range decisions {
+		s.decisions[decision.ID] = decision
+	}
+	return nil
+}
+
+func (s *memoryBanditDecisionStore) RecordBanditReward(ctx context.Context, decision *models.BanditDecision) error {
+	s.decisions[decision.ID] = decision
+	return nil
+}
+
+func (s *memoryBanditDecisionStore) ListBanditDecisions(ctx context.Context, since time.Time, limit int) ([]*models.BanditDecision, error) {
+	var decisions []*models.BanditDecision
+	for _, decision := range s.decisions {
+		decisions = append(decisions, decision)
+	}
+	return decisions, nil
+}
+
+func TestBanditService_LogsDecisionsAndRewardsToStore(t *testing.T) {
+	store := &memoryBanditDecisionStore{decisions: make(map[string]*models.BanditDecision)}
+	service, err := NewBanditService(&models.BanditConfig{}, store)
+	if err != nil {
+		t.Fatalf("NewBanditService failed: %v", err)
+	}
+	ctx := context.Background()
+	candidates := newTestBanditCandidates(3)
+
+	decisions, err := service.SelectExploratory(ctx, "user1", candidates, 2)
+	if err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+	if err := service.RecordReward(ctx, "user1", decisions[0].JobID, BanditEventSave); err != nil {
+		t.Fatalf("Reward failed: %v", err)
+	}
+
+	logged, err := service.GetDecisionLog(ctx, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("GetDecisionLog failed: %v", err)
+	}
+	if len(logged) != 2 {
+		t.Fatalf("Expected 2 stored decisions, got %d", len(logged))
+	}
+	stored := store.decisions[decisions[0].ID]
+	if stored.RewardEvent != BanditEventSave || stored.Reward != 0.4 || stored.Propensity != decisions[0].Propensity || len(stored.Context) != BanditContextDim {
+		t.Errorf("Expected the stored decision to carry its context, propensity and reward, got %+v", stored)
+	}
+	if len(service.decisionLog) != 0 {
+		t.Errorf("Expected no in-memory log with a store, got %d decisions", len(service.decisionLog))
+	}
+}
+
+func TestHybridMatchingService_ExploratorySlots(t *testing.T) {
+	hybridService := createTestHybridService()
+	hybridService.SetBanditService(newTestBanditService(t, &models.BanditConfig{ExplorationSlots: 2}))
+	ctx := context.Background()
+
+	matches, err := hybridService.FindBestMatches(ctx, "test_user", 5)
+	if err != nil {
+		t.Fatalf("FindBestMatches failed: %v", err)
+	}
+
+	if len(matches) != 5 {
+		t.Fatalf("Expected 5 matches, got %d", len(matches))
+	}
+
+	explored := 0
+	for _, match := range matches {
+		if match.Exploration != nil {
+			explored++
+			if err := hybridService.RecordExplorationReward(ctx, "test_user", match.JobID, BanditEventSave); err != nil {
+				t.Errorf("RecordExplorationReward failed: %v", err)
+			}
+		}
+	}
+
+	if explored != 2 {
+		t.Errorf("Expected 2 exploratory matches, got %d", explored)
+	}
+}
@@ -0,0 +1,563 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"microbridge/backend/internal/ai/models"
+	"microbridge/backend/internal/core/matching"
+	coreModels "microbridge/backend/internal/models"
+)
+
+// Batch job statuses
+const (
+	BatchStatusQueued    = "queued"
+	BatchStatusRunning   = "running"
+	BatchStatusCompleted = "completed"
+	BatchStatusFailed    = "failed"
+	BatchStatusCancelled = "cancelled"
+)
+
+const (
+	defaultBatchWorkers      = 8
+	defaultBatchTimeout      = 5 * time.Minute
+	defaultMaxBatchSize      = 100000
+	defaultBatchJobRetention = 24 * time.Hour
+)
+
+var (
+	ErrBatchTooLarge    = errors.New("batch exceeds maximum size")
+	ErrBatchEmpty       = errors.New("batch contains no requests")
+	ErrBatchJobNotFound = errors.New("batch job not found")
+	ErrBatchNotFinished = errors.New("batch job has not finished")
+)
+
+// BatchEntityLoader loads the users and jobs referenced by batch requests
+type BatchEntityLoader interface {
+	LoadUser(ctx context.Context, userID string) (*coreModels.User, error)
+	LoadJob(ctx context.Context, jobID string) (*coreModels.Job, error)
+}
+
+type userByIDGetter interface {
+	GetByID(ctx context.Context, id string) (*coreModels.User, error)
+}
+
+type jobByIDGetter interface {
+	GetByID(ctx context.Context, id string) (*coreModels.Job, error)
+}
+
+// repositoryEntityLoader adapts the user and job repositories to BatchEntityLoader
+type repositoryEntityLoader struct {
+	users userByIDGetter
+	jobs  jobByIDGetter
+}
+
+// NewRepositoryEntityLoader creates a BatchEntityLoader backed by user and job repositories
+func NewRepositoryEntityLoader(users userByIDGetter, jobs jobByIDGetter) BatchEntityLoader {
+	return &repositoryEntityLoader{users: users, jobs: jobs}
+}
+
+func (l *repositoryEntityLoader) LoadUser(ctx context.Context, userID string) (*coreModels.User, error) {
+	return l.users.GetByID(ctx, userID)
+}
+
+func (l *repositoryEntityLoader) LoadJob(ctx context.Context, jobID string) (*coreModels.Job, error) {
+	return l.jobs.GetByID(ctx, jobID)
+}
+
+// BatchInferenceService scores large sets of user-job pairs with NCF, GNN and the basic algorithm
+type BatchInferenceService struct {
+	mu            sync.RWMutex
+	hybridService *HybridMatchingService
+	loader        BatchEntityLoader
+	jobs          map[string]*BatchJob
+	retention     time.Duration
+}
+
+// BatchJob tracks an asynchronous batch inference run
+type BatchJob struct {
+	ID          string                       `json:"id"`
+	Status      string                       `json:"status"`
+	Total       int                          `json:"total"`
+	Progress    float64                      `json:"progress"`
+	Error       string                       `json:"error,omitempty"`
+	SubmittedBy string                       `json:"submitted_by,omitempty"`
+	SubmittedAt time.Time                    `json:"submitted_at"`
+	StartedAt   *time.Time                   `json:"started_at,omitempty"`
+	CompletedAt *time.Time                   `json:"completed_at,omitempty"`
+	Result      *models.BatchInferenceResult `json:"-"`
+	cancel      context.CancelFunc
+}
+
+// batchEntities holds users and jobs loaded once per batch
+type batchEntities struct {
+	users     map[string]*coreModels.User
+	jobs      map[string]*coreModels.Job
+	userError map[string]error
+	jobError  map[string]error
+}
+
+// NewBatchInferenceService creates a new batch inference service
+func NewBatchInferenceService(hybridService *HybridMatchingService, loader BatchEntityLoader) *BatchInferenceService {
+	return &BatchInferenceService{
+		hybridService: hybridService,
+		loader:        loader,
+		jobs:          make(map[string]*BatchJob),
+		retention:     defaultBatchJobRetention,
+	}
+}
+
+// NewPairBatchRequest builds a batch request for explicit user-job pairs
+func NewPairBatchRequest(pairs [][2]string, modelType string, options models.BatchInferenceOptions) *models.BatchInferenceRequest {
+	now := time.Now()
+	requests := make([]*models.InferenceRequest, 0, len(pairs))
+	for _, pair := range pairs {
+		requests = append(requests, &models.InferenceRequest{
+			UserID:      pair[0],
+			JobID:       pair[1],
+			ModelType:   modelType,
+			RequestedAt: now,
+		})
+	}
+
+	return &models.BatchInferenceRequest{
+		BatchID:   uuid.New().String(),
+		Requests:  requests,
+		Options:   options,
+		CreatedAt: now,
+	}
+}
+
+// NewCrossBatchRequest builds a batch request for every combination of the given users
+// and jobs. The size is checked before the pairs are built, so an oversized cross
+// product is rejected without allocating it.
+func NewCrossBatchRequest(userIDs, jobIDs []string, modelType string, options models.BatchInferenceOptions) (*models.BatchInferenceRequest, error) {
+	maxSize := options.MaxBatchSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxBatchSize
+	}
+	if size := int64(len(userIDs)) * int64(len(jobIDs)); size > int64(maxSize) {
+		return nil, fmt.Errorf("%w: %d requests, limit %d", ErrBatchTooLarge, size, maxSize)
+	}
+
+	pairs := make([][2]string, 0, len(userIDs)*len(jobIDs))
+	for _, userID := range userIDs {
+		for _, jobID := range jobIDs {
+			pairs = append(pairs, [2]string{userID, jobID})
+		}
+	}
+	return NewPairBatchRequest(pairs, modelType, options), nil
+}
+
+// BatchRequestKey returns the key used for a request in BatchInferenceResult maps
+func BatchRequestKey(userID, jobID string) string {
+	return userID + ":" + jobID
+}
+
+// RunBatch scores a batch in-process. Items that fail or miss the deadline are reported
+// in the result's Errors map; the call itself only fails for invalid requests.
+func (s *BatchInferenceService) RunBatch(ctx context.Context, request *models.BatchInferenceRequest) (*models.BatchInferenceResult, error) {
+	if err := s.validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	options := s.normalizeOptions(request.Options)
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	result := &models.BatchInferenceResult{
+		BatchID: request.BatchID,
+		Results: make(map[string]*models.InferenceResult),
+		Errors:  make(map[string]string),
+	}
+
+	entities := s.loadEntities(ctx, request.Requests)
+
+	var resultMu sync.Mutex
+	record := func(key string, inference *models.InferenceResult, err error) {
+		resultMu.Lock()
+		defer resultMu.Unlock()
+
+		if _, done := result.Results[key]; done {
+			return
+		}
+		if _, done := result.Errors[key]; done {
+			return
+		}
+
+		result.ProcessedCount++
+		if err != nil {
+			result.Errors[key] = err.Error()
+			result.ErrorCount++
+		} else {
+			result.Results[key] = inference
+			result.SuccessCount++
+		}
+
+		if options.ProgressCallback != nil {
+			options.ProgressCallback(float64(result.ProcessedCount) / float64(len(request.Requests)))
+		}
+	}
+
+	work := make(chan *models.InferenceRequest)
+	var wg sync.WaitGroup
+	for i := 0; i < options.ParallelWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				inference, err := s.scoreWithRetry(ctx, item, entities, options)
+				record(BatchRequestKey(item.UserID, item.JobID), inference, err)
+			}
+		}()
+	}
+
+dispatch:
+	for _, item := range request.Requests {
+		select {
+		case work <- item:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	// Anything not dispatched before the deadline is reported as failed
+	for _, item := range request.Requests {
+		record(BatchRequestKey(item.UserID, item.JobID), nil, fmt.Errorf("not processed: %w", ctx.Err()))
+	}
+
+	result.ProcessingTime = time.Since(startTime)
+	result.CompletedAt = time.Now()
+
+	return result, nil
+}
+
+// SubmitBatch queues a batch to run in the background and returns its job handle
+func (s *BatchInferenceService) SubmitBatch(request *models.BatchInferenceRequest, submittedBy string) (*BatchJob, error) {
+	if err := s.validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &BatchJob{
+		ID:          request.BatchID,
+		Status:      BatchStatusQueued,
+		Total:       len(request.Requests),
+		SubmittedBy: submittedBy,
+		SubmittedAt: time.Now(),
+		cancel:      cancel,
+	}
+
+	s.mu.Lock()
+	s.purgeExpiredJobs()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	userCallback := request.Options.ProgressCallback
+	request.Options.ProgressCallback = func(progress float64) {
+		s.mu.Lock()
+		job.Progress = progress
+		s.mu.Unlock()
+		if userCallback != nil {
+			userCallback(progress)
+		}
+	}
+
+	go s.runJob(ctx, job, request)
+
+	return s.snapshot(job), nil
+}
+
+// GetBatchJob returns the current status of an asynchronous batch
+func (s *BatchInferenceService) GetBatchJob(batchID string) (*BatchJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, exists := s.jobs[batchID]
+	if !exists {
+		return nil, ErrBatchJobNotFound
+	}
+	return s.snapshot(job), nil
+}
+
+// GetBatchResult returns the results of a finished asynchronous batch
+func (s *BatchInferenceService) GetBatchResult(batchID string) (*models.BatchInferenceResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, exists := s.jobs[batchID]
+	if !exists {
+		return nil, ErrBatchJobNotFound
+	}
+	if job.Result == nil {
+		return nil, ErrBatchNotFinished
+	}
+	return job.Result, nil
+}
+
+// CancelBatch stops a queued or running asynchronous batch
+func (s *BatchInferenceService) CancelBatch(batchID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[batchID]
+	if !exists {
+		return ErrBatchJobNotFound
+	}
+	if job.Status == BatchStatusQueued || job.Status == BatchStatusRunning {
+		job.Status = BatchStatusCancelled
+		job.cancel()
+	}
+	return nil
+}
+
+// Private methods
+
+func (s *BatchInferenceService) runJob(ctx context.Context, job *BatchJob, request *models.BatchInferenceRequest) {
+	defer job.cancel()
+
+	s.mu.Lock()
+	if job.Status == BatchStatusCancelled {
+		s.mu.Unlock()
+		return
+	}
+	startedAt := time.Now()
+	job.Status = BatchStatusRunning
+	job.StartedAt = &startedAt
+	s.mu.Unlock()
+
+	result, err := s.RunBatch(ctx, request)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	job.Result = result
+
+	switch {
+	case job.Status == BatchStatusCancelled:
+	case err != nil:
+		job.Status = BatchStatusFailed
+		job.Error = err.Error()
+	default:
+		job.Status = BatchStatusCompleted
+		job.Progress = 1.0
+	}
+}
+
+func (s *BatchInferenceService) scoreWithRetry(ctx context.Context, request *models.InferenceRequest, entities *batchEntities, options models.BatchInferenceOptions) (*models.InferenceResult, error) {
+	attempts := 1
+	if options.RetryFailedItems {
+		attempts += options.MaxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := s.scoreItem(ctx, request, entities)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+func (s *BatchInferenceService) scoreItem(ctx context.Context, request *models.InferenceRequest, entities *batchEntities) (*models.InferenceResult, error) {
+	startTime := time.Now()
+
+	user, job, err := entities.lookup(request.UserID, request.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	weights, err := s.weightsForModel(request.UserID, request.ModelType)
+	if err != nil {
+		return nil, err
+	}
+
+	hybrid := s.hybridService
+	scores := make(map[string]float64)
+	var basicScore *matching.MatchScore
+
+	if weights["basic"] > 0 && hybrid.basicAlgorithm != nil {
+		basicScore = hybrid.basicAlgorithm.CalculateMatchScore(user, job)
+		scores["basic"] = hybrid.getBasicScore(basicScore)
+	}
+
+	if weights["ncf"] > 0 && hybrid.ncfService != nil {
+		ncfScore, err := hybrid.ncfService.PredictUserJobInteraction(ctx, user.ID, job.ID)
+		if err != nil {
+			hybrid.recordModelError("ncf")
+			scores["ncf"] = -1 // Excluded from the ensemble
+		} else {
+			scores["ncf"] = ncfScore
+		}
+	}
+
+	if weights["gnn"] > 0 && hybrid.gnnService != nil {
+		gnnScore, err := hybrid.calculateGNNSkillAlignment(ctx, user, job)
+		if err != nil {
+			hybrid.recordModelError("gnn")
+			scores["gnn"] = -1
+		} else {
+			scores["gnn"] = gnnScore
+		}
+	}
+
+	finalScore, confidence := hybrid.ensembleScores(scores, weights)
+	if confidence == 0 {
+		return nil, fmt.Errorf("no model produced a score for %s", BatchRequestKey(user.ID, job.ID))
+	}
+
+	features := make(map[string]float64, len(scores))
+	for model, score := range scores {
+		features[model+"_score"] = score
+	}
+
+	return &models.InferenceResult{
+		RequestID:          BatchRequestKey(user.ID, job.ID),
+		UserID:             user.ID,
+		JobID:              job.ID,
+		Score:              finalScore,
+		ConfidenceLevel:    confidence,
+		SuccessProbability: hybrid.calculateSuccessProbability(finalScore, confidence, basicScore),
+		ModelUsed:          hybrid.getPrimaryModel(weights),
+		Features:           features,
+		ProcessingTime:     time.Since(startTime),
+		CreatedAt:          time.Now(),
+	}, nil
+}
+
+// weightsForModel returns ensemble weights for the requested model type.
+// RL is excluded from batch scoring because its state depends on a live session.
+func (s *BatchInferenceService) weightsForModel(userID, modelType string) (map[string]float64, error) {
+	switch modelType {
+	case "basic", "ncf", "gnn":
+		return map[string]float64{modelType: 1.0}, nil
+	case "", "hybrid":
+		s.hybridService.mu.RLock()
+		defer s.hybridService.mu.RUnlock()
+
+		userWeights := s.hybridService.getWeightsForUser(userID)
+		return map[string]float64{
+			"basic": userWeights["basic"],
+			"ncf":   userWeights["ncf"],
+			"gnn":   userWeights["gnn"],
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported model type: %s", modelType)
+	}
+}
+
+func (s *BatchInferenceService) loadEntities(ctx context.Context, requests []*models.InferenceRequest) *batchEntities {
+	entities := &batchEntities{
+		users:     make(map[string]*coreModels.User),
+		jobs:      make(map[string]*coreModels.Job),
+		userError: make(map[string]error),
+		jobError:  make(map[string]error),
+	}
+
+	for _, request := range requests {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if _, seen := entities.users[request.UserID]; !seen && entities.userError[request.UserID] == nil {
+			user, err := s.loader.LoadUser(ctx, request.UserID)
+			if err != nil {
+				entities.userError[request.UserID] = fmt.Errorf("failed to load user %s: %w", request.UserID, err)
+			} else {
+				entities.users[request.UserID] = user
+			}
+		}
+
+		if _, seen := entities.jobs[request.JobID]; !seen && entities.jobError[request.JobID] == nil {
+			job, err := s.loader.LoadJob(ctx, request.JobID)
+			if err != nil {
+				entities.jobError[request.JobID] = fmt.Errorf("failed to load job %s: %w", request.JobID, err)
+			} else {
+				entities.jobs[request.JobID] = job
+			}
+		}
+	}
+
+	return entities
+}
+
+func (e *batchEntities) lookup(userID, jobID string) (*coreModels.User, *coreModels.Job, error) {
+	if err := e.userError[userID]; err != nil {
+		return nil, nil, err
+	}
+	if err := e.jobError[jobID]; err != nil {
+		return nil, nil, err
+	}
+
+	user, userExists := e.users[userID]
+	job, jobExists := e.jobs[jobID]
+	if !userExists || !jobExists {
+		return nil, nil, fmt.Errorf("entities for %s were not loaded", BatchRequestKey(userID, jobID))
+	}
+	return user, job, nil
+}
+
+func (s *BatchInferenceService) validateRequest(request *models.BatchInferenceRequest) error {
+	if request == nil || len(request.Requests) == 0 {
+		return ErrBatchEmpty
+	}
+
+	maxSize := request.Options.MaxBatchSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxBatchSize
+	}
+	if len(request.Requests) > maxSize {
+		return fmt.Errorf("%w: %d requests, limit %d", ErrBatchTooLarge, len(request.Requests), maxSize)
+	}
+
+	if request.BatchID == "" {
+		request.BatchID = uuid.New().String()
+	}
+	return nil
+}
+
+func (s *BatchInferenceService) normalizeOptions(options models.BatchInferenceOptions) models.BatchInferenceOptions {
+	if options.ParallelWorkers <= 0 {
+		options.ParallelWorkers = defaultBatchWorkers
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultBatchTimeout
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+	return options
+}
+
+func (s *BatchInferenceService) purgeExpiredJobs() {
+	for id, job := range s.jobs {
+		if job.CompletedAt != nil && time.Since(*job.CompletedAt) > s.retention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *BatchInferenceService) snapshot(job *BatchJob) *BatchJob {
+	copied := *job
+	copied.Result = nil
+	copied.cancel = nil
+	return &copied
+}
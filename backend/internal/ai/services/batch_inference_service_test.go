@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/ai/models"
+	coreModels "microbridge/backend/internal/models"
+)
+
+type mockEntityLoader struct {
+	delay time.Duration
+}
+
+func (l *mockEntityLoader) LoadUser(ctx context.Context, userID string) (*coreModels.User, error) {
+	if userID == "missing_user" {
+		return nil, fmt.Errorf("user not found")
+	}
+	user, _ := (&HybridMatchingService{}).getMockUserAndJobData(userID)
+	return user, nil
+}
+
+func (l *mockEntityLoader) LoadJob(ctx context.Context, jobID string) (*coreModels.Job, error) {
+	time.Sleep(l.delay)
+	_, job := (&HybridMatchingService{}).getMockUserAndJobData("")
+	job.ID = jobID
+	return job, nil
+}
+
+func TestBatchInferenceService_RunBatch(t *testing.T) {
+	service := NewBatchInferenceService(createTestHybridService(), &mockEntityLoader{})
+	ctx := context.Background()
+
+	request, err := NewCrossBatchRequest(
+		[]string{"user1", "user2", "missing_user"},
+		[]string{"job1", "job2"},
+		"hybrid",
+		models.BatchInferenceOptions{ParallelWorkers: 2},
+	)
+	if err != nil {
+		t.Fatalf("NewCrossBatchRequest failed: %v", err)
+	}
+
+	result, err := service.RunBatch(ctx, request)
+	if err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+
+	if result.ProcessedCount != 6 {
+		t.Errorf("Expected 6 processed items, got %d", result.ProcessedCount)
+	}
+	if result.SuccessCount != 4 || result.ErrorCount != 2 {
+		t.Errorf("Expected 4 successes and 2 errors, got %d and %d", result.SuccessCount, result.ErrorCount)
+	}
+	if _, exists := result.Errors[BatchRequestKey("missing_user", "job1")]; !exists {
+		t.Error("Expected error for missing user")
+	}
+
+	for key, inference := range result.Results {
+		if inference.Score < 0 || inference.Score > 1 {
+			t.Errorf("Score for %s should be between 0 and 1, got %f", key, inference.Score)
+		}
+	}
+
+	// Single-model requests only use that model
+	basic, err := service.RunBatch(ctx, NewPairBatchRequest([][2]string{{"user1", "job1"}}, "basic", models.BatchInferenceOptions{}))
+	if err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+	if inference := basic.Results[BatchRequestKey("user1", "job1")]; inference == nil || len(inference.Features) != 1 {
+		t.Errorf("Expected a basic-only result, got %+v", inference)
+	}
+
+	if _, err := service.RunBatch(ctx, NewPairBatchRequest(nil, "", models.BatchInferenceOptions{})); err == nil {
+		t.Error("Expected error for empty batch")
+	}
+	if _, err := service.RunBatch(ctx, NewPairBatchRequest([][2]string{{"a", "b"}, {"c", "d"}}, "", models.BatchInferenceOptions{MaxBatchSize: 1})); err == nil {
+		t.Error("Expected error for oversized batch")
+	}
+
+	// An oversized cross product is rejected before its pairs are built
+	users, jobs := make([]string, 1000), make([]string, 1000)
+	if _, err := NewCrossBatchRequest(users, jobs, "", models.BatchInferenceOptions{}); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("Expected ErrBatchTooLarge for a million pairs, got %v", err)
+	}
+}
+
+func TestBatchInferenceService_Deadline(t *testing.T) {
+	service := NewBatchInferenceService(createTestHybridService(), &mockEntityLoader{delay: 20 * time.Millisecond})
+
+	request, err := NewCrossBatchRequest(
+		[]string{"user1"},
+		[]string{"job1", "job2", "job3", "job4", "job5"},
+		"",
+		models.BatchInferenceOptions{Timeout: 30 * time.Millisecond},
+	)
+	if err != nil {
+		t.Fatalf("NewCrossBatchRequest failed: %v", err)
+	}
+
+	result, err := service.RunBatch(context.Background(), request)
+	if err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+
+	if result.ProcessedCount != 5 {
+		t.Errorf("Every item should be reported, got %d", result.ProcessedCount)
+	}
+	if result.ErrorCount == 0 {
+		t.Error("Expected items past the deadline to be reported as errors")
+	}
+}
+
+func TestBatchInferenceService_AsyncJob(t *testing.T) {
+	service := NewBatchInferenceService(createTestHybridService(), &mockEntityLoader{})
+
+	request, err := NewCrossBatchRequest([]string{"user1"}, []string{"job1", "job2"}, "", models.BatchInferenceOptions{})
+	if err != nil {
+		t.Fatalf("NewCrossBatchRequest failed: %v", err)
+	}
+	job, err := service.SubmitBatch(request, "admin")
+	if err != nil {
+		t.Fatalf("SubmitBatch failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		status, err := service.GetBatchJob(job.ID)
+		if err != nil {
+			t.Fatalf("GetBatchJob failed: %v", err)
+		}
+		if status.Status == BatchStatusCompleted {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Batch did not complete, status %s", status.Status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	result, err := service.GetBatchResult(job.ID)
+	if err != nil {
+		t.Fatalf("GetBatchResult failed: %v", err)
+	}
+	if result.SuccessCount != 2 {
+		t.Errorf("Expected 2 successful items, got %d", result.SuccessCount)
+	}
+
+	if _, err := service.GetBatchJob("unknown"); err != ErrBatchJobNotFound {
+		t.Errorf("Expected ErrBatchJobNotFound, got %v", err)
+	}
+}
+
+func TestHybridMatchingService_TrackerHelpersAreSafeForWorkers(t *testing.T) {
+	hybrid := createTestHybridService()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				hybrid.recordModelError("ncf")
+				hybrid.recordFallback()
+				hybrid.updateModelPerformance("gnn", 0.5, 0.5)
+				hybrid.GetModelPerformanceMetrics()
+			}
+		}()
+	}
+	wg.Wait()
+
+	metrics := hybrid.GetModelPerformanceMetrics()
+	if metrics.fallbackCount != 800 || metrics.modelPerformance["ncf"].ErrorCount != 800 || metrics.modelPerformance["gnn"].TotalPredictions != 800 {
+		t.Errorf("Expected 800 of each update, got %d fallbacks, %d errors and %d predictions",
+			metrics.fallbackCount, metrics.modelPerformance["ncf"].ErrorCount, metrics.modelPerformance["gnn"].TotalPredictions)
+	}
+}
@@ -0,0 +1,516 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/ai/models"
+)
+
+// Drift severities
+const (
+	DriftSeverityNone    = "none"
+	DriftSeverityWarning = "warning"
+	DriftSeverityAlert   = "alert"
+)
+
+// Score metrics tracked for every hybrid match; features are tracked as "feature:<name>"
+const (
+	DriftMetricFinalScore = "final_score"
+	DriftMetricBasicScore = "basic_score"
+	DriftMetricNCFScore   = "ncf_score"
+	DriftMetricGNNScore   = "gnn_score"
+	DriftMetricRLScore    = "rl_score"
+)
+
+// driftSmoothing keeps empty bins from producing infinite PSI and KL values
+const driftSmoothing = 1e-4
+
+// DriftMetricsRecorder exports drift statistics; *monitoring.Metrics implements it
+type DriftMetricsRecorder interface {
+	RecordModelDrift(metric, statistic string, value float64)
+	RecordDriftAlert(metric, severity string)
+}
+
+// DriftReferenceStore persists reference samples so a restart compares against the
+// same baseline instead of freezing whatever traffic follows it
+type DriftReferenceStore interface {
+	LoadDriftReferences(ctx context.Context) ([]*models.DriftReference, error)
+	SaveDriftReference(ctx context.Context, reference *models.DriftReference) error
+}
+
+// DriftNotifier delivers drift alerts to administrators
+type DriftNotifier interface {
+	NotifyDrift(ctx context.Context, alert *DriftAlert) error
+}
+
+// DriftResult is the comparison of one metric's rolling window against its reference
+type DriftResult struct {
+	Metric           string    `json:"metric"`
+	PSI              float64   `json:"psi"`
+	KLDivergence     float64   `json:"kl_divergence"`
+	Severity         string    `json:"severity"`
+	ReferenceSamples int       `json:"reference_samples"`
+	WindowSamples    int       `json:"window_samples"`
+	ReferenceMean    float64   `json:"reference_mean"`
+	WindowMean       float64   `json:"window_mean"`
+	EvaluatedAt      time.Time `json:"evaluated_at"`
+}
+
+// DriftAlert is raised when a metric crosses its alert threshold
+type DriftAlert struct {
+	Result     DriftResult            `json:"result"`
+	Thresholds models.DriftThresholds `json:"thresholds"`
+	RaisedAt   time.Time              `json:"raised_at"`
+}
+
+// DriftReport summarises the latest evaluation
+type DriftReport struct {
+	Results     []DriftResult `json:"results"`
+	Alerts      int           `json:"alerts"`
+	Warnings    int           `json:"warnings"`
+	EvaluatedAt time.Time     `json:"evaluated_at"`
+}
+
+// driftTracker holds the reference distribution and rolling window for one metric
+type driftTracker struct {
+	reference     []float64
+	edges         []float64 // Upper bin edges from reference quantiles; the last bin is open
+	refProportion []float64
+	refMean       float64
+	window        []float64
+	next          int
+	full          bool
+	lastAlert     time.Time
+	frozenAt      time.Time
+	unsaved       bool // Frozen since the reference was last persisted
+}
+
+// DriftMonitor compares recent model outputs and inputs against reference histograms
+type DriftMonitor struct {
+	mu         sync.Mutex
+	config     *models.DriftConfig
+	trackers   map[string]*driftTracker
+	metrics    DriftMetricsRecorder
+	notifier   DriftNotifier
+	store      DriftReferenceStore
+	lastReport *DriftReport
+	running    bool
+	stop       context.CancelFunc
+	loopGroup  sync.WaitGroup
+}
+
+// NewDriftMonitor creates a new drift monitor. metrics, notifier and store may be
+// nil; without a store references are rebuilt from live traffic after a restart.
+func NewDriftMonitor(config *models.DriftConfig, metrics DriftMetricsRecorder, notifier DriftNotifier, store DriftReferenceStore) *DriftMonitor {
+	if config.Bins <= 1 {
+		config.Bins = 10
+	}
+	if config.ReferenceSize <= 0 {
+		config.ReferenceSize = 1000
+	}
+	if config.WindowSize <= 0 {
+		config.WindowSize = 1000
+	}
+	if config.MinWindowSamples <= 0 {
+		config.MinWindowSamples = 200
+	}
+	if config.Thresholds.PSIWarning <= 0 {
+		config.Thresholds.PSIWarning = 0.1
+	}
+	if config.Thresholds.PSIAlert <= 0 {
+		config.Thresholds.PSIAlert = 0.25
+	}
+	if config.Thresholds.KLAlert <= 0 {
+		config.Thresholds.KLAlert = 0.2
+	}
+	if config.TrackedFeatures == nil {
+		config.TrackedFeatures = []string{"pair_skill_coverage", "pair_experience_gap", "user_skill_count", "job_skill_count"}
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = 5 * time.Minute
+	}
+	if config.AlertCooldown <= 0 {
+		config.AlertCooldown = time.Hour
+	}
+
+	return &DriftMonitor{
+		config:   config,
+		trackers: make(map[string]*driftTracker),
+		metrics:  metrics,
+		notifier: notifier,
+		store:    store,
+	}
+}
+
+// Load restores persisted references, so their metrics are compared from the first
+// window instead of re-freezing a reference from post-restart traffic
+func (m *DriftMonitor) Load(ctx context.Context) error {
+	if m.store == nil {
+		return nil
+	}
+
+	references, err := m.store.LoadDriftReferences(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load drift references: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, reference := range references {
+		if len(reference.Values) == 0 {
+			continue
+		}
+		tracker := m.tracker(reference.Metric)
+		tracker.reference = append(tracker.reference[:0], reference.Values...)
+		tracker.freezeReference(m.config.Bins, reference.CreatedAt)
+		tracker.unsaved = false
+	}
+	return nil
+}
+
+// Start evaluates drift every CheckInterval until Stop is called
+func (m *DriftMonitor) Start(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	m.stop = cancel
+	m.running = true
+
+	m.loopGroup.Add(1)
+	go func() {
+		defer m.loopGroup.Done()
+
+		ticker := time.NewTicker(m.config.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Evaluate(ctx)
+			}
+		}
+	}()
+}
+
+// Stop halts periodic evaluation and persists references frozen since the last one
+func (m *DriftMonitor) Stop() {
+	m.mu.Lock()
+	if !m.running {
+		m.mu.Unlock()
+		return
+	}
+	m.running = false
+	m.stop()
+	m.mu.Unlock()
+
+	m.loopGroup.Wait()
+	m.saveReferences(context.Background())
+}
+
+// ObserveMatch records the scores and tracked features of a hybrid match
+func (m *DriftMonitor) ObserveMatch(match *HybridMatchResult) {
+	values := map[string]float64{
+		DriftMetricFinalScore: match.FinalScore,
+		DriftMetricNCFScore:   match.NCFScore,
+		DriftMetricGNNScore:   match.GNNSkillAlignment,
+		DriftMetricRLScore:    match.RLRecommendationScore,
+	}
+	if match.BasicAlgorithmScore != nil {
+		values[DriftMetricBasicScore] = match.BasicAlgorithmScore.TotalScore
+	}
+
+	if vector := match.FeatureVector; vector != nil {
+		for _, name := range m.config.TrackedFeatures {
+			for _, group := range []map[string]float64{vector.Interactions, vector.UserFeatures, vector.JobFeatures} {
+				if value, exists := group[name]; exists {
+					values["feature:"+name] = value
+					break
+				}
+			}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for metric, value := range values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		m.tracker(metric).observe(value, m.config)
+	}
+}
+
+// Evaluate compares every metric's window with its reference, exports the
+// statistics and notifies on alerts
+func (m *DriftMonitor) Evaluate(ctx context.Context) *DriftReport {
+	now := time.Now()
+	report := &DriftReport{EvaluatedAt: now}
+	var alerts []*DriftAlert
+
+	m.mu.Lock()
+	metricNames := make([]string, 0, len(m.trackers))
+	for metric := range m.trackers {
+		metricNames = append(metricNames, metric)
+	}
+	sort.Strings(metricNames)
+
+	for _, metric := range metricNames {
+		tracker := m.trackers[metric]
+		result, ok := tracker.compare(metric, m.config.MinWindowSamples)
+		if !ok {
+			continue
+		}
+		result.EvaluatedAt = now
+
+		thresholds := m.thresholdsFor(metric)
+		result.Severity = classifyDrift(result, thresholds)
+
+		switch result.Severity {
+		case DriftSeverityAlert:
+			report.Alerts++
+			if now.Sub(tracker.lastAlert) >= m.config.AlertCooldown {
+				tracker.lastAlert = now
+				alerts = append(alerts, &DriftAlert{Result: *result, Thresholds: thresholds, RaisedAt: now})
+			}
+		case DriftSeverityWarning:
+			report.Warnings++
+		}
+
+		report.Results = append(report.Results, *result)
+	}
+	m.lastReport = report
+	m.mu.Unlock()
+
+	m.saveReferences(ctx)
+
+	if m.metrics != nil {
+		for _, result := range report.Results {
+			m.metrics.RecordModelDrift(result.Metric, "psi", result.PSI)
+			m.metrics.RecordModelDrift(result.Metric, "kl_divergence", result.KLDivergence)
+			if result.Severity != DriftSeverityNone {
+				m.metrics.RecordDriftAlert(result.Metric, result.Severity)
+			}
+		}
+	}
+
+	if m.notifier != nil {
+		for _, alert := range alerts {
+			if err := m.notifier.NotifyDrift(ctx, alert); err != nil {
+				fmt.Printf("Failed to send drift alert for %s: %v\n", alert.Result.Metric, err)
+			}
+		}
+	}
+
+	return report
+}
+
+// GetLastReport returns the most recent evaluation, or nil before the first one
+func (m *DriftMonitor) GetLastReport() *DriftReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastReport
+}
+
+// Rebaseline replaces each reference with its current window, for use after an
+// intended change such as a reviewed retrain. An empty metric rebaselines all.
+func (m *DriftMonitor) Rebaseline(metric string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, tracker := range m.trackers {
+		if metric != "" && name != metric {
+			continue
+		}
+		tracker.rebaseline(m.config.Bins)
+	}
+}
+
+// saveReferences persists references frozen since they were last saved. Writes
+// happen outside the lock; a failed write is retried on the next evaluation.
+func (m *DriftMonitor) saveReferences(ctx context.Context) {
+	if m.store == nil {
+		return
+	}
+
+	m.mu.Lock()
+	var pending []*models.DriftReference
+	for metric, tracker := range m.trackers {
+		if !tracker.unsaved {
+			continue
+		}
+		tracker.unsaved = false
+		pending = append(pending, &models.DriftReference{
+			Metric:    metric,
+			Values:    append([]float64(nil), tracker.reference...),
+			CreatedAt: tracker.frozenAt,
+		})
+	}
+	m.mu.Unlock()
+
+	for _, reference := range pending {
+		if err := m.store.SaveDriftReference(ctx, reference); err != nil {
+			fmt.Printf("Failed to save drift reference for %s: %v\n", reference.Metric, err)
+			m.mu.Lock()
+			if tracker := m.trackers[reference.Metric]; tracker != nil && tracker.frozenAt.Equal(reference.CreatedAt) {
+				tracker.unsaved = true
+			}
+			m.mu.Unlock()
+		}
+	}
+}
+
+// Private methods
+
+func (m *DriftMonitor) tracker(metric string) *driftTracker {
+	tracker, exists := m.trackers[metric]
+	if !exists {
+		tracker = &driftTracker{
+			reference: make([]float64, 0, m.config.ReferenceSize),
+			window:    make([]float64, m.config.WindowSize),
+		}
+		m.trackers[metric] = tracker
+	}
+	return tracker
+}
+
+func (m *DriftMonitor) thresholdsFor(metric string) models.DriftThresholds {
+	if thresholds, exists := m.config.MetricThresholds[metric]; exists {
+		return thresholds
+	}
+	return m.config.Thresholds
+}
+
+func classifyDrift(result *DriftResult, thresholds models.DriftThresholds) string {
+	switch {
+	case result.PSI >= thresholds.PSIAlert || (thresholds.KLAlert > 0 && result.KLDivergence >= thresholds.KLAlert):
+		return DriftSeverityAlert
+	case result.PSI >= thresholds.PSIWarning:
+		return DriftSeverityWarning
+	default:
+		return DriftSeverityNone
+	}
+}
+
+// observe fills the reference first, then feeds the rolling window
+func (t *driftTracker) observe(value float64, config *models.DriftConfig) {
+	if t.edges == nil {
+		t.reference = append(t.reference, value)
+		if len(t.reference) >= config.ReferenceSize {
+			t.freezeReference(config.Bins, time.Now())
+			t.unsaved = true
+		}
+		return
+	}
+
+	t.window[t.next] = value
+	t.next = (t.next + 1) % len(t.window)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+func (t *driftTracker) windowValues() []float64 {
+	if t.full {
+		return t.window
+	}
+	return t.window[:t.next]
+}
+
+// freezeReference bins the reference sample at its quantiles so each bin holds
+// roughly equal mass; ties collapse duplicate edges
+func (t *driftTracker) freezeReference(bins int, frozenAt time.Time) {
+	sorted := append([]float64(nil), t.reference...)
+	sort.Float64s(sorted)
+
+	edges := make([]float64, 0, bins-1)
+	for i := 1; i < bins; i++ {
+		edge := sorted[i*len(sorted)/bins]
+		if len(edges) == 0 || edge > edges[len(edges)-1] {
+			edges = append(edges, edge)
+		}
+	}
+
+	t.edges = edges
+	t.refProportion = driftHistogram(t.reference, edges)
+	t.refMean = driftMean(t.reference)
+	t.frozenAt = frozenAt
+}
+
+func (t *driftTracker) rebaseline(bins int) {
+	window := t.windowValues()
+	if len(window) == 0 {
+		return
+	}
+	t.reference = append(t.reference[:0], window...)
+	t.freezeReference(bins, time.Now())
+	t.unsaved = true
+	t.next = 0
+	t.full = false
+}
+
+func (t *driftTracker) compare(metric string, minSamples int) (*DriftResult, bool) {
+	window := t.windowValues()
+	if t.edges == nil || len(window) < minSamples {
+		return nil, false
+	}
+
+	current := driftHistogram(window, t.edges)
+	psi, kl := 0.0, 0.0
+	for i := range current {
+		ref := t.refProportion[i]
+		cur := current[i]
+		psi += (cur - ref) * math.Log(cur/ref)
+		kl += cur * math.Log(cur/ref)
+	}
+
+	return &DriftResult{
+		Metric:           metric,
+		PSI:              psi,
+		KLDivergence:     kl,
+		ReferenceSamples: len(t.reference),
+		WindowSamples:    len(window),
+		ReferenceMean:    t.refMean,
+		WindowMean:       driftMean(window),
+	}, true
+}
+
+// Utility functions
+
+// driftHistogram returns smoothed bin proportions for values; bin i holds values <= edges[i]
+func driftHistogram(values []float64, edges []float64) []float64 {
+	counts := make([]float64, len(edges)+1)
+	for _, value := range values {
+		counts[sort.SearchFloat64s(edges, value)]++
+	}
+
+	total := float64(len(values)) + driftSmoothing*float64(len(counts))
+	for i := range counts {
+		counts[i] = (counts[i] + driftSmoothing) / total
+	}
+	return counts
+}
+
+func driftMean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/ai/models"
+)
+
+type recordingDriftNotifier struct {
+	alerts []*DriftAlert
+}
+
+func (n *recordingDriftNotifier) NotifyDrift(ctx context.Context, alert *DriftAlert) error {
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+type recordingDriftMetrics struct {
+	values map[string]float64
+}
+
+func (r *recordingDriftMetrics) RecordModelDrift(metric, statistic string, value float64) {
+	r.values[metric+"/"+statistic] = value
+}
+
+func (r *recordingDriftMetrics) RecordDriftAlert(metric, severity string) {}
+
+type memoryDriftReferenceStore struct {
+	references map[string]*models.DriftReference
+}
+
+func (s *memoryDriftReferenceStore) LoadDriftReferences(ctx context.Context) ([]*models.DriftReference, error) {
+	references := make([]*models.DriftReference, 0, len(s.references))
+	for _, reference := range s.references {
+		references = append(references, reference)
+	}
+	return references, nil
+}
+
+func (s *memoryDriftReferenceStore) SaveDriftReference(ctx context.Context, reference *models.DriftReference) error {
+	s.references[reference.Metric] = reference
+	return nil
+}
+
+func observeScores(monitor *DriftMonitor, rng *rand.Rand, n int, mean float64) {
+	for i := 0; i < n; i++ {
+		monitor.ObserveMatch(&HybridMatchResult{FinalScore: mean + rng.NormFloat64()*0.05})
+	}
+}
+
+func TestDriftMonitor_StableDistribution(t *testing.T) {
+	metrics := &recordingDriftMetrics{values: make(map[string]float64)}
+	monitor := NewDriftMonitor(&models.DriftConfig{ReferenceSize: 500, WindowSize: 500, MinWindowSamples: 100}, metrics, nil, nil)
+	rng := rand.New(rand.NewSource(1))
+
+	// Not enough window samples yet
+	observeScores(monitor, rng, 550, 0.6)
+	if report := monitor.Evaluate(context.Background()); len(report.Results) != 0 {
+		t.Errorf("Expected no results before MinWindowSamples, got %d", len(report.Results))
+	}
+
+	observeScores(monitor, rng, 450, 0.6)
+	report := monitor.Evaluate(context.Background())
+
+	var final *DriftResult
+	for i := range report.Results {
+		if report.Results[i].Metric == DriftMetricFinalScore {
+			final = &report.Results[i]
+		}
+	}
+	if final == nil {
+		t.Fatal("Expected a final_score result")
+	}
+	if final.Severity != DriftSeverityNone {
+		t.Errorf("Expected no drift for identical distributions, got %s (PSI %f)", final.Severity, final.PSI)
+	}
+	if _, exists := metrics.values["final_score/psi"]; !exists {
+		t.Error("Expected PSI to be exported")
+	}
+}
+
+func TestDriftMonitor_ShiftRaisesAlert(t *testing.T) {
+	notifier := &recordingDriftNotifier{}
+	monitor := NewDriftMonitor(&models.DriftConfig{ReferenceSize: 500, WindowSize: 500, MinWindowSamples: 100}, nil, notifier, nil)
+	rng := rand.New(rand.NewSource(2))
+
+	observeScores(monitor, rng, 500, 0.6)
+	observeScores(monitor, rng, 500, 0.4) // e.g. a bad retrain lowers every score
+
+	report := monitor.Evaluate(context.Background())
+	if report.Alerts == 0 {
+		t.Fatal("Expected a drift alert after the score distribution shifted")
+	}
+	if len(notifier.alerts) != 1 || notifier.alerts[0].Result.Metric != DriftMetricFinalScore {
+		t.Fatalf("Expected one final_score notification, got %d", len(notifier.alerts))
+	}
+
+	// Cooldown suppresses repeated notifications
+	monitor.Evaluate(context.Background())
+	if len(notifier.alerts) != 1 {
+		t.Errorf("Expected cooldown to suppress repeat alerts, got %d", len(notifier.alerts))
+	}
+
+	// Rebaselining accepts the new distribution
+	monitor.Rebaseline("")
+	observeScores(monitor, rng, 500, 0.4)
+	if report := monitor.Evaluate(context.Background()); report.Alerts != 0 {
+		t.Errorf("Expected no alerts after rebaseline, got %d", report.Alerts)
+	}
+}
+
+func TestDriftMonitor_PerMetricThresholds(t *testing.T) {
+	monitor := NewDriftMonitor(&models.DriftConfig{
+		ReferenceSize:    200,
+		WindowSize:       200,
+		MinWindowSamples: 50,
+		AlertCooldown:    time.Nanosecond,
+		MetricThresholds: map[string]models.DriftThresholds{
+			DriftMetricFinalScore: {PSIWarning: 100, PSIAlert: 200, KLAlert: 200},
+		},
+	}, nil, nil, nil)
+	rng := rand.New(rand.NewSource(3))
+
+	observeScores(monitor, rng, 200, 0.6)
+	observeScores(monitor, rng, 200, 0.3)
+
+	for _, result := range monitor.Evaluate(context.Background()).Results {
+		if result.Metric == DriftMetricFinalScore && result.Severity != DriftSeverityNone {
+			t.Errorf("Expected override thresholds to suppress final_score drift, got %s", result.Severity)
+		}
+	}
+}
+
+func TestDriftMonitor_ReferenceSurvivesRestart(t *testing.T) {
+	store := &memoryDriftReferenceStore{references: make(map[string]*models.DriftReference)}
+	config := &models.DriftConfig{ReferenceSize: 500, WindowSize: 500, MinWindowSamples: 100}
+	rng := rand.New(rand.NewSource(4))
+
+	first := NewDriftMonitor(config, nil, nil, store)
+	observeScores(first, rng, 500, 0.6)
+	first.Evaluate(context.Background())
+
+	if _, exists := store.references[DriftMetricFinalScore]; !exists {
+		t.Fatal("Expected the frozen reference to be saved")
+	}
+
+	// Shifted traffic right after a restart must be compared against the saved
+	// reference, not frozen as a new one
+	notifier := &recordingDriftNotifier{}
+	restarted := NewDriftMonitor(config, nil, notifier, store)
+	if err := restarted.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	observeScores(restarted, rng, 500, 0.3)
+
+	found := false
+	for _, result := range restarted.Evaluate(context.Background()).Results {
+		if result.Metric == DriftMetricFinalScore {
+			found = true
+			if result.Severity != DriftSeverityAlert {
+				t.Errorf("Expected alert after restart, got %s", result.Severity)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("Expected final_score to be compared from the first window after restart")
+	}
+	if len(notifier.alerts) == 0 {
+		t.Error("Expected a drift notification after restart")
+	}
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/ai/models"
+	coreModels "microbridge/backend/internal/models"
+)
+
+// Lifecycle segments. Category segments nest under these as "<lifecycle>/<category>".
+const (
+	SegmentNewStudent    = "new"
+	SegmentActiveStudent = "active"
+)
+
+// ErrUnknownSegment is returned when resetting a segment that has no learned weights
+var ErrUnknownSegment = errors.New("no learned weights for segment")
+
+// EnsembleWeightStore persists learned segment weights across restarts. Every
+// instance updates the same segments, so an update applies to the stored weights
+// atomically: update gets the current weights, nil when none are stored yet, and
+// returns the ones to store.
+type EnsembleWeightStore interface {
+	LoadSegmentWeights(ctx context.Context) ([]*models.SegmentWeights, error)
+	UpdateSegmentWeights(ctx context.Context, segment string, update func(current *models.SegmentWeights) *models.SegmentWeights) (*models.SegmentWeights, error)
+	DeleteSegmentWeights(ctx context.Context, segments ...string) error
+}
+
+// EnsembleWeightSnapshot is a read-only view of the learner state
+type EnsembleWeightSnapshot struct {
+	Defaults        map[string]float64       `json:"defaults"`
+	MinObservations int64                    `json:"min_observations"`
+	Segments        []*models.SegmentWeights `json:"segments"`
+}
+
+type servedScores struct {
+	scores  map[string]float64
+	weights map[string]float64
+}
+
+// EnsembleWeightLearner learns ensemble weights per user segment from outcome
+// feedback. Each update is a multiplicative-weights step that shrinks models in
+// proportion to their squared error on the observed outcome, with a decaying
+// learning rate capped by MaxLearningRate and weights clamped to [MinWeight, MaxWeight].
+type EnsembleWeightLearner struct {
+	mu          sync.RWMutex
+	config      *models.EnsembleWeightConfig
+	store       EnsembleWeightStore
+	segments    map[string]*models.SegmentWeights
+	served      map[string]*servedScores
+	servedOrder []string
+}
+
+// DefaultEnsembleWeights returns the weights used before any feedback is learned
+func DefaultEnsembleWeights() map[string]float64 {
+	return map[string]float64{
+		"basic": 0.15, // Reduced weight for basic algorithm
+		"ncf":   0.35, // High weight for collaborative filtering
+		"gnn":   0.25, // Moderate weight for skill relationships
+		"rl":    0.25, // Moderate weight for learned preferences
+	}
+}
+
+// NewEnsembleWeightLearner creates a learner. store may be nil to keep weights in memory only.
+func NewEnsembleWeightLearner(config *models.EnsembleWeightConfig, store EnsembleWeightStore) *EnsembleWeightLearner {
+	cfg := models.EnsembleWeightConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if len(cfg.DefaultWeights) == 0 {
+		cfg.DefaultWeights = DefaultEnsembleWeights()
+	}
+	if cfg.MaxLearningRate <= 0 {
+		cfg.MaxLearningRate = 0.1
+	}
+	if cfg.LearningRate <= 0 {
+		cfg.LearningRate = 0.05
+	}
+	cfg.LearningRate = math.Min(cfg.LearningRate, cfg.MaxLearningRate)
+	if cfg.MinLearningRate <= 0 {
+		cfg.MinLearningRate = 0.005
+	}
+	cfg.MinLearningRate = math.Min(cfg.MinLearningRate, cfg.LearningRate)
+	if cfg.MinWeight <= 0 {
+		cfg.MinWeight = 0.05
+	}
+	if cfg.MaxWeight <= 0 || cfg.MaxWeight > 1 {
+		cfg.MaxWeight = 0.6
+	}
+	if cfg.MinObservations <= 0 {
+		cfg.MinObservations = 20
+	}
+	if cfg.NewUserWindow <= 0 {
+		cfg.NewUserWindow = 30 * 24 * time.Hour
+	}
+	if cfg.ServedCacheSize <= 0 {
+		cfg.ServedCacheSize = 10000
+	}
+
+	return &EnsembleWeightLearner{
+		config:   &cfg,
+		store:    store,
+		segments: make(map[string]*models.SegmentWeights),
+		served:   make(map[string]*servedScores),
+	}
+}
+
+// Load restores persisted segment weights, replacing any in memory
+func (l *EnsembleWeightLearner) Load(ctx context.Context) error {
+	if l.store == nil {
+		return nil
+	}
+
+	stored, err := l.store.LoadSegmentWeights(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load segment weights: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.segments = make(map[string]*models.SegmentWeights, len(stored))
+	for _, segment := range stored {
+		l.segments[segment.Segment] = copySegmentWeights(segment)
+	}
+	return nil
+}
+
+// WeightsFor returns learned weights for the most specific segment of the pair that
+// has enough observations. ok is false when no segment qualifies yet.
+func (l *EnsembleWeightLearner) WeightsFor(user *coreModels.User, job *coreModels.Job) (map[string]float64, string, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, name := range l.segmentsFor(user, job) {
+		if segment, exists := l.segments[name]; exists && segment.Updates >= l.config.MinObservations {
+			return copyWeights(segment.Weights), name, true
+		}
+	}
+	return nil, "", false
+}
+
+// RecordServed remembers the model scores and weights behind a served match so
+// later feedback on it can be attributed to the models
+func (l *EnsembleWeightLearner) RecordServed(userID, jobID string, scores, weights map[string]float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := servedKey(userID, jobID)
+	if _, exists := l.served[key]; !exists {
+		l.servedOrder = append(l.servedOrder, key)
+	}
+	l.served[key] = &servedScores{scores: copyWeights(scores), weights: copyWeights(weights)}
+
+	for len(l.servedOrder) > l.config.ServedCacheSize {
+		delete(l.served, l.servedOrder[0])
+		l.servedOrder = l.servedOrder[1:]
+	}
+}
+
+// Observe updates the lifecycle and category segments of the pair from an outcome
+// in [0, 1]. Feedback on matches that were never served is ignored. With a store,
+// each step is applied to the stored weights, which already carry the updates of
+// every other instance, and the result replaces the weights in memory.
+func (l *EnsembleWeightLearner) Observe(ctx context.Context, user *coreModels.User, job *coreModels.Job, outcome float64) error {
+	if math.IsNaN(outcome) {
+		return nil
+	}
+	outcome = math.Max(0, math.Min(1, outcome))
+
+	l.mu.Lock()
+	served, exists := l.served[servedKey(user.ID, job.ID)]
+	if !exists {
+		l.mu.Unlock()
+		return nil
+	}
+	names := l.segmentsFor(user, job)
+	if l.store == nil {
+		for _, name := range names {
+			l.applyUpdate(l.getOrCreateSegment(name), served, outcome)
+		}
+		l.mu.Unlock()
+		return nil
+	}
+	l.mu.Unlock()
+
+	for _, name := range names {
+		updated, err := l.store.UpdateSegmentWeights(ctx, name, func(current *models.SegmentWeights) *models.SegmentWeights {
+			segment := l.newSegment(name)
+			if current != nil {
+				segment = copySegmentWeights(current)
+			}
+			l.applyUpdate(segment, served, outcome)
+			return segment
+		})
+		if err != nil {
+			return fmt.Errorf("failed to persist weights for segment %s: %w", name, err)
+		}
+
+		l.mu.Lock()
+		l.segments[name] = copySegmentWeights(updated)
+		l.mu.Unlock()
+	}
+	return nil
+}
+
+// Reset rolls a segment back to the default weights. An empty segment resets all of them.
+func (l *EnsembleWeightLearner) Reset(ctx context.Context, segment string) error {
+	l.mu.Lock()
+	var segments []string
+	if segment == "" {
+		for name := range l.segments {
+			segments = append(segments, name)
+		}
+	} else {
+		if _, exists := l.segments[segment]; !exists {
+			l.mu.Unlock()
+			return fmt.Errorf("%w: %s", ErrUnknownSegment, segment)
+		}
+		segments = []string{segment}
+	}
+	for _, name := range segments {
+		delete(l.segments, name)
+	}
+	l.mu.Unlock()
+
+	if l.store == nil || len(segments) == 0 {
+		return nil
+	}
+	if err := l.store.DeleteSegmentWeights(ctx, segments...); err != nil {
+		return fmt.Errorf("failed to delete segment weights: %w", err)
+	}
+	return nil
+}
+
+// Snapshot returns a copy of the defaults and every learned segment
+func (l *EnsembleWeightLearner) Snapshot() *EnsembleWeightSnapshot {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	snapshot := &EnsembleWeightSnapshot{
+		Defaults:        copyWeights(l.config.DefaultWeights),
+		MinObservations: l.config.MinObservations,
+		Segments:        make([]*models.SegmentWeights, 0, len(l.segments)),
+	}
+	for _, segment := range l.segments {
+		snapshot.Segments = append(snapshot.Segments, copySegmentWeights(segment))
+	}
+	sort.Slice(snapshot.Segments, func(i, j int) bool {
+		return snapshot.Segments[i].Segment < snapshot.Segments[j].Segment
+	})
+	return snapshot
+}
+
+// Private methods
+
+// segmentsFor lists the pair's segments from most to least specific
+func (l *EnsembleWeightLearner) segmentsFor(user *coreModels.User, job *coreModels.Job) []string {
+	lifecycle := SegmentActiveStudent
+	if !user.CreatedAt.IsZero() && time.Since(user.CreatedAt) < l.config.NewUserWindow {
+		lifecycle = SegmentNewStudent
+	}
+
+	category := strings.ToLower(strings.TrimSpace(job.Category))
+	if category == "" {
+		return []string{lifecycle}
+	}
+	return []string{lifecycle + "/" + category, lifecycle}
+}
+
+func (l *EnsembleWeightLearner) getOrCreateSegment(name string) *models.SegmentWeights {
+	if segment, exists := l.segments[name]; exists {
+		return segment
+	}
+	segment := l.newSegment(name)
+	l.segments[name] = segment
+	return segment
+}
+
+func (l *EnsembleWeightLearner) newSegment(name string) *models.SegmentWeights {
+	return &models.SegmentWeights{
+		Segment: name,
+		Weights: copyWeights(l.config.DefaultWeights),
+	}
+}
+
+func (l *EnsembleWeightLearner) applyUpdate(segment *models.SegmentWeights, served *servedScores, outcome float64) {
+	rate := l.learningRate(segment.Updates)
+
+	for model, weight := range segment.Weights {
+		score, scored := served.scores[model]
+		if !scored || served.weights[model] <= 0 || math.IsNaN(score) || score < 0 {
+			continue // Models that did not contribute to the served score learn nothing
+		}
+		loss := (score - outcome) * (score - outcome)
+		segment.Weights[model] = weight * math.Exp(-rate*loss)
+	}
+
+	l.clampAndNormalize(segment.Weights)
+	segment.Updates++
+	segment.UpdatedAt = time.Now()
+}
+
+func (l *EnsembleWeightLearner) learningRate(updates int64) float64 {
+	rate := l.config.LearningRate / math.Sqrt(1+float64(updates)/float64(l.config.MinObservations))
+	return math.Max(l.config.MinLearningRate, math.Min(l.config.MaxLearningRate, rate))
+}
+
+// clampAndNormalize keeps every weight within bounds while summing to 1. Clamping can
+// break the sum, so it alternates a few times; the bounds are loose enough to converge.
+func (l *EnsembleWeightLearner) clampAndNormalize(weights map[string]float64) {
+	for i := 0; i < 5; i++ {
+		normalizeWeights(weights)
+		clamped := false
+		for model, weight := range weights {
+			bounded := math.Max(l.config.MinWeight, math.Min(l.config.MaxWeight, weight))
+			if bounded != weight {
+				weights[model] = bounded
+				clamped = true
+			}
+		}
+		if !clamped {
+			return
+		}
+	}
+	normalizeWeights(weights)
+}
+
+// Utility functions
+
+func servedKey(userID, jobID string) string {
+	return userID + "|" + jobID
+}
+
+func normalizeWeights(weights map[string]float64) {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total > 0 {
+		for model := range weights {
+			weights[model] /= total
+		}
+	}
+}
+
+func copyWeights(weights map[string]float64) map[string]float64 {
+	copied := make(map[string]float64, len(weights))
+	for model, weight := range weights {
+		copied[model] = weight
+	}
+	return copied
+}
+
+func copySegmentWeights(segment *models.SegmentWeights) *models.SegmentWeights {
+	copied := *segment
+	copied.Weights = copyWeights(segment.Weights)
+	return &copied
+}
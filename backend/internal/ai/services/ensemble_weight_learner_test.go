@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/ai/models"
+	coreModels "microbridge/backend/internal/models"
+)
+
+type memoryWeightStore struct {
+	mu    sync.Mutex
+	saved map[string]*models.SegmentWeights
+}
+
+func (m *memoryWeightStore) LoadSegmentWeights(ctx context.Context) ([]*models.SegmentWeights, error) {
+	var segments []*models.SegmentWeights
+	for _, segment := range m.saved {
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+func (m *memoryWeightStore) UpdateSegmentWeights(ctx context.Context, segment string, update func(current *models.SegmentWeights) *models.SegmentWeights) (*models.SegmentWeights, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved[segment] = update(m.saved[segment])
+	return m.saved[segment], nil
+}
+
+func (m *memoryWeightStore) DeleteSegmentWeights(ctx context.Context, segments ...string) error {
+	for _, segment := range segments {
+		delete(m.saved, segment)
+	}
+	return nil
+}
+
+func TestEnsembleWeightLearner_LearnsPerSegment(t *testing.T) {
+	store := &memoryWeightStore{saved: make(map[string]*models.SegmentWeights)}
+	learner := NewEnsembleWeightLearner(&models.EnsembleWeightConfig{MinObservations: 5}, store)
+	ctx := context.Background()
+
+	activeUser := &coreModels.User{ID: "active_user", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	newUser := &coreModels.User{ID: "new_user", CreatedAt: time.Now()}
+	job := &coreModels.Job{ID: "job1", Category: "Software Development"}
+
+	// RL predicts the outcome well, NCF does not
+	scores := map[string]float64{"basic": 0.5, "ncf": 0.1, "gnn": 0.5, "rl": 0.9}
+	weights := DefaultEnsembleWeights()
+
+	// Feedback on an unserved match is ignored
+	if err := learner.Observe(ctx, activeUser, job, 1.0); err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if len(learner.Snapshot().Segments) != 0 {
+		t.Fatal("Expected no segments from unserved feedback")
+	}
+
+	learner.RecordServed(activeUser.ID, job.ID, scores, weights)
+	for i := 0; i < 4; i++ {
+		if err := learner.Observe(ctx, activeUser, job, 1.0); err != nil {
+			t.Fatalf("Observe failed: %v", err)
+		}
+	}
+	if _, _, ok := learner.WeightsFor(activeUser, job); ok {
+		t.Error("Expected segment not to be served before MinObservations")
+	}
+
+	if err := learner.Observe(ctx, activeUser, job, 1.0); err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	learned, segment, ok := learner.WeightsFor(activeUser, job)
+	if !ok || segment != "active/software development" {
+		t.Fatalf("Expected category segment to be served, got %q (ok=%v)", segment, ok)
+	}
+	if learned["rl"] <= weights["rl"] || learned["ncf"] >= weights["ncf"] {
+		t.Errorf("Expected RL to gain and NCF to lose weight, got %v", learned)
+	}
+
+	total := 0.0
+	for _, weight := range learned {
+		total += weight
+		if weight < 0.05-1e-9 || weight > 0.6+1e-9 {
+			t.Errorf("Weight %f outside bounds", weight)
+		}
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("Weights should sum to 1, got %f", total)
+	}
+
+	// Other segments are unaffected
+	if _, _, ok := learner.WeightsFor(newUser, job); ok {
+		t.Error("Expected new-student segment to have no learned weights")
+	}
+
+	// Persisted weights survive a restart
+	if len(store.saved) != 2 {
+		t.Errorf("Expected category and lifecycle segments persisted, got %d", len(store.saved))
+	}
+	restored := NewEnsembleWeightLearner(&models.EnsembleWeightConfig{MinObservations: 5}, store)
+	if err := restored.Load(ctx); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if restoredWeights, _, ok := restored.WeightsFor(activeUser, job); !ok || restoredWeights["rl"] != learned["rl"] {
+		t.Errorf("Expected restored weights to match, got %v", restoredWeights)
+	}
+
+	// Rollback to defaults
+	if err := restored.Reset(ctx, "active/software development"); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if _, segment, _ := restored.WeightsFor(activeUser, job); segment != SegmentActiveStudent {
+		t.Errorf("Expected fallback to lifecycle segment after reset, got %q", segment)
+	}
+	if err := restored.Reset(ctx, "unknown"); err == nil {
+		t.Error("Expected error resetting unknown segment")
+	}
+	if err := restored.Reset(ctx, ""); err != nil {
+		t.Fatalf("Reset all failed: %v", err)
+	}
+	if len(store.saved) != 0 || len(restored.Snapshot().Segments) != 0 {
+		t.Error("Expected reset all to clear every segment")
+	}
+}
+
+func TestEnsembleWeightLearner_InstancesShareUpdates(t *testing.T) {
+	store := &memoryWeightStore{saved: make(map[string]*models.SegmentWeights)}
+	config := &models.EnsembleWeightConfig{MinObservations: 5}
+	first := NewEnsembleWeightLearner(config, store)
+	second := NewEnsembleWeightLearner(config, store)
+	ctx := context.Background()
+
+	user := &coreModels.User{ID: "user1", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	job := &coreModels.Job{ID: "job1"}
+	scores := map[string]float64{"basic": 0.5, "ncf": 0.1, "gnn": 0.5, "rl": 0.9}
+	first.RecordServed(user.ID, job.ID, scores, DefaultEnsembleWeights())
+	second.RecordServed(user.ID, job.ID, scores, DefaultEnsembleWeights())
+
+	// Neither instance has loaded the other's updates, yet none are lost
+	var wg sync.WaitGroup
+	for _, learner := range []*EnsembleWeightLearner{first, second, first, second} {
+		wg.Add(1)
+		go func(learner *EnsembleWeightLearner) {
+			defer wg.Done()
+			if err := learner.Observe(ctx, user, job, 1.0); err != nil {
+				t.Errorf("Observe failed: %v", err)
+			}
+		}(learner)
+	}
+	wg.Wait()
+
+	if updates := store.saved[SegmentActiveStudent].Updates; updates != 4 {
+		t.Fatalf("Expected every instance's updates to be kept, got %d", updates)
+	}
+	if err := second.Observe(ctx, user, job, 1.0); err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if _, _, ok := second.WeightsFor(user, job); !ok {
+		t.Error("Expected the instance to serve weights built on the shared updates")
+	}
+}
+
+func TestEnsembleWeightLearner_BoundedLearningRate(t *testing.T) {
+	learner := NewEnsembleWeightLearner(&models.EnsembleWeightConfig{LearningRate: 5, MaxLearningRate: 0.2}, nil)
+
+	if rate := learner.learningRate(0); rate > 0.2 {
+		t.Errorf("Expected learning rate capped at 0.2, got %f", rate)
+	}
+	if rate := learner.learningRate(1000000); rate < learner.config.MinLearningRate {
+		t.Errorf("Expected learning rate floored at %f, got %f", learner.config.MinLearningRate, rate)
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"microbridge/backend/internal/ai/features"
+	coreModels "microbridge/backend/internal/models"
+)
+
+type userLister interface {
+	List(ctx context.Context, limit, offset int) ([]*coreModels.User, int64, error)
+}
+
+type jobLister interface {
+	List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*coreModels.Job, int64, error)
+}
+
+// repositoryMaterializeSource keeps the features of the newest students and the
+// posted jobs warm
+type repositoryMaterializeSource struct {
+	users userLister
+	jobs  jobLister
+}
+
+// NewRepositoryMaterializeSource creates a features.MaterializeSource backed by user and job repositories
+func NewRepositoryMaterializeSource(users userLister, jobs jobLister) features.MaterializeSource {
+	return &repositoryMaterializeSource{users: users, jobs: jobs}
+}
+
+// MaterializeUsers returns students among the limit most recently created users
+func (s *repositoryMaterializeSource) MaterializeUsers(ctx context.Context, limit int) ([]*coreModels.User, error) {
+	users, _, err := s.users.List(ctx, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	students := users[:0]
+	for _, user := range users {
+		if user.UserType == "student" {
+			students = append(students, user)
+		}
+	}
+	return students, nil
+}
+
+// MaterializeJobs returns the newest posted jobs
+func (s *repositoryMaterializeSource) MaterializeJobs(ctx context.Context, limit int) ([]*coreModels.Job, error) {
+	jobs, _, err := s.jobs.List(ctx, map[string]interface{}{"status": "posted"}, limit, 0)
+	return jobs, err
+}
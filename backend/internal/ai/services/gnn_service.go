@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"sort"
@@ -117,6 +118,11 @@ func (s *GNNService) GetSkillSimilarity(ctx context.Context, skill1, skill2 stri
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	return s.skillSimilarity(skill1, skill2)
+}
+
+// skillSimilarity computes cached embedding similarity; callers must hold s.mu
+func (s *GNNService) skillSimilarity(skill1, skill2 string) (float64, error) {
 	// Check cache first
 	if cached, exists := s.skillSimilarityCache[skill1]; exists {
 		if similarity, found := cached[skill2]; found {
@@ -240,6 +246,11 @@ func (s *GNNService) PropagateMessage(ctx context.Context, iterations int) error
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	return s.propagateMessages(iterations)
+}
+
+// propagateMessages runs message passing iterations; callers must hold s.mu
+func (s *GNNService) propagateMessages(iterations int) error {
 	for iter := 0; iter < iterations; iter++ {
 		newEmbeddings := make(map[string][]float64)
 
@@ -276,33 +287,198 @@ func (s *GNNService) PropagateMessage(ctx context.Context, iterations int) error
 
 // TrainGNN trains the Graph Neural Network
 func (s *GNNService) TrainGNN(ctx context.Context, trainingData []*models.TrainingData, config *models.GNNConfig) error {
+	_, err := s.TrainGNNWithProgress(ctx, trainingData, config, nil)
+	return err
+}
+
+// TrainGNNWithProgress trains the GNN, reporting each epoch to onEpoch and stopping
+// early when ctx is cancelled. The returned metrics hold the final training loss.
+func (s *GNNService) TrainGNNWithProgress(ctx context.Context, trainingData []*models.TrainingData, config *models.GNNConfig, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.fit(ctx, trainingData, nil, config, onEpoch)
+}
+
+// TrainGNNWithHoldout trains on trainData and reports each epoch's skill similarity
+// error on validData, which the caller has already held out of the training set
+func (s *GNNService) TrainGNNWithHoldout(ctx context.Context, trainData, validData []*models.TrainingData, config *models.GNNConfig, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.fit(ctx, trainData, validData, config, onEpoch)
+}
+
+// Clone returns an independent copy of the graph and weights for training off to
+// the side of the serving instance. The copy gets its own version.
+func (s *GNNService) Clone() *GNNService {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clone := &GNNService{
+		skillGraph:           &models.SkillGraph{Nodes: make(map[string]*models.SkillGraphNode, len(s.skillGraph.Nodes)), Edges: make(map[string][]models.GraphEdge, len(s.skillGraph.Edges))},
+		nodeEmbeddings:       make(map[string][]float64, len(s.nodeEmbeddings)),
+		edgeWeights:          make(map[string]map[string]float64, len(s.edgeWeights)),
+		aggregationWeights:   copyFloatMatrix(s.aggregationWeights),
+		transformWeights:     copyFloatMatrix(s.transformWeights),
+		numLayers:            s.numLayers,
+		embeddingDim:         s.embeddingDim,
+		hiddenDim:            s.hiddenDim,
+		aggregationType:      s.aggregationType,
+		modelVersion:         fmt.Sprintf("gnn_v%d", time.Now().UnixNano()),
+		lastTrainingTime:     s.lastTrainingTime,
+		skillSimilarityCache: make(map[string]map[string]float64),
+		pathCache:            make(map[string]map[string][]string),
+	}
+
+	for skillID, node := range s.skillGraph.Nodes {
+		copied := *node
+		copied.Embedding = append([]float64(nil), node.Embedding...)
+		copied.Connections = make(map[string]float64, len(node.Connections))
+		for target, weight := range node.Connections {
+			copied.Connections[target] = weight
+		}
+		clone.skillGraph.Nodes[skillID] = &copied
+	}
+	for skillID, edges := range s.skillGraph.Edges {
+		clone.skillGraph.Edges[skillID] = append([]models.GraphEdge(nil), edges...)
+	}
+	for skillID, embedding := range s.nodeEmbeddings {
+		clone.nodeEmbeddings[skillID] = append([]float64(nil), embedding...)
+	}
+	for source, targets := range s.edgeWeights {
+		clone.edgeWeights[source] = make(map[string]float64, len(targets))
+		for target, weight := range targets {
+			clone.edgeWeights[source][target] = weight
+		}
+	}
+
+	return clone
+}
+
+// ReplaceWith swaps in the graph and weights of a trained clone. Graph changes made
+// to this instance since the clone was taken are dropped; the candidate must not be
+// used afterwards.
+func (s *GNNService) ReplaceWith(candidate *GNNService) {
+	candidate.mu.RLock()
+	defer candidate.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.skillGraph = candidate.skillGraph
+	s.nodeEmbeddings = candidate.nodeEmbeddings
+	s.edgeWeights = candidate.edgeWeights
+	s.aggregationWeights = candidate.aggregationWeights
+	s.transformWeights = candidate.transformWeights
+	s.modelVersion = candidate.modelVersion
+	s.lastTrainingTime = candidate.lastTrainingTime
+	s.clearCache()
+}
+
+// gnnWeights is the serialized form of a trained GNN model
+type gnnWeights struct {
+	Version            string                        `json:"version"`
+	TrainedAt          time.Time                     `json:"trained_at"`
+	SkillGraph         *models.SkillGraph            `json:"skill_graph"`
+	NodeEmbeddings     map[string][]float64          `json:"node_embeddings"`
+	EdgeWeights        map[string]map[string]float64 `json:"edge_weights"`
+	AggregationWeights [][]float64                   `json:"aggregation_weights"`
+	TransformWeights   [][]float64                   `json:"transform_weights"`
+}
+
+// MarshalWeights serializes the skill graph and the learned GNN weights
+func (s *GNNService) MarshalWeights() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return json.Marshal(&gnnWeights{
+		Version:            s.modelVersion,
+		TrainedAt:          s.lastTrainingTime,
+		SkillGraph:         s.skillGraph,
+		NodeEmbeddings:     s.nodeEmbeddings,
+		EdgeWeights:        s.edgeWeights,
+		AggregationWeights: s.aggregationWeights,
+		TransformWeights:   s.transformWeights,
+	})
+}
+
+// RestoreWeights loads weights written by MarshalWeights. Weights trained for a
+// different layer layout are rejected.
+func (s *GNNService) RestoreWeights(data []byte) error {
+	var weights gnnWeights
+	if err := json.Unmarshal(data, &weights); err != nil {
+		return fmt.Errorf("failed to decode GNN weights: %w", err)
+	}
+	if weights.SkillGraph == nil || weights.SkillGraph.Nodes == nil {
+		return fmt.Errorf("GNN weights carry no skill graph")
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if !sameShape(weights.AggregationWeights, s.aggregationWeights) || !sameShape(weights.TransformWeights, s.transformWeights) {
+		return fmt.Errorf("GNN weights do not match the configured layer layout")
+	}
+	if weights.SkillGraph.Edges == nil {
+		weights.SkillGraph.Edges = make(map[string][]models.GraphEdge)
+	}
+	if weights.EdgeWeights == nil {
+		weights.EdgeWeights = make(map[string]map[string]float64)
+	}
+
+	s.skillGraph = weights.SkillGraph
+	s.nodeEmbeddings = nonNilEmbeddings(weights.NodeEmbeddings)
+	s.edgeWeights = weights.EdgeWeights
+	s.aggregationWeights = weights.AggregationWeights
+	s.transformWeights = weights.TransformWeights
+	s.modelVersion = weights.Version
+	s.lastTrainingTime = weights.TrainedAt
+	s.clearCache()
+	return nil
+}
+
+// fit runs the training loop, validating on validData when it is given; callers
+// must hold s.mu
+func (s *GNNService) fit(ctx context.Context, trainData, validData []*models.TrainingData, config *models.GNNConfig, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
 	s.isTraining = true
 	defer func() { s.isTraining = false }()
 
+	metrics := &models.ValidationMetrics{}
+
 	// Training loop
 	for epoch := 0; epoch < config.MaxEpochs; epoch++ {
+		if err := ctx.Err(); err != nil {
+			return metrics, err
+		}
+
 		// Forward pass - message propagation
-		err := s.PropagateMessage(ctx, config.NumLayers)
+		err := s.propagateMessages(config.NumLayers)
 		if err != nil {
-			return fmt.Errorf("message propagation failed: %w", err)
+			return metrics, fmt.Errorf("message propagation failed: %w", err)
 		}
 
 		// Compute loss and gradients
-		loss := s.computeTrainingLoss(trainingData)
+		loss := s.computeTrainingLoss(trainData)
 		
 		// Backward pass - update weights
 		s.updateWeights(config.LearningRate)
 
+		if validData != nil {
+			metrics = s.evaluate(validData)
+		} else {
+			metrics = &models.ValidationMetrics{Loss: loss, RMSE: math.Sqrt(loss)}
+		}
+		if onEpoch != nil {
+			onEpoch(epoch+1, loss, metrics)
+		}
+
 		if epoch%10 == 0 {
 			fmt.Printf("GNN Training - Epoch %d, Loss: %.4f\n", epoch, loss)
 		}
 	}
 
 	s.lastTrainingTime = time.Now()
-	return nil
+	return metrics, nil
 }
 
 // GetModelInfo returns information about the GNN model
@@ -750,7 +926,7 @@ func (s *GNNService) computeTrainingLoss(trainingData []*models.TrainingData) fl
 		
 		for _, userSkill := range userSkills {
 			for _, jobSkill := range jobSkills {
-				similarity, err := s.GetSkillSimilarity(context.Background(), userSkill, jobSkill)
+				similarity, err := s.skillSimilarity(userSkill, jobSkill)
 				if err == nil {
 					expectedSimilarity := sample.Label // Use label as expected similarity
 					loss := (similarity - expectedSimilarity) * (similarity - expectedSimilarity)
@@ -767,6 +943,43 @@ func (s *GNNService) computeTrainingLoss(trainingData []*models.TrainingData) fl
 	return 0.0
 }
 
+// evaluate scores skill similarity against the labels of held-out samples. A pair
+// counts as correct when similarity and label fall on the same side of 0.5.
+func (s *GNNService) evaluate(samples []*models.TrainingData) *models.ValidationMetrics {
+	totalLoss := 0.0
+	correct := 0
+	count := 0
+
+	for _, sample := range samples {
+		userSkills := s.extractSkillsFromFeatures(sample.Features.UserFeatures)
+		jobSkills := s.extractSkillsFromFeatures(sample.Features.JobFeatures)
+
+		for _, userSkill := range userSkills {
+			for _, jobSkill := range jobSkills {
+				similarity, err := s.skillSimilarity(userSkill, jobSkill)
+				if err != nil {
+					continue
+				}
+				totalLoss += (similarity - sample.Label) * (similarity - sample.Label)
+				if (similarity >= 0.5) == (sample.Label >= 0.5) {
+					correct++
+				}
+				count++
+			}
+		}
+	}
+
+	if count == 0 {
+		return &models.ValidationMetrics{}
+	}
+	loss := totalLoss / float64(count)
+	return &models.ValidationMetrics{
+		Loss:     loss,
+		Accuracy: float64(correct) / float64(count),
+		RMSE:     math.Sqrt(loss),
+	}
+}
+
 func (s *GNNService) updateWeights(learningRate float64) {
 	// Simplified weight update (normally would use computed gradients)
 	for layer := 0; layer < s.numLayers; layer++ {
@@ -2,15 +2,21 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
 	"time"
 
+	"microbridge/backend/internal/ai/features"
+	"microbridge/backend/internal/ai/models"
 	"microbridge/backend/internal/core/matching"
 	coreModels "microbridge/backend/internal/models"
 )
 
+// ErrLLMUnavailable is returned by the explanation methods when no LLM service is configured
+var ErrLLMUnavailable = errors.New("LLM service not available")
+
 // HybridMatchingService combines NCF, GNN, and RL for superior matching
 type HybridMatchingService struct {
 	mu                      sync.RWMutex
@@ -18,8 +24,13 @@ type HybridMatchingService struct {
 	gnnService             *GNNService
 	rlService              *RLService
 	llmService             *LLMService
+	banditService          *BanditService
+	featureStore           *features.Store
+	entityLoader           BatchEntityLoader
+	driftMonitor           *DriftMonitor
 	basicAlgorithm         *matching.MatchingAlgorithm
 	ensembleWeights        map[string]float64
+	weightLearner          *EnsembleWeightLearner
 	fallbackEnabled        bool
 	confidenceThreshold    float64
 	abTestConfig           *ABTestConfig
@@ -45,6 +56,9 @@ type HybridMatchResult struct {
 	ModelUsed            string                         `json:"model_used"`
 	ProcessingTime       time.Duration                  `json:"processing_time"`
 	Features             map[string]interface{}         `json:"features"`
+	FeatureVector        *models.FeatureVector          `json:"feature_vector,omitempty"`
+	Attribution          *MatchAttribution              `json:"attribution,omitempty"`
+	Exploration          *models.BanditDecision         `json:"exploration,omitempty"`
 	CreatedAt            time.Time                      `json:"created_at"`
 }
 
@@ -121,12 +135,8 @@ func NewHybridMatchingService(
 		rlService:       rlService,
 		llmService:      llmService,
 		basicAlgorithm:  basicAlgorithm,
-		ensembleWeights: map[string]float64{
-			"basic": 0.15, // Reduced weight for basic algorithm
-			"ncf":   0.35, // High weight for collaborative filtering
-			"gnn":   0.25, // Moderate weight for skill relationships
-			"rl":    0.25, // Moderate weight for learned preferences
-		},
+		ensembleWeights: DefaultEnsembleWeights(),
+		weightLearner:   NewEnsembleWeightLearner(nil, nil),
 		fallbackEnabled:     true,
 		confidenceThreshold: 0.3,
 		performanceTracker: &HybridPerformanceTracker{
@@ -159,11 +169,16 @@ func (s *HybridMatchingService) FindBestMatches(ctx context.Context, userID stri
 	}
 
 	var matches []*HybridMatchResult
-	user, _ := s.getMockUserAndJobData(userID) // Mock data - replace with real data
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	jobsByID := make(map[string]*coreModels.Job, len(candidateJobs))
 
-	// Process each candidate job
+	// Process each candidate job. Candidates are still mock postings, so their
+	// features are not served from the feature store.
 	for _, candidateJob := range candidateJobs {
-		match, err := s.calculateHybridMatch(ctx, user, candidateJob)
+		match, err := s.calculateHybridMatch(ctx, user, candidateJob, false)
 		if err != nil {
 			continue // Skip failed matches
 		}
@@ -171,14 +186,17 @@ func (s *HybridMatchingService) FindBestMatches(ctx context.Context, userID stri
 		// Filter by confidence threshold
 		if match.ConfidenceLevel >= s.confidenceThreshold {
 			matches = append(matches, match)
+			jobsByID[candidateJob.ID] = candidateJob
 		}
 	}
 
 	// Sort by final score (descending)
 	s.sortMatchesByScore(matches)
 
-	// Limit results
-	if len(matches) > limit {
+	// Limit results, letting the bandit fill the exploratory slots
+	if s.banditService != nil {
+		matches = s.fillExploratorySlots(ctx, user, jobsByID, matches, limit)
+	} else if len(matches) > limit {
 		matches = matches[:limit]
 	}
 
@@ -192,10 +210,12 @@ func (s *HybridMatchingService) FindBestMatches(ctx context.Context, userID stri
 func (s *HybridMatchingService) CalculateMatchScore(ctx context.Context, userID, jobID string) (*HybridMatchResult, error) {
 	startTime := time.Now()
 
-	user, job := s.getMockUserAndJobData(userID) // Mock data - replace with real data
-	job.ID = jobID
+	user, job, loaded, err := s.loadEntities(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
 
-	match, err := s.calculateHybridMatch(ctx, user, job)
+	match, err := s.calculateHybridMatch(ctx, user, job, loaded)
 	if err != nil {
 		return nil, fmt.Errorf("hybrid match calculation failed: %w", err)
 	}
@@ -214,16 +234,96 @@ func (s *HybridMatchingService) ProcessUserFeedback(ctx context.Context, userID,
 		}
 	}
 
-	// Update NCF service with interaction data
+	// Update NCF service with interaction data. Pairs the model hasn't embedded yet
+	// fail here, which must not keep the outcome from the ensemble weights.
+	var ncfErr error
 	if s.ncfService != nil {
-		err := s.ncfService.UpdateEmbeddings(ctx, userID, jobID, outcome)
-		if err != nil {
-			return fmt.Errorf("NCF update failed: %w", err)
+		if err := s.ncfService.UpdateEmbeddings(ctx, userID, jobID, outcome); err != nil {
+			ncfErr = fmt.Errorf("NCF update failed: %w", err)
 		}
 	}
 
-	// Update ensemble weights based on feedback (adaptive learning)
-	s.updateEnsembleWeights(action, outcome)
+	// Learn ensemble weights for the user's segments from the outcome
+	s.mu.RLock()
+	weightLearner := s.weightLearner
+	s.mu.RUnlock()
+
+	user, job, _, err := s.loadEntities(ctx, userID, jobID)
+	if err != nil {
+		return errors.Join(ncfErr, err)
+	}
+	if err := weightLearner.Observe(ctx, user, job, outcome); err != nil {
+		return errors.Join(ncfErr, fmt.Errorf("ensemble weight update failed: %w", err))
+	}
+
+	return ncfErr
+}
+
+// SetBanditService enables contextual bandit exploration for recommendation slots
+func (s *HybridMatchingService) SetBanditService(banditService *BanditService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.banditService = banditService
+}
+
+// SetFeatureStore attaches the shared feature store so match results carry the
+// same feature values that training reads
+func (s *HybridMatchingService) SetFeatureStore(featureStore *features.Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.featureStore = featureStore
+}
+
+// SetEntityLoader makes matching read users and jobs from storage instead of mock data
+func (s *HybridMatchingService) SetEntityLoader(entityLoader BatchEntityLoader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entityLoader = entityLoader
+}
+
+// SetDriftMonitor records every scored match for distribution drift monitoring
+func (s *HybridMatchingService) SetDriftMonitor(driftMonitor *DriftMonitor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.driftMonitor = driftMonitor
+}
+
+// SetEnsembleWeightLearner replaces the in-memory learner, typically with one backed
+// by persistent storage
+func (s *HybridMatchingService) SetEnsembleWeightLearner(weightLearner *EnsembleWeightLearner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.weightLearner = weightLearner
+}
+
+// GetEnsembleWeights returns the default and learned per-segment ensemble weights
+func (s *HybridMatchingService) GetEnsembleWeights() *EnsembleWeightSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.weightLearner.Snapshot()
+}
+
+// ResetEnsembleWeights rolls a segment, or every segment when empty, back to the defaults
+func (s *HybridMatchingService) ResetEnsembleWeights(ctx context.Context, segment string) error {
+	s.mu.RLock()
+	weightLearner := s.weightLearner
+	s.mu.RUnlock()
+	return weightLearner.Reset(ctx, segment)
+}
+
+// RecordExplorationReward feeds a view, save or apply event back to the bandit
+func (s *HybridMatchingService) RecordExplorationReward(ctx context.Context, userID, jobID, event string) error {
+	s.mu.RLock()
+	banditService := s.banditService
+	s.mu.RUnlock()
+
+	if banditService == nil {
+		return nil
+	}
+
+	if err := banditService.RecordReward(ctx, userID, jobID, event); err != nil {
+		return fmt.Errorf("bandit reward failed: %w", err)
+	}
 
 	return nil
 }
@@ -231,20 +331,22 @@ func (s *HybridMatchingService) ProcessUserFeedback(ctx context.Context, userID,
 // GetMatchExplanation generates an explanation for why a match was recommended
 func (s *HybridMatchingService) GetMatchExplanation(ctx context.Context, userID, jobID string, tierLevel string) (*LLMResponse, error) {
 	if s.llmService == nil {
-		return nil, fmt.Errorf("LLM service not available")
+		return nil, ErrLLMUnavailable
+	}
+
+	user, job, loaded, err := s.loadEntities(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the match details
-	match, err := s.CalculateMatchScore(ctx, userID, jobID)
+	match, err := s.calculateHybridMatch(ctx, user, job, loaded)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate match: %w", err)
 	}
 
-	user, job := s.getMockUserAndJobData(userID)
-	job.ID = jobID
-
 	// Generate explanation using LLM service
-	explanation, err := s.llmService.ExplainMatch(ctx, userID, match.BasicAlgorithmScore, user, job)
+	explanation, err := s.llmService.ExplainMatch(ctx, userID, match.BasicAlgorithmScore, match.Attribution, user, job)
 	if err != nil {
 		return nil, fmt.Errorf("LLM explanation failed: %w", err)
 	}
@@ -255,11 +357,13 @@ func (s *HybridMatchingService) GetMatchExplanation(ctx context.Context, userID,
 // GetSkillGapAnalysis provides skill gap analysis for pro-tier users
 func (s *HybridMatchingService) GetSkillGapAnalysis(ctx context.Context, userID, jobID string) (*LLMResponse, error) {
 	if s.llmService == nil {
-		return nil, fmt.Errorf("LLM service not available")
+		return nil, ErrLLMUnavailable
 	}
 
-	user, job := s.getMockUserAndJobData(userID)
-	job.ID = jobID
+	user, job, _, err := s.loadEntities(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate skill gap analysis using LLM service
 	analysis, err := s.llmService.AnalyzeSkillGaps(ctx, userID, user, job)
@@ -273,10 +377,13 @@ func (s *HybridMatchingService) GetSkillGapAnalysis(ctx context.Context, userID,
 // GetCareerAdvice provides personalized career advice for pro-tier users
 func (s *HybridMatchingService) GetCareerAdvice(ctx context.Context, userID, careerGoals string) (*LLMResponse, error) {
 	if s.llmService == nil {
-		return nil, fmt.Errorf("LLM service not available")
+		return nil, ErrLLMUnavailable
 	}
 
-	user, _ := s.getMockUserAndJobData(userID)
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate career advice using LLM service
 	advice, err := s.llmService.GenerateCareerAdvice(ctx, userID, user, careerGoals)
@@ -325,11 +432,14 @@ func (s *HybridMatchingService) GetModelPerformanceMetrics() *HybridPerformanceT
 
 // Private methods
 
-func (s *HybridMatchingService) calculateHybridMatch(ctx context.Context, user *coreModels.User, job *coreModels.Job) (*HybridMatchResult, error) {
+// calculateHybridMatch scores a pair. loaded reports whether user and job came from
+// the entity loader; only then are their features served from, and cached in, the
+// feature store, and the match observed for drift.
+func (s *HybridMatchingService) calculateHybridMatch(ctx context.Context, user *coreModels.User, job *coreModels.Job, loaded bool) (*HybridMatchResult, error) {
 	startTime := time.Now()
 
-	// Determine which test group user belongs to (A/B testing)
-	weights := s.getWeightsForUser(user.ID)
+	// Learned segment weights, falling back to the user's A/B test group
+	weights := s.getWeightsForMatch(user, job)
 
 	// 1. Calculate basic algorithm score
 	var basicScore *matching.MatchScore
@@ -368,12 +478,14 @@ func (s *HybridMatchingService) calculateHybridMatch(ctx context.Context, user *
 	}
 
 	// 5. Combine scores using ensemble weights
-	finalScore, confidence := s.ensembleScores(map[string]float64{
+	scores := map[string]float64{
 		"basic": s.getBasicScore(basicScore),
 		"ncf":   ncfScore,
 		"gnn":   gnnScore,
 		"rl":    rlScore,
-	}, weights)
+	}
+	finalScore, confidence := s.ensembleScores(scores, weights)
+	s.weightLearner.RecordServed(user.ID, job.ID, scores, weights)
 
 	// 6. Calculate success probability
 	successProbability := s.calculateSuccessProbability(finalScore, confidence, basicScore)
@@ -411,6 +523,14 @@ func (s *HybridMatchingService) calculateHybridMatch(ctx context.Context, user *
 			"gnn_error":            gnnError != nil,
 			"rl_error":             rlError != nil,
 		},
+		Attribution: s.attributeMatch(scores, weights, finalScore, basicScore),
+	}
+
+	if s.featureStore != nil && loaded {
+		match.FeatureVector = s.featureStore.FeatureVector(ctx, user, job)
+	}
+	if s.driftMonitor != nil && loaded {
+		s.driftMonitor.ObserveMatch(match)
 	}
 
 	// Update individual model performance
@@ -531,6 +651,13 @@ func (s *HybridMatchingService) getPrimaryModel(weights map[string]float64) stri
 	return primaryModel
 }
 
+func (s *HybridMatchingService) getWeightsForMatch(user *coreModels.User, job *coreModels.Job) map[string]float64 {
+	if weights, _, ok := s.weightLearner.WeightsFor(user, job); ok {
+		return weights
+	}
+	return s.getWeightsForUser(user.ID)
+}
+
 func (s *HybridMatchingService) getWeightsForUser(userID string) map[string]float64 {
 	// A/B testing logic
 	if s.abTestConfig != nil && s.abTestConfig.Enabled {
@@ -605,7 +732,12 @@ func (s *HybridMatchingService) initializePerformanceTracking() {
 	}
 }
 
+// The tracker helpers below run from concurrent matches and batch workers, so
+// each takes the tracker lock
 func (s *HybridMatchingService) updateModelPerformance(model string, score, confidence float64) {
+	s.performanceTracker.mu.Lock()
+	defer s.performanceTracker.mu.Unlock()
+
 	if perf, exists := s.performanceTracker.modelPerformance[model]; exists {
 		perf.TotalPredictions++
 		
@@ -622,12 +754,18 @@ func (s *HybridMatchingService) updateModelPerformance(model string, score, conf
 }
 
 func (s *HybridMatchingService) recordModelError(model string) {
+	s.performanceTracker.mu.Lock()
+	defer s.performanceTracker.mu.Unlock()
+
 	if perf, exists := s.performanceTracker.modelPerformance[model]; exists {
 		perf.ErrorCount++
 	}
 }
 
 func (s *HybridMatchingService) recordFallback() {
+	s.performanceTracker.mu.Lock()
+	defer s.performanceTracker.mu.Unlock()
+
 	s.performanceTracker.fallbackCount++
 }
 
@@ -655,40 +793,50 @@ func (s *HybridMatchingService) updatePerformanceMetrics(matchCount int64) {
 	s.performanceTracker.lastUpdated = time.Now()
 }
 
-func (s *HybridMatchingService) updateEnsembleWeights(action string, outcome float64) {
-	// Adaptive learning: adjust weights based on feedback
-	learningRate := 0.01
-	
-	switch action {
-	case "applied", "hired":
-		if outcome > 0.7 { // Positive outcome
-			// Increase RL weight slightly since it handles user feedback
-			s.ensembleWeights["rl"] = math.Min(0.5, s.ensembleWeights["rl"]+learningRate)
-			s.ensembleWeights["ncf"] = math.Max(0.1, s.ensembleWeights["ncf"]-learningRate*0.5)
+// fillExploratorySlots keeps the top exploit matches and lets the bandit pick the
+// remaining slots from the matches that did not make the cut
+func (s *HybridMatchingService) fillExploratorySlots(ctx context.Context, user *coreModels.User, jobsByID map[string]*coreModels.Job, matches []*HybridMatchResult, limit int) []*HybridMatchResult {
+	slots := s.banditService.ExplorationSlots()
+	if slots > limit {
+		slots = limit
+	}
+
+	exploitCount := limit - slots
+	if slots <= 0 || len(matches) <= exploitCount {
+		if len(matches) > limit {
+			return matches[:limit]
 		}
-	case "dismissed", "not_interested":
-		if outcome < 0.3 { // Negative outcome
-			// Increase GNN weight for better skill alignment
-			s.ensembleWeights["gnn"] = math.Min(0.4, s.ensembleWeights["gnn"]+learningRate)
-			s.ensembleWeights["ncf"] = math.Max(0.1, s.ensembleWeights["ncf"]-learningRate*0.5)
+		return matches
+	}
+
+	pool := matches[exploitCount:]
+	poolByJob := make(map[string]*HybridMatchResult, len(pool))
+	candidates := make([]*BanditCandidate, 0, len(pool))
+	for _, match := range pool {
+		job, exists := jobsByID[match.JobID]
+		if !exists {
+			continue
 		}
+		poolByJob[match.JobID] = match
+		candidates = append(candidates, &BanditCandidate{
+			JobID:   match.JobID,
+			Context: BuildBanditContext(user, job, match.FinalScore),
+		})
 	}
-	
-	// Normalize weights to sum to 1.0
-	s.normalizeEnsembleWeights()
-}
 
-func (s *HybridMatchingService) normalizeEnsembleWeights() {
-	total := 0.0
-	for _, weight := range s.ensembleWeights {
-		total += weight
+	decisions, err := s.banditService.SelectExploratory(ctx, user.ID, candidates, slots)
+	if err != nil {
+		return matches[:min(limit, len(matches))]
 	}
-	
-	if total > 0 {
-		for model := range s.ensembleWeights {
-			s.ensembleWeights[model] /= total
-		}
+
+	result := append([]*HybridMatchResult{}, matches[:exploitCount]...)
+	for _, decision := range decisions {
+		match := poolByJob[decision.JobID]
+		match.Exploration = decision
+		result = append(result, match)
 	}
+
+	return result
 }
 
 func (s *HybridMatchingService) sortMatchesByScore(matches []*HybridMatchResult) {
@@ -726,6 +874,41 @@ func (s *HybridMatchingService) getCandidateJobs(ctx context.Context, userID str
 	return jobs, nil
 }
 
+// loadUser reads a user through the entity loader, falling back to mock data when
+// none is configured
+func (s *HybridMatchingService) loadUser(ctx context.Context, userID string) (*coreModels.User, error) {
+	if s.entityLoader == nil {
+		user, _ := s.getMockUserAndJobData(userID)
+		return user, nil
+	}
+
+	user, err := s.entityLoader.LoadUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user %s: %w", userID, err)
+	}
+	return user, nil
+}
+
+// loadEntities reads a user and job through the entity loader. Without one it falls
+// back to mock data and reports loaded as false.
+func (s *HybridMatchingService) loadEntities(ctx context.Context, userID, jobID string) (*coreModels.User, *coreModels.Job, bool, error) {
+	if s.entityLoader == nil {
+		user, job := s.getMockUserAndJobData(userID)
+		job.ID = jobID
+		return user, job, false, nil
+	}
+
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	job, err := s.entityLoader.LoadJob(ctx, jobID)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to load job %s: %w", jobID, err)
+	}
+	return user, job, true, nil
+}
+
 func (s *HybridMatchingService) getMockUserAndJobData(userID string) (*coreModels.User, *coreModels.Job) {
 	// Mock user data - replace with actual data fetching
 	user := &coreModels.User{
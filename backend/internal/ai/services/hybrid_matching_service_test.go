@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"microbridge/backend/internal/ai/features"
 	"microbridge/backend/internal/ai/models"
 	"microbridge/backend/internal/core/matching"
+	coreModels "microbridge/backend/internal/models"
 )
 
 func TestHybridMatchingService_FindBestMatches(t *testing.T) {
@@ -127,6 +130,65 @@ func TestHybridMatchingService_CalculateMatchScore(t *testing.T) {
 	}
 }
 
+func TestHybridMatchingService_FeatureStoreOnlyCachesLoadedEntities(t *testing.T) {
+	hybridService := createTestHybridService()
+	cache := features.NewMemoryCache()
+	featureStore := features.NewStore(features.DefaultRegistry(), cache, nil, nil)
+	hybridService.SetFeatureStore(featureStore)
+	ctx := context.Background()
+
+	// Mock entities never reach the feature store
+	match, err := hybridService.CalculateMatchScore(ctx, "user1", "job1")
+	if err != nil {
+		t.Fatalf("CalculateMatchScore failed: %v", err)
+	}
+	if match.FeatureVector != nil {
+		t.Error("Expected no feature vector for mock entities")
+	}
+	if _, err := hybridService.FindBestMatches(ctx, "user1", 3); err != nil {
+		t.Fatalf("FindBestMatches failed: %v", err)
+	}
+	if stats := featureStore.GetStats(); stats.Hits+stats.Misses != 0 {
+		t.Errorf("Expected the feature store to be untouched, got %+v", stats)
+	}
+
+	hybridService.SetEntityLoader(&mockEntityLoader{})
+	match, err = hybridService.CalculateMatchScore(ctx, "user1", "job1")
+	if err != nil {
+		t.Fatalf("CalculateMatchScore failed: %v", err)
+	}
+	if match.FeatureVector == nil {
+		t.Fatal("Expected a feature vector for loaded entities")
+	}
+
+	if _, err := hybridService.CalculateMatchScore(ctx, "missing_user", "job1"); err == nil {
+		t.Error("Expected an error when the user can't be loaded")
+	}
+}
+
+func TestHybridMatchingService_DriftOnlyObservesLoadedEntities(t *testing.T) {
+	hybridService := createTestHybridService()
+	monitor := NewDriftMonitor(&models.DriftConfig{ReferenceSize: 10, WindowSize: 10, MinWindowSamples: 5}, nil, nil, nil)
+	hybridService.SetDriftMonitor(monitor)
+	ctx := context.Background()
+
+	// Mock entities would skew the reference distribution
+	if _, err := hybridService.CalculateMatchScore(ctx, "user1", "job1"); err != nil {
+		t.Fatalf("CalculateMatchScore failed: %v", err)
+	}
+	if _, exists := monitor.trackers[DriftMetricFinalScore]; exists {
+		t.Error("Expected matches on mock entities not to be observed")
+	}
+
+	hybridService.SetEntityLoader(&mockEntityLoader{})
+	if _, err := hybridService.CalculateMatchScore(ctx, "user1", "job1"); err != nil {
+		t.Fatalf("CalculateMatchScore failed: %v", err)
+	}
+	if tracker, exists := monitor.trackers[DriftMetricFinalScore]; !exists || len(tracker.reference) != 1 {
+		t.Error("Expected the match on loaded entities to be observed once")
+	}
+}
+
 func TestHybridMatchingService_ProcessUserFeedback(t *testing.T) {
 	hybridService := createTestHybridService()
 	ctx := context.Background()
@@ -160,6 +222,47 @@ func TestHybridMatchingService_ProcessUserFeedback(t *testing.T) {
 	}
 }
 
+// newStudentLoader loads every user as a student who signed up just now
+type newStudentLoader struct {
+	mockEntityLoader
+}
+
+func (l *newStudentLoader) LoadUser(ctx context.Context, userID string) (*coreModels.User, error) {
+	user, err := l.mockEntityLoader.LoadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.CreatedAt = time.Now()
+	return user, nil
+}
+
+func TestHybridMatchingService_FeedbackLearnsLoadedSegments(t *testing.T) {
+	hybridService := createTestHybridService()
+	hybridService.SetEntityLoader(&newStudentLoader{})
+	ctx := context.Background()
+
+	if _, err := hybridService.CalculateMatchScore(ctx, "user1", "job1"); err != nil {
+		t.Fatalf("CalculateMatchScore failed: %v", err)
+	}
+
+	// The NCF model has no embeddings for this pair, so its update fails; the
+	// ensemble weights must still learn from the outcome
+	if err := hybridService.ProcessUserFeedback(ctx, "user1", "job1", "match1", "applied", 1.0); err == nil {
+		t.Error("Expected the NCF update error to be returned")
+	}
+
+	segments := make(map[string]int64)
+	for _, segment := range hybridService.GetEnsembleWeights().Segments {
+		segments[segment.Segment] = segment.Updates
+	}
+	if segments[SegmentNewStudent] != 1 {
+		t.Errorf("Expected one update for the loaded user's segment, got %v", segments)
+	}
+	if _, exists := segments[SegmentActiveStudent]; exists {
+		t.Errorf("Expected no update for the mock user's segment, got %v", segments)
+	}
+}
+
 func TestHybridMatchingService_GetMatchExplanation(t *testing.T) {
 	hybridService := createTestHybridService()
 	ctx := context.Background()
@@ -186,6 +289,22 @@ func TestHybridMatchingService_GetMatchExplanation(t *testing.T) {
 	}
 }
 
+func TestHybridMatchingService_LLMInsightsRequireLoadedEntities(t *testing.T) {
+	hybridService := createTestHybridService()
+	hybridService.SetEntityLoader(&mockEntityLoader{})
+	ctx := context.Background()
+
+	if _, err := hybridService.GetMatchExplanation(ctx, "missing_user", "job1", "free"); err == nil {
+		t.Error("Expected GetMatchExplanation to fail when the user can't be loaded")
+	}
+	if _, err := hybridService.GetSkillGapAnalysis(ctx, "missing_user", "job1"); err == nil {
+		t.Error("Expected GetSkillGapAnalysis to fail when the user can't be loaded")
+	}
+	if _, err := hybridService.GetCareerAdvice(ctx, "missing_user", "backend engineer"); err == nil {
+		t.Error("Expected GetCareerAdvice to fail when the user can't be loaded")
+	}
+}
+
 func TestHybridMatchingService_EnsembleWeights(t *testing.T) {
 	hybridService := createTestHybridService()
 
@@ -121,7 +121,8 @@ func NewLLMService(apiKey, baseURL, model string) *LLMService {
 }
 
 // ExplainMatch generates an explanation for why a job matches a user
-func (s *LLMService) ExplainMatch(ctx context.Context, userID string, match *matching.MatchScore, user *coreModels.User, job *coreModels.Job) (*LLMResponse, error) {
+// attribution may be nil; when present its facts ground the explanation in what drove the score
+func (s *LLMService) ExplainMatch(ctx context.Context, userID string, match *matching.MatchScore, attribution *MatchAttribution, user *coreModels.User, job *coreModels.Job) (*LLMResponse, error) {
 	// Check quota first
 	if !s.checkQuota(userID, "explanation") {
 		return nil, fmt.Errorf("explanation quota exceeded for user %s", userID)
@@ -159,6 +160,7 @@ func (s *LLMService) ExplainMatch(ctx context.Context, userID string, match *mat
 		"missing_skills":     match.MissingSkills,
 		"skill_gaps":         match.SkillGaps,
 		"match_quality":      match.MatchQuality,
+		"score_drivers":      attribution.Facts(),
 	}
 
 	// Build prompt
@@ -371,7 +373,8 @@ func (s *LLMService) GetCostMetrics(ctx context.Context) (*CostMetrics, error) {
 func (s *LLMService) initializePromptTemplates() {
 	s.templateCache["match_explanation_system"] = `You are a career advisor AI that explains why jobs match user profiles. 
 Provide concise, personalized explanations focusing on skill alignment, experience fit, and career growth potential. 
-Keep responses under 200 words and be encouraging while being honest about gaps.`
+Keep responses under 200 words and be encouraging while being honest about gaps.
+Base every claim about why the score is high or low on the score drivers provided; do not invent other reasons.`
 
 	s.templateCache["skill_gap_analysis_system"] = `You are a skill development expert. Analyze the gap between a user's current skills and target job requirements.
 Provide specific, actionable learning recommendations with estimated timelines. Prioritize skills by importance and learning difficulty.
@@ -402,7 +405,7 @@ Match Analysis:
 - Matched Skills: %v
 - Missing Skills: %v
 - Match Quality: %s
-
+%s
 Provide a brief, encouraging explanation highlighting strengths and addressing any gaps.`,
 		context["match_score"].(float64)*100,
 		context["user_skills"],
@@ -416,7 +419,24 @@ Provide a brief, encouraging explanation highlighting strengths and addressing a
 		context["job_category"],
 		context["matched_skills"],
 		context["missing_skills"],
-		context["match_quality"])
+		context["match_quality"],
+		formatScoreDrivers(context["score_drivers"]))
+}
+
+func formatScoreDrivers(drivers interface{}) string {
+	facts, ok := drivers.([]string)
+	if !ok || len(facts) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("\nWhat drove this score:\n")
+	for _, fact := range facts {
+		builder.WriteString("- ")
+		builder.WriteString(fact)
+		builder.WriteString("\n")
+	}
+	return builder.String()
 }
 
 func (s *LLMService) buildSkillGapAnalysisPrompt(context map[string]interface{}) string {
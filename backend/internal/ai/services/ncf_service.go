@@ -2,12 +2,14 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/rand"
 	"sync"
 	"time"
 
+	"microbridge/backend/internal/ai/features"
 	"microbridge/backend/internal/ai/models"
 	"microbridge/backend/internal/ai/utils"
 )
@@ -32,6 +34,7 @@ type NCFService struct {
 	modelVersion          string
 	lastTrainingTime      time.Time
 	performanceMetrics    *models.ModelPerformanceMetrics
+	featureStore          *features.Store
 }
 
 // NewNCFService creates a new Neural Collaborative Filtering service
@@ -59,6 +62,11 @@ func (s *NCFService) PredictUserJobInteraction(ctx context.Context, userID, jobI
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	
+	return s.predict(ctx, userID, jobID)
+}
+
+// predict scores a user-job pair; callers must hold s.mu
+func (s *NCFService) predict(ctx context.Context, userID, jobID string) (float64, error) {
 	// Get user and job embeddings
 	userEmb, userExists := s.userEmbeddings[userID]
 	jobEmb, jobExists := s.jobEmbeddings[jobID]
@@ -113,7 +121,7 @@ func (s *NCFService) GetTopRecommendations(ctx context.Context, userID string, c
 			ConfidenceScore:   confidence,
 			PredictionScore:   score,
 			SuccessProbability: score, // For NCF, prediction score is success probability
-			Features:          s.extractFeatures(userID, jobID),
+			Features:          s.recommendationFeatures(ctx, userID, jobID),
 			CreatedAt:         time.Now(),
 			UpdatedAt:         time.Now(),
 		}
@@ -134,23 +142,179 @@ func (s *NCFService) GetTopRecommendations(ctx context.Context, userID string, c
 
 // TrainModel trains the NCF model with provided data
 func (s *NCFService) TrainModel(ctx context.Context, trainingData []*models.TrainingData, config *models.NCFConfig) error {
+	_, err := s.TrainModelWithProgress(ctx, trainingData, config, nil)
+	return err
+}
+
+// TrainModelWithProgress trains the NCF model, reporting each epoch to onEpoch and
+// stopping early when ctx is cancelled. It returns the final validation metrics.
+func (s *NCFService) TrainModelWithProgress(ctx context.Context, trainingData []*models.TrainingData, config *models.NCFConfig, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	// Split data into training and validation
+	trainData, validData := s.splitData(trainingData, config.ValidationSplit)
+	return s.fit(ctx, trainData, validData, config, onEpoch)
+}
+
+// TrainWithHoldout trains on trainData and validates every epoch against validData,
+// which the caller has already held out of the training set
+func (s *NCFService) TrainWithHoldout(ctx context.Context, trainData, validData []*models.TrainingData, config *models.NCFConfig, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	
+	return s.fit(ctx, trainData, validData, config, onEpoch)
+}
+
+// Clone returns an independent copy of the model for training off to the side of
+// the serving instance. The copy gets its own version.
+func (s *NCFService) Clone() *NCFService {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	clone := &NCFService{
+		userEmbeddings:   make(map[string][]float64, len(s.userEmbeddings)),
+		jobEmbeddings:    make(map[string][]float64, len(s.jobEmbeddings)),
+		userBias:         make(map[string]float64, len(s.userBias)),
+		jobBias:          make(map[string]float64, len(s.jobBias)),
+		globalBias:       s.globalBias,
+		embeddingDim:     s.embeddingDim,
+		hiddenLayers:     append([]int(nil), s.hiddenLayers...),
+		mlpWeights:       copyFloatMatrix(s.mlpWeights),
+		mlpBiases:        copyFloatMatrix(s.mlpBiases),
+		learningRate:     s.learningRate,
+		regularization:   s.regularization,
+		modelVersion:     fmt.Sprintf("ncf_v%d", time.Now().UnixNano()),
+		lastTrainingTime: s.lastTrainingTime,
+	}
+	for userID, embedding := range s.userEmbeddings {
+		clone.userEmbeddings[userID] = append([]float64(nil), embedding...)
+	}
+	for jobID, embedding := range s.jobEmbeddings {
+		clone.jobEmbeddings[jobID] = append([]float64(nil), embedding...)
+	}
+	for userID, bias := range s.userBias {
+		clone.userBias[userID] = bias
+	}
+	for jobID, bias := range s.jobBias {
+		clone.jobBias[jobID] = bias
+	}
+	
+	return clone
+}
+
+// ReplaceWith swaps in the weights of a trained clone. Online updates applied to
+// this instance since the clone was taken are dropped with the old weights; the
+// candidate must not be used afterwards.
+func (s *NCFService) ReplaceWith(candidate *NCFService) {
+	candidate.mu.RLock()
+	defer candidate.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.userEmbeddings = candidate.userEmbeddings
+	s.jobEmbeddings = candidate.jobEmbeddings
+	s.userBias = candidate.userBias
+	s.jobBias = candidate.jobBias
+	s.globalBias = candidate.globalBias
+	s.mlpWeights = candidate.mlpWeights
+	s.mlpBiases = candidate.mlpBiases
+	s.trainingData = candidate.trainingData
+	s.validationData = candidate.validationData
+	s.modelVersion = candidate.modelVersion
+	s.lastTrainingTime = candidate.lastTrainingTime
+	s.performanceMetrics = candidate.performanceMetrics
+}
+
+// ncfWeights is the serialized form of a trained NCF model
+type ncfWeights struct {
+	Version        string               `json:"version"`
+	TrainedAt      time.Time            `json:"trained_at"`
+	UserEmbeddings map[string][]float64 `json:"user_embeddings"`
+	JobEmbeddings  map[string][]float64 `json:"job_embeddings"`
+	UserBias       map[string]float64   `json:"user_bias"`
+	JobBias        map[string]float64   `json:"job_bias"`
+	GlobalBias     float64              `json:"global_bias"`
+	MLPWeights     [][]float64          `json:"mlp_weights"`
+	MLPBiases      [][]float64          `json:"mlp_biases"`
+}
+
+// MarshalWeights serializes the learned embeddings, biases and MLP layers
+func (s *NCFService) MarshalWeights() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return json.Marshal(&ncfWeights{
+		Version:        s.modelVersion,
+		TrainedAt:      s.lastTrainingTime,
+		UserEmbeddings: s.userEmbeddings,
+		JobEmbeddings:  s.jobEmbeddings,
+		UserBias:       s.userBias,
+		JobBias:        s.jobBias,
+		GlobalBias:     s.globalBias,
+		MLPWeights:     s.mlpWeights,
+		MLPBiases:      s.mlpBiases,
+	})
+}
+
+// RestoreWeights loads weights written by MarshalWeights. Weights trained for a
+// different embedding size or MLP layout are rejected.
+func (s *NCFService) RestoreWeights(data []byte) error {
+	var weights ncfWeights
+	if err := json.Unmarshal(data, &weights); err != nil {
+		return fmt.Errorf("failed to decode NCF weights: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !sameShape(weights.MLPWeights, s.mlpWeights) || !sameShape(weights.MLPBiases, s.mlpBiases) {
+		return fmt.Errorf("NCF weights do not match the configured MLP layout")
+	}
+	for _, embeddings := range []map[string][]float64{weights.UserEmbeddings, weights.JobEmbeddings} {
+		for id, embedding := range embeddings {
+			if len(embedding) != s.embeddingDim {
+				return fmt.Errorf("NCF embedding for %s has %d dimensions, expected %d", id, len(embedding), s.embeddingDim)
+			}
+		}
+	}
+
+	s.userEmbeddings = nonNilEmbeddings(weights.UserEmbeddings)
+	s.jobEmbeddings = nonNilEmbeddings(weights.JobEmbeddings)
+	s.userBias = nonNilBiases(weights.UserBias)
+	s.jobBias = nonNilBiases(weights.JobBias)
+	s.globalBias = weights.GlobalBias
+	s.mlpWeights = weights.MLPWeights
+	s.mlpBiases = weights.MLPBiases
+	s.modelVersion = weights.Version
+	s.lastTrainingTime = weights.TrainedAt
+	return nil
+}
+
+// fit runs the training loop; callers must hold s.mu
+func (s *NCFService) fit(ctx context.Context, trainData, validData []*models.TrainingData, config *models.NCFConfig, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
 	s.isTraining = true
 	defer func() { s.isTraining = false }()
 	
-	s.trainingData = trainingData
-	
-	// Split data into training and validation
-	trainData, validData := s.splitData(trainingData, config.ValidationSplit)
+	s.trainingData = trainData
 	s.validationData = validData
 	
 	// Initialize embeddings if not exist
 	s.initializeEmbeddings(trainData)
 	
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(trainData)
+	}
+	
+	validationMetrics := &models.ValidationMetrics{}
+	
 	// Training loop
-	for epoch := 0; epoch < config.MaxEpochs; epoch++ {
+	for epoch := 0; epoch < config.MaxEpochs && len(trainData) > 0; epoch++ {
+		if err := ctx.Err(); err != nil {
+			return validationMetrics, err
+		}
+		
 		// Shuffle training data
 		s.shuffleData(trainData)
 		
@@ -158,8 +322,8 @@ func (s *NCFService) TrainModel(ctx context.Context, trainingData []*models.Trai
 		totalLoss := 0.0
 		batchCount := 0
 		
-		for i := 0; i < len(trainData); i += config.BatchSize {
-			end := min(i+config.BatchSize, len(trainData))
+		for i := 0; i < len(trainData); i += batchSize {
+			end := min(i+batchSize, len(trainData))
 			batch := trainData[i:end]
 			
 			batchLoss := s.trainBatch(batch, config)
@@ -170,11 +334,15 @@ func (s *NCFService) TrainModel(ctx context.Context, trainingData []*models.Trai
 		avgLoss := totalLoss / float64(batchCount)
 		
 		// Validate
-		validationMetrics := s.validateModel(validData)
+		validationMetrics = s.validateModel(validData)
 		
 		fmt.Printf("Epoch %d - Loss: %.4f, Val Accuracy: %.4f\n", 
 			epoch+1, avgLoss, validationMetrics.Accuracy)
 		
+		if onEpoch != nil {
+			onEpoch(epoch+1, avgLoss, validationMetrics)
+		}
+		
 		// Early stopping check
 		if config.EarlyStopping && s.shouldEarlyStop(validationMetrics, epoch) {
 			fmt.Printf("Early stopping at epoch %d\n", epoch+1)
@@ -185,7 +353,15 @@ func (s *NCFService) TrainModel(ctx context.Context, trainingData []*models.Trai
 	s.lastTrainingTime = time.Now()
 	s.updatePerformanceMetrics()
 	
-	return nil
+	return validationMetrics, nil
+}
+
+// SetFeatureStore attaches the shared feature store recommendations read their
+// features from
+func (s *NCFService) SetFeatureStore(featureStore *features.Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.featureStore = featureStore
 }
 
 // UpdateEmbeddings updates user/job embeddings with new interaction data
@@ -193,6 +369,11 @@ func (s *NCFService) UpdateEmbeddings(ctx context.Context, userID, jobID string,
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	
+	return s.updateEmbeddings(ctx, userID, jobID, interaction)
+}
+
+// updateEmbeddings applies one SGD step for an interaction; callers must hold s.mu
+func (s *NCFService) updateEmbeddings(ctx context.Context, userID, jobID string, interaction float64) error {
 	// Online learning - update embeddings incrementally
 	userEmb := s.userEmbeddings[userID]
 	jobEmb := s.jobEmbeddings[jobID]
@@ -202,7 +383,7 @@ func (s *NCFService) UpdateEmbeddings(ctx context.Context, userID, jobID string,
 	}
 	
 	// Compute prediction error
-	prediction, _ := s.PredictUserJobInteraction(ctx, userID, jobID)
+	prediction, _ := s.predict(ctx, userID, jobID)
 	error := interaction - prediction
 	
 	// Update embeddings using SGD
@@ -428,24 +609,19 @@ func (s *NCFService) vectorNorm(vec []float64) float64 {
 	return math.Sqrt(sum)
 }
 
-func (s *NCFService) extractFeatures(userID, jobID string) map[string]float64 {
-	features := make(map[string]float64)
-	
-	// Embedding features
-	if userEmb := s.userEmbeddings[userID]; userEmb != nil {
-		features["user_embedding_norm"] = s.vectorNorm(userEmb)
+// recommendationFeatures returns the shared feature values for a pair, so a
+// recommendation carries what training read for it. Without a feature store, or
+// when the entities can't be loaded, the recommendation carries none.
+func (s *NCFService) recommendationFeatures(ctx context.Context, userID, jobID string) map[string]float64 {
+	if s.featureStore == nil {
+		return nil
 	}
 	
-	if jobEmb := s.jobEmbeddings[jobID]; jobEmb != nil {
-		features["job_embedding_norm"] = s.vectorNorm(jobEmb)
+	vector, err := s.featureStore.FeatureVectorByID(ctx, userID, jobID)
+	if err != nil {
+		return nil
 	}
-	
-	// Bias features
-	features["user_bias"] = s.userBias[userID]
-	features["job_bias"] = s.jobBias[jobID]
-	features["global_bias"] = s.globalBias
-	
-	return features
+	return features.Flatten(vector)
 }
 
 func (s *NCFService) sortRecommendations(recommendations []*models.AIRecommendation) {
@@ -475,12 +651,12 @@ func (s *NCFService) trainBatch(batch []*models.TrainingData, config *models.NCF
 	totalLoss := 0.0
 	
 	for _, sample := range batch {
-		prediction, _ := s.PredictUserJobInteraction(context.Background(), sample.UserID, sample.JobID)
+		prediction, _ := s.predict(context.Background(), sample.UserID, sample.JobID)
 		loss := (sample.Label - prediction) * (sample.Label - prediction)
 		totalLoss += loss
 		
 		// Update embeddings
-		s.UpdateEmbeddings(context.Background(), sample.UserID, sample.JobID, sample.Label)
+		s.updateEmbeddings(context.Background(), sample.UserID, sample.JobID, sample.Label)
 	}
 	
 	return totalLoss / float64(len(batch))
@@ -495,7 +671,7 @@ func (s *NCFService) validateModel(validationData []*models.TrainingData) *model
 	totalLoss := 0.0
 	
 	for _, sample := range validationData {
-		prediction, _ := s.PredictUserJobInteraction(context.Background(), sample.UserID, sample.JobID)
+		prediction, _ := s.predict(context.Background(), sample.UserID, sample.JobID)
 		
 		// Binary classification accuracy (threshold at 0.5)
 		predicted := 0.0
@@ -556,6 +732,44 @@ func (s *NCFService) updatePerformanceMetrics() {
 
 // Helper functions
 
+// sameShape reports whether two matrices have the same row count and row lengths
+func sameShape(a, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func nonNilEmbeddings(embeddings map[string][]float64) map[string][]float64 {
+	if embeddings == nil {
+		return make(map[string][]float64)
+	}
+	return embeddings
+}
+
+func nonNilBiases(biases map[string]float64) map[string]float64 {
+	if biases == nil {
+		return make(map[string]float64)
+	}
+	return biases
+}
+
+func copyFloatMatrix(source [][]float64) [][]float64 {
+	if source == nil {
+		return nil
+	}
+	target := make([][]float64, len(source))
+	for i := range source {
+		target[i] = append([]float64(nil), source[i]...)
+	}
+	return target
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"microbridge/backend/internal/ai/features"
 	"microbridge/backend/internal/ai/models"
 	"microbridge/backend/internal/ai/utils"
 )
@@ -121,6 +122,38 @@ func TestNCFService_GetTopRecommendations(t *testing.T) {
 	}
 }
 
+func TestNCFService_RecommendationsCarryStoreFeatures(t *testing.T) {
+	service := NewNCFService(&models.NCFConfig{EmbeddingDim: 16, HiddenLayers: []int{32, 16}})
+	ctx := context.Background()
+
+	recommendations, err := service.GetTopRecommendations(ctx, "user1", []string{"job1"}, 1)
+	if err != nil {
+		t.Fatalf("GetTopRecommendations failed: %v", err)
+	}
+	if len(recommendations[0].Features) != 0 {
+		t.Errorf("Expected no features without a feature store, got %v", recommendations[0].Features)
+	}
+
+	registry := features.DefaultRegistry()
+	service.SetFeatureStore(features.NewStore(registry, features.NewMemoryCache(), &mockEntityLoader{}, nil))
+	recommendations, err = service.GetTopRecommendations(ctx, "user1", []string{"job1"}, 1)
+	if err != nil {
+		t.Fatalf("GetTopRecommendations failed: %v", err)
+	}
+
+	user, job := (&HybridMatchingService{}).getMockUserAndJobData("user1")
+	want := features.Flatten(registry.ComputeVector(user, job))
+	got := recommendations[0].Features
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d store features, got %d", len(want), len(got))
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("Train/serve skew on %s: %f vs %f", key, value, got[key])
+		}
+	}
+}
+
 func TestNCFService_UpdateEmbeddings(t *testing.T) {
 	config := &models.NCFConfig{
 		EmbeddingDim: 8,
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/rand"
@@ -123,20 +124,29 @@ type Normalizer struct {
 
 // NewRLService creates a new Reinforcement Learning service
 func NewRLService(config *models.RLConfig) *RLService {
+	memorySize := config.MemorySize
+	if memorySize <= 0 {
+		memorySize = 10000
+	}
+	targetUpdateFreq := config.TargetUpdateFreq
+	if targetUpdateFreq <= 0 {
+		targetUpdateFreq = 100
+	}
+
 	service := &RLService{
 		epsilon:               config.ExplorationRate,
 		epsilonDecay:          config.ExplorationDecay,
 		epsilonMin:            0.01,
 		learningRate:          config.LearningRate,
 		discountFactor:        config.DiscountFactor,
-		targetUpdateFrequency: config.TargetUpdateFreq,
+		targetUpdateFrequency: targetUpdateFreq,
 		modelVersion:          fmt.Sprintf("rl_v%d", time.Now().Unix()),
 		performanceMetrics:    &RLPerformanceMetrics{},
 	}
 
 	// Initialize components
 	service.initializeQNetwork(config.StateSpaceDim, config.ActionSpaceDim)
-	service.initializeExperienceReplay(config.MemorySize)
+	service.initializeExperienceReplay(memorySize)
 	service.initializeStateEncoder()
 	service.initializeActionDecoder()
 
@@ -148,16 +158,41 @@ func (s *RLService) ProcessUserFeedback(ctx context.Context, userID, jobID, matc
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	experience, err := s.buildExperience(ctx, userID, jobID, action, outcome)
+	if err != nil {
+		return err
+	}
+
+	// Add to experience replay buffer
+	s.experienceReplay.Add(experience)
+
+	// Train if we have enough experiences
+	if s.experienceReplay.Size() > 32 { // Minimum batch size
+		err = s.trainStep()
+		if err != nil {
+			return fmt.Errorf("training step failed: %w", err)
+		}
+	}
+
+	// Update performance metrics
+	s.updatePerformanceMetrics(experience.Reward, experience.Action)
+
+	return nil
+}
+
+// buildExperience turns one feedback event into a terminal experience; callers
+// must hold s.mu
+func (s *RLService) buildExperience(ctx context.Context, userID, jobID, action string, outcome float64) (*Experience, error) {
 	// Extract state from current context
 	state, err := s.extractState(ctx, userID, jobID)
 	if err != nil {
-		return fmt.Errorf("failed to extract state: %w", err)
+		return nil, fmt.Errorf("failed to extract state: %w", err)
 	}
 
 	// Convert action string to action index
 	actionIndex, err := s.encodeAction(action)
 	if err != nil {
-		return fmt.Errorf("failed to encode action: %w", err)
+		return nil, fmt.Errorf("failed to encode action: %w", err)
 	}
 
 	// Calculate reward based on user outcome
@@ -166,31 +201,14 @@ func (s *RLService) ProcessUserFeedback(ctx context.Context, userID, jobID, matc
 	// Get next state (if available)
 	nextState := state // Simplified - would normally get actual next state
 
-	// Create experience
-	experience := &Experience{
+	return &Experience{
 		State:     state,
 		Action:    actionIndex,
 		Reward:    reward,
 		NextState: nextState,
 		Done:      true, // Simplified - each interaction is considered terminal
 		Timestamp: time.Now(),
-	}
-
-	// Add to experience replay buffer
-	s.experienceReplay.Add(experience)
-
-	// Train if we have enough experiences
-	if s.experienceReplay.Size() > 32 { // Minimum batch size
-		err = s.trainStep()
-		if err != nil {
-			return fmt.Errorf("training step failed: %w", err)
-		}
-	}
-
-	// Update performance metrics
-	s.updatePerformanceMetrics(reward, actionIndex)
-
-	return nil
+	}, nil
 }
 
 // GetOptimalAction returns the best action for a given user-job pair
@@ -268,24 +286,226 @@ func (s *RLService) GetRecommendationScore(ctx context.Context, userID, jobID st
 
 // TrainFromBatch trains the RL model on a batch of experiences
 func (s *RLService) TrainFromBatch(ctx context.Context, batchSize int, epochs int) error {
+	_, err := s.TrainFromBatchWithProgress(ctx, batchSize, epochs, nil)
+	return err
+}
+
+// TrainFromBatchWithProgress trains the RL model, reporting each epoch to onEpoch and
+// stopping early when ctx is cancelled. The returned metrics hold the final batch loss.
+func (s *RLService) TrainFromBatchWithProgress(ctx context.Context, batchSize int, epochs int, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.fit(ctx, batchSize, epochs, nil, onEpoch)
+}
+
+// TrainOnOutcomes loads labelled outcomes into the experience buffer, trains on
+// them and reports each epoch's temporal difference error on validData, which the
+// caller has already held out of the training set
+func (s *RLService) TrainOnOutcomes(ctx context.Context, trainData, validData []*models.TrainingData, batchSize int, epochs int, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	for _, sample := range trainData {
+		experience, err := s.buildExperience(ctx, sample.UserID, sample.JobID, outcomeAction(sample), sample.Label)
+		if err != nil {
+			return nil, err
+		}
+		s.experienceReplay.Add(experience)
+	}
+
+	validation := make([]*Experience, 0, len(validData))
+	for _, sample := range validData {
+		experience, err := s.buildExperience(ctx, sample.UserID, sample.JobID, outcomeAction(sample), sample.Label)
+		if err != nil {
+			return nil, err
+		}
+		validation = append(validation, experience)
+	}
+
+	if batchSize > len(trainData) {
+		batchSize = len(trainData)
+	}
+	return s.fit(ctx, batchSize, epochs, validation, onEpoch)
+}
+
+// Clone returns a copy of the networks with an empty experience buffer for training
+// off to the side of the serving instance. The copy gets its own version.
+func (s *RLService) Clone() *RLService {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metrics := *s.performanceMetrics
+	metrics.LearningProgress = append([]float64(nil), s.performanceMetrics.LearningProgress...)
+
+	clone := &RLService{
+		qNetwork:              s.copyQNetwork(s.qNetwork),
+		targetNetwork:         s.copyQNetwork(s.targetNetwork),
+		stateEncoder:          s.stateEncoder,
+		actionDecoder:         s.actionDecoder,
+		epsilon:               s.epsilon,
+		epsilonDecay:          s.epsilonDecay,
+		epsilonMin:            s.epsilonMin,
+		learningRate:          s.learningRate,
+		discountFactor:        s.discountFactor,
+		targetUpdateFrequency: s.targetUpdateFrequency,
+		trainingSteps:         s.trainingSteps,
+		modelVersion:          fmt.Sprintf("rl_v%d", time.Now().UnixNano()),
+		lastTrainingTime:      s.lastTrainingTime,
+		performanceMetrics:    &metrics,
+	}
+	clone.initializeExperienceReplay(s.experienceReplay.maxSize)
+
+	return clone
+}
+
+// ReplaceWith swaps in the networks of a trained clone. The live experience buffer
+// and reward metrics stay, so outcomes the clone trained on are not counted twice;
+// the candidate must not be used afterwards.
+func (s *RLService) ReplaceWith(candidate *RLService) {
+	candidate.mu.RLock()
+	defer candidate.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.qNetwork = candidate.qNetwork
+	s.targetNetwork = candidate.targetNetwork
+	s.epsilon = candidate.epsilon
+	s.trainingSteps = candidate.trainingSteps
+	s.modelVersion = candidate.modelVersion
+	s.lastTrainingTime = candidate.lastTrainingTime
+}
+
+// rlWeights is the serialized form of a trained RL model
+type rlWeights struct {
+	Version       string          `json:"version"`
+	TrainedAt     time.Time       `json:"trained_at"`
+	QNetwork      models.QNetwork `json:"q_network"`
+	TargetNetwork models.QNetwork `json:"target_network"`
+	Epsilon       float64         `json:"epsilon"`
+	TrainingSteps int             `json:"training_steps"`
+}
+
+// MarshalWeights serializes the Q and target networks. The experience buffer is
+// not included; it refills from live outcomes.
+func (s *RLService) MarshalWeights() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return json.Marshal(&rlWeights{
+		Version:       s.modelVersion,
+		TrainedAt:     s.lastTrainingTime,
+		QNetwork:      exportQNetwork(s.qNetwork),
+		TargetNetwork: exportQNetwork(s.targetNetwork),
+		Epsilon:       s.epsilon,
+		TrainingSteps: s.trainingSteps,
+	})
+}
+
+// RestoreWeights loads weights written by MarshalWeights. Networks trained for a
+// different state or action space are rejected.
+func (s *RLService) RestoreWeights(data []byte) error {
+	var weights rlWeights
+	if err := json.Unmarshal(data, &weights); err != nil {
+		return fmt.Errorf("failed to decode RL weights: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	qNetwork, err := importQNetwork(&weights.QNetwork, s.qNetwork)
+	if err != nil {
+		return err
+	}
+	targetNetwork, err := importQNetwork(&weights.TargetNetwork, s.targetNetwork)
+	if err != nil {
+		return err
+	}
+
+	s.qNetwork = qNetwork
+	s.targetNetwork = targetNetwork
+	s.epsilon = weights.Epsilon
+	s.trainingSteps = weights.TrainingSteps
+	s.modelVersion = weights.Version
+	s.lastTrainingTime = weights.TrainedAt
+	return nil
+}
+
+// exportQNetwork converts a network into its serializable model form
+func exportQNetwork(network *QNetwork) models.QNetwork {
+	exported := models.QNetwork{InputDim: network.inputDim, OutputDim: network.outputDim}
+	for i, layer := range network.layers {
+		if i < len(network.layers)-1 {
+			exported.HiddenDims = append(exported.HiddenDims, layer.outputSize)
+		}
+		exported.Weights = append(exported.Weights, layer.weights)
+		exported.Biases = append(exported.Biases, layer.biases)
+		exported.Activations = append(exported.Activations, layer.activation)
+	}
+	return exported
+}
+
+// importQNetwork rebuilds a network from its model form, checking it has the
+// same layout as the configured network
+func importQNetwork(exported *models.QNetwork, configured *QNetwork) (*QNetwork, error) {
+	if exported.InputDim != configured.inputDim || exported.OutputDim != configured.outputDim ||
+		len(exported.Weights) != len(configured.layers) || len(exported.Biases) != len(configured.layers) ||
+		len(exported.Activations) != len(configured.layers) {
+		return nil, fmt.Errorf("RL weights do not match the configured network layout")
+	}
+
+	network := &QNetwork{inputDim: exported.InputDim, outputDim: exported.OutputDim, layers: make([]Layer, len(configured.layers))}
+	for i, layer := range configured.layers {
+		if !sameShape(exported.Weights[i], layer.weights) || len(exported.Biases[i]) != len(layer.biases) {
+			return nil, fmt.Errorf("RL weights do not match the configured network layout")
+		}
+		network.layers[i] = Layer{
+			weights:    exported.Weights[i],
+			biases:     exported.Biases[i],
+			activation: exported.Activations[i],
+			inputSize:  layer.inputSize,
+			outputSize: layer.outputSize,
+		}
+	}
+	return network, nil
+}
+
+// fit runs the training loop, validating on validation when it is given; callers
+// must hold s.mu
+func (s *RLService) fit(ctx context.Context, batchSize int, epochs int, validation []*Experience, onEpoch models.EpochCallback) (*models.ValidationMetrics, error) {
 	s.isTraining = true
 	defer func() { s.isTraining = false }()
 
 	if s.experienceReplay.Size() < batchSize {
-		return fmt.Errorf("not enough experiences for training: have %d, need %d", s.experienceReplay.Size(), batchSize)
+		return nil, fmt.Errorf("not enough experiences for training: have %d, need %d", s.experienceReplay.Size(), batchSize)
 	}
 
+	metrics := &models.ValidationMetrics{}
+
 	for epoch := 0; epoch < epochs; epoch++ {
+		if err := ctx.Err(); err != nil {
+			return metrics, err
+		}
+
 		// Sample batch from experience replay
 		batch := s.experienceReplay.Sample(batchSize)
 		
 		// Train on batch
 		loss, err := s.trainOnBatch(batch)
 		if err != nil {
-			return fmt.Errorf("batch training failed: %w", err)
+			return metrics, fmt.Errorf("batch training failed: %w", err)
+		}
+
+		if validation != nil {
+			metrics, err = s.evaluate(validation)
+			if err != nil {
+				return metrics, fmt.Errorf("validation failed: %w", err)
+			}
+		} else {
+			metrics = &models.ValidationMetrics{Loss: loss, RMSE: math.Sqrt(loss)}
+		}
+		if onEpoch != nil {
+			onEpoch(epoch+1, loss, metrics)
 		}
 
 		s.trainingSteps++
@@ -304,7 +524,7 @@ func (s *RLService) TrainFromBatch(ctx context.Context, batchSize int, epochs in
 	}
 
 	s.lastTrainingTime = time.Now()
-	return nil
+	return metrics, nil
 }
 
 // GetPerformanceMetrics returns current RL performance metrics
@@ -523,26 +743,12 @@ func (s *RLService) trainOnBatch(batch []*Experience) (float64, error) {
 	totalLoss := 0.0
 	
 	for _, experience := range batch {
-		// Get current Q-values
-		currentQ, err := s.qNetwork.Forward(experience.State)
-		if err != nil {
-			return 0.0, err
-		}
-
-		// Get next Q-values from target network
-		nextQ, err := s.targetNetwork.Forward(experience.NextState)
+		target, currentValue, err := s.temporalDifference(experience)
 		if err != nil {
 			return 0.0, err
 		}
 
-		// Calculate target Q-value using Bellman equation
-		target := experience.Reward
-		if !experience.Done {
-			target += s.discountFactor * s.maxValue(nextQ)
-		}
-
 		// Calculate loss (squared error)
-		currentValue := currentQ[experience.Action]
 		loss := (target - currentValue) * (target - currentValue)
 		totalLoss += loss
 
@@ -553,6 +759,67 @@ func (s *RLService) trainOnBatch(batch []*Experience) (float64, error) {
 	return totalLoss / float64(len(batch)), nil
 }
 
+// evaluate measures the temporal difference error on held-out experiences without
+// updating any weights. An experience counts as correct when the network ranks its
+// action the way its reward says it should.
+func (s *RLService) evaluate(experiences []*Experience) (*models.ValidationMetrics, error) {
+	if len(experiences) == 0 {
+		return &models.ValidationMetrics{}, nil
+	}
+
+	totalLoss := 0.0
+	correct := 0
+	for _, experience := range experiences {
+		target, currentValue, err := s.temporalDifference(experience)
+		if err != nil {
+			return nil, err
+		}
+		totalLoss += (target - currentValue) * (target - currentValue)
+		if (currentValue > 0) == (experience.Reward > 0) {
+			correct++
+		}
+	}
+
+	loss := totalLoss / float64(len(experiences))
+	return &models.ValidationMetrics{
+		Loss:     loss,
+		Accuracy: float64(correct) / float64(len(experiences)),
+		RMSE:     math.Sqrt(loss),
+	}, nil
+}
+
+// temporalDifference returns the Bellman target and the current Q-value for an
+// experience's action
+func (s *RLService) temporalDifference(experience *Experience) (float64, float64, error) {
+	// Get current Q-values
+	currentQ, err := s.qNetwork.Forward(experience.State)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+
+	// Get next Q-values from target network
+	nextQ, err := s.targetNetwork.Forward(experience.NextState)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+
+	// Calculate target Q-value using Bellman equation
+	target := experience.Reward
+	if !experience.Done {
+		target += s.discountFactor * s.maxValue(nextQ)
+	}
+
+	return target, currentQ[experience.Action], nil
+}
+
+// outcomeAction maps a labelled outcome to the feedback action it stands for
+func outcomeAction(sample *models.TrainingData) string {
+	if sample.Label >= 0.5 {
+		return "applied"
+	}
+	return "ignored"
+}
+
 func (s *RLService) updateWeights(state []float64, action int, target, current float64) {
 	// Simplified weight update - in practice would use proper backpropagation
 	learningRate := s.learningRate
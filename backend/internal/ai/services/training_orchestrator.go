@@ -0,0 +1,695 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"microbridge/backend/internal/ai/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// Training job statuses
+const (
+	TrainingStatusPending   = "pending"
+	TrainingStatusRunning   = "running"
+	TrainingStatusCompleted = "completed"
+	TrainingStatusFailed    = "failed"
+	TrainingStatusCancelled = "cancelled"
+)
+
+var (
+	ErrTrainingJobNotFound     = errors.New("training job not found")
+	ErrTrainingQueueFull       = errors.New("training queue is full")
+	ErrUnsupportedModelType    = errors.New("unsupported model type")
+	ErrTrainingJobFinished     = errors.New("training job has already finished")
+	ErrTrainingOrchestratorOff = errors.New("training orchestrator is not running")
+	ErrInvalidTrainingRequest  = errors.New("invalid training request")
+)
+
+// maxTrainingDatasetLimit caps how many samples a single run may load
+const maxTrainingDatasetLimit = 1000000
+
+// TrainingStore loads training datasets and persists training runs and artifacts
+type TrainingStore interface {
+	GetTrainingSamples(ctx context.Context, since time.Time, limit int) ([]*models.TrainingData, error)
+	SaveTrainingJob(ctx context.Context, job *models.TrainingJob) error
+	ListUnfinishedTrainingJobs(ctx context.Context) ([]*models.TrainingJob, error)
+	SaveModelArtifact(ctx context.Context, artifact *models.ModelArtifact) error
+	GetActiveArtifact(ctx context.Context, modelType string) (*models.ModelArtifact, error)
+}
+
+// weightedModel is a serving model whose weights can be stored with its artifact
+type weightedModel interface {
+	MarshalWeights() ([]byte, error)
+	RestoreWeights(data []byte) error
+}
+
+// TrainingRequest describes a training run to queue
+type TrainingRequest struct {
+	ModelType       string    `json:"model_type"` // "ncf", "gnn", "rl"
+	MaxEpochs       int       `json:"max_epochs"`
+	BatchSize       int       `json:"batch_size"`
+	LearningRate    float64   `json:"learning_rate"`
+	ValidationSplit float64   `json:"validation_split"`
+	DatasetSince    time.Time `json:"dataset_since"`
+	DatasetLimit    int       `json:"dataset_limit"`
+	CreatedBy       string    `json:"created_by"`
+}
+
+// ValidationThresholds decide whether a finished run is good enough to register
+type ValidationThresholds struct {
+	MinAccuracy float64 `json:"min_accuracy"`
+	MaxLoss     float64 `json:"max_loss"`
+}
+
+// TrainingOrchestrator queues and runs NCF, GNN and RL training in the background
+type TrainingOrchestrator struct {
+	mu          sync.RWMutex
+	ncfService  *NCFService
+	gnnService  *GNNService
+	rlService   *RLService
+	store       TrainingStore
+	queue       chan string
+	jobs        map[string]*models.TrainingJob
+	requests    map[string]*TrainingRequest
+	cancels     map[string]context.CancelFunc
+	order       []string
+	artifacts   map[string]*models.ModelArtifact
+	thresholds  map[string]ValidationThresholds
+	running     bool
+	stopWorker  context.CancelFunc
+	workerGroup sync.WaitGroup
+}
+
+// NewTrainingOrchestrator creates a new training orchestrator
+func NewTrainingOrchestrator(ncfService *NCFService, gnnService *GNNService, rlService *RLService, store TrainingStore, queueSize int) *TrainingOrchestrator {
+	if queueSize <= 0 {
+		queueSize = 10
+	}
+
+	return &TrainingOrchestrator{
+		ncfService: ncfService,
+		gnnService: gnnService,
+		rlService:  rlService,
+		store:      store,
+		queue:      make(chan string, queueSize),
+		jobs:       make(map[string]*models.TrainingJob),
+		requests:   make(map[string]*TrainingRequest),
+		cancels:    make(map[string]context.CancelFunc),
+		artifacts:  make(map[string]*models.ModelArtifact),
+		thresholds: map[string]ValidationThresholds{
+			"ncf": {MinAccuracy: 0.5, MaxLoss: 0.25},
+			"gnn": {MaxLoss: 0.5},
+			"rl":  {MaxLoss: 10.0},
+		},
+	}
+}
+
+// Start restores the active artifacts into the serving models, requeues jobs left
+// pending by the previous process and launches the background worker. Runs execute
+// one at a time; each trains a clone of the serving model, so predictions are never
+// blocked by training.
+func (o *TrainingOrchestrator) Start(ctx context.Context) {
+	o.mu.Lock()
+	if o.running {
+		o.mu.Unlock()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	o.stopWorker = cancel
+	o.running = true
+	o.mu.Unlock()
+
+	o.restoreArtifacts(ctx)
+	o.resumeJobs(ctx)
+
+	o.workerGroup.Add(1)
+	go o.worker(ctx)
+}
+
+// Stop cancels the running job and waits for the worker to exit
+func (o *TrainingOrchestrator) Stop() {
+	o.mu.Lock()
+	if !o.running {
+		o.mu.Unlock()
+		return
+	}
+	o.running = false
+	o.stopWorker()
+	for _, cancel := range o.cancels {
+		cancel()
+	}
+	o.mu.Unlock()
+
+	o.workerGroup.Wait()
+}
+
+// SetValidationThresholds overrides the registration thresholds for a model type
+func (o *TrainingOrchestrator) SetValidationThresholds(modelType string, thresholds ValidationThresholds) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.thresholds[modelType] = thresholds
+}
+
+// Submit queues a training run and returns its job record
+func (o *TrainingOrchestrator) Submit(ctx context.Context, request *TrainingRequest) (*models.TrainingJob, error) {
+	if err := o.validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	job := &models.TrainingJob{
+		ID:        uuid.New().String(),
+		ModelType: request.ModelType,
+		Status:    TrainingStatusPending,
+		Config:    o.requestConfig(request),
+		CreatedBy: request.CreatedBy,
+	}
+
+	o.mu.Lock()
+	if !o.running {
+		o.mu.Unlock()
+		return nil, ErrTrainingOrchestratorOff
+	}
+
+	select {
+	case o.queue <- job.ID:
+	default:
+		o.mu.Unlock()
+		return nil, ErrTrainingQueueFull
+	}
+
+	o.jobs[job.ID] = job
+	o.requests[job.ID] = request
+	o.order = append(o.order, job.ID)
+	snapshot := o.snapshot(job)
+	o.mu.Unlock()
+
+	o.persistJob(ctx, snapshot)
+
+	return snapshot, nil
+}
+
+// GetJob returns a training job by ID
+func (o *TrainingOrchestrator) GetJob(jobID string) (*models.TrainingJob, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	job, exists := o.jobs[jobID]
+	if !exists {
+		return nil, ErrTrainingJobNotFound
+	}
+	return o.snapshot(job), nil
+}
+
+// ListJobs returns training jobs, newest first, optionally filtered by model type
+func (o *TrainingOrchestrator) ListJobs(modelType string) []*models.TrainingJob {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	jobs := make([]*models.TrainingJob, 0, len(o.order))
+	for i := len(o.order) - 1; i >= 0; i-- {
+		job := o.jobs[o.order[i]]
+		if modelType != "" && job.ModelType != modelType {
+			continue
+		}
+		jobs = append(jobs, o.snapshot(job))
+	}
+	return jobs
+}
+
+// Cancel stops a pending or running training job
+func (o *TrainingOrchestrator) Cancel(ctx context.Context, jobID string) error {
+	o.mu.Lock()
+	job, exists := o.jobs[jobID]
+	if !exists {
+		o.mu.Unlock()
+		return ErrTrainingJobNotFound
+	}
+
+	switch job.Status {
+	case TrainingStatusPending:
+		// The worker skips cancelled jobs when it dequeues them
+		o.finishJob(job, TrainingStatusCancelled, "cancelled before start")
+	case TrainingStatusRunning:
+		if cancel := o.cancels[jobID]; cancel != nil {
+			cancel()
+		}
+	default:
+		o.mu.Unlock()
+		return ErrTrainingJobFinished
+	}
+	snapshot := o.snapshot(job)
+	o.mu.Unlock()
+
+	o.persistJob(ctx, snapshot)
+	return nil
+}
+
+// GetActiveArtifact returns the most recently registered artifact for a model type
+func (o *TrainingOrchestrator) GetActiveArtifact(modelType string) *models.ModelArtifact {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	artifact, exists := o.artifacts[modelType]
+	if !exists {
+		return nil
+	}
+	copied := *artifact
+	return &copied
+}
+
+// Private methods
+
+func (o *TrainingOrchestrator) model(modelType string) weightedModel {
+	switch modelType {
+	case "ncf":
+		return o.ncfService
+	case "gnn":
+		return o.gnnService
+	case "rl":
+		return o.rlService
+	}
+	return nil
+}
+
+// restoreArtifacts loads the weights of each model type's active artifact into
+// the serving model, so a restart keeps serving the last promoted version
+func (o *TrainingOrchestrator) restoreArtifacts(ctx context.Context) {
+	if o.store == nil {
+		return
+	}
+
+	for _, modelType := range []string{"ncf", "gnn", "rl"} {
+		artifact, err := o.store.GetActiveArtifact(ctx, modelType)
+		if err != nil {
+			if !apperrors.IsNotFoundError(err) {
+				fmt.Printf("Failed to load active %s artifact: %v\n", modelType, err)
+			}
+			continue
+		}
+		if len(artifact.Weights) == 0 {
+			fmt.Printf("Active %s artifact %s has no stored weights; serving the initial model\n", modelType, artifact.ID)
+			continue
+		}
+		if err := o.model(modelType).RestoreWeights(artifact.Weights); err != nil {
+			fmt.Printf("Failed to restore %s artifact %s: %v\n", modelType, artifact.ID, err)
+			continue
+		}
+
+		o.mu.Lock()
+		o.artifacts[modelType] = artifact
+		o.mu.Unlock()
+	}
+}
+
+// resumeJobs requeues jobs the previous process accepted but never started. Jobs
+// it was running are marked failed: their candidate model died with the process.
+func (o *TrainingOrchestrator) resumeJobs(ctx context.Context) {
+	if o.store == nil {
+		return
+	}
+
+	jobs, err := o.store.ListUnfinishedTrainingJobs(ctx)
+	if err != nil {
+		fmt.Printf("Failed to load unfinished training jobs: %v\n", err)
+		return
+	}
+
+	for _, job := range jobs {
+		o.mu.Lock()
+		if _, exists := o.jobs[job.ID]; exists {
+			o.mu.Unlock()
+			continue
+		}
+		o.jobs[job.ID] = job
+		o.order = append(o.order, job.ID)
+
+		if job.Status == TrainingStatusRunning {
+			o.finishJob(job, TrainingStatusFailed, "interrupted by a restart")
+		} else {
+			request := requestFromConfig(job)
+			if err := o.validateRequest(request); err != nil {
+				o.finishJob(job, TrainingStatusFailed, err.Error())
+			} else {
+				select {
+				case o.queue <- job.ID:
+					o.requests[job.ID] = request
+				default:
+					o.finishJob(job, TrainingStatusFailed, ErrTrainingQueueFull.Error())
+				}
+			}
+		}
+		snapshot := o.snapshot(job)
+		o.mu.Unlock()
+
+		if snapshot.Status != TrainingStatusPending {
+			o.persistJob(ctx, snapshot)
+		}
+	}
+}
+
+// requestFromConfig rebuilds a training request from a stored job's config
+func requestFromConfig(job *models.TrainingJob) *TrainingRequest {
+	request := &TrainingRequest{ModelType: job.ModelType, CreatedBy: job.CreatedBy}
+	number := func(key string) float64 {
+		value, _ := job.Config[key].(float64)
+		return value
+	}
+	request.MaxEpochs = int(number("max_epochs"))
+	request.BatchSize = int(number("batch_size"))
+	request.LearningRate = number("learning_rate")
+	request.ValidationSplit = number("validation_split")
+	request.DatasetLimit = int(number("dataset_limit"))
+	if since, ok := job.Config["dataset_since"].(string); ok {
+		request.DatasetSince, _ = time.Parse(time.RFC3339Nano, since)
+	}
+	return request
+}
+
+func (o *TrainingOrchestrator) worker(ctx context.Context) {
+	defer o.workerGroup.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobID := <-o.queue:
+			o.runJob(ctx, jobID)
+		}
+	}
+}
+
+func (o *TrainingOrchestrator) runJob(parent context.Context, jobID string) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	o.mu.Lock()
+	job := o.jobs[jobID]
+	request := o.requests[jobID]
+	if job == nil || job.Status != TrainingStatusPending {
+		o.mu.Unlock()
+		return
+	}
+	job.Status = TrainingStatusRunning
+	job.StartedAt = time.Now()
+	o.cancels[jobID] = cancel
+	snapshot := o.snapshot(job)
+	o.mu.Unlock()
+
+	o.persistJob(ctx, snapshot)
+
+	// Epoch snapshots are written by a separate goroutine so a slow store never
+	// stalls training; only the latest pending snapshot is kept
+	progress := make(chan *models.TrainingJob, 1)
+	persisted := make(chan struct{})
+	go o.persistProgress(progress, persisted)
+
+	validation, promote, err := o.train(ctx, job, request, progress)
+
+	close(progress)
+	<-persisted
+
+	o.mu.Lock()
+	delete(o.cancels, jobID)
+	switch {
+	case errors.Is(err, context.Canceled):
+		o.finishJob(job, TrainingStatusCancelled, "cancelled while running")
+	case err != nil:
+		o.finishJob(job, TrainingStatusFailed, err.Error())
+	default:
+		job.ValidationMetrics = *validation
+		if reason := o.checkThresholds(job.ModelType, validation); reason != "" {
+			o.finishJob(job, TrainingStatusFailed, "validation failed: "+reason)
+		} else {
+			// Only a candidate that passed validation replaces the serving model.
+			// The job completes once its artifact is recorded, so a finished job
+			// always has one.
+			promote()
+			o.mu.Unlock()
+			o.registerArtifact(context.Background(), job)
+			o.mu.Lock()
+			o.finishJob(job, TrainingStatusCompleted, "")
+		}
+	}
+	snapshot = o.snapshot(job)
+	o.mu.Unlock()
+
+	// Persist with a fresh context so cancelled runs are still recorded
+	o.persistJob(context.Background(), snapshot)
+}
+
+// train fits a clone of the serving model on the training share of the dataset and
+// validates it on the held-out share. The returned promote func swaps the clone into
+// the serving model; the caller only calls it once validation has passed.
+func (o *TrainingOrchestrator) train(ctx context.Context, job *models.TrainingJob, request *TrainingRequest, progress chan *models.TrainingJob) (*models.ValidationMetrics, func(), error) {
+	if o.store == nil {
+		return nil, nil, fmt.Errorf("no training store configured")
+	}
+
+	dataset, err := o.store.GetTrainingSamples(ctx, request.DatasetSince, request.DatasetLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load training dataset: %w", err)
+	}
+	if len(dataset) < 2 {
+		return nil, nil, fmt.Errorf("training dataset needs at least 2 samples to hold one out, got %d", len(dataset))
+	}
+
+	o.mu.Lock()
+	job.DatasetSize = len(dataset)
+	o.mu.Unlock()
+
+	trainData, validData := holdOut(dataset, request.ValidationSplit)
+	onEpoch := o.epochRecorder(job, request.MaxEpochs, progress)
+
+	trainingConfig := models.TrainingConfig{
+		ModelType:       request.ModelType,
+		BatchSize:       request.BatchSize,
+		MaxEpochs:       request.MaxEpochs,
+		LearningRate:    request.LearningRate,
+		ValidationSplit: request.ValidationSplit,
+	}
+
+	switch request.ModelType {
+	case "ncf":
+		candidate := o.ncfService.Clone()
+		validation, err := candidate.TrainWithHoldout(ctx, trainData, validData, &models.NCFConfig{TrainingConfig: trainingConfig}, onEpoch)
+		return validation, func() { o.ncfService.ReplaceWith(candidate) }, err
+	case "gnn":
+		candidate := o.gnnService.Clone()
+		validation, err := candidate.TrainGNNWithHoldout(ctx, trainData, validData, &models.GNNConfig{TrainingConfig: trainingConfig, NumLayers: candidate.numLayers}, onEpoch)
+		return validation, func() { o.gnnService.ReplaceWith(candidate) }, err
+	case "rl":
+		candidate := o.rlService.Clone()
+		validation, err := candidate.TrainOnOutcomes(ctx, trainData, validData, request.BatchSize, request.MaxEpochs, onEpoch)
+		return validation, func() { o.rlService.ReplaceWith(candidate) }, err
+	default:
+		return nil, nil, ErrUnsupportedModelType
+	}
+}
+
+// holdOut shuffles a copy of the dataset and splits off the validation share,
+// keeping at least one sample on each side
+func holdOut(dataset []*models.TrainingData, validationSplit float64) ([]*models.TrainingData, []*models.TrainingData) {
+	shuffled := append([]*models.TrainingData(nil), dataset...)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	validSize := int(math.Round(float64(len(shuffled)) * validationSplit))
+	if validSize < 1 {
+		validSize = 1
+	}
+	if validSize > len(shuffled)-1 {
+		validSize = len(shuffled) - 1
+	}
+	split := len(shuffled) - validSize
+	return shuffled[:split], shuffled[split:]
+}
+
+// epochRecorder streams per-epoch metrics into the job record. It runs while the
+// candidate model is locked, so it hands snapshots to persistProgress instead of
+// writing them itself.
+func (o *TrainingOrchestrator) epochRecorder(job *models.TrainingJob, maxEpochs int, progress chan *models.TrainingJob) models.EpochCallback {
+	return func(epoch int, loss float64, validation *models.ValidationMetrics) {
+		o.mu.Lock()
+		metrics := &job.TrainingMetrics
+		metrics.Loss = append(metrics.Loss, loss)
+		metrics.Accuracy = append(metrics.Accuracy, validation.Accuracy)
+		metrics.RMSE = append(metrics.RMSE, validation.RMSE)
+		metrics.CurrentEpoch = epoch
+		if metrics.BestEpoch == 0 || loss < metrics.Loss[metrics.BestEpoch-1] {
+			metrics.BestEpoch = epoch
+		}
+		job.ValidationMetrics = *validation
+		job.Progress = math.Min(1.0, float64(epoch)/float64(maxEpochs))
+		snapshot := o.snapshot(job)
+		o.mu.Unlock()
+
+		// Replace a snapshot the persister hasn't picked up yet
+		select {
+		case <-progress:
+		default:
+		}
+		select {
+		case progress <- snapshot:
+		default:
+		}
+	}
+}
+
+// persistProgress writes epoch snapshots until progress is closed, then closes done
+func (o *TrainingOrchestrator) persistProgress(progress <-chan *models.TrainingJob, done chan<- struct{}) {
+	defer close(done)
+	for snapshot := range progress {
+		o.persistJob(context.Background(), snapshot)
+	}
+}
+
+func (o *TrainingOrchestrator) checkThresholds(modelType string, validation *models.ValidationMetrics) string {
+	if math.IsNaN(validation.Loss) || math.IsInf(validation.Loss, 0) {
+		return "loss is not finite"
+	}
+
+	thresholds := o.thresholds[modelType]
+	if thresholds.MaxLoss > 0 && validation.Loss > thresholds.MaxLoss {
+		return fmt.Sprintf("loss %.4f above %.4f", validation.Loss, thresholds.MaxLoss)
+	}
+	if thresholds.MinAccuracy > 0 && validation.Accuracy < thresholds.MinAccuracy {
+		return fmt.Sprintf("accuracy %.4f below %.4f", validation.Accuracy, thresholds.MinAccuracy)
+	}
+	return ""
+}
+
+// registerArtifact records the promoted model version with its serialized weights
+// and makes it the active artifact, so a restart serves the same weights;
+// callers must not hold o.mu
+func (o *TrainingOrchestrator) registerArtifact(ctx context.Context, job *models.TrainingJob) {
+	var info *models.ModelInfo
+	switch job.ModelType {
+	case "ncf":
+		info = o.ncfService.GetModelInfo()
+	case "gnn":
+		info = o.gnnService.GetModelInfo()
+	case "rl":
+		info = o.rlService.GetModelInfo()
+	}
+
+	weights, err := o.model(job.ModelType).MarshalWeights()
+	if err != nil {
+		fmt.Printf("Failed to serialize %s weights for job %s: %v\n", job.ModelType, job.ID, err)
+		return
+	}
+
+	o.mu.RLock()
+	metadata := map[string]interface{}{
+		"model_info":         info,
+		"validation_metrics": job.ValidationMetrics,
+		"dataset_size":       job.DatasetSize,
+		"epochs":             job.TrainingMetrics.CurrentEpoch,
+	}
+	o.mu.RUnlock()
+	checksum := sha256.Sum256(weights)
+
+	artifact := &models.ModelArtifact{
+		ID:            uuid.New().String(),
+		ModelType:     job.ModelType,
+		Version:       info.Version,
+		TrainingJobID: job.ID,
+		FileSize:      int64(len(weights)),
+		Checksum:      hex.EncodeToString(checksum[:]),
+		Metadata:      metadata,
+		Weights:       weights,
+		IsActive:      true,
+		CreatedAt:     time.Now(),
+	}
+
+	if o.store != nil {
+		if err := o.store.SaveModelArtifact(ctx, artifact); err != nil {
+			fmt.Printf("Failed to register model artifact for job %s: %v\n", job.ID, err)
+			return
+		}
+	}
+
+	o.mu.Lock()
+	o.artifacts[job.ModelType] = artifact
+	job.Version = artifact.Version
+	o.mu.Unlock()
+}
+
+// finishJob sets a terminal status; callers must hold o.mu
+func (o *TrainingOrchestrator) finishJob(job *models.TrainingJob, status, message string) {
+	completedAt := time.Now()
+	job.Status = status
+	job.ErrorMessage = message
+	job.CompletedAt = &completedAt
+	if status == TrainingStatusCompleted {
+		job.Progress = 1.0
+	}
+}
+
+func (o *TrainingOrchestrator) persistJob(ctx context.Context, job *models.TrainingJob) {
+	if o.store == nil {
+		return
+	}
+	if err := o.store.SaveTrainingJob(ctx, job); err != nil {
+		fmt.Printf("Failed to persist training job %s: %v\n", job.ID, err)
+	}
+}
+
+func (o *TrainingOrchestrator) validateRequest(request *TrainingRequest) error {
+	switch request.ModelType {
+	case "ncf", "gnn", "rl":
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedModelType, request.ModelType)
+	}
+
+	if request.MaxEpochs <= 0 {
+		request.MaxEpochs = 20
+	}
+	if request.BatchSize <= 0 {
+		request.BatchSize = 32
+	}
+	if request.LearningRate <= 0 {
+		request.LearningRate = 0.01
+	}
+	if request.ValidationSplit <= 0 || request.ValidationSplit >= 1 {
+		request.ValidationSplit = 0.2
+	}
+	if request.DatasetLimit <= 0 {
+		request.DatasetLimit = 100000
+	}
+	if request.DatasetLimit > maxTrainingDatasetLimit {
+		return fmt.Errorf("%w: dataset_limit must not exceed %d", ErrInvalidTrainingRequest, maxTrainingDatasetLimit)
+	}
+	return nil
+}
+
+func (o *TrainingOrchestrator) requestConfig(request *TrainingRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"max_epochs":       request.MaxEpochs,
+		"batch_size":       request.BatchSize,
+		"learning_rate":    request.LearningRate,
+		"validation_split": request.ValidationSplit,
+		"dataset_since":    request.DatasetSince,
+		"dataset_limit":    request.DatasetLimit,
+	}
+}
+
+// snapshot copies a job so callers never share slices with the running worker
+func (o *TrainingOrchestrator) snapshot(job *models.TrainingJob) *models.TrainingJob {
+	copied := *job
+	copied.TrainingMetrics.Loss = append([]float64(nil), job.TrainingMetrics.Loss...)
+	copied.TrainingMetrics.Accuracy = append([]float64(nil), job.TrainingMetrics.Accuracy...)
+	copied.TrainingMetrics.RMSE = append([]float64(nil), job.TrainingMetrics.RMSE...)
+	return &copied
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/ai/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+type memoryTrainingStore struct {
+	mu        sync.Mutex
+	samples   []*models.TrainingData
+	jobs      map[string]*models.TrainingJob
+	artifacts []*models.ModelArtifact
+}
+
+func newMemoryTrainingStore(n int) *memoryTrainingStore {
+	samples := make([]*models.TrainingData, n)
+	for i := 0; i < n; i++ {
+		samples[i] = &models.TrainingData{
+			ID:     fmt.Sprintf("sample_%d", i),
+			UserID: fmt.Sprintf("user_%d", i%5),
+			JobID:  fmt.Sprintf("job_%d", i%7),
+			Features: models.FeatureVector{
+				UserFeatures: map[string]float64{"skill_go": 0.8},
+				JobFeatures:  map[string]float64{"skill_go": 0.6},
+			},
+			Label:  float64(i % 2),
+			Weight: 1.0,
+		}
+	}
+	return &memoryTrainingStore{samples: samples, jobs: make(map[string]*models.TrainingJob)}
+}
+
+func (s *memoryTrainingStore) GetTrainingSamples(ctx context.Context, since time.Time, limit int) ([]*models.TrainingData, error) {
+	return s.samples, nil
+}
+
+func (s *memoryTrainingStore) SaveTrainingJob(ctx context.Context, job *models.TrainingJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *memoryTrainingStore) SaveModelArtifact(ctx context.Context, artifact *models.ModelArtifact) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.artifacts = append(s.artifacts, artifact)
+	return nil
+}
+
+func (s *memoryTrainingStore) ListUnfinishedTrainingJobs(ctx context.Context) ([]*models.TrainingJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobs []*models.TrainingJob
+	for _, job := range s.jobs {
+		if job.Status == TrainingStatusPending || job.Status == TrainingStatusRunning {
+			copied := *job
+			jobs = append(jobs, &copied)
+		}
+	}
+	return jobs, nil
+}
+
+func (s *memoryTrainingStore) GetActiveArtifact(ctx context.Context, modelType string) (*models.ModelArtifact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.artifacts) - 1; i >= 0; i-- {
+		if s.artifacts[i].ModelType == modelType && s.artifacts[i].IsActive {
+			return s.artifacts[i], nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Model artifact")
+}
+
+func createTestTrainingOrchestrator(store TrainingStore) *TrainingOrchestrator {
+	return NewTrainingOrchestrator(
+		NewNCFService(&models.NCFConfig{EmbeddingDim: 8, HiddenLayers: []int{16, 8}}),
+		NewGNNService(&models.GNNConfig{NodeEmbeddingDim: 8, NumLayers: 2}),
+		NewRLService(&models.RLConfig{StateSpaceDim: 45, ActionSpaceDim: 5}),
+		store,
+		4,
+	)
+}
+
+func waitForTrainingJob(t *testing.T, orchestrator *TrainingOrchestrator, jobID string) *models.TrainingJob {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := orchestrator.GetJob(jobID)
+		if err != nil {
+			t.Fatalf("GetJob failed: %v", err)
+		}
+		if job.CompletedAt != nil {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Training job %s did not finish", jobID)
+	return nil
+}
+
+func TestTrainingOrchestrator_RunsJobAndRegistersArtifact(t *testing.T) {
+	store := newMemoryTrainingStore(40)
+	orchestrator := createTestTrainingOrchestrator(store)
+	orchestrator.SetValidationThresholds("gnn", ValidationThresholds{})
+	orchestrator.Start(context.Background())
+	defer orchestrator.Stop()
+
+	submitted, err := orchestrator.Submit(context.Background(), &TrainingRequest{ModelType: "gnn", MaxEpochs: 3})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if submitted.Status != TrainingStatusPending {
+		t.Errorf("Expected pending status, got %s", submitted.Status)
+	}
+
+	job := waitForTrainingJob(t, orchestrator, submitted.ID)
+	if job.Status != TrainingStatusCompleted {
+		t.Fatalf("Expected completed status, got %s (%s)", job.Status, job.ErrorMessage)
+	}
+	if len(job.TrainingMetrics.Loss) != 3 || job.TrainingMetrics.CurrentEpoch != 3 {
+		t.Errorf("Expected 3 recorded epochs, got %d", len(job.TrainingMetrics.Loss))
+	}
+	if job.DatasetSize != 40 {
+		t.Errorf("Expected dataset size 40, got %d", job.DatasetSize)
+	}
+
+	artifact := orchestrator.GetActiveArtifact("gnn")
+	if artifact == nil || artifact.TrainingJobID != job.ID {
+		t.Fatal("Expected an active artifact for the completed job")
+	}
+	if len(store.artifacts) != 1 {
+		t.Errorf("Expected 1 stored artifact, got %d", len(store.artifacts))
+	}
+}
+
+func TestTrainingOrchestrator_ValidationGate(t *testing.T) {
+	orchestrator := createTestTrainingOrchestrator(newMemoryTrainingStore(40))
+	orchestrator.SetValidationThresholds("rl", ValidationThresholds{MaxLoss: 1e-12})
+	orchestrator.Start(context.Background())
+	defer orchestrator.Stop()
+
+	submitted, err := orchestrator.Submit(context.Background(), &TrainingRequest{ModelType: "rl", MaxEpochs: 2, BatchSize: 8})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	job := waitForTrainingJob(t, orchestrator, submitted.ID)
+	if job.Status != TrainingStatusFailed {
+		t.Errorf("Expected failed status below threshold, got %s", job.Status)
+	}
+	if orchestrator.GetActiveArtifact("rl") != nil {
+		t.Error("Expected no artifact for a run that failed validation")
+	}
+}
+
+func TestTrainingOrchestrator_FailedRunLeavesServingModel(t *testing.T) {
+	orchestrator := createTestTrainingOrchestrator(newMemoryTrainingStore(40))
+	orchestrator.SetValidationThresholds("ncf", ValidationThresholds{MaxLoss: 1e-12})
+	orchestrator.Start(context.Background())
+	defer orchestrator.Stop()
+
+	ncf := orchestrator.ncfService
+	if err := ncf.TrainModel(context.Background(), newMemoryTrainingStore(10).samples, &models.NCFConfig{TrainingConfig: models.TrainingConfig{MaxEpochs: 1, ValidationSplit: 0.2}}); err != nil {
+		t.Fatalf("TrainModel failed: %v", err)
+	}
+	version := ncf.GetModelInfo().Version
+	before, _ := ncf.PredictUserJobInteraction(context.Background(), "user_1", "job_1")
+
+	submitted, err := orchestrator.Submit(context.Background(), &TrainingRequest{ModelType: "ncf", MaxEpochs: 5})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	job := waitForTrainingJob(t, orchestrator, submitted.ID)
+	if job.Status != TrainingStatusFailed {
+		t.Fatalf("Expected failed status, got %s", job.Status)
+	}
+
+	if got := ncf.GetModelInfo().Version; got != version {
+		t.Errorf("Expected serving version %s to survive a failed run, got %s", version, got)
+	}
+	if after, _ := ncf.PredictUserJobInteraction(context.Background(), "user_1", "job_1"); after != before {
+		t.Errorf("Expected serving prediction %v to be untouched, got %v", before, after)
+	}
+}
+
+func TestTrainingOrchestrator_PassingRunReplacesServingModel(t *testing.T) {
+	orchestrator := createTestTrainingOrchestrator(newMemoryTrainingStore(40))
+	orchestrator.SetValidationThresholds("rl", ValidationThresholds{})
+	orchestrator.Start(context.Background())
+	defer orchestrator.Stop()
+
+	rl := orchestrator.rlService
+	version := rl.GetModelInfo().Version
+
+	submitted, err := orchestrator.Submit(context.Background(), &TrainingRequest{ModelType: "rl", MaxEpochs: 2, BatchSize: 8})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	job := waitForTrainingJob(t, orchestrator, submitted.ID)
+	if job.Status != TrainingStatusCompleted {
+		t.Fatalf("Expected completed status, got %s (%s)", job.Status, job.ErrorMessage)
+	}
+
+	info := rl.GetModelInfo()
+	if info.Version == version {
+		t.Error("Expected the trained candidate to replace the serving model")
+	}
+	if artifact := orchestrator.GetActiveArtifact("rl"); artifact == nil || artifact.Version != info.Version {
+		t.Errorf("Expected the artifact to carry the serving version %s", info.Version)
+	}
+	if size := rl.experienceReplay.Size(); size != 0 {
+		t.Errorf("Expected the serving experience buffer to stay empty, got %d", size)
+	}
+}
+
+func TestTrainingOrchestrator_RestartRestoresActiveArtifact(t *testing.T) {
+	store := newMemoryTrainingStore(40)
+	first := createTestTrainingOrchestrator(store)
+	first.SetValidationThresholds("ncf", ValidationThresholds{})
+	first.Start(context.Background())
+
+	submitted, err := first.Submit(context.Background(), &TrainingRequest{ModelType: "ncf", MaxEpochs: 2})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if job := waitForTrainingJob(t, first, submitted.ID); job.Status != TrainingStatusCompleted {
+		t.Fatalf("Expected completed status, got %s (%s)", job.Status, job.ErrorMessage)
+	}
+	first.Stop()
+
+	if len(store.artifacts) != 1 || len(store.artifacts[0].Weights) == 0 {
+		t.Fatal("Expected the artifact to be stored with its weights")
+	}
+	trained, _ := first.ncfService.PredictUserJobInteraction(context.Background(), "user_1", "job_1")
+
+	restarted := createTestTrainingOrchestrator(store)
+	restarted.Start(context.Background())
+	defer restarted.Stop()
+
+	if got, want := restarted.ncfService.GetModelInfo().Version, first.ncfService.GetModelInfo().Version; got != want {
+		t.Errorf("Expected the restarted service to serve version %s, got %s", want, got)
+	}
+	if restored, _ := restarted.ncfService.PredictUserJobInteraction(context.Background(), "user_1", "job_1"); restored != trained {
+		t.Errorf("Expected restored prediction %v, got %v", trained, restored)
+	}
+	if artifact := restarted.GetActiveArtifact("ncf"); artifact == nil || artifact.TrainingJobID != submitted.ID {
+		t.Error("Expected the restarted orchestrator to report the stored artifact")
+	}
+}
+
+func TestTrainingOrchestrator_StartResumesUnfinishedJobs(t *testing.T) {
+	store := newMemoryTrainingStore(40)
+	store.jobs["pending-job"] = &models.TrainingJob{
+		ID:        "pending-job",
+		ModelType: "gnn",
+		Status:    TrainingStatusPending,
+		Config:    map[string]interface{}{"max_epochs": float64(2), "dataset_since": "0001-01-01T00:00:00Z"},
+	}
+	store.jobs["running-job"] = &models.TrainingJob{
+		ID:        "running-job",
+		ModelType: "ncf",
+		Status:    TrainingStatusRunning,
+		Config:    map[string]interface{}{"max_epochs": float64(2)},
+	}
+
+	orchestrator := createTestTrainingOrchestrator(store)
+	orchestrator.SetValidationThresholds("gnn", ValidationThresholds{})
+	orchestrator.Start(context.Background())
+	defer orchestrator.Stop()
+
+	resumed := waitForTrainingJob(t, orchestrator, "pending-job")
+	if resumed.Status != TrainingStatusCompleted || resumed.TrainingMetrics.CurrentEpoch != 2 {
+		t.Errorf("Expected the pending job to run its 2 epochs, got %s after %d (%s)", resumed.Status, resumed.TrainingMetrics.CurrentEpoch, resumed.ErrorMessage)
+	}
+
+	interrupted := waitForTrainingJob(t, orchestrator, "running-job")
+	if interrupted.Status != TrainingStatusFailed {
+		t.Errorf("Expected the interrupted job to be marked failed, got %s", interrupted.Status)
+	}
+}
+
+func TestHoldOut(t *testing.T) {
+	dataset := newMemoryTrainingStore(10).samples
+	trainData, validData := holdOut(dataset, 0.2)
+	if len(trainData) != 8 || len(validData) != 2 {
+		t.Fatalf("Expected an 8/2 split, got %d/%d", len(trainData), len(validData))
+	}
+
+	seen := make(map[string]bool)
+	for _, sample := range append(trainData, validData...) {
+		if seen[sample.ID] {
+			t.Fatalf("Sample %s landed on both sides of the split", sample.ID)
+		}
+		seen[sample.ID] = true
+	}
+
+	trainData, validData = holdOut(dataset[:2], 0.01)
+	if len(trainData) != 1 || len(validData) != 1 {
+		t.Errorf("Expected one sample on each side, got %d/%d", len(trainData), len(validData))
+	}
+}
+
+func TestTrainingOrchestrator_CancelAndValidation(t *testing.T) {
+	orchestrator := createTestTrainingOrchestrator(newMemoryTrainingStore(10))
+
+	if _, err := orchestrator.Submit(context.Background(), &TrainingRequest{ModelType: "ncf"}); err != ErrTrainingOrchestratorOff {
+		t.Errorf("Expected ErrTrainingOrchestratorOff before Start, got %v", err)
+	}
+
+	orchestrator.Start(context.Background())
+	defer orchestrator.Stop()
+
+	if _, err := orchestrator.Submit(context.Background(), &TrainingRequest{ModelType: "svm"}); err == nil {
+		t.Error("Expected error for unsupported model type")
+	}
+
+	job, err := orchestrator.Submit(context.Background(), &TrainingRequest{ModelType: "ncf", MaxEpochs: 1000})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if err := orchestrator.Cancel(context.Background(), job.ID); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+
+	finished := waitForTrainingJob(t, orchestrator, job.ID)
+	if finished.Status != TrainingStatusCancelled {
+		t.Errorf("Expected cancelled status, got %s", finished.Status)
+	}
+	if err := orchestrator.Cancel(context.Background(), job.ID); err != ErrTrainingJobFinished {
+		t.Errorf("Expected ErrTrainingJobFinished, got %v", err)
+	}
+	if _, err := orchestrator.GetJob("missing"); err != ErrTrainingJobNotFound {
+		t.Errorf("Expected ErrTrainingJobNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+// Bulk actions an employer can apply to many applications at once
+const (
+	BulkReject    = "reject"
+	BulkReview    = "review"
+	BulkShortlist = "shortlist"
+)
+
+// MaxBulkApplications bounds a single bulk request
+const MaxBulkApplications = 1000
+
+// IsBulkAction reports whether action is a known bulk action
+func IsBulkAction(action string) bool {
+	return action == BulkReject || action == BulkReview || action == BulkShortlist
+}
+
+// BulkStore hands out queued bulk operations; repository.ApplicationBulkRepository implements it
+type BulkStore interface {
+	// ClaimBulkOperation marks the oldest pending operation as running and returns
+	// it, or nil when there is none. Operations left running since staleBefore
+	// belong to a stopped instance and are claimed again.
+	ClaimBulkOperation(ctx context.Context, now, staleBefore time.Time) (*models.ApplicationBulkOperation, error)
+	FinishBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) error
+}
+
+// BulkProcessor applies a bulk operation's action to each of its applications
+type BulkProcessor interface {
+	ProcessBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) ([]models.BulkItemResult, error)
+}
+
+// FinishOperation records the results of an operation
+func FinishOperation(operation *models.ApplicationBulkOperation, results []models.BulkItemResult, err error, now time.Time) {
+	operation.CompletedAt = &now
+	operation.Results = results
+	operation.Total = len(operation.ApplicationIDs)
+	operation.Succeeded, operation.Failed = 0, 0
+	for _, result := range results {
+		if result.Success {
+			operation.Succeeded++
+		} else {
+			operation.Failed++
+		}
+	}
+
+	if err != nil {
+		operation.Status = models.BulkOperationFailed
+		operation.Error = err.Error()
+		return
+	}
+	operation.Status = models.BulkOperationCompleted
+}
+
+// BulkWorker processes queued bulk operations. Operations are claimed one at a
+// time, so any number of instances can run the worker.
+type BulkWorker struct {
+	store      BulkStore
+	processor  BulkProcessor
+	interval   time.Duration
+	staleAfter time.Duration
+
+	mu        sync.Mutex
+	running   bool
+	stop      context.CancelFunc
+	loopGroup sync.WaitGroup
+}
+
+// NewBulkWorker creates a worker that looks for queued operations every interval.
+// Operations running for longer than staleAfter are assumed abandoned.
+func NewBulkWorker(store BulkStore, processor BulkProcessor, interval, staleAfter time.Duration) *BulkWorker {
+	return &BulkWorker{
+		store:      store,
+		processor:  processor,
+		interval:   interval,
+		staleAfter: staleAfter,
+	}
+}
+
+// Start processes queued operations immediately and then on every interval
+func (w *BulkWorker) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.stop = cancel
+	w.running = true
+
+	w.loopGroup.Add(1)
+	go func() {
+		defer w.loopGroup.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := w.Run(ctx); err != nil && ctx.Err() == nil {
+				fmt.Printf("Failed to process bulk application operations: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts the worker once the operation in progress is done
+func (w *BulkWorker) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	w.stop()
+	w.mu.Unlock()
+
+	w.loopGroup.Wait()
+}
+
+// Run processes queued operations until none are left
+func (w *BulkWorker) Run(ctx context.Context) error {
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		operation, err := w.store.ClaimBulkOperation(ctx, now, now.Add(-w.staleAfter))
+		if err != nil {
+			return err
+		}
+		if operation == nil {
+			return nil
+		}
+
+		results, err := w.processor.ProcessBulkOperation(ctx, operation)
+		FinishOperation(operation, results, err, time.Now().UTC())
+		// Finish even if the worker is stopping, so the operation isn't left running
+		if err := w.store.FinishBulkOperation(context.WithoutCancel(ctx), operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+func TestValidateFeedbackTemplate(t *testing.T) {
+	if err := ValidateFeedbackTemplate(DefaultRejectionFeedback); err != nil {
+		t.Fatalf("Expected the default template to be valid, got %v", err)
+	}
+	if err := ValidateFeedbackTemplate("Hi {{ first_name }}, about {{job_titel}} and {{salary}}"); err == nil {
+		t.Fatal("Expected unknown placeholders to be rejected")
+	}
+}
+
+func TestRenderFeedback(t *testing.T) {
+	values := FeedbackValues("Jane Doe", "Landing page redesign", "Acme")
+	got := RenderFeedback("Hi {{first_name}}, thanks for applying to {{ job_title }} at {{company}}.", values)
+	want := "Hi Jane, thanks for applying to Landing page redesign at Acme."
+	if got != want {
+		t.Errorf("RenderFeedback() = %q, want %q", got, want)
+	}
+
+	if got := RenderFeedback("Hi {{first_name}}", FeedbackValues("", "", "")); got != "Hi there" {
+		t.Errorf("Expected a fallback greeting without a name, got %q", got)
+	}
+}
+
+type fakeBulkStore struct {
+	queued   []*models.ApplicationBulkOperation
+	finished []*models.ApplicationBulkOperation
+}
+
+func (s *fakeBulkStore) ClaimBulkOperation(ctx context.Context, now, staleBefore time.Time) (*models.ApplicationBulkOperation, error) {
+	if len(s.queued) == 0 {
+		return nil, nil
+	}
+	operation := s.queued[0]
+	s.queued = s.queued[1:]
+	operation.Status = models.BulkOperationRunning
+	operation.StartedAt = &now
+	return operation, nil
+}
+
+func (s *fakeBulkStore) FinishBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) error {
+	s.finished = append(s.finished, operation)
+	return nil
+}
+
+type fakeBulkProcessor struct{}
+
+func (fakeBulkProcessor) ProcessBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) ([]models.BulkItemResult, error) {
+	if operation.Action == "explode" {
+		return nil, errors.New("job not found")
+	}
+	results := make([]models.BulkItemResult, len(operation.ApplicationIDs))
+	for i, id := range operation.ApplicationIDs {
+		results[i] = models.BulkItemResult{ApplicationID: id, Success: id != "bad", Status: StatusRejected}
+	}
+	return results, nil
+}
+
+func TestBulkWorker_Run(t *testing.T) {
+	store := &fakeBulkStore{queued: []*models.ApplicationBulkOperation{
+		{ID: "op-1", Action: BulkReject, ApplicationIDs: models.StringArray{"a", "bad", "c"}, Status: models.BulkOperationPending},
+		{ID: "op-2", Action: "explode", ApplicationIDs: models.StringArray{"d"}, Status: models.BulkOperationPending},
+	}}
+
+	worker := NewBulkWorker(store, fakeBulkProcessor{}, time.Minute, time.Hour)
+	if err := worker.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(store.finished) != 2 {
+		t.Fatalf("Expected both operations to finish, got %d", len(store.finished))
+	}
+	done := store.finished[0]
+	if done.Status != models.BulkOperationCompleted || done.Total != 3 || done.Succeeded != 2 || done.Failed != 1 || done.CompletedAt == nil {
+		t.Errorf("Unexpected completed operation: %+v", done)
+	}
+	failed := store.finished[1]
+	if failed.Status != models.BulkOperationFailed || failed.Error == "" {
+		t.Errorf("Expected the second operation to fail, got %+v", failed)
+	}
+}
@@ -0,0 +1,83 @@
+package applications
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// Placeholders a feedback template may use, written as {{name}}
+const (
+	PlaceholderApplicantName = "applicant_name"
+	PlaceholderFirstName     = "first_name"
+	PlaceholderJobTitle      = "job_title"
+	PlaceholderCompany       = "company"
+)
+
+// DefaultRejectionFeedback is sent when a bulk rejection has no template
+const DefaultRejectionFeedback = "Hi {{first_name}}, thank you for applying to {{job_title}}. " +
+	"After careful consideration we've decided to move forward with other candidates. We wish you the best in your search."
+
+// PositionFilledFeedback is sent to the other applicants when a student accepts the job's offer
+const PositionFilledFeedback = "Hi {{first_name}}, thank you for applying to {{job_title}}. " +
+	"The position has now been filled, so we're closing your application. We hope to see you apply again."
+
+// MaxFeedbackLength bounds a rendered feedback message
+const MaxFeedbackLength = 2000
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_]+)\s*\}\}`)
+
+var knownPlaceholders = map[string]bool{
+	PlaceholderApplicantName: true,
+	PlaceholderFirstName:     true,
+	PlaceholderJobTitle:      true,
+	PlaceholderCompany:       true,
+}
+
+// ValidateFeedbackTemplate rejects templates with unknown placeholders, so a typo
+// fails the whole request instead of reaching every applicant
+func ValidateFeedbackTemplate(template string) error {
+	if len(template) > MaxFeedbackLength {
+		return apperrors.NewValidationError(fmt.Sprintf("feedback template must be at most %d characters", MaxFeedbackLength))
+	}
+
+	var unknown []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+		if !knownPlaceholders[match[1]] {
+			unknown = append(unknown, match[1])
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return apperrors.NewValidationError(fmt.Sprintf("unknown feedback placeholders: %s", strings.Join(unknown, ", ")))
+	}
+	return nil
+}
+
+// RenderFeedback fills a validated template's placeholders from values
+func RenderFeedback(template string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		return values[name]
+	})
+}
+
+// FeedbackValues builds the placeholder values for one applicant
+func FeedbackValues(applicantName, jobTitle, company string) map[string]string {
+	firstName := applicantName
+	if fields := strings.Fields(applicantName); len(fields) > 0 {
+		firstName = fields[0]
+	}
+	if firstName == "" {
+		firstName = "there"
+	}
+	return map[string]string{
+		PlaceholderApplicantName: applicantName,
+		PlaceholderFirstName:     firstName,
+		PlaceholderJobTitle:      jobTitle,
+		PlaceholderCompany:       company,
+	}
+}
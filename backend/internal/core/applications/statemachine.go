@@ -0,0 +1,173 @@
+package applications
+
+import (
+	"fmt"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// Application statuses
+const (
+	StatusDraft       = "draft"
+	StatusSubmitted   = "submitted"
+	StatusReviewed    = "reviewed"
+	StatusInterviewed = "interviewed"
+	StatusAccepted    = "accepted"
+	StatusRejected    = "rejected"
+	StatusWithdrawn   = "withdrawn"
+)
+
+// Actor is the side that triggers a status change
+type Actor string
+
+const (
+	ActorStudent  Actor = "student"
+	ActorEmployer Actor = "employer"
+	// ActorSystem covers automatic changes, such as closing the other
+	// applications once a job is filled. It may make any employer transition.
+	ActorSystem Actor = "system"
+)
+
+// transition is an allowed status change and the actor who may make it
+type transition struct {
+	from  string
+	to    string
+	actor Actor
+}
+
+// transitions is the application lifecycle:
+//
+//	draft → submitted → reviewed → interviewed → accepted/rejected
+//
+// Employers may accept straight from reviewed and reject at any open stage.
+// Students may withdraw until a decision is made.
+var transitions = []transition{
+	{StatusDraft, StatusSubmitted, ActorStudent},
+
+	{StatusSubmitted, StatusReviewed, ActorEmployer},
+	{StatusReviewed, StatusInterviewed, ActorEmployer},
+	{StatusReviewed, StatusAccepted, ActorEmployer},
+	{StatusInterviewed, StatusAccepted, ActorEmployer},
+
+	{StatusSubmitted, StatusRejected, ActorEmployer},
+	{StatusReviewed, StatusRejected, ActorEmployer},
+	{StatusInterviewed, StatusRejected, ActorEmployer},
+
+	{StatusDraft, StatusWithdrawn, ActorStudent},
+	{StatusSubmitted, StatusWithdrawn, ActorStudent},
+	{StatusReviewed, StatusWithdrawn, ActorStudent},
+	{StatusInterviewed, StatusWithdrawn, ActorStudent},
+}
+
+var knownStatuses = map[string]bool{
+	StatusDraft:       true,
+	StatusSubmitted:   true,
+	StatusReviewed:    true,
+	StatusInterviewed: true,
+	StatusAccepted:    true,
+	StatusRejected:    true,
+	StatusWithdrawn:   true,
+}
+
+// IsValidStatus reports whether status is a known application status
+func IsValidStatus(status string) bool {
+	return knownStatuses[status]
+}
+
+// IsTerminal reports whether no further transitions leave status
+func IsTerminal(status string) bool {
+	for _, t := range transitions {
+		if t.from == status {
+			return false
+		}
+	}
+	return true
+}
+
+// CanTransition checks that actor may move an application from one status to another.
+// It returns a validation error for unknown statuses, a conflict for changes the
+// lifecycle doesn't allow and a forbidden error when only the other side may make them.
+func CanTransition(from, to string, actor Actor) error {
+	if !IsValidStatus(to) {
+		return apperrors.NewValidationError(fmt.Sprintf("unknown application status %q", to))
+	}
+	if from == to {
+		return apperrors.NewAppError(409, fmt.Sprintf("Application is already %s", to), nil)
+	}
+
+	allowed := false
+	for _, t := range transitions {
+		if t.from != from || t.to != to {
+			continue
+		}
+		if t.actor == actor || (actor == ActorSystem && t.actor == ActorEmployer) {
+			return nil
+		}
+		allowed = true
+	}
+	if allowed {
+		return apperrors.NewForbiddenError(fmt.Sprintf("Only the %s can move an application from %s to %s", otherSide(actor), from, to))
+	}
+	return apperrors.NewAppError(409, fmt.Sprintf("Cannot move an application from %s to %s", from, to), nil)
+}
+
+// NextStatuses lists the statuses actor may move an application to from status
+func NextStatuses(status string, actor Actor) []string {
+	next := []string{}
+	for _, t := range transitions {
+		if t.from == status && (t.actor == actor || (actor == ActorSystem && t.actor == ActorEmployer)) {
+			next = append(next, t.to)
+		}
+	}
+	return next
+}
+
+// Transition validates a status change, applies it with its side effects and
+// returns the history entry to record alongside it. actorID is empty for system changes.
+func Transition(application *models.Application, to string, actor Actor, actorID, reason string, now time.Time) (*models.ApplicationStatusHistory, error) {
+	from := application.Status
+	if err := CanTransition(from, to, actor); err != nil {
+		return nil, err
+	}
+
+	application.Status = to
+	application.UpdatedAt = now
+	switch to {
+	case StatusSubmitted:
+		application.AppliedAt = now
+	case StatusReviewed:
+		markReviewed(application, now)
+	case StatusAccepted, StatusRejected:
+		markReviewed(application, now)
+		application.ResponseAt = &now
+	}
+
+	entry := &models.ApplicationStatusHistory{
+		ApplicationID: application.ID,
+		FromStatus:    from,
+		ToStatus:      to,
+		ActorRole:     string(actor),
+		Reason:        reason,
+		CreatedAt:     now,
+	}
+	if actorID != "" {
+		entry.ActorID = &actorID
+	}
+	return entry, nil
+}
+
+// markReviewed records when the employer first acted on the application
+func markReviewed(application *models.Application, now time.Time) {
+	if application.ReviewedAt == nil {
+		application.ReviewedAt = &now
+	}
+}
+
+func otherSide(actor Actor) Actor {
+	if actor == ActorStudent {
+		return ActorEmployer
+	}
+	return ActorStudent
+}
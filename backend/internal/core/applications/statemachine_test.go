@@ -0,0 +1,100 @@
+package applications
+
+import (
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		actor    Actor
+		wantCode int // 0 when the transition is allowed
+	}{
+		{"student submits a draft", StatusDraft, StatusSubmitted, ActorStudent, 0},
+		{"employer reviews", StatusSubmitted, StatusReviewed, ActorEmployer, 0},
+		{"employer accepts after interview", StatusInterviewed, StatusAccepted, ActorEmployer, 0},
+		{"system closes an open application", StatusReviewed, StatusRejected, ActorSystem, 0},
+		{"student withdraws before a decision", StatusInterviewed, StatusWithdrawn, ActorStudent, 0},
+		{"rejected can't be resubmitted", StatusRejected, StatusSubmitted, ActorStudent, 409},
+		{"accepted is final", StatusAccepted, StatusRejected, ActorEmployer, 409},
+		{"no skipping review", StatusSubmitted, StatusInterviewed, ActorEmployer, 409},
+		{"same status", StatusReviewed, StatusReviewed, ActorEmployer, 409},
+		{"student can't review", StatusSubmitted, StatusReviewed, ActorStudent, 403},
+		{"employer can't withdraw", StatusSubmitted, StatusWithdrawn, ActorEmployer, 403},
+		{"system can't act for the student", StatusSubmitted, StatusWithdrawn, ActorSystem, 403},
+		{"unknown status", StatusSubmitted, "pending", ActorEmployer, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CanTransition(tt.from, tt.to, tt.actor)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("Expected transition to be allowed, got %v", err)
+				}
+				return
+			}
+			appErr, ok := err.(*apperrors.AppError)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("Expected a %d error, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestTransition_SideEffects(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	application := &models.Application{ID: "app-1", Status: StatusSubmitted}
+
+	entry, err := Transition(application, StatusReviewed, ActorEmployer, "employer-1", "", start)
+	if err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if application.Status != StatusReviewed || application.ReviewedAt == nil || !application.ReviewedAt.Equal(start) {
+		t.Errorf("Expected the application to be reviewed at %v, got %+v", start, application)
+	}
+	if entry.FromStatus != StatusSubmitted || entry.ToStatus != StatusReviewed || entry.ActorID == nil || *entry.ActorID != "employer-1" {
+		t.Errorf("Unexpected history entry: %+v", entry)
+	}
+
+	decided := start.Add(48 * time.Hour)
+	entry, err = Transition(application, StatusRejected, ActorSystem, "", "Position filled", decided)
+	if err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if !application.ReviewedAt.Equal(start) {
+		t.Errorf("Expected the first review time to be kept, got %v", application.ReviewedAt)
+	}
+	if application.ResponseAt == nil || !application.ResponseAt.Equal(decided) {
+		t.Errorf("Expected a response time of %v, got %v", decided, application.ResponseAt)
+	}
+	if entry.ActorID != nil || entry.ActorRole != string(ActorSystem) || entry.Reason != "Position filled" {
+		t.Errorf("Unexpected system history entry: %+v", entry)
+	}
+
+	if _, err := Transition(application, StatusAccepted, ActorEmployer, "employer-1", "", decided); err == nil {
+		t.Error("Expected a rejected application to stay rejected")
+	}
+	if application.Status != StatusRejected {
+		t.Errorf("Expected a refused transition to leave the status alone, got %s", application.Status)
+	}
+}
+
+func TestNextStatuses(t *testing.T) {
+	if next := NextStatuses(StatusReviewed, ActorEmployer); len(next) != 3 {
+		t.Errorf("Expected interviewed, accepted and rejected from reviewed, got %v", next)
+	}
+	if next := NextStatuses(StatusReviewed, ActorStudent); len(next) != 1 || next[0] != StatusWithdrawn {
+		t.Errorf("Expected the student to only withdraw, got %v", next)
+	}
+	for _, status := range []string{StatusAccepted, StatusRejected, StatusWithdrawn} {
+		if !IsTerminal(status) {
+			t.Errorf("Expected %s to be terminal", status)
+		}
+	}
+}
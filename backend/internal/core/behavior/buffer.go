@@ -0,0 +1,261 @@
+package behavior
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Buffer holds accepted events until the pipeline flushes them. Buffers are
+// bounded: Push takes as many events as fit and the caller sheds the rest.
+type Buffer interface {
+	// Push appends events in order until the buffer is full and returns how many were taken
+	Push(ctx context.Context, events []Event) (int, error)
+	// Pop waits up to wait for max events and returns what arrived in that time
+	Pop(ctx context.Context, max int, wait time.Duration) (*Batch, error)
+	// Ack marks a popped batch as handled so it is not delivered again
+	Ack(ctx context.Context, batch *Batch) error
+	// Len returns the number of events waiting to be flushed
+	Len(ctx context.Context) (int64, error)
+}
+
+// Batch is a set of events popped from a buffer
+type Batch struct {
+	Events []Event
+	ids    []string
+}
+
+// MemoryBuffer buffers events in process. Events still buffered when the
+// process dies are lost, so it suits tests and single-instance deployments.
+type MemoryBuffer struct {
+	events chan Event
+}
+
+// NewMemoryBuffer creates an in-process buffer holding up to capacity events
+func NewMemoryBuffer(capacity int) *MemoryBuffer {
+	return &MemoryBuffer{events: make(chan Event, capacity)}
+}
+
+func (b *MemoryBuffer) Push(ctx context.Context, events []Event) (int, error) {
+	for i, event := range events {
+		select {
+		case b.events <- event:
+		default:
+			return i, nil
+		}
+	}
+	return len(events), nil
+}
+
+func (b *MemoryBuffer) Pop(ctx context.Context, max int, wait time.Duration) (*Batch, error) {
+	batch := &Batch{}
+
+	var deadline <-chan time.Time
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for len(batch.Events) < max {
+		// Take whatever is ready first so a cancelled context still drains the buffer
+		select {
+		case event := <-b.events:
+			batch.Events = append(batch.Events, event)
+			continue
+		default:
+		}
+		if deadline == nil {
+			break
+		}
+
+		select {
+		case event := <-b.events:
+			batch.Events = append(batch.Events, event)
+		case <-deadline:
+			return batch, nil
+		case <-ctx.Done():
+			return batch, nil
+		}
+	}
+	return batch, nil
+}
+
+func (b *MemoryBuffer) Ack(ctx context.Context, batch *Batch) error {
+	return nil
+}
+
+func (b *MemoryBuffer) Len(ctx context.Context) (int64, error) {
+	return int64(len(b.events)), nil
+}
+
+// RedisStreamBuffer buffers events in a Redis stream read through a consumer
+// group. Unacknowledged events are redelivered to the same consumer after a
+// restart, and events left pending for claimIdle by any consumer, such as one
+// that was scaled away, are claimed by the next consumer to pop.
+type RedisStreamBuffer struct {
+	client    *redis.Client
+	stream    string
+	group     string
+	consumer  string
+	capacity  int64
+	claimIdle time.Duration
+
+	groupOnce sync.Once
+	groupErr  error
+}
+
+// NewRedisStreamBuffer creates a Redis stream buffer holding up to capacity events.
+// claimIdle must be longer than a batch takes to flush, or batches still being
+// stored are taken over by another consumer and stored twice.
+func NewRedisStreamBuffer(client *redis.Client, consumer string, capacity int64, claimIdle time.Duration) *RedisStreamBuffer {
+	return &RedisStreamBuffer{
+		client:    client,
+		stream:    "behavior:events",
+		group:     "behavior-ingestion",
+		consumer:  consumer,
+		capacity:  capacity,
+		claimIdle: claimIdle,
+	}
+}
+
+func (b *RedisStreamBuffer) ensureGroup(ctx context.Context) error {
+	b.groupOnce.Do(func() {
+		err := b.client.XGroupCreateMkStream(ctx, b.stream, b.group, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			b.groupErr = err
+		}
+	})
+	return b.groupErr
+}
+
+func (b *RedisStreamBuffer) Push(ctx context.Context, events []Event) (int, error) {
+	if err := b.ensureGroup(ctx); err != nil {
+		return 0, err
+	}
+
+	length, err := b.client.XLen(ctx, b.stream).Result()
+	if err != nil {
+		return 0, err
+	}
+	room := int(b.capacity - length)
+	if room <= 0 {
+		return 0, nil
+	}
+	if room < len(events) {
+		events = events[:room]
+	}
+
+	pipe := b.client.Pipeline()
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return 0, err
+		}
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: b.stream,
+			MaxLen: b.capacity,
+			Approx: true,
+			Values: map[string]interface{}{"event": payload},
+		})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (b *RedisStreamBuffer) Pop(ctx context.Context, max int, wait time.Duration) (*Batch, error) {
+	if err := b.ensureGroup(ctx); err != nil {
+		return nil, err
+	}
+
+	// Events delivered to this consumer but never acknowledged are retried first,
+	// along with those another consumer has left idle
+	if err := b.claimIdleEvents(ctx, max); err != nil {
+		return nil, err
+	}
+	batch, err := b.read(ctx, "0", max, -1)
+	if err != nil || len(batch.ids) > 0 {
+		return batch, err
+	}
+
+	block := time.Duration(-1)
+	if wait > 0 {
+		block = wait
+	}
+	return b.read(ctx, ">", max, block)
+}
+
+// claimIdleEvents moves events pending on any consumer for longer than claimIdle
+// to this consumer's pending list. XAUTOCLAIM is sent as a raw command because
+// the client cannot parse the three-part reply Redis 7 sends.
+func (b *RedisStreamBuffer) claimIdleEvents(ctx context.Context, max int) error {
+	if b.claimIdle <= 0 {
+		return nil
+	}
+	err := b.client.Do(ctx, "XAUTOCLAIM", b.stream, b.group, b.consumer,
+		b.claimIdle.Milliseconds(), "0-0", "COUNT", max, "JUSTID").Err()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
+
+func (b *RedisStreamBuffer) read(ctx context.Context, id string, max int, block time.Duration) (*Batch, error) {
+	streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    b.group,
+		Consumer: b.consumer,
+		Streams:  []string{b.stream, id},
+		Count:    int64(max),
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return &Batch{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{}
+	var malformed []string
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			payload, _ := message.Values["event"].(string)
+			var event Event
+			if err := json.Unmarshal([]byte(payload), &event); err != nil {
+				malformed = append(malformed, message.ID)
+				continue
+			}
+			batch.Events = append(batch.Events, event)
+			batch.ids = append(batch.ids, message.ID)
+		}
+	}
+	if len(malformed) > 0 {
+		// Undecodable entries can never be stored, so drop them rather than redeliver forever
+		if err := b.Ack(ctx, &Batch{ids: malformed}); err != nil {
+			return nil, err
+		}
+	}
+	return batch, nil
+}
+
+func (b *RedisStreamBuffer) Ack(ctx context.Context, batch *Batch) error {
+	if len(batch.ids) == 0 {
+		return nil
+	}
+	pipe := b.client.Pipeline()
+	pipe.XAck(ctx, b.stream, b.group, batch.ids...)
+	pipe.XDel(ctx, b.stream, batch.ids...)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (b *RedisStreamBuffer) Len(ctx context.Context) (int64, error) {
+	return b.client.XLen(ctx, b.stream).Result()
+}
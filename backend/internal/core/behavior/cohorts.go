@@ -0,0 +1,304 @@
+package behavior
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/repository"
+	"microbridge/backend/internal/shared/csvexport"
+)
+
+// Cohort breakdowns supported by cohort reports
+const (
+	CohortGroupNone     = ""
+	CohortGroupUserType = "user_type"
+	CohortGroupSource   = "acquisition_source"
+)
+
+// unattributedSource labels signups without an acquisition source in reports
+const unattributedSource = "unknown"
+
+// CohortStatsStore refreshes and reads the stored signup cohorts; repository.BehaviorRepository implements it
+type CohortStatsStore interface {
+	RefreshCohortStats(ctx context.Context, from, to time.Time) error
+	GetCohortStats(ctx context.Context, filter repository.CohortStatsFilter) ([]repository.CohortStats, error)
+}
+
+// CohortQuery selects and groups signup cohorts for a report
+type CohortQuery struct {
+	From              time.Time
+	To                time.Time
+	GroupBy           string
+	UserType          string
+	AcquisitionSource string
+}
+
+// CohortRow is one signup week, optionally broken down by user type or source
+type CohortRow struct {
+	CohortWeek          time.Time
+	Group               string // User type or source; empty when not broken down
+	Size                int
+	Retention           []float64 // Share of the cohort active in each week since signup
+	SuccessRate         float64   // Share of the cohort with an accepted application
+	AvgDaysToSuccess    float64   // Among successful users, days from signup to first acceptance
+	ActionsPerUser      float64
+	ApplicationsPerUser float64
+}
+
+// CohortReport is the result of a cohort query
+type CohortReport struct {
+	GroupBy       string
+	Rows          []CohortRow
+	Withheld      int // Cohorts below the minimum size, left out so small groups cannot be singled out
+	LastRefreshed time.Time
+}
+
+// BuildCohortReport rolls stored cohort counts up to signup weeks, or to weeks
+// and one breakdown dimension, and turns them into rates
+func BuildCohortReport(stats []repository.CohortStats, groupBy string, minCohortSize int) (*CohortReport, error) {
+	switch groupBy {
+	case CohortGroupNone, CohortGroupUserType, CohortGroupSource:
+	default:
+		return nil, fmt.Errorf("unsupported cohort breakdown %q", groupBy)
+	}
+
+	type rollup struct {
+		repository.CohortStats
+		group string
+	}
+	rollups := make(map[string]*rollup)
+	for _, cohort := range stats {
+		group := ""
+		switch groupBy {
+		case CohortGroupUserType:
+			group = cohort.UserType
+		case CohortGroupSource:
+			group = cohort.AcquisitionSource
+			if group == "" {
+				group = unattributedSource
+			}
+		}
+
+		key := cohort.CohortWeek.Format("2006-01-02") + "|" + group
+		total, exists := rollups[key]
+		if !exists {
+			total = &rollup{group: group}
+			total.CohortWeek = cohort.CohortWeek
+			rollups[key] = total
+		}
+		total.CohortSize += cohort.CohortSize
+		total.SuccessfulUsers += cohort.SuccessfulUsers
+		total.DaysToSuccess += cohort.DaysToSuccess
+		total.TotalActions += cohort.TotalActions
+		total.TotalApplications += cohort.TotalApplications
+		for len(total.RetainedUsers) < len(cohort.RetainedUsers) {
+			total.RetainedUsers = append(total.RetainedUsers, 0)
+		}
+		for week, users := range cohort.RetainedUsers {
+			total.RetainedUsers[week] += users
+		}
+	}
+
+	report := &CohortReport{GroupBy: groupBy, Rows: make([]CohortRow, 0, len(rollups))}
+	for _, total := range rollups {
+		if total.CohortSize == 0 || total.CohortSize < minCohortSize {
+			report.Withheld++
+			continue
+		}
+
+		size := float64(total.CohortSize)
+		row := CohortRow{
+			CohortWeek:          total.CohortWeek,
+			Group:               total.group,
+			Size:                total.CohortSize,
+			Retention:           make([]float64, len(total.RetainedUsers)),
+			SuccessRate:         float64(total.SuccessfulUsers) / size,
+			ActionsPerUser:      float64(total.TotalActions) / size,
+			ApplicationsPerUser: float64(total.TotalApplications) / size,
+		}
+		for week, users := range total.RetainedUsers {
+			row.Retention[week] = float64(users) / size
+		}
+		if total.SuccessfulUsers > 0 {
+			row.AvgDaysToSuccess = total.DaysToSuccess / float64(total.SuccessfulUsers)
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if !report.Rows[i].CohortWeek.Equal(report.Rows[j].CohortWeek) {
+			return report.Rows[i].CohortWeek.Before(report.Rows[j].CohortWeek)
+		}
+		return report.Rows[i].Group < report.Rows[j].Group
+	})
+	return report, nil
+}
+
+// WriteCohortCSV writes one line per cohort row with a column per retention week
+func WriteCohortCSV(w io.Writer, report *CohortReport) error {
+	weeks := 0
+	for _, row := range report.Rows {
+		if len(row.Retention) > weeks {
+			weeks = len(row.Retention)
+		}
+	}
+
+	header := []string{"cohort_week"}
+	if report.GroupBy != CohortGroupNone {
+		header = append(header, report.GroupBy)
+	}
+	header = append(header, "cohort_size", "success_rate", "avg_days_to_success", "actions_per_user", "applications_per_user")
+	for week := 0; week < weeks; week++ {
+		header = append(header, "week_"+strconv.Itoa(week))
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range report.Rows {
+		record := []string{row.CohortWeek.Format("2006-01-02")}
+		if report.GroupBy != CohortGroupNone {
+			record = append(record, csvexport.Cell(row.Group))
+		}
+		record = append(record,
+			strconv.Itoa(row.Size),
+			formatRate(row.SuccessRate),
+			formatRate(row.AvgDaysToSuccess),
+			formatRate(row.ActionsPerUser),
+			formatRate(row.ApplicationsPerUser),
+		)
+		// Weeks a young cohort has not reached yet stay empty rather than reading as zero retention
+		for week := 0; week < weeks; week++ {
+			if week < len(row.Retention) {
+				record = append(record, formatRate(row.Retention[week]))
+			} else {
+				record = append(record, "")
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatRate(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
+
+// CohortAggregator periodically recomputes signup cohorts over a trailing
+// window and serves reports from the stored aggregates
+type CohortAggregator struct {
+	store         CohortStatsStore
+	interval      time.Duration
+	lookbackWeeks int
+	minCohortSize int
+
+	mu            sync.Mutex
+	running       bool
+	stop          context.CancelFunc
+	loopGroup     sync.WaitGroup
+	lastRefreshed time.Time
+}
+
+// NewCohortAggregator creates an aggregator refreshing the last lookbackWeeks of cohorts every interval
+func NewCohortAggregator(store CohortStatsStore, interval time.Duration, lookbackWeeks, minCohortSize int) *CohortAggregator {
+	return &CohortAggregator{
+		store:         store,
+		interval:      interval,
+		lookbackWeeks: lookbackWeeks,
+		minCohortSize: minCohortSize,
+	}
+}
+
+// Start refreshes cohorts immediately and then on every interval
+func (a *CohortAggregator) Start(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	a.stop = cancel
+	a.running = true
+
+	a.loopGroup.Add(1)
+	go func() {
+		defer a.loopGroup.Done()
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := a.Refresh(ctx); err != nil && ctx.Err() == nil {
+				fmt.Printf("Failed to refresh signup cohorts: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts periodic refreshes
+func (a *CohortAggregator) Stop() {
+	a.mu.Lock()
+	if !a.running {
+		a.mu.Unlock()
+		return
+	}
+	a.running = false
+	a.stop()
+	a.mu.Unlock()
+
+	a.loopGroup.Wait()
+}
+
+// Refresh recomputes the cohorts in the trailing window now
+func (a *CohortAggregator) Refresh(ctx context.Context) error {
+	now := time.Now().UTC()
+	from := repository.CohortWeek(now).AddDate(0, 0, -7*a.lookbackWeeks)
+	if err := a.store.RefreshCohortStats(ctx, from, now); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.lastRefreshed = now
+	a.mu.Unlock()
+	return nil
+}
+
+// Report builds a cohort report from the stored aggregates
+func (a *CohortAggregator) Report(ctx context.Context, query CohortQuery) (*CohortReport, error) {
+	stats, err := a.store.GetCohortStats(ctx, repository.CohortStatsFilter{
+		From:              query.From,
+		To:                query.To,
+		UserType:          query.UserType,
+		AcquisitionSource: query.AcquisitionSource,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := BuildCohortReport(stats, query.GroupBy, a.minCohortSize)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	report.LastRefreshed = a.lastRefreshed
+	a.mu.Unlock()
+	return report, nil
+}
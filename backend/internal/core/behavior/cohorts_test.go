@@ -0,0 +1,176 @@
+package behavior
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/repository"
+)
+
+func cohortFixture() []repository.CohortStats {
+	week1 := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	return []repository.CohortStats{
+		{CohortWeek: week1, UserType: "student", AcquisitionSource: "campus", CohortSize: 6, RetainedUsers: []int{6, 3, 2},
+			SuccessfulUsers: 2, DaysToSuccess: 20, TotalActions: 60, TotalApplications: 12},
+		{CohortWeek: week1, UserType: "student", AcquisitionSource: "", CohortSize: 2, RetainedUsers: []int{2, 1, 0},
+			TotalActions: 10, TotalApplications: 1},
+		{CohortWeek: week1, UserType: "employer", AcquisitionSource: "campus", CohortSize: 2, RetainedUsers: []int{2, 1, 1},
+			SuccessfulUsers: 0, TotalActions: 10},
+		{CohortWeek: week2, UserType: "student", AcquisitionSource: "campus", CohortSize: 5, RetainedUsers: []int{5, 2},
+			SuccessfulUsers: 1, DaysToSuccess: 4, TotalActions: 25, TotalApplications: 5},
+	}
+}
+
+func TestBuildCohortReport(t *testing.T) {
+	report, err := BuildCohortReport(cohortFixture(), CohortGroupNone, 5)
+	if err != nil {
+		t.Fatalf("BuildCohortReport failed: %v", err)
+	}
+	if len(report.Rows) != 2 || report.Withheld != 0 {
+		t.Fatalf("Expected one row per signup week, got %+v", report)
+	}
+
+	first := report.Rows[0]
+	if first.Size != 10 || first.Group != "" {
+		t.Errorf("Expected the first week to roll up all groups, got %+v", first)
+	}
+	if len(first.Retention) != 3 || first.Retention[0] != 1 || first.Retention[1] != 0.5 || first.Retention[2] != 0.3 {
+		t.Errorf("Unexpected retention curve: %v", first.Retention)
+	}
+	if first.SuccessRate != 0.2 || first.AvgDaysToSuccess != 10 || first.ActionsPerUser != 8 || first.ApplicationsPerUser != 1.3 {
+		t.Errorf("Unexpected cohort rates: %+v", first)
+	}
+	if !report.Rows[1].CohortWeek.After(first.CohortWeek) || len(report.Rows[1].Retention) != 2 {
+		t.Errorf("Expected the younger cohort second with a shorter curve, got %+v", report.Rows[1])
+	}
+
+	bySource, err := BuildCohortReport(cohortFixture(), CohortGroupSource, 5)
+	if err != nil {
+		t.Fatalf("BuildCohortReport failed: %v", err)
+	}
+	// The unattributed week-one students fall below the minimum size
+	if len(bySource.Rows) != 2 || bySource.Withheld != 1 {
+		t.Fatalf("Expected small cohorts to be withheld, got %+v", bySource)
+	}
+	if bySource.Rows[0].Group != "campus" || bySource.Rows[0].Size != 8 {
+		t.Errorf("Expected campus signups to roll up across user types, got %+v", bySource.Rows[0])
+	}
+
+	unfiltered, _ := BuildCohortReport(cohortFixture(), CohortGroupSource, 0)
+	if len(unfiltered.Rows) != 3 || unfiltered.Rows[1].Group != unattributedSource {
+		t.Errorf("Expected signups without a source to be reported as %q, got %+v", unattributedSource, unfiltered.Rows)
+	}
+
+	if _, err := BuildCohortReport(cohortFixture(), "country", 0); err == nil {
+		t.Error("Expected an unsupported breakdown to be rejected")
+	}
+}
+
+func TestWriteCohortCSV(t *testing.T) {
+	report, err := BuildCohortReport(cohortFixture(), CohortGroupUserType, 0)
+	if err != nil {
+		t.Fatalf("BuildCohortReport failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCohortCSV(&buf, report); err != nil {
+		t.Fatalf("WriteCohortCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV back: %v", err)
+	}
+
+	if len(records) != 4 {
+		t.Fatalf("Expected a header and three rows, got %d lines", len(records))
+	}
+	header := records[0]
+	if header[1] != CohortGroupUserType || header[len(header)-1] != "week_2" {
+		t.Errorf("Unexpected header: %v", header)
+	}
+	last := records[3]
+	if last[0] != "2024-03-11" || last[1] != "student" || last[2] != "5" {
+		t.Errorf("Unexpected row: %v", last)
+	}
+	if last[len(last)-1] != "" || last[len(last)-2] != "0.4000" {
+		t.Errorf("Expected unreached weeks to be left empty, got %v", last)
+	}
+}
+
+func TestWriteCohortCSV_EscapesFormulaGroups(t *testing.T) {
+	stats := cohortFixture()
+	stats[0].AcquisitionSource = "=HYPERLINK(\"http://evil\")"
+	report, err := BuildCohortReport(stats, CohortGroupSource, 0)
+	if err != nil {
+		t.Fatalf("BuildCohortReport failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCohortCSV(&buf, report); err != nil {
+		t.Fatalf("WriteCohortCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV back: %v", err)
+	}
+	escaped := 0
+	for _, record := range records[1:] {
+		if strings.HasPrefix(record[1], "=") {
+			t.Errorf("Expected the group to be neutralized, got %q", record[1])
+		}
+		if record[1] == "'=HYPERLINK(\"http://evil\")" {
+			escaped++
+		}
+	}
+	if escaped != 1 {
+		t.Errorf("Expected the formula source to be written once with a leading quote, got %v", records)
+	}
+}
+
+type fakeCohortStore struct {
+	refreshed []time.Time
+	filter    repository.CohortStatsFilter
+}
+
+func (s *fakeCohortStore) RefreshCohortStats(ctx context.Context, from, to time.Time) error {
+	s.refreshed = append(s.refreshed, from, to)
+	return nil
+}
+
+func (s *fakeCohortStore) GetCohortStats(ctx context.Context, filter repository.CohortStatsFilter) ([]repository.CohortStats, error) {
+	s.filter = filter
+	return cohortFixture(), nil
+}
+
+func TestCohortAggregator_RefreshAndReport(t *testing.T) {
+	store := &fakeCohortStore{}
+	aggregator := NewCohortAggregator(store, time.Hour, 4, 5)
+	ctx := context.Background()
+
+	if err := aggregator.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if len(store.refreshed) != 2 {
+		t.Fatalf("Expected one refresh, got %v", store.refreshed)
+	}
+	from := store.refreshed[0]
+	if from.Weekday() != time.Monday || store.refreshed[1].Sub(from) < 4*7*24*time.Hour {
+		t.Errorf("Expected a refresh window of four whole weeks back, got %v", store.refreshed)
+	}
+
+	report, err := aggregator.Report(ctx, CohortQuery{UserType: "student", GroupBy: CohortGroupUserType})
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if store.filter.UserType != "student" {
+		t.Errorf("Expected filters to reach the store, got %+v", store.filter)
+	}
+	if report.LastRefreshed.IsZero() {
+		t.Error("Expected the report to carry the last refresh time")
+	}
+}
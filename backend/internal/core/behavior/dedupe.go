@@ -0,0 +1,86 @@
+package behavior
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Deduplicator remembers idempotency keys that have already been accepted
+type Deduplicator interface {
+	// Claim reserves key for ttl and reports whether it was not seen before
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Release forgets a key so an event that was shed or dropped can be resent
+	Release(ctx context.Context, keys ...string) error
+}
+
+// RedisDeduplicator shares claimed keys between API instances
+type RedisDeduplicator struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisDeduplicator creates a Redis-backed deduplicator
+func NewRedisDeduplicator(client *redis.Client) *RedisDeduplicator {
+	return &RedisDeduplicator{client: client, prefix: "behavior:event:"}
+}
+
+func (d *RedisDeduplicator) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return d.client.SetNX(ctx, d.prefix+key, 1, ttl).Result()
+}
+
+func (d *RedisDeduplicator) Release(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = d.prefix + key
+	}
+	return d.client.Del(ctx, prefixed...).Err()
+}
+
+// MemoryDeduplicator is an in-process Deduplicator for tests and single-instance deployments
+type MemoryDeduplicator struct {
+	mu        sync.Mutex
+	keys      map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryDeduplicator creates an in-process deduplicator
+func NewMemoryDeduplicator() *MemoryDeduplicator {
+	return &MemoryDeduplicator{keys: make(map[string]time.Time)}
+}
+
+func (d *MemoryDeduplicator) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(d.lastSweep) > time.Minute {
+		for claimed, expiresAt := range d.keys {
+			if now.After(expiresAt) {
+				delete(d.keys, claimed)
+			}
+		}
+		d.lastSweep = now
+	}
+
+	if expiresAt, exists := d.keys[key]; exists && now.Before(expiresAt) {
+		return false, nil
+	}
+	d.keys[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (d *MemoryDeduplicator) Release(ctx context.Context, keys ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, key := range keys {
+		delete(d.keys, key)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// skillInterestRate is how far one interaction moves a skill interest towards its bounds
+const skillInterestRate = 0.3
+
+// interactionScores weight skill interactions; they match the behavior service's scoring
+var interactionScores = map[string]float64{
+	"click":     0.1,
+	"hover":     0.1,
+	"explore":   0.3,
+	"read_more": 0.3,
+	"bookmark":  0.6,
+	"share":     0.6,
+	"apply":     1.0,
+	"contact":   1.0,
+	"dismiss":   -0.2,
+	"hide":      -0.2,
+}
+
+// actionSkillScores weight the skills attached to job actions
+var actionSkillScores = map[string]float64{
+	EventView:    0.1,
+	EventSave:    0.6,
+	EventApply:   1.0,
+	EventDismiss: -0.2,
+}
+
+// rewardedEvents are the job actions that credit the exploration bandit
+var rewardedEvents = map[string]bool{
+	EventView:  true,
+	EventSave:  true,
+	EventApply: true,
+}
+
+// feedbackOutcomes are the job actions fed back to the RL model and their outcome scores
+var feedbackOutcomes = map[string]struct {
+	action  string
+	outcome float64
+}{
+	EventApply:   {"applied", 0.9},
+	EventDismiss: {"dismissed", 0.1},
+}
+
+// sessionFrequencyWindow is the period SessionFrequency averages over
+const sessionFrequencyWindow = 28 * 24 * time.Hour
+
+// DerivedStore is the part of the behavior repository the derived updater writes to
+type DerivedStore interface {
+	SessionStore
+	GetSkillInterests(ctx context.Context, userID string) (map[string]float64, error)
+	UpdateSkillInterest(ctx context.Context, userID, skillName string, interest float64) error
+	GetEngagementMetrics(ctx context.Context, userID string) (*repository.EngagementMetrics, error)
+	StoreEngagementMetrics(ctx context.Context, metrics repository.EngagementMetrics) error
+}
+
+// FeedbackRecorder credits the recommendation models with job outcomes;
+// *aiServices.HybridMatchingService implements it
+type FeedbackRecorder interface {
+	RecordExplorationReward(ctx context.Context, userID, jobID, event string) error
+	ProcessUserFeedback(ctx context.Context, userID, jobID, matchID, action string, outcome float64) error
+}
+
+// RepositoryUpdater folds stored actions into each user's sessions, skill
+// interests and engagement metrics, and feeds job outcomes to the models
+type RepositoryUpdater struct {
+	store    DerivedStore
+	sessions *Sessionizer
+	feedback FeedbackRecorder
+}
+
+// NewRepositoryUpdater creates a derived updater backed by the behavior repository.
+// Actions further apart than sessionTimeout fall into separate sessions. Every
+// stored job action is passed to feedback once; it may be nil.
+func NewRepositoryUpdater(store DerivedStore, sessionTimeout time.Duration, feedback FeedbackRecorder) *RepositoryUpdater {
+	return &RepositoryUpdater{store: store, sessions: NewSessionizer(store, sessionTimeout), feedback: feedback}
+}
+
+// Apply updates every user in the batch and returns the first error after trying them all
+func (u *RepositoryUpdater) Apply(ctx context.Context, actions []repository.UserAction) error {
+	byUser := make(map[string][]repository.UserAction)
+	var users []string
+	for _, action := range actions {
+		if _, seen := byUser[action.UserID]; !seen {
+			users = append(users, action.UserID)
+		}
+		byUser[action.UserID] = append(byUser[action.UserID], action)
+	}
+
+	var firstErr error
+	for _, userID := range users {
+		userActions := byUser[userID]
+		sort.Slice(userActions, func(i, j int) bool {
+			return userActions[i].Timestamp.Before(userActions[j].Timestamp)
+		})
+		if err := u.applySkillInterests(ctx, userID, userActions); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		// Engagement totals still update when sessions fail; they only miss the session counts
+		sessions, err := u.sessions.Apply(ctx, userID, userActions)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to update sessions for %s: %w", userID, err)
+			}
+			sessions = &SessionUpdate{}
+		}
+		if err := u.applyEngagement(ctx, userID, userActions, sessions); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if err := u.applyFeedback(ctx, userID, userActions); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// applyFeedback credits the bandit for views, saves and applications and sends
+// applications and dismissals to the RL model. It runs once per stored action,
+// however the action was tracked.
+func (u *RepositoryUpdater) applyFeedback(ctx context.Context, userID string, actions []repository.UserAction) error {
+	if u.feedback == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, action := range actions {
+		if action.EntityID == "" {
+			continue
+		}
+		if rewardedEvents[action.ActionType] {
+			if err := u.feedback.RecordExplorationReward(ctx, userID, action.EntityID, action.ActionType); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("failed to record exploration reward for %s: %w", userID, err)
+			}
+		}
+		if feedback, known := feedbackOutcomes[action.ActionType]; known {
+			if err := u.feedback.ProcessUserFeedback(ctx, userID, action.EntityID, "", feedback.action, feedback.outcome); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("failed to process RL feedback for %s: %w", userID, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+func (u *RepositoryUpdater) applySkillInterests(ctx context.Context, userID string, actions []repository.UserAction) error {
+	var touched bool
+	deltas := make(map[string][]float64)
+	for _, action := range actions {
+		for skill, score := range skillScores(action) {
+			deltas[skill] = append(deltas[skill], score)
+			touched = true
+		}
+	}
+	if !touched {
+		return nil
+	}
+
+	interests, err := u.store.GetSkillInterests(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load skill interests for %s: %w", userID, err)
+	}
+	for skill, scores := range deltas {
+		interest := interests[skill]
+		for _, score := range scores {
+			// Positive signals close part of the gap to 1, negative ones part of the gap to 0
+			if score >= 0 {
+				interest += skillInterestRate * score * (1 - interest)
+			} else {
+				interest += skillInterestRate * score * interest
+			}
+		}
+		interest = math.Max(0, math.Min(1, interest))
+		if err := u.store.UpdateSkillInterest(ctx, userID, skill, interest); err != nil {
+			return fmt.Errorf("failed to update skill interest %s for %s: %w", skill, userID, err)
+		}
+	}
+	return nil
+}
+
+// skillScores returns the skills an action signals interest in
+func skillScores(action repository.UserAction) map[string]float64 {
+	scores := make(map[string]float64)
+	if action.ActionType == EventSkillInterest {
+		interaction, _ := action.Data["interaction_type"].(string)
+		if score, known := interactionScores[interaction]; known && action.EntityID != "" {
+			scores[action.EntityID] = score
+		}
+		return scores
+	}
+
+	score, known := actionSkillScores[action.ActionType]
+	if !known {
+		return scores
+	}
+	skills, _ := action.Data["skills"].([]interface{})
+	for _, skill := range skills {
+		if name, ok := skill.(string); ok && strings.TrimSpace(name) != "" {
+			scores[strings.TrimSpace(name)] = score
+		}
+	}
+	return scores
+}
+
+func (u *RepositoryUpdater) applyEngagement(ctx context.Context, userID string, actions []repository.UserAction, sessions *SessionUpdate) error {
+	metrics, err := u.store.GetEngagementMetrics(ctx, userID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != http.StatusNotFound {
+			return fmt.Errorf("failed to load engagement metrics for %s: %w", userID, err)
+		}
+		metrics = &repository.EngagementMetrics{UserID: userID}
+	}
+
+	totalViewTime := metrics.AverageTimePerJob * time.Duration(metrics.TotalViews)
+	for _, action := range actions {
+		switch action.ActionType {
+		case EventView:
+			metrics.TotalViews++
+			if seconds, ok := action.Data["time_spent"].(float64); ok && seconds > 0 {
+				totalViewTime += time.Duration(seconds * float64(time.Second))
+			}
+		case EventApply:
+			metrics.TotalApplications++
+		case EventSave:
+			metrics.TotalSaves++
+		case EventSearch:
+			metrics.TotalSearches++
+		}
+		if action.Timestamp.After(metrics.LastActiveSession) {
+			metrics.LastActiveSession = action.Timestamp
+		}
+	}
+
+	if metrics.TotalViews > 0 {
+		metrics.AverageTimePerJob = totalViewTime / time.Duration(metrics.TotalViews)
+		metrics.ApplicationRate = float64(metrics.TotalApplications) / float64(metrics.TotalViews)
+	}
+	if metrics.TotalSearches > 0 {
+		metrics.SearchToViewRatio = float64(metrics.TotalViews) / float64(metrics.TotalSearches)
+	}
+
+	now := time.Now().UTC()
+	if len(sessions.Sessions) > 0 || len(sessions.Deleted) > 0 {
+		// Merged sessions were counted when they started, so they come off the total
+		metrics.TotalSessions += int64(sessions.Started - len(sessions.Deleted))
+		if metrics.TotalSessions < 0 {
+			metrics.TotalSessions = 0
+		}
+		recent, err := u.store.GetUserSessions(ctx, userID, now.Add(-sessionFrequencyWindow), now)
+		if err != nil {
+			return fmt.Errorf("failed to load sessions for %s: %w", userID, err)
+		}
+		metrics.SessionFrequency = SessionsPerWeek(recent, now, sessionFrequencyWindow)
+	}
+	metrics.LastCalculated = now
+
+	if err := u.store.StoreEngagementMetrics(ctx, *metrics); err != nil {
+		return fmt.Errorf("failed to store engagement metrics for %s: %w", userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package behavior
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"microbridge/backend/internal/repository"
+)
+
+// maxUserAgentLength bounds the stored user agent; longer ones are truncated
+const maxUserAgentLength = 512
+
+var screenSizePattern = regexp.MustCompile(`^\d{2,5}x\d{2,5}$`)
+
+// clientPlatforms are the values accepted from the X-Client-Platform header
+var clientPlatforms = map[string]bool{"web": true, "mobile": true, "desktop": true}
+
+// browserMarkers and osMarkers map user agent substrings to names, first match wins. Order
+// matters: Edge and Opera also claim Chrome, and Chrome also claims Safari.
+var (
+	browserMarkers = []struct{ marker, name string }{
+		{"Edg/", "Edge"},
+		{"EdgiOS/", "Edge"},
+		{"OPR/", "Opera"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+	osMarkers = []struct{ marker, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iOS"},
+		{"Android", "Android"},
+		{"CrOS", "ChromeOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// DeviceFromHeaders describes the client behind a request. Apps can state
+// their platform and screen in X-Client-Platform and X-Screen-Size; everything
+// else comes from client hints and the user agent.
+func DeviceFromHeaders(header http.Header) repository.DeviceInfo {
+	userAgent := header.Get("User-Agent")
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	device := repository.DeviceInfo{
+		UserAgent:  userAgent,
+		Browser:    firstMarker(userAgent, browserMarkers),
+		OS:         strings.Trim(header.Get("Sec-CH-UA-Platform"), `"`),
+		DeviceType: deviceType(header, userAgent),
+	}
+	if device.OS == "" {
+		device.OS = firstMarker(userAgent, osMarkers)
+	}
+	if size := strings.ToLower(strings.TrimSpace(header.Get("X-Screen-Size"))); screenSizePattern.MatchString(size) {
+		device.ScreenSize = size
+	}
+
+	platform := strings.ToLower(strings.TrimSpace(header.Get("X-Client-Platform")))
+	switch {
+	case clientPlatforms[platform]:
+		device.Platform = platform
+	case strings.Contains(userAgent, "Electron/"):
+		device.Platform = "desktop"
+	case userAgent != "" && !strings.HasPrefix(userAgent, "Mozilla/"):
+		// Native HTTP clients such as okhttp or CFNetwork don't pose as browsers
+		device.Platform = "mobile"
+	default:
+		device.Platform = "web"
+	}
+	return device
+}
+
+func deviceType(header http.Header, userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "iPad"), strings.Contains(userAgent, "Tablet"),
+		strings.Contains(userAgent, "Android") && !strings.Contains(userAgent, "Mobile"):
+		return "tablet"
+	case header.Get("Sec-CH-UA-Mobile") == "?1", strings.Contains(userAgent, "Mobi"), strings.Contains(userAgent, "iPhone"):
+		return "phone"
+	case strings.HasPrefix(userAgent, "Mozilla/"):
+		return "desktop"
+	default:
+		// Native clients rarely say what they run on; apps can send client hints
+		return ""
+	}
+}
+
+func firstMarker(userAgent string, markers []struct{ marker, name string }) string {
+	for _, m := range markers {
+		if strings.Contains(userAgent, m.marker) {
+			return m.name
+		}
+	}
+	return ""
+}
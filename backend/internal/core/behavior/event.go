@@ -0,0 +1,112 @@
+package behavior
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"microbridge/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Event types accepted by the ingestion pipeline. They match the action types
+// stored by the behavior repository.
+const (
+	EventImpression    = "impression" // A job was shown in a list, search result or recommendation
+	EventView          = "view"
+	EventApply         = "apply"
+	EventSave          = "save"
+	EventDismiss       = "dismiss"
+	EventSearch        = "search"
+	EventSkillInterest = "skill_interest"
+)
+
+const maxIdempotencyKeyLength = 128
+
+// eventNamespace derives stable action IDs from idempotency keys
+var eventNamespace = uuid.MustParse("6f1c4a52-9b0e-4d7a-8a35-2f3c9e1d7b64")
+
+// entityTypes is the entity an event type refers to; search events carry their query in Data
+var entityTypes = map[string]string{
+	EventImpression:    "job",
+	EventView:          "job",
+	EventApply:         "job",
+	EventSave:          "job",
+	EventDismiss:       "job",
+	EventSearch:        "",
+	EventSkillInterest: "skill",
+}
+
+// Event is a single client-reported behavior event
+type Event struct {
+	IdempotencyKey string                 `json:"idempotency_key"`
+	UserID         string                 `json:"user_id"`
+	Type           string                 `json:"type"`
+	EntityID       string                 `json:"entity_id,omitempty"`
+	EntityType     string                 `json:"entity_type,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+	SessionID      string                 `json:"session_id,omitempty"`
+	OccurredAt     time.Time              `json:"occurred_at"`
+	// Device is taken from the request headers, never from the client's payload
+	Device repository.DeviceInfo `json:"device"`
+}
+
+// normalize fills defaults and checks the event can be stored. Events older
+// than maxAge or noticeably in the future are rejected rather than clamped,
+// since a wrong timestamp would land them in the wrong partition and trend.
+func (e *Event) normalize(now time.Time, maxAge time.Duration) error {
+	e.IdempotencyKey = strings.TrimSpace(e.IdempotencyKey)
+	if e.IdempotencyKey == "" {
+		return fmt.Errorf("idempotency_key is required")
+	}
+	if len(e.IdempotencyKey) > maxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency_key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+
+	entityType, known := entityTypes[e.Type]
+	if !known {
+		return fmt.Errorf("unknown event type %q", e.Type)
+	}
+	if entityType != "" {
+		if strings.TrimSpace(e.EntityID) == "" {
+			return fmt.Errorf("entity_id is required for %s events", e.Type)
+		}
+		e.EntityType = entityType
+	} else if query, _ := e.Data["query"].(string); strings.TrimSpace(query) == "" {
+		return fmt.Errorf("data.query is required for %s events", e.Type)
+	}
+
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = now
+	}
+	if e.OccurredAt.After(now.Add(time.Minute)) {
+		return fmt.Errorf("occurred_at is in the future")
+	}
+	if maxAge > 0 && now.Sub(e.OccurredAt) > maxAge {
+		return fmt.Errorf("occurred_at is older than %s", maxAge)
+	}
+	e.OccurredAt = e.OccurredAt.UTC()
+	return nil
+}
+
+// dedupeKey scopes the client's idempotency key to the user who sent it
+func (e *Event) dedupeKey() string {
+	return e.UserID + ":" + e.IdempotencyKey
+}
+
+// toAction converts the event into a stored action. The action ID is derived
+// from the idempotency key, so a retried flush cannot insert the event twice.
+func (e *Event) toAction() repository.UserAction {
+	return repository.UserAction{
+		ID:         uuid.NewSHA1(eventNamespace, []byte(e.dedupeKey())).String(),
+		UserID:     e.UserID,
+		ActionType: e.Type,
+		EntityID:   e.EntityID,
+		EntityType: e.EntityType,
+		Data:       e.Data,
+		Timestamp:  e.OccurredAt,
+		SessionID:  e.SessionID,
+		DeviceInfo: e.Device,
+	}
+}
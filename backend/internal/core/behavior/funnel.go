@@ -0,0 +1,296 @@
+package behavior
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/repository"
+)
+
+// Marketplace funnel stages, in order
+const (
+	StageImpression = "impression"
+	StageView       = "view"
+	StageSave       = "save"
+	StageApply      = "apply"
+	StageInterview  = "interview"
+	StageAccept     = "accept"
+	StageComplete   = "complete"
+)
+
+// funnelExportLag keeps the exporter's window behind the clock so rows
+// committed with a slightly earlier timestamp are not skipped
+const funnelExportLag = time.Minute
+
+// FunnelStore reads funnel counts; repository.BehaviorRepository implements it
+type FunnelStore interface {
+	GetFunnelStats(ctx context.Context, filter repository.FunnelFilter) ([]repository.FunnelStats, error)
+	CountFunnelTransitions(ctx context.Context, from, to time.Time) ([]repository.FunnelTransitionCount, error)
+}
+
+// FunnelStage is one step of a funnel. Conversion and timing are measured from
+// the previous step; saving is optional, so applications are measured from views.
+type FunnelStage struct {
+	Name                   string
+	Count                  int64
+	ConversionRate         float64
+	MedianTimeFromPrevious time.Duration
+}
+
+// Funnel is the conversion funnel of one job, employer, category or the whole platform
+type Funnel struct {
+	Key               string
+	Stages            []FunnelStage
+	OverallConversion float64 // Completions per impression
+}
+
+// BuildFunnel turns stored counts into ordered stages with conversion rates
+func BuildFunnel(stats repository.FunnelStats) Funnel {
+	stages := []struct {
+		name     string
+		count    int64
+		previous int64
+		median   time.Duration
+	}{
+		{StageImpression, stats.Impressions, 0, 0},
+		{StageView, stats.Views, stats.Impressions, stats.MedianImpressionToView},
+		{StageSave, stats.Saves, stats.Views, stats.MedianViewToSave},
+		{StageApply, stats.Applications, stats.Views, stats.MedianViewToApply},
+		{StageInterview, stats.Interviews, stats.Applications, stats.MedianApplyToInterview},
+		{StageAccept, stats.Accepted, stats.Interviews, stats.MedianInterviewToAccept},
+		{StageComplete, stats.Completed, stats.Accepted, stats.MedianAcceptToComplete},
+	}
+
+	funnel := Funnel{Key: stats.Key, Stages: make([]FunnelStage, len(stages))}
+	for i, stage := range stages {
+		funnel.Stages[i] = FunnelStage{
+			Name:                   stage.name,
+			Count:                  stage.count,
+			ConversionRate:         conversionRate(stage.count, stage.previous),
+			MedianTimeFromPrevious: stage.median,
+		}
+	}
+	funnel.OverallConversion = conversionRate(stats.Completed, stats.Impressions)
+	return funnel
+}
+
+// conversionRate is count/previous, or 0 when nothing reached the previous stage.
+// Stages are tracked independently, so a rate can exceed 1 while impression
+// tracking rolls out to every client.
+func conversionRate(count, previous int64) float64 {
+	if previous == 0 {
+		return 0
+	}
+	return float64(count) / float64(previous)
+}
+
+// FunnelQuery selects funnels; zero times default to the service's trailing window
+type FunnelQuery struct {
+	Scope      string
+	From       time.Time
+	To         time.Time
+	JobID      string
+	EmployerID string
+	Category   string
+}
+
+// FunnelComparison sets funnels against the platform funnel over the same window
+type FunnelComparison struct {
+	Scope    string
+	From     time.Time
+	To       time.Time
+	Baseline Funnel
+	Funnels  []Funnel
+	// Deltas[i][stage] is the difference in conversion rate between Funnels[i] and the baseline
+	Deltas []map[string]float64
+}
+
+// FunnelService computes marketplace conversion funnels from tracked actions and applications
+type FunnelService struct {
+	store  FunnelStore
+	window time.Duration
+}
+
+// NewFunnelService creates a funnel service reporting on the trailing window by default
+func NewFunnelService(store FunnelStore, window time.Duration) *FunnelService {
+	return &FunnelService{store: store, window: window}
+}
+
+// Funnels returns one funnel per key of the query's scope
+func (s *FunnelService) Funnels(ctx context.Context, query FunnelQuery) ([]Funnel, error) {
+	query = s.Resolve(query)
+	stats, err := s.store.GetFunnelStats(ctx, repository.FunnelFilter{
+		Scope:      query.Scope,
+		From:       query.From,
+		To:         query.To,
+		JobID:      query.JobID,
+		EmployerID: query.EmployerID,
+		Category:   query.Category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	funnels := make([]Funnel, len(stats))
+	for i, stat := range stats {
+		funnels[i] = BuildFunnel(stat)
+	}
+	return funnels, nil
+}
+
+// Compare computes the query's funnels alongside the platform-wide baseline
+func (s *FunnelService) Compare(ctx context.Context, query FunnelQuery) (*FunnelComparison, error) {
+	query = s.Resolve(query)
+	baseline, err := s.Funnels(ctx, FunnelQuery{Scope: repository.FunnelScopePlatform, From: query.From, To: query.To})
+	if err != nil {
+		return nil, err
+	}
+	funnels, err := s.Funnels(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &FunnelComparison{
+		Scope:    query.Scope,
+		From:     query.From,
+		To:       query.To,
+		Baseline: BuildFunnel(repository.FunnelStats{}),
+		Funnels:  funnels,
+		Deltas:   make([]map[string]float64, len(funnels)),
+	}
+	if len(baseline) > 0 {
+		comparison.Baseline = baseline[0]
+	}
+	for i, funnel := range funnels {
+		deltas := make(map[string]float64, len(funnel.Stages))
+		for j, stage := range funnel.Stages {
+			deltas[stage.Name] = stage.ConversionRate - comparison.Baseline.Stages[j].ConversionRate
+		}
+		comparison.Deltas[i] = deltas
+	}
+	return comparison, nil
+}
+
+// Resolve fills in the default scope and window, so several queries can cover the same period
+func (s *FunnelService) Resolve(query FunnelQuery) FunnelQuery {
+	if query.Scope == "" {
+		query.Scope = repository.FunnelScopePlatform
+	}
+	if query.To.IsZero() {
+		query.To = time.Now().UTC()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-s.window)
+	}
+	return query
+}
+
+// ConversionRecorder receives funnel transition counts; monitoring.Metrics implements it
+type ConversionRecorder interface {
+	RecordApplicationConversions(status, matchScoreRange, jobCategory string, count int)
+}
+
+// FunnelMetricsExporter periodically adds the applications that reached each
+// funnel stage since its last run to the application conversion counter. Every
+// running exporter adds to the counter, so run it on a single instance.
+type FunnelMetricsExporter struct {
+	store    FunnelStore
+	recorder ConversionRecorder
+	interval time.Duration
+
+	mu        sync.Mutex
+	running   bool
+	stop      context.CancelFunc
+	loopGroup sync.WaitGroup
+	exported  time.Time // End of the last exported window
+}
+
+// NewFunnelMetricsExporter creates an exporter that runs every interval
+func NewFunnelMetricsExporter(store FunnelStore, recorder ConversionRecorder, interval time.Duration) *FunnelMetricsExporter {
+	return &FunnelMetricsExporter{
+		store:    store,
+		recorder: recorder,
+		interval: interval,
+	}
+}
+
+// Start exports transitions from now on; earlier ones are already in the funnel reports
+func (e *FunnelMetricsExporter) Start(ctx context.Context) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.running {
+		return
+	}
+	if e.exported.IsZero() {
+		e.exported = time.Now().UTC().Add(-funnelExportLag)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	e.stop = cancel
+	e.running = true
+
+	e.loopGroup.Add(1)
+	go func() {
+		defer e.loopGroup.Done()
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.Export(ctx); err != nil && ctx.Err() == nil {
+					fmt.Printf("Failed to export funnel metrics: %v\n", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop halts periodic exports
+func (e *FunnelMetricsExporter) Stop() {
+	e.mu.Lock()
+	if !e.running {
+		e.mu.Unlock()
+		return
+	}
+	e.running = false
+	e.stop()
+	e.mu.Unlock()
+
+	e.loopGroup.Wait()
+}
+
+// Export records the transitions since the previous export. A failed export
+// leaves the window open so the next run covers it.
+func (e *FunnelMetricsExporter) Export(ctx context.Context) error {
+	e.mu.Lock()
+	from := e.exported
+	e.mu.Unlock()
+
+	to := time.Now().UTC().Add(-funnelExportLag)
+	if from.IsZero() {
+		from = to.Add(-e.interval)
+	}
+	if !to.After(from) {
+		return nil
+	}
+
+	counts, err := e.store.CountFunnelTransitions(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	for _, count := range counts {
+		e.recorder.RecordApplicationConversions(count.Stage, count.MatchScoreRange, count.Category, int(count.Count))
+	}
+
+	e.mu.Lock()
+	e.exported = to
+	e.mu.Unlock()
+	return nil
+}
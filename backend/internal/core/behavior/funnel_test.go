@@ -0,0 +1,122 @@
+package behavior
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/repository"
+)
+
+type fakeFunnelStore struct {
+	stats       map[string][]repository.FunnelStats
+	filters     []repository.FunnelFilter
+	transitions []repository.FunnelTransitionCount
+	windows     [][2]time.Time
+}
+
+func (s *fakeFunnelStore) GetFunnelStats(ctx context.Context, filter repository.FunnelFilter) ([]repository.FunnelStats, error) {
+	s.filters = append(s.filters, filter)
+	return s.stats[filter.Scope], nil
+}
+
+func (s *fakeFunnelStore) CountFunnelTransitions(ctx context.Context, from, to time.Time) ([]repository.FunnelTransitionCount, error) {
+	s.windows = append(s.windows, [2]time.Time{from, to})
+	return s.transitions, nil
+}
+
+type fakeConversionRecorder struct {
+	counts map[string]int
+}
+
+func (r *fakeConversionRecorder) RecordApplicationConversions(status, matchScoreRange, jobCategory string, count int) {
+	r.counts[status+"/"+matchScoreRange+"/"+jobCategory] += count
+}
+
+func TestBuildFunnel(t *testing.T) {
+	funnel := BuildFunnel(repository.FunnelStats{
+		Key: "job-1", Impressions: 200, Views: 50, Saves: 10, Applications: 20, Interviews: 5, Accepted: 2, Completed: 1,
+		MedianViewToApply: 2 * time.Hour,
+	})
+
+	if len(funnel.Stages) != 7 || funnel.Stages[0].Name != StageImpression || funnel.Stages[6].Name != StageComplete {
+		t.Fatalf("Unexpected stages: %+v", funnel.Stages)
+	}
+	if funnel.Stages[0].ConversionRate != 0 || funnel.Stages[1].ConversionRate != 0.25 {
+		t.Errorf("Unexpected view conversion: %+v", funnel.Stages[:2])
+	}
+	// Saving is optional, so applications convert from views
+	apply := funnel.Stages[3]
+	if apply.Name != StageApply || apply.ConversionRate != 0.4 || apply.MedianTimeFromPrevious != 2*time.Hour {
+		t.Errorf("Unexpected apply stage: %+v", apply)
+	}
+	if funnel.Stages[6].ConversionRate != 0.5 || funnel.OverallConversion != 0.005 {
+		t.Errorf("Unexpected completion conversion: %+v, overall %f", funnel.Stages[6], funnel.OverallConversion)
+	}
+
+	empty := BuildFunnel(repository.FunnelStats{})
+	for _, stage := range empty.Stages {
+		if stage.ConversionRate != 0 {
+			t.Errorf("Expected empty stages to have no conversion, got %+v", stage)
+		}
+	}
+}
+
+func TestFunnelService_Compare(t *testing.T) {
+	store := &fakeFunnelStore{stats: map[string][]repository.FunnelStats{
+		repository.FunnelScopePlatform: {{Impressions: 100, Views: 40, Applications: 10}},
+		repository.FunnelScopeCategory: {
+			{Key: "design", Impressions: 50, Views: 25, Applications: 5},
+			{Key: "writing", Impressions: 50, Views: 15, Applications: 5},
+		},
+	}}
+	service := NewFunnelService(store, 30*24*time.Hour)
+
+	comparison, err := service.Compare(context.Background(), FunnelQuery{Scope: repository.FunnelScopeCategory})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if len(comparison.Funnels) != 2 || comparison.Baseline.Stages[1].Count != 40 {
+		t.Fatalf("Unexpected comparison: %+v", comparison)
+	}
+	if delta := comparison.Deltas[0][StageView]; delta < 0.0999 || delta > 0.1001 {
+		t.Errorf("Expected design views to convert 10 points above the platform, got %f", delta)
+	}
+	if delta := comparison.Deltas[1][StageApply]; delta < 0.0832 || delta > 0.0834 {
+		t.Errorf("Expected writing applications to convert about 8 points above the platform, got %f", delta)
+	}
+
+	// Both queries cover the same default window
+	if len(store.filters) != 2 || !store.filters[0].From.Equal(store.filters[1].From) || !store.filters[0].To.Equal(store.filters[1].To) {
+		t.Fatalf("Expected baseline and categories over the same window, got %+v", store.filters)
+	}
+	if window := store.filters[0].To.Sub(store.filters[0].From); window != 30*24*time.Hour {
+		t.Errorf("Expected the default 30 day window, got %s", window)
+	}
+}
+
+func TestFunnelMetricsExporter_Export(t *testing.T) {
+	store := &fakeFunnelStore{transitions: []repository.FunnelTransitionCount{
+		{Category: "design", Stage: "applied", MatchScoreRange: "0.7-1.0", Count: 3},
+		{Category: "design", Stage: "accepted", MatchScoreRange: "0.7-1.0", Count: 1},
+	}}
+	recorder := &fakeConversionRecorder{counts: make(map[string]int)}
+	exporter := NewFunnelMetricsExporter(store, recorder, time.Minute)
+	ctx := context.Background()
+
+	if err := exporter.Export(ctx); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if recorder.counts["applied/0.7-1.0/design"] != 3 || recorder.counts["accepted/0.7-1.0/design"] != 1 {
+		t.Errorf("Unexpected recorded conversions: %v", recorder.counts)
+	}
+
+	// Consecutive exports cover adjacent windows, so no transition is counted twice
+	time.Sleep(time.Millisecond)
+	if err := exporter.Export(ctx); err != nil {
+		t.Fatalf("Second export failed: %v", err)
+	}
+	if len(store.windows) != 2 || !store.windows[1][0].Equal(store.windows[0][1]) {
+		t.Errorf("Expected the second window to start where the first ended, got %v", store.windows)
+	}
+}
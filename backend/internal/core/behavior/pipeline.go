@@ -0,0 +1,332 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/repository"
+)
+
+// ErrBatchTooLarge is returned when a client submits more events than one request may carry
+var ErrBatchTooLarge = errors.New("too many events in batch")
+
+// Outcomes recorded for ingested events
+const (
+	OutcomeAccepted       = "accepted"
+	OutcomeDuplicate      = "duplicate"
+	OutcomeRejected       = "rejected"
+	OutcomeShed           = "shed"
+	OutcomeStored         = "stored"
+	OutcomeDropped        = "dropped"
+	OutcomeDerivedDropped = "derived_dropped"
+)
+
+// ActionStore persists flushed events; repository.BehaviorRepository implements it
+type ActionStore interface {
+	StoreUserActions(ctx context.Context, actions []repository.UserAction) error
+}
+
+// DerivedUpdater recomputes aggregates such as skill interests and engagement
+// from actions that have been stored
+type DerivedUpdater interface {
+	Apply(ctx context.Context, actions []repository.UserAction) error
+}
+
+// MetricsRecorder exports ingestion metrics; *monitoring.Metrics implements it
+type MetricsRecorder interface {
+	RecordBehaviorEvents(outcome string, count int)
+	RecordBehaviorBufferDepth(depth int64)
+	RecordBehaviorFlush(status string, duration time.Duration)
+}
+
+// Config tunes batching, backpressure and retries
+type Config struct {
+	MaxEventsPerRequest int           // Larger submissions are rejected outright
+	BatchSize           int           // Events written per flush
+	FlushInterval       time.Duration // Longest an event waits for a batch to fill
+	DedupeTTL           time.Duration // How long idempotency keys are remembered
+	MaxEventAge         time.Duration // Older events are rejected
+	MaxRetries          int           // Store attempts after the first before a batch is dropped
+	RetryBackoff        time.Duration // Doubled after every failed attempt
+	DerivedQueueSize    int           // Stored batches waiting for derived updates
+	DerivedTimeout      time.Duration // Budget for the derived updates of one batch
+	DrainTimeout        time.Duration // Budget for flushing buffered events on Stop
+	RetryAfter          time.Duration // Suggested client wait when events are shed
+}
+
+// DefaultConfig returns the settings used when the API does not override them
+func DefaultConfig() Config {
+	return Config{
+		MaxEventsPerRequest: 100,
+		BatchSize:           200,
+		FlushInterval:       time.Second,
+		DedupeTTL:           24 * time.Hour,
+		MaxEventAge:         7 * 24 * time.Hour,
+		MaxRetries:          3,
+		RetryBackoff:        200 * time.Millisecond,
+		DerivedQueueSize:    100,
+		DerivedTimeout:      10 * time.Second,
+		DrainTimeout:        10 * time.Second,
+		RetryAfter:          5 * time.Second,
+	}
+}
+
+// EventError explains why one event of a submission was rejected
+type EventError struct {
+	Index          int
+	IdempotencyKey string
+	Reason         string
+}
+
+// SubmitResult summarises what happened to a submitted batch. Shed events were
+// valid but did not fit in the buffer; their keys are released so the client
+// can resend the whole batch after RetryAfter without duplicating the rest.
+type SubmitResult struct {
+	Accepted   int
+	Duplicates int
+	Shed       int
+	Rejected   []EventError
+	RetryAfter time.Duration
+}
+
+// Pipeline accepts behavior events, buffers them and writes them in batches.
+// Derived aggregates are updated off the request path once a batch is stored.
+type Pipeline struct {
+	config  Config
+	store   ActionStore
+	buffer  Buffer
+	dedupe  Deduplicator
+	updater DerivedUpdater
+	metrics MetricsRecorder
+
+	mu        sync.Mutex
+	running   bool
+	stop      context.CancelFunc
+	loopGroup sync.WaitGroup
+}
+
+// NewPipeline creates an ingestion pipeline; updater and metrics may be nil
+func NewPipeline(config Config, store ActionStore, buffer Buffer, dedupe Deduplicator, updater DerivedUpdater, metrics MetricsRecorder) *Pipeline {
+	return &Pipeline{
+		config:  config,
+		store:   store,
+		buffer:  buffer,
+		dedupe:  dedupe,
+		updater: updater,
+		metrics: metrics,
+	}
+}
+
+// Submit validates, deduplicates and buffers events reported by userID. The
+// user ID on each event is overwritten so clients cannot report for others.
+func (p *Pipeline) Submit(ctx context.Context, userID string, events []Event) (*SubmitResult, error) {
+	if len(events) > p.config.MaxEventsPerRequest {
+		return nil, ErrBatchTooLarge
+	}
+
+	result := &SubmitResult{}
+	now := time.Now()
+	fresh := make([]Event, 0, len(events))
+	for i, event := range events {
+		event.UserID = userID
+		if err := event.normalize(now, p.config.MaxEventAge); err != nil {
+			result.Rejected = append(result.Rejected, EventError{Index: i, IdempotencyKey: event.IdempotencyKey, Reason: err.Error()})
+			continue
+		}
+
+		claimed, err := p.dedupe.Claim(ctx, event.dedupeKey(), p.config.DedupeTTL)
+		if err != nil {
+			p.release(ctx, fresh)
+			return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+		}
+		if !claimed {
+			result.Duplicates++
+			continue
+		}
+		fresh = append(fresh, event)
+	}
+
+	taken, err := p.buffer.Push(ctx, fresh)
+	if err != nil {
+		p.release(ctx, fresh)
+		return nil, fmt.Errorf("failed to buffer events: %w", err)
+	}
+	result.Accepted = taken
+	if shed := fresh[taken:]; len(shed) > 0 {
+		p.release(ctx, shed)
+		result.Shed = len(shed)
+		result.RetryAfter = p.config.RetryAfter
+	}
+
+	p.record(OutcomeAccepted, result.Accepted)
+	p.record(OutcomeDuplicate, result.Duplicates)
+	p.record(OutcomeRejected, len(result.Rejected))
+	p.record(OutcomeShed, result.Shed)
+	return result, nil
+}
+
+// Start begins flushing buffered events in the background
+func (p *Pipeline) Start(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p.stop = cancel
+	p.running = true
+
+	derived := make(chan []repository.UserAction, p.config.DerivedQueueSize)
+	p.loopGroup.Add(2)
+	go func() {
+		defer p.loopGroup.Done()
+		defer close(derived)
+		p.flushLoop(ctx, derived)
+	}()
+	go func() {
+		defer p.loopGroup.Done()
+		p.deriveLoop(derived)
+	}()
+}
+
+// Stop flushes what is still buffered, finishes queued derived updates and returns
+func (p *Pipeline) Stop() {
+	p.mu.Lock()
+	if !p.running {
+		p.mu.Unlock()
+		return
+	}
+	p.running = false
+	p.stop()
+	p.mu.Unlock()
+
+	p.loopGroup.Wait()
+}
+
+func (p *Pipeline) flushLoop(ctx context.Context, derived chan<- []repository.UserAction) {
+	for ctx.Err() == nil {
+		batch, err := p.buffer.Pop(ctx, p.config.BatchSize, p.config.FlushInterval)
+		if err != nil {
+			fmt.Printf("Failed to read behavior events: %v\n", err)
+			p.wait(ctx, p.config.FlushInterval)
+			continue
+		}
+		p.flush(ctx, batch, derived)
+		p.recordDepth(ctx)
+	}
+
+	// Drain on a fresh context; the loop context is already cancelled
+	drainCtx, cancel := context.WithTimeout(context.Background(), p.config.DrainTimeout)
+	defer cancel()
+	for drainCtx.Err() == nil {
+		batch, err := p.buffer.Pop(drainCtx, p.config.BatchSize, 0)
+		if err != nil || len(batch.Events) == 0 {
+			return
+		}
+		p.flush(drainCtx, batch, derived)
+	}
+}
+
+func (p *Pipeline) flush(ctx context.Context, batch *Batch, derived chan<- []repository.UserAction) {
+	if len(batch.Events) == 0 {
+		return
+	}
+	// A popped batch is always finished, even when Stop cancels the loop mid-flush
+	ctx = context.WithoutCancel(ctx)
+
+	actions := make([]repository.UserAction, len(batch.Events))
+	for i := range batch.Events {
+		actions[i] = batch.Events[i].toAction()
+	}
+
+	started := time.Now()
+	err := p.store.StoreUserActions(ctx, actions)
+	backoff := p.config.RetryBackoff
+	for attempt := 0; err != nil && attempt < p.config.MaxRetries; attempt++ {
+		p.wait(ctx, backoff)
+		backoff *= 2
+		err = p.store.StoreUserActions(ctx, actions)
+	}
+
+	if err != nil {
+		fmt.Printf("Dropping %d behavior events after failed flush: %v\n", len(actions), err)
+		p.recordFlush("failed", time.Since(started))
+		p.record(OutcomeDropped, len(actions))
+		// Let clients that resend these events have them accepted again
+		p.release(ctx, batch.Events)
+	} else {
+		p.recordFlush("success", time.Since(started))
+		p.record(OutcomeStored, len(actions))
+	}
+
+	if ackErr := p.buffer.Ack(ctx, batch); ackErr != nil {
+		fmt.Printf("Failed to acknowledge behavior events: %v\n", ackErr)
+	}
+
+	if err == nil && p.updater != nil {
+		select {
+		case derived <- actions:
+		default:
+			// Derived aggregates are best effort; stored actions stay the source of truth
+			p.record(OutcomeDerivedDropped, len(actions))
+		}
+	}
+}
+
+func (p *Pipeline) deriveLoop(derived <-chan []repository.UserAction) {
+	for actions := range derived {
+		ctx, cancel := context.WithTimeout(context.Background(), p.config.DerivedTimeout)
+		if err := p.updater.Apply(ctx, actions); err != nil {
+			fmt.Printf("Failed to update derived behavior data: %v\n", err)
+		}
+		cancel()
+	}
+}
+
+func (p *Pipeline) release(ctx context.Context, events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	keys := make([]string, len(events))
+	for i := range events {
+		keys[i] = events[i].dedupeKey()
+	}
+	if err := p.dedupe.Release(ctx, keys...); err != nil {
+		fmt.Printf("Failed to release behavior idempotency keys: %v\n", err)
+	}
+}
+
+func (p *Pipeline) wait(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+func (p *Pipeline) record(outcome string, count int) {
+	if p.metrics != nil && count > 0 {
+		p.metrics.RecordBehaviorEvents(outcome, count)
+	}
+}
+
+func (p *Pipeline) recordFlush(status string, duration time.Duration) {
+	if p.metrics != nil {
+		p.metrics.RecordBehaviorFlush(status, duration)
+	}
+}
+
+func (p *Pipeline) recordDepth(ctx context.Context) {
+	if p.metrics == nil {
+		return
+	}
+	if depth, err := p.buffer.Len(ctx); err == nil {
+		p.metrics.RecordBehaviorBufferDepth(depth)
+	}
+}
@@ -0,0 +1,287 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+type fakeActionStore struct {
+	mu      sync.Mutex
+	actions map[string]repository.UserAction
+	err     error
+	calls   int
+}
+
+func newFakeActionStore() *fakeActionStore {
+	return &fakeActionStore{actions: make(map[string]repository.UserAction)}
+}
+
+func (s *fakeActionStore) StoreUserActions(ctx context.Context, actions []repository.UserAction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.err != nil {
+		return s.err
+	}
+	for _, action := range actions {
+		s.actions[action.ID] = action
+	}
+	return nil
+}
+
+type fakeUpdater struct {
+	mu      sync.Mutex
+	applied []repository.UserAction
+}
+
+func (u *fakeUpdater) Apply(ctx context.Context, actions []repository.UserAction) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.applied = append(u.applied, actions...)
+	return nil
+}
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.FlushInterval = 10 * time.Millisecond
+	config.RetryBackoff = time.Millisecond
+	return config
+}
+
+func viewEvent(key, jobID string) Event {
+	return Event{IdempotencyKey: key, Type: EventView, EntityID: jobID, Data: map[string]interface{}{"time_spent": 30.0}}
+}
+
+func TestPipeline_SubmitAndFlush(t *testing.T) {
+	store := newFakeActionStore()
+	updater := &fakeUpdater{}
+	pipeline := NewPipeline(testConfig(), store, NewMemoryBuffer(100), NewMemoryDeduplicator(), updater, nil)
+	ctx := context.Background()
+
+	result, err := pipeline.Submit(ctx, "user-1", []Event{
+		viewEvent("a", "job-1"),
+		viewEvent("a", "job-1"),
+		{IdempotencyKey: "b", Type: EventSearch, Data: map[string]interface{}{"query": "go developer"}, UserID: "someone-else"},
+		{IdempotencyKey: "c", Type: "teleport", EntityID: "job-1"},
+		{IdempotencyKey: "d", Type: EventApply},
+		viewEvent("", "job-2"),
+	})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if result.Accepted != 2 || result.Duplicates != 1 || len(result.Rejected) != 3 || result.Shed != 0 {
+		t.Fatalf("Unexpected submit result: %+v", result)
+	}
+	if result.Rejected[0].Index != 3 || result.Rejected[1].Index != 4 || result.Rejected[2].Index != 5 {
+		t.Errorf("Expected rejections to point at the invalid events, got %+v", result.Rejected)
+	}
+
+	pipeline.Start(ctx)
+	// A retried request after the events were accepted is fully deduplicated
+	retry, err := pipeline.Submit(ctx, "user-1", []Event{viewEvent("a", "job-1")})
+	if err != nil || retry.Accepted != 0 || retry.Duplicates != 1 {
+		t.Fatalf("Expected retry to be a duplicate, got %+v (%v)", retry, err)
+	}
+	// Idempotency keys are per user
+	other, err := pipeline.Submit(ctx, "user-2", []Event{viewEvent("a", "job-1")})
+	if err != nil || other.Accepted != 1 {
+		t.Fatalf("Expected another user's key to be accepted, got %+v (%v)", other, err)
+	}
+	pipeline.Stop()
+
+	if len(store.actions) != 3 {
+		t.Fatalf("Expected 3 stored actions, got %d", len(store.actions))
+	}
+	for _, action := range store.actions {
+		if action.UserID != "user-1" && action.UserID != "user-2" {
+			t.Errorf("Expected the authenticated user on stored actions, got %s", action.UserID)
+		}
+		if action.ActionType == EventView && action.EntityType != "job" {
+			t.Errorf("Expected view actions to reference jobs, got %q", action.EntityType)
+		}
+	}
+	if len(updater.applied) != 3 {
+		t.Errorf("Expected derived updates for every stored action, got %d", len(updater.applied))
+	}
+
+	// A batch stored twice, as after a crash before the buffer ack, maps onto the same rows
+	resent := viewEvent("a", "job-1")
+	resent.UserID = "user-1"
+	if _, exists := store.actions[resent.toAction().ID]; !exists {
+		t.Error("Expected the stored action ID to be derived from the idempotency key")
+	}
+}
+
+func TestPipeline_ShedsWhenBufferIsFull(t *testing.T) {
+	config := testConfig()
+	buffer := NewMemoryBuffer(2)
+	pipeline := NewPipeline(config, newFakeActionStore(), buffer, NewMemoryDeduplicator(), nil, nil)
+	ctx := context.Background()
+
+	events := []Event{viewEvent("a", "job-1"), viewEvent("b", "job-2"), viewEvent("c", "job-3")}
+	result, err := pipeline.Submit(ctx, "user-1", events)
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if result.Accepted != 2 || result.Shed != 1 || result.RetryAfter != config.RetryAfter {
+		t.Fatalf("Expected one event to be shed, got %+v", result)
+	}
+
+	// Once the buffer has room the whole batch can be resent without duplicating accepted events
+	if _, err := buffer.Pop(ctx, 10, 0); err != nil {
+		t.Fatalf("Pop failed: %v", err)
+	}
+	resent, err := pipeline.Submit(ctx, "user-1", events)
+	if err != nil || resent.Accepted != 1 || resent.Duplicates != 2 {
+		t.Fatalf("Expected only the shed event to be accepted on resend, got %+v (%v)", resent, err)
+	}
+
+	tooMany := make([]Event, config.MaxEventsPerRequest+1)
+	if _, err := pipeline.Submit(ctx, "user-1", tooMany); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("Expected ErrBatchTooLarge, got %v", err)
+	}
+}
+
+func TestPipeline_DroppedBatchReleasesKeys(t *testing.T) {
+	store := newFakeActionStore()
+	store.err = errors.New("database unavailable")
+	config := testConfig()
+	config.MaxRetries = 2
+	pipeline := NewPipeline(config, store, NewMemoryBuffer(10), NewMemoryDeduplicator(), &fakeUpdater{}, nil)
+	ctx := context.Background()
+
+	pipeline.Start(ctx)
+	if _, err := pipeline.Submit(ctx, "user-1", []Event{viewEvent("a", "job-1")}); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	pipeline.Stop()
+
+	if store.calls != config.MaxRetries+1 {
+		t.Errorf("Expected %d store attempts, got %d", config.MaxRetries+1, store.calls)
+	}
+	result, err := pipeline.Submit(ctx, "user-1", []Event{viewEvent("a", "job-1")})
+	if err != nil || result.Accepted != 1 {
+		t.Errorf("Expected a dropped event to be accepted again, got %+v (%v)", result, err)
+	}
+}
+
+type fakeDerivedStore struct {
+	fakeSessionStore
+	interests map[string]float64
+	metrics   *repository.EngagementMetrics
+}
+
+func (s *fakeDerivedStore) GetSkillInterests(ctx context.Context, userID string) (map[string]float64, error) {
+	return s.interests, nil
+}
+
+func (s *fakeDerivedStore) UpdateSkillInterest(ctx context.Context, userID, skillName string, interest float64) error {
+	s.interests[skillName] = interest
+	return nil
+}
+
+func (s *fakeDerivedStore) GetEngagementMetrics(ctx context.Context, userID string) (*repository.EngagementMetrics, error) {
+	if s.metrics == nil {
+		return nil, apperrors.NewNotFoundError("Engagement metrics")
+	}
+	return s.metrics, nil
+}
+
+func (s *fakeDerivedStore) StoreEngagementMetrics(ctx context.Context, metrics repository.EngagementMetrics) error {
+	s.metrics = &metrics
+	return nil
+}
+
+func TestRepositoryUpdater_Apply(t *testing.T) {
+	store := &fakeDerivedStore{interests: map[string]float64{"Go": 0.5, "PHP": 0.5}}
+	now := time.Now().UTC()
+
+	err := NewRepositoryUpdater(store, DefaultSessionTimeout, nil).Apply(context.Background(), []repository.UserAction{
+		{UserID: "user-1", ActionType: EventView, Timestamp: now, Data: map[string]interface{}{
+			"time_spent": 60.0, "skills": []interface{}{"Go", "Docker"},
+		}},
+		{UserID: "user-1", ActionType: EventApply, Timestamp: now.Add(time.Minute), Data: map[string]interface{}{
+			"skills": []interface{}{"Go"},
+		}},
+		{UserID: "user-1", ActionType: EventSkillInterest, EntityID: "PHP", Timestamp: now, Data: map[string]interface{}{
+			"interaction_type": "hide",
+		}},
+		{UserID: "user-1", ActionType: EventSearch, Timestamp: now, Data: map[string]interface{}{"query": "go"}},
+		{UserID: "user-1", ActionType: EventView, Timestamp: now, Data: map[string]interface{}{"time_spent": 20.0}},
+	})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if store.interests["Go"] <= 0.5 || store.interests["Docker"] <= 0 || store.interests["PHP"] >= 0.5 {
+		t.Errorf("Unexpected skill interests: %v", store.interests)
+	}
+	if store.interests["Go"] > 1 {
+		t.Errorf("Expected interests to stay within [0, 1], got %f", store.interests["Go"])
+	}
+
+	metrics := store.metrics
+	if metrics == nil {
+		t.Fatal("Expected engagement metrics to be created")
+	}
+	if metrics.TotalViews != 2 || metrics.TotalApplications != 1 || metrics.TotalSearches != 1 {
+		t.Errorf("Unexpected engagement totals: %+v", metrics)
+	}
+	if metrics.AverageTimePerJob != 40*time.Second || metrics.ApplicationRate != 0.5 || metrics.SearchToViewRatio != 2 {
+		t.Errorf("Unexpected engagement ratios: %+v", metrics)
+	}
+	if !metrics.LastActiveSession.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected last activity at the latest action, got %s", metrics.LastActiveSession)
+	}
+	if metrics.TotalSessions != 1 || metrics.SessionFrequency != 0.25 {
+		t.Errorf("Expected one session in the last four weeks, got %d sessions at %f per week", metrics.TotalSessions, metrics.SessionFrequency)
+	}
+}
+
+type fakeFeedbackRecorder struct {
+	rewards  []string
+	feedback []string
+}
+
+func (r *fakeFeedbackRecorder) RecordExplorationReward(ctx context.Context, userID, jobID, event string) error {
+	r.rewards = append(r.rewards, jobID+":"+event)
+	return nil
+}
+
+func (r *fakeFeedbackRecorder) ProcessUserFeedback(ctx context.Context, userID, jobID, matchID, action string, outcome float64) error {
+	r.feedback = append(r.feedback, jobID+":"+action)
+	return nil
+}
+
+func TestRepositoryUpdater_FeedsJobOutcomesOnce(t *testing.T) {
+	store := &fakeDerivedStore{interests: map[string]float64{}}
+	recorder := &fakeFeedbackRecorder{}
+	now := time.Now().UTC()
+
+	err := NewRepositoryUpdater(store, DefaultSessionTimeout, recorder).Apply(context.Background(), []repository.UserAction{
+		{UserID: "user-1", ActionType: EventView, EntityID: "job-1", Timestamp: now},
+		{UserID: "user-1", ActionType: EventSave, EntityID: "job-1", Timestamp: now.Add(time.Second)},
+		{UserID: "user-1", ActionType: EventApply, EntityID: "job-1", Timestamp: now.Add(2 * time.Second)},
+		{UserID: "user-1", ActionType: EventDismiss, EntityID: "job-2", Timestamp: now.Add(3 * time.Second)},
+		{UserID: "user-1", ActionType: EventSearch, Timestamp: now, Data: map[string]interface{}{"query": "go"}},
+		{UserID: "user-1", ActionType: EventView, Timestamp: now},
+	})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if want := []string{"job-1:view", "job-1:save", "job-1:apply"}; fmt.Sprint(recorder.rewards) != fmt.Sprint(want) {
+		t.Errorf("Expected bandit rewards %v, got %v", want, recorder.rewards)
+	}
+	if want := []string{"job-1:applied", "job-2:dismissed"}; fmt.Sprint(recorder.feedback) != fmt.Sprint(want) {
+		t.Errorf("Expected RL feedback %v, got %v", want, recorder.feedback)
+	}
+}
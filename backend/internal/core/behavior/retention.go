@@ -0,0 +1,87 @@
+package behavior
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RetentionStore removes expired behavior data; repository.BehaviorRepository implements it
+type RetentionStore interface {
+	CleanupOldBehaviorData(ctx context.Context, olderThan time.Time) error
+}
+
+// RetentionJob periodically deletes behavior data older than the retention
+// period. Deleting is idempotent, so every instance may run it.
+type RetentionJob struct {
+	store     RetentionStore
+	retention time.Duration
+	interval  time.Duration
+
+	mu        sync.Mutex
+	running   bool
+	stop      context.CancelFunc
+	loopGroup sync.WaitGroup
+}
+
+// NewRetentionJob creates a job that runs every interval and keeps retention's worth of data
+func NewRetentionJob(store RetentionStore, retention, interval time.Duration) *RetentionJob {
+	return &RetentionJob{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Start cleans up immediately and then on every interval
+func (j *RetentionJob) Start(ctx context.Context) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	j.stop = cancel
+	j.running = true
+
+	j.loopGroup.Add(1)
+	go func() {
+		defer j.loopGroup.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := j.Run(ctx); err != nil && ctx.Err() == nil {
+				fmt.Printf("Failed to clean up old behavior data: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts periodic cleanups
+func (j *RetentionJob) Stop() {
+	j.mu.Lock()
+	if !j.running {
+		j.mu.Unlock()
+		return
+	}
+	j.running = false
+	j.stop()
+	j.mu.Unlock()
+
+	j.loopGroup.Wait()
+}
+
+// Run deletes the data that fell out of the retention period
+func (j *RetentionJob) Run(ctx context.Context) error {
+	return j.store.CleanupOldBehaviorData(ctx, time.Now().UTC().Add(-j.retention))
+}
@@ -0,0 +1,250 @@
+package behavior
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"microbridge/backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSessionTimeout is the inactivity gap that ends a session
+const DefaultSessionTimeout = 30 * time.Minute
+
+// journeyWindow is how far back sessions shape the journey stage
+const journeyWindow = 30 * 24 * time.Hour
+
+// sessionNamespace derives stable session IDs from the session's first action
+var sessionNamespace = uuid.MustParse("0d8e3b7c-52a4-4f1e-9c6b-7a2e5d9f1b30")
+
+// SessionStore reads and writes sessions; repository.BehaviorRepository implements it
+type SessionStore interface {
+	GetUserSessions(ctx context.Context, userID string, from, to time.Time) ([]repository.UserSession, error)
+	StoreUserSessions(ctx context.Context, sessions []repository.UserSession, deletedIDs []string) error
+}
+
+// SessionUpdate is the result of sessionizing a batch of one user's actions
+type SessionUpdate struct {
+	Sessions []repository.UserSession // Created or extended sessions
+	Deleted  []string                 // Sessions merged into another because a late action bridged them
+	Started  int                      // Sessions that did not exist before the batch
+}
+
+// Sessionizer groups a user's actions into sessions split on inactivity. Actions
+// may arrive late and out of order, so each batch is merged with the stored
+// sessions it could touch rather than only appended to the latest one.
+type Sessionizer struct {
+	store   SessionStore
+	timeout time.Duration
+}
+
+// NewSessionizer creates a sessionizer; a zero timeout uses DefaultSessionTimeout
+func NewSessionizer(store SessionStore, timeout time.Duration) *Sessionizer {
+	if timeout <= 0 {
+		timeout = DefaultSessionTimeout
+	}
+	return &Sessionizer{store: store, timeout: timeout}
+}
+
+// Apply merges the user's actions into their sessions and stores the result
+func (s *Sessionizer) Apply(ctx context.Context, userID string, actions []repository.UserAction) (*SessionUpdate, error) {
+	if len(actions) == 0 {
+		return &SessionUpdate{}, nil
+	}
+
+	sorted := make([]repository.UserAction, len(actions))
+	copy(sorted, actions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	from := sorted[0].Timestamp.Add(-s.timeout)
+	to := sorted[len(sorted)-1].Timestamp.Add(s.timeout)
+	existing, err := s.store.GetUserSessions(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	update := sessionize(userID, existing, sorted, s.timeout)
+	if len(update.Sessions) == 0 && len(update.Deleted) == 0 {
+		return update, nil
+	}
+	if err := s.store.StoreUserSessions(ctx, update.Sessions, update.Deleted); err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+// sessionize sweeps stored sessions and new actions in time order, starting a
+// new group whenever the gap since the group's last activity exceeds the timeout.
+// Each group keeps the ID of its earliest stored session.
+func sessionize(userID string, existing []repository.UserSession, actions []repository.UserAction, timeout time.Duration) *SessionUpdate {
+	type item struct {
+		start, end time.Time
+		session    *repository.UserSession
+		action     *repository.UserAction
+	}
+	items := make([]item, 0, len(existing)+len(actions))
+	for i := range existing {
+		items = append(items, item{start: existing[i].StartedAt, end: existing[i].EndedAt, session: &existing[i]})
+	}
+	for i := range actions {
+		items = append(items, item{start: actions[i].Timestamp, end: actions[i].Timestamp, action: &actions[i]})
+	}
+	// Stored sessions sort before actions at the same instant so they anchor their group
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].start.Equal(items[j].start) {
+			return items[i].start.Before(items[j].start)
+		}
+		return items[i].session != nil && items[j].session == nil
+	})
+
+	update := &SessionUpdate{}
+	var group []item
+	var groupEnd time.Time
+	flush := func() {
+		if len(group) == 0 {
+			return
+		}
+		var base *repository.UserSession
+		var changed bool
+		for _, member := range group {
+			switch {
+			case member.session != nil && base == nil:
+				merged := *member.session
+				base = &merged
+			case member.session != nil:
+				mergeSession(base, *member.session)
+				update.Deleted = append(update.Deleted, member.session.ID)
+				changed = true
+			default:
+				if base == nil {
+					base = &repository.UserSession{
+						ID:        uuid.NewSHA1(sessionNamespace, []byte(userID+":"+member.action.ID)).String(),
+						UserID:    userID,
+						StartedAt: member.action.Timestamp,
+						EndedAt:   member.action.Timestamp,
+					}
+					update.Started++
+				}
+				addAction(base, *member.action)
+				changed = true
+			}
+		}
+		if changed {
+			base.EngagementScore = SessionEngagementScore(*base)
+			update.Sessions = append(update.Sessions, *base)
+		}
+		group = nil
+	}
+
+	for _, it := range items {
+		if len(group) > 0 && it.start.Sub(groupEnd) > timeout {
+			flush()
+		}
+		if len(group) == 0 || it.end.After(groupEnd) {
+			groupEnd = it.end
+		}
+		group = append(group, it)
+	}
+	flush()
+	return update
+}
+
+func addAction(session *repository.UserSession, action repository.UserAction) {
+	if action.Timestamp.Before(session.StartedAt) {
+		session.StartedAt = action.Timestamp
+	}
+	if action.Timestamp.After(session.EndedAt) {
+		session.EndedAt = action.Timestamp
+	}
+	session.ActionCount++
+	switch action.ActionType {
+	case EventView:
+		session.Views++
+	case EventApply:
+		session.Applications++
+	case EventSave:
+		session.Saves++
+	case EventSearch:
+		session.Searches++
+	}
+	session.Quality += repository.ActionQuality(action.ActionType)
+	if session.DeviceInfo == (repository.DeviceInfo{}) {
+		session.DeviceInfo = action.DeviceInfo
+	}
+}
+
+func mergeSession(into *repository.UserSession, other repository.UserSession) {
+	if other.StartedAt.Before(into.StartedAt) {
+		into.StartedAt = other.StartedAt
+	}
+	if other.EndedAt.After(into.EndedAt) {
+		into.EndedAt = other.EndedAt
+	}
+	into.ActionCount += other.ActionCount
+	into.Views += other.Views
+	into.Applications += other.Applications
+	into.Saves += other.Saves
+	into.Searches += other.Searches
+	into.Quality += other.Quality
+	if into.DeviceInfo == (repository.DeviceInfo{}) {
+		into.DeviceInfo = other.DeviceInfo
+	}
+}
+
+// SessionEngagementScore rates a session from 0 to 1 by depth, time spent and
+// the intent its actions show. Ten actions, or about twenty minutes, approach
+// the maximum for depth and time.
+func SessionEngagementScore(session repository.UserSession) float64 {
+	if session.ActionCount == 0 {
+		return 0
+	}
+	depth := math.Min(1, float64(session.ActionCount)/10)
+	duration := 1 - math.Exp(-session.Duration().Minutes()/10)
+	quality := math.Min(1, session.Quality/float64(session.ActionCount))
+	return 0.4*depth + 0.3*duration + 0.3*quality
+}
+
+// JourneyStage places a user from their recent sessions: "decisive" when a
+// quarter of their sessions end in applications, "focused" when they keep
+// saving jobs or read several per session, and "explorer" otherwise.
+func JourneyStage(sessions []repository.UserSession, now time.Time) string {
+	var recent, applying, saving, views int
+	for _, session := range sessions {
+		if now.Sub(session.EndedAt) > journeyWindow {
+			continue
+		}
+		recent++
+		views += session.Views
+		if session.Applications > 0 {
+			applying++
+		}
+		if session.Saves > 0 {
+			saving++
+		}
+	}
+
+	switch {
+	case recent == 0:
+		return "explorer"
+	case float64(applying)/float64(recent) >= 0.25:
+		return "decisive"
+	case float64(saving)/float64(recent) >= 0.25, recent >= 2 && float64(views)/float64(recent) >= 5:
+		return "focused"
+	default:
+		return "explorer"
+	}
+}
+
+// SessionsPerWeek is the average weekly number of sessions started in the window before now
+func SessionsPerWeek(sessions []repository.UserSession, now time.Time, window time.Duration) float64 {
+	var started int
+	for _, session := range sessions {
+		if !session.StartedAt.Before(now.Add(-window)) && !session.StartedAt.After(now) {
+			started++
+		}
+	}
+	return float64(started) / (window.Hours() / (24 * 7))
+}
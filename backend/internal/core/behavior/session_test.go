@@ -0,0 +1,166 @@
+package behavior
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/repository"
+)
+
+type fakeSessionStore struct {
+	sessions map[string]repository.UserSession
+}
+
+func (s *fakeSessionStore) GetUserSessions(ctx context.Context, userID string, from, to time.Time) ([]repository.UserSession, error) {
+	var sessions []repository.UserSession
+	for _, session := range s.sessions {
+		if session.UserID == userID && !session.StartedAt.After(to) && !session.EndedAt.Before(from) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartedAt.Before(sessions[j].StartedAt) })
+	return sessions, nil
+}
+
+func (s *fakeSessionStore) StoreUserSessions(ctx context.Context, sessions []repository.UserSession, deletedIDs []string) error {
+	if s.sessions == nil {
+		s.sessions = make(map[string]repository.UserSession)
+	}
+	for _, id := range deletedIDs {
+		delete(s.sessions, id)
+	}
+	for _, session := range sessions {
+		s.sessions[session.ID] = session
+	}
+	return nil
+}
+
+func TestSessionizer_Apply(t *testing.T) {
+	store := &fakeSessionStore{}
+	sessionizer := NewSessionizer(store, 30*time.Minute)
+	ctx := context.Background()
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	phone := repository.DeviceInfo{Platform: "mobile", DeviceType: "phone"}
+
+	action := func(id, actionType string, offset time.Duration) repository.UserAction {
+		return repository.UserAction{ID: id, UserID: "user-1", ActionType: actionType, Timestamp: start.Add(offset), DeviceInfo: phone}
+	}
+
+	// Two bursts an hour apart are separate sessions
+	update, err := sessionizer.Apply(ctx, "user-1", []repository.UserAction{
+		action("a3", EventApply, 70*time.Minute),
+		action("a1", EventSearch, 0),
+		action("a2", EventView, 10*time.Minute),
+		action("a4", EventView, 75*time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if update.Started != 2 || len(store.sessions) != 2 {
+		t.Fatalf("Expected two sessions, got %+v", update)
+	}
+	first := update.Sessions[0]
+	if first.ActionCount != 2 || first.Searches != 1 || first.Views != 1 || first.Duration() != 10*time.Minute {
+		t.Errorf("Unexpected first session: %+v", first)
+	}
+	if first.DeviceInfo != phone || first.EngagementScore <= 0 || first.EngagementScore > 1 {
+		t.Errorf("Expected the device and a score in (0, 1], got %+v", first)
+	}
+
+	// A late action in the gap bridges both sessions into the earlier one
+	update, err = sessionizer.Apply(ctx, "user-1", []repository.UserAction{action("a5", EventSave, 40*time.Minute)})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if update.Started != 0 || len(update.Deleted) != 1 || len(store.sessions) != 1 {
+		t.Fatalf("Expected the sessions to merge, got %+v", update)
+	}
+	merged := store.sessions[first.ID]
+	if merged.ActionCount != 5 || merged.Applications != 1 || merged.Saves != 1 || merged.Duration() != 75*time.Minute {
+		t.Errorf("Unexpected merged session: %+v", merged)
+	}
+
+	// Activity past the timeout starts a new session
+	update, err = sessionizer.Apply(ctx, "user-1", []repository.UserAction{action("a6", EventView, 3*time.Hour)})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if update.Started != 1 || len(store.sessions) != 2 {
+		t.Errorf("Expected a new session, got %+v", update)
+	}
+}
+
+func TestJourneyStage(t *testing.T) {
+	now := time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)
+	session := func(views, saves, applications int) repository.UserSession {
+		return repository.UserSession{StartedAt: now.Add(-time.Hour), EndedAt: now.Add(-time.Hour), Views: views, Saves: saves, Applications: applications}
+	}
+	stale := session(0, 0, 3)
+	stale.StartedAt, stale.EndedAt = now.AddDate(0, -3, 0), now.AddDate(0, -3, 0)
+
+	tests := []struct {
+		name     string
+		sessions []repository.UserSession
+		want     string
+	}{
+		{"no sessions", nil, "explorer"},
+		{"old applications only", []repository.UserSession{stale, session(2, 0, 0)}, "explorer"},
+		{"applies regularly", []repository.UserSession{session(3, 0, 1), session(2, 0, 0), session(4, 0, 0)}, "decisive"},
+		{"saves jobs", []repository.UserSession{session(2, 1, 0), session(2, 0, 0)}, "focused"},
+		{"reads closely", []repository.UserSession{session(6, 0, 0), session(5, 0, 0)}, "focused"},
+		{"browses", []repository.UserSession{session(1, 0, 0), session(2, 0, 0)}, "explorer"},
+	}
+	for _, tt := range tests {
+		if got := JourneyStage(tt.sessions, now); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestDeviceFromHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   repository.DeviceInfo
+	}{
+		{
+			name: "desktop chrome",
+			header: map[string]string{
+				"User-Agent":         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36",
+				"Sec-CH-UA-Platform": `"Windows"`,
+				"X-Screen-Size":      "1920x1080",
+			},
+			want: repository.DeviceInfo{Platform: "web", DeviceType: "desktop", Browser: "Chrome", OS: "Windows", ScreenSize: "1920x1080"},
+		},
+		{
+			name: "iphone safari",
+			header: map[string]string{
+				"User-Agent": "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			},
+			want: repository.DeviceInfo{Platform: "web", DeviceType: "phone", Browser: "Safari", OS: "iOS"},
+		},
+		{
+			name: "android app",
+			header: map[string]string{
+				"User-Agent":        "okhttp/4.12.0",
+				"X-Client-Platform": "Mobile",
+				"X-Screen-Size":     "<script>",
+			},
+			want: repository.DeviceInfo{Platform: "mobile"},
+		},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		for key, value := range tt.header {
+			header.Set(key, value)
+		}
+		got := DeviceFromHeaders(header)
+		tt.want.UserAgent = tt.header["User-Agent"]
+		if got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+}
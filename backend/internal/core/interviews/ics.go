@@ -0,0 +1,128 @@
+package interviews
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	// icsLineLimit is the RFC 5545 content line limit in octets, excluding the CRLF
+	icsLineLimit = 75
+)
+
+// Attendee is a participant of an interview invite
+type Attendee struct {
+	Name  string
+	Email string
+}
+
+// Invite describes one interview as a calendar event
+type Invite struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+	Sequence    int
+	Cancelled   bool
+	Organizer   Attendee
+	Attendees   []Attendee
+}
+
+// WriteICS renders invite as an RFC 5545 calendar. Calendar clients replace an
+// earlier copy of the event when UID matches and Sequence is higher.
+func WriteICS(w io.Writer, invite Invite) error {
+	buf := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(buf, name+":"+value)
+	}
+
+	method, status := "REQUEST", "CONFIRMED"
+	if invite.Cancelled {
+		method, status = "CANCEL", "CANCELLED"
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//MicroBridge//Interviews//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", method)
+	line("BEGIN", "VEVENT")
+	line("UID", escapeText(invite.UID))
+	line("DTSTAMP", invite.Stamp.UTC().Format(icsTimeFormat))
+	line("DTSTART", invite.Start.UTC().Format(icsTimeFormat))
+	line("DTEND", invite.End.UTC().Format(icsTimeFormat))
+	line("SEQUENCE", fmt.Sprintf("%d", invite.Sequence))
+	line("STATUS", status)
+	line("SUMMARY", escapeText(invite.Summary))
+	if invite.Description != "" {
+		line("DESCRIPTION", escapeText(invite.Description))
+	}
+	if invite.Location != "" {
+		line("LOCATION", escapeText(invite.Location))
+	}
+	if invite.URL != "" {
+		line("URL", invite.URL)
+	}
+	if invite.Organizer.Email != "" {
+		writeFolded(buf, "ORGANIZER"+participantParams(invite.Organizer)+":mailto:"+invite.Organizer.Email)
+	}
+	for _, attendee := range invite.Attendees {
+		if attendee.Email == "" {
+			continue
+		}
+		writeFolded(buf, "ATTENDEE;ROLE=REQ-PARTICIPANT"+participantParams(attendee)+":mailto:"+attendee.Email)
+	}
+	line("END", "VEVENT")
+	line("END", "VCALENDAR")
+
+	return buf.Flush()
+}
+
+func participantParams(attendee Attendee) string {
+	if attendee.Name == "" {
+		return ""
+	}
+	// Parameter values can't be escaped, only quoted
+	name := strings.NewReplacer(`"`, "'", "\r", " ", "\n", " ").Replace(attendee.Name)
+	return `;CN="` + name + `"`
+}
+
+// escapeText escapes a TEXT property value (RFC 5545 section 3.3.11)
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without splitting
+// a UTF-8 sequence. Continuation lines start with a single space.
+func writeFolded(w *bufio.Writer, content string) {
+	limit := icsLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// The leading space counts toward the next line's limit
+		limit = icsLineLimit - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,72 @@
+package interviews
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWriteICS(t *testing.T) {
+	start := time.Date(2024, 5, 6, 14, 0, 0, 0, time.FixedZone("EDT", -4*3600))
+	invite := Invite{
+		UID:         "interview-1@microbridge",
+		Summary:     "Interview: Frontend developer, React; TypeScript",
+		Description: "Bring your portfolio.\nWe'll talk about the project — ünïcödé included and long enough to need folding across several lines.",
+		Location:    "Online",
+		URL:         "https://meet.example.com/abc",
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Stamp:       start.Add(-48 * time.Hour),
+		Sequence:    2,
+		Organizer:   Attendee{Name: "Acme", Email: "hr@acme.example"},
+		Attendees:   []Attendee{{Name: "Jane", Email: "jane@example.com"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, invite); err != nil {
+		t.Fatalf("WriteICS failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"METHOD:REQUEST\r\n",
+		"DTSTART:20240506T180000Z\r\n",
+		"DTEND:20240506T190000Z\r\n",
+		"SEQUENCE:2\r\n",
+		"STATUS:CONFIRMED\r\n",
+		`SUMMARY:Interview: Frontend developer\, React\; TypeScript` + "\r\n",
+		`ORGANIZER;CN="Acme":mailto:hr@acme.example` + "\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the calendar:\n%s", want, out)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > icsLineLimit {
+			t.Errorf("Line longer than %d octets: %q", icsLineLimit, line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("Folding split a UTF-8 sequence: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, `DESCRIPTION:Bring your portfolio.\nWe'll talk about the project — ünïcödé`) {
+		t.Errorf("Expected the description to survive folding:\n%s", unfolded)
+	}
+}
+
+func TestWriteICS_Cancelled(t *testing.T) {
+	start := time.Date(2024, 5, 6, 14, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, Invite{UID: "interview-1", Summary: "Interview", Start: start, End: start.Add(time.Hour), Stamp: start, Sequence: 3, Cancelled: true}); err != nil {
+		t.Fatalf("WriteICS failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "METHOD:CANCEL\r\n") || !strings.Contains(out, "STATUS:CANCELLED\r\n") {
+		t.Errorf("Expected a cancellation, got:\n%s", out)
+	}
+}
@@ -0,0 +1,111 @@
+package interviews
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+// ReminderStore hands out interviews that are due a reminder; repository.InterviewRepository implements it
+type ReminderStore interface {
+	// ClaimDueReminders marks scheduled interviews starting before dueBefore as
+	// reminded and returns them. A claim is atomic, so each interview is returned once
+	// no matter how many instances run the job.
+	ClaimDueReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Interview, error)
+}
+
+// ReminderNotifier tells both sides about an upcoming interview
+type ReminderNotifier interface {
+	NotifyInterviewReminder(ctx context.Context, interview *models.Interview) error
+}
+
+// ReminderJob periodically reminds students and employers of interviews that
+// start within the lead time
+type ReminderJob struct {
+	store    ReminderStore
+	notifier ReminderNotifier
+	lead     time.Duration
+	interval time.Duration
+
+	mu        sync.Mutex
+	running   bool
+	stop      context.CancelFunc
+	loopGroup sync.WaitGroup
+}
+
+// NewReminderJob creates a job that runs every interval and reminds lead ahead of each interview
+func NewReminderJob(store ReminderStore, notifier ReminderNotifier, lead, interval time.Duration) *ReminderJob {
+	return &ReminderJob{
+		store:    store,
+		notifier: notifier,
+		lead:     lead,
+		interval: interval,
+	}
+}
+
+// Start sends due reminders immediately and then on every interval
+func (j *ReminderJob) Start(ctx context.Context) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	j.stop = cancel
+	j.running = true
+
+	j.loopGroup.Add(1)
+	go func() {
+		defer j.loopGroup.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := j.Run(ctx); err != nil && ctx.Err() == nil {
+				fmt.Printf("Failed to send interview reminders: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts periodic reminders
+func (j *ReminderJob) Stop() {
+	j.mu.Lock()
+	if !j.running {
+		j.mu.Unlock()
+		return
+	}
+	j.running = false
+	j.stop()
+	j.mu.Unlock()
+
+	j.loopGroup.Wait()
+}
+
+// Run claims the interviews that are due and notifies both sides of each one.
+// A failed notification is not retried; the reminder is best effort.
+func (j *ReminderJob) Run(ctx context.Context) error {
+	now := time.Now().UTC()
+	due, err := j.store.ClaimDueReminders(ctx, now.Add(j.lead), now)
+	if err != nil {
+		return err
+	}
+
+	for i := range due {
+		if err := j.notifier.NotifyInterviewReminder(ctx, &due[i]); err != nil {
+			fmt.Printf("Failed to send reminder for interview %s: %v\n", due[i].ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package interviews
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+const (
+	// MaxProposedSlots bounds how many slots an employer can offer at once
+	MaxProposedSlots = 10
+	// MaxSlotLength bounds a single interview slot
+	MaxSlotLength = 4 * time.Hour
+)
+
+// Reasons a proposed slot is dropped
+const (
+	ReasonOutsideAvailability = "outside_availability"
+	ReasonConflict            = "conflict"
+)
+
+// RejectedSlot is a proposed slot that was dropped and why
+type RejectedSlot struct {
+	Slot   models.InterviewSlot `json:"slot"`
+	Reason string               `json:"reason"`
+}
+
+// ValidateSlots checks that slots are well formed, in the future and not too many
+func ValidateSlots(slots []models.InterviewSlot, now time.Time) error {
+	if len(slots) == 0 {
+		return apperrors.NewValidationError("at least one interview slot is required")
+	}
+	if len(slots) > MaxProposedSlots {
+		return apperrors.NewValidationError(fmt.Sprintf("at most %d interview slots can be proposed", MaxProposedSlots))
+	}
+	for i, slot := range slots {
+		if !slot.End.After(slot.Start) {
+			return apperrors.NewValidationError(fmt.Sprintf("slot %d must end after it starts", i+1))
+		}
+		if slot.End.Sub(slot.Start) > MaxSlotLength {
+			return apperrors.NewValidationError(fmt.Sprintf("slot %d is longer than %s", i+1, MaxSlotLength))
+		}
+		if !slot.Start.After(now) {
+			return apperrors.NewValidationError(fmt.Sprintf("slot %d is in the past", i+1))
+		}
+	}
+	return nil
+}
+
+// FilterSlots keeps the proposed slots that fit the student's availability and
+// overlap none of the busy slots, such as either side's scheduled interviews
+func FilterSlots(proposed []models.InterviewSlot, availability models.Availability, busy []models.InterviewSlot) ([]models.InterviewSlot, []RejectedSlot) {
+	fit := make([]models.InterviewSlot, 0, len(proposed))
+	var rejected []RejectedSlot
+
+	for _, slot := range proposed {
+		switch {
+		case !FitsAvailability(slot, availability):
+			rejected = append(rejected, RejectedSlot{Slot: slot, Reason: ReasonOutsideAvailability})
+		case Conflicts(slot, busy):
+			rejected = append(rejected, RejectedSlot{Slot: slot, Reason: ReasonConflict})
+		default:
+			fit = append(fit, slot)
+		}
+	}
+	return fit, rejected
+}
+
+// Conflicts reports whether slot overlaps any of the busy slots
+func Conflicts(slot models.InterviewSlot, busy []models.InterviewSlot) bool {
+	for _, other := range busy {
+		if slot.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// FitsAvailability reports whether slot lies within the student's availability window
+// and inside one of their preferred hours, read in their timezone. Flexible students
+// and students without preferred hours fit any slot in their window.
+func FitsAvailability(slot models.InterviewSlot, availability models.Availability) bool {
+	if availability.StartDate != nil && slot.Start.Before(*availability.StartDate) {
+		return false
+	}
+	if availability.EndDate != nil && slot.Start.After(availability.EndDate.Add(24*time.Hour)) {
+		return false
+	}
+	if availability.IsFlexible || len(availability.PreferredHours) == 0 {
+		return true
+	}
+
+	location, err := time.LoadLocation(availability.Timezone)
+	if err != nil || availability.Timezone == "" {
+		location = time.UTC
+	}
+	start, end := slot.Start.In(location), slot.End.In(location)
+	if start.YearDay() != end.YearDay() || start.Year() != end.Year() {
+		return false
+	}
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	for _, preferred := range availability.PreferredHours {
+		if preferred.DayOfWeek != int(start.Weekday()) {
+			continue
+		}
+		from, ok := parseClock(preferred.StartTime)
+		if !ok {
+			continue
+		}
+		to, ok := parseClock(preferred.EndTime)
+		if !ok {
+			continue
+		}
+		if startMinute >= from && endMinute <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock reads "HH:MM" as minutes after midnight; "24:00" is the end of the day
+func parseClock(clock string) (int, bool) {
+	parts := strings.Split(strings.TrimSpace(clock), ":")
+	if len(parts) != 2 {
+		return 0, false
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, false
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || hours < 0 || minutes < 0 || minutes > 59 || hours*60+minutes > 24*60 {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
@@ -0,0 +1,105 @@
+package interviews
+
+import (
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+func slot(start time.Time, length time.Duration) models.InterviewSlot {
+	return models.InterviewSlot{Start: start, End: start.Add(length)}
+}
+
+func TestValidateSlots(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		slots   []models.InterviewSlot
+		wantErr bool
+	}{
+		{"valid", []models.InterviewSlot{slot(tomorrow, time.Hour)}, false},
+		{"empty", nil, true},
+		{"ends before it starts", []models.InterviewSlot{slot(tomorrow, -time.Hour)}, true},
+		{"too long", []models.InterviewSlot{slot(tomorrow, 5*time.Hour)}, true},
+		{"in the past", []models.InterviewSlot{slot(now.Add(-time.Hour), 30*time.Minute)}, true},
+		{"too many", make([]models.InterviewSlot, MaxProposedSlots+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSlots(tt.slots, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFitsAvailability(t *testing.T) {
+	// Mondays 09:00-12:00 in New York, which is UTC-4 in May
+	availability := models.Availability{
+		Timezone: "America/New_York",
+		PreferredHours: []models.TimeSlot{
+			{DayOfWeek: int(time.Monday), StartTime: "09:00", EndTime: "12:00"},
+		},
+	}
+	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		slot models.InterviewSlot
+		want bool
+	}{
+		{"inside the window in local time", slot(monday.Add(14*time.Hour), time.Hour), true},
+		{"inside the window only in UTC", slot(monday.Add(9*time.Hour), time.Hour), false},
+		{"runs past the window", slot(monday.Add(15*time.Hour+30*time.Minute), time.Hour), false},
+		{"wrong weekday", slot(monday.Add(38*time.Hour), time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FitsAvailability(tt.slot, availability); got != tt.want {
+				t.Errorf("FitsAvailability() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	flexible := availability
+	flexible.IsFlexible = true
+	if !FitsAvailability(slot(monday.Add(38*time.Hour), time.Hour), flexible) {
+		t.Error("Expected a flexible student to fit any slot")
+	}
+
+	ended := monday.Add(-48 * time.Hour)
+	flexible.EndDate = &ended
+	if FitsAvailability(slot(monday.Add(14*time.Hour), time.Hour), flexible) {
+		t.Error("Expected slots after the availability end date to be rejected")
+	}
+}
+
+func TestFilterSlots(t *testing.T) {
+	monday := time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)
+	availability := models.Availability{
+		PreferredHours: []models.TimeSlot{
+			{DayOfWeek: int(time.Monday), StartTime: "09:00", EndTime: "17:00"},
+		},
+	}
+	busy := []models.InterviewSlot{slot(monday.Add(2*time.Hour), time.Hour)}
+	proposed := []models.InterviewSlot{
+		slot(monday, time.Hour),                                 // Free
+		slot(monday.Add(2*time.Hour+30*time.Minute), time.Hour), // Overlaps the busy slot
+		slot(monday.Add(10*time.Hour), time.Hour),               // After 17:00
+		slot(monday.Add(3*time.Hour), time.Hour),                // Back to back with the busy slot
+	}
+
+	fit, rejected := FilterSlots(proposed, availability, busy)
+	if len(fit) != 2 || !fit[0].Start.Equal(proposed[0].Start) || !fit[1].Start.Equal(proposed[3].Start) {
+		t.Fatalf("Expected the first and last slots to fit, got %+v", fit)
+	}
+	if len(rejected) != 2 || rejected[0].Reason != ReasonConflict || rejected[1].Reason != ReasonOutsideAvailability {
+		t.Fatalf("Unexpected rejected slots: %+v", rejected)
+	}
+}
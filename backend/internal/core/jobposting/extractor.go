@@ -0,0 +1,452 @@
+package jobposting
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"microbridge/backend/internal/core/skills"
+	"microbridge/backend/internal/models"
+)
+
+// Experience levels, as stored on models.Job
+const (
+	LevelEntry        = "entry"
+	LevelIntermediate = "intermediate"
+	LevelAdvanced     = "advanced"
+	LevelSenior       = "senior"
+	LevelExpert       = "expert"
+)
+
+// How strongly the posting asks for a skill
+const (
+	NeedRequired  = "required"
+	NeedPreferred = "preferred"
+	NeedMentioned = "mentioned"
+)
+
+// maxEvidence bounds how many source clauses are kept per suggestion
+const maxEvidence = 3
+
+// SkillSuggestion is a required skill inferred from the posting, for the employer to review
+type SkillSuggestion struct {
+	Name          string   `json:"name"`
+	Category      string   `json:"category"`
+	Level         int      `json:"level"` // 1-5 scale, as in models.RequiredSkill
+	IsRequired    bool     `json:"is_required"`
+	Importance    float64  `json:"importance"`
+	CanLearn      bool     `json:"can_learn"`
+	Need          string   `json:"need"` // "required" | "preferred" | "mentioned"
+	Confidence    float64  `json:"confidence"`
+	Evidence      []string `json:"evidence"`
+	AlreadyListed bool     `json:"already_listed"`
+}
+
+// RequiredSkill converts an accepted suggestion into a job skill requirement
+func (s *SkillSuggestion) RequiredSkill() models.RequiredSkill {
+	return models.RequiredSkill{
+		Name:       s.Name,
+		Level:      s.Level,
+		IsRequired: s.IsRequired,
+		Importance: s.Importance,
+		CanLearn:   s.CanLearn,
+	}
+}
+
+// Analysis is what the extractor found in a job posting
+type Analysis struct {
+	Skills          []*SkillSuggestion `json:"skills"`
+	ExperienceLevel string             `json:"experience_level,omitempty"` // Suggested level, empty when no cue was found
+	MinYears        float64            `json:"min_years,omitempty"`
+	SeniorityCues   []string           `json:"seniority_cues,omitempty"`
+}
+
+// Posting is the free text of a job the extractor reads
+type Posting struct {
+	Title           string
+	Description     string
+	Requirements    []string
+	ExperienceLevel string   // Level chosen by the employer, if any
+	Skills          []string // Skills the employer already listed
+}
+
+// Extractor infers required skills and seniority from job descriptions using the
+// skill dictionary. It runs entirely in-process.
+type Extractor struct {
+	dictionary *skills.Dictionary
+}
+
+// NewExtractor creates an extractor backed by the given skill dictionary
+func NewExtractor(dictionary *skills.Dictionary) *Extractor {
+	return &Extractor{dictionary: dictionary}
+}
+
+var (
+	requiredCuePattern  = regexp.MustCompile(`(?i)\b(must|required|requirements?|essential|mandatory|need to|needs to|you have|you will need|minimum|proficien\w*|solid|strong|expert\w*)\b`)
+	preferredCuePattern = regexp.MustCompile(`(?i)\b(nice to have|nice-to-have|preferred|preferably|bonus|plus|desirable|ideally|advantage\w*|optional|familiarity|exposure|good to have)\b`)
+	learnableCuePattern = regexp.MustCompile(`(?i)\b(will train|training (is )?provided|willing to learn|eager to learn|learn on the job|we will teach|no experience (is )?needed|familiarity|exposure)\b`)
+	strongCuePattern    = regexp.MustCompile(`(?i)\b(expert\w*|advanced|deep|extensive|strong|solid|proficien\w*|in-depth)\b`)
+	weakCuePattern      = regexp.MustCompile(`(?i)\b(basic|familiarity|exposure|some|introductory|understanding of)\b`)
+	yearsPattern        = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*\+?\s*(?:-\s*\d+\s*)?(?:years?|yrs?)\b`)
+	headingPattern      = regexp.MustCompile(`^\s*#*\s*([A-Za-z][A-Za-z /&'-]{2,40}?)\s*:?\s*$`)
+	clauseSplitPattern  = regexp.MustCompile(`[.;!?]\s+|\n`)
+
+	seniorityCues = []struct {
+		pattern *regexp.Regexp
+		level   string
+	}{
+		{regexp.MustCompile(`(?i)\b(intern|internship|entry[- ]level|junior|graduate|no experience|student|trainee)\b`), LevelEntry},
+		{regexp.MustCompile(`(?i)\b(mid[- ]level|intermediate)\b`), LevelIntermediate},
+		{regexp.MustCompile(`(?i)\b(advanced[- ]level)\b`), LevelAdvanced},
+		{regexp.MustCompile(`(?i)\b(senior|team lead|tech lead|lead (?:engineer|developer|designer|analyst)|principal)\b`), LevelSenior},
+		{regexp.MustCompile(`(?i)\b(architect|head of)\b`), LevelExpert},
+	}
+
+	levelRank = map[string]int{
+		LevelEntry:        1,
+		LevelIntermediate: 2,
+		LevelAdvanced:     3,
+		LevelSenior:       4,
+		LevelExpert:       5,
+	}
+)
+
+type clause struct {
+	text    string
+	need    string // Need implied by the clause wording or its section heading
+	inTitle bool
+}
+
+type skillEvidence struct {
+	name      string
+	mentions  int
+	need      string
+	years     float64
+	strong    bool
+	weak      bool
+	learnable bool
+	inTitle   bool
+	clauses   []string
+}
+
+// Extract analyses a posting and suggests required skills ordered by importance
+func (e *Extractor) Extract(posting Posting) *Analysis {
+	clauses := splitClauses(posting)
+
+	analysis := &Analysis{}
+	analysis.ExperienceLevel, analysis.MinYears, analysis.SeniorityCues = detectSeniority(clauses)
+
+	level := posting.ExperienceLevel
+	if level == "" {
+		level = analysis.ExperienceLevel
+	}
+
+	listed := make(map[string]bool, len(posting.Skills))
+	for _, name := range posting.Skills {
+		listed[e.dictionary.Canonical(name)] = true
+	}
+
+	found := make(map[string]*skillEvidence)
+	var order []string
+	for _, c := range clauses {
+		mentions := e.dictionary.Find(c.text)
+		if len(mentions) == 0 {
+			continue
+		}
+		need := clauseNeed(c)
+		years := 0.0
+		if match := yearsPattern.FindStringSubmatch(c.text); match != nil {
+			years, _ = strconv.ParseFloat(match[1], 64)
+		}
+
+		seen := make(map[string]bool)
+		for _, mention := range mentions {
+			evidence, exists := found[mention.Skill]
+			if !exists {
+				evidence = &skillEvidence{name: mention.Skill, need: NeedMentioned}
+				found[mention.Skill] = evidence
+				order = append(order, mention.Skill)
+			}
+			evidence.mentions++
+			evidence.need = strongerNeed(evidence.need, need)
+			evidence.inTitle = evidence.inTitle || c.inTitle
+			if years > 0 && years <= 40 && len(mentions) <= 3 {
+				evidence.years = math.Max(evidence.years, years)
+			}
+
+			// Proficiency wording applies only when the skill is alone in the clause
+			// or nearly so, otherwise "strong Python, some SQL" would blur together
+			window := cueWindow(c.text, mention, len(mentions) == 1)
+			evidence.strong = evidence.strong || strongCuePattern.MatchString(window)
+			evidence.weak = evidence.weak || weakCuePattern.MatchString(window)
+			evidence.learnable = evidence.learnable || learnableCuePattern.MatchString(c.text)
+
+			if !seen[mention.Skill] && !c.inTitle && len(evidence.clauses) < maxEvidence {
+				evidence.clauses = append(evidence.clauses, truncate(strings.TrimSpace(c.text), 160))
+			}
+			seen[mention.Skill] = true
+		}
+	}
+
+	analysis.Skills = make([]*SkillSuggestion, 0, len(order))
+	for _, name := range order {
+		evidence := found[name]
+		skill, _ := e.dictionary.Lookup(name)
+		analysis.Skills = append(analysis.Skills, &SkillSuggestion{
+			Name:          name,
+			Category:      skill.Category,
+			Level:         estimateLevel(level, evidence),
+			IsRequired:    evidence.need == NeedRequired,
+			Importance:    estimateImportance(evidence),
+			CanLearn:      evidence.need != NeedRequired || evidence.learnable,
+			Need:          evidence.need,
+			Confidence:    estimateConfidence(evidence),
+			Evidence:      evidence.clauses,
+			AlreadyListed: listed[name],
+		})
+	}
+
+	sort.SliceStable(analysis.Skills, func(i, j int) bool {
+		return analysis.Skills[i].Importance > analysis.Skills[j].Importance
+	})
+	return analysis
+}
+
+// Private functions
+
+// splitClauses breaks the posting into clauses, carrying the need implied by the
+// section heading ("Requirements", "Nice to have") onto the lines below it
+func splitClauses(posting Posting) []clause {
+	var clauses []clause
+	if strings.TrimSpace(posting.Title) != "" {
+		clauses = append(clauses, clause{text: posting.Title, need: NeedMentioned, inTitle: true})
+	}
+
+	sectionNeed := NeedMentioned
+	for _, line := range strings.Split(posting.Description, "\n") {
+		trimmed := strings.TrimSpace(strings.Trim(strings.TrimSpace(line), "-*•·"))
+		if trimmed == "" {
+			continue
+		}
+		if match := headingPattern.FindStringSubmatch(trimmed); match != nil && isHeading(line, trimmed) {
+			sectionNeed = headingNeed(match[1])
+			continue
+		}
+		for _, text := range clauseSplitPattern.Split(trimmed, -1) {
+			if strings.TrimSpace(text) != "" {
+				clauses = append(clauses, clause{text: text, need: sectionNeed})
+			}
+		}
+	}
+
+	// The structured requirements list is the employer's own list of must-haves
+	for _, requirement := range posting.Requirements {
+		if strings.TrimSpace(requirement) != "" {
+			clauses = append(clauses, clause{text: requirement, need: NeedRequired})
+		}
+	}
+	return clauses
+}
+
+// isHeading tells a section heading ("Requirements:", "## Nice to have") from a short
+// sentence. List items are never headings.
+func isHeading(line, trimmed string) bool {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "•") {
+		return false
+	}
+	if strings.HasPrefix(line, "#") || strings.HasSuffix(trimmed, ":") {
+		return true
+	}
+	return len(strings.Fields(trimmed)) <= 4 && headingNeed(trimmed) != NeedMentioned
+}
+
+func headingNeed(heading string) string {
+	lower := strings.ToLower(heading)
+	switch {
+	case preferredCuePattern.MatchString(lower):
+		return NeedPreferred
+	case strings.Contains(lower, "requirement"), strings.Contains(lower, "qualification"),
+		strings.Contains(lower, "must"), strings.Contains(lower, "what you need"),
+		strings.Contains(lower, "what you'll need"), strings.Contains(lower, "skills"):
+		return NeedRequired
+	default:
+		return NeedMentioned
+	}
+}
+
+// clauseNeed lets explicit wording override the section the clause sits in
+func clauseNeed(c clause) string {
+	if c.inTitle {
+		return NeedRequired
+	}
+	switch {
+	case preferredCuePattern.MatchString(c.text):
+		return NeedPreferred
+	case requiredCuePattern.MatchString(c.text):
+		return NeedRequired
+	default:
+		return c.need
+	}
+}
+
+func strongerNeed(current, next string) string {
+	rank := map[string]int{NeedMentioned: 0, NeedPreferred: 1, NeedRequired: 2}
+	if rank[next] > rank[current] {
+		return next
+	}
+	return current
+}
+
+// detectSeniority picks the most senior level cued anywhere in the posting, letting
+// a stated minimum of years raise it
+func detectSeniority(clauses []clause) (string, float64, []string) {
+	level := ""
+	minYears := 0.0
+	var cues []string
+	for _, c := range clauses {
+		for _, cue := range seniorityCues {
+			if match := cue.pattern.FindString(c.text); match != "" {
+				cues = appendUnique(cues, strings.ToLower(match))
+				if levelRank[cue.level] > levelRank[level] {
+					level = cue.level
+				}
+			}
+		}
+		if match := yearsPattern.FindStringSubmatch(c.text); match != nil {
+			if years, err := strconv.ParseFloat(match[1], 64); err == nil && years <= 40 {
+				minYears = math.Max(minYears, years)
+			}
+		}
+	}
+
+	if yearsLevel := levelForYears(minYears); levelRank[yearsLevel] > levelRank[level] {
+		level = yearsLevel
+	}
+	return level, minYears, cues
+}
+
+func levelForYears(years float64) string {
+	switch {
+	case years >= 8:
+		return LevelExpert
+	case years >= 5:
+		return LevelSenior
+	case years >= 3:
+		return LevelAdvanced
+	case years >= 1:
+		return LevelIntermediate
+	case years > 0:
+		return LevelEntry
+	default:
+		return ""
+	}
+}
+
+// estimateLevel starts from the job's seniority, then lets years stated next to the
+// skill and proficiency wording pull it up or down
+func estimateLevel(experienceLevel string, evidence *skillEvidence) int {
+	level := 2
+	switch experienceLevel {
+	case LevelIntermediate:
+		level = 3
+	case LevelAdvanced, LevelSenior:
+		level = 4
+	case LevelExpert:
+		level = 5
+	}
+
+	switch {
+	case evidence.years >= 5:
+		level = 5
+	case evidence.years >= 3:
+		level = 4
+	case evidence.years >= 1:
+		level = 3
+	}
+
+	switch {
+	case evidence.weak && !evidence.strong:
+		level--
+	case evidence.strong && !evidence.weak && level < 4:
+		level++
+	}
+	if evidence.need == NeedPreferred && level > 1 {
+		level--
+	}
+	return clampLevel(level)
+}
+
+// estimateImportance ranks how much the skill should weigh in matching: the need
+// sets the base and repeated or title mentions add to it
+func estimateImportance(evidence *skillEvidence) float64 {
+	importance := 0.5
+	switch evidence.need {
+	case NeedRequired:
+		importance = 0.8
+	case NeedPreferred:
+		importance = 0.4
+	}
+	importance += 0.05 * math.Min(float64(evidence.mentions-1), 3)
+	if evidence.inTitle {
+		importance += 0.1
+	}
+	return math.Min(1, math.Round(importance*100)/100)
+}
+
+func estimateConfidence(evidence *skillEvidence) float64 {
+	confidence := 0.5 + 0.1*math.Min(float64(evidence.mentions-1), 2)
+	if evidence.need != NeedMentioned {
+		confidence += 0.2
+	}
+	if evidence.years > 0 {
+		confidence += 0.1
+	}
+	return math.Min(0.95, math.Round(confidence*100)/100)
+}
+
+// Utility functions
+
+func cueWindow(text string, mention skills.Mention, onlyMention bool) string {
+	if onlyMention {
+		return text
+	}
+	start := mention.Offset - 25
+	if start < 0 {
+		start = 0
+	}
+	end := mention.Offset + len(mention.Matched) + 10
+	if end > len(text) {
+		end = len(text)
+	}
+	return text[start:end]
+}
+
+func clampLevel(level int) int {
+	if level < 1 {
+		return 1
+	}
+	if level > 5 {
+		return 5
+	}
+	return level
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
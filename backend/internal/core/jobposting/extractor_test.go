@@ -0,0 +1,71 @@
+package jobposting
+
+import (
+	"testing"
+
+	"microbridge/backend/internal/core/skills"
+)
+
+const description = `We are looking for a Junior Backend Developer to help build our booking platform.
+
+Requirements:
+- 2+ years of Python
+- Experience with PostgreSQL and REST APIs
+- Strong communication skills
+
+Nice to have:
+- Docker
+- Basic knowledge of React
+
+You will work in an Agile team. Training provided on Kubernetes.`
+
+func TestExtractor_Extract(t *testing.T) {
+	analysis := NewExtractor(skills.DefaultDictionary()).Extract(Posting{
+		Title:        "Python Backend Intern",
+		Description:  description,
+		Requirements: []string{"Git"},
+		Skills:       []string{"python"},
+	})
+
+	if analysis.ExperienceLevel != LevelIntermediate || analysis.MinYears != 2 {
+		t.Errorf("Expected stated years to raise the level to intermediate, got %s (%.1f years)",
+			analysis.ExperienceLevel, analysis.MinYears)
+	}
+
+	suggestions := make(map[string]*SkillSuggestion)
+	for _, suggestion := range analysis.Skills {
+		suggestions[suggestion.Name] = suggestion
+	}
+
+	for _, name := range []string{"Python", "PostgreSQL", "REST APIs", "Communication", "Git"} {
+		if s := suggestions[name]; s == nil || !s.IsRequired || s.Need != NeedRequired {
+			t.Errorf("Expected %s to be suggested as required, got %+v", name, s)
+		}
+	}
+	for _, name := range []string{"Docker", "React"} {
+		if s := suggestions[name]; s == nil || s.IsRequired || !s.CanLearn || s.Need != NeedPreferred {
+			t.Errorf("Expected %s to be suggested as preferred, got %+v", name, s)
+		}
+	}
+	if s := suggestions["Kubernetes"]; s == nil || s.IsRequired || !s.CanLearn {
+		t.Errorf("Expected Kubernetes to be learnable, got %+v", s)
+	}
+
+	python := suggestions["Python"]
+	if python == nil || !python.AlreadyListed || python.Level != 3 {
+		t.Errorf("Expected listed Python at level 3 from stated years, got %+v", python)
+	}
+	if analysis.Skills[0].Name != "Python" {
+		t.Errorf("Expected the title skill to rank first, got %s", analysis.Skills[0].Name)
+	}
+	if react := suggestions["React"]; react != nil && react.Level >= python.Level {
+		t.Errorf("Expected basic preferred React below Python, got %d", react.Level)
+	}
+}
+
+func TestExtractor_EmptyPosting(t *testing.T) {
+	analysis := NewExtractor(skills.DefaultDictionary()).Extract(Posting{Description: "Help us at our cafe."})
+	if len(analysis.Skills) != 0 || analysis.ExperienceLevel != "" {
+		t.Errorf("Expected nothing to be extracted, got %+v", analysis)
+	}
+}
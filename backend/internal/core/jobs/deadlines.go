@@ -0,0 +1,227 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// DeadlineStore finds the jobs the scheduler acts on; repository.JobRepository implements it
+type DeadlineStore interface {
+	// ListExpiredPostings returns posted jobs whose application deadline passed
+	// before the given time and that have no open offer
+	ListExpiredPostings(ctx context.Context, before time.Time, limit int) ([]*models.Job, error)
+	// ListStaleDrafts returns drafts nobody has touched since before
+	ListStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error)
+	// ListEndedReviewPeriods returns jobs still waiting on reviews after their review period
+	ListEndedReviewPeriods(ctx context.Context, now time.Time, limit int) ([]*models.Job, error)
+	// ClaimApplicationReminders stamps and returns posted jobs with applications
+	// submitted before appliedBefore and still unreviewed, skipping jobs reminded
+	// since remindedBefore. A claim is atomic, so each reminder goes out once no
+	// matter how many instances run the scheduler.
+	ClaimApplicationReminders(ctx context.Context, appliedBefore, remindedBefore, now time.Time) ([]models.PendingApplications, error)
+	// ClaimReviewReminders stamps and returns jobs whose review period ends
+	// before dueBefore and that haven't been reminded yet, atomically like
+	// ClaimApplicationReminders
+	ClaimReviewReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Job, error)
+}
+
+// DeadlineActions carries out what the scheduler decides
+type DeadlineActions interface {
+	// TransitionBySystem moves a job through the lifecycle as the system
+	TransitionBySystem(ctx context.Context, job *models.Job, to, reason string) error
+	RemindPendingApplications(ctx context.Context, pending models.PendingApplications) error
+	// RemindReviewPeriod reminds the parties who haven't reviewed the job yet
+	RemindReviewPeriod(ctx context.Context, job *models.Job) error
+}
+
+// DeadlineConfig says what the scheduler does; a zero duration turns that part off
+type DeadlineConfig struct {
+	Interval time.Duration // How often the scheduler runs
+	// BatchSize caps how many jobs each part handles per run; the rest wait for the next one
+	BatchSize int
+	// CloseExpiredPostings archives posted jobs once their application deadline
+	// and ExpiredPostingGrace have passed and no offer is open. The deadline alone
+	// only closes applications; employers can still hire from the applicants.
+	CloseExpiredPostings bool
+	ExpiredPostingGrace  time.Duration
+	// CompleteEndedReviews completes jobs whose review period ended without both reviews
+	CompleteEndedReviews bool
+	StaleDraftAge        time.Duration // Drafts untouched this long are archived
+	ApplicationReminder  time.Duration // Employers are reminded of applications unreviewed this long, at most this often
+	ReviewReminderLead   time.Duration // How long before a review period ends the parties are reminded
+}
+
+// DeadlineReport counts what a run did
+type DeadlineReport struct {
+	ClosedPostings       int
+	CompletedReviews     int
+	ArchivedDrafts       int
+	ApplicationReminders int
+	ReviewReminders      int
+}
+
+// DeadlineJob acts on job deadlines on a schedule: it closes postings left unfilled
+// past their application deadline, completes jobs whose review period ended, archives stale
+// drafts and reminds employers and students of work waiting on them. Status
+// changes only apply while the job is still in the status it was found in and
+// reminders are claimed atomically, so any number of instances can run the job
+// and running it twice changes nothing.
+type DeadlineJob struct {
+	store   DeadlineStore
+	actions DeadlineActions
+	config  DeadlineConfig
+
+	mu        sync.Mutex
+	running   bool
+	stop      context.CancelFunc
+	loopGroup sync.WaitGroup
+}
+
+// NewDeadlineJob creates a job that runs every config.Interval
+func NewDeadlineJob(store DeadlineStore, actions DeadlineActions, config DeadlineConfig) *DeadlineJob {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	return &DeadlineJob{
+		store:   store,
+		actions: actions,
+		config:  config,
+	}
+}
+
+// Start handles due deadlines immediately and then on every interval
+func (j *DeadlineJob) Start(ctx context.Context) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	j.stop = cancel
+	j.running = true
+
+	j.loopGroup.Add(1)
+	go func() {
+		defer j.loopGroup.Done()
+
+		ticker := time.NewTicker(j.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := j.Run(ctx); err != nil && ctx.Err() == nil {
+				fmt.Printf("Failed to handle job deadlines: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts the scheduler
+func (j *DeadlineJob) Stop() {
+	j.mu.Lock()
+	if !j.running {
+		j.mu.Unlock()
+		return
+	}
+	j.running = false
+	j.stop()
+	j.mu.Unlock()
+
+	j.loopGroup.Wait()
+}
+
+// Run handles every due deadline once. A failure in one part doesn't stop the
+// others; the first error is returned once they have all run.
+func (j *DeadlineJob) Run(ctx context.Context) (DeadlineReport, error) {
+	var report DeadlineReport
+	var firstErr error
+	record := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	now := time.Now().UTC()
+
+	if j.config.CloseExpiredPostings {
+		closed, err := j.transitionAll(ctx, StatusArchived, "Application deadline passed", func() ([]*models.Job, error) {
+			return j.store.ListExpiredPostings(ctx, now.Add(-j.config.ExpiredPostingGrace), j.config.BatchSize)
+		})
+		report.ClosedPostings = closed
+		record(err)
+	}
+	if j.config.CompleteEndedReviews {
+		completed, err := j.transitionAll(ctx, StatusCompleted, "Review period ended", func() ([]*models.Job, error) {
+			return j.store.ListEndedReviewPeriods(ctx, now, j.config.BatchSize)
+		})
+		report.CompletedReviews = completed
+		record(err)
+	}
+	if j.config.StaleDraftAge > 0 {
+		archived, err := j.transitionAll(ctx, StatusArchived, "Draft inactive", func() ([]*models.Job, error) {
+			return j.store.ListStaleDrafts(ctx, now.Add(-j.config.StaleDraftAge), j.config.BatchSize)
+		})
+		report.ArchivedDrafts = archived
+		record(err)
+	}
+
+	if j.config.ApplicationReminder > 0 {
+		due, err := j.store.ClaimApplicationReminders(ctx, now.Add(-j.config.ApplicationReminder), now.Add(-j.config.ApplicationReminder), now)
+		record(err)
+		for _, pending := range due {
+			if err := j.actions.RemindPendingApplications(ctx, pending); err != nil {
+				fmt.Printf("Failed to remind employer about applications for job %s: %v\n", pending.JobID, err)
+				continue
+			}
+			report.ApplicationReminders++
+		}
+	}
+	if j.config.ReviewReminderLead > 0 {
+		due, err := j.store.ClaimReviewReminders(ctx, now.Add(j.config.ReviewReminderLead), now)
+		record(err)
+		for i := range due {
+			if err := j.actions.RemindReviewPeriod(ctx, &due[i]); err != nil {
+				fmt.Printf("Failed to send review reminders for job %s: %v\n", due[i].ID, err)
+				continue
+			}
+			report.ReviewReminders++
+		}
+	}
+
+	return report, firstErr
+}
+
+// transitionAll moves the listed jobs to status to and counts the moves. A
+// conflict means another instance or a user changed the job first, so it is
+// skipped; a job failing its guards is reported and skipped.
+func (j *DeadlineJob) transitionAll(ctx context.Context, to, reason string, list func() ([]*models.Job, error)) (int, error) {
+	due, err := list()
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, job := range due {
+		if ctx.Err() != nil {
+			return moved, ctx.Err()
+		}
+		if err := j.actions.TransitionBySystem(ctx, job, to, reason); err != nil {
+			if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != 409 {
+				fmt.Printf("Failed to move job %s to %s: %v\n", job.ID, to, err)
+			}
+			continue
+		}
+		moved++
+	}
+	return moved, nil
+}
@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+type fakeDeadlineStore struct {
+	expired, stale, ended []*models.Job
+	pending               []models.PendingApplications
+	reviews               []models.Job
+	listErr               error
+	staleBefore           time.Time
+}
+
+// ListExpiredPostings leaves out postings whose deadline is after before
+func (s *fakeDeadlineStore) ListExpiredPostings(ctx context.Context, before time.Time, limit int) ([]*models.Job, error) {
+	var expired []*models.Job
+	for _, job := range s.expired {
+		if job.ApplicationDeadline == nil || !job.ApplicationDeadline.After(before) {
+			expired = append(expired, job)
+		}
+	}
+	return expired, s.listErr
+}
+
+func (s *fakeDeadlineStore) ListStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error) {
+	s.staleBefore = before
+	return s.stale, nil
+}
+
+func (s *fakeDeadlineStore) ListEndedReviewPeriods(ctx context.Context, now time.Time, limit int) ([]*models.Job, error) {
+	return s.ended, nil
+}
+
+// The claims hand each item out once, like the repository's atomic updates
+func (s *fakeDeadlineStore) ClaimApplicationReminders(ctx context.Context, appliedBefore, remindedBefore, now time.Time) ([]models.PendingApplications, error) {
+	claimed := s.pending
+	s.pending = nil
+	return claimed, nil
+}
+
+func (s *fakeDeadlineStore) ClaimReviewReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Job, error) {
+	claimed := s.reviews
+	s.reviews = nil
+	return claimed, nil
+}
+
+type fakeDeadlineActions struct {
+	moved    map[string]string
+	reminded []string
+}
+
+func (a *fakeDeadlineActions) TransitionBySystem(ctx context.Context, job *models.Job, to, reason string) error {
+	if job.ID == "taken" {
+		return apperrors.NewAppError(409, "Job status changed in the meantime; reload and try again", nil)
+	}
+	if _, done := a.moved[job.ID]; done {
+		return apperrors.NewAppError(409, "Job is already "+to, nil)
+	}
+	a.moved[job.ID] = to
+	return nil
+}
+
+func (a *fakeDeadlineActions) RemindPendingApplications(ctx context.Context, pending models.PendingApplications) error {
+	a.reminded = append(a.reminded, pending.JobID)
+	return nil
+}
+
+func (a *fakeDeadlineActions) RemindReviewPeriod(ctx context.Context, job *models.Job) error {
+	if job.ID == "broken" {
+		return errors.New("notification failed")
+	}
+	a.reminded = append(a.reminded, job.ID)
+	return nil
+}
+
+func TestDeadlineJob_Run(t *testing.T) {
+	store := &fakeDeadlineStore{
+		expired: []*models.Job{{ID: "expired"}, {ID: "taken"}},
+		stale:   []*models.Job{{ID: "stale"}},
+		ended:   []*models.Job{{ID: "ended"}},
+		pending: []models.PendingApplications{{JobID: "waiting", Pending: 3}},
+		reviews: []models.Job{{ID: "reviewing"}, {ID: "broken"}},
+	}
+	actions := &fakeDeadlineActions{moved: map[string]string{}}
+	job := NewDeadlineJob(store, actions, DeadlineConfig{
+		CloseExpiredPostings: true,
+		CompleteEndedReviews: true,
+		StaleDraftAge:        30 * 24 * time.Hour,
+		ApplicationReminder:  72 * time.Hour,
+		ReviewReminderLead:   72 * time.Hour,
+	})
+
+	before := time.Now().UTC()
+	report, err := job.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := DeadlineReport{ClosedPostings: 1, CompletedReviews: 1, ArchivedDrafts: 1, ApplicationReminders: 1, ReviewReminders: 1}
+	if report != want {
+		t.Errorf("Expected %+v, got %+v", want, report)
+	}
+	if actions.moved["expired"] != StatusArchived || actions.moved["stale"] != StatusArchived || actions.moved["ended"] != StatusCompleted {
+		t.Errorf("Unexpected status changes %v", actions.moved)
+	}
+	if cutoff := before.Add(-30 * 24 * time.Hour); store.staleBefore.Before(cutoff) {
+		t.Errorf("Expected drafts untouched since %v, got %v", cutoff, store.staleBefore)
+	}
+
+	// A second run finds the same jobs but changes and sends nothing
+	report, err = job.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Second run failed: %v", err)
+	}
+	if report != (DeadlineReport{}) {
+		t.Errorf("Expected the second run to do nothing, got %+v", report)
+	}
+}
+
+func TestDeadlineJob_OfferAcceptedAfterDeadline(t *testing.T) {
+	now := time.Now().UTC()
+	deadline := now.Add(-time.Hour)
+	posting := &models.Job{ID: "posting", Status: StatusPosted, Skills: models.RequiredSkillsArray{{Name: "Go"}}, ApplicationDeadline: &deadline}
+	store := &fakeDeadlineStore{expired: []*models.Job{posting}}
+	actions := &fakeDeadlineActions{moved: map[string]string{}}
+	job := NewDeadlineJob(store, actions, DeadlineConfig{CloseExpiredPostings: true, ExpiredPostingGrace: 7 * 24 * time.Hour})
+
+	// The deadline passes: applications close but the posting stays open for hiring
+	report, err := job.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if report.ClosedPostings != 0 || len(actions.moved) != 0 {
+		t.Fatalf("Expected the posting to stay open within the grace period, got %+v", actions.moved)
+	}
+	if AcceptsApplications(posting, now) {
+		t.Error("Expected applications to close at the deadline")
+	}
+
+	// The employer's offer is accepted afterwards
+	studentID := "student-1"
+	posting.HiredStudentID = &studentID
+	if _, err := Transition(posting, StatusHired, ActorSystem, "", "Offer accepted", now); err != nil {
+		t.Fatalf("Expected an offer to be accepted after the deadline: %v", err)
+	}
+	if posting.Status != StatusHired {
+		t.Errorf("Expected the job to be hired, got %s", posting.Status)
+	}
+}
+
+func TestDeadlineJob_RunContinuesAfterErrors(t *testing.T) {
+	store := &fakeDeadlineStore{
+		listErr: errors.New("database unavailable"),
+		stale:   []*models.Job{{ID: "stale"}},
+	}
+	actions := &fakeDeadlineActions{moved: map[string]string{}}
+	job := NewDeadlineJob(store, actions, DeadlineConfig{CloseExpiredPostings: true, StaleDraftAge: time.Hour})
+
+	report, err := job.Run(context.Background())
+	if err == nil {
+		t.Fatal("Expected the listing error to be returned")
+	}
+	if report.ArchivedDrafts != 1 {
+		t.Errorf("Expected drafts to be archived despite the error, got %+v", report)
+	}
+}
+
+func TestDeadlineJob_RunSkipsDisabledParts(t *testing.T) {
+	store := &fakeDeadlineStore{
+		expired: []*models.Job{{ID: "expired"}},
+		pending: []models.PendingApplications{{JobID: "waiting", Pending: 1}},
+	}
+	actions := &fakeDeadlineActions{moved: map[string]string{}}
+
+	report, err := NewDeadlineJob(store, actions, DeadlineConfig{}).Run(context.Background())
+	if err != nil || report != (DeadlineReport{}) || len(actions.moved) != 0 || len(actions.reminded) != 0 {
+		t.Errorf("Expected a disabled scheduler to do nothing, got %+v, %v", report, err)
+	}
+}
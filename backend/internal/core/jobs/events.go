@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+// Event is a job status change, emitted once it is saved
+type Event struct {
+	JobID          string
+	JobTitle       string
+	EmployerID     string
+	HiredStudentID string // Empty while nobody is hired
+	From           string
+	To             string
+	Actor          Actor
+	ActorID        string // Empty for system changes
+	Reason         string
+	Override       bool
+	At             time.Time
+}
+
+// NewEvent describes the change entry recorded on job
+func NewEvent(job *models.Job, entry *models.JobStatusHistory) Event {
+	event := Event{
+		JobID:      job.ID,
+		JobTitle:   job.Title,
+		EmployerID: job.EmployerID,
+		From:       entry.FromStatus,
+		To:         entry.ToStatus,
+		Actor:      Actor(entry.ActorRole),
+		Reason:     entry.Reason,
+		Override:   entry.Override,
+		At:         entry.CreatedAt,
+	}
+	if job.HiredStudentID != nil {
+		event.HiredStudentID = *job.HiredStudentID
+	}
+	if entry.ActorID != nil {
+		event.ActorID = *entry.ActorID
+	}
+	return event
+}
+
+// Listener reacts to job status changes. Listeners run in the order they
+// subscribed, on the goroutine that made the change, and can't fail it.
+type Listener func(ctx context.Context, event Event)
+
+// Emitter hands job lifecycle events to its listeners. A nil Emitter drops them.
+type Emitter struct {
+	mu        sync.RWMutex
+	listeners []Listener
+}
+
+func NewEmitter() *Emitter {
+	return &Emitter{}
+}
+
+// Subscribe adds a listener for every later event
+func (e *Emitter) Subscribe(listener Listener) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.listeners = append(e.listeners, listener)
+}
+
+// Emit calls every listener with the event. A panicking listener is reported
+// and skipped so the rest still hear about the change.
+func (e *Emitter) Emit(ctx context.Context, event Event) {
+	if e == nil {
+		return
+	}
+	e.mu.RLock()
+	listeners := append([]Listener(nil), e.listeners...)
+	e.mu.RUnlock()
+
+	for _, listener := range listeners {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					fmt.Printf("Job lifecycle listener failed for job %s (%s → %s): %v\n", event.JobID, event.From, event.To, r)
+				}
+			}()
+			listener(ctx, event)
+		}()
+	}
+}
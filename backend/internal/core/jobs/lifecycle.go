@@ -0,0 +1,255 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// Job statuses
+const (
+	StatusDraft         = "draft"
+	StatusPosted        = "posted"
+	StatusHired         = "hired"
+	StatusInProgress    = "in_progress"
+	StatusSubmitted     = "submitted"
+	StatusReviewPending = "review_pending"
+	StatusCompleted     = "completed"
+	StatusDisputed      = "disputed"
+	StatusArchived      = "archived"
+)
+
+// Actor is the side that triggers a status change
+type Actor string
+
+const (
+	ActorEmployer Actor = "employer"
+	// ActorStudent is the job's hired student
+	ActorStudent Actor = "student"
+	// ActorAdmin settles disputes; any other change by an admin is an override
+	ActorAdmin Actor = "admin"
+	// ActorSystem covers automatic changes, such as filling a job when its
+	// offer is accepted or closing it once both reviews are in
+	ActorSystem Actor = "system"
+)
+
+// guard is a condition the job must meet to enter a status
+type guard func(job *models.Job, now time.Time) error
+
+// transition is an allowed status change and the actors who may make it
+type transition struct {
+	from   string
+	to     string
+	actors []Actor
+}
+
+// transitions is the job lifecycle:
+//
+//	draft → posted → hired → in_progress ⇄ submitted → review_pending → completed → archived
+//
+// Either party may dispute active work, and an admin settles the dispute.
+// Employers may archive a job that isn't under way.
+var transitions = []transition{
+	{StatusDraft, StatusPosted, []Actor{ActorEmployer}},
+	{StatusPosted, StatusDraft, []Actor{ActorEmployer}},
+	{StatusPosted, StatusHired, []Actor{ActorSystem}},
+
+	{StatusHired, StatusInProgress, []Actor{ActorEmployer, ActorStudent}},
+	{StatusInProgress, StatusSubmitted, []Actor{ActorStudent}},
+	{StatusSubmitted, StatusInProgress, []Actor{ActorEmployer}}, // Changes requested
+	{StatusInProgress, StatusReviewPending, []Actor{ActorEmployer, ActorStudent}},
+	{StatusSubmitted, StatusReviewPending, []Actor{ActorEmployer, ActorStudent}},
+	{StatusReviewPending, StatusCompleted, []Actor{ActorSystem}},
+
+	{StatusInProgress, StatusDisputed, []Actor{ActorEmployer, ActorStudent}},
+	{StatusSubmitted, StatusDisputed, []Actor{ActorEmployer, ActorStudent}},
+	{StatusReviewPending, StatusDisputed, []Actor{ActorEmployer, ActorStudent}},
+	{StatusDisputed, StatusInProgress, []Actor{ActorAdmin}},
+	{StatusDisputed, StatusReviewPending, []Actor{ActorAdmin}},
+	{StatusDisputed, StatusArchived, []Actor{ActorAdmin}},
+
+	{StatusDraft, StatusArchived, []Actor{ActorEmployer, ActorSystem}},
+	{StatusPosted, StatusArchived, []Actor{ActorEmployer, ActorSystem}},
+	{StatusHired, StatusArchived, []Actor{ActorEmployer}},
+	{StatusCompleted, StatusArchived, []Actor{ActorEmployer, ActorSystem}},
+}
+
+// guards are the conditions for entering each status
+var guards = map[string][]guard{
+	StatusPosted:        {requireSkills, requireOpenDeadline, requireNoHire},
+	StatusDraft:         {requireNoHire},
+	StatusHired:         {requireHiredStudent},
+	StatusInProgress:    {requireHiredStudent},
+	StatusSubmitted:     {requireHiredStudent},
+	StatusReviewPending: {requireHiredStudent},
+	StatusCompleted:     {requireHiredStudent},
+}
+
+var knownStatuses = map[string]bool{
+	StatusDraft:         true,
+	StatusPosted:        true,
+	StatusHired:         true,
+	StatusInProgress:    true,
+	StatusSubmitted:     true,
+	StatusReviewPending: true,
+	StatusCompleted:     true,
+	StatusDisputed:      true,
+	StatusArchived:      true,
+}
+
+// IsValidStatus reports whether status is a known job status
+func IsValidStatus(status string) bool {
+	return knownStatuses[status]
+}
+
+// CanTransition checks that actor may move the job to status to. It returns a
+// validation error for unknown statuses, a conflict for changes the lifecycle
+// doesn't allow, a forbidden error when another actor must make them and a bad
+// request when the job doesn't meet the target status's guards.
+func CanTransition(job *models.Job, to string, actor Actor, now time.Time) error {
+	if !IsValidStatus(to) {
+		return apperrors.NewValidationError(fmt.Sprintf("unknown job status %q", to))
+	}
+	if job.Status == to {
+		return apperrors.NewAppError(409, fmt.Sprintf("Job is already %s", to), nil)
+	}
+
+	var allowed []Actor
+	for _, t := range transitions {
+		if t.from == job.Status && t.to == to {
+			allowed = t.actors
+			break
+		}
+	}
+	if allowed == nil {
+		return apperrors.NewAppError(409, fmt.Sprintf("Cannot move a job from %s to %s", job.Status, to), nil)
+	}
+	if !hasActor(allowed, actor) {
+		return apperrors.NewForbiddenError(fmt.Sprintf("Only the %s can move a job from %s to %s", describeActors(allowed), job.Status, to))
+	}
+
+	for _, check := range guards[to] {
+		if err := check(job, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NextStatuses lists the statuses actor may move the job to, guards aside
+func NextStatuses(status string, actor Actor) []string {
+	next := []string{}
+	for _, t := range transitions {
+		if t.from == status && hasActor(t.actors, actor) {
+			next = append(next, t.to)
+		}
+	}
+	return next
+}
+
+// Transition validates a status change, applies it and returns the history
+// entry to record alongside it. actorID is empty for system changes.
+func Transition(job *models.Job, to string, actor Actor, actorID, reason string, now time.Time) (*models.JobStatusHistory, error) {
+	if err := CanTransition(job, to, actor, now); err != nil {
+		return nil, err
+	}
+	return apply(job, to, actor, actorID, reason, false, now), nil
+}
+
+// Override moves a job to any status regardless of the lifecycle and its
+// guards. Only admins override, and they must say why. Reopening a job for
+// hiring, as a draft or posted, releases its hired student.
+func Override(job *models.Job, to, adminID, reason string, now time.Time) (*models.JobStatusHistory, error) {
+	if !IsValidStatus(to) {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("unknown job status %q", to))
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, apperrors.NewValidationError("a reason is required to override a job's status")
+	}
+	if job.Status == to {
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Job is already %s", to), nil)
+	}
+
+	if to == StatusDraft || to == StatusPosted {
+		job.HiredStudentID = nil
+	}
+	return apply(job, to, ActorAdmin, adminID, strings.TrimSpace(reason), true, now), nil
+}
+
+func apply(job *models.Job, to string, actor Actor, actorID, reason string, override bool, now time.Time) *models.JobStatusHistory {
+	from := job.Status
+	job.Status = to
+	job.UpdatedAt = now
+	if to == StatusCompleted && job.CompletedAt == nil {
+		job.CompletedAt = &now
+	}
+
+	entry := &models.JobStatusHistory{
+		JobID:      job.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorRole:  string(actor),
+		Reason:     reason,
+		Override:   override,
+		CreatedAt:  now,
+	}
+	if actorID != "" {
+		entry.ActorID = &actorID
+	}
+	return entry
+}
+
+// AcceptsApplications reports whether students may still apply to the job: it
+// is posted and its application deadline, if any, hasn't passed. A job past its
+// deadline stays posted so the employer can still hire from its applicants.
+func AcceptsApplications(job *models.Job, now time.Time) bool {
+	return job.Status == StatusPosted && (job.ApplicationDeadline == nil || job.ApplicationDeadline.After(now))
+}
+
+func requireSkills(job *models.Job, now time.Time) error {
+	if len(job.Skills) == 0 {
+		return apperrors.NewAppError(400, "Add at least one required skill before posting this job", nil)
+	}
+	return nil
+}
+
+func requireOpenDeadline(job *models.Job, now time.Time) error {
+	if job.ApplicationDeadline != nil && !job.ApplicationDeadline.After(now) {
+		return apperrors.NewAppError(400, "Move the application deadline into the future before posting this job", nil)
+	}
+	return nil
+}
+
+func requireNoHire(job *models.Job, now time.Time) error {
+	if job.HiredStudentID != nil {
+		return apperrors.NewAppError(400, "This job already has a hired student", nil)
+	}
+	return nil
+}
+
+func requireHiredStudent(job *models.Job, now time.Time) error {
+	if job.HiredStudentID == nil || *job.HiredStudentID == "" {
+		return apperrors.NewAppError(400, "This job has no hired student yet", nil)
+	}
+	return nil
+}
+
+func hasActor(actors []Actor, actor Actor) bool {
+	for _, a := range actors {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
+
+func describeActors(actors []Actor) string {
+	names := make([]string, len(actors))
+	for i, actor := range actors {
+		names[i] = string(actor)
+	}
+	return strings.Join(names, " or ")
+}
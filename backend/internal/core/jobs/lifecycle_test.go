@@ -0,0 +1,158 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+func TestCanTransition(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	student := "student-1"
+	skills := []models.RequiredSkill{{Name: "Go"}}
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		job      models.Job
+		to       string
+		actor    Actor
+		wantCode int // 0 when the transition is allowed
+	}{
+		{"employer posts a draft", models.Job{Status: StatusDraft, Skills: skills}, StatusPosted, ActorEmployer, 0},
+		{"system fills a posted job", models.Job{Status: StatusPosted, HiredStudentID: &student}, StatusHired, ActorSystem, 0},
+		{"student starts hired work", models.Job{Status: StatusHired, HiredStudentID: &student}, StatusInProgress, ActorStudent, 0},
+		{"employer requests changes", models.Job{Status: StatusSubmitted, HiredStudentID: &student}, StatusInProgress, ActorEmployer, 0},
+		{"either party disputes", models.Job{Status: StatusReviewPending, HiredStudentID: &student}, StatusDisputed, ActorStudent, 0},
+		{"admin settles a dispute", models.Job{Status: StatusDisputed, HiredStudentID: &student}, StatusReviewPending, ActorAdmin, 0},
+		{"no skipping to completed", models.Job{Status: StatusInProgress, HiredStudentID: &student}, StatusCompleted, ActorEmployer, 409},
+		{"archived is final", models.Job{Status: StatusArchived}, StatusPosted, ActorEmployer, 409},
+		{"same status", models.Job{Status: StatusPosted}, StatusPosted, ActorEmployer, 409},
+		{"employer can't hire directly", models.Job{Status: StatusPosted, HiredStudentID: &student}, StatusHired, ActorEmployer, 403},
+		{"only the student submits", models.Job{Status: StatusInProgress, HiredStudentID: &student}, StatusSubmitted, ActorEmployer, 403},
+		{"parties can't settle disputes", models.Job{Status: StatusDisputed, HiredStudentID: &student}, StatusInProgress, ActorEmployer, 403},
+		{"posting needs skills", models.Job{Status: StatusDraft}, StatusPosted, ActorEmployer, 400},
+		{"posting needs an open deadline", models.Job{Status: StatusDraft, Skills: skills, ApplicationDeadline: &past}, StatusPosted, ActorEmployer, 400},
+		{"hiring needs a student", models.Job{Status: StatusPosted}, StatusHired, ActorSystem, 400},
+		{"unknown status", models.Job{Status: StatusDraft}, "active", ActorEmployer, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CanTransition(&tt.job, tt.to, tt.actor, now)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("Expected transition to be allowed, got %v", err)
+				}
+				return
+			}
+			appErr, ok := err.(*apperrors.AppError)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("Expected a %d error, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestTransition_RecordsChange(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	student := "student-1"
+	job := &models.Job{ID: "job-1", Status: StatusReviewPending, HiredStudentID: &student}
+
+	entry, err := Transition(job, StatusCompleted, ActorSystem, "", "Both parties reviewed", now)
+	if err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if job.Status != StatusCompleted || job.CompletedAt == nil || !job.UpdatedAt.Equal(now) {
+		t.Errorf("Expected the job to be completed at %v, got %+v", now, job)
+	}
+	if entry.JobID != "job-1" || entry.FromStatus != StatusReviewPending || entry.ToStatus != StatusCompleted ||
+		entry.ActorID != nil || entry.ActorRole != "system" || entry.Override {
+		t.Errorf("Unexpected history entry %+v", entry)
+	}
+
+	// A rejected change leaves the job alone
+	if _, err := Transition(job, StatusPosted, ActorEmployer, "employer-1", "", now); err == nil {
+		t.Fatal("Expected reposting a completed job to be rejected")
+	}
+	if job.Status != StatusCompleted {
+		t.Errorf("Expected the job to stay completed, got %s", job.Status)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	student := "student-1"
+	job := &models.Job{ID: "job-1", Status: StatusArchived, HiredStudentID: &student}
+
+	if _, err := Override(job, StatusPosted, "admin-1", "  ", now); err == nil {
+		t.Fatal("Expected an override without a reason to be rejected")
+	}
+
+	entry, err := Override(job, StatusPosted, "admin-1", "Archived by mistake", now)
+	if err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	if job.Status != StatusPosted || job.HiredStudentID != nil {
+		t.Errorf("Expected the job reposted without its hire, got %+v", job)
+	}
+	if !entry.Override || entry.ActorRole != "admin" || entry.ActorID == nil || *entry.ActorID != "admin-1" {
+		t.Errorf("Expected an admin override entry, got %+v", entry)
+	}
+}
+
+func TestAcceptsApplications(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		job      *models.Job
+		expected bool
+	}{
+		{"posted without deadline", &models.Job{Status: StatusPosted}, true},
+		{"posted before deadline", &models.Job{Status: StatusPosted, ApplicationDeadline: &future}, true},
+		{"posted past deadline", &models.Job{Status: StatusPosted, ApplicationDeadline: &past}, false},
+		{"draft", &models.Job{Status: StatusDraft}, false},
+		{"hired", &models.Job{Status: StatusHired, ApplicationDeadline: &future}, false},
+	}
+	for _, tt := range tests {
+		if got := AcceptsApplications(tt.job, now); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestNextStatuses(t *testing.T) {
+	next := NextStatuses(StatusInProgress, ActorStudent)
+	want := map[string]bool{StatusSubmitted: true, StatusReviewPending: true, StatusDisputed: true}
+	if len(next) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, next)
+	}
+	for _, status := range next {
+		if !want[status] {
+			t.Errorf("Unexpected next status %s", status)
+		}
+	}
+	if next := NextStatuses(StatusArchived, ActorEmployer); len(next) != 0 {
+		t.Errorf("Expected no way out of archived, got %v", next)
+	}
+}
+
+func TestEmitter(t *testing.T) {
+	var nilEmitter *Emitter
+	nilEmitter.Emit(context.Background(), Event{JobID: "job-1"})
+
+	emitter := NewEmitter()
+	var heard []string
+	emitter.Subscribe(func(ctx context.Context, event Event) { panic("listener failed") })
+	emitter.Subscribe(func(ctx context.Context, event Event) { heard = append(heard, event.To) })
+
+	emitter.Emit(context.Background(), Event{JobID: "job-1", From: StatusDraft, To: StatusPosted})
+	if len(heard) != 1 || heard[0] != StatusPosted {
+		t.Errorf("Expected the second listener to hear the change despite the first failing, got %v", heard)
+	}
+}
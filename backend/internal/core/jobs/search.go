@@ -0,0 +1,42 @@
+package jobs
+
+import "sort"
+
+// How a student's search results are ordered: text relevance still leads, so a
+// strong match on a barely relevant posting can't outrank what was searched for
+const (
+	SearchRelevanceWeight = 0.6
+	SearchMatchWeight     = 0.4
+	// SearchRerankWindow is how many of the best text matches are re-ranked for a
+	// student; later pages keep the text order
+	SearchRerankWindow = 200
+)
+
+// SearchCandidate is a search hit to re-rank by how well it fits the searcher
+type SearchCandidate struct {
+	Index     int     // Position in the text-ranked results
+	Relevance float64 // Text rank
+	Match     float64 // Match score in [0, 1]
+	Score     float64 // Set by Rerank
+}
+
+// Rerank scores candidates by relevance, scaled to the most relevant one, blended
+// with their match score, and sorts them best first. Ties keep the text order.
+func Rerank(candidates []SearchCandidate) {
+	best := 0.0
+	for _, candidate := range candidates {
+		if candidate.Relevance > best {
+			best = candidate.Relevance
+		}
+	}
+	for i := range candidates {
+		relevance := 0.0
+		if best > 0 {
+			relevance = candidates[i].Relevance / best
+		}
+		candidates[i].Score = SearchRelevanceWeight*relevance + SearchMatchWeight*candidates[i].Match
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRerank(t *testing.T) {
+	candidates := []SearchCandidate{
+		{Index: 0, Relevance: 0.5, Match: 0.1},
+		{Index: 1, Relevance: 0.45, Match: 0.9},
+		{Index: 2, Relevance: 0.05, Match: 1},
+		{Index: 3, Relevance: 0.25, Match: 0.5},
+		{Index: 4, Relevance: 0.25, Match: 0.5},
+	}
+	Rerank(candidates)
+
+	// A close text match that fits the student moves up, but a perfect fit for a
+	// barely relevant posting doesn't beat the postings that were searched for
+	want := []int{1, 0, 3, 4, 2}
+	for i, candidate := range candidates {
+		if candidate.Index != want[i] {
+			t.Fatalf("Expected order %v, got %+v", want, candidates)
+		}
+	}
+	if score := candidates[0].Score; math.Abs(score-0.9) > 1e-9 {
+		t.Errorf("Expected a blended score of 0.6*0.9+0.4*0.9, got %v", score)
+	}
+}
+
+func TestRerank_NoRelevance(t *testing.T) {
+	candidates := []SearchCandidate{{Index: 0, Match: 0.2}, {Index: 1, Match: 0.8}}
+	Rerank(candidates)
+	if candidates[0].Index != 1 || math.Abs(candidates[0].Score-SearchMatchWeight*0.8) > 1e-9 {
+		t.Errorf("Expected the match score alone to order the results, got %+v", candidates)
+	}
+}
@@ -26,6 +26,22 @@ type SkillGap struct {
 	Importance    float64 `json:"importance"`
 }
 
+// Component weights for each direction of the harmonic mean
+var userToJobWeights = map[string]float64{
+	"skills":       0.35, // Skills are most important
+	"experience":   0.25,
+	"location":     0.20,
+	"availability": 0.15,
+	"learning":     0.05,
+}
+
+var jobToUserWeights = map[string]float64{
+	"interest":        0.40, // Interest is most important for job-to-user
+	"career_fit":      0.30,
+	"time_commitment": 0.20,
+	"learning":        0.10,
+}
+
 type MatchingAlgorithm struct{}
 
 func NewMatchingAlgorithm() *MatchingAlgorithm {
@@ -310,14 +326,6 @@ func (ma *MatchingAlgorithm) calculateCareerFitScore(user *models.User, job *mod
 
 // calculateUserToJobScore calculates weighted user-to-job compatibility
 func (ma *MatchingAlgorithm) calculateUserToJobScore(skills, experience, location, availability, learning float64) float64 {
-	weights := map[string]float64{
-		"skills":      0.35, // Skills are most important
-		"experience":  0.25,
-		"location":    0.20,
-		"availability": 0.15,
-		"learning":    0.05,
-	}
-
 	scores := map[string]float64{
 		"skills":       skills,
 		"experience":   experience,
@@ -326,18 +334,11 @@ func (ma *MatchingAlgorithm) calculateUserToJobScore(skills, experience, locatio
 		"learning":     learning,
 	}
 
-	return ma.calculateWeightedScore(scores, weights)
+	return ma.calculateWeightedScore(scores, userToJobWeights)
 }
 
 // calculateJobToUserScore calculates weighted job-to-user compatibility
 func (ma *MatchingAlgorithm) calculateJobToUserScore(interest, timeCommitment, careerFit, learning float64) float64 {
-	weights := map[string]float64{
-		"interest":       0.40, // Interest is most important for job-to-user
-		"career_fit":     0.30,
-		"time_commitment": 0.20,
-		"learning":       0.10,
-	}
-
 	scores := map[string]float64{
 		"interest":        interest,
 		"career_fit":      careerFit,
@@ -345,7 +346,7 @@ func (ma *MatchingAlgorithm) calculateJobToUserScore(interest, timeCommitment, c
 		"learning":        learning,
 	}
 
-	return ma.calculateWeightedScore(scores, weights)
+	return ma.calculateWeightedScore(scores, jobToUserWeights)
 }
 
 // calculateHarmonicMean calculates harmonic mean for balanced scoring
@@ -0,0 +1,109 @@
+package matching
+
+import "sort"
+
+// ComponentAttribution describes how one breakdown component drives the harmonic mean
+type ComponentAttribution struct {
+	Component      string  `json:"component"`
+	Direction      string  `json:"direction"` // "user_to_job" | "job_to_user" | "both"
+	Score          float64 `json:"score"`
+	MarginalEffect float64 `json:"marginal_effect"` // dTotal/dScore at the current scores
+	Contribution   float64 `json:"contribution"`    // Drop in total score if this component were zero
+}
+
+// AttributeComponents explains a MatchScore by each breakdown component's effect on
+// the harmonic mean of the user-to-job and job-to-user scores. Results are sorted by
+// contribution, largest first. Knocked-out matches have no breakdown and return nil.
+func (ma *MatchingAlgorithm) AttributeComponents(score *MatchScore) []ComponentAttribution {
+	if score == nil || len(score.Breakdown) == 0 {
+		return nil
+	}
+
+	userToJob := weightedScore(score.Breakdown, userToJobWeights)
+	jobToUser := weightedScore(score.Breakdown, jobToUserWeights)
+	total := harmonicMean(userToJob, jobToUser)
+
+	// Partial derivatives of 2UJ/(U+J) with respect to each side
+	var dTotalDUserToJob, dTotalDJobToUser float64
+	if sum := userToJob + jobToUser; sum > 0 {
+		dTotalDUserToJob = 2 * jobToUser * jobToUser / (sum * sum)
+		dTotalDJobToUser = 2 * userToJob * userToJob / (sum * sum)
+	}
+	userToJobTotal := totalWeight(userToJobWeights)
+	jobToUserTotal := totalWeight(jobToUserWeights)
+
+	attributions := make([]ComponentAttribution, 0, len(score.Breakdown))
+	for component, value := range score.Breakdown {
+		userWeight, inUserToJob := userToJobWeights[component]
+		jobWeight, inJobToUser := jobToUserWeights[component]
+
+		attribution := ComponentAttribution{Component: component, Score: value}
+		switch {
+		case inUserToJob && inJobToUser:
+			attribution.Direction = "both"
+		case inUserToJob:
+			attribution.Direction = "user_to_job"
+		case inJobToUser:
+			attribution.Direction = "job_to_user"
+		default:
+			continue // Reported in the breakdown but not weighted into the score
+		}
+
+		if inUserToJob {
+			attribution.MarginalEffect += dTotalDUserToJob * userWeight / userToJobTotal
+		}
+		if inJobToUser {
+			attribution.MarginalEffect += dTotalDJobToUser * jobWeight / jobToUserTotal
+		}
+
+		if value != 0 {
+			without := make(map[string]float64, len(score.Breakdown))
+			for name, other := range score.Breakdown {
+				without[name] = other
+			}
+			without[component] = 0
+			attribution.Contribution = total - harmonicMean(
+				weightedScore(without, userToJobWeights),
+				weightedScore(without, jobToUserWeights),
+			)
+		}
+
+		attributions = append(attributions, attribution)
+	}
+
+	sort.Slice(attributions, func(i, j int) bool {
+		if attributions[i].Contribution != attributions[j].Contribution {
+			return attributions[i].Contribution > attributions[j].Contribution
+		}
+		return attributions[i].Component < attributions[j].Component
+	})
+
+	return attributions
+}
+
+func weightedScore(scores, weights map[string]float64) float64 {
+	total, weightSum := 0.0, 0.0
+	for component, weight := range weights {
+		total += scores[component] * weight
+		weightSum += weight
+	}
+	if weightSum == 0 {
+		return 0.0
+	}
+	return total / weightSum
+}
+
+func harmonicMean(a, b float64) float64 {
+	if a == 0 || b == 0 {
+		return 0.0
+	}
+	return 2.0 * a * b / (a + b)
+}
+
+func totalWeight(weights map[string]float64) float64 {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	return total
+}
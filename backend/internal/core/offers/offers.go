@@ -0,0 +1,109 @@
+package offers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+const (
+	// DefaultExpiry is how long a student has to answer an offer when the employer sets no expiry
+	DefaultExpiry = 7 * 24 * time.Hour
+	// MaxExpiry bounds how long an offer may stay open
+	MaxExpiry = 30 * 24 * time.Hour
+	// MaxTermsLength bounds an offer's free-text terms
+	MaxTermsLength = 5000
+)
+
+var knownPeriods = map[string]bool{
+	"hourly":  true,
+	"monthly": true,
+	"yearly":  true,
+	"fixed":   true,
+}
+
+// IsOpen reports whether the offer still waits for an answer
+func IsOpen(status string) bool {
+	return status == models.OfferExtended || status == models.OfferCountered
+}
+
+// IsExpired reports whether an open offer has run past its expiry
+func IsExpired(offer *models.Offer, now time.Time) bool {
+	return IsOpen(offer.Status) && !now.Before(offer.ExpiresAt)
+}
+
+// ResolvePay fills the offered pay from the job's salary range. The currency and
+// period always follow the range when it sets them; the amount defaults to the
+// top of the range and must stay inside it unless the salary is negotiable.
+func ResolvePay(salary models.SalaryRange, amount *int, currency, period string) (models.OfferPay, error) {
+	pay := models.OfferPay{
+		Currency: strings.ToUpper(strings.TrimSpace(salary.Currency)),
+		Period:   strings.ToLower(strings.TrimSpace(salary.Period)),
+	}
+	if pay.Currency == "" {
+		pay.Currency = strings.ToUpper(strings.TrimSpace(currency))
+	}
+	if pay.Period == "" {
+		pay.Period = strings.ToLower(strings.TrimSpace(period))
+	}
+
+	switch {
+	case amount != nil:
+		pay.Amount = *amount
+	case salary.Max > 0:
+		pay.Amount = salary.Max
+	default:
+		pay.Amount = salary.Min
+	}
+
+	if pay.Amount <= 0 {
+		return pay, apperrors.NewValidationError("pay_amount is required when the job has no salary range")
+	}
+	if len(pay.Currency) != 3 {
+		return pay, apperrors.NewValidationError("currency must be a three-letter code")
+	}
+	if !knownPeriods[pay.Period] {
+		return pay, apperrors.NewValidationError("period must be one of hourly, monthly, yearly or fixed")
+	}
+	if !salary.IsNegotiable && salary.Max > 0 && (pay.Amount < salary.Min || pay.Amount > salary.Max) {
+		return pay, apperrors.NewValidationError(fmt.Sprintf("pay_amount must be between %d and %d; the job's salary isn't negotiable", salary.Min, salary.Max))
+	}
+	return pay, nil
+}
+
+// ValidateDates checks that an engagement ends after it starts
+func ValidateDates(start, end *time.Time) error {
+	if start != nil && end != nil && !end.After(*start) {
+		return apperrors.NewValidationError("end_date must be after start_date")
+	}
+	return nil
+}
+
+// ResolveExpiry returns when an offer made now expires, defaulting to DefaultExpiry
+func ResolveExpiry(requested *time.Time, now time.Time) (time.Time, error) {
+	if requested == nil {
+		return now.Add(DefaultExpiry), nil
+	}
+	expiresAt := requested.UTC()
+	if !expiresAt.After(now) {
+		return expiresAt, apperrors.NewValidationError("expires_at must be in the future")
+	}
+	if expiresAt.Sub(now) > MaxExpiry {
+		return expiresAt, apperrors.NewValidationError(fmt.Sprintf("expires_at can be at most %d days away", int(MaxExpiry.Hours()/24)))
+	}
+	return expiresAt, nil
+}
+
+// ValidateCounter checks that a counter proposes something and that what it proposes is sound
+func ValidateCounter(counter models.OfferCounter) error {
+	if counter.PayAmount == nil && counter.StartDate == nil && counter.EndDate == nil && strings.TrimSpace(counter.Message) == "" {
+		return apperrors.NewValidationError("a counter needs a pay_amount, dates or a message")
+	}
+	if counter.PayAmount != nil && *counter.PayAmount <= 0 {
+		return apperrors.NewValidationError("pay_amount must be positive")
+	}
+	return ValidateDates(counter.StartDate, counter.EndDate)
+}
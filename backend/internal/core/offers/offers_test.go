@@ -0,0 +1,99 @@
+package offers
+
+import (
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+func TestResolvePay(t *testing.T) {
+	salary := models.SalaryRange{Min: 20, Max: 30, Currency: "usd", Period: "Hourly"}
+
+	pay, err := ResolvePay(salary, nil, "", "")
+	if err != nil {
+		t.Fatalf("ResolvePay failed: %v", err)
+	}
+	if pay != (models.OfferPay{Amount: 30, Currency: "USD", Period: "hourly"}) {
+		t.Errorf("Expected the top of the range, got %+v", pay)
+	}
+
+	// The range's currency wins over the request's
+	amount := 25
+	pay, err = ResolvePay(salary, &amount, "EUR", "monthly")
+	if err != nil {
+		t.Fatalf("ResolvePay failed: %v", err)
+	}
+	if pay.Amount != 25 || pay.Currency != "USD" || pay.Period != "hourly" {
+		t.Errorf("Expected 25 USD hourly, got %+v", pay)
+	}
+
+	amount = 40
+	if _, err := ResolvePay(salary, &amount, "", ""); err == nil {
+		t.Error("Expected pay above a fixed range to be rejected")
+	}
+	salary.IsNegotiable = true
+	if _, err := ResolvePay(salary, &amount, "", ""); err != nil {
+		t.Errorf("Expected a negotiable range to allow %d, got %v", amount, err)
+	}
+
+	if _, err := ResolvePay(models.SalaryRange{}, nil, "USD", "fixed"); err == nil {
+		t.Error("Expected an amount to be required without a salary range")
+	}
+	amount = 500
+	pay, err = ResolvePay(models.SalaryRange{}, &amount, "gbp", "fixed")
+	if err != nil || pay.Currency != "GBP" {
+		t.Errorf("Expected the request's currency without a range, got %+v, %v", pay, err)
+	}
+	if _, err := ResolvePay(models.SalaryRange{}, &amount, "GBP", "weekly"); err == nil {
+		t.Error("Expected an unknown period to be rejected")
+	}
+}
+
+func TestResolveExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	expiresAt, err := ResolveExpiry(nil, now)
+	if err != nil || !expiresAt.Equal(now.Add(DefaultExpiry)) {
+		t.Errorf("Expected the default expiry, got %v, %v", expiresAt, err)
+	}
+
+	past := now.Add(-time.Minute)
+	if _, err := ResolveExpiry(&past, now); err == nil {
+		t.Error("Expected a past expiry to be rejected")
+	}
+	tooFar := now.Add(MaxExpiry + time.Hour)
+	if _, err := ResolveExpiry(&tooFar, now); err == nil {
+		t.Error("Expected an expiry beyond the maximum to be rejected")
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	offer := &models.Offer{Status: models.OfferCountered, ExpiresAt: now}
+	if !IsExpired(offer, now) {
+		t.Error("Expected an open offer at its expiry to be expired")
+	}
+	offer.Status = models.OfferAccepted
+	if IsExpired(offer, now.Add(time.Hour)) {
+		t.Error("Expected an answered offer never to expire")
+	}
+}
+
+func TestValidateCounter(t *testing.T) {
+	if err := ValidateCounter(models.OfferCounter{Message: "  "}); err == nil {
+		t.Error("Expected an empty counter to be rejected")
+	}
+	zero := 0
+	if err := ValidateCounter(models.OfferCounter{PayAmount: &zero}); err == nil {
+		t.Error("Expected a zero pay counter to be rejected")
+	}
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(-24 * time.Hour)
+	if err := ValidateCounter(models.OfferCounter{StartDate: &start, EndDate: &end}); err == nil {
+		t.Error("Expected a counter ending before it starts to be rejected")
+	}
+	if err := ValidateCounter(models.OfferCounter{Message: "Could we start a week later?"}); err != nil {
+		t.Errorf("Expected a message-only counter to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package privacy
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// Store reads and erases personal data; repository.PrivacyRepository implements it
+type Store interface {
+	ExportUserData(ctx context.Context, userID string) (*repository.UserDataExport, error)
+	EraseUserData(ctx context.Context, userID, requestedBy string) (*repository.DataSubjectRequest, error)
+	RecordDataSubjectRequest(ctx context.Context, request *repository.DataSubjectRequest) error
+	ListDataSubjectRequests(ctx context.Context, userID string) ([]*repository.DataSubjectRequest, error)
+}
+
+// BlobDeleter deletes stored file contents; storage.Provider implements it
+type BlobDeleter interface {
+	Delete(ctx context.Context, key string) error
+}
+
+// Service exports and erases users' personal data and keeps an audit trail of both
+type Service struct {
+	store Store
+	blobs BlobDeleter
+}
+
+// NewService creates a privacy service. Erasure deletes the stored contents of
+// the user's files from blobs.
+func NewService(store Store, blobs BlobDeleter) *Service {
+	return &Service{store: store, blobs: blobs}
+}
+
+// Export collects the user's data and records who requested it
+func (s *Service) Export(ctx context.Context, userID, requestedBy string) (*repository.UserDataExport, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperrors.NewValidationError("user ID is required")
+	}
+
+	export, err := s.store.ExportUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &repository.DataSubjectRequest{
+		UserID:       userID,
+		Type:         repository.DataRequestExport,
+		RequestedBy:  requestedBy,
+		AffectedRows: make(map[string]int64, len(export.Tables)),
+	}
+	for table, rows := range export.Tables {
+		request.AffectedRows[table] = int64(len(rows))
+	}
+	// An export that can't be audited is not handed out
+	if err := s.store.RecordDataSubjectRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Erase deletes or anonymizes the user's data; the returned record is the audit entry
+func (s *Service) Erase(ctx context.Context, userID, requestedBy string) (*repository.DataSubjectRequest, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperrors.NewValidationError("user ID is required")
+	}
+
+	request, err := s.store.EraseUserData(ctx, userID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rows are gone by now, so a failed delete can't be retried through another
+	// erasure; it is logged with its key instead of failing the request
+	if s.blobs != nil {
+		for _, key := range request.OrphanedFileKeys {
+			if err := s.blobs.Delete(ctx, key); err != nil {
+				fmt.Printf("Failed to delete stored file %s erased with user %s: %v\n", key, userID, err)
+			}
+		}
+	}
+	return request, nil
+}
+
+// History returns the user's exports and erasures, newest first
+func (s *Service) History(ctx context.Context, userID string) ([]*repository.DataSubjectRequest, error) {
+	return s.store.ListDataSubjectRequests(ctx, userID)
+}
+
+// archiveManifest is the index written first in an export archive
+type archiveManifest struct {
+	UserID      string           `json:"user_id"`
+	GeneratedAt string           `json:"generated_at"`
+	Files       map[string]int64 `json:"files"` // File name -> row count
+}
+
+// WriteArchive writes the export as a ZIP holding manifest.json and one JSON file per table
+func WriteArchive(w io.Writer, export *repository.UserDataExport) error {
+	tables := make([]string, 0, len(export.Tables))
+	for table := range export.Tables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	manifest := archiveManifest{
+		UserID:      export.UserID,
+		GeneratedAt: export.GeneratedAt.Format(time.RFC3339),
+		Files:       make(map[string]int64, len(tables)),
+	}
+	for _, table := range tables {
+		manifest.Files[table+".json"] = int64(len(export.Tables[table]))
+	}
+
+	archive := zip.NewWriter(w)
+	if err := writeArchiveFile(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		rows := export.Tables[table]
+		if rows == nil {
+			rows = []map[string]interface{}{}
+		}
+		if err := writeArchiveFile(archive, table+".json", rows); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func writeArchiveFile(archive *zip.Writer, name string, value interface{}) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,146 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/repository"
+)
+
+type fakeStore struct {
+	export       *repository.UserDataExport
+	requests     []*repository.DataSubjectRequest
+	recordErr    error
+	orphanedKeys []string
+}
+
+func (s *fakeStore) ExportUserData(ctx context.Context, userID string) (*repository.UserDataExport, error) {
+	return s.export, nil
+}
+
+func (s *fakeStore) EraseUserData(ctx context.Context, userID, requestedBy string) (*repository.DataSubjectRequest, error) {
+	request := &repository.DataSubjectRequest{UserID: userID, Type: repository.DataRequestErasure, RequestedBy: requestedBy, OrphanedFileKeys: s.orphanedKeys}
+	s.requests = append(s.requests, request)
+	return request, nil
+}
+
+func (s *fakeStore) RecordDataSubjectRequest(ctx context.Context, request *repository.DataSubjectRequest) error {
+	if s.recordErr != nil {
+		return s.recordErr
+	}
+	s.requests = append(s.requests, request)
+	return nil
+}
+
+func (s *fakeStore) ListDataSubjectRequests(ctx context.Context, userID string) ([]*repository.DataSubjectRequest, error) {
+	return s.requests, nil
+}
+
+type fakeBlobs struct {
+	deleted []string
+	failKey string
+}
+
+func (b *fakeBlobs) Delete(ctx context.Context, key string) error {
+	b.deleted = append(b.deleted, key)
+	if key == b.failKey {
+		return errors.New("storage unavailable")
+	}
+	return nil
+}
+
+func newTestExport() *repository.UserDataExport {
+	return &repository.UserDataExport{
+		UserID:      "user-1",
+		GeneratedAt: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+		Tables: map[string][]map[string]interface{}{
+			"users":        {{"id": "user-1", "email": "ann@example.com"}},
+			"applications": {{"id": "app-1"}, {"id": "app-2"}},
+			"reviews":      nil,
+		},
+	}
+}
+
+func TestService_ExportIsAudited(t *testing.T) {
+	store := &fakeStore{export: newTestExport()}
+	service := NewService(store, nil)
+
+	if _, err := service.Export(context.Background(), "user-1", "admin-1"); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(store.requests) != 1 {
+		t.Fatalf("Expected one audit record, got %d", len(store.requests))
+	}
+	request := store.requests[0]
+	if request.Type != repository.DataRequestExport || request.RequestedBy != "admin-1" || request.AffectedRows["applications"] != 2 {
+		t.Errorf("Unexpected audit record: %+v", request)
+	}
+
+	store.recordErr = errors.New("audit unavailable")
+	if _, err := service.Export(context.Background(), "user-1", "admin-1"); err == nil {
+		t.Error("Expected an export that can't be audited to fail")
+	}
+	if _, err := service.Erase(context.Background(), " ", "admin-1"); err == nil {
+		t.Error("Expected an empty user ID to be rejected")
+	}
+}
+
+func TestService_EraseDeletesOrphanedFiles(t *testing.T) {
+	store := &fakeStore{orphanedKeys: []string{"files/aa", "files/bb", "files/cc"}}
+	blobs := &fakeBlobs{failKey: "files/bb"}
+	service := NewService(store, blobs)
+
+	request, err := service.Erase(context.Background(), "user-1", "admin-1")
+	if err != nil {
+		t.Fatalf("Expected a failed blob delete not to fail the erasure: %v", err)
+	}
+	if request.Type != repository.DataRequestErasure {
+		t.Errorf("Unexpected audit record: %+v", request)
+	}
+	// Every orphaned object is attempted, even after one fails
+	if len(blobs.deleted) != 3 || blobs.deleted[0] != "files/aa" || blobs.deleted[2] != "files/cc" {
+		t.Errorf("Expected every orphaned key to be deleted, got %v", blobs.deleted)
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteArchive(&buf, newTestExport()); err != nil {
+		t.Fatalf("WriteArchive failed: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Expected a valid ZIP: %v", err)
+	}
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		files[file.Name], _ = io.ReadAll(reader)
+		reader.Close()
+	}
+	if archive.File[0].Name != "manifest.json" || len(files) != 4 {
+		t.Fatalf("Expected the manifest first and one file per table, got %d files", len(files))
+	}
+
+	var manifest archiveManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("Invalid manifest: %v", err)
+	}
+	if manifest.Files["applications.json"] != 2 || manifest.GeneratedAt != "2024-03-04T09:00:00Z" {
+		t.Errorf("Unexpected manifest: %+v", manifest)
+	}
+	var reviews []map[string]interface{}
+	if err := json.Unmarshal(files["reviews.json"], &reviews); err != nil || reviews == nil {
+		t.Errorf("Expected an empty table to be written as an empty list, got %s", files["reviews.json"])
+	}
+}
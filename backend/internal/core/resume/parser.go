@@ -0,0 +1,766 @@
+package resume
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"microbridge/backend/internal/core/skills"
+	"microbridge/backend/internal/models"
+)
+
+// Supported resume formats
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatPDF      = "pdf"
+)
+
+// Resume sections recognised from headings
+const (
+	SectionSummary    = "summary"
+	SectionEducation  = "education"
+	SectionExperience = "experience"
+	SectionSkills     = "skills"
+	SectionProjects   = "projects"
+	SectionOther      = "other"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported resume format")
+
+// SkillProposal is a skill found in the resume, for the student to confirm before saving
+type SkillProposal struct {
+	Name              string   `json:"name"`
+	Category          string   `json:"category"`
+	Level             int      `json:"level"`      // 1-5 scale, as in models.UserSkill
+	Experience        string   `json:"experience"` // "0-1 years", "1-2 years", ...
+	YearsOfExperience float64  `json:"years_of_experience"`
+	Confidence        float64  `json:"confidence"`
+	Evidence          []string `json:"evidence"`
+	AlreadyInProfile  bool     `json:"already_in_profile"`
+}
+
+// UserSkill converts a confirmed proposal into a profile skill
+func (p *SkillProposal) UserSkill() models.UserSkill {
+	return models.UserSkill{
+		Name:       p.Name,
+		Level:      p.Level,
+		Experience: p.Experience,
+		Verified:   false, // Self-reported until assessed
+	}
+}
+
+// Education is one education entry
+type Education struct {
+	Institution string  `json:"institution"`
+	Degree      string  `json:"degree,omitempty"`
+	Field       string  `json:"field,omitempty"`
+	StartYear   int     `json:"start_year,omitempty"`
+	EndYear     int     `json:"end_year,omitempty"`
+	GPA         float64 `json:"gpa,omitempty"`
+	Current     bool    `json:"current"`
+}
+
+// WorkExperience is one job, internship or volunteer role
+type WorkExperience struct {
+	Title       string     `json:"title"`
+	Company     string     `json:"company,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	Current     bool       `json:"current"`
+	Months      int        `json:"months"`
+	Description []string   `json:"description,omitempty"`
+}
+
+// ParsedResume is the structured content extracted from a resume
+type ParsedResume struct {
+	Format                string            `json:"format"`
+	Skills                []*SkillProposal  `json:"skills"`
+	Education             []*Education      `json:"education"`
+	WorkHistory           []*WorkExperience `json:"work_history"`
+	TotalExperienceMonths int               `json:"total_experience_months"`
+	Sections              []string          `json:"sections"`
+	Warnings              []string          `json:"warnings,omitempty"`
+}
+
+// Parser extracts skills, education and work history from resume text using the
+// skill dictionary. It runs entirely in-process.
+type Parser struct {
+	dictionary *skills.Dictionary
+	now        func() time.Time
+}
+
+// NewParser creates a parser backed by the given skill dictionary
+func NewParser(dictionary *skills.Dictionary) *Parser {
+	return &Parser{
+		dictionary: dictionary,
+		now:        time.Now,
+	}
+}
+
+// DetectFormat infers the format from a file name, falling back to sniffing the content
+func DetectFormat(filename string, content []byte) string {
+	lower := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lower, ".pdf"):
+		return FormatPDF
+	case strings.HasSuffix(lower, ".md"), strings.HasSuffix(lower, ".markdown"):
+		return FormatMarkdown
+	case strings.HasPrefix(strings.TrimSpace(string(content)), "%PDF-"):
+		return FormatPDF
+	default:
+		return FormatText
+	}
+}
+
+// Parse extracts structured data from resume content in the given format
+func (p *Parser) Parse(content []byte, format string) (*ParsedResume, error) {
+	var text string
+	switch format {
+	case FormatText, FormatMarkdown, "":
+		text = string(content)
+	case FormatPDF:
+		extracted, err := ExtractPDFText(content)
+		if err != nil {
+			return nil, err
+		}
+		text = extracted
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if format == "" {
+		format = FormatText
+	}
+
+	lines := splitLines(text, format == FormatMarkdown)
+	sections := p.sectionize(lines)
+
+	result := &ParsedResume{
+		Format:      format,
+		Education:   p.parseEducation(sections[SectionEducation]),
+		WorkHistory: p.parseWorkHistory(sections[SectionExperience]),
+	}
+	for _, name := range []string{SectionSummary, SectionEducation, SectionExperience, SectionSkills, SectionProjects, SectionOther} {
+		if len(sections[name]) > 0 {
+			result.Sections = append(result.Sections, name)
+		}
+	}
+	result.TotalExperienceMonths = totalMonths(result.WorkHistory)
+	result.Skills = p.proposeSkills(lines, result.WorkHistory)
+
+	if len(result.Skills) == 0 {
+		result.Warnings = append(result.Warnings, "no known skills were found; add them to your profile manually")
+	}
+	if len(sections[SectionExperience]) > 0 && len(result.WorkHistory) == 0 {
+		result.Warnings = append(result.Warnings, "an experience section was found but no dated entries could be read")
+	}
+
+	return result, nil
+}
+
+// line is one logical line of resume text after markup is removed
+type line struct {
+	text    string
+	section string
+	heading bool
+	bullet  bool
+}
+
+// Private methods
+
+var (
+	markdownLinkPattern  = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	markdownEmphasis     = regexp.MustCompile("\\*\\*|__|`")
+	numberedListPattern  = regexp.MustCompile(`^\d+[.)]\s+`)
+	sectionHeadingTitles = map[string]string{
+		"summary":                    SectionSummary,
+		"profile":                    SectionSummary,
+		"about me":                   SectionSummary,
+		"objective":                  SectionSummary,
+		"career objective":           SectionSummary,
+		"education":                  SectionEducation,
+		"academic background":        SectionEducation,
+		"education and training":     SectionEducation,
+		"qualifications":             SectionEducation,
+		"experience":                 SectionExperience,
+		"work experience":            SectionExperience,
+		"professional experience":    SectionExperience,
+		"employment":                 SectionExperience,
+		"employment history":         SectionExperience,
+		"work history":               SectionExperience,
+		"internships":                SectionExperience,
+		"internship experience":      SectionExperience,
+		"skills":                     SectionSkills,
+		"technical skills":           SectionSkills,
+		"core skills":                SectionSkills,
+		"skills and tools":           SectionSkills,
+		"technologies":               SectionSkills,
+		"languages and tools":        SectionSkills,
+		"projects":                   SectionProjects,
+		"personal projects":          SectionProjects,
+		"academic projects":          SectionProjects,
+		"certifications":             SectionOther,
+		"awards":                     SectionOther,
+		"languages":                  SectionOther,
+		"activities":                 SectionOther,
+		"extracurricular activities": SectionOther,
+		"interests":                  SectionOther,
+		"references":                 SectionOther,
+		"volunteering":               SectionOther,
+		"volunteer experience":       SectionOther,
+	}
+)
+
+func splitLines(text string, markdown bool) []*line {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var lines []*line
+	for _, raw := range strings.Split(text, "\n") {
+		current := &line{text: strings.TrimSpace(raw)}
+		if current.text == "" {
+			continue
+		}
+
+		if markdown {
+			if strings.HasPrefix(current.text, "#") {
+				current.heading = true
+				current.text = strings.TrimSpace(strings.TrimLeft(current.text, "#"))
+			}
+			current.text = markdownLinkPattern.ReplaceAllString(current.text, "$1")
+			current.text = markdownEmphasis.ReplaceAllString(current.text, "")
+			if strings.Trim(current.text, "-=*_ ") == "" {
+				continue // Horizontal rules and setext underlines
+			}
+		}
+
+		for _, marker := range []string{"- ", "* ", "+ ", "• ", "▪ ", "◦ ", "– "} {
+			if strings.HasPrefix(current.text, marker) {
+				current.bullet = true
+				current.text = strings.TrimSpace(current.text[len(marker):])
+				break
+			}
+		}
+		if !current.bullet && numberedListPattern.MatchString(current.text) {
+			current.bullet = true
+			current.text = numberedListPattern.ReplaceAllString(current.text, "")
+		}
+		if current.text != "" {
+			lines = append(lines, current)
+		}
+	}
+	return lines
+}
+
+// sectionize assigns every line to a section using recognised headings
+func (p *Parser) sectionize(lines []*line) map[string][]*line {
+	sections := make(map[string][]*line)
+	current := SectionSummary
+	for _, l := range lines {
+		if section, ok := headingSection(l); ok {
+			current = section
+			l.heading = true
+			l.section = section
+			continue
+		}
+		l.section = current
+		sections[current] = append(sections[current], l)
+	}
+	return sections
+}
+
+func headingSection(l *line) (string, bool) {
+	if l.bullet || len(strings.Fields(l.text)) > 5 {
+		return "", false
+	}
+	title := strings.ToLower(strings.TrimRight(l.text, ": "))
+	title = strings.Join(strings.Fields(strings.ReplaceAll(title, "&", "and")), " ")
+
+	if section, ok := sectionHeadingTitles[title]; ok {
+		return section, true
+	}
+	// Markdown headings and all-caps lines are headings even with extra words
+	if l.heading || isUpperCase(l.text) {
+		for prefix, section := range sectionHeadingTitles {
+			if strings.HasPrefix(title, prefix+" ") {
+				return section, true
+			}
+		}
+		if l.heading {
+			return SectionOther, true
+		}
+	}
+	return "", false
+}
+
+var (
+	degreePattern      = regexp.MustCompile(`(?i)\b(bachelor(?:'s)?|master(?:'s)?|ph\.?d|doctorate|b\.?sc|m\.?sc|b\.?a\.?|m\.?a\.?|bba|mba|b\.?eng|m\.?eng|associate degree|higher diploma|diploma|hkdse|a-levels?)\b`)
+	institutionPattern = regexp.MustCompile(`(?i)\b(university|college|institute|school|polytechnic|academy)\b`)
+	fieldPatterns      = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bin\s+([A-Za-z][A-Za-z &]+)`),
+		regexp.MustCompile(`(?i)\bof\s+([A-Za-z][A-Za-z &]+)`),
+	}
+	gpaPattern        = regexp.MustCompile(`(?i)\b(?:c?gpa)\s*[:\-]?\s*([0-4]\.\d{1,2})`)
+	yearPattern       = regexp.MustCompile(`\b((?:19|20)\d{2})\b`)
+	fragmentSeparator = regexp.MustCompile(`\s*(?:\||,|;|\s[-–—]\s)\s*`)
+)
+
+func (p *Parser) parseEducation(lines []*line) []*Education {
+	var entries []*Education
+	var current *Education
+
+	for _, l := range lines {
+		text := l.text
+		hasInstitution := institutionPattern.MatchString(text)
+		hasDegree := degreePattern.MatchString(text)
+
+		if current == nil ||
+			(hasInstitution && current.Institution != "") ||
+			(hasDegree && current.Degree != "" && !hasInstitution) {
+			if !hasInstitution && !hasDegree {
+				continue
+			}
+			current = &Education{}
+			entries = append(entries, current)
+		}
+
+		for _, fragment := range fragmentSeparator.Split(stripDateRanges(text), -1) {
+			fragment = strings.TrimSpace(fragment)
+			switch {
+			case fragment == "":
+			case current.Institution == "" && institutionPattern.MatchString(fragment):
+				current.Institution = fragment
+			case current.Degree == "" && degreePattern.MatchString(fragment):
+				current.Degree = fragment
+				// "Bachelor of Engineering in Computer Science" names its field after "in"
+				for _, pattern := range fieldPatterns {
+					if match := pattern.FindStringSubmatch(fragment); match != nil {
+						current.Field = strings.TrimSpace(match[1])
+						break
+					}
+				}
+			}
+		}
+
+		if dates := findDateRange(text, p.now()); dates != nil {
+			current.StartYear = dates.start.Year()
+			if dates.current {
+				current.Current = true
+			} else {
+				current.EndYear = dates.end.Year()
+			}
+		} else if years := yearPattern.FindAllString(text, -1); len(years) > 0 && current.EndYear == 0 {
+			current.EndYear, _ = strconv.Atoi(years[len(years)-1])
+		}
+		if match := gpaPattern.FindStringSubmatch(text); match != nil {
+			current.GPA, _ = strconv.ParseFloat(match[1], 64)
+		}
+	}
+
+	return entries
+}
+
+var atSeparator = regexp.MustCompile(`(?i)\s+(?:at|@)\s+`)
+
+var roleWords = regexp.MustCompile(`(?i)\b(intern|engineer|developer|programmer|assistant|analyst|designer|manager|officer|tutor|teacher|consultant|lead|researcher|coordinator|specialist|associate|volunteer|ambassador|president|founder|representative|executive|administrator|scientist|barista|cashier|clerk|server|waiter|waitress|instructor|mentor|trainee|apprentice|secretary|treasurer|editor|writer|photographer|captain)\b`)
+
+func (p *Parser) parseWorkHistory(lines []*line) []*WorkExperience {
+	var entries []*WorkExperience
+	var current *WorkExperience
+	var pending *line // Undated line that may be the header of the next entry
+
+	flushPending := func() {
+		if pending != nil && current != nil {
+			current.Description = append(current.Description, pending.text)
+		}
+		pending = nil
+	}
+
+	for _, l := range lines {
+		dates := findDateRange(l.text, p.now())
+		if dates == nil {
+			if l.bullet {
+				flushPending()
+				if current != nil {
+					current.Description = append(current.Description, l.text)
+				}
+			} else {
+				flushPending()
+				pending = l
+			}
+			continue
+		}
+
+		// A dated line starts a new entry; a short header borrows the line above it,
+		// as in "Acme Ltd" followed by "Software Intern  Jun 2022 - Aug 2022"
+		fragments := splitHeader(strings.Trim(stripDateRanges(l.text), " ,|()-–—"))
+		if len(fragments) < 2 && pending != nil {
+			fragments = append(fragments, splitHeader(pending.text)...)
+			pending = nil
+		}
+		flushPending()
+
+		current = &WorkExperience{
+			StartDate: dates.start,
+			Current:   dates.current,
+			Months:    dates.months(),
+		}
+		if !dates.current {
+			current.EndDate = dates.end
+		}
+		current.Title, current.Company = titleAndCompany(fragments)
+		entries = append(entries, current)
+	}
+	flushPending()
+
+	return entries
+}
+
+func splitHeader(header string) []string {
+	if parts := atSeparator.Split(header, 2); len(parts) == 2 {
+		return []string{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])}
+	}
+	var fragments []string
+	for _, fragment := range fragmentSeparator.Split(header, -1) {
+		if fragment = strings.TrimSpace(fragment); fragment != "" {
+			fragments = append(fragments, fragment)
+		}
+	}
+	return fragments
+}
+
+func titleAndCompany(fragments []string) (string, string) {
+	switch len(fragments) {
+	case 0:
+		return "", ""
+	case 1:
+		return fragments[0], ""
+	}
+	for i, fragment := range fragments[:2] {
+		if roleWords.MatchString(fragment) {
+			return fragment, fragments[1-i]
+		}
+	}
+	return fragments[0], fragments[1]
+}
+
+var (
+	explicitYearsPattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*\+?\s*(?:years?|yrs?)`)
+	strongCuePattern     = regexp.MustCompile(`(?i)\b(expert|advanced|proficient|fluent|extensive|strong|deep)\b`)
+	weakCuePattern       = regexp.MustCompile(`(?i)\b(basic|basics|familiar|beginner|learning|exposure|introductory|coursework)\b`)
+	middleCuePattern     = regexp.MustCompile(`(?i)\b(intermediate|working knowledge|hands-on)\b`)
+)
+
+// maxEvidence bounds how many supporting lines are kept per skill
+const maxEvidence = 3
+
+type skillEvidence struct {
+	name          string
+	mentions      int
+	explicitYears float64
+	workMonths    int
+	inSkills      bool
+	strong, weak  bool
+	middle        bool
+	lines         []string
+}
+
+func (p *Parser) proposeSkills(lines []*line, work []*WorkExperience) []*SkillProposal {
+	found := make(map[string]*skillEvidence)
+	var order []string
+
+	for _, l := range lines {
+		mentions := p.dictionary.Find(l.text)
+		if len(mentions) == 0 {
+			continue
+		}
+		yearsMatches := explicitYearsPattern.FindAllStringSubmatchIndex(l.text, -1)
+
+		seen := make(map[string]bool)
+		for _, mention := range mentions {
+			evidence, exists := found[mention.Skill]
+			if !exists {
+				evidence = &skillEvidence{name: mention.Skill}
+				found[mention.Skill] = evidence
+				order = append(order, mention.Skill)
+			}
+			evidence.mentions++
+			if l.section == SectionSkills {
+				evidence.inSkills = true
+			}
+
+			// Years stated close to the skill, as in "Python (3 years)" or "4+ yrs of Go"
+			for _, match := range yearsMatches {
+				if abs(match[0]-mention.Offset) <= 40 {
+					years, _ := strconv.ParseFloat(l.text[match[2]:match[3]], 64)
+					if years <= 40 {
+						evidence.explicitYears = math.Max(evidence.explicitYears, years)
+					}
+				}
+			}
+
+			// Proficiency cues apply to the skill when it is the only one on the line
+			// or the cue sits right next to it
+			window := cueWindow(l.text, mention, len(mentions) == 1)
+			evidence.strong = evidence.strong || strongCuePattern.MatchString(window)
+			evidence.weak = evidence.weak || weakCuePattern.MatchString(window)
+			evidence.middle = evidence.middle || middleCuePattern.MatchString(window)
+
+			if !seen[mention.Skill] && len(evidence.lines) < maxEvidence {
+				evidence.lines = append(evidence.lines, truncate(l.text, 160))
+			}
+			seen[mention.Skill] = true
+		}
+	}
+
+	// Time spent in roles that mention the skill
+	for _, entry := range work {
+		text := entry.Title + "\n" + strings.Join(entry.Description, "\n")
+		used := make(map[string]bool)
+		for _, mention := range p.dictionary.Find(text) {
+			used[mention.Skill] = true
+		}
+		for name := range used {
+			if evidence, exists := found[name]; exists {
+				evidence.workMonths += entry.Months
+			}
+		}
+	}
+
+	proposals := make([]*SkillProposal, 0, len(order))
+	for _, name := range order {
+		evidence := found[name]
+		skill, _ := p.dictionary.Lookup(name)
+
+		years := evidence.explicitYears
+		if years == 0 {
+			years = float64(evidence.workMonths) / 12
+		}
+		proposals = append(proposals, &SkillProposal{
+			Name:              name,
+			Category:          skill.Category,
+			Level:             estimateLevel(years, evidence),
+			Experience:        experienceBand(years),
+			YearsOfExperience: math.Round(years*10) / 10,
+			Confidence:        estimateConfidence(evidence),
+			Evidence:          evidence.lines,
+		})
+	}
+
+	sort.SliceStable(proposals, func(i, j int) bool {
+		return proposals[i].Confidence > proposals[j].Confidence
+	})
+	return proposals
+}
+
+// estimateLevel maps years of use onto the 1-5 profile scale, then lets explicit
+// proficiency wording pull the estimate up or down
+func estimateLevel(years float64, evidence *skillEvidence) int {
+	level := 2 // Listed without any supporting detail
+	switch {
+	case years >= 5:
+		level = 5
+	case years >= 3:
+		level = 4
+	case years >= 1.5:
+		level = 3
+	case years >= 0.5:
+		level = 2
+	case years > 0:
+		level = 1
+	}
+
+	switch {
+	case evidence.weak && !evidence.strong:
+		if level > 2 {
+			level = 2
+		}
+	case evidence.strong && !evidence.weak:
+		if level < 4 {
+			level = 4
+		}
+	case evidence.middle && years == 0:
+		level = 3
+	}
+	return level
+}
+
+func estimateConfidence(evidence *skillEvidence) float64 {
+	confidence := 0.4 + 0.1*math.Min(float64(evidence.mentions-1), 2)
+	if evidence.explicitYears > 0 {
+		confidence += 0.25
+	}
+	if evidence.workMonths > 0 {
+		confidence += 0.15
+	}
+	if evidence.inSkills {
+		confidence += 0.1
+	}
+	return math.Min(0.95, math.Round(confidence*100)/100)
+}
+
+// experienceBand formats years the way profile skills store them
+func experienceBand(years float64) string {
+	switch {
+	case years <= 0:
+		return ""
+	case years < 1:
+		return "0-1 years"
+	case years < 2:
+		return "1-2 years"
+	case years < 3:
+		return "2-3 years"
+	case years < 5:
+		return "3-5 years"
+	default:
+		return "5+ years"
+	}
+}
+
+// Date ranges
+
+var (
+	monthPattern     = `(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?`
+	datePointPattern = `(?:(` + monthPattern + `)\s+|(\d{1,2})/)?((?:19|20)\d{2})`
+	dateRangePattern = regexp.MustCompile(`(?i)` + datePointPattern + `\s*(?:-|–|—|to|until)\s*(?:` + datePointPattern + `|(present|current|now|ongoing|today))`)
+	monthNumbers     = map[string]time.Month{
+		"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+		"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+		"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+	}
+)
+
+type dateRange struct {
+	start   *time.Time
+	end     *time.Time
+	current bool
+}
+
+func (d *dateRange) months() int {
+	months := (d.end.Year()-d.start.Year())*12 + int(d.end.Month()) - int(d.start.Month()) + 1
+	if months < 1 {
+		return 1
+	}
+	return months
+}
+
+func findDateRange(text string, now time.Time) *dateRange {
+	match := dateRangePattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+
+	start := datePoint(match[1], match[2], match[3], time.January)
+	result := &dateRange{start: &start}
+	if match[7] != "" {
+		end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		result.end = &end
+		result.current = true
+	} else {
+		end := datePoint(match[4], match[5], match[6], time.December)
+		result.end = &end
+	}
+	if result.end.Before(*result.start) {
+		return nil
+	}
+	return result
+}
+
+func datePoint(monthName, monthNumber, year string, fallback time.Month) time.Time {
+	y, _ := strconv.Atoi(year)
+	month := fallback
+	if monthName != "" {
+		key := strings.ToLower(monthName)
+		if len(key) > 3 {
+			key = key[:3]
+		}
+		if m, ok := monthNumbers[key]; ok {
+			month = m
+		}
+	} else if monthNumber != "" {
+		if m, err := strconv.Atoi(monthNumber); err == nil && m >= 1 && m <= 12 {
+			month = time.Month(m)
+		}
+	}
+	return time.Date(y, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func stripDateRanges(text string) string {
+	return strings.TrimSpace(dateRangePattern.ReplaceAllString(text, ""))
+}
+
+// totalMonths sums work history without double counting overlapping roles
+func totalMonths(work []*WorkExperience) int {
+	type interval struct{ start, end int }
+	var intervals []interval
+	for _, entry := range work {
+		if entry.StartDate == nil {
+			continue
+		}
+		start := entry.StartDate.Year()*12 + int(entry.StartDate.Month())
+		intervals = append(intervals, interval{start: start, end: start + entry.Months - 1})
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+
+	total := 0
+	lastEnd := math.MinInt32
+	for _, current := range intervals {
+		if current.start > lastEnd {
+			total += current.end - current.start + 1
+			lastEnd = current.end
+		} else if current.end > lastEnd {
+			total += current.end - lastEnd
+			lastEnd = current.end
+		}
+	}
+	return total
+}
+
+// Utility functions
+
+func cueWindow(text string, mention skills.Mention, onlyMention bool) string {
+	if onlyMention {
+		return text
+	}
+	start := mention.Offset - 25
+	if start < 0 {
+		start = 0
+	}
+	end := mention.Offset + len(mention.Matched) + 25
+	if end > len(text) {
+		end = len(text)
+	}
+	return text[start:end]
+}
+
+func isUpperCase(text string) bool {
+	hasLetter := false
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			if !unicode.IsUpper(r) {
+				return false
+			}
+		}
+	}
+	return hasLetter
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
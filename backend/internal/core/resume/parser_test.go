@@ -0,0 +1,119 @@
+package resume
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/core/skills"
+)
+
+const markdownResume = `# Chan Tai Man
+chan@example.com | Hong Kong
+
+## Education
+**The University of Hong Kong** | Bachelor of Engineering in Computer Science | 2020 - 2024
+- GPA: 3.65
+
+## Work Experience
+**Software Engineer Intern** at Acme Ltd | Jun 2022 - Aug 2023
+- Built REST APIs in Go with PostgreSQL
+- Wrote React dashboards
+
+Cafe Central
+Barista, Sep 2019 - May 2020
+- Trained new staff
+
+## Skills
+- Python (4 years), JavaScript, Docker
+- Basic knowledge of Kubernetes
+`
+
+func newTestParser() *Parser {
+	parser := NewParser(skills.DefaultDictionary())
+	parser.now = func() time.Time { return time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC) }
+	return parser
+}
+
+func TestParser_ParseMarkdown(t *testing.T) {
+	result, err := newTestParser().Parse([]byte(markdownResume), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(result.Education) != 1 {
+		t.Fatalf("Expected 1 education entry, got %d", len(result.Education))
+	}
+	education := result.Education[0]
+	if education.Institution != "The University of Hong Kong" || education.Field != "Computer Science" {
+		t.Errorf("Unexpected education entry: %+v", education)
+	}
+	if education.StartYear != 2020 || education.EndYear != 2024 || education.GPA != 3.65 {
+		t.Errorf("Unexpected education dates or GPA: %+v", education)
+	}
+
+	if len(result.WorkHistory) != 2 {
+		t.Fatalf("Expected 2 work entries, got %d", len(result.WorkHistory))
+	}
+	intern := result.WorkHistory[0]
+	if intern.Title != "Software Engineer Intern" || intern.Company != "Acme Ltd" || intern.Months != 15 {
+		t.Errorf("Unexpected work entry: %+v", intern)
+	}
+	if len(intern.Description) != 2 {
+		t.Errorf("Expected 2 description lines, got %v", intern.Description)
+	}
+	barista := result.WorkHistory[1]
+	if barista.Title != "Barista" || barista.Company != "Cafe Central" {
+		t.Errorf("Expected header to borrow the line above, got %+v", barista)
+	}
+	if result.TotalExperienceMonths != 24 {
+		t.Errorf("Expected 24 months of experience, got %d", result.TotalExperienceMonths)
+	}
+
+	proposals := make(map[string]*SkillProposal)
+	for _, proposal := range result.Skills {
+		proposals[proposal.Name] = proposal
+	}
+	for _, name := range []string{"Go", "PostgreSQL", "React", "Python", "JavaScript", "Docker", "Kubernetes", "REST APIs"} {
+		if proposals[name] == nil {
+			t.Errorf("Expected skill %s to be proposed", name)
+		}
+	}
+	if python := proposals["Python"]; python != nil && (python.Level != 4 || python.Experience != "3-5 years") {
+		t.Errorf("Expected stated years to drive Python level, got %+v", python)
+	}
+	if goSkill := proposals["Go"]; goSkill != nil && (goSkill.Level != 2 || goSkill.Experience != "1-2 years") {
+		t.Errorf("Expected internship months to drive Go level, got %+v", goSkill)
+	}
+	if k8s := proposals["Kubernetes"]; k8s != nil && k8s.Level > 2 {
+		t.Errorf("Expected basic cue to cap Kubernetes level, got %d", k8s.Level)
+	}
+}
+
+func TestExtractPDFText(t *testing.T) {
+	content := "BT /F1 12 Tf 72 720 Td (Skills: Python and Docker) Tj 0 -14 Td [(Go)-300(developer)] TJ ET"
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	writer.Write([]byte(content))
+	writer.Close()
+
+	pdf := fmt.Sprintf("%%PDF-1.4\n4 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream\nendobj\n%%%%EOF",
+		compressed.Len(), compressed.String())
+
+	text, err := ExtractPDFText([]byte(pdf))
+	if err != nil {
+		t.Fatalf("ExtractPDFText failed: %v", err)
+	}
+	if text != "Skills: Python and Docker\nGo developer" {
+		t.Errorf("Unexpected text: %q", text)
+	}
+
+	if _, err := ExtractPDFText([]byte("plain text")); err != ErrNotPDF {
+		t.Errorf("Expected ErrNotPDF, got %v", err)
+	}
+	if _, err := ExtractPDFText([]byte("%PDF-1.4\n%%EOF")); err != ErrNoPDFText {
+		t.Errorf("Expected ErrNoPDFText, got %v", err)
+	}
+}
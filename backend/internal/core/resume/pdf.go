@@ -0,0 +1,365 @@
+package resume
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNotPDF    = errors.New("content is not a PDF document")
+	ErrNoPDFText = errors.New("PDF contains no extractable text; scanned documents are not supported")
+)
+
+// maxStreamSize caps the decompressed size of a single content stream
+const maxStreamSize = 8 << 20
+
+// ExtractPDFText pulls the text out of a PDF's page content streams without any
+// external service. It handles uncompressed and Flate-encoded streams with simple
+// font encodings, which covers resumes exported from word processors; scanned
+// documents and CID fonts without a ToUnicode-compatible encoding yield little or no text.
+func ExtractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF-")) {
+		return "", ErrNotPDF
+	}
+
+	var text strings.Builder
+	offset := 0
+	for {
+		start := bytes.Index(data[offset:], []byte("stream"))
+		if start < 0 {
+			break
+		}
+		start += offset
+		offset = start + len("stream")
+
+		// Skip "endstream" and the keyword appearing inside other tokens
+		if start >= 3 && string(data[start-3:start]) == "end" {
+			continue
+		}
+		bodyStart := offset
+		if bodyStart < len(data) && data[bodyStart] == '\r' {
+			bodyStart++
+		}
+		if bodyStart < len(data) && data[bodyStart] == '\n' {
+			bodyStart++
+		}
+		end := bytes.Index(data[bodyStart:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		end += bodyStart
+		offset = end + len("endstream")
+
+		header := streamHeader(data[:start])
+		content, ok := decodeStream(header, data[bodyStart:end])
+		if !ok || !bytes.Contains(content, []byte("BT")) {
+			continue
+		}
+		text.WriteString(extractTextOperators(content))
+		text.WriteString("\n")
+	}
+
+	extracted := collapseBlankLines(text.String())
+	if strings.TrimSpace(extracted) == "" {
+		return "", ErrNoPDFText
+	}
+	return extracted, nil
+}
+
+// Private functions
+
+// streamHeader returns the object dictionary preceding a stream keyword
+func streamHeader(before []byte) []byte {
+	if objStart := bytes.LastIndex(before, []byte(" obj")); objStart >= 0 {
+		return before[objStart:]
+	}
+	if len(before) > 512 {
+		return before[len(before)-512:]
+	}
+	return before
+}
+
+func decodeStream(header, body []byte) ([]byte, bool) {
+	// Images, embedded fonts and colour profiles never hold page text
+	for _, skip := range []string{"/Image", "/FontFile", "/Length1", "/ICCBased", "/XRef"} {
+		if bytes.Contains(header, []byte(skip)) {
+			return nil, false
+		}
+	}
+
+	if !bytes.Contains(header, []byte("/Filter")) {
+		return body, true
+	}
+	if !bytes.Contains(header, []byte("/FlateDecode")) || bytes.Contains(header, []byte("/DecodeParms")) {
+		return nil, false
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, false
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxStreamSize))
+	if err != nil && len(decoded) == 0 {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// extractTextOperators interprets the text-showing operators of a content stream
+func extractTextOperators(content []byte) string {
+	var out strings.Builder
+	var operands []interface{}
+	var array []interface{}
+	inArray := false
+
+	push := func(value interface{}) {
+		if inArray {
+			array = append(array, value)
+		} else {
+			operands = append(operands, value)
+		}
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case isPDFWhitespace(c):
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			value, next := readLiteralString(content, i)
+			push(value)
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			// Inline dictionaries (marked content properties) carry no text
+			depth := 0
+			for i < len(content)-1 {
+				if content[i] == '<' && content[i+1] == '<' {
+					depth++
+					i += 2
+				} else if content[i] == '>' && content[i+1] == '>' {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+		case c == '<':
+			value, next := readHexString(content, i)
+			push(value)
+			i = next
+		case c == '[':
+			inArray, array = true, nil
+			i++
+		case c == ']':
+			inArray = false
+			operands = append(operands, array)
+			i++
+		case c == '/':
+			// Names are operands we never need
+			i++
+			for i < len(content) && !isPDFWhitespace(content[i]) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+			push(nil)
+		default:
+			start := i
+			for i < len(content) && !isPDFWhitespace(content[i]) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+			if i == start {
+				i++
+				continue
+			}
+			token := string(content[start:i])
+			if number, err := strconv.ParseFloat(token, 64); err == nil {
+				push(number)
+				continue
+			}
+			applyTextOperator(&out, token, operands)
+			operands = operands[:0]
+		}
+	}
+
+	return out.String()
+}
+
+func applyTextOperator(out *strings.Builder, operator string, operands []interface{}) {
+	switch operator {
+	case "Tj":
+		writeLastString(out, operands)
+	case "'", "\"":
+		out.WriteString("\n")
+		writeLastString(out, operands)
+	case "TJ":
+		if len(operands) == 0 {
+			return
+		}
+		elements, _ := operands[len(operands)-1].([]interface{})
+		for _, element := range elements {
+			switch value := element.(type) {
+			case string:
+				out.WriteString(value)
+			case float64:
+				// Large negative kerning is how most generators encode a word gap
+				if value < -200 {
+					out.WriteString(" ")
+				}
+			}
+		}
+	case "Td", "TD":
+		if len(operands) >= 2 {
+			if ty, ok := operands[len(operands)-1].(float64); ok && ty != 0 {
+				out.WriteString("\n")
+				return
+			}
+		}
+		out.WriteString(" ")
+	case "T*", "ET", "Tm":
+		out.WriteString("\n")
+	}
+}
+
+func writeLastString(out *strings.Builder, operands []interface{}) {
+	for i := len(operands) - 1; i >= 0; i-- {
+		if value, ok := operands[i].(string); ok {
+			out.WriteString(value)
+			return
+		}
+	}
+}
+
+func readLiteralString(content []byte, start int) (string, int) {
+	var value []rune
+	depth := 0
+	i := start
+	for i < len(content) {
+		c := content[i]
+		switch {
+		case c == '\\' && i+1 < len(content):
+			i++
+			escaped := content[i]
+			switch escaped {
+			case 'n':
+				value = append(value, '\n')
+			case 'r':
+				value = append(value, '\r')
+			case 't':
+				value = append(value, '\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					code := 0
+					digits := 0
+					for digits < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7' {
+						code = code*8 + int(content[i]-'0')
+						i++
+						digits++
+					}
+					value = append(value, rune(code&0xff))
+					continue
+				}
+				value = append(value, rune(escaped))
+			}
+			i++
+		case c == '(':
+			depth++
+			if depth > 1 {
+				value = append(value, '(')
+			}
+			i++
+		case c == ')':
+			depth--
+			i++
+			if depth == 0 {
+				return string(value), i
+			}
+			value = append(value, ')')
+		default:
+			// Simple fonts use single-byte encodings close enough to Latin-1
+			value = append(value, rune(c))
+			i++
+		}
+	}
+	return string(value), i
+}
+
+func readHexString(content []byte, start int) (string, int) {
+	end := bytes.IndexByte(content[start:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+	end += start
+
+	var digits []byte
+	for _, c := range content[start+1 : end] {
+		if !isPDFWhitespace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	raw := make([]byte, len(digits)/2)
+	for i := range raw {
+		b, err := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		if err != nil {
+			return "", end + 1
+		}
+		raw[i] = byte(b)
+	}
+
+	// Two-byte codes with a zero high byte are almost always UTF-16BE text
+	var value []rune
+	if len(raw)%2 == 0 && len(raw) > 0 && raw[0] == 0 {
+		for i := 0; i < len(raw); i += 2 {
+			value = append(value, rune(raw[i])<<8|rune(raw[i+1]))
+		}
+	} else {
+		for _, b := range raw {
+			value = append(value, rune(b))
+		}
+	}
+	return string(value), end + 1
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func collapseBlankLines(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			if !blank && len(kept) > 0 {
+				kept = append(kept, "")
+			}
+			blank = true
+			continue
+		}
+		kept = append(kept, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
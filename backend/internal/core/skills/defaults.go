@@ -0,0 +1,113 @@
+package skills
+
+// Skill categories
+const (
+	CategoryProgramming = "programming"
+	CategoryWeb         = "web"
+	CategoryMobile      = "mobile"
+	CategoryData        = "data"
+	CategoryCloud       = "cloud"
+	CategoryDesign      = "design"
+	CategoryBusiness    = "business"
+	CategorySoft        = "soft"
+)
+
+// DefaultDictionary returns the platform skill dictionary used for resume and job
+// description extraction
+func DefaultDictionary() *Dictionary {
+	return NewDictionary(defaultSkills)
+}
+
+var defaultSkills = []Skill{
+	// Programming languages
+	{Name: "Python", Category: CategoryProgramming, Aliases: []string{"python3"}},
+	{Name: "Java", Category: CategoryProgramming},
+	{Name: "JavaScript", Category: CategoryProgramming, Aliases: []string{"js", "ecmascript", "es6"}},
+	{Name: "TypeScript", Category: CategoryProgramming, CaseSensitiveAliases: []string{"TS"}},
+	{Name: "Go", Category: CategoryProgramming, Aliases: []string{"golang"}, CaseSensitiveAliases: []string{"Go", "GO"}},
+	{Name: "Rust", Category: CategoryProgramming},
+	{Name: "C", Category: CategoryProgramming, CaseSensitiveAliases: []string{"C"}},
+	{Name: "C++", Category: CategoryProgramming, Aliases: []string{"cpp"}},
+	{Name: "C#", Category: CategoryProgramming, Aliases: []string{"csharp", "c sharp"}},
+	{Name: "PHP", Category: CategoryProgramming},
+	{Name: "Ruby", Category: CategoryProgramming},
+	{Name: "Kotlin", Category: CategoryProgramming},
+	{Name: "Swift", Category: CategoryMobile, CaseSensitiveAliases: []string{"Swift"}},
+	{Name: "R", Category: CategoryData, CaseSensitiveAliases: []string{"R"}},
+	{Name: "SQL", Category: CategoryData, Aliases: []string{"t-sql", "pl/sql"}},
+	{Name: "Bash", Category: CategoryProgramming, Aliases: []string{"shell scripting"}},
+
+	// Web
+	{Name: "HTML", Category: CategoryWeb, Aliases: []string{"html5"}},
+	{Name: "CSS", Category: CategoryWeb, Aliases: []string{"css3", "sass", "scss"}},
+	{Name: "React", Category: CategoryWeb, Aliases: []string{"react.js", "reactjs"}},
+	{Name: "Vue", Category: CategoryWeb, Aliases: []string{"vue.js", "vuejs"}},
+	{Name: "Angular", Category: CategoryWeb, Aliases: []string{"angularjs", "angular.js"}},
+	{Name: "Next.js", Category: CategoryWeb, Aliases: []string{"nextjs"}},
+	{Name: "Node.js", Category: CategoryWeb, Aliases: []string{"nodejs"}, CaseSensitiveAliases: []string{"Node"}},
+	{Name: "Express", Category: CategoryWeb, Aliases: []string{"express.js", "expressjs"}, CaseSensitiveAliases: []string{"Express"}},
+	{Name: "Django", Category: CategoryWeb},
+	{Name: "Flask", Category: CategoryWeb},
+	{Name: "Spring", Category: CategoryWeb, Aliases: []string{"spring boot"}, CaseSensitiveAliases: []string{"Spring"}},
+	{Name: ".NET", Category: CategoryWeb, Aliases: []string{"dotnet", "asp.net"}},
+	{Name: "GraphQL", Category: CategoryWeb},
+	{Name: "REST APIs", Category: CategoryWeb, Aliases: []string{"restful", "rest api", "restful apis"}},
+	{Name: "Tailwind CSS", Category: CategoryWeb, Aliases: []string{"tailwind"}},
+
+	// Mobile
+	{Name: "React Native", Category: CategoryMobile},
+	{Name: "Flutter", Category: CategoryMobile, Aliases: []string{"dart"}},
+	{Name: "Android", Category: CategoryMobile},
+	{Name: "iOS", Category: CategoryMobile},
+
+	// Data and machine learning
+	{Name: "Machine Learning", Category: CategoryData, CaseSensitiveAliases: []string{"ML"}},
+	{Name: "Deep Learning", Category: CategoryData},
+	{Name: "Data Analysis", Category: CategoryData, Aliases: []string{"data analytics"}},
+	{Name: "Pandas", Category: CategoryData},
+	{Name: "NumPy", Category: CategoryData},
+	{Name: "TensorFlow", Category: CategoryData},
+	{Name: "PyTorch", Category: CategoryData},
+	{Name: "Scikit-learn", Category: CategoryData, Aliases: []string{"sklearn", "scikit learn"}},
+	{Name: "PostgreSQL", Category: CategoryData, Aliases: []string{"postgres", "psql"}},
+	{Name: "MySQL", Category: CategoryData},
+	{Name: "MongoDB", Category: CategoryData, Aliases: []string{"mongo"}},
+	{Name: "Redis", Category: CategoryData},
+	{Name: "Excel", Category: CategoryData, Aliases: []string{"microsoft excel", "ms excel"}},
+	{Name: "Tableau", Category: CategoryData},
+	{Name: "Power BI", Category: CategoryData, Aliases: []string{"powerbi"}},
+
+	// Cloud and infrastructure
+	{Name: "AWS", Category: CategoryCloud, Aliases: []string{"amazon web services"}},
+	{Name: "Google Cloud", Category: CategoryCloud, Aliases: []string{"gcp", "google cloud platform"}},
+	{Name: "Azure", Category: CategoryCloud, Aliases: []string{"microsoft azure"}},
+	{Name: "Docker", Category: CategoryCloud},
+	{Name: "Kubernetes", Category: CategoryCloud, Aliases: []string{"k8s"}},
+	{Name: "Git", Category: CategoryCloud, Aliases: []string{"github", "gitlab"}},
+	{Name: "CI/CD", Category: CategoryCloud, Aliases: []string{"github actions", "jenkins"}, CaseSensitiveAliases: []string{"CI"}},
+	{Name: "Linux", Category: CategoryCloud},
+
+	// Design
+	{Name: "Figma", Category: CategoryDesign},
+	{Name: "UI Design", Category: CategoryDesign, Aliases: []string{"user interface design"}, CaseSensitiveAliases: []string{"UI"}},
+	{Name: "UX Design", Category: CategoryDesign, Aliases: []string{"user experience", "ux research"}, CaseSensitiveAliases: []string{"UX"}},
+	{Name: "Adobe Photoshop", Category: CategoryDesign, Aliases: []string{"photoshop"}},
+	{Name: "Adobe Illustrator", Category: CategoryDesign, Aliases: []string{"illustrator"}},
+
+	// Business
+	{Name: "Digital Marketing", Category: CategoryBusiness, Aliases: []string{"online marketing"}},
+	{Name: "SEO", Category: CategoryBusiness, Aliases: []string{"search engine optimization"}},
+	{Name: "Content Writing", Category: CategoryBusiness, Aliases: []string{"copywriting"}},
+	{Name: "Project Management", Category: CategoryBusiness},
+	{Name: "Agile", Category: CategoryBusiness, Aliases: []string{"scrum", "kanban"}},
+	{Name: "Financial Analysis", Category: CategoryBusiness, Aliases: []string{"financial modeling", "financial modelling"}},
+
+	// Soft skills
+	{Name: "Communication", Category: CategorySoft, Aliases: []string{"communication skills"}},
+	{Name: "Leadership", Category: CategorySoft},
+	{Name: "Teamwork", Category: CategorySoft, Aliases: []string{"collaboration"}},
+	{Name: "Problem Solving", Category: CategorySoft, Aliases: []string{"problem-solving"}},
+	{Name: "Cantonese", Category: CategorySoft},
+	{Name: "Mandarin", Category: CategorySoft, Aliases: []string{"putonghua"}},
+	{Name: "English", Category: CategorySoft},
+}
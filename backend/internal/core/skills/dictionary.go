@@ -0,0 +1,159 @@
+package skills
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxAliasTokens bounds how many tokens a multi-word alias may span
+const maxAliasTokens = 3
+
+// Skill is a canonical skill with the spellings that refer to it
+type Skill struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Aliases  []string `json:"aliases,omitempty"`
+	// CaseSensitiveAliases only match with exact casing, for names that are also
+	// common words ("Go", "R", "Swift")
+	CaseSensitiveAliases []string `json:"case_sensitive_aliases,omitempty"`
+}
+
+// Mention is an occurrence of a dictionary skill in free text
+type Mention struct {
+	Skill   string `json:"skill"`
+	Matched string `json:"matched"`
+	Offset  int    `json:"offset"` // Byte offset of the match in the scanned text
+}
+
+// Dictionary resolves skill names and aliases to canonical skills
+type Dictionary struct {
+	skills        map[string]*Skill
+	aliases       map[string]string // lower-cased alias -> canonical name
+	caseSensitive map[string]string // exact alias -> canonical name
+}
+
+var tokenPattern = regexp.MustCompile(`\.?[A-Za-z0-9][A-Za-z0-9+#.]*`)
+
+// NewDictionary builds a dictionary. Later entries win when aliases collide.
+func NewDictionary(entries []Skill) *Dictionary {
+	d := &Dictionary{
+		skills:        make(map[string]*Skill, len(entries)),
+		aliases:       make(map[string]string),
+		caseSensitive: make(map[string]string),
+	}
+	for i := range entries {
+		d.Add(entries[i])
+	}
+	return d
+}
+
+// Add registers a skill and its aliases
+func (d *Dictionary) Add(skill Skill) {
+	entry := skill
+	d.skills[entry.Name] = &entry
+	if !containsString(entry.CaseSensitiveAliases, entry.Name) {
+		d.aliases[normalizeAlias(entry.Name)] = entry.Name
+	}
+	for _, alias := range entry.Aliases {
+		d.aliases[normalizeAlias(alias)] = entry.Name
+	}
+	for _, alias := range entry.CaseSensitiveAliases {
+		d.caseSensitive[alias] = entry.Name
+	}
+}
+
+// Lookup resolves a skill name or alias, ignoring case and surrounding punctuation
+func (d *Dictionary) Lookup(name string) (Skill, bool) {
+	trimmed := strings.TrimSpace(name)
+	if canonical, ok := d.caseSensitive[trimmed]; ok {
+		return *d.skills[canonical], true
+	}
+	if canonical, ok := d.aliases[normalizeAlias(trimmed)]; ok {
+		return *d.skills[canonical], true
+	}
+	return Skill{}, false
+}
+
+// Canonical returns the canonical name for a skill, or the trimmed input when unknown
+func (d *Dictionary) Canonical(name string) string {
+	if skill, ok := d.Lookup(name); ok {
+		return skill.Name
+	}
+	return strings.TrimSpace(name)
+}
+
+// Skills returns every skill sorted by name
+func (d *Dictionary) Skills() []Skill {
+	list := make([]Skill, 0, len(d.skills))
+	for _, skill := range d.skills {
+		list = append(list, *skill)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Find returns every skill mention in text, preferring the longest alias at each position
+func (d *Dictionary) Find(text string) []Mention {
+	spans := tokenPattern.FindAllStringIndex(text, -1)
+	tokens := make([]string, len(spans))
+	for i, span := range spans {
+		// Sentence punctuation is not part of the token, but ".NET" and "Node.js" are
+		tokens[i] = strings.TrimRight(text[span[0]:span[1]], ".")
+	}
+
+	var mentions []Mention
+	for i := 0; i < len(tokens); {
+		matched := 0
+		for n := maxAliasTokens; n >= 1 && matched == 0; n-- {
+			if i+n > len(tokens) || !adjacent(text, spans, i, n) {
+				continue
+			}
+			phrase := strings.Join(tokens[i:i+n], " ")
+			canonical, ok := d.caseSensitive[phrase]
+			if !ok {
+				canonical, ok = d.aliases[normalizeAlias(phrase)]
+			}
+			if ok {
+				original := strings.TrimRight(text[spans[i][0]:spans[i+n-1][1]], ".")
+				mentions = append(mentions, Mention{Skill: canonical, Matched: original, Offset: spans[i][0]})
+				matched = n
+			}
+		}
+		if matched == 0 {
+			matched = 1
+		}
+		i += matched
+	}
+	return mentions
+}
+
+// Utility functions
+
+// normalizeAlias lower-cases and treats hyphens as spaces, so "scikit-learn" in text
+// matches however the alias was written. A leading dot is kept for ".NET".
+func normalizeAlias(alias string) string {
+	trimmed := strings.TrimRight(strings.Trim(alias, " \t,;:()[]"), ".")
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(trimmed, "-", " "))), " ")
+}
+
+// adjacent reports whether n tokens from i are separated only by spaces or hyphens,
+// so phrases never span lines or list separators
+func adjacent(text string, spans [][]int, i, n int) bool {
+	for k := i; k < i+n-1; k++ {
+		gap := text[spans[k][1]:spans[k+1][0]]
+		if strings.Trim(gap, " -") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
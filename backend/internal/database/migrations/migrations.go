@@ -188,5 +188,697 @@ func GetAllMigrations() []Migration {
 				DROP COLUMN IF EXISTS last_activity_at;
 			`,
 		},
+		{
+			Version: 20240101000008,
+			Name:    "create_ai_training_tables",
+			Description: "Create AI training job and model artifact tables",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS ai_training_jobs (
+					id UUID PRIMARY KEY,
+					model_type VARCHAR(20) NOT NULL,
+					version VARCHAR(50),
+					status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+					progress DECIMAL(5,4) DEFAULT 0,
+					dataset_size INTEGER DEFAULT 0,
+					config JSONB DEFAULT '{}',
+					training_metrics JSONB DEFAULT '{}',
+					validation_metrics JSONB DEFAULT '{}',
+					error_message TEXT,
+					created_by VARCHAR(255),
+					started_at TIMESTAMP,
+					completed_at TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_ai_training_jobs_model ON ai_training_jobs(model_type);
+				CREATE INDEX IF NOT EXISTS idx_ai_training_jobs_status ON ai_training_jobs(status);
+				CREATE INDEX IF NOT EXISTS idx_ai_training_jobs_started ON ai_training_jobs(started_at DESC);
+
+				CREATE TABLE IF NOT EXISTS ai_model_artifacts (
+					id UUID PRIMARY KEY,
+					model_type VARCHAR(20) NOT NULL,
+					version VARCHAR(50) NOT NULL,
+					training_job_id UUID REFERENCES ai_training_jobs(id) ON DELETE SET NULL,
+					model_path TEXT,
+					config_path TEXT,
+					metrics_path TEXT,
+					file_size BIGINT DEFAULT 0,
+					checksum VARCHAR(64),
+					metadata JSONB DEFAULT '{}',
+					is_active BOOLEAN DEFAULT FALSE,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_ai_model_artifacts_model ON ai_model_artifacts(model_type);
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_ai_model_artifacts_active ON ai_model_artifacts(model_type) WHERE is_active = TRUE;
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS ai_model_artifacts;
+				DROP TABLE IF EXISTS ai_training_jobs;
+			`,
+		},
+		{
+			Version: 20240101000009,
+			Name:    "create_ai_ensemble_weights",
+			Description: "Create table for learned per-segment ensemble weights",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS ai_ensemble_weights (
+					segment VARCHAR(255) PRIMARY KEY,
+					weights JSONB NOT NULL DEFAULT '{}',
+					updates BIGINT NOT NULL DEFAULT 0,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS ai_ensemble_weights;
+			`,
+		},
+		{
+			Version: 20240101000010,
+			Name:    "create_user_behavior_tables",
+			Description: "Create user behavior tables; actions are range-partitioned by month",
+			UpSQL: `
+				-- Monthly partitions (user_actions_YYYY_MM) are created on demand by the repository
+				CREATE TABLE IF NOT EXISTS user_actions (
+					id UUID NOT NULL,
+					user_id UUID NOT NULL,
+					action_type VARCHAR(50) NOT NULL,
+					entity_id VARCHAR(255),
+					entity_type VARCHAR(50),
+					data JSONB DEFAULT '{}',
+					session_id VARCHAR(255),
+					device_info JSONB DEFAULT '{}',
+					location JSONB DEFAULT '{}',
+					occurred_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (id, occurred_at)
+				) PARTITION BY RANGE (occurred_at);
+				CREATE INDEX IF NOT EXISTS idx_user_actions_user_time ON user_actions(user_id, occurred_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_user_actions_user_type_time ON user_actions(user_id, action_type, occurred_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_user_actions_entity ON user_actions(entity_type, entity_id);
+				CREATE INDEX IF NOT EXISTS idx_user_actions_session ON user_actions(session_id) WHERE session_id <> '';
+
+				CREATE TABLE IF NOT EXISTS user_actions_archive (
+					id UUID NOT NULL,
+					user_id UUID NOT NULL,
+					action_type VARCHAR(50) NOT NULL,
+					entity_id VARCHAR(255),
+					entity_type VARCHAR(50),
+					data JSONB DEFAULT '{}',
+					session_id VARCHAR(255),
+					device_info JSONB DEFAULT '{}',
+					location JSONB DEFAULT '{}',
+					occurred_at TIMESTAMP NOT NULL,
+					archived_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (id, occurred_at)
+				);
+				CREATE INDEX IF NOT EXISTS idx_user_actions_archive_user ON user_actions_archive(user_id, occurred_at);
+
+				CREATE TABLE IF NOT EXISTS user_behavior_patterns (
+					user_id UUID PRIMARY KEY,
+					activity_level VARCHAR(20),
+					preferred_skills JSONB DEFAULT '[]',
+					preferred_industries JSONB DEFAULT '[]',
+					preferred_locations JSONB DEFAULT '[]',
+					work_preferences JSONB DEFAULT '{}',
+					avg_application_rate DOUBLE PRECISION DEFAULT 0,
+					avg_engagement_time BIGINT DEFAULT 0,
+					search_patterns JSONB DEFAULT '[]',
+					behavior_vector JSONB DEFAULT '[]',
+					successful_matches JSONB DEFAULT '[]',
+					rejected_matches JSONB DEFAULT '[]',
+					journey_stage VARCHAR(20),
+					last_analyzed TIMESTAMP,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_user_behavior_patterns_stage ON user_behavior_patterns(journey_stage);
+
+				CREATE TABLE IF NOT EXISTS user_preference_signals (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL,
+					signal_type VARCHAR(100) NOT NULL,
+					value DOUBLE PRECISION NOT NULL DEFAULT 0,
+					previous_value DOUBLE PRECISION,
+					confidence DOUBLE PRECISION NOT NULL DEFAULT 0,
+					source VARCHAR(20),
+					last_updated TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE(user_id, signal_type)
+				);
+
+				CREATE TABLE IF NOT EXISTS user_engagement_metrics (
+					user_id UUID PRIMARY KEY,
+					total_views BIGINT DEFAULT 0,
+					total_applications BIGINT DEFAULT 0,
+					total_searches BIGINT DEFAULT 0,
+					total_saves BIGINT DEFAULT 0,
+					average_time_per_job BIGINT DEFAULT 0,
+					session_frequency DOUBLE PRECISION DEFAULT 0,
+					application_rate DOUBLE PRECISION DEFAULT 0,
+					search_to_view_ratio DOUBLE PRECISION DEFAULT 0,
+					engagement_trend VARCHAR(20),
+					last_active_session TIMESTAMP,
+					total_sessions BIGINT DEFAULT 0,
+					weekly_engagement JSONB DEFAULT '[]',
+					last_calculated TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_user_engagement_last_active ON user_engagement_metrics(last_active_session DESC);
+
+				CREATE TABLE IF NOT EXISTS user_search_patterns (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL,
+					query TEXT,
+					filters JSONB DEFAULT '{}',
+					result_count INTEGER DEFAULT 0,
+					clicked_jobs JSONB DEFAULT '[]',
+					session_id VARCHAR(255),
+					searched_at TIMESTAMP NOT NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_user_search_patterns_user_time ON user_search_patterns(user_id, searched_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_user_search_patterns_time ON user_search_patterns(searched_at);
+
+				CREATE TABLE IF NOT EXISTS user_skill_interests (
+					user_id UUID NOT NULL,
+					skill_name VARCHAR(100) NOT NULL,
+					interest DOUBLE PRECISION NOT NULL DEFAULT 0,
+					previous_interest DOUBLE PRECISION,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (user_id, skill_name)
+				);
+				CREATE INDEX IF NOT EXISTS idx_user_skill_interests_skill ON user_skill_interests(skill_name);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS user_skill_interests;
+				DROP TABLE IF EXISTS user_search_patterns;
+				DROP TABLE IF EXISTS user_engagement_metrics;
+				DROP TABLE IF EXISTS user_preference_signals;
+				DROP TABLE IF EXISTS user_behavior_patterns;
+				DROP TABLE IF EXISTS user_actions_archive;
+				DROP TABLE IF EXISTS user_actions;
+			`,
+		},
+		{
+			Version: 20240101000011,
+			Name:    "create_user_cohort_stats",
+			Description: "Record user acquisition source and store weekly signup cohort aggregates",
+			UpSQL: `
+				ALTER TABLE users
+				ADD COLUMN IF NOT EXISTS acquisition_source VARCHAR(100) NOT NULL DEFAULT '';
+				CREATE INDEX IF NOT EXISTS idx_users_created ON users(created_at);
+
+				CREATE TABLE IF NOT EXISTS user_cohort_stats (
+					cohort_week DATE NOT NULL,
+					user_type VARCHAR(20) NOT NULL,
+					acquisition_source VARCHAR(100) NOT NULL,
+					cohort_size INTEGER NOT NULL,
+					retained_users JSONB NOT NULL DEFAULT '[]',
+					successful_users INTEGER NOT NULL DEFAULT 0,
+					days_to_success DOUBLE PRECISION NOT NULL DEFAULT 0,
+					total_actions BIGINT NOT NULL DEFAULT 0,
+					total_applications BIGINT NOT NULL DEFAULT 0,
+					calculated_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (cohort_week, user_type, acquisition_source)
+				);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS user_cohort_stats;
+				DROP INDEX IF EXISTS idx_users_created;
+				ALTER TABLE users DROP COLUMN IF EXISTS acquisition_source;
+			`,
+		},
+		{
+			Version: 20240101000012,
+			Name:    "create_user_sessions",
+			Description: "Store server-side user sessions split on inactivity",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS user_sessions (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL,
+					started_at TIMESTAMP NOT NULL,
+					ended_at TIMESTAMP NOT NULL,
+					action_count INTEGER NOT NULL DEFAULT 0,
+					views INTEGER NOT NULL DEFAULT 0,
+					applications INTEGER NOT NULL DEFAULT 0,
+					saves INTEGER NOT NULL DEFAULT 0,
+					searches INTEGER NOT NULL DEFAULT 0,
+					quality DOUBLE PRECISION NOT NULL DEFAULT 0,
+					engagement_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+					device_info JSONB NOT NULL DEFAULT '{}',
+					updated_at TIMESTAMP NOT NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_user_sessions_user_time ON user_sessions(user_id, started_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_user_sessions_started ON user_sessions(started_at);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS user_sessions;
+			`,
+		},
+		{
+			Version: 20240101000013,
+			Name:    "create_data_subject_requests",
+			Description: "Audit personal data exports and erasures and mark erased users",
+			UpSQL: `
+				ALTER TABLE users
+				ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP;
+
+				CREATE TABLE IF NOT EXISTS data_subject_requests (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL,
+					request_type VARCHAR(20) NOT NULL CHECK (request_type IN ('export', 'erasure')),
+					requested_by UUID NOT NULL,
+					affected_rows JSONB NOT NULL DEFAULT '{}',
+					created_at TIMESTAMP NOT NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_data_subject_requests_user ON data_subject_requests(user_id, created_at DESC);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS data_subject_requests;
+				ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
+			`,
+		},
+		{
+			Version: 20240101000014,
+			Name:    "create_notifications",
+			Description: "Store in-app notifications and per-user notification settings",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS notifications (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					title VARCHAR(255) NOT NULL,
+					message TEXT NOT NULL,
+					type VARCHAR(20) NOT NULL DEFAULT 'info' CHECK (type IN ('info', 'success', 'warning', 'error')),
+					is_read BOOLEAN NOT NULL DEFAULT FALSE,
+					action_url TEXT,
+					action_text VARCHAR(100),
+					metadata JSONB,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					read_at TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE NOT is_read;
+
+				CREATE TABLE IF NOT EXISTS notification_settings (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+					email_notifications BOOLEAN NOT NULL DEFAULT TRUE,
+					push_notifications BOOLEAN NOT NULL DEFAULT TRUE,
+					job_updates BOOLEAN NOT NULL DEFAULT TRUE,
+					payment_notifications BOOLEAN NOT NULL DEFAULT TRUE,
+					deadline_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+					project_updates BOOLEAN NOT NULL DEFAULT TRUE,
+					system_notifications BOOLEAN NOT NULL DEFAULT TRUE,
+					do_not_disturb_start TIME,
+					do_not_disturb_end TIME,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS notification_settings;
+				DROP TABLE IF EXISTS notifications;
+			`,
+		},
+		{
+			Version: 20240101000015,
+			Name:    "create_application_status_history",
+			Description: "Enforce the application lifecycle and record every status change",
+			UpSQL: `
+				ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_status_check;
+				UPDATE applications SET status = 'submitted' WHERE status = 'pending' OR status IS NULL;
+				ALTER TABLE applications
+				ALTER COLUMN status SET DEFAULT 'submitted',
+				ALTER COLUMN status SET NOT NULL,
+				ADD CONSTRAINT applications_status_check CHECK (status IN ('draft', 'submitted', 'reviewed', 'interviewed', 'accepted', 'rejected', 'withdrawn')),
+				ADD COLUMN IF NOT EXISTS custom_resume TEXT,
+				ADD COLUMN IF NOT EXISTS score_breakdown JSONB,
+				ADD COLUMN IF NOT EXISTS applied_at TIMESTAMP,
+				ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP,
+				ADD COLUMN IF NOT EXISTS response_at TIMESTAMP,
+				ADD COLUMN IF NOT EXISTS employer_feedback TEXT,
+				ADD COLUMN IF NOT EXISTS candidate_feedback TEXT,
+				ADD COLUMN IF NOT EXISTS internal_notes TEXT,
+				ADD COLUMN IF NOT EXISTS interview_scheduled TIMESTAMP,
+				ADD COLUMN IF NOT EXISTS interview_notes TEXT;
+				UPDATE applications SET applied_at = created_at WHERE applied_at IS NULL;
+
+				CREATE TABLE IF NOT EXISTS application_status_history (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+					from_status VARCHAR(20) NOT NULL DEFAULT '',
+					to_status VARCHAR(20) NOT NULL,
+					actor_id UUID,
+					actor_role VARCHAR(20) NOT NULL CHECK (actor_role IN ('student', 'employer', 'system')),
+					reason TEXT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_application_status_history_application ON application_status_history(application_id, created_at);
+
+				-- History is append-only; rows only go away with their application
+				CREATE OR REPLACE FUNCTION reject_application_status_history_update() RETURNS trigger AS $$
+				BEGIN
+					RAISE EXCEPTION 'application_status_history is append-only';
+				END;
+				$$ LANGUAGE plpgsql;
+				DROP TRIGGER IF EXISTS application_status_history_append_only ON application_status_history;
+				CREATE TRIGGER application_status_history_append_only
+				BEFORE UPDATE ON application_status_history
+				FOR EACH ROW EXECUTE FUNCTION reject_application_status_history_update();
+
+				INSERT INTO application_status_history (application_id, from_status, to_status, actor_id, actor_role, reason, created_at)
+				SELECT id, '', status, NULL, 'system', 'Recorded when status history was introduced', COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+				FROM applications;
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS application_status_history;
+				DROP FUNCTION IF EXISTS reject_application_status_history_update();
+				ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_status_check;
+			`,
+		},
+		{
+			Version: 20240101000016,
+			Name:    "create_interviews",
+			Description: "Create interviews table for scheduling, invites and reminders",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS interviews (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+					job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+					employer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					status VARCHAR(30) NOT NULL DEFAULT 'proposed' CHECK (status IN ('proposed', 'reschedule_requested', 'scheduled', 'completed', 'cancelled')),
+					proposed_slots JSONB NOT NULL DEFAULT '[]',
+					scheduled_start TIMESTAMP,
+					scheduled_end TIMESTAMP,
+					location VARCHAR(255),
+					meeting_url VARCHAR(500),
+					notes TEXT,
+					sequence INTEGER NOT NULL DEFAULT 0,
+					cancelled_by UUID,
+					cancel_reason TEXT,
+					reminder_sent_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_interviews_application ON interviews(application_id);
+				CREATE INDEX IF NOT EXISTS idx_interviews_student_start ON interviews(student_id, scheduled_start);
+				CREATE INDEX IF NOT EXISTS idx_interviews_employer_start ON interviews(employer_id, scheduled_start);
+				CREATE INDEX IF NOT EXISTS idx_interviews_due_reminders ON interviews(scheduled_start)
+					WHERE status = 'scheduled' AND reminder_sent_at IS NULL;
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS interviews;
+			`,
+		},
+		{
+			Version: 20240101000017,
+			Name:    "create_application_bulk_operations",
+			Description: "Add application shortlisting and queued bulk operations",
+			UpSQL: `
+				ALTER TABLE applications ADD COLUMN IF NOT EXISTS shortlisted_at TIMESTAMP;
+
+				CREATE TABLE IF NOT EXISTS application_bulk_operations (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+					employer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					action VARCHAR(20) NOT NULL CHECK (action IN ('reject', 'review', 'shortlist')),
+					application_ids JSONB NOT NULL DEFAULT '[]',
+					feedback_template TEXT,
+					reason TEXT,
+					status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+					total INTEGER NOT NULL DEFAULT 0,
+					succeeded INTEGER NOT NULL DEFAULT 0,
+					failed INTEGER NOT NULL DEFAULT 0,
+					results JSONB NOT NULL DEFAULT '[]',
+					error TEXT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					started_at TIMESTAMP,
+					completed_at TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_application_bulk_operations_job ON application_bulk_operations(job_id, created_at);
+				CREATE INDEX IF NOT EXISTS idx_application_bulk_operations_queue ON application_bulk_operations(created_at)
+					WHERE status IN ('pending', 'running');
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS application_bulk_operations;
+				ALTER TABLE applications DROP COLUMN IF EXISTS shortlisted_at;
+			`,
+		},
+		{
+			Version: 20240101000018,
+			Name:    "add_screening_questions",
+			Description: "Add per-job screening questions and the answers stored with applications",
+			UpSQL: `
+				ALTER TABLE jobs ADD COLUMN IF NOT EXISTS screening_questions JSONB NOT NULL DEFAULT '[]';
+
+				ALTER TABLE applications ADD COLUMN IF NOT EXISTS screening_answers JSONB NOT NULL DEFAULT '[]';
+				ALTER TABLE applications ADD COLUMN IF NOT EXISTS screening_flagged BOOLEAN NOT NULL DEFAULT FALSE;
+
+				CREATE INDEX IF NOT EXISTS idx_applications_screening_answers ON applications USING GIN (screening_answers jsonb_path_ops);
+				CREATE INDEX IF NOT EXISTS idx_applications_job_flagged ON applications(job_id, screening_flagged);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_applications_job_flagged;
+				DROP INDEX IF EXISTS idx_applications_screening_answers;
+				ALTER TABLE applications DROP COLUMN IF EXISTS screening_flagged;
+				ALTER TABLE applications DROP COLUMN IF EXISTS screening_answers;
+				ALTER TABLE jobs DROP COLUMN IF EXISTS screening_questions;
+			`,
+		},
+		{
+			Version: 20240101000019,
+			Name:    "create_files",
+			Description: "Track uploaded files and attach them to profiles and applications",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS files (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('resume', 'cover_letter', 'logo')),
+					filename VARCHAR(255) NOT NULL DEFAULT '',
+					content_type VARCHAR(100) NOT NULL,
+					size BIGINT NOT NULL CHECK (size > 0),
+					sha256 CHAR(64) NOT NULL,
+					storage_key VARCHAR(255) NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_files_owner ON files(owner_id, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_files_storage_key ON files(storage_key);
+
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS resume_file_id UUID REFERENCES files(id) ON DELETE SET NULL;
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS logo_file_id UUID REFERENCES files(id) ON DELETE SET NULL;
+				ALTER TABLE applications ADD COLUMN IF NOT EXISTS resume_file_id UUID REFERENCES files(id) ON DELETE SET NULL;
+				ALTER TABLE applications ADD COLUMN IF NOT EXISTS cover_letter_file_id UUID REFERENCES files(id) ON DELETE SET NULL;
+			`,
+			DownSQL: `
+				ALTER TABLE applications DROP COLUMN IF EXISTS cover_letter_file_id;
+				ALTER TABLE applications DROP COLUMN IF EXISTS resume_file_id;
+				ALTER TABLE users DROP COLUMN IF EXISTS logo_file_id;
+				ALTER TABLE users DROP COLUMN IF EXISTS resume_file_id;
+				DROP TABLE IF EXISTS files;
+			`,
+		},
+		{
+			Version: 20240101000020,
+			Name:    "create_offers",
+			Description: "Create offers table for extending, countering and accepting job offers",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS offers (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+					job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+					employer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					status VARCHAR(20) NOT NULL DEFAULT 'extended' CHECK (status IN ('extended', 'countered', 'accepted', 'declined', 'withdrawn', 'expired')),
+					terms TEXT,
+					pay JSONB NOT NULL DEFAULT '{}',
+					start_date TIMESTAMP,
+					end_date TIMESTAMP,
+					expires_at TIMESTAMP NOT NULL,
+					revision INTEGER NOT NULL DEFAULT 0,
+					counter JSONB NOT NULL DEFAULT '{}',
+					reason TEXT,
+					responded_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					CHECK (start_date IS NULL OR end_date IS NULL OR end_date > start_date)
+				);
+				-- One open offer per application and one accepted offer per job
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_offers_open_application ON offers(application_id)
+					WHERE status IN ('extended', 'countered');
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_offers_accepted_job ON offers(job_id)
+					WHERE status = 'accepted';
+				CREATE INDEX IF NOT EXISTS idx_offers_job_status ON offers(job_id, status);
+				CREATE INDEX IF NOT EXISTS idx_offers_student ON offers(student_id, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_offers_employer ON offers(employer_id, created_at DESC);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS offers;
+			`,
+		},
+		{
+			Version: 20240101000021,
+			Name:    "create_job_status_history",
+			Description: "Enforce the job lifecycle and record every status change",
+			UpSQL: `
+				ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+				UPDATE jobs SET status = CASE status
+					WHEN 'active' THEN 'posted'
+					WHEN 'paused' THEN 'draft'
+					WHEN 'closed' THEN 'archived'
+					ELSE COALESCE(status, 'draft')
+				END
+				WHERE status IS NULL OR status IN ('active', 'paused', 'closed');
+				ALTER TABLE jobs
+				ALTER COLUMN status SET DEFAULT 'draft',
+				ALTER COLUMN status SET NOT NULL,
+				ADD CONSTRAINT jobs_status_check CHECK (status IN ('draft', 'posted', 'hired', 'in_progress', 'submitted', 'review_pending', 'completed', 'disputed', 'archived')),
+				ADD COLUMN IF NOT EXISTS hired_student_id UUID REFERENCES users(id) ON DELETE SET NULL,
+				ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP,
+				ADD COLUMN IF NOT EXISTS review_due_date TIMESTAMP;
+				DROP INDEX IF EXISTS idx_jobs_status;
+				CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);
+
+				CREATE TABLE IF NOT EXISTS job_status_history (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+					from_status VARCHAR(20) NOT NULL DEFAULT '',
+					to_status VARCHAR(20) NOT NULL,
+					actor_id UUID,
+					actor_role VARCHAR(20) NOT NULL CHECK (actor_role IN ('employer', 'student', 'admin', 'system')),
+					reason TEXT,
+					override BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					CHECK (NOT override OR (actor_role = 'admin' AND COALESCE(reason, '') <> ''))
+				);
+				CREATE INDEX IF NOT EXISTS idx_job_status_history_job ON job_status_history(job_id, created_at);
+
+				-- History is append-only; rows only go away with their job
+				CREATE OR REPLACE FUNCTION reject_job_status_history_update() RETURNS trigger AS $$
+				BEGIN
+					RAISE EXCEPTION 'job_status_history is append-only';
+				END;
+				$$ LANGUAGE plpgsql;
+				DROP TRIGGER IF EXISTS job_status_history_append_only ON job_status_history;
+				CREATE TRIGGER job_status_history_append_only
+				BEFORE UPDATE ON job_status_history
+				FOR EACH ROW EXECUTE FUNCTION reject_job_status_history_update();
+
+				INSERT INTO job_status_history (job_id, from_status, to_status, actor_id, actor_role, reason, created_at)
+				SELECT id, '', status, NULL, 'system', 'Recorded when status history was introduced', COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+				FROM jobs;
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS job_status_history;
+				DROP FUNCTION IF EXISTS reject_job_status_history_update();
+				ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+			`,
+		},
+		{
+			Version: 20240101000022,
+			Name:    "add_job_deadline_tracking",
+			Description: "Track application deadlines and the reminders the deadline scheduler sends",
+			UpSQL: `
+				ALTER TABLE jobs
+				ADD COLUMN IF NOT EXISTS application_deadline TIMESTAMP,
+				ADD COLUMN IF NOT EXISTS applications_reminded_at TIMESTAMP,
+				ADD COLUMN IF NOT EXISTS review_reminded_at TIMESTAMP;
+				UPDATE jobs SET application_deadline = expires_at
+				WHERE application_deadline IS NULL AND expires_at IS NOT NULL;
+
+				CREATE INDEX IF NOT EXISTS idx_jobs_posted_deadline ON jobs(application_deadline)
+					WHERE status = 'posted' AND application_deadline IS NOT NULL;
+				CREATE INDEX IF NOT EXISTS idx_jobs_draft_updated ON jobs(updated_at)
+					WHERE status = 'draft';
+				CREATE INDEX IF NOT EXISTS idx_jobs_review_due ON jobs(review_due_date)
+					WHERE status = 'review_pending';
+				CREATE INDEX IF NOT EXISTS idx_applications_job_status ON applications(job_id, status, applied_at);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_applications_job_status;
+				DROP INDEX IF EXISTS idx_jobs_review_due;
+				DROP INDEX IF EXISTS idx_jobs_draft_updated;
+				DROP INDEX IF EXISTS idx_jobs_posted_deadline;
+				ALTER TABLE jobs
+				DROP COLUMN IF EXISTS review_reminded_at,
+				DROP COLUMN IF EXISTS applications_reminded_at;
+			`,
+		},
+		{
+			Version: 20240101000023,
+			Name:    "add_job_search_vector",
+			Description: "Full-text job search over a weighted title, skills and description vector",
+			UpSQL: `
+				ALTER TABLE jobs
+				ADD COLUMN IF NOT EXISTS skills JSONB NOT NULL DEFAULT '[]',
+				ADD COLUMN IF NOT EXISTS category VARCHAR(100),
+				ADD COLUMN IF NOT EXISTS job_type VARCHAR(50);
+
+				-- Title ranks highest, then skill names, then the description; category
+				-- and location still match but count least
+				ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+					setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+					setweight(jsonb_to_tsvector('english', jsonb_path_query_array(COALESCE(skills, '[]'::jsonb), '$[*].name'), '["string"]'), 'B') ||
+					setweight(to_tsvector('english', COALESCE(description, '')), 'C') ||
+					setweight(to_tsvector('english', COALESCE(category, '') || ' ' || COALESCE(location, '')), 'D')
+				) STORED;
+				CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON jobs USING GIN (search_vector);
+			`,
+			DownSQL: `
+				DROP INDEX IF EXISTS idx_jobs_search_vector;
+				ALTER TABLE jobs DROP COLUMN IF EXISTS search_vector;
+			`,
+		},
+		{
+			Version: 20240101000024,
+			Name:    "create_ai_drift_references",
+			Description: "Persist drift reference samples so restarts keep their baseline",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS ai_drift_references (
+					metric VARCHAR(255) PRIMARY KEY,
+					samples JSONB NOT NULL DEFAULT '[]',
+					created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS ai_drift_references;
+			`,
+		},
+		{
+			Version: 20240101000025,
+			Name:    "add_ai_model_artifact_weights",
+			Description: "Store trained model weights with their artifact so restarts serve the promoted model",
+			UpSQL: `
+				ALTER TABLE ai_model_artifacts ADD COLUMN IF NOT EXISTS weights BYTEA;
+			`,
+			DownSQL: `
+				ALTER TABLE ai_model_artifacts DROP COLUMN IF EXISTS weights;
+			`,
+		},
+		{
+			Version: 20240101000026,
+			Name:    "create_ai_bandit_decisions",
+			Description: "Log exploration decisions with their propensity and reward for offline policy evaluation",
+			UpSQL: `
+				CREATE TABLE IF NOT EXISTS ai_bandit_decisions (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					job_id UUID NOT NULL,
+					policy VARCHAR(50) NOT NULL,
+					slot INTEGER NOT NULL,
+					candidate_count INTEGER NOT NULL,
+					propensity DOUBLE PRECISION NOT NULL,
+					score DOUBLE PRECISION NOT NULL,
+					context JSONB NOT NULL DEFAULT '[]',
+					reward DOUBLE PRECISION NOT NULL DEFAULT 0,
+					reward_event VARCHAR(20),
+					model_version VARCHAR(50),
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					rewarded_at TIMESTAMP
+				);
+				CREATE INDEX IF NOT EXISTS idx_ai_bandit_decisions_created ON ai_bandit_decisions(created_at);
+				CREATE INDEX IF NOT EXISTS idx_ai_bandit_decisions_user ON ai_bandit_decisions(user_id);
+			`,
+			DownSQL: `
+				DROP TABLE IF EXISTS ai_bandit_decisions;
+			`,
+		},
 	}
 }
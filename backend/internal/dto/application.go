@@ -2,12 +2,28 @@ package dto
 
 import (
 	"time"
+
+	"microbridge/backend/internal/models"
 )
 
 type CreateApplicationRequest struct {
 	JobID       string `json:"job_id" validate:"required"`
 	CoverLetter string `json:"cover_letter" validate:"required,min=10,max=2000"`
 	CustomResume string `json:"custom_resume,omitempty"`
+	ScreeningAnswers models.ScreeningAnswers `json:"screening_answers,omitempty"`
+	Draft       bool   `json:"draft,omitempty"` // Save without submitting
+}
+
+// SubmitDraftRequest optionally replaces a draft's screening answers as it is submitted
+type SubmitDraftRequest struct {
+	ScreeningAnswers models.ScreeningAnswers `json:"screening_answers,omitempty"`
+}
+
+// JobApplicationFilters narrows the applications an employer lists for a job
+type JobApplicationFilters struct {
+	Status  string            `json:"status"`
+	Flagged *bool             `json:"flagged"`
+	Answers map[string]string `json:"answers"` // Screening question ID to the exact answer
 }
 
 type UpdateApplicationRequest struct {
@@ -26,15 +42,20 @@ type ApplicationResponse struct {
 	Status            string     `json:"status"`
 	CoverLetter       string     `json:"cover_letter"`
 	CustomResume      string     `json:"custom_resume,omitempty"`
+	ResumeFileID      *string    `json:"resume_file_id,omitempty"`
+	CoverLetterFileID *string    `json:"cover_letter_file_id,omitempty"`
 	MatchScore        float64    `json:"match_score"`
 	AppliedAt         time.Time  `json:"applied_at"`
 	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
 	ResponseAt        *time.Time `json:"response_at,omitempty"`
+	ShortlistedAt     *time.Time `json:"shortlisted_at,omitempty"`
 	EmployerFeedback  string     `json:"employer_feedback,omitempty"`
 	CandidateFeedback string     `json:"candidate_feedback,omitempty"`
 	InternalNotes     string     `json:"internal_notes,omitempty"`
 	InterviewScheduled *time.Time `json:"interview_scheduled,omitempty"`
 	InterviewNotes    string     `json:"interview_notes,omitempty"`
+	ScreeningAnswers  models.ScreeningAnswers `json:"screening_answers,omitempty"`
+	ScreeningFlagged  bool       `json:"screening_flagged,omitempty"`
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
 	
@@ -101,3 +122,52 @@ type ApplicationFilters struct {
 	MinScore   float64 `json:"min_score,omitempty"`
 	MaxScore   float64 `json:"max_score,omitempty"`
 }
+
+// ApplicationStatusChangeResponse is one entry of an application's timeline
+type ApplicationStatusChangeResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    *string   `json:"actor_id,omitempty"`
+	ActorRole  string    `json:"actor_role"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ApplicationTimelineResponse is an application's status history, oldest first,
+// with the statuses the requester can move it to next
+type ApplicationTimelineResponse struct {
+	ApplicationID string                            `json:"application_id"`
+	Status        string                            `json:"status"`
+	NextStatuses  []string                          `json:"next_statuses"`
+	History       []ApplicationStatusChangeResponse `json:"history"`
+}
+
+// BulkApplicationActionRequest applies one action to many of a job's applications.
+// FeedbackTemplate is sent to rejected applicants and may use {{applicant_name}},
+// {{first_name}}, {{job_title}} and {{company}}.
+type BulkApplicationActionRequest struct {
+	Action           string   `json:"action" binding:"required,oneof=reject review shortlist"`
+	ApplicationIDs   []string `json:"application_ids" binding:"required,min=1,max=1000,dive,required"`
+	FeedbackTemplate string   `json:"feedback_template,omitempty" binding:"max=2000"`
+	Reason           string   `json:"reason,omitempty" binding:"max=500"`
+}
+
+// ExportApplicationsRequest selects the applications to export; empty exports all of them
+type ExportApplicationsRequest struct {
+	ApplicationIDs []string `json:"application_ids,omitempty" binding:"max=1000"`
+}
+
+// BulkApplicationResponse reports a bulk action. Large sets are queued and
+// report a pending status and an operation ID to poll instead of results.
+type BulkApplicationResponse struct {
+	OperationID string                  `json:"operation_id,omitempty"`
+	Action      string                  `json:"action"`
+	Status      string                  `json:"status"`
+	Total       int                     `json:"total"`
+	Succeeded   int                     `json:"succeeded"`
+	Failed      int                     `json:"failed"`
+	Results     []models.BulkItemResult `json:"results"`
+	Error       string                  `json:"error,omitempty"`
+	CreatedAt   *time.Time              `json:"created_at,omitempty"`
+	CompletedAt *time.Time              `json:"completed_at,omitempty"`
+}
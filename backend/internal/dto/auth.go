@@ -12,6 +12,9 @@ type UserRegistrationRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Password string `json:"password" binding:"required,min=8"`
 	UserType string `json:"user_type" binding:"required,oneof=student employer"`
+
+	// AcquisitionSource attributes the signup, typically the landing page's utm_source
+	AcquisitionSource string `json:"acquisition_source,omitempty"`
 }
 
 type LoginRequest struct {
@@ -70,6 +73,8 @@ type UserResponse struct {
 	Portfolio       string                `json:"portfolio"`
 	PreferredSalary string                `json:"preferred_salary"`
 	WorkPreference  string                `json:"work_preference"`
+	ResumeFileID    *string               `json:"resume_file_id,omitempty"`
+	LogoFileID      *string               `json:"logo_file_id,omitempty"`
 	Level           int                   `json:"level"`
 	XP              int                   `json:"xp"`
 	CareerCoins     int                   `json:"career_coins"`
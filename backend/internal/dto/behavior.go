@@ -3,6 +3,7 @@ package dto
 import (
 	"errors"
 	"strings"
+	"time"
 )
 
 // Behavior tracking request DTOs
@@ -147,6 +148,21 @@ func (r *FeedbackRequest) Validate() error {
 	return nil
 }
 
+// BehaviorEventRequest is one event of a batched tracking request. The client
+// generates IdempotencyKey once per event and reuses it on retries.
+type BehaviorEventRequest struct {
+	IdempotencyKey string                 `json:"idempotency_key" binding:"required"`
+	Type           string                 `json:"type" binding:"required"` // "impression", "view", "apply", "save", "dismiss", "search", "skill_interest"
+	EntityID       string                 `json:"entity_id,omitempty"`     // Job ID or skill name
+	Data           map[string]interface{} `json:"data,omitempty"`
+	SessionID      string                 `json:"session_id,omitempty"`
+	OccurredAt     time.Time              `json:"occurred_at,omitempty"`
+}
+
+type TrackBehaviorEventsRequest struct {
+	Events []BehaviorEventRequest `json:"events" binding:"required"`
+}
+
 // Response DTOs
 
 type TrackingResponse struct {
@@ -154,6 +170,22 @@ type TrackingResponse struct {
 	Message string `json:"message"`
 }
 
+// TrackBehaviorEventsResponse reports the outcome of a batched tracking request.
+// Shed events should be resent with the whole batch after RetryAfterSeconds.
+type TrackBehaviorEventsResponse struct {
+	Accepted          int                      `json:"accepted"`
+	Duplicates        int                      `json:"duplicates"`
+	Shed              int                      `json:"shed"`
+	Rejected          []RejectedBehaviorEvent  `json:"rejected,omitempty"`
+	RetryAfterSeconds int                      `json:"retry_after_seconds,omitempty"`
+}
+
+type RejectedBehaviorEvent struct {
+	Index          int    `json:"index"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	Reason         string `json:"reason"`
+}
+
 type BehaviorContextResponse struct {
 	UserID               string                    `json:"user_id"`
 	RecentViews          []string                  `json:"recent_views"`
@@ -271,6 +303,61 @@ type RecommendationImpactResponse struct {
 	PersonalizationLevel  string  `json:"personalization_level"` // "high", "medium", "low"
 }
 
+// Cohort analytics DTOs
+
+type CohortReportResponse struct {
+	GroupBy       string           `json:"group_by,omitempty"` // "user_type", "acquisition_source" or empty
+	Cohorts       []CohortResponse `json:"cohorts"`
+	Withheld      int              `json:"withheld"` // Cohorts below the minimum reportable size
+	LastRefreshed string           `json:"last_refreshed,omitempty"`
+}
+
+type CohortResponse struct {
+	CohortWeek          string    `json:"cohort_week"` // Monday of the signup week
+	Group               string    `json:"group,omitempty"`
+	Size                int       `json:"size"`
+	Retention           []float64 `json:"retention"` // Share active in each week since signup, week 0 first
+	SuccessRate         float64   `json:"success_rate"` // Share with an accepted application
+	AvgDaysToSuccess    float64   `json:"avg_days_to_success"`
+	ActionsPerUser      float64   `json:"actions_per_user"`
+	ApplicationsPerUser float64   `json:"applications_per_user"`
+}
+
+// Conversion funnel DTOs
+
+type FunnelResponse struct {
+	Key               string                `json:"key,omitempty"` // Job ID, employer ID or category
+	Stages            []FunnelStageResponse `json:"stages"`
+	OverallConversion float64               `json:"overall_conversion"` // Completions per impression
+}
+
+type FunnelStageResponse struct {
+	Name                      string  `json:"name"` // "impression", "view", "save", "apply", "interview", "accept", "complete"
+	Count                     int64   `json:"count"`
+	ConversionRate            float64 `json:"conversion_rate"` // From the previous stage; applications convert from views
+	MedianSecondsFromPrevious float64 `json:"median_seconds_from_previous"`
+}
+
+type EmployerFunnelResponse struct {
+	From    string           `json:"from"`
+	To      string           `json:"to"`
+	Overall FunnelResponse   `json:"overall"`
+	Jobs    []FunnelResponse `json:"jobs"`
+}
+
+type FunnelComparisonResponse struct {
+	Scope    string                  `json:"scope"` // "category", "employer", "job" or "platform"
+	From     string                  `json:"from"`
+	To       string                  `json:"to"`
+	Baseline FunnelResponse          `json:"baseline"` // Platform-wide funnel over the same window
+	Funnels  []FunnelComparisonEntry `json:"funnels"`
+}
+
+type FunnelComparisonEntry struct {
+	FunnelResponse
+	ConversionDeltas map[string]float64 `json:"conversion_deltas"` // Stage conversion rate minus the baseline's
+}
+
 // Enhanced recommendation DTOs
 
 type EnhancedRecommendationResponse struct {
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// FileResponse describes an uploaded file with a short-lived download link
+type FileResponse struct {
+	ID           string    `json:"id"`
+	Purpose      string    `json:"purpose"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	SHA256       string    `json:"sha256"`
+	URL          string    `json:"url"`
+	URLExpiresAt time.Time `json:"url_expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
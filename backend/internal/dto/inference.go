@@ -0,0 +1,20 @@
+package dto
+
+// UserJobPair identifies a single user-job pair to score
+type UserJobPair struct {
+	UserID string `json:"user_id" binding:"required"`
+	JobID  string `json:"job_id" binding:"required"`
+}
+
+// BatchInferenceRequest represents a request to score many user-job pairs.
+// Either Pairs or both UserIDs and JobIDs (scored as a cross product) must be set.
+type BatchInferenceRequest struct {
+	Pairs            []UserJobPair `json:"pairs,omitempty" binding:"omitempty,dive"`
+	UserIDs          []string      `json:"user_ids,omitempty" binding:"omitempty,dive,required"`
+	JobIDs           []string      `json:"job_ids,omitempty" binding:"omitempty,dive,required"`
+	ModelType        string        `json:"model_type,omitempty" binding:"omitempty,oneof=hybrid basic ncf gnn"`
+	TimeoutSeconds   int           `json:"timeout_seconds,omitempty" binding:"omitempty,min=1,max=3600"`
+	ParallelWorkers  int           `json:"parallel_workers,omitempty" binding:"omitempty,min=1,max=64"`
+	RetryFailedItems bool          `json:"retry_failed_items"`
+	MaxRetries       int           `json:"max_retries,omitempty" binding:"omitempty,min=0,max=5"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+// ProposeInterviewRequest offers the applicant one or more interview slots
+type ProposeInterviewRequest struct {
+	Slots      []models.InterviewSlot `json:"slots" binding:"required,min=1"`
+	Location   string                 `json:"location,omitempty" binding:"max=255"`
+	MeetingURL string                 `json:"meeting_url,omitempty" binding:"omitempty,url,max=500"`
+	Notes      string                 `json:"notes,omitempty"`
+}
+
+// SelectInterviewSlotRequest picks one of the proposed slots by its start time
+type SelectInterviewSlotRequest struct {
+	Start time.Time `json:"start" binding:"required"`
+}
+
+// RescheduleInterviewRequest moves an interview. Employers send new slots; students
+// send only a reason and the employer is asked to propose again.
+type RescheduleInterviewRequest struct {
+	Slots  []models.InterviewSlot `json:"slots,omitempty"`
+	Reason string                 `json:"reason,omitempty" binding:"max=1000"`
+}
+
+// CancelInterviewRequest cancels an interview
+type CancelInterviewRequest struct {
+	Reason string `json:"reason,omitempty" binding:"max=1000"`
+}
+
+// RejectedInterviewSlot is a proposed slot that was dropped before reaching the applicant
+type RejectedInterviewSlot struct {
+	Slot   models.InterviewSlot `json:"slot"`
+	Reason string               `json:"reason"` // "outside_availability" | "conflict"
+}
+
+// InterviewResponse represents an interview
+type InterviewResponse struct {
+	ID             string                 `json:"id"`
+	ApplicationID  string                 `json:"application_id"`
+	JobID          string                 `json:"job_id"`
+	EmployerID     string                 `json:"employer_id"`
+	StudentID      string                 `json:"student_id"`
+	Status         string                 `json:"status"`
+	ProposedSlots  []models.InterviewSlot `json:"proposed_slots"`
+	ScheduledStart *time.Time             `json:"scheduled_start,omitempty"`
+	ScheduledEnd   *time.Time             `json:"scheduled_end,omitempty"`
+	Location       string                 `json:"location,omitempty"`
+	MeetingURL     string                 `json:"meeting_url,omitempty"`
+	Notes          string                 `json:"notes,omitempty"`
+	Sequence       int                    `json:"sequence"`
+	CancelReason   string                 `json:"cancel_reason,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+
+	// Set when proposing or rescheduling
+	RejectedSlots []RejectedInterviewSlot `json:"rejected_slots,omitempty"`
+}
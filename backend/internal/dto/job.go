@@ -10,7 +10,7 @@ type CreateJobRequest struct {
 	Title               string                     `json:"title" validate:"required,min=3,max=100"`
 	Description         string                     `json:"description" validate:"required,min=10"`
 	Company             string                     `json:"company"`
-	Skills              []SkillRequest             `json:"skills" validate:"required,min=1"`
+	Skills              []SkillRequest             `json:"skills"` // Suggested from the description when empty
 	ExperienceLevel     string                     `json:"experience_level" validate:"oneof=entry intermediate advanced senior expert"`
 	Location            string                     `json:"location"`
 	Duration            int                        `json:"duration"`
@@ -22,6 +22,7 @@ type CreateJobRequest struct {
 	Requirements        models.StringArray         `json:"requirements"`
 	WorkArrangement     models.WorkArrangement     `json:"work_arrangement"`
 	PreferredCandidates models.CandidatePreferences `json:"preferred_candidates"`
+	ScreeningQuestions  models.ScreeningQuestions  `json:"screening_questions"`
 	ApplicationDeadline *time.Time                 `json:"application_deadline,omitempty"`
 	StartDate           *time.Time                 `json:"start_date,omitempty"`
 	EndDate             *time.Time                 `json:"end_date,omitempty"`
@@ -44,6 +45,7 @@ type UpdateJobRequest struct {
 	Requirements        models.StringArray          `json:"requirements,omitempty"`
 	WorkArrangement     *models.WorkArrangement     `json:"work_arrangement,omitempty"`
 	PreferredCandidates *models.CandidatePreferences `json:"preferred_candidates,omitempty"`
+	ScreeningQuestions  *models.ScreeningQuestions  `json:"screening_questions,omitempty"` // An empty list removes every question
 	ApplicationDeadline *time.Time                  `json:"application_deadline,omitempty"`
 	StartDate           *time.Time                  `json:"start_date,omitempty"`
 	EndDate             *time.Time                  `json:"end_date,omitempty"`
@@ -70,6 +72,15 @@ type SkillRequest struct {
 	CanLearn   bool    `json:"can_learn"`
 }
 
+// SuggestJobSkillsRequest is a draft posting to extract required skills from before saving
+type SuggestJobSkillsRequest struct {
+	Title           string             `json:"title"`
+	Description     string             `json:"description" validate:"required,min=10,max=20000"`
+	Requirements    models.StringArray `json:"requirements"`
+	ExperienceLevel string             `json:"experience_level,omitempty"`
+	Skills          []SkillRequest     `json:"skills,omitempty"` // Skills already chosen, flagged in the suggestions
+}
+
 // SkillResponse represents a skill in responses
 type SkillResponse struct {
 	Name       string  `json:"name"`
@@ -102,6 +113,7 @@ type JobResponse struct {
 	StartDate           *time.Time                 `json:"start_date,omitempty"`
 	EndDate             *time.Time                 `json:"end_date,omitempty"`
 	PreferredCandidates models.CandidatePreferences `json:"preferred_candidates"`
+	ScreeningQuestions  models.ScreeningQuestions  `json:"screening_questions"` // Knockout answers are shown to the job's employer only
 	Status              string                     `json:"status"`
 	Views               int                        `json:"views"`
 	Applications        int                        `json:"applications"`
@@ -110,6 +122,13 @@ type JobResponse struct {
 	HiredStudentID      *string                    `json:"hired_student_id,omitempty"`
 	CreatedAt           time.Time                  `json:"created_at"`
 	UpdatedAt           time.Time                  `json:"updated_at"`
+
+	// Set on create and update only: skills found in the description that the job
+	// does not list yet, and whether the listed skills were filled in from it
+	// (only ever on create; updates leave the employer's skills as sent)
+	SkillSuggestions         []SkillResponse `json:"skill_suggestions,omitempty"`
+	SkillsSuggested          bool            `json:"skills_suggested,omitempty"`
+	SuggestedExperienceLevel string          `json:"suggested_experience_level,omitempty"`
 }
 
 // PaginatedJobResponse represents a paginated list of jobs
@@ -118,6 +137,38 @@ type PaginatedJobResponse struct {
 	Pagination PaginationResponse  `json:"pagination"`
 }
 
+// JobSearchResponse is a page of search results with facet counts over all of them
+type JobSearchResponse struct {
+	Jobs       []*JobSearchResult `json:"jobs"`
+	Pagination PaginationResponse `json:"pagination"`
+	Facets     JobSearchFacets    `json:"facets"`
+}
+
+// JobSearchResult is a job found by a search. Score orders the results: the text
+// relevance, blended with the match score when a student searches.
+type JobSearchResult struct {
+	*JobResponse
+	Relevance      float64  `json:"relevance"`
+	MatchScore     *float64 `json:"match_score,omitempty"` // Students only
+	Score          float64  `json:"score"`
+	TitleHighlight string   `json:"title_highlight,omitempty"` // HTML-escaped, matches wrapped in <mark>
+	Snippet        string   `json:"snippet,omitempty"`         // HTML-escaped, matches wrapped in <mark>
+}
+
+// JobSearchFacets count the matching jobs by value, most common first
+type JobSearchFacets struct {
+	Category        []FacetCount `json:"category"`
+	JobType         []FacetCount `json:"job_type"`
+	ExperienceLevel []FacetCount `json:"experience_level"`
+	Remote          []FacetCount `json:"remote"`
+}
+
+// FacetCount is how many matching jobs have a facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
 // JobRecommendation represents a job recommendation
 type JobRecommendation struct {
 	JobID       string    `json:"job_id"`
@@ -130,4 +181,35 @@ type JobRecommendation struct {
 	MatchScore  float64   `json:"match_score"`
 	Reasons     []string  `json:"reasons"`
 	CreatedAt   time.Time `json:"created_at"`
-}
\ No newline at end of file
+}
+// JobTransitionRequest moves a job along its lifecycle
+type JobTransitionRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason,omitempty" binding:"max=500"`
+}
+
+// JobStatusOverrideRequest forces a job into any status, for administrators
+type JobStatusOverrideRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// JobStatusChangeResponse is one entry of a job's timeline
+type JobStatusChangeResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    *string   `json:"actor_id,omitempty"`
+	ActorRole  string    `json:"actor_role"`
+	Reason     string    `json:"reason,omitempty"`
+	Override   bool      `json:"override,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// JobTimelineResponse is a job's status history, oldest first, with the
+// statuses the requester can move it to next
+type JobTimelineResponse struct {
+	JobID        string                    `json:"job_id"`
+	Status       string                    `json:"status"`
+	NextStatuses []string                  `json:"next_statuses"`
+	History      []JobStatusChangeResponse `json:"history"`
+}
@@ -21,4 +21,14 @@ type NotificationSettings struct {
 	PaymentNotifications bool `json:"payment_notifications"`
 	DeadlineReminders bool `json:"deadline_reminders"`
 	SystemAnnouncements bool `json:"system_announcements"`
-}
\ No newline at end of file
+}
+// SendNotificationRequest represents a notification sent to a user by an administrator
+type SendNotificationRequest struct {
+	UserID     string                 `json:"user_id" binding:"required"`
+	Title      string                 `json:"title" binding:"required,max=255"`
+	Message    string                 `json:"message" binding:"required"`
+	Type       string                 `json:"type" binding:"required,oneof=info success warning error"`
+	ActionURL  *string                `json:"action_url,omitempty"`
+	ActionText *string                `json:"action_text,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"microbridge/backend/internal/models"
+)
+
+// CreateOfferRequest offers an applicant the job. Pay defaults to the top of the
+// job's salary range and the dates to the job's; the currency and period only
+// apply when the job's salary doesn't set them.
+type CreateOfferRequest struct {
+	Terms     string     `json:"terms,omitempty" binding:"max=5000"`
+	PayAmount *int       `json:"pay_amount,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+	Period    string     `json:"period,omitempty"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// UpdateOfferRequest revises an open offer, typically in answer to a counter.
+// Fields left out keep their value; a new expiry defaults to a fresh one.
+type UpdateOfferRequest struct {
+	Terms     *string    `json:"terms,omitempty" binding:"omitempty,max=5000"`
+	PayAmount *int       `json:"pay_amount,omitempty"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CounterOfferRequest proposes other terms back to the employer
+type CounterOfferRequest struct {
+	PayAmount *int       `json:"pay_amount,omitempty"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Message   string     `json:"message,omitempty" binding:"max=2000"`
+}
+
+// OfferReasonRequest declines or withdraws an offer
+type OfferReasonRequest struct {
+	Reason string `json:"reason,omitempty" binding:"max=1000"`
+}
+
+// OfferResponse represents an offer
+type OfferResponse struct {
+	ID            string               `json:"id"`
+	ApplicationID string               `json:"application_id"`
+	JobID         string               `json:"job_id"`
+	EmployerID    string               `json:"employer_id"`
+	StudentID     string               `json:"student_id"`
+	Status        string               `json:"status"`
+	Terms         string               `json:"terms,omitempty"`
+	Pay           models.OfferPay      `json:"pay"`
+	StartDate     *time.Time           `json:"start_date,omitempty"`
+	EndDate       *time.Time           `json:"end_date,omitempty"`
+	ExpiresAt     time.Time            `json:"expires_at"`
+	Revision      int                  `json:"revision"`
+	Counter       *models.OfferCounter `json:"counter,omitempty"`
+	Reason        string               `json:"reason,omitempty"`
+	RespondedAt   *time.Time           `json:"responded_at,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+
+	// Set when the offer is accepted
+	ClosedApplications int `json:"closed_applications,omitempty"`
+	WithdrawnOffers    int `json:"withdrawn_offers,omitempty"`
+}
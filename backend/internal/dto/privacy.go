@@ -0,0 +1,12 @@
+package dto
+
+// Data subject request DTOs
+
+type DataSubjectRequestResponse struct {
+	ID           string           `json:"id"`
+	UserID       string           `json:"user_id"`
+	Type         string           `json:"type"` // "export" or "erasure"
+	RequestedBy  string           `json:"requested_by"`
+	AffectedRows map[string]int64 `json:"affected_rows"` // Rows exported, deleted or anonymized per table
+	CreatedAt    string           `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// ParseResumeRequest carries pasted resume text. PDFs are uploaded as multipart files instead.
+type ParseResumeRequest struct {
+	Content string `json:"content" validate:"required,max=200000"`
+	Format  string `json:"format,omitempty" validate:"omitempty,oneof=text markdown"`
+}
+
+// ConfirmedSkill is a proposed resume skill accepted by the student, possibly edited
+type ConfirmedSkill struct {
+	Name       string `json:"name" validate:"required,max=100"`
+	Level      int    `json:"level" validate:"required,min=1,max=5"`
+	Experience string `json:"experience,omitempty" validate:"omitempty,max=50"`
+}
+
+// ConfirmResumeSkillsRequest saves confirmed skills to the profile
+type ConfirmResumeSkillsRequest struct {
+	Skills     []ConfirmedSkill `json:"skills" validate:"required,min=1,max=50,dive"`
+	ResumeText string           `json:"resume_text,omitempty" validate:"omitempty,max=200000"`
+}
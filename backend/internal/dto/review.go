@@ -12,6 +12,14 @@ type CreateReviewRequest struct {
 	Rating          int               `json:"rating" binding:"required,min=1,max=5"`
 	Comment         string            `json:"comment"`
 	CategoryRatings CategoryRatings   `json:"category_ratings"`
+	Anonymous       bool              `json:"anonymous"`
+}
+
+// UpdateReviewRequest represents an edit to a review before it becomes visible
+type UpdateReviewRequest struct {
+	Rating          int               `json:"rating" binding:"required,min=1,max=5"`
+	Comment         string            `json:"comment"`
+	CategoryRatings CategoryRatings   `json:"category_ratings"`
 }
 
 // CategoryRatings DTO for review categories
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// StartTrainingRequest represents a request to queue a model training run
+type StartTrainingRequest struct {
+	ModelType       string    `json:"model_type" binding:"required,oneof=ncf gnn rl"`
+	MaxEpochs       int       `json:"max_epochs,omitempty" binding:"omitempty,min=1,max=1000"`
+	BatchSize       int       `json:"batch_size,omitempty" binding:"omitempty,min=1,max=4096"`
+	LearningRate    float64   `json:"learning_rate,omitempty" binding:"omitempty,gt=0,lt=1"`
+	ValidationSplit float64   `json:"validation_split,omitempty" binding:"omitempty,gt=0,lt=1"`
+	DatasetSince    time.Time `json:"dataset_since,omitempty"`
+	DatasetLimit    int       `json:"dataset_limit,omitempty" binding:"omitempty,min=1,max=1000000"`
+}
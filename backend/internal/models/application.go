@@ -16,6 +16,12 @@ type Application struct {
     Status          string              `json:"status"`          // "draft" | "submitted" | "reviewed" | "interviewed" | "accepted" | "rejected"
     CoverLetter     string              `json:"cover_letter"`
     CustomResume    string              `json:"custom_resume"`
+    ResumeFileID    *string             `json:"resume_file_id,omitempty"`       // Uploaded resume for this application
+    CoverLetterFileID *string           `json:"cover_letter_file_id,omitempty"` // Uploaded cover letter
+    
+    // Screening answers; flagged when an answer matched a knockout that flags
+    ScreeningAnswers ScreeningAnswers   `json:"screening_answers" gorm:"type:jsonb"`
+    ScreeningFlagged bool               `json:"screening_flagged"`
     
     // Enhanced matching data
     MatchScore      float64             `json:"match_score"`
@@ -25,6 +31,7 @@ type Application struct {
     AppliedAt       time.Time           `json:"applied_at"`
     ReviewedAt      *time.Time          `json:"reviewed_at,omitempty"`
     ResponseAt      *time.Time          `json:"response_at,omitempty"`
+    ShortlistedAt   *time.Time          `json:"shortlisted_at,omitempty"`
     
     // Feedback and notes
     EmployerFeedback    string          `json:"employer_feedback"`
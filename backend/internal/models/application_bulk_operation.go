@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Bulk operation statuses
+const (
+	BulkOperationPending   = "pending"
+	BulkOperationRunning   = "running"
+	BulkOperationCompleted = "completed"
+	BulkOperationFailed    = "failed"
+)
+
+// ApplicationBulkOperation is a bulk action on a job's applications that is
+// too large to apply within a request; a background worker processes it
+type ApplicationBulkOperation struct {
+	ID               string          `json:"id" gorm:"primaryKey;default:gen_random_uuid()"`
+	JobID            string          `json:"job_id" gorm:"index"`
+	EmployerID       string          `json:"employer_id"`
+	Action           string          `json:"action"` // "reject" | "review" | "shortlist"
+	ApplicationIDs   StringArray     `json:"application_ids" gorm:"type:jsonb"`
+	FeedbackTemplate string          `json:"feedback_template,omitempty"`
+	Reason           string          `json:"reason,omitempty"`
+	Status           string          `json:"status"`
+	Total            int             `json:"total"`
+	Succeeded        int             `json:"succeeded"`
+	Failed           int             `json:"failed"`
+	Results          BulkItemResults `json:"results" gorm:"type:jsonb"`
+	Error            string          `json:"error,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	StartedAt        *time.Time      `json:"started_at,omitempty"`
+	CompletedAt      *time.Time      `json:"completed_at,omitempty"`
+}
+
+// BulkItemResult is the outcome of a bulk action on one application
+type BulkItemResult struct {
+	ApplicationID string `json:"application_id"`
+	Success       bool   `json:"success"`
+	Status        string `json:"status,omitempty"` // The application's status afterwards
+	Error         string `json:"error,omitempty"`
+}
+
+type BulkItemResults []BulkItemResult
+
+func (r BulkItemResults) Value() (driver.Value, error) {
+	if r == nil {
+		return json.Marshal([]BulkItemResult{})
+	}
+	return json.Marshal(r)
+}
+
+func (r *BulkItemResults) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("cannot scan non-bytes into BulkItemResults")
+	}
+	return json.Unmarshal(bytes, r)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ApplicationStatusHistory is one status change of an application. Rows are
+// append-only; the database rejects updates.
+type ApplicationStatusHistory struct {
+	ID            string    `json:"id" gorm:"primaryKey;default:gen_random_uuid()"`
+	ApplicationID string    `json:"application_id" gorm:"index"`
+	FromStatus    string    `json:"from_status"` // Empty for the first status
+	ToStatus      string    `json:"to_status"`
+	ActorID       *string   `json:"actor_id,omitempty"` // Nil for system changes
+	ActorRole     string    `json:"actor_role"`         // "student" | "employer" | "system"
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (ApplicationStatusHistory) TableName() string {
+	return "application_status_history"
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// What an uploaded file is for; each purpose accepts its own content types
+const (
+	FilePurposeResume      = "resume"
+	FilePurposeCoverLetter = "cover_letter"
+	FilePurposeLogo        = "logo"
+)
+
+// File is an uploaded file. Its bytes live in object storage under Key, which
+// is derived from the content, so identical uploads share one stored object.
+type File struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	OwnerID     string    `json:"owner_id" gorm:"index"`
+	Purpose     string    `json:"purpose"`
+	Filename    string    `json:"filename"` // As uploaded, for display and downloads
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256" gorm:"column:sha256"`
+	Key         string    `json:"-" gorm:"column:storage_key"`
+	CreatedAt   time.Time `json:"created_at"`
+}
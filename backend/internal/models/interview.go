@@ -0,0 +1,76 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Interview statuses
+const (
+	InterviewProposed            = "proposed"             // Employer offered slots; waiting for the student
+	InterviewRescheduleRequested = "reschedule_requested" // Student asked the employer for new slots
+	InterviewScheduled           = "scheduled"
+	InterviewCompleted           = "completed"
+	InterviewCancelled           = "cancelled"
+)
+
+type Interview struct {
+	ID            string         `json:"id" gorm:"primaryKey;default:gen_random_uuid()"`
+	ApplicationID string         `json:"application_id" gorm:"index"`
+	JobID         string         `json:"job_id"`
+	EmployerID    string         `json:"employer_id" gorm:"index"`
+	StudentID     string         `json:"student_id" gorm:"index"`
+	Status        string         `json:"status"`
+	ProposedSlots InterviewSlots `json:"proposed_slots" gorm:"type:jsonb"`
+
+	// Set once the student picks a slot; all times are UTC
+	ScheduledStart *time.Time `json:"scheduled_start,omitempty"`
+	ScheduledEnd   *time.Time `json:"scheduled_end,omitempty"`
+
+	Location   string `json:"location,omitempty"`
+	MeetingURL string `json:"meeting_url,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+
+	// Sequence is the RFC 5545 revision of the invite; it grows on every reschedule or cancellation
+	Sequence     int     `json:"sequence"`
+	CancelledBy  *string `json:"cancelled_by,omitempty"`
+	CancelReason string  `json:"cancel_reason,omitempty"`
+
+	ReminderSentAt *time.Time `json:"reminder_sent_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// InterviewSlot is a time range offered for an interview
+type InterviewSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Overlaps reports whether the two slots share any time
+func (s InterviewSlot) Overlaps(other InterviewSlot) bool {
+	return s.Start.Before(other.End) && other.Start.Before(s.End)
+}
+
+type InterviewSlots []InterviewSlot
+
+func (s InterviewSlots) Value() (driver.Value, error) {
+	if s == nil {
+		return json.Marshal([]InterviewSlot{})
+	}
+	return json.Marshal(s)
+}
+
+func (s *InterviewSlots) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("cannot scan non-bytes into InterviewSlots")
+	}
+	return json.Unmarshal(bytes, s)
+}
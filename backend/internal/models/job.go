@@ -41,6 +41,9 @@ type Job struct {
     // Matching preferences
     PreferredCandidates CandidatePreferences `json:"preferred_candidates" gorm:"type:jsonb"`
     
+    // Asked at apply time
+    ScreeningQuestions ScreeningQuestions `json:"screening_questions" gorm:"type:jsonb"`
+    
     // Status and metadata - Updated lifecycle states
     Status          string              `json:"status"`           // "draft" | "posted" | "hired" | "in_progress" | "submitted" | "review_pending" | "completed" | "disputed" | "archived"
     Views           int                 `json:"views"`
@@ -51,11 +54,23 @@ type Job struct {
     CompletedAt     *time.Time          `json:"completed_at,omitempty"`
     HiredStudentID  *string             `json:"hired_student_id,omitempty"`
     
+    // Stamped by the deadline scheduler when it sends reminders; never saved from the model
+    ApplicationsRemindedAt *time.Time   `json:"-" gorm:"->"`
+    ReviewRemindedAt       *time.Time   `json:"-" gorm:"->"`
+    
     // Timestamps
     CreatedAt       time.Time           `json:"created_at"`
     UpdatedAt       time.Time           `json:"updated_at"`
 }
 
+// PendingApplications is a posted job with applications waiting on its employer
+type PendingApplications struct {
+    JobID      string
+    JobTitle   string
+    EmployerID string
+    Pending    int
+}
+
 // Custom types for Job
 type RequiredSkillsArray []RequiredSkill
 
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// JobStatusHistory is one status change of a job. Rows are append-only; the
+// database rejects updates.
+type JobStatusHistory struct {
+	ID         string    `json:"id" gorm:"primaryKey;default:gen_random_uuid()"`
+	JobID      string    `json:"job_id" gorm:"index"`
+	FromStatus string    `json:"from_status"` // Empty for the first status
+	ToStatus   string    `json:"to_status"`
+	ActorID    *string   `json:"actor_id,omitempty"` // Nil for system changes
+	ActorRole  string    `json:"actor_role"`         // "employer" | "student" | "admin" | "system"
+	Reason     string    `json:"reason,omitempty"`
+	Override   bool      `json:"override"` // An admin bypassed the lifecycle
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (JobStatusHistory) TableName() string {
+	return "job_status_history"
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -16,8 +17,8 @@ const (
 )
 
 type Notification struct {
-	ID          uint             `json:"id" gorm:"primaryKey"`
-	UserID      uint             `json:"user_id" gorm:"not null;index"`
+	ID          string           `json:"id" gorm:"primaryKey;default:gen_random_uuid()"`
+	UserID      string           `json:"user_id" gorm:"not null;index"`
 	Title       string           `json:"title" gorm:"not null"`
 	Message     string           `json:"message" gorm:"not null"`
 	Type        NotificationType `json:"type" gorm:"not null;default:'info'"`
@@ -34,8 +35,8 @@ type Notification struct {
 }
 
 type NotificationSettings struct {
-	ID                    uint   `json:"id" gorm:"primaryKey"`
-	UserID                uint   `json:"user_id" gorm:"not null;uniqueIndex"`
+	ID                    string `json:"id" gorm:"primaryKey;default:gen_random_uuid()"`
+	UserID                string `json:"user_id" gorm:"not null;uniqueIndex"`
 	EmailNotifications    bool   `json:"email_notifications" gorm:"default:true"`
 	PushNotifications     bool   `json:"push_notifications" gorm:"default:true"`
 	JobUpdates            bool   `json:"job_updates" gorm:"default:true"`
@@ -55,8 +56,11 @@ type NotificationSettings struct {
 // JSON type for storing flexible metadata
 type JSON map[string]interface{}
 
-func (j JSON) Value() (interface{}, error) {
-	return j, nil
+func (j JSON) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return json.Marshal(j)
 }
 
 func (j *JSON) Scan(value interface{}) error {
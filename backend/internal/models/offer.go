@@ -0,0 +1,96 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Offer statuses
+const (
+	OfferExtended  = "extended"  // Waiting for the student
+	OfferCountered = "countered" // The student proposed other terms; waiting for the employer
+	OfferAccepted  = "accepted"
+	OfferDeclined  = "declined"
+	OfferWithdrawn = "withdrawn"
+	OfferExpired   = "expired"
+)
+
+type Offer struct {
+	ID            string `json:"id" gorm:"primaryKey;default:gen_random_uuid()"`
+	ApplicationID string `json:"application_id" gorm:"index"`
+	JobID         string `json:"job_id" gorm:"index"`
+	EmployerID    string `json:"employer_id" gorm:"index"`
+	StudentID     string `json:"student_id" gorm:"index"`
+	Status        string `json:"status"`
+
+	Terms     string     `json:"terms"`
+	Pay       OfferPay   `json:"pay" gorm:"type:jsonb"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+
+	// Revision grows every time the employer changes the terms
+	Revision int `json:"revision"`
+	// Counter is the student's latest counter proposal, if any
+	Counter OfferCounter `json:"counter" gorm:"type:jsonb"`
+	// Reason is why the student declined or the employer withdrew the offer
+	Reason string `json:"reason,omitempty"`
+
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// OfferPay is the offered pay; currency and period follow the job's salary range
+type OfferPay struct {
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+	Period   string `json:"period"` // "hourly" | "monthly" | "yearly" | "fixed"
+}
+
+// OfferCounter is what a student proposed instead of the offered terms
+type OfferCounter struct {
+	PayAmount  *int       `json:"pay_amount,omitempty"`
+	StartDate  *time.Time `json:"start_date,omitempty"`
+	EndDate    *time.Time `json:"end_date,omitempty"`
+	Message    string     `json:"message,omitempty"`
+	ProposedAt *time.Time `json:"proposed_at,omitempty"`
+}
+
+// IsEmpty reports whether the student never countered
+func (c OfferCounter) IsEmpty() bool {
+	return c.ProposedAt == nil
+}
+
+func (p OfferPay) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (p *OfferPay) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("cannot scan non-bytes into OfferPay")
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (c OfferCounter) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *OfferCounter) Scan(value interface{}) error {
+	if value == nil {
+		*c = OfferCounter{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("cannot scan non-bytes into OfferCounter")
+	}
+	return json.Unmarshal(bytes, c)
+}
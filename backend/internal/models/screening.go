@@ -0,0 +1,95 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Screening question types
+const (
+	ScreeningYesNo        = "yes_no"
+	ScreeningSingleChoice = "single_choice"
+	ScreeningText         = "text"
+	ScreeningURL          = "url"
+)
+
+// What a knockout answer does to an application
+const (
+	KnockoutReject = "reject" // The application is rejected as soon as it is submitted
+	KnockoutFlag   = "flag"   // The application is kept but flagged for the employer
+)
+
+// ScreeningQuestion is a question a job asks at apply time
+type ScreeningQuestion struct {
+	ID       string   `json:"id"`
+	Prompt   string   `json:"prompt"`
+	Type     string   `json:"type"`              // "yes_no" | "single_choice" | "text" | "url"
+	Options  []string `json:"options,omitempty"` // Choices for single_choice questions
+	Required bool     `json:"required"`
+
+	// Answers that knock an application out; yes_no questions use "yes" and "no".
+	// Only the job's employer sees them.
+	KnockoutAnswers []string `json:"knockout_answers,omitempty"`
+	KnockoutAction  string   `json:"knockout_action,omitempty"` // "reject" | "flag"
+}
+
+type ScreeningQuestions []ScreeningQuestion
+
+// Public returns the questions without their knockout rules, for applicants
+func (q ScreeningQuestions) Public() ScreeningQuestions {
+	public := make(ScreeningQuestions, len(q))
+	for i, question := range q {
+		question.KnockoutAnswers = nil
+		question.KnockoutAction = ""
+		public[i] = question
+	}
+	return public
+}
+
+func (q ScreeningQuestions) Value() (driver.Value, error) {
+	if q == nil {
+		return json.Marshal([]ScreeningQuestion{})
+	}
+	return json.Marshal(q)
+}
+
+func (q *ScreeningQuestions) Scan(value interface{}) error {
+	if value == nil {
+		*q = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("cannot scan non-bytes into ScreeningQuestions")
+	}
+	return json.Unmarshal(bytes, q)
+}
+
+// ScreeningAnswer is an applicant's answer to one screening question
+type ScreeningAnswer struct {
+	QuestionID string `json:"question_id"`
+	Value      string `json:"value"`
+	Knockout   bool   `json:"knockout,omitempty"` // Set when the answer is one of the question's knockout answers
+}
+
+type ScreeningAnswers []ScreeningAnswer
+
+func (a ScreeningAnswers) Value() (driver.Value, error) {
+	if a == nil {
+		return json.Marshal([]ScreeningAnswer{})
+	}
+	return json.Marshal(a)
+}
+
+func (a *ScreeningAnswers) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("cannot scan non-bytes into ScreeningAnswers")
+	}
+	return json.Unmarshal(bytes, a)
+}
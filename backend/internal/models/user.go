@@ -14,6 +14,7 @@ type User struct {
     Name            string          `json:"name"`
     Password        string          `json:"-" gorm:"column:password_hash"` // Hidden from JSON
     UserType        string          `json:"user_type"` // "student" | "employer"
+    AcquisitionSource string        `json:"acquisition_source,omitempty"` // Signup attribution such as utm_source; empty when unknown
     
     // Authentication fields
     EmailVerified   bool            `json:"email_verified" gorm:"default:false"`
@@ -33,6 +34,8 @@ type User struct {
     Bio             string          `json:"bio"`
     Portfolio       string          `json:"portfolio"`
     Resume          string          `json:"resume"`
+    ResumeFileID    *string         `json:"resume_file_id,omitempty"` // Uploaded resume
+    LogoFileID      *string         `json:"logo_file_id,omitempty"`   // Uploaded company logo, for employers
     PreferredSalary string          `json:"preferred_salary"`
     WorkPreference  string          `json:"work_preference"` // "remote" | "onsite" | "hybrid"
     
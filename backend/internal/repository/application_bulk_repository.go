@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+type ApplicationBulkRepository interface {
+	Create(ctx context.Context, operation *models.ApplicationBulkOperation) error
+	GetByID(ctx context.Context, id string) (*models.ApplicationBulkOperation, error)
+	ClaimBulkOperation(ctx context.Context, now, staleBefore time.Time) (*models.ApplicationBulkOperation, error)
+	FinishBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) error
+}
+
+type applicationBulkRepository struct {
+	db *gorm.DB
+}
+
+func NewApplicationBulkRepository(db *gorm.DB) ApplicationBulkRepository {
+	return &applicationBulkRepository{db: db}
+}
+
+func (r *applicationBulkRepository) Create(ctx context.Context, operation *models.ApplicationBulkOperation) error {
+	if err := r.db.WithContext(ctx).Create(operation).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to queue bulk operation", err)
+	}
+	return nil
+}
+
+func (r *applicationBulkRepository) GetByID(ctx context.Context, id string) (*models.ApplicationBulkOperation, error) {
+	var operation models.ApplicationBulkOperation
+	if err := r.db.WithContext(ctx).First(&operation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Bulk operation")
+		}
+		return nil, apperrors.NewAppError(500, "Failed to get bulk operation", err)
+	}
+	return &operation, nil
+}
+
+// ClaimBulkOperation marks the oldest queued operation as running and returns it.
+// SKIP LOCKED lets concurrent workers each claim a different operation.
+func (r *applicationBulkRepository) ClaimBulkOperation(ctx context.Context, now, staleBefore time.Time) (*models.ApplicationBulkOperation, error) {
+	var claimed []models.ApplicationBulkOperation
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE application_bulk_operations
+		SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM application_bulk_operations
+			WHERE status = ? OR (status = ? AND started_at < ?)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.BulkOperationRunning, now,
+		models.BulkOperationPending, models.BulkOperationRunning, staleBefore,
+	).Scan(&claimed).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to claim bulk operation", err)
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+	return &claimed[0], nil
+}
+
+func (r *applicationBulkRepository) FinishBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) error {
+	if err := r.db.WithContext(ctx).
+		Model(&models.ApplicationBulkOperation{}).
+		Where("id = ?", operation.ID).
+		Updates(map[string]interface{}{
+			"status":       operation.Status,
+			"total":        operation.Total,
+			"succeeded":    operation.Succeeded,
+			"failed":       operation.Failed,
+			"results":      operation.Results,
+			"error":        operation.Error,
+			"completed_at": operation.CompletedAt,
+		}).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to finish bulk operation", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/database/migrations"
+	"microbridge/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestApplicationBulkRepository_ClaimBulkOperation(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`ALTER TABLE jobs ALTER COLUMN id TYPE UUID USING id::uuid;`).Error; err != nil {
+		t.Fatalf("Failed to adapt the jobs fixture: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "create_application_bulk_operations" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	employerID, jobID := uuid.New().String(), uuid.New().String()
+	seedFixtures(t, db,
+		fixture{"INSERT INTO users (id, user_type) VALUES (?, 'employer')", []interface{}{employerID}},
+		fixture{"INSERT INTO jobs (id, employer_id, status) VALUES (?, ?, 'active')", []interface{}{jobID, employerID}},
+	)
+
+	repo := NewApplicationBulkRepository(db)
+	queue := func(createdAt time.Time) *models.ApplicationBulkOperation {
+		operation := &models.ApplicationBulkOperation{
+			JobID: jobID, EmployerID: employerID, Action: "reject",
+			ApplicationIDs: models.StringArray{uuid.New().String()},
+			Status:         models.BulkOperationPending, CreatedAt: createdAt,
+		}
+		if err := repo.Create(ctx, operation); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		return operation
+	}
+	first := queue(now.Add(-2 * time.Minute))
+	second := queue(now.Add(-time.Minute))
+
+	claimed, err := repo.ClaimBulkOperation(ctx, now, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ClaimBulkOperation failed: %v", err)
+	}
+	if claimed == nil || claimed.ID != first.ID || claimed.Status != models.BulkOperationRunning {
+		t.Fatalf("Expected the oldest operation to be claimed, got %+v", claimed)
+	}
+
+	claimed, err = repo.ClaimBulkOperation(ctx, now, now.Add(-time.Hour))
+	if err != nil || claimed == nil || claimed.ID != second.ID {
+		t.Fatalf("Expected the next operation to be claimed, got %+v (%v)", claimed, err)
+	}
+	if claimed, _ := repo.ClaimBulkOperation(ctx, now, now.Add(-time.Hour)); claimed != nil {
+		t.Fatalf("Expected running operations to stay claimed, got %+v", claimed)
+	}
+
+	// An operation running since before the stale cutoff is claimed again
+	later := now.Add(2 * time.Hour)
+	claimed, err = repo.ClaimBulkOperation(ctx, later, later.Add(-time.Hour))
+	if err != nil || claimed == nil || claimed.ID != first.ID {
+		t.Fatalf("Expected the abandoned operation to be reclaimed, got %+v (%v)", claimed, err)
+	}
+
+	claimed.Status = models.BulkOperationCompleted
+	claimed.Results = models.BulkItemResults{{ApplicationID: claimed.ApplicationIDs[0], Success: true, Status: "rejected"}}
+	claimed.Total, claimed.Succeeded = 1, 1
+	claimed.CompletedAt = &later
+	if err := repo.FinishBulkOperation(ctx, claimed); err != nil {
+		t.Fatalf("FinishBulkOperation failed: %v", err)
+	}
+	stored, err := repo.GetByID(ctx, claimed.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.Status != models.BulkOperationCompleted || len(stored.Results) != 1 || !stored.Results[0].Success {
+		t.Errorf("Expected the stored results, got %+v", stored)
+	}
+}
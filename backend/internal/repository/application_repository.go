@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 	"microbridge/backend/internal/models"
@@ -77,18 +78,33 @@ func (r *applicationRepository) GetByUserID(ctx context.Context, userID string,
 	return applications, total, nil
 }
 
-func (r *applicationRepository) GetByJobID(ctx context.Context, jobID string, limit, offset int) ([]*models.Application, int64, error) {
+func (r *applicationRepository) GetByJobID(ctx context.Context, jobID string, filter ApplicationFilter, limit, offset int) ([]*models.Application, int64, error) {
 	var applications []*models.Application
 	var total int64
 
+	query := r.db.WithContext(ctx).Model(&models.Application{}).Where("job_id = ?", jobID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Flagged != nil {
+		query = query.Where("screening_flagged = ?", *filter.Flagged)
+	}
+	for _, answer := range filter.Answers {
+		// Containment on the answer alone, so the knockout marker doesn't have to match
+		contains, err := json.Marshal([]map[string]string{{"question_id": answer.QuestionID, "value": answer.Value}})
+		if err != nil {
+			return nil, 0, apperrors.NewAppError(500, "Failed to filter job applications", err)
+		}
+		query = query.Where("screening_answers @> ?::jsonb", string(contains))
+	}
+
 	// Get total count
-	if err := r.db.WithContext(ctx).Model(&models.Application{}).Where("job_id = ?", jobID).Count(&total).Error; err != nil {
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, apperrors.NewAppError(500, "Failed to count job applications", err)
 	}
 
 	// Get paginated results
-	if err := r.db.WithContext(ctx).
-		Where("job_id = ?", jobID).
+	if err := query.
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -128,6 +144,111 @@ func (r *applicationRepository) UpdateStatus(ctx context.Context, id string, sta
 	return nil
 }
 
+// CreateWithHistory inserts an application together with its first history entry
+func (r *applicationRepository) CreateWithHistory(ctx context.Context, application *models.Application, entry *models.ApplicationStatusHistory) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(application).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to create application", err)
+		}
+		entry.ApplicationID = application.ID
+		if err := tx.Create(entry).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to record application status", err)
+		}
+		return nil
+	})
+}
+
+// TransitionStatus saves an application whose status changed from fromStatus and
+// appends the history entry in the same transaction. The write only applies while
+// the stored status is still fromStatus, so concurrent changes can't both succeed.
+func (r *applicationRepository) TransitionStatus(ctx context.Context, application *models.Application, fromStatus string, entry *models.ApplicationStatusHistory) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Application{}).
+			Where("id = ? AND status = ?", application.ID, fromStatus).
+			Select("*").
+			Omit("id", "created_at").
+			Updates(application)
+		if result.Error != nil {
+			return apperrors.NewAppError(500, "Failed to update application status", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewAppError(409, "Application status changed in the meantime; reload and try again", nil)
+		}
+
+		if err := tx.Create(entry).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to record application status", err)
+		}
+		return nil
+	})
+}
+
+// GetStatusHistory returns an application's status changes, oldest first
+func (r *applicationRepository) GetStatusHistory(ctx context.Context, applicationID string) ([]*models.ApplicationStatusHistory, error) {
+	var history []*models.ApplicationStatusHistory
+	if err := r.db.WithContext(ctx).
+		Where("application_id = ?", applicationID).
+		Order("created_at ASC, id ASC").
+		Find(&history).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get application status history", err)
+	}
+	return history, nil
+}
+
+// GetByJobAndIDs returns the given applications of a job, or all its submitted
+// applications when ids is empty. Ids belonging to other jobs are left out.
+func (r *applicationRepository) GetByJobAndIDs(ctx context.Context, jobID string, ids []string) ([]*models.Application, error) {
+	var applications []*models.Application
+	query := r.db.WithContext(ctx).Where("job_id = ?", jobID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	} else {
+		query = query.Where("status <> ?", "draft")
+	}
+	if err := query.Order("applied_at ASC").Find(&applications).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get job applications", err)
+	}
+	return applications, nil
+}
+
+// Shortlist marks an application as shortlisted while its status is unchanged
+func (r *applicationRepository) Shortlist(ctx context.Context, application *models.Application, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Application{}).
+		Where("id = ? AND status = ?", application.ID, application.Status).
+		Updates(map[string]interface{}{
+			"shortlisted_at": at,
+			"updated_at":     at,
+		})
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to shortlist application", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewAppError(409, "Application status changed in the meantime; reload and try again", nil)
+	}
+	application.ShortlistedAt = &at
+	application.UpdatedAt = at
+	return nil
+}
+
+// SetInterviewScheduled writes only the interview time, so it never overwrites a
+// concurrent status change. A nil time clears it.
+func (r *applicationRepository) SetInterviewScheduled(ctx context.Context, id string, scheduled *time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Application{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"interview_scheduled": scheduled,
+			"updated_at":          time.Now(),
+		})
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to update interview time", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewAppError(404, "Application not found", nil)
+	}
+	return nil
+}
+
 func (r *applicationRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*models.Application, int64, error) {
 	var applications []*models.Application
 	var total int64
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/database/migrations"
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+func TestApplicationRepository_StatusHistory(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`ALTER TABLE applications ADD COLUMN cover_letter TEXT, ADD COLUMN created_at TIMESTAMP;`).Error; err != nil {
+		t.Fatalf("Failed to extend the applications fixture: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "create_application_status_history" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	repo := NewApplicationRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	studentID, employerID := uuid.New().String(), uuid.New().String()
+
+	application := &models.Application{
+		ID:        uuid.New().String(),
+		UserID:    studentID,
+		JobID:     "job-1",
+		Status:    "submitted",
+		AppliedAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := repo.CreateWithHistory(ctx, application, &models.ApplicationStatusHistory{
+		ToStatus: "submitted", ActorID: &studentID, ActorRole: "student", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("CreateWithHistory failed: %v", err)
+	}
+
+	application.Status = "reviewed"
+	application.ReviewedAt = &now
+	reviewed := &models.ApplicationStatusHistory{
+		ApplicationID: application.ID, FromStatus: "submitted", ToStatus: "reviewed",
+		ActorID: &employerID, ActorRole: "employer", CreatedAt: now.Add(time.Minute),
+	}
+	if err := repo.TransitionStatus(ctx, application, "submitted", reviewed); err != nil {
+		t.Fatalf("TransitionStatus failed: %v", err)
+	}
+
+	// A second change based on the stale status loses
+	application.Status = "rejected"
+	stale := &models.ApplicationStatusHistory{
+		ApplicationID: application.ID, FromStatus: "submitted", ToStatus: "rejected",
+		ActorID: &employerID, ActorRole: "employer", CreatedAt: now.Add(2 * time.Minute),
+	}
+	err := repo.TransitionStatus(ctx, application, "submitted", stale)
+	if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != 409 {
+		t.Fatalf("Expected a conflict for a stale transition, got %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, application.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.Status != "reviewed" || stored.ReviewedAt == nil {
+		t.Errorf("Expected the application to stay reviewed, got %+v", stored)
+	}
+
+	history, err := repo.GetStatusHistory(ctx, application.ID)
+	if err != nil {
+		t.Fatalf("GetStatusHistory failed: %v", err)
+	}
+	if len(history) != 2 || history[0].ToStatus != "submitted" || history[1].ToStatus != "reviewed" {
+		t.Fatalf("Expected submitted then reviewed, got %+v", history)
+	}
+
+	if err := db.Exec("UPDATE application_status_history SET reason = 'edited'").Error; err == nil {
+		t.Error("Expected history rows to be append-only")
+	}
+}
+
+func TestApplicationRepository_GetByJobIDFilters(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`ALTER TABLE applications ADD COLUMN created_at TIMESTAMP;`).Error; err != nil {
+		t.Fatalf("Failed to extend the applications fixture: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "add_screening_questions" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	insert := func(status string, flagged bool, answers string) string {
+		id := uuid.New().String()
+		if err := db.Exec(`INSERT INTO applications (id, user_id, job_id, status, screening_flagged, screening_answers, created_at)
+			VALUES (?, ?, 'job-1', ?, ?, ?::jsonb, ?)`, id, uuid.New().String(), status, flagged, answers, time.Now().UTC()).Error; err != nil {
+			t.Fatalf("Failed to insert application: %v", err)
+		}
+		return id
+	}
+	clean := insert("submitted", false, `[{"question_id":"available","value":"yes"},{"question_id":"hours","value":"20+"}]`)
+	flagged := insert("submitted", true, `[{"question_id":"available","value":"yes"},{"question_id":"hours","value":"Under 10","knockout":true}]`)
+	insert("rejected", false, `[{"question_id":"available","value":"no","knockout":true}]`)
+
+	repo := NewApplicationRepository(db)
+	ctx := context.Background()
+	yes := true
+
+	tests := []struct {
+		name   string
+		filter ApplicationFilter
+		want   []string
+	}{
+		{"status", ApplicationFilter{Status: "submitted"}, []string{clean, flagged}},
+		{"flagged", ApplicationFilter{Flagged: &yes}, []string{flagged}},
+		{"knockout answer", ApplicationFilter{Answers: models.ScreeningAnswers{{QuestionID: "hours", Value: "Under 10"}}}, []string{flagged}},
+		{"every answer", ApplicationFilter{Answers: models.ScreeningAnswers{{QuestionID: "available", Value: "yes"}, {QuestionID: "hours", Value: "20+"}}}, []string{clean}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applications, total, err := repo.GetByJobID(ctx, "job-1", tt.filter, 10, 0)
+			if err != nil {
+				t.Fatalf("GetByJobID failed: %v", err)
+			}
+			if int(total) != len(tt.want) || len(applications) != len(tt.want) {
+				t.Fatalf("Expected %d applications, got %d (total %d)", len(tt.want), len(applications), total)
+			}
+			found := make(map[string]bool)
+			for _, application := range applications {
+				found[application.ID] = true
+			}
+			for _, id := range tt.want {
+				if !found[id] {
+					t.Errorf("Expected application %s in the results", id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	aiModels "microbridge/backend/internal/ai/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BanditDecisionRepository interface {
+	SaveBanditDecisions(ctx context.Context, decisions []*aiModels.BanditDecision) error
+	RecordBanditReward(ctx context.Context, decision *aiModels.BanditDecision) error
+	ListBanditDecisions(ctx context.Context, since time.Time, limit int) ([]*aiModels.BanditDecision, error)
+}
+
+// banditDecisionRecord is the persisted form of aiModels.BanditDecision
+type banditDecisionRecord struct {
+	ID             string `gorm:"primaryKey"`
+	UserID         string
+	JobID          string
+	Policy         string
+	Slot           int
+	CandidateCount int
+	Propensity     float64
+	Score          float64
+	Context        string `gorm:"type:jsonb"`
+	Reward         float64
+	RewardEvent    string
+	ModelVersion   string
+	CreatedAt      time.Time
+	RewardedAt     *time.Time
+}
+
+func (banditDecisionRecord) TableName() string {
+	return "ai_bandit_decisions"
+}
+
+type banditDecisionRepository struct {
+	db *gorm.DB
+}
+
+func NewBanditDecisionRepository(db *gorm.DB) BanditDecisionRepository {
+	return &banditDecisionRepository{db: db}
+}
+
+// SaveBanditDecisions stores new decisions; decisions already stored are left alone
+func (r *banditDecisionRepository) SaveBanditDecisions(ctx context.Context, decisions []*aiModels.BanditDecision) error {
+	if len(decisions) == 0 {
+		return nil
+	}
+
+	records := make([]*banditDecisionRecord, 0, len(decisions))
+	for _, decision := range decisions {
+		encoded, err := json.Marshal(decision.Context)
+		if err != nil {
+			return apperrors.NewAppError(500, "Failed to encode bandit decision", err)
+		}
+		records = append(records, &banditDecisionRecord{
+			ID:             decision.ID,
+			UserID:         decision.UserID,
+			JobID:          decision.JobID,
+			Policy:         decision.Policy,
+			Slot:           decision.Slot,
+			CandidateCount: decision.CandidateCount,
+			Propensity:     decision.Propensity,
+			Score:          decision.Score,
+			Context:        string(encoded),
+			Reward:         decision.Reward,
+			RewardEvent:    decision.RewardEvent,
+			ModelVersion:   decision.ModelVersion,
+			CreatedAt:      decision.CreatedAt,
+			RewardedAt:     decision.RewardedAt,
+		})
+	}
+
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&records).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to save bandit decisions", err)
+	}
+	return nil
+}
+
+// RecordBanditReward stores the reward credited to a decision. A reward lower than
+// the stored one is ignored, so a late write never undoes a stronger event.
+func (r *banditDecisionRepository) RecordBanditReward(ctx context.Context, decision *aiModels.BanditDecision) error {
+	err := r.db.WithContext(ctx).Model(&banditDecisionRecord{}).
+		Where("id = ? AND reward <= ?", decision.ID, decision.Reward).
+		Updates(map[string]interface{}{
+			"reward":       decision.Reward,
+			"reward_event": decision.RewardEvent,
+			"rewarded_at":  decision.RewardedAt,
+		}).Error
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to record bandit reward", err)
+	}
+	return nil
+}
+
+// ListBanditDecisions returns decisions created since the given time, oldest first
+func (r *banditDecisionRepository) ListBanditDecisions(ctx context.Context, since time.Time, limit int) ([]*aiModels.BanditDecision, error) {
+	var records []*banditDecisionRecord
+	query := r.db.WithContext(ctx).Where("created_at >= ?", since).Order("created_at ASC, slot ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to list bandit decisions", err)
+	}
+
+	decisions := make([]*aiModels.BanditDecision, 0, len(records))
+	for _, record := range records {
+		decision := &aiModels.BanditDecision{
+			ID:             record.ID,
+			UserID:         record.UserID,
+			JobID:          record.JobID,
+			Policy:         record.Policy,
+			Slot:           record.Slot,
+			CandidateCount: record.CandidateCount,
+			Propensity:     record.Propensity,
+			Score:          record.Score,
+			Reward:         record.Reward,
+			RewardEvent:    record.RewardEvent,
+			ModelVersion:   record.ModelVersion,
+			CreatedAt:      record.CreatedAt,
+			RewardedAt:     record.RewardedAt,
+		}
+		if err := json.Unmarshal([]byte(record.Context), &decision.Context); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode bandit decision", err)
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions, nil
+}
@@ -0,0 +1,555 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+// Cohort segments understood by GetCohortBehavior
+const (
+	CohortAllUsers    = "all_users"
+	CohortNewUsers    = "new_users"
+	CohortActiveUsers = "active_users"
+	CohortJobSeekers  = "job_seekers"
+)
+
+// actionQuality weighs how much intent an action shows, matching the weights the
+// behavior service uses for engagement quality
+var actionQuality = map[string]float64{
+	"apply":          1.0,
+	"save":           0.6,
+	"feedback":       0.4,
+	"skill_interest": 0.4,
+	"view":           0.3,
+	"search":         0.1,
+}
+
+// ActionQuality returns the intent weight of an action type, 0 for passive ones such as impressions
+func ActionQuality(actionType string) float64 {
+	return actionQuality[actionType]
+}
+
+// GetUserJourney returns the user's actions over the last days as journey points,
+// scored by the intent each action shows
+func (r *behaviorRepository) GetUserJourney(ctx context.Context, userID string, days int) ([]UserJourneyPoint, error) {
+	actions, err := r.GetUserActionHistory(ctx, userID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]UserJourneyPoint, len(actions))
+	for i, action := range actions {
+		score := actionQuality[action.ActionType]
+		// Time spent reading a posting separates a skim from real interest
+		if seconds, ok := action.Data["time_spent"].(float64); ok && seconds > 0 {
+			score += math.Min(0.4, seconds/300)
+		}
+		points[i] = UserJourneyPoint{
+			Timestamp:       action.Timestamp,
+			Action:          action.ActionType,
+			EntityID:        action.EntityID,
+			EntityType:      action.EntityType,
+			Context:         action.Data,
+			EngagementScore: math.Min(1, score),
+			SessionID:       action.SessionID,
+		}
+	}
+	return points, nil
+}
+
+// GetBehaviorTrends aggregates the user's activity, skill interest and preference
+// changes over the last days
+func (r *behaviorRepository) GetBehaviorTrends(ctx context.Context, userID string, days int) (*BehaviorTrends, error) {
+	if days <= 0 {
+		days = 30
+	}
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -days)
+	weeks := int(math.Ceil(float64(days) / 7))
+
+	trends := &BehaviorTrends{
+		UserID:        userID,
+		TimeRangeDays: days,
+		CalculatedAt:  now,
+	}
+
+	activity, err := r.activityTrend(ctx, userID, since, weeks)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to aggregate activity trend", err)
+	}
+	trends.ActivityTrend = *activity
+
+	if trends.SkillInterestChanges, err = r.skillInterestChanges(ctx, userID, since, weeks); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get skill interest changes", err)
+	}
+	if trends.PreferenceShifts, err = r.preferenceShifts(ctx, userID, since); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get preference shifts", err)
+	}
+	if trends.EngagementEvolution, err = r.engagementEvolution(ctx, userID, since); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to aggregate engagement", err)
+	}
+
+	var stages []string
+	if err := r.db.WithContext(ctx).Model(&behaviorPatternRecord{}).
+		Where("user_id = ?", userID).
+		Pluck("journey_stage", &stages).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get journey stage", err)
+	}
+	if len(stages) > 0 {
+		trends.JourneyProgression = stages[0]
+	}
+
+	return trends, nil
+}
+
+// GetCohortBehavior aggregates engagement, placement and retention for the users in a
+// segment over the criteria's time range. Cohorts smaller than MinCohortSize are
+// withheld so small groups cannot be singled out.
+func (r *behaviorRepository) GetCohortBehavior(ctx context.Context, criteria CohortCriteria) ([]CohortBehaviorData, error) {
+	end := criteria.TimeRange.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := criteria.TimeRange.Start
+	if start.IsZero() {
+		start = end.AddDate(0, 0, -30)
+	}
+	start, end = start.UTC(), end.UTC()
+	segment := criteria.UserSegment
+	if segment == "" {
+		segment = CohortAllUsers
+	}
+
+	cohort, err := r.cohortQuery(ctx, segment, criteria.Filters, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	if err := r.db.WithContext(ctx).Table("(?) AS cohort", cohort).Count(&size).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to count cohort", err)
+	}
+	if size == 0 || size < int64(criteria.MinCohortSize) {
+		return []CohortBehaviorData{}, nil
+	}
+
+	data := CohortBehaviorData{
+		CohortID:     fmt.Sprintf("%s:%s..%s", segment, start.Format("2006-01-02"), end.Format("2006-01-02")),
+		CohortSize:   int(size),
+		CalculatedAt: time.Now().UTC(),
+	}
+
+	if err := r.cohortMetrics(ctx, cohort, &data.AvgMetrics); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to aggregate cohort engagement", err)
+	}
+	if data.CommonPatterns, err = r.cohortPatterns(ctx, cohort); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to aggregate cohort patterns", err)
+	}
+	if data.BehaviorProfile, err = r.cohortProfile(ctx, cohort, start, end, size); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to aggregate cohort behavior", err)
+	}
+
+	var placed int64
+	if err := r.db.WithContext(ctx).Table("applications").
+		Where("status = ? AND user_id IN (?) AND updated_at <= ?", "accepted", cohort, end).
+		Distinct("user_id").
+		Count(&placed).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to aggregate cohort placements", err)
+	}
+	data.SuccessRate = float64(placed) / float64(size)
+
+	// Retained users were active during the last week of the range
+	var retained int64
+	if err := r.db.WithContext(ctx).Model(&userActionRecord{}).
+		Where("user_id IN (?) AND occurred_at >= ? AND occurred_at < ?", cohort, end.AddDate(0, 0, -7), end).
+		Distinct("user_id").
+		Count(&retained).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to aggregate cohort retention", err)
+	}
+	data.RetentionRate = float64(retained) / float64(size)
+
+	return []CohortBehaviorData{data}, nil
+}
+
+// Private methods
+
+type weeklyCount struct {
+	Week       int
+	ActionType string
+	Count      int64
+}
+
+func (r *behaviorRepository) activityTrend(ctx context.Context, userID string, since time.Time, weeks int) (*ActivityTrend, error) {
+	var counts []weeklyCount
+	if err := r.db.WithContext(ctx).Model(&userActionRecord{}).
+		Select("FLOOR(EXTRACT(EPOCH FROM (occurred_at - ?::timestamp)) / 604800)::int AS week, action_type, COUNT(*) AS count", since).
+		Where("user_id = ? AND occurred_at >= ?", userID, since).
+		Group("week, action_type").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	trend := &ActivityTrend{
+		ViewsPerWeek:        make([]float64, weeks),
+		ApplicationsPerWeek: make([]float64, weeks),
+		SearchesPerWeek:     make([]float64, weeks),
+		Seasonality:         make([]float64, 7),
+	}
+	totals := make([]float64, weeks)
+	for _, count := range counts {
+		if count.Week < 0 || count.Week >= weeks {
+			continue
+		}
+		totals[count.Week] += float64(count.Count)
+		switch count.ActionType {
+		case "view":
+			trend.ViewsPerWeek[count.Week] += float64(count.Count)
+		case "apply":
+			trend.ApplicationsPerWeek[count.Week] += float64(count.Count)
+		case "search":
+			trend.SearchesPerWeek[count.Week] += float64(count.Count)
+		}
+	}
+	trend.TrendDirection, trend.ChangePercentage = compareHalves(totals)
+
+	var byDay []struct {
+		Dow   int
+		Count int64
+	}
+	if err := r.db.WithContext(ctx).Model(&userActionRecord{}).
+		Select("EXTRACT(DOW FROM occurred_at)::int AS dow, COUNT(*) AS count").
+		Where("user_id = ? AND occurred_at >= ?", userID, since).
+		Group("dow").
+		Scan(&byDay).Error; err != nil {
+		return nil, err
+	}
+	var total float64
+	for _, day := range byDay {
+		total += float64(day.Count)
+	}
+	for _, day := range byDay {
+		if day.Dow >= 0 && day.Dow < 7 && total > 0 {
+			trend.Seasonality[day.Dow] = float64(day.Count) / total
+		}
+	}
+	return trend, nil
+}
+
+func (r *behaviorRepository) skillInterestChanges(ctx context.Context, userID string, since time.Time, weeks int) ([]SkillTrendChange, error) {
+	var records []*skillInterestRecord
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND updated_at >= ? AND previous_interest IS NOT NULL", userID, since).
+		Order("skill_name").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	changes := make([]SkillTrendChange, 0, len(records))
+	for _, record := range records {
+		delta := record.Interest - *record.PreviousInterest
+		changes = append(changes, SkillTrendChange{
+			SkillName:       record.SkillName,
+			PreviousScore:   *record.PreviousInterest,
+			CurrentScore:    record.Interest,
+			ChangeDirection: changeDirection(delta, 0.05, "increasing", "decreasing"),
+			ChangeRate:      delta / float64(weeks),
+			Significance:    math.Min(1, math.Abs(delta)),
+		})
+	}
+	return changes, nil
+}
+
+func (r *behaviorRepository) preferenceShifts(ctx context.Context, userID string, since time.Time) ([]PreferenceShift, error) {
+	var records []*preferenceSignalRecord
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND last_updated >= ? AND previous_value IS NOT NULL AND previous_value <> value", userID, since).
+		Order("signal_type").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	shifts := make([]PreferenceShift, 0, len(records))
+	for _, record := range records {
+		delta := math.Abs(record.Value - *record.PreviousValue)
+		impact := "low"
+		switch {
+		case delta > 0.3:
+			impact = "high"
+		case delta > 0.1:
+			impact = "medium"
+		}
+		shifts = append(shifts, PreferenceShift{
+			PreferenceName: record.SignalType,
+			PreviousValue:  *record.PreviousValue,
+			CurrentValue:   record.Value,
+			Confidence:     record.Confidence,
+			DetectedAt:     record.LastUpdated,
+			Impact:         impact,
+		})
+	}
+	return shifts, nil
+}
+
+func (r *behaviorRepository) engagementEvolution(ctx context.Context, userID string, since time.Time) ([]EngagementPoint, error) {
+	var days []struct {
+		Day      time.Time
+		Actions  int
+		Sessions int
+		First    time.Time
+		Last     time.Time
+		Quality  float64
+	}
+	if err := r.db.WithContext(ctx).Model(&userActionRecord{}).
+		Select(`DATE_TRUNC('day', occurred_at) AS day, COUNT(*) AS actions,
+			COUNT(DISTINCT NULLIF(session_id, '')) AS sessions,
+			MIN(occurred_at) AS first, MAX(occurred_at) AS last,
+			SUM(`+qualityCase()+`) AS quality`).
+		Where("user_id = ? AND occurred_at >= ?", userID, since).
+		Group("day").
+		Order("day").
+		Scan(&days).Error; err != nil {
+		return nil, err
+	}
+
+	// Stored sessions replace the per-day estimate once the actions have been sessionized
+	var sessionDays []struct {
+		Day      time.Time
+		Sessions int
+		Seconds  float64
+		Actions  int
+		Score    float64
+	}
+	if err := r.db.WithContext(ctx).Model(&userSessionRecord{}).
+		Select(`DATE_TRUNC('day', started_at) AS day, COUNT(*) AS sessions,
+			SUM(EXTRACT(EPOCH FROM ended_at - started_at)) AS seconds,
+			SUM(action_count) AS actions, AVG(engagement_score) AS score`).
+		Where("user_id = ? AND started_at >= ?", userID, since).
+		Group("day").
+		Scan(&sessionDays).Error; err != nil {
+		return nil, err
+	}
+	sessionsByDay := make(map[time.Time]int, len(sessionDays))
+	for i, day := range sessionDays {
+		sessionsByDay[day.Day.UTC()] = i
+	}
+
+	points := make([]EngagementPoint, len(days))
+	for i, day := range days {
+		if j, ok := sessionsByDay[day.Day.UTC()]; ok {
+			stored := sessionDays[j]
+			points[i] = EngagementPoint{
+				Date:              day.Day,
+				EngagementScore:   stored.Score,
+				SessionDuration:   time.Duration(stored.Seconds / float64(stored.Sessions) * float64(time.Second)),
+				ActionsPerSession: stored.Actions / stored.Sessions,
+				QualityScore:      day.Quality / float64(day.Actions),
+			}
+			continue
+		}
+
+		sessions := day.Sessions
+		if sessions == 0 {
+			sessions = 1 // Untracked sessions count as one per day
+		}
+		points[i] = EngagementPoint{
+			Date:              day.Day,
+			EngagementScore:   math.Min(1, float64(day.Actions)/10),
+			SessionDuration:   day.Last.Sub(day.First) / time.Duration(sessions),
+			ActionsPerSession: day.Actions / sessions,
+			QualityScore:      day.Quality / float64(day.Actions),
+		}
+	}
+	return points, nil
+}
+
+// cohortQuery builds the subquery selecting the user IDs in a segment
+func (r *behaviorRepository) cohortQuery(ctx context.Context, segment string, filters map[string]interface{}, start, end time.Time) (*gorm.DB, error) {
+	query := r.db.WithContext(ctx).Table("users").Select("users.id")
+	switch segment {
+	case CohortAllUsers:
+		query = query.Where("users.created_at <= ?", end)
+	case CohortNewUsers:
+		query = query.Where("users.created_at >= ? AND users.created_at < ?", start, end)
+	case CohortActiveUsers:
+		query = query.Where("users.id IN (?)", r.db.Model(&userActionRecord{}).
+			Select("user_id").
+			Where("occurred_at >= ? AND occurred_at < ?", start, end))
+	case CohortJobSeekers:
+		query = query.Where("users.id IN (?)", r.db.Model(&userActionRecord{}).
+			Select("user_id").
+			Where("action_type = ? AND occurred_at >= ? AND occurred_at < ?", "apply", start, end))
+	default:
+		return nil, apperrors.NewValidationError(fmt.Sprintf("unknown cohort segment %q", segment))
+	}
+
+	for key, value := range filters {
+		switch key {
+		case "user_type":
+			query = query.Where("users.user_type = ?", value)
+		case "activity_level", "journey_stage":
+			query = query.Where("users.id IN (?)", r.db.Model(&behaviorPatternRecord{}).
+				Select("user_id").
+				Where(key+" = ?", value))
+		default:
+			return nil, apperrors.NewValidationError(fmt.Sprintf("unsupported cohort filter %q", key))
+		}
+	}
+	return query, nil
+}
+
+func (r *behaviorRepository) cohortMetrics(ctx context.Context, cohort *gorm.DB, metrics *EngagementMetrics) error {
+	var averages struct {
+		TotalViews        float64
+		TotalApplications float64
+		TotalSearches     float64
+		TotalSaves        float64
+		AverageTimePerJob float64
+		SessionFrequency  float64
+		ApplicationRate   float64
+		SearchToViewRatio float64
+		TotalSessions     float64
+	}
+	if err := r.db.WithContext(ctx).Model(&engagementMetricsRecord{}).
+		Select(`COALESCE(AVG(total_views), 0) AS total_views,
+			COALESCE(AVG(total_applications), 0) AS total_applications,
+			COALESCE(AVG(total_searches), 0) AS total_searches,
+			COALESCE(AVG(total_saves), 0) AS total_saves,
+			COALESCE(AVG(average_time_per_job), 0) AS average_time_per_job,
+			COALESCE(AVG(session_frequency), 0) AS session_frequency,
+			COALESCE(AVG(application_rate), 0) AS application_rate,
+			COALESCE(AVG(search_to_view_ratio), 0) AS search_to_view_ratio,
+			COALESCE(AVG(total_sessions), 0) AS total_sessions`).
+		Where("user_id IN (?)", cohort).
+		Scan(&averages).Error; err != nil {
+		return err
+	}
+
+	*metrics = EngagementMetrics{
+		TotalViews:        int64(math.Round(averages.TotalViews)),
+		TotalApplications: int64(math.Round(averages.TotalApplications)),
+		TotalSearches:     int64(math.Round(averages.TotalSearches)),
+		TotalSaves:        int64(math.Round(averages.TotalSaves)),
+		AverageTimePerJob: time.Duration(averages.AverageTimePerJob),
+		SessionFrequency:  averages.SessionFrequency,
+		ApplicationRate:   averages.ApplicationRate,
+		SearchToViewRatio: averages.SearchToViewRatio,
+		TotalSessions:     int64(math.Round(averages.TotalSessions)),
+		LastCalculated:    time.Now().UTC(),
+	}
+	return nil
+}
+
+// cohortPatterns lists the most common journey stages and activity levels in the cohort
+func (r *behaviorRepository) cohortPatterns(ctx context.Context, cohort *gorm.DB) ([]string, error) {
+	var patterns []string
+	for _, column := range []string{"journey_stage", "activity_level"} {
+		var values []string
+		if err := r.db.WithContext(ctx).Model(&behaviorPatternRecord{}).
+			Select(column).
+			Where("user_id IN (?) AND "+column+" <> ''", cohort).
+			Group(column).
+			Order("COUNT(*) DESC, "+column).
+			Limit(3).
+			Pluck(column, &values).Error; err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			patterns = append(patterns, column+":"+value)
+		}
+	}
+	return patterns, nil
+}
+
+// cohortProfile reports actions per cohort member by type over the range
+func (r *behaviorRepository) cohortProfile(ctx context.Context, cohort *gorm.DB, start, end time.Time, size int64) (map[string]interface{}, error) {
+	var counts []struct {
+		ActionType string
+		Count      int64
+	}
+	if err := r.db.WithContext(ctx).Model(&userActionRecord{}).
+		Select("action_type, COUNT(*) AS count").
+		Where("user_id IN (?) AND occurred_at >= ? AND occurred_at < ?", cohort, start, end).
+		Group("action_type").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	perUser := make(map[string]float64, len(counts))
+	var total int64
+	for _, count := range counts {
+		perUser[count.ActionType] = float64(count.Count) / float64(size)
+		total += count.Count
+	}
+	return map[string]interface{}{
+		"actions_per_user": perUser,
+		"total_actions":    total,
+		"range_start":      start,
+		"range_end":        end,
+	}, nil
+}
+
+// Utility functions
+
+// qualityCase renders actionQuality as a SQL CASE over action_type
+func qualityCase() string {
+	types := make([]string, 0, len(actionQuality))
+	for actionType := range actionQuality {
+		types = append(types, actionType)
+	}
+	sort.Strings(types)
+
+	var b strings.Builder
+	b.WriteString("CASE action_type")
+	for _, actionType := range types {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %g", actionType, actionQuality[actionType])
+	}
+	b.WriteString(" ELSE 0 END")
+	return b.String()
+}
+
+// compareHalves compares total activity in the later half of the weeks with the earlier half
+func compareHalves(totals []float64) (string, float64) {
+	if len(totals) < 2 {
+		return "stable", 0
+	}
+	half := len(totals) / 2
+	var earlier, recent float64
+	for i, total := range totals {
+		if i < len(totals)-half {
+			earlier += total
+		} else {
+			recent += total
+		}
+	}
+	// Compare weekly averages, since an odd number of weeks gives uneven halves
+	earlier /= float64(len(totals) - half)
+	recent /= float64(half)
+
+	var change float64
+	switch {
+	case earlier > 0:
+		change = (recent - earlier) / earlier * 100
+	case recent > 0:
+		change = 100
+	}
+	return changeDirection(change, 10, "up", "down"), math.Round(change*10) / 10
+}
+
+func changeDirection(delta, threshold float64, up, down string) string {
+	switch {
+	case delta > threshold:
+		return up
+	case delta < -threshold:
+		return down
+	default:
+		return "stable"
+	}
+}
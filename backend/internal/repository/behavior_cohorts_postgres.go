@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+// maxRetentionWeeks bounds the retention curve stored for a cohort
+const maxRetentionWeeks = 52
+
+type cohortStatsRecord struct {
+	CohortWeek        time.Time `gorm:"primaryKey;type:date"`
+	UserType          string    `gorm:"primaryKey"`
+	AcquisitionSource string    `gorm:"primaryKey"`
+	CohortSize        int
+	RetainedUsers     string `gorm:"type:jsonb"`
+	SuccessfulUsers   int
+	DaysToSuccess     float64
+	TotalActions      int64
+	TotalApplications int64
+	CalculatedAt      time.Time
+}
+
+func (cohortStatsRecord) TableName() string { return "user_cohort_stats" }
+
+// CohortWeek returns the Monday starting t's week in UTC, matching Postgres DATE_TRUNC('week', ...)
+func CohortWeek(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// cohortKey identifies one stored cohort row
+func cohortKey(week time.Time, userType, source string) string {
+	return week.Format("2006-01-02") + "|" + userType + "|" + source
+}
+
+// cohortColumns groups users by signup week, type and source
+const cohortColumns = `DATE_TRUNC('week', users.created_at)::date AS cohort_week,
+	COALESCE(users.user_type, '') AS user_type,
+	COALESCE(users.acquisition_source, '') AS acquisition_source`
+
+// RefreshCohortStats recomputes the cohorts of every signup week from the week
+// containing from through the week containing to. Older cohorts keep changing
+// as their users return, so the scheduler refreshes a trailing window.
+func (r *behaviorRepository) RefreshCohortStats(ctx context.Context, from, to time.Time) error {
+	from, to = CohortWeek(from), CohortWeek(to).AddDate(0, 0, 7)
+	now := time.Now().UTC()
+
+	var sizes []struct {
+		CohortWeek        time.Time
+		UserType          string
+		AcquisitionSource string
+		Users             int
+	}
+	if err := r.db.WithContext(ctx).Table("users").
+		Select(cohortColumns+", COUNT(*) AS users").
+		Where("users.created_at >= ? AND users.created_at < ?", from, to).
+		Group("1, 2, 3").
+		Scan(&sizes).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to count signup cohorts", err)
+	}
+
+	stats := make(map[string]*CohortStats, len(sizes))
+	order := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		weeks := int(now.Sub(size.CohortWeek).Hours()/(24*7)) + 1
+		if weeks > maxRetentionWeeks {
+			weeks = maxRetentionWeeks
+		}
+		key := cohortKey(size.CohortWeek, size.UserType, size.AcquisitionSource)
+		stats[key] = &CohortStats{
+			CohortWeek:        size.CohortWeek,
+			UserType:          size.UserType,
+			AcquisitionSource: size.AcquisitionSource,
+			CohortSize:        size.Users,
+			RetainedUsers:     make([]int, weeks),
+			CalculatedAt:      now,
+		}
+		order = append(order, key)
+	}
+
+	// Week offsets count calendar weeks from the signup week, so week 0 is the signup week itself
+	var activity []struct {
+		CohortWeek        time.Time
+		UserType          string
+		AcquisitionSource string
+		WeekOffset        int
+		Users             int
+		Actions           int64
+		Applications      int64
+	}
+	if err := r.db.WithContext(ctx).Table("users").
+		Select(cohortColumns+`,
+			(DATE_TRUNC('week', user_actions.occurred_at)::date - DATE_TRUNC('week', users.created_at)::date) / 7 AS week_offset,
+			COUNT(DISTINCT users.id) AS users,
+			COUNT(*) AS actions,
+			COUNT(*) FILTER (WHERE user_actions.action_type = 'apply') AS applications`).
+		Joins("JOIN user_actions ON user_actions.user_id = users.id AND user_actions.occurred_at >= DATE_TRUNC('week', users.created_at)").
+		Where("users.created_at >= ? AND users.created_at < ?", from, to).
+		Group("1, 2, 3, 4").
+		Scan(&activity).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to aggregate cohort retention", err)
+	}
+	for _, row := range activity {
+		cohort := stats[cohortKey(row.CohortWeek, row.UserType, row.AcquisitionSource)]
+		if cohort == nil {
+			continue
+		}
+		cohort.TotalActions += row.Actions
+		cohort.TotalApplications += row.Applications
+		if row.WeekOffset >= 0 && row.WeekOffset < len(cohort.RetainedUsers) {
+			cohort.RetainedUsers[row.WeekOffset] = row.Users
+		}
+	}
+
+	var successes []struct {
+		CohortWeek        time.Time
+		UserType          string
+		AcquisitionSource string
+		Users             int
+		Days              float64
+	}
+	// Acceptance time comes from the status history; updated_at moves with any
+	// later edit to the application
+	firstAccepted := r.db.Table("application_status_history").
+		Select("applications.user_id, MIN(application_status_history.created_at) AS accepted_at").
+		Joins("JOIN applications ON applications.id = application_status_history.application_id").
+		Where("application_status_history.to_status = ?", "accepted").
+		Group("applications.user_id")
+	if err := r.db.WithContext(ctx).Table("users").
+		Select(cohortColumns+`, COUNT(*) AS users,
+			COALESCE(SUM(GREATEST(EXTRACT(EPOCH FROM (first_accepted.accepted_at - users.created_at)), 0) / 86400), 0) AS days`).
+		Joins("JOIN (?) AS first_accepted ON first_accepted.user_id = users.id", firstAccepted).
+		Where("users.created_at >= ? AND users.created_at < ?", from, to).
+		Group("1, 2, 3").
+		Scan(&successes).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to aggregate cohort success", err)
+	}
+	for _, row := range successes {
+		if cohort := stats[cohortKey(row.CohortWeek, row.UserType, row.AcquisitionSource)]; cohort != nil {
+			cohort.SuccessfulUsers = row.Users
+			cohort.DaysToSuccess = row.Days
+		}
+	}
+
+	records := make([]*cohortStatsRecord, 0, len(order))
+	for _, key := range order {
+		cohort := stats[key]
+		retained, err := encodeJSON(cohort.RetainedUsers, "[]")
+		if err != nil {
+			return apperrors.NewAppError(500, "Failed to encode cohort retention", err)
+		}
+		records = append(records, &cohortStatsRecord{
+			CohortWeek:        cohort.CohortWeek,
+			UserType:          cohort.UserType,
+			AcquisitionSource: cohort.AcquisitionSource,
+			CohortSize:        cohort.CohortSize,
+			RetainedUsers:     retained,
+			SuccessfulUsers:   cohort.SuccessfulUsers,
+			DaysToSuccess:     cohort.DaysToSuccess,
+			TotalActions:      cohort.TotalActions,
+			TotalApplications: cohort.TotalApplications,
+			CalculatedAt:      cohort.CalculatedAt,
+		})
+	}
+
+	// Replace the window wholesale so cohorts whose users were all deleted disappear
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cohort_week >= ? AND cohort_week < ?", from, to).Delete(&cohortStatsRecord{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(records, 500).Error
+	}); err != nil {
+		return apperrors.NewAppError(500, "Failed to store cohort stats", err)
+	}
+	return nil
+}
+
+func (r *behaviorRepository) GetCohortStats(ctx context.Context, filter CohortStatsFilter) ([]CohortStats, error) {
+	query := r.db.WithContext(ctx).Model(&cohortStatsRecord{})
+	if !filter.From.IsZero() {
+		query = query.Where("cohort_week >= ?", CohortWeek(filter.From))
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("cohort_week < ?", filter.To.UTC())
+	}
+	if filter.UserType != "" {
+		query = query.Where("user_type = ?", filter.UserType)
+	}
+	if filter.AcquisitionSource != "" {
+		query = query.Where("acquisition_source = ?", filter.AcquisitionSource)
+	}
+
+	var records []*cohortStatsRecord
+	if err := query.Order("cohort_week, user_type, acquisition_source").Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get cohort stats", err)
+	}
+
+	stats := make([]CohortStats, 0, len(records))
+	for _, record := range records {
+		cohort := CohortStats{
+			CohortWeek:        record.CohortWeek.UTC(),
+			UserType:          record.UserType,
+			AcquisitionSource: record.AcquisitionSource,
+			CohortSize:        record.CohortSize,
+			SuccessfulUsers:   record.SuccessfulUsers,
+			DaysToSuccess:     record.DaysToSuccess,
+			TotalActions:      record.TotalActions,
+			TotalApplications: record.TotalApplications,
+			CalculatedAt:      record.CalculatedAt,
+		}
+		if err := decodeJSON(record.RetainedUsers, &cohort.RetainedUsers); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode cohort retention", err)
+		}
+		stats = append(stats, cohort)
+	}
+	return stats, nil
+}
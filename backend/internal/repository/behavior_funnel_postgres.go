@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// Stage timestamps derived from applications and their jobs; NULL until the
+// stage is reached. Interviews count from the scheduled slot when there is one.
+const (
+	applyStageAt     = `applications.applied_at`
+	interviewStageAt = `CASE WHEN applications.interview_scheduled IS NOT NULL OR applications.status = 'interviewed'
+		THEN COALESCE(applications.interview_scheduled, applications.reviewed_at, applications.updated_at) END`
+	acceptStageAt = `CASE WHEN applications.status = 'accepted'
+		THEN COALESCE(applications.response_at, applications.updated_at) END`
+	completeStageAt = `CASE WHEN applications.status = 'accepted' AND jobs.status = 'completed'
+		AND jobs.hired_student_id::text = applications.user_id::text
+		THEN COALESCE(jobs.completed_at, jobs.updated_at) END`
+)
+
+// funnelKeys is the grouping column of each funnel scope
+var funnelKeys = map[string]string{
+	FunnelScopePlatform: `''::text`,
+	FunnelScopeCategory: `COALESCE(jobs.category, '')`,
+	FunnelScopeEmployer: `jobs.employer_id::text`,
+	FunnelScopeJob:      `jobs.id::text`,
+}
+
+// funnelMedian is the median seconds from one stage to the next among pairs that reached both in order
+func funnelMedian(from, to string) string {
+	return fmt.Sprintf("PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM %[2]s - %[1]s)) FILTER (WHERE %[2]s >= %[1]s)", from, to)
+}
+
+func (r *behaviorRepository) GetFunnelStats(ctx context.Context, filter FunnelFilter) ([]FunnelStats, error) {
+	key, known := funnelKeys[filter.Scope]
+	if !known {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("unsupported funnel scope %q", filter.Scope))
+	}
+
+	args := map[string]interface{}{"from": filter.From.UTC(), "to": filter.To.UTC()}
+	var jobConditions []string
+	if filter.JobID != "" {
+		jobConditions = append(jobConditions, "jobs.id::text = @job_id")
+		args["job_id"] = filter.JobID
+	}
+	if filter.EmployerID != "" {
+		jobConditions = append(jobConditions, "jobs.employer_id::text = @employer_id")
+		args["employer_id"] = filter.EmployerID
+	}
+	if filter.Category != "" {
+		jobConditions = append(jobConditions, "jobs.category = @category")
+		args["category"] = filter.Category
+	}
+	jobFilter, touchFilter := "TRUE", ""
+	if len(jobConditions) > 0 {
+		jobFilter = strings.Join(jobConditions, " AND ")
+		// Narrow the action scan up front; the outer join below would stop the planner pushing it down
+		touchFilter = "AND user_actions.entity_id IN (SELECT jobs.id::text FROM jobs WHERE " + jobFilter + ")"
+	}
+
+	query := `
+		WITH touches AS (
+			SELECT user_actions.entity_id AS job_id, user_actions.user_id::text AS user_id,
+				MIN(user_actions.occurred_at) FILTER (WHERE user_actions.action_type = 'impression') AS impression_at,
+				MIN(user_actions.occurred_at) FILTER (WHERE user_actions.action_type = 'view') AS view_at,
+				MIN(user_actions.occurred_at) FILTER (WHERE user_actions.action_type = 'save') AS save_at
+			FROM user_actions
+			WHERE user_actions.entity_type = 'job'
+				AND user_actions.action_type IN ('impression', 'view', 'save')
+				AND user_actions.occurred_at >= @from AND user_actions.occurred_at < @to
+				` + touchFilter + `
+			GROUP BY 1, 2
+		), applied AS (
+			SELECT applications.job_id::text AS job_id, applications.user_id::text AS user_id,
+				MIN(` + applyStageAt + `) AS apply_at,
+				MIN(` + interviewStageAt + `) AS interview_at,
+				MIN(` + acceptStageAt + `) AS accept_at,
+				MIN(` + completeStageAt + `) AS complete_at
+			FROM applications
+			JOIN jobs ON jobs.id::text = applications.job_id::text
+			WHERE applications.status <> 'draft'
+				AND applications.applied_at >= @from AND applications.applied_at < @to
+				AND ` + jobFilter + `
+			GROUP BY 1, 2
+		), pairs AS (
+			SELECT COALESCE(touches.job_id, applied.job_id) AS job_id,
+				touches.impression_at, touches.view_at, touches.save_at,
+				applied.apply_at, applied.interview_at, applied.accept_at, applied.complete_at
+			FROM touches
+			FULL OUTER JOIN applied ON applied.job_id = touches.job_id AND applied.user_id = touches.user_id
+		)
+		SELECT ` + key + ` AS key,
+			COUNT(pairs.impression_at) AS impressions,
+			COUNT(pairs.view_at) AS views,
+			COUNT(pairs.save_at) AS saves,
+			COUNT(pairs.apply_at) AS applications,
+			COUNT(pairs.interview_at) AS interviews,
+			COUNT(pairs.accept_at) AS accepted,
+			COUNT(pairs.complete_at) AS completed,
+			` + funnelMedian("pairs.impression_at", "pairs.view_at") + ` AS impression_to_view,
+			` + funnelMedian("pairs.view_at", "pairs.save_at") + ` AS view_to_save,
+			` + funnelMedian("pairs.view_at", "pairs.apply_at") + ` AS view_to_apply,
+			` + funnelMedian("pairs.apply_at", "pairs.interview_at") + ` AS apply_to_interview,
+			` + funnelMedian("pairs.interview_at", "pairs.accept_at") + ` AS interview_to_accept,
+			` + funnelMedian("pairs.accept_at", "pairs.complete_at") + ` AS accept_to_complete
+		FROM pairs
+		JOIN jobs ON jobs.id::text = pairs.job_id
+		WHERE ` + jobFilter + `
+		GROUP BY 1
+		ORDER BY 1`
+
+	var rows []struct {
+		Key                                 string
+		Impressions, Views, Saves           int64
+		Applications, Interviews            int64
+		Accepted, Completed                 int64
+		ImpressionToView, ViewToSave        *float64
+		ViewToApply, ApplyToInterview       *float64
+		InterviewToAccept, AcceptToComplete *float64
+	}
+	if err := r.db.WithContext(ctx).Raw(query, args).Scan(&rows).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to compute conversion funnel", err)
+	}
+
+	stats := make([]FunnelStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, FunnelStats{
+			Key:                     row.Key,
+			Impressions:             row.Impressions,
+			Views:                   row.Views,
+			Saves:                   row.Saves,
+			Applications:            row.Applications,
+			Interviews:              row.Interviews,
+			Accepted:                row.Accepted,
+			Completed:               row.Completed,
+			MedianImpressionToView:  secondsToDuration(row.ImpressionToView),
+			MedianViewToSave:        secondsToDuration(row.ViewToSave),
+			MedianViewToApply:       secondsToDuration(row.ViewToApply),
+			MedianApplyToInterview:  secondsToDuration(row.ApplyToInterview),
+			MedianInterviewToAccept: secondsToDuration(row.InterviewToAccept),
+			MedianAcceptToComplete:  secondsToDuration(row.AcceptToComplete),
+		})
+	}
+	return stats, nil
+}
+
+// CountFunnelTransitions counts the applications reaching each stage during
+// [from, to), by job category and match score range
+func (r *behaviorRepository) CountFunnelTransitions(ctx context.Context, from, to time.Time) ([]FunnelTransitionCount, error) {
+	var counts []FunnelTransitionCount
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT COALESCE(NULLIF(jobs.category, ''), 'uncategorized') AS category,
+			stages.stage,
+			CASE
+				WHEN applications.match_score >= 0.7 THEN '0.7-1.0'
+				WHEN applications.match_score >= 0.5 THEN '0.5-0.7'
+				ELSE '0.0-0.5'
+			END AS match_score_range,
+			COUNT(*) AS count
+		FROM applications
+		JOIN jobs ON jobs.id::text = applications.job_id::text
+		CROSS JOIN LATERAL (VALUES
+			('applied', `+applyStageAt+`),
+			('interviewed', `+interviewStageAt+`),
+			('accepted', `+acceptStageAt+`),
+			('completed', `+completeStageAt+`)
+		) AS stages(stage, reached_at)
+		WHERE applications.status <> 'draft'
+			AND stages.reached_at >= ? AND stages.reached_at < ?
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`, from.UTC(), to.UTC()).Scan(&counts).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to count funnel transitions", err)
+	}
+	return counts, nil
+}
+
+func secondsToDuration(seconds *float64) time.Duration {
+	if seconds == nil {
+		return 0
+	}
+	return time.Duration(*seconds * float64(time.Second))
+}
@@ -9,6 +9,7 @@ import (
 type BehaviorRepository interface {
 	// Action tracking
 	StoreUserAction(ctx context.Context, action UserAction) error
+	StoreUserActions(ctx context.Context, actions []UserAction) error
 	GetUserActions(ctx context.Context, userID string, actionType string, limit int, since time.Time) ([]UserAction, error)
 	GetUserActionHistory(ctx context.Context, userID string, days int) ([]UserAction, error)
 	
@@ -40,6 +41,18 @@ type BehaviorRepository interface {
 	GetBehaviorTrends(ctx context.Context, userID string, days int) (*BehaviorTrends, error)
 	GetCohortBehavior(ctx context.Context, cohortCriteria CohortCriteria) ([]CohortBehaviorData, error)
 	
+	// Signup cohorts
+	RefreshCohortStats(ctx context.Context, from, to time.Time) error
+	GetCohortStats(ctx context.Context, filter CohortStatsFilter) ([]CohortStats, error)
+	
+	// Sessions
+	GetUserSessions(ctx context.Context, userID string, from, to time.Time) ([]UserSession, error)
+	StoreUserSessions(ctx context.Context, sessions []UserSession, deletedIDs []string) error
+	
+	// Marketplace conversion funnel
+	GetFunnelStats(ctx context.Context, filter FunnelFilter) ([]FunnelStats, error)
+	CountFunnelTransitions(ctx context.Context, from, to time.Time) ([]FunnelTransitionCount, error)
+	
 	// Cleanup and maintenance
 	CleanupOldBehaviorData(ctx context.Context, olderThan time.Time) error
 	ArchiveBehaviorData(ctx context.Context, userID string, archiveDate time.Time) error
@@ -224,4 +237,98 @@ type CohortBehaviorData struct {
 	SuccessRate     float64                `json:"success_rate" bson:"success_rate"` // Job placement success rate
 	RetentionRate   float64                `json:"retention_rate" bson:"retention_rate"`
 	CalculatedAt    time.Time              `json:"calculated_at" bson:"calculated_at"`
-}
\ No newline at end of file
+}
+
+// CohortStats holds additive counts for the users who signed up in the same week
+// with the same user type and acquisition source. Counts rather than rates are
+// stored so cohorts can be rolled up across user types and sources.
+type CohortStats struct {
+	CohortWeek        time.Time `json:"cohort_week"`        // Monday of the signup week
+	UserType          string    `json:"user_type"`
+	AcquisitionSource string    `json:"acquisition_source"` // Empty when the signup was not attributed
+	CohortSize        int       `json:"cohort_size"`
+	RetainedUsers     []int     `json:"retained_users"`     // Active users per week since signup; index 0 is the signup week
+	SuccessfulUsers   int       `json:"successful_users"`   // Users with an accepted application
+	DaysToSuccess     float64   `json:"days_to_success"`    // Sum of days from signup to first accepted application
+	TotalActions      int64     `json:"total_actions"`
+	TotalApplications int64     `json:"total_applications"`
+	CalculatedAt      time.Time `json:"calculated_at"`
+}
+
+type CohortStatsFilter struct {
+	From              time.Time // Earliest signup week, inclusive
+	To                time.Time // Latest signup week, exclusive
+	UserType          string
+	AcquisitionSource string
+}
+
+// Funnel breakdowns
+const (
+	FunnelScopePlatform = "platform"
+	FunnelScopeCategory = "category"
+	FunnelScopeEmployer = "employer"
+	FunnelScopeJob      = "job"
+)
+
+// FunnelFilter selects the job/user pairs counted in a funnel. Pairs are counted
+// when their first tracked touch or their application falls inside [From, To);
+// later stages count no matter when they were reached.
+type FunnelFilter struct {
+	Scope      string // One of the FunnelScope constants; rows are keyed by it
+	From       time.Time
+	To         time.Time
+	JobID      string
+	EmployerID string
+	Category   string
+}
+
+// FunnelStats counts the distinct job/user pairs reaching each stage. Medians
+// are taken over pairs that reached both ends of a transition.
+type FunnelStats struct {
+	Key          string `json:"key"` // Job ID, employer ID or category; empty platform-wide
+	Impressions  int64  `json:"impressions"`
+	Views        int64  `json:"views"`
+	Saves        int64  `json:"saves"`
+	Applications int64  `json:"applications"`
+	Interviews   int64  `json:"interviews"`
+	Accepted     int64  `json:"accepted"`
+	Completed    int64  `json:"completed"`
+
+	MedianImpressionToView  time.Duration `json:"median_impression_to_view"`
+	MedianViewToSave        time.Duration `json:"median_view_to_save"`
+	MedianViewToApply       time.Duration `json:"median_view_to_apply"`
+	MedianApplyToInterview  time.Duration `json:"median_apply_to_interview"`
+	MedianInterviewToAccept time.Duration `json:"median_interview_to_accept"`
+	MedianAcceptToComplete  time.Duration `json:"median_accept_to_complete"`
+}
+
+// FunnelTransitionCount is the number of applications reaching a stage in a window
+type FunnelTransitionCount struct {
+	Category        string `json:"category"`
+	Stage           string `json:"stage"`             // "applied", "interviewed", "accepted" or "completed"
+	MatchScoreRange string `json:"match_score_range"` // "0.0-0.5", "0.5-0.7" or "0.7-1.0"
+	Count           int64  `json:"count"`
+}
+
+// UserSession is a run of a user's actions with no gap longer than the
+// inactivity timeout. Counts are additive so sessions can be merged when a
+// late action bridges two of them.
+type UserSession struct {
+	ID              string     `json:"id"`
+	UserID          string     `json:"user_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         time.Time  `json:"ended_at"` // Time of the last action
+	ActionCount     int        `json:"action_count"`
+	Views           int        `json:"views"`
+	Applications    int        `json:"applications"`
+	Saves           int        `json:"saves"`
+	Searches        int        `json:"searches"`
+	Quality         float64    `json:"quality"` // Sum of the actions' intent weights
+	EngagementScore float64    `json:"engagement_score"`
+	DeviceInfo      DeviceInfo `json:"device_info"` // Device of the first action that reported one
+}
+
+// Duration is the time between the session's first and last action
+func (s UserSession) Duration() time.Duration {
+	return s.EndedAt.Sub(s.StartedAt)
+}
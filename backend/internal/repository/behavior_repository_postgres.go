@@ -0,0 +1,845 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// actionPartitionPattern matches the monthly partitions of user_actions
+var actionPartitionPattern = regexp.MustCompile(`^user_actions_(\d{4})_(\d{2})$`)
+
+// actionInsertBatchSize bounds the rows sent in one INSERT when storing action batches
+const actionInsertBatchSize = 500
+
+// Persisted forms of the behavior domain models. Nested structures are stored as jsonb.
+
+type userActionRecord struct {
+	ID         string `gorm:"primaryKey"`
+	UserID     string
+	ActionType string
+	EntityID   string
+	EntityType string
+	Data       string `gorm:"type:jsonb"`
+	SessionID  string
+	DeviceInfo string    `gorm:"type:jsonb"`
+	Location   string    `gorm:"type:jsonb"`
+	OccurredAt time.Time `gorm:"primaryKey"`
+}
+
+func (userActionRecord) TableName() string {
+	return "user_actions"
+}
+
+type behaviorPatternRecord struct {
+	UserID              string `gorm:"primaryKey"`
+	ActivityLevel       string
+	PreferredSkills     string `gorm:"type:jsonb"`
+	PreferredIndustries string `gorm:"type:jsonb"`
+	PreferredLocations  string `gorm:"type:jsonb"`
+	WorkPreferences     string `gorm:"type:jsonb"`
+	AvgApplicationRate  float64
+	AvgEngagementTime   int64  // Nanoseconds
+	SearchPatterns      string `gorm:"type:jsonb"`
+	BehaviorVector      string `gorm:"type:jsonb"`
+	SuccessfulMatches   string `gorm:"type:jsonb"`
+	RejectedMatches     string `gorm:"type:jsonb"`
+	JourneyStage        string
+	LastAnalyzed        time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (behaviorPatternRecord) TableName() string {
+	return "user_behavior_patterns"
+}
+
+type preferenceSignalRecord struct {
+	ID            string `gorm:"primaryKey"`
+	UserID        string
+	SignalType    string
+	Value         float64
+	PreviousValue *float64
+	Confidence    float64
+	Source        string
+	LastUpdated   time.Time
+	CreatedAt     time.Time
+}
+
+func (preferenceSignalRecord) TableName() string {
+	return "user_preference_signals"
+}
+
+type engagementMetricsRecord struct {
+	UserID            string `gorm:"primaryKey"`
+	TotalViews        int64
+	TotalApplications int64
+	TotalSearches     int64
+	TotalSaves        int64
+	AverageTimePerJob int64 // Nanoseconds
+	SessionFrequency  float64
+	ApplicationRate   float64
+	SearchToViewRatio float64
+	EngagementTrend   string
+	LastActiveSession time.Time
+	TotalSessions     int64
+	WeeklyEngagement  string `gorm:"type:jsonb"`
+	LastCalculated    time.Time
+}
+
+func (engagementMetricsRecord) TableName() string {
+	return "user_engagement_metrics"
+}
+
+type searchPatternRecord struct {
+	ID          string `gorm:"primaryKey"`
+	UserID      string
+	Query       string
+	Filters     string `gorm:"type:jsonb"`
+	ResultCount int
+	ClickedJobs string `gorm:"type:jsonb"`
+	SessionID   string
+	SearchedAt  time.Time
+}
+
+func (searchPatternRecord) TableName() string {
+	return "user_search_patterns"
+}
+
+type skillInterestRecord struct {
+	UserID           string `gorm:"primaryKey"`
+	SkillName        string `gorm:"primaryKey"`
+	Interest         float64
+	PreviousInterest *float64
+	UpdatedAt        time.Time
+}
+
+func (skillInterestRecord) TableName() string {
+	return "user_skill_interests"
+}
+
+type behaviorRepository struct {
+	db *gorm.DB
+
+	// Monthly action partitions known to exist, keyed by "2006_01"
+	partitions sync.Map
+}
+
+// NewBehaviorRepository creates a Postgres-backed behavior repository. Actions are
+// written to monthly range partitions of user_actions, which are created on first use
+// and dropped whole by CleanupOldBehaviorData.
+func NewBehaviorRepository(db *gorm.DB) BehaviorRepository {
+	return &behaviorRepository{db: db}
+}
+
+// Action tracking
+
+func (r *behaviorRepository) StoreUserAction(ctx context.Context, action UserAction) error {
+	if action.ID == "" {
+		action.ID = uuid.New().String()
+	}
+	if action.Timestamp.IsZero() {
+		action.Timestamp = time.Now()
+	}
+
+	record, err := actionToRecord(action)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode user action", err)
+	}
+	if err := r.ensureActionPartition(ctx, record.OccurredAt); err != nil {
+		return apperrors.NewAppError(500, "Failed to prepare user action partition", err)
+	}
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to store user action", err)
+	}
+	return nil
+}
+
+// StoreUserActions inserts a batch of actions. Actions that already exist are
+// skipped, so a batch can be retried after a partial failure.
+func (r *behaviorRepository) StoreUserActions(ctx context.Context, actions []UserAction) error {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	records := make([]*userActionRecord, 0, len(actions))
+	for _, action := range actions {
+		if action.ID == "" {
+			action.ID = uuid.New().String()
+		}
+		if action.Timestamp.IsZero() {
+			action.Timestamp = now
+		}
+		record, err := actionToRecord(action)
+		if err != nil {
+			return apperrors.NewAppError(500, "Failed to encode user action", err)
+		}
+		if err := r.ensureActionPartition(ctx, record.OccurredAt); err != nil {
+			return apperrors.NewAppError(500, "Failed to prepare user action partition", err)
+		}
+		records = append(records, record)
+	}
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(records, actionInsertBatchSize).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to store user actions", err)
+	}
+	return nil
+}
+
+func (r *behaviorRepository) GetUserActions(ctx context.Context, userID string, actionType string, limit int, since time.Time) ([]UserAction, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ? AND occurred_at >= ?", userID, since.UTC())
+	if actionType != "" {
+		query = query.Where("action_type = ?", actionType)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var records []*userActionRecord
+	if err := query.Order("occurred_at DESC").Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get user actions", err)
+	}
+	return recordsToActions(records)
+}
+
+// GetUserActionHistory returns the user's actions over the last days in chronological order
+func (r *behaviorRepository) GetUserActionHistory(ctx context.Context, userID string, days int) ([]UserAction, error) {
+	since := time.Now().UTC().AddDate(0, 0, -days)
+
+	var records []*userActionRecord
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND occurred_at >= ?", userID, since).
+		Order("occurred_at ASC").
+		Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get user action history", err)
+	}
+	return recordsToActions(records)
+}
+
+// Behavioral patterns
+
+func (r *behaviorRepository) StoreUserBehaviorPattern(ctx context.Context, pattern UserBehaviorPattern) error {
+	now := time.Now().UTC()
+	if pattern.CreatedAt.IsZero() {
+		pattern.CreatedAt = now
+	}
+	pattern.UpdatedAt = now
+
+	record, err := patternToRecord(pattern)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode behavior pattern", err)
+	}
+	// A user has a single pattern, so storing again replaces it but keeps created_at
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns(patternUpdateColumns),
+	}).Create(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to store behavior pattern", err)
+	}
+	return nil
+}
+
+func (r *behaviorRepository) GetUserBehaviorPattern(ctx context.Context, userID string) (*UserBehaviorPattern, error) {
+	var record behaviorPatternRecord
+	if err := r.db.WithContext(ctx).First(&record, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Behavior pattern")
+		}
+		return nil, apperrors.NewAppError(500, "Failed to get behavior pattern", err)
+	}
+
+	pattern, err := recordToPattern(&record)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to decode behavior pattern", err)
+	}
+	return pattern, nil
+}
+
+func (r *behaviorRepository) UpdateUserBehaviorPattern(ctx context.Context, pattern UserBehaviorPattern) error {
+	pattern.UpdatedAt = time.Now().UTC()
+	record, err := patternToRecord(pattern)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode behavior pattern", err)
+	}
+
+	result := r.db.WithContext(ctx).Model(&behaviorPatternRecord{}).
+		Where("user_id = ?", pattern.UserID).
+		Select(patternUpdateColumns).
+		Updates(record)
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to update behavior pattern", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewNotFoundError("Behavior pattern")
+	}
+	return nil
+}
+
+// Preference signals
+
+// StorePreferenceSignal upserts the signal for its user and type, keeping the value it
+// replaces so preference shifts can be reported
+func (r *behaviorRepository) StorePreferenceSignal(ctx context.Context, signal PreferenceSignal) error {
+	now := time.Now().UTC()
+	if signal.ID == "" {
+		signal.ID = uuid.New().String()
+	}
+	if signal.LastUpdated.IsZero() {
+		signal.LastUpdated = now
+	}
+	if signal.CreatedAt.IsZero() {
+		signal.CreatedAt = now
+	}
+
+	record := &preferenceSignalRecord{
+		ID:          signal.ID,
+		UserID:      signal.UserID,
+		SignalType:  signal.SignalType,
+		Value:       signal.Value,
+		Confidence:  signal.Confidence,
+		Source:      signal.Source,
+		LastUpdated: signal.LastUpdated.UTC(),
+		CreatedAt:   signal.CreatedAt.UTC(),
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "signal_type"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "previous_value"}, Value: gorm.Expr("user_preference_signals.value")},
+			{Column: clause.Column{Name: "value"}, Value: gorm.Expr("EXCLUDED.value")},
+			{Column: clause.Column{Name: "confidence"}, Value: gorm.Expr("EXCLUDED.confidence")},
+			{Column: clause.Column{Name: "source"}, Value: gorm.Expr("EXCLUDED.source")},
+			{Column: clause.Column{Name: "last_updated"}, Value: gorm.Expr("EXCLUDED.last_updated")},
+		},
+	}).Create(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to store preference signal", err)
+	}
+	return nil
+}
+
+func (r *behaviorRepository) GetPreferenceSignals(ctx context.Context, userID string) ([]PreferenceSignal, error) {
+	var records []*preferenceSignalRecord
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("signal_type").Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get preference signals", err)
+	}
+
+	signals := make([]PreferenceSignal, len(records))
+	for i, record := range records {
+		signals[i] = PreferenceSignal{
+			ID:          record.ID,
+			UserID:      record.UserID,
+			SignalType:  record.SignalType,
+			Value:       record.Value,
+			Confidence:  record.Confidence,
+			Source:      record.Source,
+			LastUpdated: record.LastUpdated,
+			CreatedAt:   record.CreatedAt,
+		}
+	}
+	return signals, nil
+}
+
+// UpdatePreferenceSignal sets the value of a signal, creating an inferred signal with
+// low confidence when the user has none of that type yet
+func (r *behaviorRepository) UpdatePreferenceSignal(ctx context.Context, userID, signalType string, value float64) error {
+	result := r.db.WithContext(ctx).Model(&preferenceSignalRecord{}).
+		Where("user_id = ? AND signal_type = ?", userID, signalType).
+		Updates(map[string]interface{}{
+			"previous_value": gorm.Expr("value"),
+			"value":          value,
+			"last_updated":   time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to update preference signal", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	return r.StorePreferenceSignal(ctx, PreferenceSignal{
+		UserID:     userID,
+		SignalType: signalType,
+		Value:      value,
+		Confidence: 0.1,
+		Source:     "inferred",
+	})
+}
+
+// Engagement metrics
+
+func (r *behaviorRepository) StoreEngagementMetrics(ctx context.Context, metrics EngagementMetrics) error {
+	if metrics.LastCalculated.IsZero() {
+		metrics.LastCalculated = time.Now()
+	}
+	record, err := metricsToRecord(metrics)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode engagement metrics", err)
+	}
+	if err := r.db.WithContext(ctx).Save(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to store engagement metrics", err)
+	}
+	return nil
+}
+
+func (r *behaviorRepository) GetEngagementMetrics(ctx context.Context, userID string) (*EngagementMetrics, error) {
+	var record engagementMetricsRecord
+	if err := r.db.WithContext(ctx).First(&record, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Engagement metrics")
+		}
+		return nil, apperrors.NewAppError(500, "Failed to get engagement metrics", err)
+	}
+
+	metrics, err := recordToMetrics(&record)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to decode engagement metrics", err)
+	}
+	return metrics, nil
+}
+
+// UpdateEngagementMetrics applies the non-zero fields of updates, as GORM does for
+// struct updates, so callers can send only what changed
+func (r *behaviorRepository) UpdateEngagementMetrics(ctx context.Context, userID string, updates EngagementMetrics) error {
+	record, err := metricsToRecord(updates)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode engagement metrics", err)
+	}
+	record.UserID = ""
+	if len(updates.WeeklyEngagement) == 0 {
+		record.WeeklyEngagement = ""
+	}
+	if updates.LastCalculated.IsZero() {
+		record.LastCalculated = time.Now().UTC()
+	}
+
+	result := r.db.WithContext(ctx).Model(&engagementMetricsRecord{}).Where("user_id = ?", userID).Updates(record)
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to update engagement metrics", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewNotFoundError("Engagement metrics")
+	}
+	return nil
+}
+
+// Search patterns
+
+func (r *behaviorRepository) StoreSearchPattern(ctx context.Context, pattern SearchPattern) error {
+	if pattern.ID == "" {
+		pattern.ID = uuid.New().String()
+	}
+	if pattern.Timestamp.IsZero() {
+		pattern.Timestamp = time.Now()
+	}
+
+	filters, err := encodeJSON(pattern.Filters, "{}")
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode search filters", err)
+	}
+	clicked, err := encodeJSON(pattern.ClickedJobs, "[]")
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode clicked jobs", err)
+	}
+
+	record := &searchPatternRecord{
+		ID:          pattern.ID,
+		UserID:      pattern.UserID,
+		Query:       pattern.Query,
+		Filters:     filters,
+		ResultCount: pattern.ResultCount,
+		ClickedJobs: clicked,
+		SessionID:   pattern.SessionID,
+		SearchedAt:  pattern.Timestamp.UTC(),
+	}
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to store search pattern", err)
+	}
+	return nil
+}
+
+func (r *behaviorRepository) GetSearchPatterns(ctx context.Context, userID string, limit int) ([]SearchPattern, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("searched_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var records []*searchPatternRecord
+	if err := query.Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get search patterns", err)
+	}
+
+	patterns := make([]SearchPattern, len(records))
+	for i, record := range records {
+		patterns[i] = SearchPattern{
+			ID:          record.ID,
+			UserID:      record.UserID,
+			Query:       record.Query,
+			ResultCount: record.ResultCount,
+			SessionID:   record.SessionID,
+			Timestamp:   record.SearchedAt,
+		}
+		if err := decodeJSON(record.Filters, &patterns[i].Filters); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode search filters", err)
+		}
+		if err := decodeJSON(record.ClickedJobs, &patterns[i].ClickedJobs); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode clicked jobs", err)
+		}
+	}
+	return patterns, nil
+}
+
+// Skill interests
+
+// UpdateSkillInterest sets the interest score for a skill, keeping the previous score
+// so trends can report the change
+func (r *behaviorRepository) UpdateSkillInterest(ctx context.Context, userID, skillName string, interest float64) error {
+	record := &skillInterestRecord{
+		UserID:    userID,
+		SkillName: skillName,
+		Interest:  interest,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "skill_name"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "previous_interest"}, Value: gorm.Expr("user_skill_interests.interest")},
+			{Column: clause.Column{Name: "interest"}, Value: gorm.Expr("EXCLUDED.interest")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("EXCLUDED.updated_at")},
+		},
+	}).Create(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to update skill interest", err)
+	}
+	return nil
+}
+
+func (r *behaviorRepository) GetSkillInterests(ctx context.Context, userID string) (map[string]float64, error) {
+	var records []*skillInterestRecord
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get skill interests", err)
+	}
+
+	interests := make(map[string]float64, len(records))
+	for _, record := range records {
+		interests[record.SkillName] = record.Interest
+	}
+	return interests, nil
+}
+
+// Cleanup and maintenance
+
+// CleanupOldBehaviorData deletes actions, searches and sessions older than the cutoff. Whole
+// monthly partitions before the cutoff are dropped rather than deleted row by row.
+func (r *behaviorRepository) CleanupOldBehaviorData(ctx context.Context, olderThan time.Time) error {
+	olderThan = olderThan.UTC()
+	partitions, err := r.listActionPartitions(ctx)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to list user action partitions", err)
+	}
+
+	db := r.db.WithContext(ctx)
+	for name, month := range partitions {
+		if month.AddDate(0, 1, 0).After(olderThan) {
+			continue
+		}
+		if err := db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", name)).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to drop user action partition", err)
+		}
+		r.partitions.Delete(month.Format("2006_01"))
+	}
+
+	if err := db.Where("occurred_at < ?", olderThan).Delete(&userActionRecord{}).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to delete old user actions", err)
+	}
+	if err := db.Exec("DELETE FROM user_actions_archive WHERE occurred_at < ?", olderThan).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to delete old archived actions", err)
+	}
+	if err := db.Where("searched_at < ?", olderThan).Delete(&searchPatternRecord{}).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to delete old search patterns", err)
+	}
+	if err := db.Where("ended_at < ?", olderThan).Delete(&userSessionRecord{}).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to delete old user sessions", err)
+	}
+	return nil
+}
+
+// ArchiveBehaviorData moves the user's actions before archiveDate out of the hot,
+// partitioned table into user_actions_archive
+func (r *behaviorRepository) ArchiveBehaviorData(ctx context.Context, userID string, archiveDate time.Time) error {
+	archiveDate = archiveDate.UTC()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO user_actions_archive
+				(id, user_id, action_type, entity_id, entity_type, data, session_id, device_info, location, occurred_at)
+			SELECT id, user_id, action_type, entity_id, entity_type, data, session_id, device_info, location, occurred_at
+			FROM user_actions
+			WHERE user_id = ? AND occurred_at < ?
+			ON CONFLICT DO NOTHING`, userID, archiveDate).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND occurred_at < ?", userID, archiveDate).Delete(&userActionRecord{}).Error
+	})
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to archive behavior data", err)
+	}
+	return nil
+}
+
+// Private methods
+
+// ensureActionPartition creates the monthly partition that holds at, if missing
+func (r *behaviorRepository) ensureActionPartition(ctx context.Context, at time.Time) error {
+	month := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+	key := month.Format("2006_01")
+	if _, ok := r.partitions.Load(key); ok {
+		return nil
+	}
+
+	name := "user_actions_" + key
+	statement := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s PARTITION OF user_actions FOR VALUES FROM ('%s') TO ('%s')",
+		name, month.Format("2006-01-02"), month.AddDate(0, 1, 0).Format("2006-01-02"),
+	)
+	if err := r.db.WithContext(ctx).Exec(statement).Error; err != nil {
+		// Another instance may have created the partition between our check and create
+		var exists bool
+		if lookupErr := r.db.WithContext(ctx).Raw("SELECT to_regclass(?) IS NOT NULL", name).Scan(&exists).Error; lookupErr != nil || !exists {
+			return err
+		}
+	}
+	r.partitions.Store(key, struct{}{})
+	return nil
+}
+
+// listActionPartitions returns the monthly partitions of user_actions by name
+func (r *behaviorRepository) listActionPartitions(ctx context.Context) (map[string]time.Time, error) {
+	var names []string
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class child ON pg_inherits.inhrelid = child.oid
+		WHERE pg_inherits.inhparent = 'user_actions'::regclass`).Scan(&names).Error; err != nil {
+		return nil, err
+	}
+
+	partitions := make(map[string]time.Time, len(names))
+	for _, name := range names {
+		match := actionPartitionPattern.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		month, err := time.Parse("2006-01", match[1]+"-"+match[2])
+		if err != nil {
+			continue
+		}
+		partitions[name] = month
+	}
+	return partitions, nil
+}
+
+// Conversion helpers
+
+// patternUpdateColumns are overwritten when a stored pattern is replaced or updated
+var patternUpdateColumns = []string{
+	"activity_level", "preferred_skills", "preferred_industries", "preferred_locations",
+	"work_preferences", "avg_application_rate", "avg_engagement_time", "search_patterns",
+	"behavior_vector", "successful_matches", "rejected_matches", "journey_stage",
+	"last_analyzed", "updated_at",
+}
+
+func actionToRecord(action UserAction) (*userActionRecord, error) {
+	data, err := encodeJSON(action.Data, "{}")
+	if err != nil {
+		return nil, err
+	}
+	device, err := encodeJSON(action.DeviceInfo, "{}")
+	if err != nil {
+		return nil, err
+	}
+	location, err := encodeJSON(action.Location, "{}")
+	if err != nil {
+		return nil, err
+	}
+	return &userActionRecord{
+		ID:         action.ID,
+		UserID:     action.UserID,
+		ActionType: action.ActionType,
+		EntityID:   action.EntityID,
+		EntityType: action.EntityType,
+		Data:       data,
+		SessionID:  action.SessionID,
+		DeviceInfo: device,
+		Location:   location,
+		OccurredAt: action.Timestamp.UTC(),
+	}, nil
+}
+
+func recordsToActions(records []*userActionRecord) ([]UserAction, error) {
+	actions := make([]UserAction, len(records))
+	for i, record := range records {
+		actions[i] = UserAction{
+			ID:         record.ID,
+			UserID:     record.UserID,
+			ActionType: record.ActionType,
+			EntityID:   record.EntityID,
+			EntityType: record.EntityType,
+			SessionID:  record.SessionID,
+			Timestamp:  record.OccurredAt,
+		}
+		if err := decodeJSON(record.Data, &actions[i].Data); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode user action", err)
+		}
+		if err := decodeJSON(record.DeviceInfo, &actions[i].DeviceInfo); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode user action", err)
+		}
+		if err := decodeJSON(record.Location, &actions[i].Location); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode user action", err)
+		}
+	}
+	return actions, nil
+}
+
+func patternToRecord(pattern UserBehaviorPattern) (*behaviorPatternRecord, error) {
+	record := &behaviorPatternRecord{
+		UserID:             pattern.UserID,
+		ActivityLevel:      pattern.ActivityLevel,
+		AvgApplicationRate: pattern.AvgApplicationRate,
+		AvgEngagementTime:  int64(pattern.AvgEngagementTime),
+		JourneyStage:       pattern.JourneyStage,
+		LastAnalyzed:       pattern.LastAnalyzed.UTC(),
+		CreatedAt:          pattern.CreatedAt.UTC(),
+		UpdatedAt:          pattern.UpdatedAt.UTC(),
+	}
+
+	fields := []struct {
+		target   *string
+		value    interface{}
+		fallback string
+	}{
+		{&record.PreferredSkills, pattern.PreferredSkills, "[]"},
+		{&record.PreferredIndustries, pattern.PreferredIndustries, "[]"},
+		{&record.PreferredLocations, pattern.PreferredLocations, "[]"},
+		{&record.WorkPreferences, pattern.WorkPreferences, "{}"},
+		{&record.SearchPatterns, pattern.SearchPatterns, "[]"},
+		{&record.BehaviorVector, pattern.BehaviorVector, "[]"},
+		{&record.SuccessfulMatches, pattern.SuccessfulMatches, "[]"},
+		{&record.RejectedMatches, pattern.RejectedMatches, "[]"},
+	}
+	for _, field := range fields {
+		encoded, err := encodeJSON(field.value, field.fallback)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = encoded
+	}
+	return record, nil
+}
+
+func recordToPattern(record *behaviorPatternRecord) (*UserBehaviorPattern, error) {
+	pattern := &UserBehaviorPattern{
+		UserID:             record.UserID,
+		ActivityLevel:      record.ActivityLevel,
+		AvgApplicationRate: record.AvgApplicationRate,
+		AvgEngagementTime:  time.Duration(record.AvgEngagementTime),
+		JourneyStage:       record.JourneyStage,
+		LastAnalyzed:       record.LastAnalyzed,
+		CreatedAt:          record.CreatedAt,
+		UpdatedAt:          record.UpdatedAt,
+	}
+
+	fields := []struct {
+		value  string
+		target interface{}
+	}{
+		{record.PreferredSkills, &pattern.PreferredSkills},
+		{record.PreferredIndustries, &pattern.PreferredIndustries},
+		{record.PreferredLocations, &pattern.PreferredLocations},
+		{record.WorkPreferences, &pattern.WorkPreferences},
+		{record.SearchPatterns, &pattern.SearchPatterns},
+		{record.BehaviorVector, &pattern.BehaviorVector},
+		{record.SuccessfulMatches, &pattern.SuccessfulMatches},
+		{record.RejectedMatches, &pattern.RejectedMatches},
+	}
+	for _, field := range fields {
+		if err := decodeJSON(field.value, field.target); err != nil {
+			return nil, err
+		}
+	}
+	return pattern, nil
+}
+
+func metricsToRecord(metrics EngagementMetrics) (*engagementMetricsRecord, error) {
+	weekly, err := encodeJSON(metrics.WeeklyEngagement, "[]")
+	if err != nil {
+		return nil, err
+	}
+	return &engagementMetricsRecord{
+		UserID:            metrics.UserID,
+		TotalViews:        metrics.TotalViews,
+		TotalApplications: metrics.TotalApplications,
+		TotalSearches:     metrics.TotalSearches,
+		TotalSaves:        metrics.TotalSaves,
+		AverageTimePerJob: int64(metrics.AverageTimePerJob),
+		SessionFrequency:  metrics.SessionFrequency,
+		ApplicationRate:   metrics.ApplicationRate,
+		SearchToViewRatio: metrics.SearchToViewRatio,
+		EngagementTrend:   metrics.EngagementTrend,
+		LastActiveSession: metrics.LastActiveSession.UTC(),
+		TotalSessions:     metrics.TotalSessions,
+		WeeklyEngagement:  weekly,
+		LastCalculated:    metrics.LastCalculated.UTC(),
+	}, nil
+}
+
+func recordToMetrics(record *engagementMetricsRecord) (*EngagementMetrics, error) {
+	metrics := &EngagementMetrics{
+		UserID:            record.UserID,
+		TotalViews:        record.TotalViews,
+		TotalApplications: record.TotalApplications,
+		TotalSearches:     record.TotalSearches,
+		TotalSaves:        record.TotalSaves,
+		AverageTimePerJob: time.Duration(record.AverageTimePerJob),
+		SessionFrequency:  record.SessionFrequency,
+		ApplicationRate:   record.ApplicationRate,
+		SearchToViewRatio: record.SearchToViewRatio,
+		EngagementTrend:   record.EngagementTrend,
+		LastActiveSession: record.LastActiveSession,
+		TotalSessions:     record.TotalSessions,
+		LastCalculated:    record.LastCalculated,
+	}
+	if err := decodeJSON(record.WeeklyEngagement, &metrics.WeeklyEngagement); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// encodeJSON marshals a value for a jsonb column, storing nil maps and slices as the
+// column's empty default rather than null
+func encodeJSON(value interface{}, empty string) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	if string(encoded) == "null" {
+		return empty, nil
+	}
+	return string(encoded), nil
+}
+
+func decodeJSON(value string, target interface{}) error {
+	if value == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(value), target)
+}
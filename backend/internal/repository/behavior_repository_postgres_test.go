@@ -0,0 +1,513 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/database/migrations"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newBehaviorTestDB opens TEST_DATABASE_URL in a throwaway schema holding the behavior
+// tables and the parts of users, jobs and applications the analytics queries read
+func newBehaviorTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set; skipping Postgres integration test")
+	}
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	schema := fmt.Sprintf("behavior_test_%d", time.Now().UnixNano())
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("TEST_DATABASE_URL must be a URL: %v", err)
+	}
+	query := parsed.Query()
+	query.Set("search_path", schema)
+	parsed.RawQuery = query.Encode()
+
+	db, err := gorm.Open(postgres.Open(parsed.String()), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to connect to test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if err := db.Exec(`
+		CREATE TABLE users (id UUID PRIMARY KEY, user_type VARCHAR(20), created_at TIMESTAMP);
+		CREATE TABLE jobs (id TEXT PRIMARY KEY, employer_id TEXT, category TEXT, status TEXT, hired_student_id TEXT,
+			completed_at TIMESTAMP, updated_at TIMESTAMP);
+		CREATE TABLE applications (id UUID PRIMARY KEY, user_id UUID, job_id TEXT, status VARCHAR(20),
+			match_score DOUBLE PRECISION DEFAULT 0, applied_at TIMESTAMP, reviewed_at TIMESTAMP, response_at TIMESTAMP,
+			interview_scheduled TIMESTAMP, updated_at TIMESTAMP);
+		CREATE TABLE application_status_history (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), application_id UUID,
+			from_status VARCHAR(20), to_status VARCHAR(20), created_at TIMESTAMP);
+	`).Error; err != nil {
+		t.Fatalf("Failed to create fixture tables: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "create_user_behavior_tables" || migration.Name == "create_user_cohort_stats" ||
+			migration.Name == "create_user_sessions" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+	return db
+}
+
+// fixture is one seeding statement. Postgres won't prepare several statements at
+// once, so each one that takes arguments runs on its own.
+type fixture struct {
+	sql  string
+	args []interface{}
+}
+
+// seedFixtures runs the fixtures in order, failing the test on the first error
+func seedFixtures(t *testing.T, db *gorm.DB, fixtures ...fixture) {
+	t.Helper()
+	for _, f := range fixtures {
+		if err := db.Exec(f.sql, f.args...).Error; err != nil {
+			t.Fatalf("Failed to insert fixture %q: %v", f.sql, err)
+		}
+	}
+}
+
+func TestBehaviorRepository_ActionsAndPartitions(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	repo := NewBehaviorRepository(db)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	now := time.Now().UTC()
+	lastYear := now.AddDate(-1, 0, 0)
+
+	for _, action := range []UserAction{
+		{UserID: userID, ActionType: "view", EntityID: "job-1", EntityType: "job", Data: map[string]interface{}{"time_spent": 120.0}, Timestamp: now.Add(-2 * time.Hour), SessionID: "s1"},
+		{UserID: userID, ActionType: "apply", EntityID: "job-1", EntityType: "job", Timestamp: now.Add(-time.Hour), SessionID: "s1"},
+		{UserID: userID, ActionType: "view", EntityID: "job-0", EntityType: "job", Timestamp: lastYear},
+	} {
+		if err := repo.StoreUserAction(ctx, action); err != nil {
+			t.Fatalf("StoreUserAction failed: %v", err)
+		}
+	}
+
+	history, err := repo.GetUserActionHistory(ctx, userID, 7)
+	if err != nil {
+		t.Fatalf("GetUserActionHistory failed: %v", err)
+	}
+	if len(history) != 2 || history[0].ActionType != "view" || history[1].ActionType != "apply" {
+		t.Fatalf("Expected two recent actions in chronological order, got %+v", history)
+	}
+	if history[0].Data["time_spent"] != 120.0 {
+		t.Errorf("Expected action data to round-trip, got %v", history[0].Data)
+	}
+
+	views, err := repo.GetUserActions(ctx, userID, "view", 10, lastYear.Add(-time.Hour))
+	if err != nil || len(views) != 2 {
+		t.Fatalf("Expected views across both partitions, got %d (%v)", len(views), err)
+	}
+
+	journey, err := repo.GetUserJourney(ctx, userID, 7)
+	if err != nil || len(journey) != 2 {
+		t.Fatalf("GetUserJourney returned %d points (%v)", len(journey), err)
+	}
+	if journey[0].EngagementScore <= actionQuality["view"] || journey[1].EngagementScore != 1 {
+		t.Errorf("Unexpected journey scores: %v, %v", journey[0].EngagementScore, journey[1].EngagementScore)
+	}
+
+	if err := repo.CleanupOldBehaviorData(ctx, now.AddDate(0, -6, 0)); err != nil {
+		t.Fatalf("CleanupOldBehaviorData failed: %v", err)
+	}
+	var exists bool
+	db.Raw("SELECT to_regclass(?) IS NOT NULL", "user_actions_"+lastYear.Format("2006_01")).Scan(&exists)
+	if exists {
+		t.Error("Expected last year's partition to be dropped")
+	}
+	if remaining, _ := repo.GetUserActions(ctx, userID, "", 0, lastYear.Add(-time.Hour)); len(remaining) != 2 {
+		t.Errorf("Expected recent actions to survive cleanup, got %d", len(remaining))
+	}
+
+	if err := repo.ArchiveBehaviorData(ctx, userID, now.Add(-90*time.Minute)); err != nil {
+		t.Fatalf("ArchiveBehaviorData failed: %v", err)
+	}
+	var archived int64
+	db.Table("user_actions_archive").Where("user_id = ?", userID).Count(&archived)
+	if remaining, _ := repo.GetUserActionHistory(ctx, userID, 7); archived != 1 || len(remaining) != 1 {
+		t.Errorf("Expected one action archived and one left, got %d and %d", archived, len(remaining))
+	}
+}
+
+func TestBehaviorRepository_SignalsMetricsAndTrends(t *testing.T) {
+	repo := NewBehaviorRepository(newBehaviorTestDB(t))
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	// Patterns
+	if _, err := repo.GetUserBehaviorPattern(ctx, userID); !isNotFound(err) {
+		t.Errorf("Expected not found for a missing pattern, got %v", err)
+	}
+	if err := repo.UpdateUserBehaviorPattern(ctx, UserBehaviorPattern{UserID: userID}); !isNotFound(err) {
+		t.Errorf("Expected not found updating a missing pattern, got %v", err)
+	}
+	pattern := UserBehaviorPattern{
+		UserID:          userID,
+		ActivityLevel:   "high",
+		PreferredSkills: []string{"Go"},
+		WorkPreferences: WorkPreferences{RemotePreference: 0.5, SkillWeights: map[string]float64{"Go": 1}},
+		JourneyStage:    "explorer",
+	}
+	if err := repo.StoreUserBehaviorPattern(ctx, pattern); err != nil {
+		t.Fatalf("StoreUserBehaviorPattern failed: %v", err)
+	}
+	pattern.JourneyStage = "focused"
+	if err := repo.UpdateUserBehaviorPattern(ctx, pattern); err != nil {
+		t.Fatalf("UpdateUserBehaviorPattern failed: %v", err)
+	}
+	stored, err := repo.GetUserBehaviorPattern(ctx, userID)
+	if err != nil || stored.JourneyStage != "focused" || stored.WorkPreferences.SkillWeights["Go"] != 1 {
+		t.Errorf("Unexpected stored pattern %+v (%v)", stored, err)
+	}
+
+	// Preference signals upsert per type and remember the value they replace
+	for _, value := range []float64{0.2, 0.7} {
+		if err := repo.StorePreferenceSignal(ctx, PreferenceSignal{UserID: userID, SignalType: "remote_preference", Value: value, Confidence: 0.5, Source: "learned"}); err != nil {
+			t.Fatalf("StorePreferenceSignal failed: %v", err)
+		}
+	}
+	if err := repo.UpdatePreferenceSignal(ctx, userID, "salary_importance", 0.4); err != nil {
+		t.Fatalf("UpdatePreferenceSignal failed: %v", err)
+	}
+	signals, err := repo.GetPreferenceSignals(ctx, userID)
+	if err != nil || len(signals) != 2 || signals[0].SignalType != "remote_preference" || signals[0].Value != 0.7 {
+		t.Errorf("Unexpected preference signals %+v (%v)", signals, err)
+	}
+
+	// Engagement metrics apply partial updates
+	if err := repo.StoreEngagementMetrics(ctx, EngagementMetrics{UserID: userID, TotalViews: 10, TotalSaves: 2, WeeklyEngagement: []float64{0.1, 0.2}}); err != nil {
+		t.Fatalf("StoreEngagementMetrics failed: %v", err)
+	}
+	if err := repo.UpdateEngagementMetrics(ctx, userID, EngagementMetrics{TotalViews: 11}); err != nil {
+		t.Fatalf("UpdateEngagementMetrics failed: %v", err)
+	}
+	metrics, err := repo.GetEngagementMetrics(ctx, userID)
+	if err != nil || metrics.TotalViews != 11 || metrics.TotalSaves != 2 || len(metrics.WeeklyEngagement) != 2 {
+		t.Errorf("Unexpected engagement metrics %+v (%v)", metrics, err)
+	}
+
+	// Search patterns
+	if err := repo.StoreSearchPattern(ctx, SearchPattern{UserID: userID, Query: "golang intern", Filters: map[string]interface{}{"is_remote": true}, ClickedJobs: []string{"job-1"}}); err != nil {
+		t.Fatalf("StoreSearchPattern failed: %v", err)
+	}
+	searches, err := repo.GetSearchPatterns(ctx, userID, 5)
+	if err != nil || len(searches) != 1 || searches[0].Filters["is_remote"] != true || searches[0].ClickedJobs[0] != "job-1" {
+		t.Errorf("Unexpected search patterns %+v (%v)", searches, err)
+	}
+
+	// Skill interests feed trend changes
+	for _, interest := range []float64{0.3, 0.8} {
+		if err := repo.UpdateSkillInterest(ctx, userID, "Go", interest); err != nil {
+			t.Fatalf("UpdateSkillInterest failed: %v", err)
+		}
+	}
+	interests, err := repo.GetSkillInterests(ctx, userID)
+	if err != nil || interests["Go"] != 0.8 {
+		t.Errorf("Unexpected skill interests %v (%v)", interests, err)
+	}
+
+	if err := repo.StoreUserAction(ctx, UserAction{UserID: userID, ActionType: "search"}); err != nil {
+		t.Fatalf("StoreUserAction failed: %v", err)
+	}
+	trends, err := repo.GetBehaviorTrends(ctx, userID, 14)
+	if err != nil {
+		t.Fatalf("GetBehaviorTrends failed: %v", err)
+	}
+	if len(trends.ActivityTrend.SearchesPerWeek) != 2 || trends.ActivityTrend.SearchesPerWeek[1] != 1 {
+		t.Errorf("Expected this week's search, got %v", trends.ActivityTrend.SearchesPerWeek)
+	}
+	if len(trends.SkillInterestChanges) != 1 || trends.SkillInterestChanges[0].ChangeDirection != "increasing" {
+		t.Errorf("Unexpected skill changes %+v", trends.SkillInterestChanges)
+	}
+	if len(trends.PreferenceShifts) != 1 || trends.PreferenceShifts[0].Impact != "high" {
+		t.Errorf("Unexpected preference shifts %+v", trends.PreferenceShifts)
+	}
+	if len(trends.EngagementEvolution) != 1 || trends.JourneyProgression != "focused" {
+		t.Errorf("Unexpected engagement evolution %+v / %s", trends.EngagementEvolution, trends.JourneyProgression)
+	}
+}
+
+func TestBehaviorRepository_CohortBehavior(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	repo := NewBehaviorRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	users := []string{uuid.New().String(), uuid.New().String(), uuid.New().String()}
+	for i, id := range users {
+		seedFixtures(t, db, fixture{"INSERT INTO users (id, user_type, created_at) VALUES (?, 'student', ?)", []interface{}{id, now.AddDate(0, 0, -10-i)}})
+	}
+	seedFixtures(t, db,
+		fixture{"INSERT INTO applications (id, user_id, status, updated_at) VALUES (?, ?, 'accepted', ?)", []interface{}{uuid.New().String(), users[0], now.AddDate(0, 0, -1)}},
+	)
+	for _, action := range []UserAction{
+		{UserID: users[0], ActionType: "apply", Timestamp: now.AddDate(0, 0, -2)},
+		{UserID: users[1], ActionType: "view", Timestamp: now.AddDate(0, 0, -20)},
+	} {
+		if err := repo.StoreUserAction(ctx, action); err != nil {
+			t.Fatalf("StoreUserAction failed: %v", err)
+		}
+	}
+
+	cohorts, err := repo.GetCohortBehavior(ctx, CohortCriteria{
+		UserSegment: CohortNewUsers,
+		TimeRange:   TimeRange{Start: now.AddDate(0, 0, -30), End: now},
+		Filters:     map[string]interface{}{"user_type": "student"},
+	})
+	if err != nil || len(cohorts) != 1 {
+		t.Fatalf("Expected one cohort, got %d (%v)", len(cohorts), err)
+	}
+	cohort := cohorts[0]
+	if cohort.CohortSize != 3 {
+		t.Errorf("Expected 3 users, got %d", cohort.CohortSize)
+	}
+	if cohort.SuccessRate < 0.33 || cohort.SuccessRate > 0.34 || cohort.RetentionRate < 0.33 || cohort.RetentionRate > 0.34 {
+		t.Errorf("Expected one in three placed and retained, got %.2f and %.2f", cohort.SuccessRate, cohort.RetentionRate)
+	}
+
+	seekers, err := repo.GetCohortBehavior(ctx, CohortCriteria{UserSegment: CohortJobSeekers, MinCohortSize: 2})
+	if err != nil || len(seekers) != 0 {
+		t.Errorf("Expected a single job seeker to be withheld, got %+v (%v)", seekers, err)
+	}
+	if _, err := repo.GetCohortBehavior(ctx, CohortCriteria{UserSegment: "whales"}); err == nil {
+		t.Error("Expected an unknown segment to be rejected")
+	}
+}
+
+func TestBehaviorRepository_CohortStats(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	repo := NewBehaviorRepository(db)
+	ctx := context.Background()
+	signupWeek := CohortWeek(time.Now()).AddDate(0, 0, -14)
+
+	students := []string{uuid.New().String(), uuid.New().String()}
+	for _, id := range students {
+		seedFixtures(t, db, fixture{"INSERT INTO users (id, user_type, acquisition_source, created_at) VALUES (?, 'student', 'campus', ?)", []interface{}{id, signupWeek.Add(time.Hour)}})
+	}
+	employer := uuid.New().String()
+	seedFixtures(t, db, fixture{"INSERT INTO users (id, user_type, created_at) VALUES (?, 'employer', ?)", []interface{}{employer, signupWeek.AddDate(0, 0, 2)}})
+
+	// Both students are active in their signup week, one returns two weeks later and is placed
+	for _, action := range []UserAction{
+		{UserID: students[0], ActionType: "view", Timestamp: signupWeek.Add(2 * time.Hour)},
+		{UserID: students[1], ActionType: "view", Timestamp: signupWeek.Add(3 * time.Hour)},
+		{UserID: students[0], ActionType: "apply", Timestamp: signupWeek.AddDate(0, 0, 14)},
+	} {
+		if err := repo.StoreUserAction(ctx, action); err != nil {
+			t.Fatalf("StoreUserAction failed: %v", err)
+		}
+	}
+	// The application was edited after acceptance; placement still dates from the
+	// accepted history entry
+	applicationID := uuid.New().String()
+	seedFixtures(t, db,
+		fixture{"INSERT INTO applications (id, user_id, status, updated_at) VALUES (?, ?, 'accepted', ?)", []interface{}{applicationID, students[0], time.Now().UTC()}},
+		fixture{"INSERT INTO application_status_history (application_id, from_status, to_status, created_at) VALUES (?, 'submitted', 'reviewed', ?)", []interface{}{applicationID, signupWeek.AddDate(0, 0, 5)}},
+		fixture{"INSERT INTO application_status_history (application_id, from_status, to_status, created_at) VALUES (?, 'reviewed', 'accepted', ?)", []interface{}{applicationID, signupWeek.AddDate(0, 0, 10).Add(time.Hour)}},
+	)
+
+	if err := repo.RefreshCohortStats(ctx, signupWeek, time.Now()); err != nil {
+		t.Fatalf("RefreshCohortStats failed: %v", err)
+	}
+	// Refreshing again replaces rather than duplicates the window
+	if err := repo.RefreshCohortStats(ctx, signupWeek, time.Now()); err != nil {
+		t.Fatalf("Second RefreshCohortStats failed: %v", err)
+	}
+
+	stats, err := repo.GetCohortStats(ctx, CohortStatsFilter{From: signupWeek})
+	if err != nil || len(stats) != 2 {
+		t.Fatalf("Expected employer and student cohorts, got %+v (%v)", stats, err)
+	}
+	employers, studentCohort := stats[0], stats[1]
+	if !studentCohort.CohortWeek.Equal(signupWeek) || studentCohort.AcquisitionSource != "campus" || studentCohort.CohortSize != 2 {
+		t.Fatalf("Unexpected student cohort: %+v", studentCohort)
+	}
+	if len(studentCohort.RetainedUsers) != 3 || studentCohort.RetainedUsers[0] != 2 || studentCohort.RetainedUsers[1] != 0 || studentCohort.RetainedUsers[2] != 1 {
+		t.Errorf("Unexpected retention: %v", studentCohort.RetainedUsers)
+	}
+	if studentCohort.SuccessfulUsers != 1 || studentCohort.DaysToSuccess < 10 || studentCohort.DaysToSuccess > 10.1 {
+		t.Errorf("Expected one placement after about ten days, got %d after %.2f", studentCohort.SuccessfulUsers, studentCohort.DaysToSuccess)
+	}
+	if studentCohort.TotalActions != 3 || studentCohort.TotalApplications != 1 {
+		t.Errorf("Unexpected activity totals: %+v", studentCohort)
+	}
+	if employers.UserType != "employer" || employers.AcquisitionSource != "" || employers.RetainedUsers[0] != 0 {
+		t.Errorf("Unexpected employer cohort: %+v", employers)
+	}
+
+	filtered, err := repo.GetCohortStats(ctx, CohortStatsFilter{AcquisitionSource: "campus"})
+	if err != nil || len(filtered) != 1 {
+		t.Errorf("Expected the source filter to select the student cohort, got %d (%v)", len(filtered), err)
+	}
+}
+
+func TestBehaviorRepository_Funnel(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	repo := NewBehaviorRepository(db)
+	ctx := context.Background()
+	start := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+
+	employer := uuid.New().String()
+	browser, applicant, hired := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	seedFixtures(t, db,
+		fixture{"INSERT INTO jobs (id, employer_id, category, status) VALUES ('job-1', ?, 'design', 'posted')", []interface{}{employer}},
+		fixture{"INSERT INTO jobs (id, employer_id, category, status, completed_at) VALUES ('job-2', ?, 'design', 'completed', ?)", []interface{}{employer, start.Add(40 * time.Hour)}},
+		fixture{"INSERT INTO jobs (id, employer_id, category, status) VALUES ('job-3', ?, 'writing', 'posted')", []interface{}{uuid.New().String()}},
+		fixture{"UPDATE jobs SET hired_student_id = ? WHERE id = 'job-2'", []interface{}{hired}},
+	)
+	track := func(userID, actionType, jobID string, at time.Time) {
+		if err := repo.StoreUserAction(ctx, UserAction{UserID: userID, ActionType: actionType, EntityID: jobID, EntityType: "job", Timestamp: at}); err != nil {
+			t.Fatalf("StoreUserAction failed: %v", err)
+		}
+	}
+	track(browser, "impression", "job-1", start)
+	track(applicant, "impression", "job-1", start)
+	track(applicant, "view", "job-1", start.Add(10*time.Minute))
+	track(applicant, "view", "job-1", start.Add(time.Hour)) // Only the first view counts
+	track(hired, "impression", "job-2", start)
+	track(hired, "view", "job-2", start.Add(30*time.Minute))
+	track(hired, "save", "job-2", start.Add(time.Hour))
+	track(browser, "impression", "job-3", start)
+
+	seedFixtures(t, db,
+		fixture{`INSERT INTO applications (id, user_id, job_id, status, match_score, applied_at, updated_at)
+			VALUES (?, ?, 'job-1', 'submitted', 0.6, ?, ?)`,
+			[]interface{}{uuid.New().String(), applicant, start.Add(2 * time.Hour), start.Add(2 * time.Hour)}},
+		fixture{`INSERT INTO applications (id, user_id, job_id, status, match_score, applied_at, interview_scheduled, response_at, updated_at)
+			VALUES (?, ?, 'job-2', 'accepted', 0.9, ?, ?, ?, ?)`,
+			[]interface{}{uuid.New().String(), hired, start.Add(2*time.Hour + 30*time.Minute), start.Add(10 * time.Hour), start.Add(20 * time.Hour), start.Add(20 * time.Hour)}},
+		fixture{`INSERT INTO applications (id, user_id, job_id, status, applied_at, updated_at)
+			VALUES (?, ?, 'job-1', 'draft', ?, ?)`,
+			[]interface{}{uuid.New().String(), browser, start.Add(time.Hour), start.Add(time.Hour)}},
+	)
+
+	window := FunnelFilter{From: start.Add(-time.Hour), To: time.Now().UTC()}
+	platform := window
+	platform.Scope = FunnelScopePlatform
+	stats, err := repo.GetFunnelStats(ctx, platform)
+	if err != nil || len(stats) != 1 {
+		t.Fatalf("Expected one platform funnel, got %+v (%v)", stats, err)
+	}
+	funnel := stats[0]
+	if funnel.Impressions != 4 || funnel.Views != 2 || funnel.Saves != 1 || funnel.Applications != 2 ||
+		funnel.Interviews != 1 || funnel.Accepted != 1 || funnel.Completed != 1 {
+		t.Errorf("Unexpected platform funnel: %+v", funnel)
+	}
+	if funnel.MedianImpressionToView != 20*time.Minute || funnel.MedianViewToApply != 115*time.Minute {
+		t.Errorf("Unexpected median stage times: %s and %s", funnel.MedianImpressionToView, funnel.MedianViewToApply)
+	}
+	if funnel.MedianAcceptToComplete != 20*time.Hour {
+		t.Errorf("Expected completion 20h after acceptance, got %s", funnel.MedianAcceptToComplete)
+	}
+
+	byEmployer := window
+	byEmployer.Scope, byEmployer.EmployerID = FunnelScopeJob, employer
+	jobs, err := repo.GetFunnelStats(ctx, byEmployer)
+	if err != nil || len(jobs) != 2 || jobs[0].Key != "job-1" || jobs[1].Key != "job-2" {
+		t.Fatalf("Expected the employer's two jobs, got %+v (%v)", jobs, err)
+	}
+	if jobs[0].Impressions != 2 || jobs[0].Applications != 1 || jobs[0].Accepted != 0 {
+		t.Errorf("Unexpected job funnel: %+v", jobs[0])
+	}
+
+	byCategory := window
+	byCategory.Scope = FunnelScopeCategory
+	categories, err := repo.GetFunnelStats(ctx, byCategory)
+	if err != nil || len(categories) != 2 || categories[0].Key != "design" || categories[1].Impressions != 1 {
+		t.Errorf("Expected design and writing funnels, got %+v (%v)", categories, err)
+	}
+
+	counts, err := repo.CountFunnelTransitions(ctx, start, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("CountFunnelTransitions failed: %v", err)
+	}
+	reached := make(map[string]int64)
+	for _, count := range counts {
+		reached[count.Stage+"/"+count.MatchScoreRange] += count.Count
+	}
+	if reached["applied/0.5-0.7"] != 1 || reached["applied/0.7-1.0"] != 1 || reached["interviewed/0.7-1.0"] != 1 ||
+		reached["accepted/0.7-1.0"] != 1 || reached["completed/0.7-1.0"] != 1 {
+		t.Errorf("Unexpected transition counts: %v", reached)
+	}
+	later, err := repo.CountFunnelTransitions(ctx, start.Add(30*time.Hour), time.Now().UTC())
+	if err != nil || len(later) != 1 || later[0].Stage != "completed" {
+		t.Errorf("Expected only the completion after 30h, got %+v (%v)", later, err)
+	}
+
+	if _, err := repo.GetFunnelStats(ctx, FunnelFilter{Scope: "region"}); err == nil {
+		t.Error("Expected an unsupported scope to be rejected")
+	}
+}
+
+func TestBehaviorRepository_Sessions(t *testing.T) {
+	repo := NewBehaviorRepository(newBehaviorTestDB(t))
+	ctx := context.Background()
+	userID := uuid.New().String()
+	start := time.Now().UTC().Truncate(time.Second).Add(-48 * time.Hour)
+
+	first := UserSession{
+		ID: uuid.New().String(), UserID: userID, StartedAt: start, EndedAt: start.Add(20 * time.Minute),
+		ActionCount: 4, Views: 3, Applications: 1, Quality: 1.9, EngagementScore: 0.6,
+		DeviceInfo: DeviceInfo{Platform: "web", Browser: "Firefox"},
+	}
+	second := UserSession{ID: uuid.New().String(), UserID: userID, StartedAt: start.Add(3 * time.Hour), EndedAt: start.Add(3 * time.Hour), ActionCount: 1}
+	if err := repo.StoreUserSessions(ctx, []UserSession{first, second}, nil); err != nil {
+		t.Fatalf("StoreUserSessions failed: %v", err)
+	}
+
+	sessions, err := repo.GetUserSessions(ctx, userID, start.Add(10*time.Minute), start.Add(time.Hour))
+	if err != nil || len(sessions) != 1 || sessions[0].ID != first.ID {
+		t.Fatalf("Expected the overlapping session, got %+v (%v)", sessions, err)
+	}
+	if sessions[0].DeviceInfo.Browser != "Firefox" || sessions[0].Duration() != 20*time.Minute || sessions[0].Quality != 1.9 {
+		t.Errorf("Unexpected stored session %+v", sessions[0])
+	}
+
+	// Merging keeps one session and removes the other
+	first.EndedAt, first.ActionCount = second.EndedAt, 5
+	if err := repo.StoreUserSessions(ctx, []UserSession{first}, []string{second.ID}); err != nil {
+		t.Fatalf("StoreUserSessions failed: %v", err)
+	}
+	sessions, err = repo.GetUserSessions(ctx, userID, start, start.Add(24*time.Hour))
+	if err != nil || len(sessions) != 1 || sessions[0].ActionCount != 5 {
+		t.Errorf("Expected a single merged session, got %+v (%v)", sessions, err)
+	}
+}
+
+func isNotFound(err error) bool {
+	appErr, ok := err.(*apperrors.AppError)
+	return ok && appErr.Code == 404
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type userSessionRecord struct {
+	ID              string `gorm:"primaryKey"`
+	UserID          string
+	StartedAt       time.Time
+	EndedAt         time.Time
+	ActionCount     int
+	Views           int
+	Applications    int
+	Saves           int
+	Searches        int
+	Quality         float64
+	EngagementScore float64
+	DeviceInfo      string `gorm:"type:jsonb"`
+	UpdatedAt       time.Time
+}
+
+func (userSessionRecord) TableName() string { return "user_sessions" }
+
+// GetUserSessions returns the user's sessions overlapping [from, to], oldest first
+func (r *behaviorRepository) GetUserSessions(ctx context.Context, userID string, from, to time.Time) ([]UserSession, error) {
+	var records []*userSessionRecord
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND started_at <= ? AND ended_at >= ?", userID, to.UTC(), from.UTC()).
+		Order("started_at").
+		Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get user sessions", err)
+	}
+
+	sessions := make([]UserSession, 0, len(records))
+	for _, record := range records {
+		session := UserSession{
+			ID:              record.ID,
+			UserID:          record.UserID,
+			StartedAt:       record.StartedAt.UTC(),
+			EndedAt:         record.EndedAt.UTC(),
+			ActionCount:     record.ActionCount,
+			Views:           record.Views,
+			Applications:    record.Applications,
+			Saves:           record.Saves,
+			Searches:        record.Searches,
+			Quality:         record.Quality,
+			EngagementScore: record.EngagementScore,
+		}
+		if err := decodeJSON(record.DeviceInfo, &session.DeviceInfo); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode session device", err)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// StoreUserSessions upserts sessions and removes the ones merged into them, in one transaction
+func (r *behaviorRepository) StoreUserSessions(ctx context.Context, sessions []UserSession, deletedIDs []string) error {
+	now := time.Now().UTC()
+	records := make([]*userSessionRecord, 0, len(sessions))
+	for _, session := range sessions {
+		device, err := encodeJSON(session.DeviceInfo, "{}")
+		if err != nil {
+			return apperrors.NewAppError(500, "Failed to encode session device", err)
+		}
+		records = append(records, &userSessionRecord{
+			ID:              session.ID,
+			UserID:          session.UserID,
+			StartedAt:       session.StartedAt.UTC(),
+			EndedAt:         session.EndedAt.UTC(),
+			ActionCount:     session.ActionCount,
+			Views:           session.Views,
+			Applications:    session.Applications,
+			Saves:           session.Saves,
+			Searches:        session.Searches,
+			Quality:         session.Quality,
+			EngagementScore: session.EngagementScore,
+			DeviceInfo:      device,
+			UpdatedAt:       now,
+		})
+	}
+
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(deletedIDs) > 0 {
+			if err := tx.Where("id IN ?", deletedIDs).Delete(&userSessionRecord{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(records).Error
+	}); err != nil {
+		return apperrors.NewAppError(500, "Failed to store user sessions", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	aiModels "microbridge/backend/internal/ai/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+type DriftReferenceRepository interface {
+	LoadDriftReferences(ctx context.Context) ([]*aiModels.DriftReference, error)
+	SaveDriftReference(ctx context.Context, reference *aiModels.DriftReference) error
+}
+
+// driftReferenceRecord is the persisted form of aiModels.DriftReference
+type driftReferenceRecord struct {
+	Metric    string `gorm:"primaryKey"`
+	Samples   string `gorm:"type:jsonb"`
+	CreatedAt time.Time
+}
+
+func (driftReferenceRecord) TableName() string {
+	return "ai_drift_references"
+}
+
+type driftReferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewDriftReferenceRepository(db *gorm.DB) DriftReferenceRepository {
+	return &driftReferenceRepository{db: db}
+}
+
+func (r *driftReferenceRepository) LoadDriftReferences(ctx context.Context) ([]*aiModels.DriftReference, error) {
+	var records []*driftReferenceRecord
+	if err := r.db.WithContext(ctx).Order("metric").Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to load drift references", err)
+	}
+
+	references := make([]*aiModels.DriftReference, 0, len(records))
+	for _, record := range records {
+		reference := &aiModels.DriftReference{
+			Metric:    record.Metric,
+			CreatedAt: record.CreatedAt,
+		}
+		if err := json.Unmarshal([]byte(record.Samples), &reference.Values); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode drift reference", err)
+		}
+		references = append(references, reference)
+	}
+	return references, nil
+}
+
+// SaveDriftReference replaces the stored reference for the metric
+func (r *driftReferenceRepository) SaveDriftReference(ctx context.Context, reference *aiModels.DriftReference) error {
+	encoded, err := json.Marshal(reference.Values)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode drift reference", err)
+	}
+
+	record := &driftReferenceRecord{
+		Metric:    reference.Metric,
+		Samples:   string(encoded),
+		CreatedAt: reference.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to save drift reference", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	aiModels "microbridge/backend/internal/ai/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EnsembleWeightRepository interface {
+	LoadSegmentWeights(ctx context.Context) ([]*aiModels.SegmentWeights, error)
+	UpdateSegmentWeights(ctx context.Context, segment string, update func(current *aiModels.SegmentWeights) *aiModels.SegmentWeights) (*aiModels.SegmentWeights, error)
+	DeleteSegmentWeights(ctx context.Context, segments ...string) error
+}
+
+// segmentWeightsRecord is the persisted form of aiModels.SegmentWeights
+type segmentWeightsRecord struct {
+	Segment   string `gorm:"primaryKey"`
+	Weights   string `gorm:"type:jsonb"`
+	Updates   int64
+	UpdatedAt time.Time
+}
+
+func (segmentWeightsRecord) TableName() string {
+	return "ai_ensemble_weights"
+}
+
+// toSegmentWeights decodes the record. A row that was created but never updated
+// holds no weights and decodes to nil.
+func (record *segmentWeightsRecord) toSegmentWeights() (*aiModels.SegmentWeights, error) {
+	segment := &aiModels.SegmentWeights{
+		Segment:   record.Segment,
+		Updates:   record.Updates,
+		UpdatedAt: record.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(record.Weights), &segment.Weights); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to decode ensemble weights", err)
+	}
+	if len(segment.Weights) == 0 {
+		return nil, nil
+	}
+	return segment, nil
+}
+
+type ensembleWeightRepository struct {
+	db *gorm.DB
+}
+
+func NewEnsembleWeightRepository(db *gorm.DB) EnsembleWeightRepository {
+	return &ensembleWeightRepository{db: db}
+}
+
+func (r *ensembleWeightRepository) LoadSegmentWeights(ctx context.Context) ([]*aiModels.SegmentWeights, error) {
+	var records []*segmentWeightsRecord
+	if err := r.db.WithContext(ctx).Order("segment").Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to load ensemble weights", err)
+	}
+
+	segments := make([]*aiModels.SegmentWeights, 0, len(records))
+	for _, record := range records {
+		segment, err := record.toSegmentWeights()
+		if err != nil {
+			return nil, err
+		}
+		if segment != nil {
+			segments = append(segments, segment)
+		}
+	}
+	return segments, nil
+}
+
+// UpdateSegmentWeights applies update to a segment's stored weights while holding
+// its row lock, so concurrent updates from other instances are applied in turn
+// rather than overwriting each other
+func (r *ensembleWeightRepository) UpdateSegmentWeights(ctx context.Context, segment string, update func(current *aiModels.SegmentWeights) *aiModels.SegmentWeights) (*aiModels.SegmentWeights, error) {
+	var updated *aiModels.SegmentWeights
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// An empty row gives a new segment something to lock; it reads as no weights
+		placeholder := &segmentWeightsRecord{Segment: segment, Weights: "{}", UpdatedAt: time.Now()}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(placeholder).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to save ensemble weights", err)
+		}
+
+		var record segmentWeightsRecord
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("segment = ?", segment).First(&record).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to load ensemble weights", err)
+		}
+		current, err := record.toSegmentWeights()
+		if err != nil {
+			return err
+		}
+
+		updated = update(current)
+		encoded, err := json.Marshal(updated.Weights)
+		if err != nil {
+			return apperrors.NewAppError(500, "Failed to encode ensemble weights", err)
+		}
+		if err := tx.Model(&segmentWeightsRecord{}).Where("segment = ?", segment).Updates(map[string]interface{}{
+			"weights":    string(encoded),
+			"updates":    updated.Updates,
+			"updated_at": updated.UpdatedAt,
+		}).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to save ensemble weights", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *ensembleWeightRepository) DeleteSegmentWeights(ctx context.Context, segments ...string) error {
+	if len(segments) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Where("segment IN ?", segments).Delete(&segmentWeightsRecord{}).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to delete ensemble weights", err)
+	}
+	return nil
+}
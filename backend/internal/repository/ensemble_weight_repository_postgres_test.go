@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	aiModels "microbridge/backend/internal/ai/models"
+	"microbridge/backend/internal/database/migrations"
+)
+
+func TestEnsembleWeightRepository_ConcurrentUpdatesAreNotLost(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "create_ai_ensemble_weights" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+	repo := NewEnsembleWeightRepository(db)
+	ctx := context.Background()
+
+	// Each update adds to the weight it read, so a lost update leaves it short
+	increment := func(current *aiModels.SegmentWeights) *aiModels.SegmentWeights {
+		if current == nil {
+			current = &aiModels.SegmentWeights{Segment: "active", Weights: map[string]float64{"rl": 0}}
+		}
+		current.Weights["rl"]++
+		current.Updates++
+		current.UpdatedAt = time.Now()
+		return current
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.UpdateSegmentWeights(ctx, "active", increment); err != nil {
+				t.Errorf("UpdateSegmentWeights failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	segments, err := repo.LoadSegmentWeights(ctx)
+	if err != nil {
+		t.Fatalf("LoadSegmentWeights failed: %v", err)
+	}
+	if len(segments) != 1 || segments[0].Updates != 8 || segments[0].Weights["rl"] != 8 {
+		t.Errorf("Expected all 8 updates applied, got %+v", segments)
+	}
+}
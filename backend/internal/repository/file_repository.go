@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+type FileRepository interface {
+	Create(ctx context.Context, file *models.File) error
+	GetByID(ctx context.Context, id string) (*models.File, error)
+	AttachToUser(ctx context.Context, userID string, file *models.File) error
+	AttachToApplication(ctx context.Context, applicationID string, file *models.File) error
+	EmployerCanView(ctx context.Context, file *models.File, employerID string) (bool, error)
+}
+
+// Columns that hold a user's or an application's file of each purpose
+var (
+	userFileColumns = map[string]string{
+		models.FilePurposeResume: "resume_file_id",
+		models.FilePurposeLogo:   "logo_file_id",
+	}
+	applicationFileColumns = map[string]string{
+		models.FilePurposeResume:      "resume_file_id",
+		models.FilePurposeCoverLetter: "cover_letter_file_id",
+	}
+)
+
+type fileRepository struct {
+	db *gorm.DB
+}
+
+func NewFileRepository(db *gorm.DB) FileRepository {
+	return &fileRepository{db: db}
+}
+
+func (r *fileRepository) Create(ctx context.Context, file *models.File) error {
+	if err := r.db.WithContext(ctx).Create(file).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to save file", err)
+	}
+	return nil
+}
+
+func (r *fileRepository) GetByID(ctx context.Context, id string) (*models.File, error) {
+	var file models.File
+	if err := r.db.WithContext(ctx).First(&file, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("File")
+		}
+		return nil, apperrors.NewAppError(500, "Failed to get file", err)
+	}
+	return &file, nil
+}
+
+// AttachToUser makes the file the user's current resume or logo
+func (r *fileRepository) AttachToUser(ctx context.Context, userID string, file *models.File) error {
+	column, ok := userFileColumns[file.Purpose]
+	if !ok {
+		return apperrors.NewAppError(400, "A "+file.Purpose+" can't be added to a profile", nil)
+	}
+	result := r.db.WithContext(ctx).Table("users").Where("id = ?", userID).
+		Updates(map[string]interface{}{column: file.ID, "updated_at": time.Now()})
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to attach file", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewNotFoundError("User")
+	}
+	return nil
+}
+
+// AttachToApplication makes the file the application's resume or cover letter
+func (r *fileRepository) AttachToApplication(ctx context.Context, applicationID string, file *models.File) error {
+	column, ok := applicationFileColumns[file.Purpose]
+	if !ok {
+		return apperrors.NewAppError(400, "A "+file.Purpose+" can't be added to an application", nil)
+	}
+	result := r.db.WithContext(ctx).Table("applications").Where("id = ?", applicationID).
+		Updates(map[string]interface{}{column: file.ID, "updated_at": time.Now()})
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to attach file", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewNotFoundError("Application")
+	}
+	return nil
+}
+
+// EmployerCanView reports whether the file's owner applied to one of the
+// employer's jobs with it, either attached to the application or as the
+// resume on their profile. Drafts are not shared with employers.
+func (r *fileRepository) EmployerCanView(ctx context.Context, file *models.File, employerID string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		JOIN users u ON u.id = a.user_id
+		WHERE a.user_id = ? AND j.employer_id = ? AND a.status <> 'draft'
+			AND (a.resume_file_id = ? OR a.cover_letter_file_id = ? OR u.resume_file_id = ?)`,
+		file.OwnerID, employerID, file.ID, file.ID, file.ID,
+	).Scan(&count).Error; err != nil {
+		return false, apperrors.NewAppError(500, "Failed to check file access", err)
+	}
+	return count > 0, nil
+}
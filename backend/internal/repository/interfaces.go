@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"microbridge/backend/internal/models"
 )
 
@@ -21,10 +22,21 @@ type JobRepository interface {
 	Create(ctx context.Context, job *models.Job) error
 	GetByID(ctx context.Context, id string) (*models.Job, error)
 	Update(ctx context.Context, job *models.Job) error
+	IncrementApplications(ctx context.Context, id string) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*models.Job, int64, error)
-	GetByEmployerID(ctx context.Context, employerID string, limit, offset int) ([]*models.Job, int64, error)
-	Search(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) ([]*models.Job, int64, error)
+	GetByEmployerID(ctx context.Context, employerID, status string, limit, offset int) ([]*models.Job, int64, error)
+	Search(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) (*JobSearchResult, error)
+	RankMatches(ctx context.Context, query string, filters map[string]interface{}, limit int) (*JobSearchResult, error)
+	Highlight(ctx context.Context, query string, hits []JobSearchHit) error
+	TransitionStatus(ctx context.Context, job *models.Job, fromStatus string, entry *models.JobStatusHistory) error
+	GetStatusHistory(ctx context.Context, jobID string) ([]*models.JobStatusHistory, error)
+	// Deadline scheduling; see jobs.DeadlineStore
+	ListExpiredPostings(ctx context.Context, now time.Time, limit int) ([]*models.Job, error)
+	ListStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error)
+	ListEndedReviewPeriods(ctx context.Context, now time.Time, limit int) ([]*models.Job, error)
+	ClaimApplicationReminders(ctx context.Context, appliedBefore, remindedBefore, now time.Time) ([]models.PendingApplications, error)
+	ClaimReviewReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Job, error)
 }
 
 type ApplicationRepository interface {
@@ -33,9 +45,22 @@ type ApplicationRepository interface {
 	Update(ctx context.Context, application *models.Application) error
 	Delete(ctx context.Context, id string) error
 	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*models.Application, int64, error)
-	GetByJobID(ctx context.Context, jobID string, limit, offset int) ([]*models.Application, int64, error)
+	GetByJobID(ctx context.Context, jobID string, filter ApplicationFilter, limit, offset int) ([]*models.Application, int64, error)
 	GetByUserAndJob(ctx context.Context, userID, jobID string) (*models.Application, error)
 	UpdateStatus(ctx context.Context, id string, status string) error
+	CreateWithHistory(ctx context.Context, application *models.Application, entry *models.ApplicationStatusHistory) error
+	TransitionStatus(ctx context.Context, application *models.Application, fromStatus string, entry *models.ApplicationStatusHistory) error
+	GetStatusHistory(ctx context.Context, applicationID string) ([]*models.ApplicationStatusHistory, error)
+	GetByJobAndIDs(ctx context.Context, jobID string, ids []string) ([]*models.Application, error)
+	Shortlist(ctx context.Context, application *models.Application, at time.Time) error
+	SetInterviewScheduled(ctx context.Context, id string, scheduled *time.Time) error
+}
+
+// ApplicationFilter narrows a job's applications; zero values match everything
+type ApplicationFilter struct {
+	Status  string
+	Flagged *bool
+	Answers models.ScreeningAnswers // Each answer must be present with exactly this value
 }
 
 type NotificationRepository interface {
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+type InterviewRepository interface {
+	Create(ctx context.Context, interview *models.Interview) error
+	GetByID(ctx context.Context, id string) (*models.Interview, error)
+	// Update saves the interview if it is still in fromStatus
+	Update(ctx context.Context, interview *models.Interview, fromStatus string) error
+	// ListByUser returns the interviews a user takes part in, optionally for one application
+	ListByUser(ctx context.Context, userID, applicationID string) ([]*models.Interview, error)
+	// ListScheduled returns the scheduled interviews of any of the users that overlap [from, to)
+	ListScheduled(ctx context.Context, userIDs []string, from, to time.Time, excludeID string) ([]*models.Interview, error)
+	ClaimDueReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Interview, error)
+}
+
+type interviewRepository struct {
+	db *gorm.DB
+}
+
+func NewInterviewRepository(db *gorm.DB) InterviewRepository {
+	return &interviewRepository{db: db}
+}
+
+func (r *interviewRepository) Create(ctx context.Context, interview *models.Interview) error {
+	if err := r.db.WithContext(ctx).Create(interview).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to create interview", err)
+	}
+	return nil
+}
+
+func (r *interviewRepository) GetByID(ctx context.Context, id string) (*models.Interview, error) {
+	var interview models.Interview
+	if err := r.db.WithContext(ctx).First(&interview, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Interview")
+		}
+		return nil, apperrors.NewAppError(500, "Failed to get interview", err)
+	}
+	return &interview, nil
+}
+
+// Update writes the interview only while it is still in fromStatus, so concurrent
+// transitions can't overwrite each other. reminder_sent_at belongs to the reminder
+// job and is never written from the interview; it is cleared in the same
+// transaction when the update moves the scheduled start, so the new time gets its
+// own reminder.
+func (r *interviewRepository) Update(ctx context.Context, interview *models.Interview, fromStatus string) error {
+	interview.UpdatedAt = time.Now().UTC()
+	var remindAgain bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reset := tx.Model(&models.Interview{}).
+			Where("id = ? AND status = ? AND reminder_sent_at IS NOT NULL", interview.ID, fromStatus).
+			Where("scheduled_start IS DISTINCT FROM ?", interview.ScheduledStart).
+			Update("reminder_sent_at", nil)
+		if reset.Error != nil {
+			return apperrors.NewAppError(500, "Failed to update interview", reset.Error)
+		}
+
+		result := tx.Model(&models.Interview{}).
+			Where("id = ? AND status = ?", interview.ID, fromStatus).
+			Select("*").
+			Omit("id", "created_at", "reminder_sent_at").
+			Updates(interview)
+		if result.Error != nil {
+			return apperrors.NewAppError(500, "Failed to update interview", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewAppError(409, "Interview changed in the meantime; reload and try again", nil)
+		}
+		remindAgain = reset.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if remindAgain {
+		interview.ReminderSentAt = nil
+	}
+	return nil
+}
+
+func (r *interviewRepository) ListByUser(ctx context.Context, userID, applicationID string) ([]*models.Interview, error) {
+	var interviews []*models.Interview
+	query := r.db.WithContext(ctx).Where("student_id = ? OR employer_id = ?", userID, userID)
+	if applicationID != "" {
+		query = query.Where("application_id = ?", applicationID)
+	}
+	if err := query.Order("scheduled_start ASC NULLS LAST, created_at DESC").Find(&interviews).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get interviews", err)
+	}
+	return interviews, nil
+}
+
+func (r *interviewRepository) ListScheduled(ctx context.Context, userIDs []string, from, to time.Time, excludeID string) ([]*models.Interview, error) {
+	var interviews []*models.Interview
+	query := r.db.WithContext(ctx).
+		Where("status = ?", models.InterviewScheduled).
+		Where("student_id IN ? OR employer_id IN ?", userIDs, userIDs).
+		Where("scheduled_start < ? AND scheduled_end > ?", to, from)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Find(&interviews).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get scheduled interviews", err)
+	}
+	return interviews, nil
+}
+
+// ClaimDueReminders stamps and returns the scheduled interviews starting between
+// now and dueBefore that haven't been reminded yet. The single UPDATE makes the
+// claim atomic, so replicas running the reminder job never remind twice.
+func (r *interviewRepository) ClaimDueReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Interview, error) {
+	var interviews []models.Interview
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE interviews
+		SET reminder_sent_at = ?
+		WHERE status = ? AND reminder_sent_at IS NULL
+		AND scheduled_start > ? AND scheduled_start <= ?
+		RETURNING *`,
+		now, models.InterviewScheduled, now, dueBefore,
+	).Scan(&interviews).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to claim interview reminders", err)
+	}
+	return interviews, nil
+}
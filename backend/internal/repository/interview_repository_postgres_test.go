@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/database/migrations"
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+// newInterviewTestRepository migrates the interviews table and returns a repository
+// with a scheduler for interviews between one student and one employer
+func newInterviewTestRepository(t *testing.T) (InterviewRepository, func(start time.Time) *models.Interview, string) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`ALTER TABLE jobs ALTER COLUMN id TYPE UUID USING id::uuid;`).Error; err != nil {
+		t.Fatalf("Failed to adapt the jobs fixture: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "create_interviews" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	studentID, employerID := uuid.New().String(), uuid.New().String()
+	jobID, applicationID := uuid.New().String(), uuid.New().String()
+	seedFixtures(t, db,
+		fixture{"INSERT INTO users (id, user_type) VALUES (?, 'student'), (?, 'employer')", []interface{}{studentID, employerID}},
+		fixture{"INSERT INTO jobs (id, employer_id, status) VALUES (?, ?, 'active')", []interface{}{jobID, employerID}},
+		fixture{"INSERT INTO applications (id, user_id, job_id, status) VALUES (?, ?, ?, 'reviewed')", []interface{}{applicationID, studentID, jobID}},
+	)
+
+	repo := NewInterviewRepository(db)
+	schedule := func(start time.Time) *models.Interview {
+		end := start.Add(time.Hour)
+		interview := &models.Interview{
+			ApplicationID: applicationID, JobID: jobID, EmployerID: employerID, StudentID: studentID,
+			Status: models.InterviewScheduled, ScheduledStart: &start, ScheduledEnd: &end,
+			CreatedAt: now, UpdatedAt: now,
+		}
+		if err := repo.Create(context.Background(), interview); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		return interview
+	}
+	return repo, schedule, employerID
+}
+
+func TestInterviewRepository_ClaimDueReminders(t *testing.T) {
+	repo, schedule, employerID := newInterviewTestRepository(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	soon := schedule(now.Add(2 * time.Hour))
+	schedule(now.Add(72 * time.Hour))
+
+	claimed, err := repo.ClaimDueReminders(ctx, now.Add(24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != soon.ID || claimed[0].ReminderSentAt == nil {
+		t.Fatalf("Expected only the upcoming interview to be claimed, got %+v", claimed)
+	}
+
+	claimed, err = repo.ClaimDueReminders(ctx, now.Add(24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Errorf("Expected a reminder to be claimed once, got %+v", claimed)
+	}
+
+	busy, err := repo.ListScheduled(ctx, []string{employerID}, now.Add(2*time.Hour+30*time.Minute), now.Add(4*time.Hour), "")
+	if err != nil {
+		t.Fatalf("ListScheduled failed: %v", err)
+	}
+	if len(busy) != 1 || busy[0].ID != soon.ID {
+		t.Errorf("Expected the overlapping interview, got %+v", busy)
+	}
+}
+
+func TestInterviewRepository_UpdateComparesStatusAndKeepsReminder(t *testing.T) {
+	repo, schedule, _ := newInterviewTestRepository(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	interview := schedule(now.Add(2 * time.Hour))
+	if _, err := repo.ClaimDueReminders(ctx, now.Add(24*time.Hour), now); err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+
+	// A stale copy without the reminder stamp must not clear it
+	interview.Sequence++
+	if err := repo.Update(ctx, interview, models.InterviewScheduled); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	stored, err := repo.GetByID(ctx, interview.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.ReminderSentAt == nil || stored.Sequence != interview.Sequence {
+		t.Errorf("Expected the update to keep the reminder stamp, got %+v", stored)
+	}
+
+	// An update from a status the interview already left conflicts
+	stale := *stored
+	stale.Status = models.InterviewCancelled
+	if err := repo.Update(ctx, &stale, models.InterviewProposed); err == nil {
+		t.Error("Expected a conflict for a stale status")
+	} else if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != 409 {
+		t.Errorf("Expected a conflict for a stale status, got %v", err)
+	}
+
+	// Moving the scheduled start clears the stamp so the new time is reminded
+	moved := now.Add(3 * time.Hour)
+	stored.ScheduledStart = &moved
+	if err := repo.Update(ctx, stored, models.InterviewScheduled); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if stored.ReminderSentAt != nil {
+		t.Error("Expected the returned interview to drop the reminder stamp")
+	}
+	claimed, err := repo.ClaimDueReminders(ctx, now.Add(24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != interview.ID {
+		t.Errorf("Expected the moved interview to be reminded again, got %+v", claimed)
+	}
+}
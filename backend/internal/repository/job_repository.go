@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"errors"
+	"html"
+	"strconv"
+	"strings"
 	"time"
 	"microbridge/backend/internal/models"
 	apperrors "microbridge/backend/internal/shared/errors"
@@ -36,14 +39,74 @@ func (r *jobRepository) GetByID(ctx context.Context, id string) (*models.Job, er
 	return &job, nil
 }
 
+// lifecycleColumns change only through TransitionStatus, so every change is
+// checked and recorded
+var lifecycleColumns = []string{"status", "hired_student_id", "completed_at", "review_due_date"}
+
+// counterColumns change only through atomic increments, never from a loaded copy
+var counterColumns = []string{"applications", "views"}
+
+// Update saves a job's details. Lifecycle columns and counters are left alone,
+// so saving a stale copy can't revert a hire or lose applications.
 func (r *jobRepository) Update(ctx context.Context, job *models.Job) error {
 	job.UpdatedAt = time.Now()
-	if err := r.db.WithContext(ctx).Save(job).Error; err != nil {
+	if err := r.db.WithContext(ctx).Omit(append(lifecycleColumns, counterColumns...)...).Save(job).Error; err != nil {
 		return apperrors.NewAppError(500, "Failed to update job", err)
 	}
 	return nil
 }
 
+// IncrementApplications adds one to the job's application count in place
+func (r *jobRepository) IncrementApplications(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Job{}).
+		Where("id = ?", id).
+		UpdateColumn("applications", gorm.Expr("applications + 1"))
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to count application", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrJobNotFound
+	}
+	return nil
+}
+
+// TransitionStatus saves a job whose status changed from fromStatus and appends
+// the history entry in the same transaction. The write only applies while the
+// stored status is still fromStatus, so concurrent changes can't both succeed.
+func (r *jobRepository) TransitionStatus(ctx context.Context, job *models.Job, fromStatus string, entry *models.JobStatusHistory) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Job{}).
+			Where("id = ? AND status = ?", job.ID, fromStatus).
+			Select("*").
+			Omit(append([]string{"id", "created_at"}, counterColumns...)...).
+			Updates(job)
+		if result.Error != nil {
+			return apperrors.NewAppError(500, "Failed to update job status", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewAppError(409, "Job status changed in the meantime; reload and try again", nil)
+		}
+
+		if err := tx.Create(entry).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to record job status", err)
+		}
+		return nil
+	})
+}
+
+// GetStatusHistory returns a job's status changes, oldest first
+func (r *jobRepository) GetStatusHistory(ctx context.Context, jobID string) ([]*models.JobStatusHistory, error) {
+	var history []*models.JobStatusHistory
+	if err := r.db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("created_at ASC, id ASC").
+		Find(&history).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get job status history", err)
+	}
+	return history, nil
+}
+
 func (r *jobRepository) Delete(ctx context.Context, id string) error {
 	if err := r.db.WithContext(ctx).Delete(&models.Job{}, "id = ?", id).Error; err != nil {
 		return apperrors.NewAppError(500, "Failed to delete job", err)
@@ -55,23 +118,7 @@ func (r *jobRepository) List(ctx context.Context, filters map[string]interface{}
 	var jobs []*models.Job
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&models.Job{})
-
-	// Apply filters
-	for key, value := range filters {
-		switch key {
-		case "status":
-			query = query.Where("status = ?", value)
-		case "location":
-			query = query.Where("location ILIKE ?", "%"+value.(string)+"%")
-		case "experience_level":
-			query = query.Where("experience_level = ?", value)
-		case "is_remote":
-			query = query.Where("is_remote = ?", value)
-		case "employer_id":
-			query = query.Where("employer_id = ?", value)
-		}
-	}
+	query := applyJobFilters(r.db.WithContext(ctx).Model(&models.Job{}), filters)
 
 	// Get total count
 	if err := query.Count(&total).Error; err != nil {
@@ -86,57 +133,362 @@ func (r *jobRepository) List(ctx context.Context, filters map[string]interface{}
 	return jobs, total, nil
 }
 
-func (r *jobRepository) GetByEmployerID(ctx context.Context, employerID string, limit, offset int) ([]*models.Job, int64, error) {
-	return r.List(ctx, map[string]interface{}{"employer_id": employerID}, limit, offset)
+// GetByEmployerID lists an employer's jobs; an empty status lists every status
+func (r *jobRepository) GetByEmployerID(ctx context.Context, employerID, status string, limit, offset int) ([]*models.Job, int64, error) {
+	filters := map[string]interface{}{"employer_id": employerID}
+	if status != "" {
+		filters["status"] = status
+	}
+	return r.List(ctx, filters, limit, offset)
+}
+
+// JobSearchResult is a page of search hits with facet counts over every match
+type JobSearchResult struct {
+	Hits   []JobSearchHit
+	Total  int64
+	Facets JobSearchFacets
+}
+
+// JobSearchHit is a matching job with its text rank and highlighted excerpts.
+// The excerpts are HTML-escaped with the matched terms wrapped in <mark>; they
+// are empty when the search has no query.
+type JobSearchHit struct {
+	Job            *models.Job
+	Rank           float64 // ts_rank normalised to [0, 1)
+	TitleHighlight string
+	Snippet        string
+}
+
+// JobSearchFacets count the matching jobs by each value of the facet fields
+type JobSearchFacets struct {
+	Category        []FacetCount
+	JobType         []FacetCount
+	ExperienceLevel []FacetCount
+	Remote          []FacetCount
+}
+
+// FacetCount is how many matching jobs have a facet value
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// Headline markers; they can't occur in job text, so the excerpts can be
+// escaped before the markers become <mark> tags
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+var (
+	titleHeadlineOptions   = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+	snippetHeadlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop +
+		`, MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=" ... "`
+	highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+)
+
+// Search finds jobs matching a web-style query (quoted phrases, "or", -exclusions)
+// against the title, skills and description, best matches first. Without a
+// query it lists the filtered jobs, newest first.
+func (r *jobRepository) Search(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) (*JobSearchResult, error) {
+	result, err := r.searchPage(ctx, query, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Highlight(ctx, query, result.Hits); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RankMatches returns the best limit matches like Search, but without excerpts.
+// It is for callers that reorder the hits before paging them; Highlight builds
+// the excerpts for the page they keep.
+func (r *jobRepository) RankMatches(ctx context.Context, query string, filters map[string]interface{}, limit int) (*JobSearchResult, error) {
+	return r.searchPage(ctx, query, filters, limit, 0)
 }
 
-func (r *jobRepository) Search(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) ([]*models.Job, int64, error) {
+// Highlight fills in the excerpts of hits for query. Headlines are expensive, so
+// they are only built for the page that is returned.
+func (r *jobRepository) Highlight(ctx context.Context, query string, hits []JobSearchHit) error {
+	query = strings.TrimSpace(query)
+	if query == "" || len(hits) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.Job.ID
+	}
+	var rows []struct {
+		ID             string
+		TitleHighlight string
+		Snippet        string
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Job{}).
+		Select(`id,
+			ts_headline('english', COALESCE(title, ''), websearch_to_tsquery('english', ?), ?) AS title_highlight,
+			ts_headline('english', COALESCE(description, ''), websearch_to_tsquery('english', ?), ?) AS snippet`,
+			query, titleHeadlineOptions, query, snippetHeadlineOptions).
+		Where("id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to highlight search results", err)
+	}
+
+	byID := make(map[string]int, len(rows))
+	for i, row := range rows {
+		byID[row.ID] = i
+	}
+	for i := range hits {
+		if row, ok := byID[hits[i].Job.ID]; ok {
+			hits[i].TitleHighlight = markHighlights(rows[row].TitleHighlight)
+			hits[i].Snippet = markHighlights(rows[row].Snippet)
+		}
+	}
+	return nil
+}
+
+// searchPage counts and facets the matches and returns one page of hits without
+// excerpts. Ties in rank are broken by age and then id, so the order is the same
+// however the matches are paged.
+func (r *jobRepository) searchPage(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) (*JobSearchResult, error) {
+	query = strings.TrimSpace(query)
+	matching := func() *gorm.DB {
+		dbQuery := applyJobFilters(r.db.WithContext(ctx).Model(&models.Job{}), filters)
+		if query != "" {
+			dbQuery = dbQuery.Where("search_vector @@ websearch_to_tsquery('english', ?)", query)
+		}
+		return dbQuery
+	}
+
+	result := &JobSearchResult{}
+	if err := matching().Count(&result.Total).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to count jobs", err)
+	}
+	if result.Total == 0 {
+		return result, nil
+	}
+
+	facets, err := r.searchFacets(matching())
+	if err != nil {
+		return nil, err
+	}
+	result.Facets = facets
+
+	if query == "" {
+		var jobs []*models.Job
+		if err := matching().Order("created_at DESC").Offset(offset).Limit(limit).Find(&jobs).Error; err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to search jobs", err)
+		}
+		result.Hits = make([]JobSearchHit, len(jobs))
+		for i, job := range jobs {
+			result.Hits[i] = JobSearchHit{Job: job}
+		}
+		return result, nil
+	}
+
+	var ranked []struct {
+		ID   string
+		Rank float64
+	}
+	if err := matching().
+		Select("id, ts_rank(search_vector, websearch_to_tsquery('english', ?), 32) AS rank", query).
+		Order("rank DESC, created_at DESC, id").Offset(offset).Limit(limit).
+		Scan(&ranked).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to search jobs", err)
+	}
+	if len(ranked) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(ranked))
+	for i, row := range ranked {
+		ids[i] = row.ID
+	}
 	var jobs []*models.Job
-	var total int64
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&jobs).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to search jobs", err)
+	}
+	byID := make(map[string]*models.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
 
-	dbQuery := r.db.WithContext(ctx).Model(&models.Job{})
+	result.Hits = make([]JobSearchHit, 0, len(ranked))
+	for _, row := range ranked {
+		job, ok := byID[row.ID]
+		if !ok {
+			continue // Deleted since the page was ranked
+		}
+		result.Hits = append(result.Hits, JobSearchHit{Job: job, Rank: row.Rank})
+	}
+	return result, nil
+}
 
-	// Add search query
-	if query != "" {
-		dbQuery = dbQuery.Where(
-			"title ILIKE ? OR description ILIKE ? OR location ILIKE ?",
-			"%"+query+"%", "%"+query+"%", "%"+query+"%",
-		)
+// searchFacets counts the matching jobs per facet value in one pass
+func (r *jobRepository) searchFacets(matching *gorm.DB) (JobSearchFacets, error) {
+	var rows []struct {
+		Category          *string
+		JobType           *string
+		ExperienceLevel   *string
+		IsRemote          *bool
+		ByCategory        bool
+		ByJobType         bool
+		ByExperienceLevel bool
+		Count             int64
+	}
+	if err := matching.
+		Select(`category, job_type, experience_level, is_remote,
+			GROUPING(category) = 0 AS by_category,
+			GROUPING(job_type) = 0 AS by_job_type,
+			GROUPING(experience_level) = 0 AS by_experience_level,
+			COUNT(*) AS count`).
+		Group("GROUPING SETS ((category), (job_type), (experience_level), (is_remote))").
+		Order("count DESC").
+		Scan(&rows).Error; err != nil {
+		return JobSearchFacets{}, apperrors.NewAppError(500, "Failed to count search facets", err)
+	}
+
+	var facets JobSearchFacets
+	add := func(counts *[]FacetCount, value *string, count int64) {
+		if value != nil && *value != "" {
+			*counts = append(*counts, FacetCount{Value: *value, Count: count})
+		}
+	}
+	for _, row := range rows {
+		switch {
+		case row.ByCategory:
+			add(&facets.Category, row.Category, row.Count)
+		case row.ByJobType:
+			add(&facets.JobType, row.JobType, row.Count)
+		case row.ByExperienceLevel:
+			add(&facets.ExperienceLevel, row.ExperienceLevel, row.Count)
+		case row.IsRemote != nil:
+			remote := strconv.FormatBool(*row.IsRemote)
+			add(&facets.Remote, &remote, row.Count)
+		}
 	}
+	return facets, nil
+}
+
+// markHighlights escapes a headline and turns its markers into <mark> tags
+func markHighlights(headline string) string {
+	return highlightReplacer.Replace(html.EscapeString(headline))
+}
 
-	// Apply additional filters
+// applyJobFilters narrows a jobs query by the filters List and Search accept
+func applyJobFilters(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
 	for key, value := range filters {
 		switch key {
 		case "status":
-			dbQuery = dbQuery.Where("status = ?", value)
+			query = query.Where("status = ?", value)
 		case "location":
-			dbQuery = dbQuery.Where("location ILIKE ?", "%"+value.(string)+"%")
+			query = query.Where("location ILIKE ?", "%"+value.(string)+"%")
 		case "experience_level":
-			dbQuery = dbQuery.Where("experience_level = ?", value)
+			query = query.Where("experience_level = ?", value)
 		case "is_remote":
-			dbQuery = dbQuery.Where("is_remote = ?", value)
+			query = query.Where("is_remote = ?", value)
 		case "employer_id":
-			dbQuery = dbQuery.Where("employer_id = ?", value)
+			query = query.Where("employer_id = ?", value)
+		case "category":
+			query = query.Where("category = ?", value)
+		case "job_type":
+			query = query.Where("job_type = ?", value)
 		}
 	}
+	return query
+}
 
-	// Get total count
-	if err := dbQuery.Count(&total).Error; err != nil {
-		return nil, 0, apperrors.NewAppError(500, "Failed to count jobs", err)
+// ListExpiredPostings returns posted jobs whose application deadline passed
+// before the given time and that have no open offer, oldest deadline first
+func (r *jobRepository) ListExpiredPostings(ctx context.Context, before time.Time, limit int) ([]*models.Job, error) {
+	var jobs []*models.Job
+	openOffers := r.db.Table("offers").
+		Select("1").
+		Where("offers.job_id = jobs.id AND offers.status IN ?", []string{models.OfferExtended, models.OfferCountered})
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND application_deadline IS NOT NULL AND application_deadline <= ?", "posted", before).
+		Where("NOT EXISTS (?)", openOffers).
+		Order("application_deadline ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get expired job postings", err)
+	}
+	return jobs, nil
+}
+
+// ListStaleDrafts returns drafts last updated before the given time, oldest first
+func (r *jobRepository) ListStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error) {
+	var jobs []*models.Job
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND updated_at < ?", "draft", before).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get stale drafts", err)
 	}
+	return jobs, nil
+}
 
-	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Order("created_at DESC").Find(&jobs).Error; err != nil {
-		return nil, 0, apperrors.NewAppError(500, "Failed to search jobs", err)
+// ListEndedReviewPeriods returns jobs still pending review after their review due date
+func (r *jobRepository) ListEndedReviewPeriods(ctx context.Context, now time.Time, limit int) ([]*models.Job, error) {
+	var jobs []*models.Job
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND review_due_date IS NOT NULL AND review_due_date <= ?", "review_pending", now).
+		Order("review_due_date ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get jobs past their review period", err)
 	}
+	return jobs, nil
+}
 
-	return jobs, total, nil
+// ClaimApplicationReminders stamps and returns posted jobs holding submitted
+// applications from before appliedBefore, unless they were reminded after
+// remindedBefore. The single UPDATE makes the claim atomic, so replicas running
+// the scheduler never remind twice.
+func (r *jobRepository) ClaimApplicationReminders(ctx context.Context, appliedBefore, remindedBefore, now time.Time) ([]models.PendingApplications, error) {
+	var pending []models.PendingApplications
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE jobs
+		SET applications_reminded_at = ?
+		WHERE status = ?
+		AND (applications_reminded_at IS NULL OR applications_reminded_at <= ?)
+		AND EXISTS (
+			SELECT 1 FROM applications
+			WHERE applications.job_id = jobs.id AND applications.status = ? AND applications.applied_at <= ?
+		)
+		RETURNING id AS job_id, title AS job_title, employer_id,
+			(SELECT COUNT(*) FROM applications WHERE applications.job_id = jobs.id AND applications.status = ?) AS pending`,
+		now, "posted", remindedBefore, "submitted", appliedBefore, "submitted",
+	).Scan(&pending).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to claim application reminders", err)
+	}
+	return pending, nil
+}
+
+// ClaimReviewReminders stamps and returns the jobs pending review whose review
+// period ends between now and dueBefore and that haven't been reminded yet,
+// atomically like ClaimApplicationReminders
+func (r *jobRepository) ClaimReviewReminders(ctx context.Context, dueBefore, now time.Time) ([]models.Job, error) {
+	var jobs []models.Job
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE jobs
+		SET review_reminded_at = ?
+		WHERE status = ? AND review_reminded_at IS NULL
+		AND review_due_date > ? AND review_due_date <= ?
+		RETURNING *`,
+		now, "review_pending", now, dueBefore,
+	).Scan(&jobs).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to claim review reminders", err)
+	}
+	return jobs, nil
 }
 
 // GetActiveJobs returns active jobs for matching algorithm
 func (r *jobRepository) GetActiveJobs(ctx context.Context, jobs *[]models.Job, limit int) error {
 	return r.db.WithContext(ctx).
-		Where("status = ?", "active").
+		Where("status = ?", "posted").
 		Limit(limit).
 		Order("created_at DESC").
 		Find(jobs).Error
@@ -146,7 +498,7 @@ func (r *jobRepository) GetActiveJobs(ctx context.Context, jobs *[]models.Job, l
 func (r *jobRepository) GetJobs(ctx context.Context, jobs *[]models.Job, page, limit int, location string, skills []string) (int64, error) {
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&models.Job{}).Where("status = ?", "active")
+	query := r.db.WithContext(ctx).Model(&models.Job{}).Where("status = ?", "posted")
 
 	// Apply location filter
 	if location != "" {
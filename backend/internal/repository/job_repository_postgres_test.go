@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/database/migrations"
+	"microbridge/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestJobRepository_DeadlineClaims(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`ALTER TABLE jobs ADD COLUMN title TEXT, ADD COLUMN expires_at TIMESTAMP, ADD COLUMN review_due_date TIMESTAMP;
+		CREATE TABLE offers (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), job_id TEXT, status VARCHAR(20));`).Error; err != nil {
+		t.Fatalf("Failed to extend the jobs fixture: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "add_job_deadline_tracking" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	employerID, studentID := uuid.New().String(), uuid.New().String()
+	waitingID, freshID, expiredID, reviewID := uuid.New().String(), uuid.New().String(), uuid.New().String(), uuid.New().String()
+	offeredID := uuid.New().String()
+	seedFixtures(t, db,
+		fixture{"INSERT INTO jobs (id, employer_id, title, status) VALUES (?, ?, 'Waiting', 'posted'), (?, ?, 'Fresh', 'posted')",
+			[]interface{}{waitingID, employerID, freshID, employerID}},
+		fixture{"INSERT INTO jobs (id, employer_id, title, status, application_deadline) VALUES (?, ?, 'Expired', 'posted', ?), (?, ?, 'Offered', 'posted', ?)",
+			[]interface{}{expiredID, employerID, now.Add(-time.Hour), offeredID, employerID, now.Add(-2 * time.Hour)}},
+		// Only an open offer keeps an expired posting from closing
+		fixture{"INSERT INTO offers (job_id, status) VALUES (?, 'declined'), (?, 'extended')", []interface{}{expiredID, offeredID}},
+		fixture{"INSERT INTO jobs (id, employer_id, title, status, review_due_date, hired_student_id) VALUES (?, ?, 'Reviewing', 'review_pending', ?, ?)",
+			[]interface{}{reviewID, employerID, now.Add(24 * time.Hour), studentID}},
+		fixture{"INSERT INTO applications (id, user_id, job_id, status, applied_at) VALUES (?, ?, ?, 'submitted', ?), (?, ?, ?, 'submitted', ?), (?, ?, ?, 'submitted', ?)",
+			[]interface{}{
+				uuid.New().String(), studentID, waitingID, now.Add(-96 * time.Hour),
+				uuid.New().String(), uuid.New().String(), waitingID, now,
+				uuid.New().String(), studentID, freshID, now,
+			}},
+	)
+
+	repo := NewJobRepository(db)
+
+	expired, err := repo.ListExpiredPostings(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("ListExpiredPostings failed: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != expiredID {
+		t.Errorf("Expected only the expired posting without an open offer, got %+v", expired)
+	}
+	// Still within the grace period
+	if expired, err = repo.ListExpiredPostings(ctx, now.Add(-2*time.Hour), 10); err != nil || len(expired) != 0 {
+		t.Errorf("Expected no posting past the grace period yet, got %+v, %v", expired, err)
+	}
+
+	cutoff := now.Add(-72 * time.Hour)
+	pending, err := repo.ClaimApplicationReminders(ctx, cutoff, cutoff, now)
+	if err != nil {
+		t.Fatalf("ClaimApplicationReminders failed: %v", err)
+	}
+	// The fresh job's only application isn't overdue yet
+	if len(pending) != 1 || pending[0].JobID != waitingID || pending[0].Pending != 2 || pending[0].JobTitle != "Waiting" {
+		t.Fatalf("Expected the waiting job with two pending applications, got %+v", pending)
+	}
+	if pending, err = repo.ClaimApplicationReminders(ctx, cutoff, cutoff, now); err != nil || len(pending) != 0 {
+		t.Errorf("Expected a second claim to find nothing, got %+v, %v", pending, err)
+	}
+	// Once the reminder interval has gone by the employer is reminded again
+	later := now.Add(73 * time.Hour)
+	if pending, err = repo.ClaimApplicationReminders(ctx, later.Add(-72*time.Hour), later.Add(-72*time.Hour), later); err != nil || len(pending) != 2 {
+		t.Errorf("Expected both jobs to be due again, got %+v, %v", pending, err)
+	}
+
+	reviews, err := repo.ClaimReviewReminders(ctx, now.Add(72*time.Hour), now)
+	if err != nil {
+		t.Fatalf("ClaimReviewReminders failed: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].ID != reviewID || reviews[0].HiredStudentID == nil {
+		t.Fatalf("Expected the job pending review, got %+v", reviews)
+	}
+	if reviews, err = repo.ClaimReviewReminders(ctx, now.Add(72*time.Hour), now); err != nil || len(reviews) != 0 {
+		t.Errorf("Expected the review reminder to be claimed once, got %+v, %v", reviews, err)
+	}
+}
+
+func TestJobRepository_Search(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`ALTER TABLE jobs ADD COLUMN title TEXT, ADD COLUMN description TEXT, ADD COLUMN location TEXT,
+		ADD COLUMN experience_level TEXT, ADD COLUMN is_remote BOOLEAN DEFAULT false, ADD COLUMN created_at TIMESTAMP;`).Error; err != nil {
+		t.Fatalf("Failed to extend the jobs fixture: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "add_job_search_vector" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	employerID := uuid.New().String()
+	titleID, skillID, descriptionID, otherID := uuid.New().String(), uuid.New().String(), uuid.New().String(), uuid.New().String()
+	insert := `INSERT INTO jobs (id, employer_id, status, title, description, skills, category, job_type, experience_level, is_remote, created_at)
+		VALUES (?, ?, 'posted', ?, ?, ?, ?, ?, ?, ?, ?)`
+	seedFixtures(t, db,
+		fixture{insert, []interface{}{descriptionID, employerID, "Data pipeline work", "Build ETL jobs, mostly <b>Python</b> scripting.", `[]`, "data", "contract", "entry", false, now}},
+		fixture{insert, []interface{}{skillID, employerID, "Backend developer", "Maintain our APIs.", `[{"name": "Python"}]`, "engineering", "part-time", "intermediate", true, now.Add(-time.Hour)}},
+		fixture{insert, []interface{}{titleID, employerID, "Python developer", "Write services for the platform.", `[]`, "engineering", "contract", "intermediate", true, now.Add(-2 * time.Hour)}},
+		fixture{insert, []interface{}{otherID, employerID, "Graphic designer", "Design marketing material.", `[{"name": "Figma"}]`, "design", "contract", "entry", false, now}},
+	)
+
+	repo := NewJobRepository(db)
+
+	result, err := repo.Search(ctx, "python", map[string]interface{}{"status": "posted"}, 10, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if result.Total != 3 || len(result.Hits) != 3 {
+		t.Fatalf("Expected three Python jobs, got %d total and %+v", result.Total, result.Hits)
+	}
+	// A title match outranks a skill, which outranks the description, despite being older
+	for i, want := range []string{titleID, skillID, descriptionID} {
+		if result.Hits[i].Job.ID != want {
+			t.Errorf("Expected hit %d to be %s, got %s", i, want, result.Hits[i].Job.ID)
+		}
+	}
+	if rank := result.Hits[0].Rank; rank <= result.Hits[1].Rank || rank >= 1 {
+		t.Errorf("Expected a normalised, descending rank, got %v then %v", rank, result.Hits[1].Rank)
+	}
+	if got := result.Hits[0].TitleHighlight; got != "<mark>Python</mark> developer" {
+		t.Errorf("Expected the title match highlighted, got %q", got)
+	}
+	if got := result.Hits[2].Snippet; !strings.Contains(got, "<mark>Python</mark>") || strings.Contains(got, "<b>") {
+		t.Errorf("Expected an escaped snippet with the match highlighted, got %q", got)
+	}
+
+	facets := result.Facets
+	if len(facets.Category) != 2 || facets.Category[0] != (FacetCount{Value: "engineering", Count: 2}) {
+		t.Errorf("Unexpected category facets %+v", facets.Category)
+	}
+	if len(facets.JobType) != 2 || facets.JobType[0] != (FacetCount{Value: "contract", Count: 2}) {
+		t.Errorf("Unexpected job type facets %+v", facets.JobType)
+	}
+	if len(facets.ExperienceLevel) != 2 || len(facets.Remote) != 2 || facets.Remote[0] != (FacetCount{Value: "true", Count: 2}) {
+		t.Errorf("Unexpected experience level or remote facets %+v %+v", facets.ExperienceLevel, facets.Remote)
+	}
+
+	// Ranking without excerpts, then highlighting only the kept page, pages the
+	// same order as Search
+	ranked, err := repo.RankMatches(ctx, "python", map[string]interface{}{"status": "posted"}, 2)
+	if err != nil || ranked.Total != 3 || len(ranked.Hits) != 2 {
+		t.Fatalf("Expected the best two of three Python jobs, got %+v, %v", ranked, err)
+	}
+	if ranked.Hits[0].Job.ID != titleID || ranked.Hits[1].Job.ID != skillID || ranked.Hits[0].TitleHighlight != "" {
+		t.Errorf("Expected the text order without excerpts, got %+v", ranked.Hits)
+	}
+	if err := repo.Highlight(ctx, "python", ranked.Hits[:1]); err != nil {
+		t.Fatalf("Highlight failed: %v", err)
+	}
+	if got := ranked.Hits[0].TitleHighlight; got != "<mark>Python</mark> developer" || ranked.Hits[1].TitleHighlight != "" {
+		t.Errorf("Expected only the kept hit highlighted, got %q and %q", got, ranked.Hits[1].TitleHighlight)
+	}
+	rest, err := repo.Search(ctx, "python", map[string]interface{}{"status": "posted"}, 10, 2)
+	if err != nil || len(rest.Hits) != 1 || rest.Hits[0].Job.ID != descriptionID {
+		t.Errorf("Expected the matches after the ranked ones to continue the order, got %+v, %v", rest, err)
+	}
+
+	// Filters apply to the hits and the facets alike
+	result, err = repo.Search(ctx, "python", map[string]interface{}{"job_type": "contract"}, 10, 0)
+	if err != nil || result.Total != 2 || len(result.Facets.JobType) != 1 {
+		t.Errorf("Expected two Python contracts, got %+v, %v", result, err)
+	}
+
+	// Without a query every job is listed newest first, without excerpts
+	result, err = repo.Search(ctx, "  ", nil, 2, 0)
+	if err != nil || result.Total != 4 || len(result.Hits) != 2 || result.Hits[0].Snippet != "" {
+		t.Fatalf("Expected the newest two of four jobs, got %+v, %v", result, err)
+	}
+	if result.Hits[1].Job.ID == titleID {
+		t.Errorf("Expected newest first, got the oldest job on the first page")
+	}
+}
+
+func TestJobRepository_UpdateKeepsLifecycleAndCounters(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.AutoMigrate(&models.Job{}); err != nil {
+		t.Fatalf("Failed to extend the jobs fixture: %v", err)
+	}
+
+	ctx := context.Background()
+	jobID, studentID := uuid.New().String(), uuid.New().String()
+	seedFixtures(t, db,
+		fixture{"INSERT INTO jobs (id, employer_id, title, status, applications) VALUES (?, ?, 'Original', 'posted', 0)",
+			[]interface{}{jobID, uuid.New().String()}},
+	)
+
+	repo := NewJobRepository(db)
+	stale, err := repo.GetByID(ctx, jobID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+
+	// The job is hired and applied to after the employer loaded it
+	seedFixtures(t, db,
+		fixture{"UPDATE jobs SET status = 'hired', hired_student_id = ?, applications = 2 WHERE id = ?", []interface{}{studentID, jobID}},
+	)
+	if err := repo.IncrementApplications(ctx, jobID); err != nil {
+		t.Fatalf("IncrementApplications failed: %v", err)
+	}
+
+	stale.Title = "Edited"
+	if err := repo.Update(ctx, stale); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	job, err := repo.GetByID(ctx, jobID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if job.Title != "Edited" {
+		t.Errorf("Expected the edited title to be saved, got %q", job.Title)
+	}
+	if job.Status != "hired" || job.HiredStudentID == nil || *job.HiredStudentID != studentID || job.Applications != 3 {
+		t.Errorf("Expected the hire and application count to survive a stale save, got %s, %v and %d",
+			job.Status, job.HiredStudentID, job.Applications)
+	}
+
+	if err := repo.IncrementApplications(ctx, uuid.New().String()); !isNotFound(err) {
+		t.Errorf("Expected not found counting an application for a missing job, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+type OfferRepository interface {
+	Create(ctx context.Context, offer *models.Offer) error
+	GetByID(ctx context.Context, id string) (*models.Offer, error)
+	// Update saves an offer whose status was fromStatus, failing if it changed in the meantime
+	Update(ctx context.Context, offer *models.Offer, fromStatus string) error
+	// ListByUser returns the offers a user made or received, optionally for one application
+	ListByUser(ctx context.Context, userID, applicationID string) ([]*models.Offer, error)
+	HasOpenOffer(ctx context.Context, applicationID string) (bool, error)
+	// Accept hires the offer's student; see OfferAcceptance
+	Accept(ctx context.Context, offer *models.Offer, job JobChange, hired ApplicationChange, others []ApplicationChange, now time.Time) (*OfferAcceptance, error)
+}
+
+// JobChange is a job status change and the history entry recording it
+type JobChange struct {
+	Job        *models.Job
+	FromStatus string
+	Entry      *models.JobStatusHistory
+}
+
+// ApplicationChange is an application status change and the history entry recording it
+type ApplicationChange struct {
+	Application *models.Application
+	FromStatus  string
+	Entry       *models.ApplicationStatusHistory
+}
+
+// OfferAcceptance is what accepting an offer changed besides the offer itself
+type OfferAcceptance struct {
+	// Closed are the other applications that were closed
+	Closed []*models.Application
+	// Withdrawn are the job's other open offers
+	Withdrawn []models.Offer
+}
+
+var openOfferStatuses = []string{models.OfferExtended, models.OfferCountered}
+
+type offerRepository struct {
+	db *gorm.DB
+}
+
+func NewOfferRepository(db *gorm.DB) OfferRepository {
+	return &offerRepository{db: db}
+}
+
+func (r *offerRepository) Create(ctx context.Context, offer *models.Offer) error {
+	if err := r.db.WithContext(ctx).Create(offer).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to create offer", err)
+	}
+	return nil
+}
+
+func (r *offerRepository) GetByID(ctx context.Context, id string) (*models.Offer, error) {
+	var offer models.Offer
+	if err := r.db.WithContext(ctx).First(&offer, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Offer")
+		}
+		return nil, apperrors.NewAppError(500, "Failed to get offer", err)
+	}
+	return &offer, nil
+}
+
+func (r *offerRepository) Update(ctx context.Context, offer *models.Offer, fromStatus string) error {
+	offer.UpdatedAt = time.Now().UTC()
+	result := r.db.WithContext(ctx).Model(&models.Offer{}).
+		Where("id = ? AND status = ?", offer.ID, fromStatus).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(offer)
+	if result.Error != nil {
+		return apperrors.NewAppError(500, "Failed to update offer", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewAppError(409, "Offer changed in the meantime; reload and try again", nil)
+	}
+	return nil
+}
+
+func (r *offerRepository) ListByUser(ctx context.Context, userID, applicationID string) ([]*models.Offer, error) {
+	var offers []*models.Offer
+	query := r.db.WithContext(ctx).Where("student_id = ? OR employer_id = ?", userID, userID)
+	if applicationID != "" {
+		query = query.Where("application_id = ?", applicationID)
+	}
+	if err := query.Order("created_at DESC").Find(&offers).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to get offers", err)
+	}
+	return offers, nil
+}
+
+func (r *offerRepository) HasOpenOffer(ctx context.Context, applicationID string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Offer{}).
+		Where("application_id = ? AND status IN ?", applicationID, openOfferStatuses).
+		Count(&count).Error; err != nil {
+		return false, apperrors.NewAppError(500, "Failed to check offers", err)
+	}
+	return count > 0, nil
+}
+
+// Accept marks the offer accepted, fills the job with its student, accepts the
+// student's application and closes the job's other applications and offers, all
+// in one transaction. The offer must still be extended and unexpired and the job
+// still posted and unfilled, so of two students accepting at once only one is
+// hired. Other applications that changed in the meantime, say by a withdrawal,
+// are left alone.
+func (r *offerRepository) Accept(ctx context.Context, offer *models.Offer, job JobChange, hired ApplicationChange, others []ApplicationChange, now time.Time) (*OfferAcceptance, error) {
+	acceptance := &OfferAcceptance{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Offer{}).
+			Where("id = ? AND status = ? AND expires_at > ?", offer.ID, models.OfferExtended, now).
+			Updates(map[string]interface{}{"status": models.OfferAccepted, "responded_at": now, "updated_at": now})
+		if result.Error != nil {
+			return apperrors.NewAppError(500, "Failed to accept offer", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewAppError(409, "This offer is no longer open", nil)
+		}
+
+		result = tx.Table("jobs").
+			Where("id = ? AND status = ? AND hired_student_id IS NULL", offer.JobID, job.FromStatus).
+			Updates(map[string]interface{}{
+				"status":           job.Job.Status,
+				"hired_student_id": job.Job.HiredStudentID,
+				"updated_at":       job.Job.UpdatedAt,
+			})
+		if result.Error != nil {
+			return apperrors.NewAppError(500, "Failed to fill job", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewAppError(409, "This position has already been filled or closed", nil)
+		}
+		if err := tx.Create(job.Entry).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to record job status", err)
+		}
+
+		applied, err := applyApplicationChange(tx, hired)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return apperrors.NewAppError(409, "Application status changed in the meantime; reload and try again", nil)
+		}
+
+		for _, change := range others {
+			applied, err := applyApplicationChange(tx, change)
+			if err != nil {
+				return err
+			}
+			if applied {
+				acceptance.Closed = append(acceptance.Closed, change.Application)
+			}
+		}
+
+		if err := tx.Raw(`
+			UPDATE offers
+			SET status = ?, responded_at = ?, updated_at = ?
+			WHERE job_id = ? AND id <> ? AND status IN ?
+			RETURNING *`,
+			models.OfferWithdrawn, now, now, offer.JobID, offer.ID, openOfferStatuses,
+		).Scan(&acceptance.Withdrawn).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to withdraw other offers", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offer.Status = models.OfferAccepted
+	offer.RespondedAt = &now
+	offer.UpdatedAt = now
+	return acceptance, nil
+}
+
+// applyApplicationChange writes a status change while the stored status is
+// still the one it started from, and reports whether it did
+func applyApplicationChange(tx *gorm.DB, change ApplicationChange) (bool, error) {
+	application := change.Application
+	result := tx.Model(&models.Application{}).
+		Where("id = ? AND status = ?", application.ID, change.FromStatus).
+		Updates(map[string]interface{}{
+			"status":            application.Status,
+			"reviewed_at":       application.ReviewedAt,
+			"response_at":       application.ResponseAt,
+			"employer_feedback": application.EmployerFeedback,
+			"updated_at":        application.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, apperrors.NewAppError(500, "Failed to update application status", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	if err := tx.Create(change.Entry).Error; err != nil {
+		return false, apperrors.NewAppError(500, "Failed to record application status", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/database/migrations"
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+func TestOfferRepository_Accept(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`
+		ALTER TABLE jobs ALTER COLUMN id TYPE UUID USING id::uuid, ADD COLUMN created_at TIMESTAMP;
+		ALTER TABLE applications ADD COLUMN employer_feedback TEXT;
+	`).Error; err != nil {
+		t.Fatalf("Failed to adapt the fixtures: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "create_application_status_history" || migration.Name == "create_offers" ||
+			migration.Name == "create_job_status_history" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	employerID, hiredID, otherID := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	jobID := uuid.New().String()
+	hiredAppID, otherAppID := uuid.New().String(), uuid.New().String()
+	seedFixtures(t, db,
+		fixture{"INSERT INTO users (id, user_type) VALUES (?, 'employer'), (?, 'student'), (?, 'student')", []interface{}{employerID, hiredID, otherID}},
+		fixture{"INSERT INTO jobs (id, employer_id, status) VALUES (?, ?, 'posted')", []interface{}{jobID, employerID}},
+		fixture{"INSERT INTO applications (id, user_id, job_id, status) VALUES (?, ?, ?, 'interviewed'), (?, ?, ?, 'reviewed')",
+			[]interface{}{hiredAppID, hiredID, jobID, otherAppID, otherID, jobID}},
+	)
+
+	repo := NewOfferRepository(db)
+	extend := func(applicationID, studentID string) *models.Offer {
+		offer := &models.Offer{
+			ApplicationID: applicationID, JobID: jobID, EmployerID: employerID, StudentID: studentID,
+			Status: models.OfferExtended, Pay: models.OfferPay{Amount: 30, Currency: "USD", Period: "hourly"},
+			ExpiresAt: now.Add(time.Hour), CreatedAt: now, UpdatedAt: now,
+		}
+		if err := repo.Create(ctx, offer); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		return offer
+	}
+	hiredOffer := extend(hiredAppID, hiredID)
+	otherOffer := extend(otherAppID, otherID)
+
+	change := func(applicationID, studentID, from, to string) ApplicationChange {
+		application := &models.Application{ID: applicationID, UserID: studentID, Status: to, ResponseAt: &now, UpdatedAt: now}
+		return ApplicationChange{
+			Application: application,
+			FromStatus:  from,
+			Entry: &models.ApplicationStatusHistory{
+				ApplicationID: applicationID, FromStatus: from, ToStatus: to, ActorRole: "system", CreatedAt: now,
+			},
+		}
+	}
+	fill := func(studentID string) JobChange {
+		job := &models.Job{ID: jobID, Status: "hired", HiredStudentID: &studentID, UpdatedAt: now}
+		return JobChange{
+			Job:        job,
+			FromStatus: "posted",
+			Entry:      &models.JobStatusHistory{JobID: jobID, FromStatus: "posted", ToStatus: "hired", ActorRole: "system", CreatedAt: now},
+		}
+	}
+	hired := change(hiredAppID, hiredID, "interviewed", "accepted")
+	others := []ApplicationChange{
+		change(otherAppID, otherID, "reviewed", "rejected"),
+		// Stale: the application moved on before the acceptance
+		change(uuid.New().String(), otherID, "submitted", "rejected"),
+	}
+
+	acceptance, err := repo.Accept(ctx, hiredOffer, fill(hiredID), hired, others, now)
+	if err != nil {
+		t.Fatalf("Accept failed: %v", err)
+	}
+	if len(acceptance.Closed) != 1 || acceptance.Closed[0].ID != otherAppID {
+		t.Errorf("Expected only the other application to be closed, got %+v", acceptance.Closed)
+	}
+	if len(acceptance.Withdrawn) != 1 || acceptance.Withdrawn[0].ID != otherOffer.ID {
+		t.Errorf("Expected the other offer to be withdrawn, got %+v", acceptance.Withdrawn)
+	}
+
+	var job struct {
+		Status         string
+		HiredStudentID *string
+	}
+	if err := db.Raw("SELECT status, hired_student_id FROM jobs WHERE id = ?", jobID).Scan(&job).Error; err != nil {
+		t.Fatalf("Failed to read the job: %v", err)
+	}
+	if job.Status != "hired" || job.HiredStudentID == nil || *job.HiredStudentID != hiredID {
+		t.Errorf("Expected the job to be hired by the student, got %+v", job)
+	}
+
+	var history int64
+	db.Table("application_status_history").Count(&history)
+	if history != 2 {
+		t.Errorf("Expected history for the two changed applications, got %d entries", history)
+	}
+	var jobHistory int64
+	db.Table("job_status_history").Where("to_status = ?", "hired").Count(&jobHistory)
+	if jobHistory != 1 {
+		t.Errorf("Expected the hire in the job's history, got %d entries", jobHistory)
+	}
+
+	// The other offer was withdrawn with the job filled, so it can't be accepted any more
+	otherOffer.Status = models.OfferExtended
+	_, err = repo.Accept(ctx, otherOffer, fill(otherID), change(otherAppID, otherID, "rejected", "accepted"), nil, now)
+	if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != 409 {
+		t.Fatalf("Expected a conflict accepting a second offer, got %v", err)
+	}
+}
@@ -0,0 +1,419 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Data subject request types
+const (
+	DataRequestExport  = "export"
+	DataRequestErasure = "erasure"
+)
+
+type PrivacyRepository interface {
+	ExportUserData(ctx context.Context, userID string) (*UserDataExport, error)
+	EraseUserData(ctx context.Context, userID, requestedBy string) (*DataSubjectRequest, error)
+	RecordDataSubjectRequest(ctx context.Context, request *DataSubjectRequest) error
+	ListDataSubjectRequests(ctx context.Context, userID string) ([]*DataSubjectRequest, error)
+}
+
+// UserDataExport is every row that references a user, keyed by table
+type UserDataExport struct {
+	UserID      string                              `json:"user_id"`
+	GeneratedAt time.Time                           `json:"generated_at"`
+	Tables      map[string][]map[string]interface{} `json:"tables"`
+}
+
+// DataSubjectRequest is the audit record of an export or erasure. AffectedRows
+// counts the rows exported, deleted or anonymized per table.
+type DataSubjectRequest struct {
+	ID           string           `json:"id"`
+	UserID       string           `json:"user_id"`
+	Type         string           `json:"type"`
+	RequestedBy  string           `json:"requested_by"`
+	AffectedRows map[string]int64 `json:"affected_rows"`
+	CreatedAt    time.Time        `json:"created_at"`
+	// OrphanedFileKeys are the storage keys of erased files that no remaining file
+	// row shares; their stored objects can be deleted. Not persisted.
+	OrphanedFileKeys []string `json:"-"`
+}
+
+type dataSubjectRequestRecord struct {
+	ID           string `gorm:"primaryKey"`
+	UserID       string
+	RequestType  string
+	RequestedBy  string
+	AffectedRows string `gorm:"type:jsonb"`
+	CreatedAt    time.Time
+}
+
+func (dataSubjectRequestRecord) TableName() string { return "data_subject_requests" }
+
+// erasureMode is how EraseUserData treats a table's rows
+type erasureMode int
+
+const (
+	erasureDelete erasureMode = iota
+	erasureKeep               // Kept for the other party; anonymized through the user row or separately
+)
+
+// personalDataTable is a table holding rows about a user
+type personalDataTable struct {
+	name    string
+	columns []string // Columns that reference the user
+	erasure erasureMode
+	// legacyIDs marks tables that key users by integer; they are compared as text
+	legacyIDs bool
+}
+
+// personalDataTables lists every table that references users. Tables and
+// columns missing from the schema are skipped, so models without a migration
+// can be listed.
+var personalDataTables = []personalDataTable{
+	{name: "users", columns: []string{"id"}, erasure: erasureKeep},
+	{name: "user_profiles", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "employer_profiles", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "jobs", columns: []string{"employer_id", "hired_student_id"}, erasure: erasureKeep},
+	{name: "applications", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "application_status_history", columns: []string{"actor_id"}, erasure: erasureKeep},
+	{name: "job_status_history", columns: []string{"actor_id"}, erasure: erasureKeep},
+	{name: "interviews", columns: []string{"student_id", "employer_id"}, erasure: erasureKeep},
+	{name: "offers", columns: []string{"student_id", "employer_id"}, erasure: erasureKeep},
+	{name: "application_bulk_operations", columns: []string{"employer_id"}, erasure: erasureDelete},
+	{name: "files", columns: []string{"owner_id"}, erasure: erasureDelete},
+	{name: "reviews", columns: []string{"reviewer_id", "reviewee_id"}, erasure: erasureKeep},
+	{name: "projects", columns: []string{"freelancer_id", "employer_id"}, erasure: erasureKeep, legacyIDs: true},
+	{name: "notifications", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "notification_settings", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "match_cache", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_actions", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_actions_archive", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_sessions", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_behavior_patterns", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_preference_signals", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_engagement_metrics", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_search_patterns", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "user_skill_interests", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "ai_bandit_decisions", columns: []string{"user_id"}, erasure: erasureDelete},
+	{name: "data_subject_requests", columns: []string{"user_id"}, erasure: erasureKeep},
+}
+
+// exportExcludedColumns are credentials, which are not exported
+var exportExcludedColumns = map[string]map[string]bool{
+	"users": {"password_hash": true, "verification_token": true, "reset_token": true, "reset_token_expires_at": true},
+}
+
+// erasedUserColumns replace the personal fields of an erased user. The row
+// itself stays so reviews, jobs and projects keep a valid reference.
+func erasedUserColumns(userID string, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"email":                  "erased-" + userID + "@erased.invalid",
+		"name":                   "Deleted user",
+		"first_name":             "Deleted",
+		"last_name":              "user",
+		"password_hash":          "",
+		"verification_token":     nil,
+		"reset_token":            nil,
+		"reset_token_expires_at": nil,
+		"is_active":              false,
+		"skills":                 "[]",
+		"interests":              "[]",
+		"learning_goals":         "[]",
+		"career_goals":           "[]",
+		"availability":           "{}",
+		"location":               "",
+		"bio":                    "",
+		"portfolio":              "",
+		"resume":                 "",
+		"preferred_salary":       "",
+		"work_preference":        "",
+		"erased_at":              now,
+		"updated_at":             now,
+	}
+}
+
+type privacyRepository struct {
+	db *gorm.DB
+}
+
+func NewPrivacyRepository(db *gorm.DB) PrivacyRepository {
+	return &privacyRepository{db: db}
+}
+
+// ExportUserData collects the user's rows from every personal data table
+func (r *privacyRepository) ExportUserData(ctx context.Context, userID string) (*UserDataExport, error) {
+	db := r.db.WithContext(ctx)
+	if err := requireUser(db, userID); err != nil {
+		return nil, err
+	}
+
+	export := &UserDataExport{
+		UserID:      userID,
+		GeneratedAt: time.Now().UTC(),
+		Tables:      make(map[string][]map[string]interface{}),
+	}
+	for _, table := range personalDataTables {
+		table = table.inSchema(db)
+		if len(table.columns) == 0 {
+			continue
+		}
+		var rows []map[string]interface{}
+		if err := db.Table(table.name).Where(table.condition(), table.args(userID)...).Find(&rows).Error; err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to export "+table.name, err)
+		}
+		for _, row := range rows {
+			for column, value := range row {
+				if exportExcludedColumns[table.name][column] {
+					delete(row, column)
+					continue
+				}
+				row[column] = exportValue(value)
+			}
+		}
+		export.Tables[table.name] = rows
+	}
+	return export, nil
+}
+
+// EraseUserData deletes the user's personal data and anonymizes what other users
+// rely on: the user row becomes a tombstone, reviews they wrote become anonymous
+// and their open jobs are archived. The audit record is written in the same
+// transaction, so an erasure is never left unrecorded.
+func (r *privacyRepository) EraseUserData(ctx context.Context, userID, requestedBy string) (*DataSubjectRequest, error) {
+	now := time.Now().UTC()
+	request := &DataSubjectRequest{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Type:         DataRequestErasure,
+		RequestedBy:  requestedBy,
+		AffectedRows: make(map[string]int64),
+		CreatedAt:    now,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireUser(tx, userID); err != nil {
+			return err
+		}
+
+		// Stored objects are content-addressed and may be shared with other users'
+		// files, so note the keys now and keep only those left unreferenced
+		var fileKeys []string
+		hasFiles := tx.Migrator().HasTable("files")
+		if hasFiles {
+			if err := tx.Table("files").Where("owner_id = ?", userID).Distinct().Pluck("storage_key", &fileKeys).Error; err != nil {
+				return apperrors.NewAppError(500, "Failed to read file keys", err)
+			}
+		}
+
+		for _, table := range personalDataTables {
+			if table.erasure != erasureDelete {
+				continue
+			}
+			if table = table.inSchema(tx); len(table.columns) == 0 {
+				continue
+			}
+			result := tx.Exec("DELETE FROM "+table.name+" WHERE "+table.condition(), table.args(userID)...)
+			if result.Error != nil {
+				return apperrors.NewAppError(500, "Failed to erase "+table.name, result.Error)
+			}
+			request.AffectedRows[table.name] = result.RowsAffected
+		}
+
+		if hasFiles && len(fileKeys) > 0 {
+			var sharedKeys []string
+			if err := tx.Table("files").Where("storage_key IN ?", fileKeys).Distinct().Pluck("storage_key", &sharedKeys).Error; err != nil {
+				return apperrors.NewAppError(500, "Failed to read shared file keys", err)
+			}
+			shared := make(map[string]bool, len(sharedKeys))
+			for _, key := range sharedKeys {
+				shared[key] = true
+			}
+			for _, key := range fileKeys {
+				if !shared[key] {
+					request.OrphanedFileKeys = append(request.OrphanedFileKeys, key)
+				}
+			}
+		}
+
+		if tx.Migrator().HasColumn("reviews", "anonymous") {
+			result := tx.Exec("UPDATE reviews SET anonymous = TRUE, updated_at = ? WHERE reviewer_id = ?", now, userID)
+			if result.Error != nil {
+				return apperrors.NewAppError(500, "Failed to anonymize reviews", result.Error)
+			}
+			request.AffectedRows["reviews"] = result.RowsAffected
+		}
+
+		result := tx.Exec("UPDATE jobs SET status = 'archived', updated_at = ? WHERE employer_id = ? AND status IN ('draft', 'posted')", now, userID)
+		if result.Error != nil {
+			return apperrors.NewAppError(500, "Failed to archive jobs", result.Error)
+		}
+		request.AffectedRows["jobs"] = result.RowsAffected
+
+		columns, err := tx.Migrator().ColumnTypes("users")
+		if err != nil {
+			return apperrors.NewAppError(500, "Failed to read users columns", err)
+		}
+		anonymized := erasedUserColumns(userID, now)
+		updates := make(map[string]interface{}, len(anonymized))
+		for _, column := range columns {
+			if value, ok := anonymized[column.Name()]; ok {
+				updates[column.Name()] = value
+			}
+		}
+		if err := tx.Table("users").Where("id = ?", userID).Updates(updates).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to anonymize user", err)
+		}
+		request.AffectedRows["users"] = 1
+
+		record, err := toDataSubjectRequestRecord(request)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return apperrors.NewAppError(500, "Failed to record erasure", err)
+		}
+		return nil
+	})
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperrors.NewAppError(500, "Failed to erase user data", err)
+	}
+	return request, nil
+}
+
+// RecordDataSubjectRequest stores the audit record of a request handled outside EraseUserData
+func (r *privacyRepository) RecordDataSubjectRequest(ctx context.Context, request *DataSubjectRequest) error {
+	if request.ID == "" {
+		request.ID = uuid.New().String()
+	}
+	if request.CreatedAt.IsZero() {
+		request.CreatedAt = time.Now().UTC()
+	}
+	record, err := toDataSubjectRequestRecord(request)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to record data subject request", err)
+	}
+	return nil
+}
+
+// ListDataSubjectRequests returns the user's exports and erasures, newest first
+func (r *privacyRepository) ListDataSubjectRequests(ctx context.Context, userID string) ([]*DataSubjectRequest, error) {
+	var records []*dataSubjectRequestRecord
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to list data subject requests", err)
+	}
+
+	requests := make([]*DataSubjectRequest, 0, len(records))
+	for _, record := range records {
+		request := &DataSubjectRequest{
+			ID:          record.ID,
+			UserID:      record.UserID,
+			Type:        record.RequestType,
+			RequestedBy: record.RequestedBy,
+			CreatedAt:   record.CreatedAt.UTC(),
+		}
+		if err := decodeJSON(record.AffectedRows, &request.AffectedRows); err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to decode data subject request", err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// Private methods
+
+// inSchema drops the columns the database doesn't have; none are left when the table is missing
+func (t personalDataTable) inSchema(db *gorm.DB) personalDataTable {
+	present := personalDataTable{name: t.name, erasure: t.erasure, legacyIDs: t.legacyIDs}
+	if !db.Migrator().HasTable(t.name) {
+		return present
+	}
+	for _, column := range t.columns {
+		if db.Migrator().HasColumn(t.name, column) {
+			present.columns = append(present.columns, column)
+		}
+	}
+	return present
+}
+
+func (t personalDataTable) condition() string {
+	conditions := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		if t.legacyIDs {
+			column += "::text"
+		}
+		conditions[i] = column + " = ?"
+	}
+	return strings.Join(conditions, " OR ")
+}
+
+func (t personalDataTable) args(userID string) []interface{} {
+	args := make([]interface{}, len(t.columns))
+	for i := range args {
+		args[i] = userID
+	}
+	return args
+}
+
+func requireUser(db *gorm.DB, userID string) error {
+	var count int64
+	if err := db.Table("users").Where("id = ?", userID).Count(&count).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to get user", err)
+	}
+	if count == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// exportValue turns raw column values into JSON-friendly ones; jsonb columns
+// are embedded as JSON rather than as strings
+func exportValue(value interface{}) interface{} {
+	var text string
+	switch v := value.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return value
+	}
+	trimmed := strings.TrimSpace(text)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+	return text
+}
+
+func toDataSubjectRequestRecord(request *DataSubjectRequest) (*dataSubjectRequestRecord, error) {
+	affected, err := encodeJSON(request.AffectedRows, "{}")
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to encode data subject request", err)
+	}
+	return &dataSubjectRequestRecord{
+		ID:           request.ID,
+		UserID:       request.UserID,
+		RequestType:  request.Type,
+		RequestedBy:  request.RequestedBy,
+		AffectedRows: affected,
+		CreatedAt:    request.CreatedAt.UTC(),
+	}, nil
+}
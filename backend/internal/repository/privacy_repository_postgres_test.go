@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microbridge/backend/internal/database/migrations"
+
+	"github.com/google/uuid"
+)
+
+func TestPrivacyRepository_ExportAndErase(t *testing.T) {
+	db := newBehaviorTestDB(t)
+	if err := db.Exec(`
+		ALTER TABLE users ADD COLUMN email TEXT, ADD COLUMN name TEXT, ADD COLUMN password_hash TEXT,
+			ADD COLUMN bio TEXT, ADD COLUMN is_active BOOLEAN DEFAULT TRUE, ADD COLUMN updated_at TIMESTAMP;
+		CREATE TABLE reviews (id UUID PRIMARY KEY, reviewer_id UUID, reviewee_id UUID, rating INTEGER, comment TEXT,
+			anonymous BOOLEAN DEFAULT FALSE, updated_at TIMESTAMP);
+		CREATE TABLE files (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), owner_id UUID, storage_key TEXT);
+	`).Error; err != nil {
+		t.Fatalf("Failed to create privacy fixture tables: %v", err)
+	}
+	for _, migration := range migrations.GetAllMigrations() {
+		if migration.Name == "create_data_subject_requests" {
+			if err := db.Exec(migration.UpSQL).Error; err != nil {
+				t.Fatalf("Failed to apply %s migration: %v", migration.Name, err)
+			}
+		}
+	}
+
+	repo := NewPrivacyRepository(db)
+	behaviorRepo := NewBehaviorRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	userID, employerID, adminID := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+	fixtures := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{"INSERT INTO users (id, user_type, email, name, password_hash, bio, created_at) VALUES (?, 'student', 'ann@example.com', 'Ann', 'hash', 'Go developer', ?)", []interface{}{userID, now}},
+		{"INSERT INTO users (id, user_type, email, name, created_at) VALUES (?, 'employer', 'hr@example.com', 'Acme', ?)", []interface{}{employerID, now}},
+		{"INSERT INTO jobs (id, employer_id, status) VALUES ('job-1', ?, 'posted')", []interface{}{employerID}},
+		{"INSERT INTO applications (id, user_id, job_id, status, applied_at) VALUES (?, ?, 'job-1', 'submitted', ?)", []interface{}{uuid.New().String(), userID, now}},
+		{"INSERT INTO reviews (id, reviewer_id, reviewee_id, rating, comment) VALUES (?, ?, ?, 5, 'Great employer')", []interface{}{uuid.New().String(), userID, employerID}},
+		// The employer uploaded identical content, so that object is shared
+		{"INSERT INTO files (owner_id, storage_key) VALUES (?, 'files/resume'), (?, 'files/shared'), (?, 'files/shared')", []interface{}{userID, userID, userID}},
+		{"INSERT INTO files (owner_id, storage_key) VALUES (?, 'files/shared')", []interface{}{employerID}},
+	}
+	for _, fixture := range fixtures {
+		if err := db.Exec(fixture.sql, fixture.args...).Error; err != nil {
+			t.Fatalf("Failed to insert fixture: %v", err)
+		}
+	}
+	if err := behaviorRepo.StoreUserAction(ctx, UserAction{ID: uuid.New().String(), UserID: userID, ActionType: "view", EntityID: "job-1", Timestamp: now}); err != nil {
+		t.Fatalf("StoreUserAction failed: %v", err)
+	}
+
+	// Export
+	if _, err := repo.ExportUserData(ctx, uuid.New().String()); !isNotFound(err) {
+		t.Errorf("Expected not found exporting a missing user, got %v", err)
+	}
+	export, err := repo.ExportUserData(ctx, userID)
+	if err != nil {
+		t.Fatalf("ExportUserData failed: %v", err)
+	}
+	if len(export.Tables["users"]) != 1 || len(export.Tables["applications"]) != 1 ||
+		len(export.Tables["reviews"]) != 1 || len(export.Tables["user_actions"]) != 1 {
+		t.Errorf("Expected the user's rows in every table, got %v", export.Tables)
+	}
+	if _, leaked := export.Tables["users"][0]["password_hash"]; leaked {
+		t.Error("Expected credentials to be left out of the export")
+	}
+	if _, hasEmployerProfiles := export.Tables["employer_profiles"]; hasEmployerProfiles {
+		t.Error("Expected tables missing from the schema to be skipped")
+	}
+
+	// Erasure
+	request, err := repo.EraseUserData(ctx, userID, adminID)
+	if err != nil {
+		t.Fatalf("EraseUserData failed: %v", err)
+	}
+	if request.AffectedRows["applications"] != 1 || request.AffectedRows["user_actions"] != 1 || request.AffectedRows["reviews"] != 1 ||
+		request.AffectedRows["files"] != 3 {
+		t.Errorf("Unexpected affected rows: %v", request.AffectedRows)
+	}
+	if len(request.OrphanedFileKeys) != 1 || request.OrphanedFileKeys[0] != "files/resume" {
+		t.Errorf("Expected only the unshared object to be orphaned, got %v", request.OrphanedFileKeys)
+	}
+
+	var user struct {
+		Email    string
+		Name     string
+		Bio      string
+		IsActive bool
+		ErasedAt *time.Time
+	}
+	if err := db.Table("users").Where("id = ?", userID).Take(&user).Error; err != nil {
+		t.Fatalf("Expected the user row to remain: %v", err)
+	}
+	if user.Email == "ann@example.com" || user.Name != "Deleted user" || user.Bio != "" || user.IsActive || user.ErasedAt == nil {
+		t.Errorf("Expected the user to be anonymized, got %+v", user)
+	}
+	var remaining, anonymous int64
+	db.Table("applications").Where("user_id = ?", userID).Count(&remaining)
+	db.Table("reviews").Where("reviewer_id = ? AND anonymous AND comment = 'Great employer'", userID).Count(&anonymous)
+	if remaining != 0 || anonymous != 1 {
+		t.Errorf("Expected applications deleted and the review kept anonymously, got %d and %d", remaining, anonymous)
+	}
+
+	// Employers' open jobs are archived
+	if _, err := repo.EraseUserData(ctx, employerID, adminID); err != nil {
+		t.Fatalf("EraseUserData failed: %v", err)
+	}
+	var status string
+	db.Table("jobs").Select("status").Where("id = 'job-1'").Scan(&status)
+	if status != "archived" {
+		t.Errorf("Expected the erased employer's job to be archived, got %s", status)
+	}
+
+	requests, err := repo.ListDataSubjectRequests(ctx, userID)
+	if err != nil || len(requests) != 1 || requests[0].Type != DataRequestErasure || requests[0].RequestedBy != adminID {
+		t.Errorf("Expected the erasure in the audit trail, got %+v (%v)", requests, err)
+	}
+}
@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"microbridge/backend/internal/ai/features"
+	aiModels "microbridge/backend/internal/ai/models"
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+// applicationLabels maps terminal application outcomes to training labels
+var applicationLabels = map[string]float64{
+	"accepted":    1.0,
+	"interviewed": 0.75,
+	"rejected":    0.0,
+}
+
+// trainingLoadChunkSize bounds the IDs bound into one query when loading the
+// users and jobs behind training samples
+const trainingLoadChunkSize = 10000
+
+type TrainingRepository interface {
+	GetTrainingSamples(ctx context.Context, since time.Time, limit int) ([]*aiModels.TrainingData, error)
+	SaveTrainingJob(ctx context.Context, job *aiModels.TrainingJob) error
+	ListTrainingJobs(ctx context.Context, modelType string, limit int) ([]*aiModels.TrainingJob, error)
+	ListUnfinishedTrainingJobs(ctx context.Context) ([]*aiModels.TrainingJob, error)
+	SaveModelArtifact(ctx context.Context, artifact *aiModels.ModelArtifact) error
+	GetActiveArtifact(ctx context.Context, modelType string) (*aiModels.ModelArtifact, error)
+}
+
+// trainingJobRecord is the persisted form of aiModels.TrainingJob
+type trainingJobRecord struct {
+	ID                string `gorm:"primaryKey"`
+	ModelType         string
+	Version           string
+	Status            string
+	Progress          float64
+	DatasetSize       int
+	Config            string `gorm:"type:jsonb"`
+	TrainingMetrics   string `gorm:"type:jsonb"`
+	ValidationMetrics string `gorm:"type:jsonb"`
+	ErrorMessage      string
+	CreatedBy         string
+	StartedAt         *time.Time
+	CompletedAt       *time.Time
+	UpdatedAt         time.Time
+}
+
+func (trainingJobRecord) TableName() string {
+	return "ai_training_jobs"
+}
+
+// modelArtifactRecord is the persisted form of aiModels.ModelArtifact
+type modelArtifactRecord struct {
+	ID            string `gorm:"primaryKey"`
+	ModelType     string
+	Version       string
+	TrainingJobID string
+	ModelPath     string
+	ConfigPath    string
+	MetricsPath   string
+	FileSize      int64
+	Checksum      string
+	Metadata      string `gorm:"type:jsonb"`
+	Weights       []byte
+	IsActive      bool
+	CreatedAt     time.Time
+}
+
+func (modelArtifactRecord) TableName() string {
+	return "ai_model_artifacts"
+}
+
+type trainingRepository struct {
+	db       *gorm.DB
+	features *features.Registry
+}
+
+// NewTrainingRepository creates a training repository. Samples are built with the
+// given feature registry so training sees the same features as inference.
+func NewTrainingRepository(db *gorm.DB, registry *features.Registry) TrainingRepository {
+	return &trainingRepository{db: db, features: registry}
+}
+
+// GetTrainingSamples builds labelled user-job pairs from application outcomes
+func (r *trainingRepository) GetTrainingSamples(ctx context.Context, since time.Time, limit int) ([]*aiModels.TrainingData, error) {
+	statuses := make([]string, 0, len(applicationLabels))
+	for status := range applicationLabels {
+		statuses = append(statuses, status)
+	}
+
+	var applications []*models.Application
+	query := r.db.WithContext(ctx).Where("status IN ?", statuses)
+	if !since.IsZero() {
+		query = query.Where("updated_at >= ?", since)
+	}
+	if err := query.Order("updated_at DESC").Limit(limit).Find(&applications).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to load training samples", err)
+	}
+
+	userIDs := make([]string, 0, len(applications))
+	jobIDs := make([]string, 0, len(applications))
+	usersByID := make(map[string]*models.User)
+	jobsByID := make(map[string]*models.Job)
+	for _, application := range applications {
+		if _, seen := usersByID[application.UserID]; !seen {
+			usersByID[application.UserID] = nil
+			userIDs = append(userIDs, application.UserID)
+		}
+		if _, seen := jobsByID[application.JobID]; !seen {
+			jobsByID[application.JobID] = nil
+			jobIDs = append(jobIDs, application.JobID)
+		}
+	}
+
+	// Postgres caps a statement at 65535 parameters, so large datasets load their
+	// users and jobs a chunk at a time
+	for start := 0; start < len(userIDs); start += trainingLoadChunkSize {
+		var users []*models.User
+		chunk := userIDs[start:min(start+trainingLoadChunkSize, len(userIDs))]
+		if err := r.db.WithContext(ctx).Where("id IN ?", chunk).Find(&users).Error; err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to load training users", err)
+		}
+		for _, user := range users {
+			usersByID[user.ID] = user
+		}
+	}
+	for start := 0; start < len(jobIDs); start += trainingLoadChunkSize {
+		var jobs []*models.Job
+		chunk := jobIDs[start:min(start+trainingLoadChunkSize, len(jobIDs))]
+		if err := r.db.WithContext(ctx).Where("id IN ?", chunk).Find(&jobs).Error; err != nil {
+			return nil, apperrors.NewAppError(500, "Failed to load training jobs", err)
+		}
+		for _, job := range jobs {
+			jobsByID[job.ID] = job
+		}
+	}
+
+	samples := make([]*aiModels.TrainingData, 0, len(applications))
+	for _, application := range applications {
+		user, job := usersByID[application.UserID], jobsByID[application.JobID]
+		if user == nil || job == nil {
+			continue
+		}
+		featureVector := r.features.ComputeVector(user, job)
+		featureVector.ContextFeatures["match_score"] = application.MatchScore
+
+		samples = append(samples, &aiModels.TrainingData{
+			ID:         application.ID,
+			UserID:     application.UserID,
+			JobID:      application.JobID,
+			Features:   *featureVector,
+			Label:      applicationLabels[application.Status],
+			Weight:     1.0,
+			DataSource: "applications",
+			CreatedAt:  application.UpdatedAt,
+		})
+	}
+
+	return samples, nil
+}
+
+func (r *trainingRepository) SaveTrainingJob(ctx context.Context, job *aiModels.TrainingJob) error {
+	record, err := toTrainingJobRecord(job)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode training job", err)
+	}
+	if err := r.db.WithContext(ctx).Save(record).Error; err != nil {
+		return apperrors.NewAppError(500, "Failed to save training job", err)
+	}
+	return nil
+}
+
+func (r *trainingRepository) ListTrainingJobs(ctx context.Context, modelType string, limit int) ([]*aiModels.TrainingJob, error) {
+	var records []*trainingJobRecord
+	query := r.db.WithContext(ctx)
+	if modelType != "" {
+		query = query.Where("model_type = ?", modelType)
+	}
+	if err := query.Order("started_at DESC NULLS FIRST").Limit(limit).Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to list training jobs", err)
+	}
+
+	jobs := make([]*aiModels.TrainingJob, 0, len(records))
+	for _, record := range records {
+		jobs = append(jobs, record.toTrainingJob())
+	}
+	return jobs, nil
+}
+
+// ListUnfinishedTrainingJobs returns pending and running jobs, oldest first
+func (r *trainingRepository) ListUnfinishedTrainingJobs(ctx context.Context) ([]*aiModels.TrainingJob, error) {
+	var records []*trainingJobRecord
+	if err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{"pending", "running"}).
+		Order("updated_at ASC").
+		Find(&records).Error; err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to list unfinished training jobs", err)
+	}
+
+	jobs := make([]*aiModels.TrainingJob, 0, len(records))
+	for _, record := range records {
+		jobs = append(jobs, record.toTrainingJob())
+	}
+	return jobs, nil
+}
+
+// SaveModelArtifact stores a new artifact and makes it the only active one for its model type
+func (r *trainingRepository) SaveModelArtifact(ctx context.Context, artifact *aiModels.ModelArtifact) error {
+	metadata, err := json.Marshal(artifact.Metadata)
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to encode model artifact", err)
+	}
+
+	record := &modelArtifactRecord{
+		ID:            artifact.ID,
+		ModelType:     artifact.ModelType,
+		Version:       artifact.Version,
+		TrainingJobID: artifact.TrainingJobID,
+		ModelPath:     artifact.ModelPath,
+		ConfigPath:    artifact.ConfigPath,
+		MetricsPath:   artifact.MetricsPath,
+		FileSize:      artifact.FileSize,
+		Checksum:      artifact.Checksum,
+		Metadata:      string(metadata),
+		Weights:       artifact.Weights,
+		IsActive:      artifact.IsActive,
+		CreatedAt:     artifact.CreatedAt,
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if record.IsActive {
+			if err := tx.Model(&modelArtifactRecord{}).
+				Where("model_type = ? AND is_active = ?", record.ModelType, true).
+				Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(record).Error
+	})
+	if err != nil {
+		return apperrors.NewAppError(500, "Failed to save model artifact", err)
+	}
+	return nil
+}
+
+func (r *trainingRepository) GetActiveArtifact(ctx context.Context, modelType string) (*aiModels.ModelArtifact, error) {
+	var record modelArtifactRecord
+	if err := r.db.WithContext(ctx).
+		Where("model_type = ? AND is_active = ?", modelType, true).
+		First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewAppError(404, "Model artifact not found", nil)
+		}
+		return nil, apperrors.NewAppError(500, "Failed to get model artifact", err)
+	}
+
+	artifact := &aiModels.ModelArtifact{
+		ID:            record.ID,
+		ModelType:     record.ModelType,
+		Version:       record.Version,
+		TrainingJobID: record.TrainingJobID,
+		ModelPath:     record.ModelPath,
+		ConfigPath:    record.ConfigPath,
+		MetricsPath:   record.MetricsPath,
+		FileSize:      record.FileSize,
+		Checksum:      record.Checksum,
+		Weights:       record.Weights,
+		IsActive:      record.IsActive,
+		CreatedAt:     record.CreatedAt,
+	}
+	_ = json.Unmarshal([]byte(record.Metadata), &artifact.Metadata)
+	return artifact, nil
+}
+
+func toTrainingJobRecord(job *aiModels.TrainingJob) (*trainingJobRecord, error) {
+	config, err := json.Marshal(job.Config)
+	if err != nil {
+		return nil, err
+	}
+	trainingMetrics, err := json.Marshal(job.TrainingMetrics)
+	if err != nil {
+		return nil, err
+	}
+	validationMetrics, err := json.Marshal(job.ValidationMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &trainingJobRecord{
+		ID:                job.ID,
+		ModelType:         job.ModelType,
+		Version:           job.Version,
+		Status:            job.Status,
+		Progress:          job.Progress,
+		DatasetSize:       job.DatasetSize,
+		Config:            string(config),
+		TrainingMetrics:   string(trainingMetrics),
+		ValidationMetrics: string(validationMetrics),
+		ErrorMessage:      job.ErrorMessage,
+		CreatedBy:         job.CreatedBy,
+		CompletedAt:       job.CompletedAt,
+		UpdatedAt:         time.Now(),
+	}
+	if !job.StartedAt.IsZero() {
+		startedAt := job.StartedAt
+		record.StartedAt = &startedAt
+	}
+	return record, nil
+}
+
+func (r *trainingJobRecord) toTrainingJob() *aiModels.TrainingJob {
+	job := &aiModels.TrainingJob{
+		ID:           r.ID,
+		ModelType:    r.ModelType,
+		Version:      r.Version,
+		Status:       r.Status,
+		Progress:     r.Progress,
+		DatasetSize:  r.DatasetSize,
+		ErrorMessage: r.ErrorMessage,
+		CreatedBy:    r.CreatedBy,
+		CompletedAt:  r.CompletedAt,
+	}
+	if r.StartedAt != nil {
+		job.StartedAt = *r.StartedAt
+	}
+	_ = json.Unmarshal([]byte(r.Config), &job.Config)
+	_ = json.Unmarshal([]byte(r.TrainingMetrics), &job.TrainingMetrics)
+	_ = json.Unmarshal([]byte(r.ValidationMetrics), &job.ValidationMetrics)
+	return job
+}
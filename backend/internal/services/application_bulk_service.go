@@ -0,0 +1,351 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appstatus "microbridge/backend/internal/core/applications"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/repository"
+	"microbridge/backend/internal/shared/csvexport"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationBulkService applies employer actions to many of a job's applications.
+// Each application moves through the lifecycle on its own, so one failure never
+// holds back the others; the results report every application separately.
+type ApplicationBulkService interface {
+	BulkUpdateApplications(ctx context.Context, jobID, employerID string, req dto.BulkApplicationActionRequest) (*dto.BulkApplicationResponse, error)
+	GetBulkOperation(ctx context.Context, jobID, operationID, employerID string) (*dto.BulkApplicationResponse, error)
+	// ExportApplications renders the selected applications as CSV
+	ExportApplications(ctx context.Context, jobID, employerID string, req dto.ExportApplicationsRequest) ([]byte, error)
+	ProcessBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) ([]models.BulkItemResult, error)
+}
+
+type applicationBulkService struct {
+	applicationRepo repository.ApplicationRepository
+	bulkRepo        repository.ApplicationBulkRepository
+	jobRepo         repository.JobRepository
+	userRepo        repository.UserRepository
+	notifications   *NotificationService
+	syncLimit       int
+}
+
+// NewApplicationBulkService creates the service. Requests with more than syncLimit
+// applications are queued for the bulk worker instead of applied in the request.
+func NewApplicationBulkService(
+	applicationRepo repository.ApplicationRepository,
+	bulkRepo repository.ApplicationBulkRepository,
+	jobRepo repository.JobRepository,
+	userRepo repository.UserRepository,
+	notifications *NotificationService,
+	syncLimit int,
+) ApplicationBulkService {
+	return &applicationBulkService{
+		applicationRepo: applicationRepo,
+		bulkRepo:        bulkRepo,
+		jobRepo:         jobRepo,
+		userRepo:        userRepo,
+		notifications:   notifications,
+		syncLimit:       syncLimit,
+	}
+}
+
+func (s *applicationBulkService) BulkUpdateApplications(ctx context.Context, jobID, employerID string, req dto.BulkApplicationActionRequest) (*dto.BulkApplicationResponse, error) {
+	job, err := s.ownedJob(ctx, jobID, employerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !appstatus.IsBulkAction(req.Action) {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("unknown bulk action %q", req.Action))
+	}
+	ids := uniqueIDs(req.ApplicationIDs)
+	if len(ids) == 0 {
+		return nil, apperrors.NewValidationError("at least one application is required")
+	}
+	if len(ids) > appstatus.MaxBulkApplications {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("at most %d applications can be updated at once", appstatus.MaxBulkApplications))
+	}
+
+	template := ""
+	if req.Action == appstatus.BulkReject {
+		template = req.FeedbackTemplate
+		if strings.TrimSpace(template) == "" {
+			template = appstatus.DefaultRejectionFeedback
+		}
+		if err := appstatus.ValidateFeedbackTemplate(template); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ids) > s.syncLimit {
+		operation := &models.ApplicationBulkOperation{
+			JobID:            job.ID,
+			EmployerID:       employerID,
+			Action:           req.Action,
+			ApplicationIDs:   ids,
+			FeedbackTemplate: template,
+			Reason:           req.Reason,
+			Status:           models.BulkOperationPending,
+			Total:            len(ids),
+			CreatedAt:        time.Now().UTC(),
+		}
+		if err := s.bulkRepo.Create(ctx, operation); err != nil {
+			return nil, err
+		}
+		return bulkOperationToResponse(operation), nil
+	}
+
+	results, err := s.apply(ctx, job, employerID, req.Action, template, req.Reason, ids)
+	if err != nil {
+		return nil, err
+	}
+	operation := &models.ApplicationBulkOperation{Action: req.Action, ApplicationIDs: ids}
+	appstatus.FinishOperation(operation, results, nil, time.Now().UTC())
+	return bulkOperationToResponse(operation), nil
+}
+
+func (s *applicationBulkService) GetBulkOperation(ctx context.Context, jobID, operationID, employerID string) (*dto.BulkApplicationResponse, error) {
+	operation, err := s.bulkRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return nil, err
+	}
+	if operation.JobID != jobID || operation.EmployerID != employerID {
+		return nil, apperrors.NewNotFoundError("Bulk operation")
+	}
+	return bulkOperationToResponse(operation), nil
+}
+
+// ProcessBulkOperation applies a queued operation for the bulk worker
+func (s *applicationBulkService) ProcessBulkOperation(ctx context.Context, operation *models.ApplicationBulkOperation) ([]models.BulkItemResult, error) {
+	job, err := s.ownedJob(ctx, operation.JobID, operation.EmployerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, job, operation.EmployerID, operation.Action, operation.FeedbackTemplate, operation.Reason, operation.ApplicationIDs)
+}
+
+func (s *applicationBulkService) ExportApplications(ctx context.Context, jobID, employerID string, req dto.ExportApplicationsRequest) ([]byte, error) {
+	job, err := s.ownedJob(ctx, jobID, employerID)
+	if err != nil {
+		return nil, err
+	}
+	ids := uniqueIDs(req.ApplicationIDs)
+	if len(ids) > 0 {
+		if ids = validIDs(ids); len(ids) == 0 {
+			return nil, apperrors.NewValidationError("application_ids must be application IDs")
+		}
+	}
+	applications, err := s.applicationRepo.GetByJobAndIDs(ctx, job.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{
+		"application_id", "applicant_name", "applicant_email", "status", "match_score",
+		"applied_at", "reviewed_at", "shortlisted_at", "interview_scheduled", "cover_letter",
+	})
+	for _, application := range applications {
+		var name, email string
+		if user, err := s.userRepo.GetByID(ctx, application.UserID); err == nil {
+			name, email = user.Name, user.Email
+		}
+		writer.Write([]string{
+			application.ID,
+			csvexport.Cell(name),
+			csvexport.Cell(email),
+			application.Status,
+			strconv.FormatFloat(application.MatchScore, 'f', 2, 64),
+			application.AppliedAt.UTC().Format(time.RFC3339),
+			csvTime(application.ReviewedAt),
+			csvTime(application.ShortlistedAt),
+			csvTime(application.InterviewScheduled),
+			csvexport.Cell(application.CoverLetter),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to export applications", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Helper methods
+
+// apply runs action on each application and reports every one. Ids that don't
+// belong to the job fail without touching anything.
+func (s *applicationBulkService) apply(ctx context.Context, job *models.Job, employerID, action, template, reason string, ids []string) ([]models.BulkItemResult, error) {
+	byID := make(map[string]*models.Application, len(ids))
+	if valid := validIDs(ids); len(valid) > 0 {
+		// An empty list would select every application of the job
+		applications, err := s.applicationRepo.GetByJobAndIDs(ctx, job.ID, valid)
+		if err != nil {
+			return nil, err
+		}
+		for _, application := range applications {
+			byID[application.ID] = application
+		}
+	}
+
+	results := make([]models.BulkItemResult, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		application, ok := byID[id]
+		if !ok {
+			results = append(results, models.BulkItemResult{ApplicationID: id, Error: "Application not found for this job"})
+			continue
+		}
+
+		result := models.BulkItemResult{ApplicationID: id, Success: true}
+		if err := s.applyOne(ctx, job, employerID, action, template, reason, application); err != nil {
+			result.Success = false
+			result.Error = err.Error()
+			if appErr, ok := err.(*apperrors.AppError); ok {
+				result.Error = appErr.Message
+			}
+		}
+		result.Status = application.Status
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *applicationBulkService) applyOne(ctx context.Context, job *models.Job, employerID, action, template, reason string, application *models.Application) error {
+	switch action {
+	case appstatus.BulkReject:
+		name := ""
+		if user, err := s.userRepo.GetByID(ctx, application.UserID); err == nil {
+			name = user.Name
+		}
+		feedback := appstatus.RenderFeedback(template, appstatus.FeedbackValues(name, job.Title, job.Company))
+		previousFeedback := application.EmployerFeedback
+		application.EmployerFeedback = feedback
+		if err := transitionApplication(ctx, s.applicationRepo, application, appstatus.StatusRejected, appstatus.ActorEmployer, employerID, reason); err != nil {
+			application.EmployerFeedback = previousFeedback
+			return err
+		}
+		s.notify(ctx, application, "Application Update", feedback, models.NotificationTypeInfo)
+
+	case appstatus.BulkReview:
+		if err := transitionApplication(ctx, s.applicationRepo, application, appstatus.StatusReviewed, appstatus.ActorEmployer, employerID, reason); err != nil {
+			return err
+		}
+		s.notify(ctx, application, "Application Reviewed",
+			fmt.Sprintf("Your application for '%s' is being reviewed by the employer.", job.Title),
+			models.NotificationTypeInfo)
+
+	case appstatus.BulkShortlist:
+		if application.Status == appstatus.StatusDraft || appstatus.IsTerminal(application.Status) {
+			return apperrors.NewAppError(409, fmt.Sprintf("Can't shortlist a %s application", application.Status), nil)
+		}
+		if application.ShortlistedAt != nil {
+			return nil
+		}
+		now := time.Now().UTC()
+		if application.Status == appstatus.StatusSubmitted {
+			// Shortlisting reviews the application too; both land in one write
+			application.ShortlistedAt = &now
+			if err := transitionApplication(ctx, s.applicationRepo, application, appstatus.StatusReviewed, appstatus.ActorEmployer, employerID, reason); err != nil {
+				application.ShortlistedAt = nil
+				return err
+			}
+		} else if err := s.applicationRepo.Shortlist(ctx, application, now); err != nil {
+			return err
+		}
+		s.notify(ctx, application, "You've Been Shortlisted",
+			fmt.Sprintf("Good news! You've been shortlisted for '%s'.", job.Title),
+			models.NotificationTypeSuccess)
+
+	default:
+		return apperrors.NewValidationError(fmt.Sprintf("unknown bulk action %q", action))
+	}
+	return nil
+}
+
+func (s *applicationBulkService) ownedJob(ctx context.Context, jobID, employerID string) (*models.Job, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.EmployerID != employerID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to manage these applications", nil)
+	}
+	return job, nil
+}
+
+// notify tells the applicant about the change; failures don't fail the item
+func (s *applicationBulkService) notify(ctx context.Context, application *models.Application, title, message string, notificationType models.NotificationType) {
+	if s.notifications == nil {
+		return
+	}
+	_ = s.notifications.CreateApplicationUpdateNotification(ctx, application.UserID, application.ID, title, message, notificationType)
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// validIDs drops ids that can't be application IDs, so they fail as not found
+// instead of failing the lookup
+func validIDs(ids []string) []string {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	return valid
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func bulkOperationToResponse(operation *models.ApplicationBulkOperation) *dto.BulkApplicationResponse {
+	results := []models.BulkItemResult(operation.Results)
+	if results == nil {
+		results = []models.BulkItemResult{}
+	}
+	response := &dto.BulkApplicationResponse{
+		OperationID: operation.ID,
+		Action:      operation.Action,
+		Status:      operation.Status,
+		Total:       operation.Total,
+		Succeeded:   operation.Succeeded,
+		Failed:      operation.Failed,
+		Results:     results,
+		Error:       operation.Error,
+		CompletedAt: operation.CompletedAt,
+	}
+	if !operation.CreatedAt.IsZero() {
+		response.CreatedAt = &operation.CreatedAt
+	}
+	return response
+}
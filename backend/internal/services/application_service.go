@@ -3,12 +3,16 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	appstatus "microbridge/backend/internal/core/applications"
+	jobstatus "microbridge/backend/internal/core/jobs"
 	"microbridge/backend/internal/dto"
 	"microbridge/backend/internal/models"
 	"microbridge/backend/internal/repository"
 	apperrors "microbridge/backend/internal/shared/errors"
+	"microbridge/backend/internal/shared/validation"
 
 	"github.com/google/uuid"
 )
@@ -19,9 +23,11 @@ type ApplicationService interface {
 	UpdateApplication(ctx context.Context, applicationID string, req dto.UpdateApplicationRequest) (*dto.ApplicationResponse, error)
 	WithdrawApplication(ctx context.Context, applicationID, userID string, req dto.ApplicationWithdrawalRequest) error
 	GetUserApplications(ctx context.Context, userID string, page, limit int) (*dto.PaginatedApplicationResponse, error)
-	GetJobApplications(ctx context.Context, jobID string, employerID string, page, limit int) (*dto.PaginatedApplicationResponse, error)
+	GetJobApplications(ctx context.Context, jobID string, employerID string, filters dto.JobApplicationFilters, page, limit int) (*dto.PaginatedApplicationResponse, error)
 	GetApplicationDetails(ctx context.Context, applicationID string, requesterID string) (*dto.ApplicationResponse, error)
 	UpdateApplicationStatus(ctx context.Context, applicationID, employerID string, req dto.ApplicationStatusUpdateRequest) (*dto.ApplicationResponse, error)
+	SubmitDraft(ctx context.Context, applicationID, userID string, req dto.SubmitDraftRequest) (*dto.ApplicationResponse, error)
+	GetApplicationTimeline(ctx context.Context, applicationID, requesterID string) (*dto.ApplicationTimelineResponse, error)
 }
 
 type applicationService struct {
@@ -54,7 +60,7 @@ func (s *applicationService) SubmitApplication(ctx context.Context, userID strin
 		return nil, err
 	}
 
-	if job.Status != "posted" {
+	if !jobstatus.AcceptsApplications(job, time.Now()) {
 		return nil, apperrors.NewAppError(400, "Job is not accepting applications", nil)
 	}
 
@@ -73,28 +79,49 @@ func (s *applicationService) SubmitApplication(ctx context.Context, userID strin
 		return nil, err
 	}
 
+	// Drafts may leave required questions for later; they are checked again on submit
+	screening, err := validation.EvaluateScreening(job.ScreeningQuestions, req.ScreeningAnswers, !req.Draft)
+	if err != nil {
+		return nil, err
+	}
+
+	status := appstatus.StatusSubmitted
+	if req.Draft {
+		status = appstatus.StatusDraft
+	}
+
 	// Create application
 	application := &models.Application{
 		ID:           uuid.New().String(),
 		UserID:       userID,
 		JobID:        req.JobID,
-		Status:       "submitted",
+		Status:       status,
 		CoverLetter:  req.CoverLetter,
 		CustomResume: req.CustomResume,
+		ScreeningAnswers: screening.Answers,
+		ScreeningFlagged: screening.Outcome == models.KnockoutFlag,
 		MatchScore:   s.calculateMatchScore(user, job), // Simplified for now
 		AppliedAt:    time.Now(),
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	if err := s.applicationRepo.Create(ctx, application); err != nil {
+	entry := &models.ApplicationStatusHistory{
+		ToStatus:  status,
+		ActorID:   &userID,
+		ActorRole: string(appstatus.ActorStudent),
+		CreatedAt: application.CreatedAt,
+	}
+	if err := s.applicationRepo.CreateWithHistory(ctx, application, entry); err != nil {
 		return nil, err
 	}
 
 	// Update job application count
-	job.Applications++
-	if err := s.jobRepo.Update(ctx, job); err != nil {
-		// Log error but don't fail the application creation
+	if status == appstatus.StatusSubmitted {
+		s.countApplication(ctx, job)
+		if err := s.applyKnockout(ctx, application, screening); err != nil {
+			return nil, err
+		}
 	}
 
 	return s.applicationToResponse(application, job, user), nil
@@ -126,10 +153,12 @@ func (s *applicationService) UpdateApplication(ctx context.Context, applicationI
 		return nil, err
 	}
 
-	// Update fields if provided
-	if req.Status != "" {
-		application.Status = req.Status
+	// Status changes go through the lifecycle so they are validated and recorded
+	if req.Status != "" && req.Status != application.Status {
+		return nil, apperrors.NewValidationError("status can only be changed through the application status endpoints")
 	}
+
+	// Update fields if provided
 	if req.CoverLetter != "" {
 		application.CoverLetter = req.CoverLetter
 	}
@@ -177,17 +206,10 @@ func (s *applicationService) WithdrawApplication(ctx context.Context, applicatio
 		return apperrors.NewAppError(403, "You don't have permission to withdraw this application", nil)
 	}
 
-	// Check if application can be withdrawn
-	if application.Status == "accepted" || application.Status == "rejected" {
-		return apperrors.NewAppError(400, "Cannot withdraw application that has been finalized", nil)
+	if req.Reason != "" {
+		application.CandidateFeedback = req.Reason
 	}
-
-	// Update application status
-	application.Status = "withdrawn"
-	application.CandidateFeedback = req.Reason
-	application.UpdatedAt = time.Now()
-
-	return s.applicationRepo.Update(ctx, application)
+	return s.transition(ctx, application, appstatus.StatusWithdrawn, appstatus.ActorStudent, userID, req.Reason)
 }
 
 func (s *applicationService) GetUserApplications(ctx context.Context, userID string, page, limit int) (*dto.PaginatedApplicationResponse, error) {
@@ -239,7 +261,7 @@ func (s *applicationService) GetUserApplications(ctx context.Context, userID str
 	}, nil
 }
 
-func (s *applicationService) GetJobApplications(ctx context.Context, jobID string, employerID string, page, limit int) (*dto.PaginatedApplicationResponse, error) {
+func (s *applicationService) GetJobApplications(ctx context.Context, jobID string, employerID string, filters dto.JobApplicationFilters, page, limit int) (*dto.PaginatedApplicationResponse, error) {
 	// Verify that the employer owns this job
 	job, err := s.jobRepo.GetByID(ctx, jobID)
 	if err != nil {
@@ -259,7 +281,12 @@ func (s *applicationService) GetJobApplications(ctx context.Context, jobID strin
 
 	offset := (page - 1) * limit
 
-	applications, total, err := s.applicationRepo.GetByJobID(ctx, jobID, limit, offset)
+	filter, err := s.applicationFilter(job, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, total, err := s.applicationRepo.GetByJobID(ctx, jobID, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -326,20 +353,68 @@ func (s *applicationService) UpdateApplicationStatus(ctx context.Context, applic
 		return nil, apperrors.NewAppError(403, "You don't have permission to update this application", nil)
 	}
 
-	// Update application
-	application.Status = req.Status
-	application.EmployerFeedback = req.Feedback
-	application.InterviewScheduled = req.InterviewScheduled
-	application.InterviewNotes = req.InterviewNotes
-	application.UpdatedAt = time.Now()
+	if req.Feedback != "" {
+		application.EmployerFeedback = req.Feedback
+	}
+	if req.InterviewScheduled != nil {
+		application.InterviewScheduled = req.InterviewScheduled
+	}
+	if req.InterviewNotes != "" {
+		application.InterviewNotes = req.InterviewNotes
+	}
 
-	// Set response timestamp for final decisions
-	if req.Status == "accepted" || req.Status == "rejected" {
-		now := time.Now()
-		application.ResponseAt = &now
+	if err := s.transition(ctx, application, req.Status, appstatus.ActorEmployer, employerID, req.Feedback); err != nil {
+		return nil, err
 	}
 
-	if err := s.applicationRepo.Update(ctx, application); err != nil {
+	user, err := s.userRepo.GetByID(ctx, application.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applicationToResponse(application, job, user), nil
+}
+
+// SubmitDraft submits an application the student saved as a draft. The draft's
+// screening answers are kept unless the request brings new ones.
+func (s *applicationService) SubmitDraft(ctx context.Context, applicationID, userID string, req dto.SubmitDraftRequest) (*dto.ApplicationResponse, error) {
+	application, err := s.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application.UserID != userID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to submit this application", nil)
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if !jobstatus.AcceptsApplications(job, time.Now()) {
+		return nil, apperrors.NewAppError(400, "Job is not accepting applications", nil)
+	}
+
+	if application.Status != appstatus.StatusDraft {
+		return nil, apperrors.NewAppError(409, "Only draft applications can be submitted", nil)
+	}
+
+	answers := application.ScreeningAnswers
+	if req.ScreeningAnswers != nil {
+		answers = req.ScreeningAnswers
+	}
+	screening, err := validation.EvaluateScreening(job.ScreeningQuestions, answers, true)
+	if err != nil {
+		return nil, err
+	}
+	application.ScreeningAnswers = screening.Answers
+	application.ScreeningFlagged = screening.Outcome == models.KnockoutFlag
+
+	// The transition saves the application, answers included
+	if err := s.transition(ctx, application, appstatus.StatusSubmitted, appstatus.ActorStudent, userID, ""); err != nil {
+		return nil, err
+	}
+	s.countApplication(ctx, job)
+	if err := s.applyKnockout(ctx, application, screening); err != nil {
 		return nil, err
 	}
 
@@ -351,8 +426,110 @@ func (s *applicationService) UpdateApplicationStatus(ctx context.Context, applic
 	return s.applicationToResponse(application, job, user), nil
 }
 
+// GetApplicationTimeline returns an application's status history to its applicant or the job's employer
+func (s *applicationService) GetApplicationTimeline(ctx context.Context, applicationID, requesterID string) (*dto.ApplicationTimelineResponse, error) {
+	application, err := s.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var actor appstatus.Actor
+	switch requesterID {
+	case application.UserID:
+		actor = appstatus.ActorStudent
+	case job.EmployerID:
+		actor = appstatus.ActorEmployer
+	default:
+		return nil, apperrors.NewAppError(403, "You don't have permission to view this application", nil)
+	}
+
+	history, err := s.applicationRepo.GetStatusHistory(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &dto.ApplicationTimelineResponse{
+		ApplicationID: application.ID,
+		Status:        application.Status,
+		NextStatuses:  appstatus.NextStatuses(application.Status, actor),
+		History:       make([]dto.ApplicationStatusChangeResponse, len(history)),
+	}
+	for i, entry := range history {
+		timeline.History[i] = dto.ApplicationStatusChangeResponse{
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			ActorID:    entry.ActorID,
+			ActorRole:  entry.ActorRole,
+			Reason:     entry.Reason,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+	return timeline, nil
+}
+
 // Helper methods
 
+// transition moves an application through the lifecycle and records the change
+func (s *applicationService) transition(ctx context.Context, application *models.Application, to string, actor appstatus.Actor, actorID, reason string) error {
+	return transitionApplication(ctx, s.applicationRepo, application, to, actor, actorID, reason)
+}
+
+// transitionApplication moves an application through the lifecycle and records
+// the change; every service that changes an application's status goes through it
+func transitionApplication(ctx context.Context, repo repository.ApplicationRepository, application *models.Application, to string, actor appstatus.Actor, actorID, reason string) error {
+	from := application.Status
+	entry, err := appstatus.Transition(application, to, actor, actorID, reason, time.Now())
+	if err != nil {
+		return err
+	}
+	return repo.TransitionStatus(ctx, application, from, entry)
+}
+
+// applyKnockout rejects a just-submitted application whose screening answers
+// knock it out; flagged applications stay submitted for the employer to look at
+func (s *applicationService) applyKnockout(ctx context.Context, application *models.Application, screening *validation.ScreeningResult) error {
+	if screening.Outcome != models.KnockoutReject {
+		return nil
+	}
+	reason := "Screening knockout: " + strings.Join(screening.Knockouts, "; ")
+	return s.transition(ctx, application, appstatus.StatusRejected, appstatus.ActorSystem, "", reason)
+}
+
+// applicationFilter checks answer filters against the job's questions
+func (s *applicationService) applicationFilter(job *models.Job, filters dto.JobApplicationFilters) (repository.ApplicationFilter, error) {
+	filter := repository.ApplicationFilter{Status: filters.Status, Flagged: filters.Flagged}
+	if len(filters.Answers) == 0 {
+		return filter, nil
+	}
+
+	answers := make(models.ScreeningAnswers, 0, len(filters.Answers))
+	for questionID, value := range filters.Answers {
+		answers = append(answers, models.ScreeningAnswer{QuestionID: questionID, Value: value})
+	}
+	// Evaluating normalizes the values the same way stored answers were, so
+	// "Yes" finds "yes" and option filters match the option's spelling
+	screening, err := validation.EvaluateScreening(job.ScreeningQuestions, answers, false)
+	if err != nil {
+		return filter, err
+	}
+	filter.Answers = screening.Answers
+	return filter, nil
+}
+
+// countApplication bumps the job's application count; a failure doesn't fail the application
+func (s *applicationService) countApplication(ctx context.Context, job *models.Job) {
+	if err := s.jobRepo.IncrementApplications(ctx, job.ID); err != nil {
+		fmt.Printf("Failed to count application for job %s: %v\n", job.ID, err)
+		return
+	}
+	job.Applications++
+}
+
 func (s *applicationService) validateCreateApplicationRequest(req dto.CreateApplicationRequest) error {
 	if req.JobID == "" {
 		return apperrors.NewAppError(400, "Job ID is required", nil)
@@ -420,15 +597,20 @@ func (s *applicationService) applicationToResponse(application *models.Applicati
 		Status:             application.Status,
 		CoverLetter:        application.CoverLetter,
 		CustomResume:       application.CustomResume,
+		ResumeFileID:       application.ResumeFileID,
+		CoverLetterFileID:  application.CoverLetterFileID,
 		MatchScore:         application.MatchScore,
 		AppliedAt:          application.AppliedAt,
 		ReviewedAt:         application.ReviewedAt,
 		ResponseAt:         application.ResponseAt,
+		ShortlistedAt:      application.ShortlistedAt,
 		EmployerFeedback:   application.EmployerFeedback,
 		CandidateFeedback:  application.CandidateFeedback,
 		InternalNotes:      application.InternalNotes,
 		InterviewScheduled: application.InterviewScheduled,
 		InterviewNotes:     application.InterviewNotes,
+		ScreeningAnswers:   application.ScreeningAnswers,
+		ScreeningFlagged:   application.ScreeningFlagged,
 		CreatedAt:          application.CreatedAt,
 		UpdatedAt:          application.UpdatedAt,
 	}
@@ -479,4 +661,4 @@ func (s *applicationService) applicationToResponse(application *models.Applicati
 	}
 
 	return response
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	aiServices "microbridge/backend/internal/ai/services"
+)
+
+// AdminDriftNotifier delivers model drift alerts as in-app notifications to administrators
+type AdminDriftNotifier struct {
+	notifications *NotificationService
+	adminUserIDs  []string
+}
+
+// NewAdminDriftNotifier creates a drift notifier for the given administrator accounts
+func NewAdminDriftNotifier(notifications *NotificationService, adminUserIDs []string) *AdminDriftNotifier {
+	return &AdminDriftNotifier{
+		notifications: notifications,
+		adminUserIDs:  adminUserIDs,
+	}
+}
+
+// NotifyDrift implements aiServices.DriftNotifier
+func (n *AdminDriftNotifier) NotifyDrift(ctx context.Context, alert *aiServices.DriftAlert) error {
+	var failed int
+	for _, adminID := range n.adminUserIDs {
+		if err := n.notifications.CreateModelDriftAlert(ctx, adminID, alert.Result.Metric, alert.Result.PSI, alert.Result.KLDivergence); err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to notify %d of %d administrators", failed, len(n.adminUserIDs))
+	}
+	return nil
+}
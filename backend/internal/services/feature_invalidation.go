@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// FeatureInvalidator drops cached model features for profiles and postings that
+// changed; features.Store implements it
+type FeatureInvalidator interface {
+	InvalidateUser(ctx context.Context, userID string) error
+	InvalidateJob(ctx context.Context, jobID string) error
+}
+
+// invalidateUserFeatures drops a user's cached features. A failure only serves stale
+// values until the entry expires, so it is logged rather than returned.
+func invalidateUserFeatures(ctx context.Context, invalidator FeatureInvalidator, userID string) {
+	if invalidator == nil {
+		return
+	}
+	if err := invalidator.InvalidateUser(ctx, userID); err != nil {
+		fmt.Printf("Failed to invalidate features for user %s: %v\n", userID, err)
+	}
+}
+
+// invalidateJobFeatures drops a job's cached features, logging failures like
+// invalidateUserFeatures
+func invalidateJobFeatures(ctx context.Context, invalidator FeatureInvalidator, jobID string) {
+	if invalidator == nil {
+		return
+	}
+	if err := invalidator.InvalidateJob(ctx, jobID); err != nil {
+		fmt.Printf("Failed to invalidate features for job %s: %v\n", jobID, err)
+	}
+}
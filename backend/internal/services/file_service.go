@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	appstatus "microbridge/backend/internal/core/applications"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+	"microbridge/backend/internal/shared/storage"
+
+	"github.com/google/uuid"
+)
+
+type FileService interface {
+	UploadProfileFile(ctx context.Context, userID, purpose, filename string, content io.Reader) (*dto.FileResponse, error)
+	UploadApplicationFile(ctx context.Context, applicationID, userID, purpose, filename string, content io.Reader) (*dto.FileResponse, error)
+	GetFile(ctx context.Context, fileID, requesterID string) (*dto.FileResponse, error)
+	OpenDownload(ctx context.Context, key, expires, signature string) (io.ReadCloser, string, error)
+	MaxFileSize() int64
+}
+
+const maxFilenameLength = 255
+
+// fileRules are the content types each purpose accepts and who may upload it
+var fileRules = map[string]struct {
+	allowed  []string
+	userType string
+}{
+	models.FilePurposeResume:      {storage.Documents, "student"},
+	models.FilePurposeCoverLetter: {storage.Documents, "student"},
+	models.FilePurposeLogo:        {storage.Images, "employer"},
+}
+
+type fileService struct {
+	fileRepo        repository.FileRepository
+	userRepo        repository.UserRepository
+	applicationRepo repository.ApplicationRepository
+	uploader        *storage.Uploader
+	urlExpiry       time.Duration
+}
+
+func NewFileService(
+	fileRepo repository.FileRepository,
+	userRepo repository.UserRepository,
+	applicationRepo repository.ApplicationRepository,
+	uploader *storage.Uploader,
+	urlExpiry time.Duration,
+) FileService {
+	return &fileService{
+		fileRepo:        fileRepo,
+		userRepo:        userRepo,
+		applicationRepo: applicationRepo,
+		uploader:        uploader,
+		urlExpiry:       urlExpiry,
+	}
+}
+
+// UploadProfileFile stores a student's resume or an employer's logo and puts it on their profile
+func (s *fileService) UploadProfileFile(ctx context.Context, userID, purpose, filename string, content io.Reader) (*dto.FileResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rules, ok := fileRules[purpose]
+	if !ok || purpose == models.FilePurposeCoverLetter {
+		return nil, apperrors.NewAppError(400, "Only a resume or a logo can be added to a profile", nil)
+	}
+	if user.UserType != rules.userType {
+		return nil, apperrors.NewForbiddenError("Only " + rules.userType + "s can upload a " + purpose)
+	}
+
+	file, err := s.store(ctx, userID, purpose, filename, content)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fileRepo.AttachToUser(ctx, userID, file); err != nil {
+		return nil, err
+	}
+	return s.fileToResponse(ctx, file)
+}
+
+// UploadApplicationFile attaches a resume or cover letter to the student's own
+// application while the employer hasn't started reviewing it
+func (s *fileService) UploadApplicationFile(ctx context.Context, applicationID, userID, purpose, filename string, content io.Reader) (*dto.FileResponse, error) {
+	if purpose != models.FilePurposeResume && purpose != models.FilePurposeCoverLetter {
+		return nil, apperrors.NewAppError(400, "Only a resume or a cover letter can be added to an application", nil)
+	}
+
+	application, err := s.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application.UserID != userID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to change this application", nil)
+	}
+	if application.Status != appstatus.StatusDraft && application.Status != appstatus.StatusSubmitted {
+		return nil, apperrors.NewAppError(409, "Files can't be changed once the employer has reviewed the application", nil)
+	}
+
+	file, err := s.store(ctx, userID, purpose, filename, content)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fileRepo.AttachToApplication(ctx, applicationID, file); err != nil {
+		return nil, err
+	}
+	return s.fileToResponse(ctx, file)
+}
+
+// GetFile returns a file with a signed download URL. Owners can read their
+// files, logos are public, and employers can read the resumes and cover
+// letters students applied to their jobs with.
+func (s *fileService) GetFile(ctx context.Context, fileID, requesterID string) (*dto.FileResponse, error) {
+	if _, err := uuid.Parse(fileID); err != nil {
+		return nil, apperrors.NewNotFoundError("File")
+	}
+	file, err := s.fileRepo.GetByID(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.OwnerID != requesterID && file.Purpose != models.FilePurposeLogo {
+		allowed, err := s.fileRepo.EmployerCanView(ctx, file, requesterID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			// Not found rather than forbidden, so file IDs can't be probed
+			return nil, apperrors.NewNotFoundError("File")
+		}
+	}
+	return s.fileToResponse(ctx, file)
+}
+
+// OpenDownload serves a signed URL issued by the local provider. Other
+// providers hand out URLs to the object store itself.
+func (s *fileService) OpenDownload(ctx context.Context, key, expires, signature string) (io.ReadCloser, string, error) {
+	verifier, ok := s.uploader.Provider().(storage.URLVerifier)
+	if !ok {
+		return nil, "", apperrors.NewNotFoundError("File")
+	}
+	if err := verifier.VerifySignedURL(key, expires, signature, time.Now()); err != nil {
+		return nil, "", apperrors.NewForbiddenError("This download link is invalid or has expired")
+	}
+
+	body, err := s.uploader.Provider().Open(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, "", apperrors.NewNotFoundError("File")
+	}
+	if err != nil {
+		return nil, "", apperrors.NewAppError(500, "Failed to read file", err)
+	}
+	return body, storage.ContentTypeForKey(key), nil
+}
+
+func (s *fileService) MaxFileSize() int64 {
+	return s.uploader.MaxFileSize()
+}
+
+// Helper methods
+
+func (s *fileService) store(ctx context.Context, ownerID, purpose, filename string, content io.Reader) (*models.File, error) {
+	upload, err := s.uploader.Store(ctx, purpose+"s", filename, content, fileRules[purpose].allowed)
+	switch {
+	case errors.Is(err, storage.ErrTooLarge):
+		return nil, apperrors.NewAppError(413, "File is larger than the upload limit", nil)
+	case errors.Is(err, storage.ErrUnsupportedType):
+		return nil, apperrors.NewAppError(415, "A "+purpose+" must be one of: "+strings.Join(fileRules[purpose].allowed, ", "), nil)
+	case err != nil:
+		return nil, apperrors.NewAppError(500, "Failed to store file", err)
+	}
+
+	file := &models.File{
+		ID:          uuid.New().String(),
+		OwnerID:     ownerID,
+		Purpose:     purpose,
+		Filename:    cleanFilename(filename),
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+		SHA256:      upload.SHA256,
+		Key:         upload.Key,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.fileRepo.Create(ctx, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *fileService) fileToResponse(ctx context.Context, file *models.File) (*dto.FileResponse, error) {
+	expiry := s.urlExpiry
+	if expiry <= 0 {
+		expiry = storage.DefaultURLExpiry
+	}
+	if expiry > storage.MaxURLExpiry {
+		expiry = storage.MaxURLExpiry
+	}
+	url, err := s.uploader.Provider().SignedURL(ctx, file.Key, expiry)
+	if err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to sign download URL", err)
+	}
+	return &dto.FileResponse{
+		ID:           file.ID,
+		Purpose:      file.Purpose,
+		Filename:     file.Filename,
+		ContentType:  file.ContentType,
+		Size:         file.Size,
+		SHA256:       file.SHA256,
+		URL:          url,
+		URLExpiresAt: time.Now().Add(expiry).UTC(),
+		CreatedAt:    file.CreatedAt,
+	}, nil
+}
+
+// cleanFilename keeps the base name of an uploaded file without control
+// characters or quotes, so it is safe to show and to put in headers
+func cleanFilename(filename string) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		name = ""
+	}
+	// Keep the end of long names, where the extension is
+	if runes := []rune(name); len(runes) > maxFilenameLength {
+		name = string(runes[len(runes)-maxFilenameLength:])
+	}
+	return name
+}
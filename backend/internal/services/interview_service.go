@@ -0,0 +1,534 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appstatus "microbridge/backend/internal/core/applications"
+	"microbridge/backend/internal/core/interviews"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+type InterviewService interface {
+	ProposeInterview(ctx context.Context, applicationID, employerID string, req dto.ProposeInterviewRequest) (*dto.InterviewResponse, error)
+	GetInterview(ctx context.Context, interviewID, userID string) (*dto.InterviewResponse, error)
+	ListInterviews(ctx context.Context, userID, applicationID string) ([]*dto.InterviewResponse, error)
+	SelectSlot(ctx context.Context, interviewID, studentID string, req dto.SelectInterviewSlotRequest) (*dto.InterviewResponse, error)
+	RescheduleInterview(ctx context.Context, interviewID, userID string, req dto.RescheduleInterviewRequest) (*dto.InterviewResponse, error)
+	CancelInterview(ctx context.Context, interviewID, userID string, req dto.CancelInterviewRequest) (*dto.InterviewResponse, error)
+	CompleteInterview(ctx context.Context, interviewID, employerID string) (*dto.InterviewResponse, error)
+	// GetInvite renders the interview as an iCalendar (.ics) file
+	GetInvite(ctx context.Context, interviewID, userID string) ([]byte, error)
+	NotifyInterviewReminder(ctx context.Context, interview *models.Interview) error
+}
+
+type interviewService struct {
+	interviewRepo   repository.InterviewRepository
+	applicationRepo repository.ApplicationRepository
+	jobRepo         repository.JobRepository
+	userRepo        repository.UserRepository
+	notifications   *NotificationService
+}
+
+func NewInterviewService(
+	interviewRepo repository.InterviewRepository,
+	applicationRepo repository.ApplicationRepository,
+	jobRepo repository.JobRepository,
+	userRepo repository.UserRepository,
+	notifications *NotificationService,
+) InterviewService {
+	return &interviewService{
+		interviewRepo:   interviewRepo,
+		applicationRepo: applicationRepo,
+		jobRepo:         jobRepo,
+		userRepo:        userRepo,
+		notifications:   notifications,
+	}
+}
+
+// ProposeInterview offers the applicant the slots that fit their availability and
+// neither side's calendar. Proposing an interview for a submitted application
+// marks it as reviewed.
+func (s *interviewService) ProposeInterview(ctx context.Context, applicationID, employerID string, req dto.ProposeInterviewRequest) (*dto.InterviewResponse, error) {
+	application, err := s.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.jobRepo.GetByID(ctx, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.EmployerID != employerID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to interview for this application", nil)
+	}
+
+	switch application.Status {
+	case appstatus.StatusSubmitted, appstatus.StatusReviewed, appstatus.StatusInterviewed:
+	default:
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't schedule an interview for a %s application", application.Status), nil)
+	}
+
+	existing, err := s.interviewRepo.ListByUser(ctx, employerID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, interview := range existing {
+		if isOpenInterview(interview.Status) {
+			return nil, apperrors.NewAppError(409, "This application already has an open interview; reschedule or cancel it instead", nil)
+		}
+	}
+
+	now := time.Now().UTC()
+	fit, rejected, err := s.fitSlots(ctx, req.Slots, application.UserID, employerID, "", now)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status == appstatus.StatusSubmitted {
+		if err := transitionApplication(ctx, s.applicationRepo, application, appstatus.StatusReviewed, appstatus.ActorEmployer, employerID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	interview := &models.Interview{
+		ApplicationID: application.ID,
+		JobID:         job.ID,
+		EmployerID:    employerID,
+		StudentID:     application.UserID,
+		Status:        models.InterviewProposed,
+		ProposedSlots: fit,
+		Location:      req.Location,
+		MeetingURL:    req.MeetingURL,
+		Notes:         req.Notes,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.interviewRepo.Create(ctx, interview); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, interview.StudentID, interview.ID, "Interview Invitation",
+		fmt.Sprintf("You've been invited to interview for '%s'. Pick one of %d proposed times.", job.Title, len(fit)),
+		models.NotificationTypeSuccess)
+
+	response := interviewToResponse(interview)
+	response.RejectedSlots = rejected
+	return response, nil
+}
+
+func (s *interviewService) GetInterview(ctx context.Context, interviewID, userID string) (*dto.InterviewResponse, error) {
+	interview, err := s.getForParticipant(ctx, interviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return interviewToResponse(interview), nil
+}
+
+func (s *interviewService) ListInterviews(ctx context.Context, userID, applicationID string) ([]*dto.InterviewResponse, error) {
+	list, err := s.interviewRepo.ListByUser(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*dto.InterviewResponse, len(list))
+	for i, interview := range list {
+		responses[i] = interviewToResponse(interview)
+	}
+	return responses, nil
+}
+
+// SelectSlot books one of the proposed slots, provided both sides are still free
+func (s *interviewService) SelectSlot(ctx context.Context, interviewID, studentID string, req dto.SelectInterviewSlotRequest) (*dto.InterviewResponse, error) {
+	interview, err := s.interviewRepo.GetByID(ctx, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.StudentID != studentID {
+		return nil, apperrors.NewAppError(403, "Only the applicant can pick an interview slot", nil)
+	}
+	if interview.Status != models.InterviewProposed {
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't pick a slot for a %s interview", interview.Status), nil)
+	}
+
+	var chosen *models.InterviewSlot
+	for i := range interview.ProposedSlots {
+		if interview.ProposedSlots[i].Start.Equal(req.Start) {
+			chosen = &interview.ProposedSlots[i]
+			break
+		}
+	}
+	if chosen == nil {
+		return nil, apperrors.NewValidationError("start must be one of the proposed slots")
+	}
+
+	now := time.Now().UTC()
+	if !chosen.Start.After(now) {
+		return nil, apperrors.NewAppError(409, "That slot has already passed; ask for new times", nil)
+	}
+	busy, err := s.busySlots(ctx, []string{interview.StudentID, interview.EmployerID}, []models.InterviewSlot{*chosen}, interview.ID)
+	if err != nil {
+		return nil, err
+	}
+	if interviews.Conflicts(*chosen, busy) {
+		return nil, apperrors.NewAppError(409, "That slot is no longer free; pick another one", nil)
+	}
+
+	start, end := chosen.Start.UTC(), chosen.End.UTC()
+	interview.Status = models.InterviewScheduled
+	interview.ScheduledStart = &start
+	interview.ScheduledEnd = &end
+	if err := s.interviewRepo.Update(ctx, interview, models.InterviewProposed); err != nil {
+		return nil, err
+	}
+	s.syncApplication(ctx, interview)
+
+	s.notify(ctx, interview.EmployerID, interview.ID, "Interview Scheduled",
+		fmt.Sprintf("The applicant picked %s for their interview.", start.Format(time.RFC1123)),
+		models.NotificationTypeSuccess)
+
+	return interviewToResponse(interview), nil
+}
+
+// RescheduleInterview moves an open interview. The employer proposes new slots;
+// the student asks the employer to do so.
+func (s *interviewService) RescheduleInterview(ctx context.Context, interviewID, userID string, req dto.RescheduleInterviewRequest) (*dto.InterviewResponse, error) {
+	interview, err := s.getForParticipant(ctx, interviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOpenInterview(interview.Status) {
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't reschedule a %s interview", interview.Status), nil)
+	}
+
+	fromStatus := interview.Status
+	var rejected []dto.RejectedInterviewSlot
+	if userID == interview.EmployerID {
+		if len(req.Slots) == 0 {
+			return nil, apperrors.NewValidationError("slots are required to reschedule an interview")
+		}
+		fit, dropped, err := s.fitSlots(ctx, req.Slots, interview.StudentID, interview.EmployerID, interview.ID, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		interview.ProposedSlots = fit
+		interview.Status = models.InterviewProposed
+		rejected = dropped
+	} else {
+		interview.Status = models.InterviewRescheduleRequested
+	}
+
+	if interview.ScheduledStart != nil {
+		// Calendars holding the earlier invite pick up the change by its higher sequence
+		interview.Sequence++
+	}
+	interview.ScheduledStart = nil
+	interview.ScheduledEnd = nil
+	if err := s.interviewRepo.Update(ctx, interview, fromStatus); err != nil {
+		return nil, err
+	}
+	s.syncApplication(ctx, interview)
+
+	if userID == interview.EmployerID {
+		s.notify(ctx, interview.StudentID, interview.ID, "Interview Rescheduled",
+			withReason("Your interviewer proposed new times; please pick one.", req.Reason),
+			models.NotificationTypeInfo)
+	} else {
+		s.notify(ctx, interview.EmployerID, interview.ID, "Reschedule Requested",
+			withReason("The applicant asked for different interview times.", req.Reason),
+			models.NotificationTypeWarning)
+	}
+
+	response := interviewToResponse(interview)
+	response.RejectedSlots = rejected
+	return response, nil
+}
+
+func (s *interviewService) CancelInterview(ctx context.Context, interviewID, userID string, req dto.CancelInterviewRequest) (*dto.InterviewResponse, error) {
+	interview, err := s.getForParticipant(ctx, interviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOpenInterview(interview.Status) {
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't cancel a %s interview", interview.Status), nil)
+	}
+
+	fromStatus := interview.Status
+	interview.Status = models.InterviewCancelled
+	interview.CancelledBy = &userID
+	interview.CancelReason = req.Reason
+	interview.Sequence++
+	if err := s.interviewRepo.Update(ctx, interview, fromStatus); err != nil {
+		return nil, err
+	}
+	s.syncApplication(ctx, interview)
+
+	other := interview.EmployerID
+	if userID == interview.EmployerID {
+		other = interview.StudentID
+	}
+	s.notify(ctx, other, interview.ID, "Interview Cancelled",
+		withReason("An upcoming interview was cancelled.", req.Reason),
+		models.NotificationTypeWarning)
+
+	return interviewToResponse(interview), nil
+}
+
+// CompleteInterview records that a scheduled interview took place and moves a
+// reviewed application on to interviewed
+func (s *interviewService) CompleteInterview(ctx context.Context, interviewID, employerID string) (*dto.InterviewResponse, error) {
+	interview, err := s.interviewRepo.GetByID(ctx, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.EmployerID != employerID {
+		return nil, apperrors.NewAppError(403, "Only the employer can complete an interview", nil)
+	}
+	if interview.Status != models.InterviewScheduled {
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't complete a %s interview", interview.Status), nil)
+	}
+	if interview.ScheduledStart.After(time.Now().UTC()) {
+		return nil, apperrors.NewAppError(409, "The interview hasn't started yet", nil)
+	}
+
+	application, err := s.applicationRepo.GetByID(ctx, interview.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application.Status == appstatus.StatusReviewed {
+		if err := transitionApplication(ctx, s.applicationRepo, application, appstatus.StatusInterviewed, appstatus.ActorEmployer, employerID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	interview.Status = models.InterviewCompleted
+	if err := s.interviewRepo.Update(ctx, interview, models.InterviewScheduled); err != nil {
+		return nil, err
+	}
+	return interviewToResponse(interview), nil
+}
+
+func (s *interviewService) GetInvite(ctx context.Context, interviewID, userID string) ([]byte, error) {
+	interview, err := s.getForParticipant(ctx, interviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.ScheduledStart == nil {
+		if interview.Status == models.InterviewCancelled {
+			return nil, apperrors.NewAppError(409, "The interview was cancelled before it was scheduled", nil)
+		}
+		return nil, apperrors.NewAppError(409, "The interview hasn't been scheduled yet", nil)
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, interview.JobID)
+	if err != nil {
+		return nil, err
+	}
+	invite := interviews.Invite{
+		UID:       interview.ID + "@microbridge",
+		Summary:   fmt.Sprintf("Interview: %s", job.Title),
+		Location:  interview.Location,
+		URL:       interview.MeetingURL,
+		Start:     *interview.ScheduledStart,
+		End:       *interview.ScheduledEnd,
+		Stamp:     interview.UpdatedAt,
+		Sequence:  interview.Sequence,
+		Cancelled: interview.Status == models.InterviewCancelled,
+	}
+	if job.Company != "" {
+		invite.Summary += " at " + job.Company
+	}
+	description := []string{}
+	if interview.Notes != "" {
+		description = append(description, interview.Notes)
+	}
+	if interview.MeetingURL != "" {
+		description = append(description, "Join: "+interview.MeetingURL)
+	}
+	invite.Description = strings.Join(description, "\n\n")
+
+	if employer, err := s.userRepo.GetByID(ctx, interview.EmployerID); err == nil {
+		invite.Organizer = interviews.Attendee{Name: employer.Name, Email: employer.Email}
+	}
+	if student, err := s.userRepo.GetByID(ctx, interview.StudentID); err == nil {
+		invite.Attendees = []interviews.Attendee{{Name: student.Name, Email: student.Email}}
+	}
+
+	var buf bytes.Buffer
+	if err := interviews.WriteICS(&buf, invite); err != nil {
+		return nil, apperrors.NewAppError(500, "Failed to render calendar invite", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// NotifyInterviewReminder reminds both sides of an upcoming interview
+func (s *interviewService) NotifyInterviewReminder(ctx context.Context, interview *models.Interview) error {
+	if interview.ScheduledStart == nil || s.notifications == nil {
+		return nil
+	}
+	title := "your upcoming interview"
+	if job, err := s.jobRepo.GetByID(ctx, interview.JobID); err == nil {
+		title = fmt.Sprintf("'%s'", job.Title)
+	}
+
+	for _, userID := range []string{interview.StudentID, interview.EmployerID} {
+		when := interview.ScheduledStart.Format(time.RFC1123)
+		if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+			when = formatInUserTimezone(*interview.ScheduledStart, user.Availability.Timezone)
+		}
+		if err := s.notifications.CreateInterviewNotification(ctx, userID, interview.ID, "Interview Reminder",
+			fmt.Sprintf("The interview for %s starts %s.", title, when), models.NotificationTypeInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper methods
+
+// fitSlots validates proposed slots and keeps those that suit the student's
+// availability and both sides' scheduled interviews
+func (s *interviewService) fitSlots(ctx context.Context, slots []models.InterviewSlot, studentID, employerID, excludeID string, now time.Time) (models.InterviewSlots, []dto.RejectedInterviewSlot, error) {
+	normalized := make([]models.InterviewSlot, len(slots))
+	for i, slot := range slots {
+		normalized[i] = models.InterviewSlot{Start: slot.Start.UTC(), End: slot.End.UTC()}
+	}
+	if err := interviews.ValidateSlots(normalized, now); err != nil {
+		return nil, nil, err
+	}
+
+	student, err := s.userRepo.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	busy, err := s.busySlots(ctx, []string{studentID, employerID}, normalized, excludeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fit, dropped := interviews.FilterSlots(normalized, student.Availability, busy)
+	rejected := make([]dto.RejectedInterviewSlot, len(dropped))
+	for i, slot := range dropped {
+		rejected[i] = dto.RejectedInterviewSlot{Slot: slot.Slot, Reason: slot.Reason}
+	}
+	if len(fit) == 0 {
+		err := apperrors.NewAppError(409, "None of the proposed slots fit the applicant's availability and both calendars", nil)
+		err.Details = describeRejectedSlots(dropped)
+		return nil, rejected, err
+	}
+	return fit, rejected, nil
+}
+
+// busySlots returns the scheduled interviews of the users that overlap the span of slots
+func (s *interviewService) busySlots(ctx context.Context, userIDs []string, slots []models.InterviewSlot, excludeID string) ([]models.InterviewSlot, error) {
+	from, to := slots[0].Start, slots[0].End
+	for _, slot := range slots[1:] {
+		if slot.Start.Before(from) {
+			from = slot.Start
+		}
+		if slot.End.After(to) {
+			to = slot.End
+		}
+	}
+
+	scheduled, err := s.interviewRepo.ListScheduled(ctx, userIDs, from, to, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	busy := make([]models.InterviewSlot, 0, len(scheduled))
+	for _, interview := range scheduled {
+		busy = append(busy, models.InterviewSlot{Start: *interview.ScheduledStart, End: *interview.ScheduledEnd})
+	}
+	return busy, nil
+}
+
+func (s *interviewService) getForParticipant(ctx context.Context, interviewID, userID string) (*models.Interview, error) {
+	interview, err := s.interviewRepo.GetByID(ctx, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.StudentID != userID && interview.EmployerID != userID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to access this interview", nil)
+	}
+	return interview, nil
+}
+
+// syncApplication mirrors the scheduled time onto the application; a failure
+// doesn't fail the interview change
+func (s *interviewService) syncApplication(ctx context.Context, interview *models.Interview) {
+	var scheduled *time.Time
+	if interview.Status == models.InterviewScheduled {
+		scheduled = interview.ScheduledStart
+	}
+	if err := s.applicationRepo.SetInterviewScheduled(ctx, interview.ApplicationID, scheduled); err != nil {
+		fmt.Printf("Failed to sync interview time onto application %s: %v\n", interview.ApplicationID, err)
+	}
+}
+
+// notify sends an interview notification; failures don't fail the interview change
+func (s *interviewService) notify(ctx context.Context, userID, interviewID, title, message string, notificationType models.NotificationType) {
+	if s.notifications == nil {
+		return
+	}
+	_ = s.notifications.CreateInterviewNotification(ctx, userID, interviewID, title, message, notificationType)
+}
+
+func isOpenInterview(status string) bool {
+	return status == models.InterviewProposed || status == models.InterviewRescheduleRequested || status == models.InterviewScheduled
+}
+
+func withReason(message, reason string) string {
+	if reason == "" {
+		return message
+	}
+	return fmt.Sprintf("%s Reason: %s", message, reason)
+}
+
+func describeRejectedSlots(rejected []interviews.RejectedSlot) string {
+	var outside, conflicts int
+	for _, slot := range rejected {
+		if slot.Reason == interviews.ReasonConflict {
+			conflicts++
+		} else {
+			outside++
+		}
+	}
+	return fmt.Sprintf("%d outside the applicant's availability, %d conflicting with a scheduled interview", outside, conflicts)
+}
+
+func formatInUserTimezone(t time.Time, timezone string) string {
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		location = time.UTC
+	}
+	return t.In(location).Format("Mon Jan 2 at 15:04 MST")
+}
+
+func interviewToResponse(interview *models.Interview) *dto.InterviewResponse {
+	slots := []models.InterviewSlot(interview.ProposedSlots)
+	if slots == nil {
+		slots = []models.InterviewSlot{}
+	}
+	return &dto.InterviewResponse{
+		ID:             interview.ID,
+		ApplicationID:  interview.ApplicationID,
+		JobID:          interview.JobID,
+		EmployerID:     interview.EmployerID,
+		StudentID:      interview.StudentID,
+		Status:         interview.Status,
+		ProposedSlots:  slots,
+		ScheduledStart: interview.ScheduledStart,
+		ScheduledEnd:   interview.ScheduledEnd,
+		Location:       interview.Location,
+		MeetingURL:     interview.MeetingURL,
+		Notes:          interview.Notes,
+		Sequence:       interview.Sequence,
+		CancelReason:   interview.CancelReason,
+		CreatedAt:      interview.CreatedAt,
+		UpdatedAt:      interview.UpdatedAt,
+	}
+}
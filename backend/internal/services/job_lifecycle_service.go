@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	jobstatus "microbridge/backend/internal/core/jobs"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// JobLifecycleService moves jobs through their lifecycle on behalf of the
+// employer, the hired student, an administrator or the deadline scheduler, and
+// shows their history
+type JobLifecycleService interface {
+	TransitionJob(ctx context.Context, jobID, userID string, req dto.JobTransitionRequest) (*dto.JobTimelineResponse, error)
+	OverrideJobStatus(ctx context.Context, jobID, adminID string, req dto.JobStatusOverrideRequest) (*dto.JobTimelineResponse, error)
+	GetJobHistory(ctx context.Context, jobID, userID string) (*dto.JobTimelineResponse, error)
+
+	// Deadline scheduling; see jobs.DeadlineActions
+	TransitionBySystem(ctx context.Context, job *models.Job, to, reason string) error
+	RemindPendingApplications(ctx context.Context, pending models.PendingApplications) error
+	RemindReviewPeriod(ctx context.Context, job *models.Job) error
+}
+
+type jobLifecycleService struct {
+	jobRepo       repository.JobRepository
+	userRepo      repository.UserRepository
+	reviewRepo    repository.ReviewRepository
+	notifications *NotificationService
+	events        *jobstatus.Emitter
+}
+
+func NewJobLifecycleService(
+	jobRepo repository.JobRepository,
+	userRepo repository.UserRepository,
+	reviewRepo repository.ReviewRepository,
+	notifications *NotificationService,
+	events *jobstatus.Emitter,
+) JobLifecycleService {
+	return &jobLifecycleService{
+		jobRepo:       jobRepo,
+		userRepo:      userRepo,
+		reviewRepo:    reviewRepo,
+		notifications: notifications,
+		events:        events,
+	}
+}
+
+// TransitionJob makes a status change the lifecycle allows the user to make
+func (s *jobLifecycleService) TransitionJob(ctx context.Context, jobID, userID string, req dto.JobTransitionRequest) (*dto.JobTimelineResponse, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := s.actorFor(ctx, job, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transitionJob(ctx, s.jobRepo, s.events, job, req.Status, actor, userID, strings.TrimSpace(req.Reason)); err != nil {
+		return nil, err
+	}
+	return s.timeline(ctx, job, actor)
+}
+
+// OverrideJobStatus forces a job into any status. The override is recorded with
+// the administrator's reason.
+func (s *jobLifecycleService) OverrideJobStatus(ctx context.Context, jobID, adminID string, req dto.JobStatusOverrideRequest) (*dto.JobTimelineResponse, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := job.Status
+	entry, err := jobstatus.Override(job, req.Status, adminID, req.Reason, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.TransitionStatus(ctx, job, from, entry); err != nil {
+		return nil, err
+	}
+	s.events.Emit(ctx, jobstatus.NewEvent(job, entry))
+
+	return s.timeline(ctx, job, jobstatus.ActorAdmin)
+}
+
+// GetJobHistory returns a job's status history to its employer, its hired
+// student or an administrator
+func (s *jobLifecycleService) GetJobHistory(ctx context.Context, jobID, userID string) (*dto.JobTimelineResponse, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := s.actorFor(ctx, job, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.timeline(ctx, job, actor)
+}
+
+// TransitionBySystem makes a scheduled status change. Listeners hear about it
+// like any other change, so the parties are notified.
+func (s *jobLifecycleService) TransitionBySystem(ctx context.Context, job *models.Job, to, reason string) error {
+	return transitionJob(ctx, s.jobRepo, s.events, job, to, jobstatus.ActorSystem, "", reason)
+}
+
+// RemindPendingApplications reminds an employer of applications waiting for a review
+func (s *jobLifecycleService) RemindPendingApplications(ctx context.Context, pending models.PendingApplications) error {
+	if s.notifications == nil || pending.Pending == 0 {
+		return nil
+	}
+	noun := "applications are"
+	if pending.Pending == 1 {
+		noun = "application is"
+	}
+	return s.notifications.CreateJobUpdateNotification(ctx, pending.EmployerID, pending.JobID, "Applications Awaiting Review",
+		fmt.Sprintf("%d %s waiting for your review on '%s'.", pending.Pending, noun, pending.JobTitle),
+		models.NotificationTypeInfo)
+}
+
+// RemindReviewPeriod reminds the parties who haven't reviewed a job that its review period is ending
+func (s *jobLifecycleService) RemindReviewPeriod(ctx context.Context, job *models.Job) error {
+	if s.notifications == nil || job.ReviewDueDate == nil || job.HiredStudentID == nil {
+		return nil
+	}
+
+	for _, userID := range []string{*job.HiredStudentID, job.EmployerID} {
+		review, err := s.reviewRepo.GetByReviewerAndJob(ctx, userID, job.ID)
+		if err != nil {
+			return err
+		}
+		if review != nil {
+			continue
+		}
+		if err := s.notifications.CreateJobUpdateNotification(ctx, userID, job.ID, "Review Reminder",
+			fmt.Sprintf("The review period for '%s' ends %s. Leave your review before then.", job.Title, job.ReviewDueDate.Format(time.RFC1123)),
+			models.NotificationTypeWarning); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper methods
+
+// actorFor works out which side of the job the user is on
+func (s *jobLifecycleService) actorFor(ctx context.Context, job *models.Job, userID string) (jobstatus.Actor, error) {
+	if userID == job.EmployerID {
+		return jobstatus.ActorEmployer, nil
+	}
+	if job.HiredStudentID != nil && userID == *job.HiredStudentID {
+		return jobstatus.ActorStudent, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.UserType == "admin" {
+		return jobstatus.ActorAdmin, nil
+	}
+	return "", apperrors.NewForbiddenError("You don't have permission to change this job")
+}
+
+func (s *jobLifecycleService) timeline(ctx context.Context, job *models.Job, actor jobstatus.Actor) (*dto.JobTimelineResponse, error) {
+	history, err := s.jobRepo.GetStatusHistory(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &dto.JobTimelineResponse{
+		JobID:        job.ID,
+		Status:       job.Status,
+		NextStatuses: jobstatus.NextStatuses(job.Status, actor),
+		History:      make([]dto.JobStatusChangeResponse, len(history)),
+	}
+	for i, entry := range history {
+		timeline.History[i] = dto.JobStatusChangeResponse{
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			ActorID:    entry.ActorID,
+			ActorRole:  entry.ActorRole,
+			Reason:     entry.Reason,
+			Override:   entry.Override,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+	return timeline, nil
+}
+
+// transitionJob moves a job through the lifecycle, records the change and tells
+// the listeners about it; every service that changes a job's status goes through it
+func transitionJob(ctx context.Context, repo repository.JobRepository, events *jobstatus.Emitter, job *models.Job, to string, actor jobstatus.Actor, actorID, reason string) error {
+	from := job.Status
+	entry, err := jobstatus.Transition(job, to, actor, actorID, reason, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := repo.TransitionStatus(ctx, job, from, entry); err != nil {
+		return err
+	}
+	events.Emit(ctx, jobstatus.NewEvent(job, entry))
+	return nil
+}
+
+// jobStatusMessages describe each status a job can move to, for the parties
+var jobStatusMessages = map[string]string{
+	jobstatus.StatusDraft:         "'%s' was moved back to draft.",
+	jobstatus.StatusPosted:        "'%s' is now posted.",
+	jobstatus.StatusInProgress:    "Work on '%s' is in progress.",
+	jobstatus.StatusSubmitted:     "Work on '%s' was submitted for review.",
+	jobstatus.StatusReviewPending: "'%s' is complete. Leave a review for the other party.",
+	jobstatus.StatusCompleted:     "'%s' is completed and its reviews are published.",
+	jobstatus.StatusDisputed:      "'%s' is disputed. An administrator will look into it.",
+	jobstatus.StatusArchived:      "'%s' was archived.",
+}
+
+// JobStatusNotifier tells the employer and the hired student about status
+// changes they didn't make themselves. Hiring is left out; accepting the offer
+// already notifies both.
+func JobStatusNotifier(notifications *NotificationService) jobstatus.Listener {
+	return func(ctx context.Context, event jobstatus.Event) {
+		message, ok := jobStatusMessages[event.To]
+		if !ok || notifications == nil {
+			return
+		}
+		message = fmt.Sprintf(message, event.JobTitle)
+		if event.Reason != "" {
+			message += " Reason: " + event.Reason
+		}
+		notificationType := models.NotificationTypeInfo
+		if event.To == jobstatus.StatusDisputed || event.Override {
+			notificationType = models.NotificationTypeWarning
+		}
+
+		for _, userID := range []string{event.EmployerID, event.HiredStudentID} {
+			if userID == "" || userID == event.ActorID {
+				continue
+			}
+			_ = notifications.CreateJobUpdateNotification(ctx, userID, event.JobID, "Job Status Updated", message, notificationType)
+		}
+	}
+}
@@ -2,12 +2,18 @@ package services
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	jobstatus "microbridge/backend/internal/core/jobs"
+	"microbridge/backend/internal/core/jobposting"
+	"microbridge/backend/internal/core/matching"
+	"microbridge/backend/internal/core/skills"
 	"microbridge/backend/internal/dto"
 	"microbridge/backend/internal/models"
 	"microbridge/backend/internal/repository"
 	apperrors "microbridge/backend/internal/shared/errors"
+	"microbridge/backend/internal/shared/validation"
 
 	"github.com/google/uuid"
 )
@@ -18,17 +24,37 @@ type JobService interface {
 	UpdateJob(ctx context.Context, jobID string, employerID string, req dto.UpdateJobRequest) (*dto.JobResponse, error)
 	DeleteJob(ctx context.Context, jobID string, employerID string) error
 	ListJobs(ctx context.Context, filters dto.JobFilters, page, limit int) (*dto.PaginatedJobResponse, error)
-	GetJobsByEmployer(ctx context.Context, employerID string, page, limit int) (*dto.PaginatedJobResponse, error)
-	SearchJobs(ctx context.Context, query string, filters dto.JobFilters, page, limit int) (*dto.PaginatedJobResponse, error)
+	GetJobsByEmployer(ctx context.Context, employerID, status string, page, limit int) (*dto.PaginatedJobResponse, error)
+	SearchJobs(ctx context.Context, searcherID, query string, filters dto.JobFilters, page, limit int) (*dto.JobSearchResponse, error)
+	SuggestJobSkills(ctx context.Context, req dto.SuggestJobSkillsRequest) (*jobposting.Analysis, error)
+	GetScreeningQuestions(ctx context.Context, jobID string, employerID string) (models.ScreeningQuestions, error)
 }
 
+const (
+	maxJobDescriptionSize = 20000
+	// maxSuggestedSkills caps how many extracted skills are filled in for the employer
+	maxSuggestedSkills = 10
+)
+
 type jobService struct {
-	jobRepo repository.JobRepository
+	jobRepo    repository.JobRepository
+	userRepo   repository.UserRepository
+	extractor  *jobposting.Extractor
+	dictionary *skills.Dictionary
+	matcher    *matching.MatchingAlgorithm
+	events     *jobstatus.Emitter
+	features   FeatureInvalidator
 }
 
-func NewJobService(jobRepo repository.JobRepository) JobService {
+func NewJobService(jobRepo repository.JobRepository, userRepo repository.UserRepository, dictionary *skills.Dictionary, events *jobstatus.Emitter, features FeatureInvalidator) JobService {
 	return &jobService{
-		jobRepo: jobRepo,
+		jobRepo:    jobRepo,
+		userRepo:   userRepo,
+		extractor:  jobposting.NewExtractor(dictionary),
+		dictionary: dictionary,
+		matcher:    matching.NewMatchingAlgorithm(),
+		events:     events,
+		features:   features,
 	}
 }
 
@@ -37,6 +63,10 @@ func (s *jobService) CreateJob(ctx context.Context, employerID string, req dto.C
 	if err := s.validateCreateJobRequest(req); err != nil {
 		return nil, err
 	}
+	questions, err := validation.NormalizeScreeningQuestions(req.ScreeningQuestions)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create job model from request
 	job := &models.Job{
@@ -58,6 +88,7 @@ func (s *jobService) CreateJob(ctx context.Context, employerID string, req dto.C
 		Requirements: req.Requirements,
 		WorkArrangement: req.WorkArrangement,
 		PreferredCandidates: req.PreferredCandidates,
+		ScreeningQuestions: questions,
 		ApplicationDeadline: req.ApplicationDeadline,
 		StartDate:    req.StartDate,
 		EndDate:      req.EndDate,
@@ -67,11 +98,18 @@ func (s *jobService) CreateJob(ctx context.Context, employerID string, req dto.C
 		UpdatedAt:    time.Now(),
 	}
 
+	// Fill in skills the employer left out so the job is matchable, and keep the
+	// rest of the extracted skills as suggestions to review before posting
+	analysis, suggested := s.applySkillSuggestions(job)
+	if len(job.Skills) == 0 {
+		return nil, apperrors.NewAppError(400, "At least one skill is required and none were found in the description", nil)
+	}
+
 	if err := s.jobRepo.Create(ctx, job); err != nil {
 		return nil, err
 	}
 
-	return s.jobToResponse(job), nil
+	return s.jobToDraftResponse(job, analysis, suggested), nil
 }
 
 func (s *jobService) GetJob(ctx context.Context, id string) (*dto.JobResponse, error) {
@@ -99,6 +137,9 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID string, employerID str
 		job.Title = *req.Title
 	}
 	if req.Description != nil {
+		if len(*req.Description) > maxJobDescriptionSize {
+			return nil, apperrors.NewAppError(400, "Job description is too long", nil)
+		}
 		job.Description = *req.Description
 	}
 	if req.Location != nil {
@@ -116,9 +157,6 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID string, employerID str
 	if req.ExperienceLevel != nil {
 		job.ExperienceLevel = *req.ExperienceLevel
 	}
-	if req.Status != nil {
-		job.Status = *req.Status
-	}
 	if req.Duration != nil {
 		job.Duration = *req.Duration
 	}
@@ -140,6 +178,13 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID string, employerID str
 	if req.PreferredCandidates != nil {
 		job.PreferredCandidates = *req.PreferredCandidates
 	}
+	if req.ScreeningQuestions != nil {
+		questions, err := validation.NormalizeScreeningQuestions(*req.ScreeningQuestions)
+		if err != nil {
+			return nil, err
+		}
+		job.ScreeningQuestions = questions
+	}
 	if req.ApplicationDeadline != nil {
 		job.ApplicationDeadline = req.ApplicationDeadline
 	}
@@ -150,13 +195,35 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID string, employerID str
 		job.EndDate = req.EndDate
 	}
 
-	job.UpdatedAt = time.Now()
+	// Edits only suggest skills and a level; the employer's own fields are never
+	// overwritten, so a job posted in this update is posted as the employer sent it
+	analysis := s.analyzeSkills(job)
+
+	// A status change goes through the lifecycle; check it against the edited
+	// job before saving anything so a rejected change leaves the job untouched
+	now := time.Now()
+	changeStatus := req.Status != nil && *req.Status != job.Status
+	if changeStatus {
+		if err := jobstatus.CanTransition(job, *req.Status, jobstatus.ActorEmployer, now); err != nil {
+			return nil, err
+		}
+	} else if job.Status == jobstatus.StatusPosted && len(job.Skills) == 0 {
+		return nil, apperrors.NewAppError(400, "A posted job needs at least one required skill", nil)
+	}
+
+	job.UpdatedAt = now
 
 	if err := s.jobRepo.Update(ctx, job); err != nil {
 		return nil, err
 	}
+	invalidateJobFeatures(ctx, s.features, job.ID)
+	if changeStatus {
+		if err := transitionJob(ctx, s.jobRepo, s.events, job, *req.Status, jobstatus.ActorEmployer, employerID, ""); err != nil {
+			return nil, err
+		}
+	}
 
-	return s.jobToResponse(job), nil
+	return s.jobToDraftResponse(job, analysis, false), nil
 }
 
 func (s *jobService) DeleteJob(ctx context.Context, jobID string, employerID string) error {
@@ -170,7 +237,11 @@ func (s *jobService) DeleteJob(ctx context.Context, jobID string, employerID str
 		return apperrors.NewAppError(403, "You don't have permission to delete this job", nil)
 	}
 
-	return s.jobRepo.Delete(ctx, jobID)
+	if err := s.jobRepo.Delete(ctx, jobID); err != nil {
+		return err
+	}
+	invalidateJobFeatures(ctx, s.features, jobID)
+	return nil
 }
 
 func (s *jobService) ListJobs(ctx context.Context, filters dto.JobFilters, page, limit int) (*dto.PaginatedJobResponse, error) {
@@ -205,7 +276,7 @@ func (s *jobService) ListJobs(ctx context.Context, filters dto.JobFilters, page,
 	}, nil
 }
 
-func (s *jobService) GetJobsByEmployer(ctx context.Context, employerID string, page, limit int) (*dto.PaginatedJobResponse, error) {
+func (s *jobService) GetJobsByEmployer(ctx context.Context, employerID, status string, page, limit int) (*dto.PaginatedJobResponse, error) {
 	if page <= 0 {
 		page = 1
 	}
@@ -215,7 +286,7 @@ func (s *jobService) GetJobsByEmployer(ctx context.Context, employerID string, p
 
 	offset := (page - 1) * limit
 
-	jobs, total, err := s.jobRepo.GetByEmployerID(ctx, employerID, limit, offset)
+	jobs, total, err := s.jobRepo.GetByEmployerID(ctx, employerID, status, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +307,9 @@ func (s *jobService) GetJobsByEmployer(ctx context.Context, employerID string, p
 	}, nil
 }
 
-func (s *jobService) SearchJobs(ctx context.Context, query string, filters dto.JobFilters, page, limit int) (*dto.PaginatedJobResponse, error) {
+// SearchJobs ranks jobs by how well they match the query. When a student
+// searches, the best text matches are re-ranked by how well each job fits them.
+func (s *jobService) SearchJobs(ctx context.Context, searcherID, query string, filters dto.JobFilters, page, limit int) (*dto.JobSearchResponse, error) {
 	if page <= 0 {
 		page = 1
 	}
@@ -246,30 +319,216 @@ func (s *jobService) SearchJobs(ctx context.Context, query string, filters dto.J
 
 	offset := (page - 1) * limit
 	filterMap := s.filtersToMap(filters)
-
-	jobs, total, err := s.jobRepo.Search(ctx, query, filterMap, limit, offset)
+	query = strings.TrimSpace(query)
+	student := s.searchingStudent(ctx, searcherID)
+
+	// A student's best text matches are re-ranked as a whole, so pages inside the
+	// window are cut from the re-ranked order; matches past it keep the text order
+	var result *repository.JobSearchResult
+	var reranked map[string]jobstatus.SearchCandidate
+	var err error
+	if student != nil && query != "" && offset < jobstatus.SearchRerankWindow {
+		result, reranked, err = s.searchReranked(ctx, student, query, filterMap, offset, limit)
+	} else {
+		result, err = s.jobRepo.Search(ctx, query, filterMap, limit, offset)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	jobResponses := make([]*dto.JobResponse, len(jobs))
-	for i, job := range jobs {
-		jobResponses[i] = s.jobToResponse(job)
+	jobResults := make([]*dto.JobSearchResult, len(result.Hits))
+	for i, hit := range result.Hits {
+		jobResults[i] = &dto.JobSearchResult{
+			JobResponse:    s.jobToResponse(hit.Job),
+			Relevance:      hit.Rank,
+			Score:          hit.Rank,
+			TitleHighlight: hit.TitleHighlight,
+			Snippet:        hit.Snippet,
+		}
+		if candidate, ok := reranked[hit.Job.ID]; ok {
+			jobResults[i].Score = candidate.Score
+			jobResults[i].MatchScore = &candidate.Match
+		} else if student != nil {
+			matchScore := s.matcher.CalculateMatchScore(student, hit.Job).TotalScore
+			jobResults[i].MatchScore = &matchScore
+		}
 	}
 
-	return &dto.PaginatedJobResponse{
-		Jobs: jobResponses,
+	return &dto.JobSearchResponse{
+		Jobs: jobResults,
 		Pagination: dto.PaginationResponse{
-			Page:     page,
-			Limit:    limit,
-			Total:    total,
-			HasMore:  int64(page*limit) < total,
+			Page:    page,
+			Limit:   limit,
+			Total:   result.Total,
+			HasMore: int64(page*limit) < result.Total,
+		},
+		Facets: dto.JobSearchFacets{
+			Category:        facetCountsToResponse(result.Facets.Category),
+			JobType:         facetCountsToResponse(result.Facets.JobType),
+			ExperienceLevel: facetCountsToResponse(result.Facets.ExperienceLevel),
+			Remote:          facetCountsToResponse(result.Facets.Remote),
 		},
 	}, nil
 }
 
+// searchReranked pages the search from the re-ranked window. A page reaching past
+// the window continues with the text-ranked matches after it, which the window
+// can't hold, so no job is repeated or skipped between pages. The returned
+// candidates hold the re-ranked hits' scores by job ID.
+func (s *jobService) searchReranked(ctx context.Context, student *models.User, query string, filters map[string]interface{}, offset, limit int) (*repository.JobSearchResult, map[string]jobstatus.SearchCandidate, error) {
+	window := jobstatus.SearchRerankWindow
+	result, err := s.jobRepo.RankMatches(ctx, query, filters, window)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := make([]jobstatus.SearchCandidate, len(result.Hits))
+	for i, hit := range result.Hits {
+		candidates[i] = jobstatus.SearchCandidate{
+			Index:     i,
+			Relevance: hit.Rank,
+			Match:     s.matcher.CalculateMatchScore(student, hit.Job).TotalScore,
+		}
+	}
+	jobstatus.Rerank(candidates)
+
+	page := candidates[min(offset, len(candidates)):min(offset+limit, len(candidates))]
+	hits := make([]repository.JobSearchHit, 0, limit)
+	reranked := make(map[string]jobstatus.SearchCandidate, len(page))
+	for _, candidate := range page {
+		hit := result.Hits[candidate.Index]
+		hits = append(hits, hit)
+		reranked[hit.Job.ID] = candidate
+	}
+	if err := s.jobRepo.Highlight(ctx, query, hits); err != nil {
+		return nil, nil, err
+	}
+
+	if rest := offset + limit - window; rest > 0 && len(result.Hits) == window {
+		tail, err := s.jobRepo.Search(ctx, query, filters, rest, window)
+		if err != nil {
+			return nil, nil, err
+		}
+		hits = append(hits, tail.Hits...)
+	}
+
+	result.Hits = hits
+	return result, reranked, nil
+}
+
+// SuggestJobSkills extracts required skills and seniority from a draft posting so the
+// employer can review them before creating or updating the job. Nothing is saved.
+func (s *jobService) SuggestJobSkills(ctx context.Context, req dto.SuggestJobSkillsRequest) (*jobposting.Analysis, error) {
+	if strings.TrimSpace(req.Description) == "" {
+		return nil, apperrors.NewValidationError("job description is required")
+	}
+	if len(req.Description) > maxJobDescriptionSize {
+		return nil, apperrors.NewValidationError("job description is too long")
+	}
+
+	listed := make([]string, len(req.Skills))
+	for i, skill := range req.Skills {
+		listed[i] = skill.Name
+	}
+
+	return s.extractor.Extract(jobposting.Posting{
+		Title:           req.Title,
+		Description:     req.Description,
+		Requirements:    req.Requirements,
+		ExperienceLevel: req.ExperienceLevel,
+		Skills:          listed,
+	}), nil
+}
+
 // Helper methods
 
+// analyzeSkills extracts skills and seniority from the job text without changing the job
+func (s *jobService) analyzeSkills(job *models.Job) *jobposting.Analysis {
+	listed := make([]string, len(job.Skills))
+	for i, skill := range job.Skills {
+		listed[i] = skill.Name
+	}
+	return s.extractor.Extract(jobposting.Posting{
+		Title:           job.Title,
+		Description:     job.Description,
+		Requirements:    job.Requirements,
+		ExperienceLevel: job.ExperienceLevel,
+		Skills:          listed,
+	})
+}
+
+// applySkillSuggestions extracts skills from a new job's text. A job without skills
+// gets the strongest suggestions filled in, and a job without an experience level gets
+// the detected one. It reports whether the skills were filled in.
+func (s *jobService) applySkillSuggestions(job *models.Job) (*jobposting.Analysis, bool) {
+	analysis := s.analyzeSkills(job)
+
+	if job.ExperienceLevel == "" {
+		job.ExperienceLevel = analysis.ExperienceLevel
+	}
+	if len(job.Skills) > 0 || len(analysis.Skills) == 0 {
+		return analysis, false
+	}
+
+	// Prefer skills the posting actually asks for, falling back to plain mentions
+	var picked models.RequiredSkillsArray
+	for _, suggestion := range analysis.Skills {
+		if suggestion.Need != jobposting.NeedMentioned && len(picked) < maxSuggestedSkills {
+			picked = append(picked, suggestion.RequiredSkill())
+		}
+	}
+	if len(picked) == 0 {
+		for _, suggestion := range analysis.Skills {
+			if len(picked) < maxSuggestedSkills {
+				picked = append(picked, suggestion.RequiredSkill())
+			}
+		}
+	}
+	job.Skills = picked
+	return analysis, true
+}
+
+// GetScreeningQuestions returns a job's screening questions with their knockout answers, for its employer
+func (s *jobService) GetScreeningQuestions(ctx context.Context, jobID string, employerID string) (models.ScreeningQuestions, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.EmployerID != employerID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to view this job's screening questions", nil)
+	}
+	if job.ScreeningQuestions == nil {
+		return models.ScreeningQuestions{}, nil
+	}
+	return job.ScreeningQuestions, nil
+}
+
+// jobToDraftResponse adds the suggestions the job does not list yet to the response.
+// It goes to the job's employer only, so the screening questions keep their knockout answers.
+func (s *jobService) jobToDraftResponse(job *models.Job, analysis *jobposting.Analysis, suggested bool) *dto.JobResponse {
+	response := s.jobToResponse(job)
+	response.ScreeningQuestions = job.ScreeningQuestions
+	response.SkillsSuggested = suggested
+	response.SuggestedExperienceLevel = analysis.ExperienceLevel
+
+	listed := make(map[string]bool, len(job.Skills))
+	for _, skill := range job.Skills {
+		listed[s.dictionary.Canonical(skill.Name)] = true
+	}
+	for _, suggestion := range analysis.Skills {
+		if !listed[suggestion.Name] {
+			response.SkillSuggestions = append(response.SkillSuggestions, dto.SkillResponse{
+				Name:       suggestion.Name,
+				Level:      suggestion.Level,
+				IsRequired: suggestion.IsRequired,
+				Importance: suggestion.Importance,
+				CanLearn:   suggestion.CanLearn,
+			})
+		}
+	}
+	return response
+}
+
 func (s *jobService) validateCreateJobRequest(req dto.CreateJobRequest) error {
 	if req.Title == "" {
 		return apperrors.NewAppError(400, "Job title is required", nil)
@@ -280,8 +539,8 @@ func (s *jobService) validateCreateJobRequest(req dto.CreateJobRequest) error {
 	if req.Category == "" {
 		return apperrors.NewAppError(400, "Job category is required", nil)
 	}
-	if len(req.Skills) == 0 {
-		return apperrors.NewAppError(400, "At least one skill is required", nil)
+	if len(req.Description) > maxJobDescriptionSize {
+		return apperrors.NewAppError(400, "Job description is too long", nil)
 	}
 
 	return nil
@@ -301,6 +560,27 @@ func (s *jobService) convertToRequiredSkills(skillNames []dto.SkillRequest) mode
 	return skills
 }
 
+// searchingStudent returns the searcher when they are a student. Anyone else,
+// or a searcher who can't be loaded, searches by text alone.
+func (s *jobService) searchingStudent(ctx context.Context, searcherID string) *models.User {
+	if searcherID == "" || s.userRepo == nil {
+		return nil
+	}
+	user, err := s.userRepo.GetByID(ctx, searcherID)
+	if err != nil || user.UserType != "student" {
+		return nil
+	}
+	return user
+}
+
+func facetCountsToResponse(counts []repository.FacetCount) []dto.FacetCount {
+	response := make([]dto.FacetCount, len(counts))
+	for i, count := range counts {
+		response[i] = dto.FacetCount{Value: count.Value, Count: count.Count}
+	}
+	return response
+}
+
 func (s *jobService) filtersToMap(filters dto.JobFilters) map[string]interface{} {
 	filterMap := make(map[string]interface{})
 
@@ -352,6 +632,7 @@ func (s *jobService) jobToResponse(job *models.Job) *dto.JobResponse {
 		StartDate:       job.StartDate,
 		EndDate:         job.EndDate,
 		PreferredCandidates: job.PreferredCandidates,
+		ScreeningQuestions: job.ScreeningQuestions.Public(),
 		Status:          job.Status,
 		Views:           job.Views,
 		Applications:    job.Applications,
@@ -1,10 +1,12 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
 	"gorm.io/gorm"
 )
 
@@ -17,7 +19,7 @@ func NewNotificationService(db *gorm.DB) *NotificationService {
 }
 
 // CreateNotification creates a new notification for a user
-func (s *NotificationService) CreateNotification(userID uint, title, message string, notificationType models.NotificationType, actionURL, actionText *string, metadata map[string]interface{}) (*models.Notification, error) {
+func (s *NotificationService) CreateNotification(ctx context.Context, userID string, title, message string, notificationType models.NotificationType, actionURL, actionText *string, metadata map[string]interface{}) (*models.Notification, error) {
 	notification := &models.Notification{
 		UserID:     userID,
 		Title:      title,
@@ -31,7 +33,7 @@ func (s *NotificationService) CreateNotification(userID uint, title, message str
 		UpdatedAt:  time.Now(),
 	}
 
-	if err := s.db.Create(notification).Error; err != nil {
+	if err := s.db.WithContext(ctx).Create(notification).Error; err != nil {
 		return nil, err
 	}
 
@@ -39,11 +41,11 @@ func (s *NotificationService) CreateNotification(userID uint, title, message str
 }
 
 // GetUserNotifications retrieves notifications for a specific user with pagination
-func (s *NotificationService) GetUserNotifications(userID uint, page, limit int, unreadOnly bool) ([]models.Notification, int64, error) {
+func (s *NotificationService) GetUserNotifications(ctx context.Context, userID string, page, limit int, unreadOnly bool) ([]models.Notification, int64, error) {
 	var notifications []models.Notification
 	var total int64
 
-	query := s.db.Where("user_id = ?", userID)
+	query := s.db.WithContext(ctx).Where("user_id = ?", userID)
 	
 	if unreadOnly {
 		query = query.Where("is_read = ?", false)
@@ -64,9 +66,9 @@ func (s *NotificationService) GetUserNotifications(userID uint, page, limit int,
 }
 
 // MarkAsRead marks a specific notification as read
-func (s *NotificationService) MarkAsRead(userID uint, notificationID uint) error {
+func (s *NotificationService) MarkAsRead(ctx context.Context, userID string, notificationID string) error {
 	now := time.Now()
-	result := s.db.Model(&models.Notification{}).
+	result := s.db.WithContext(ctx).Model(&models.Notification{}).
 		Where("id = ? AND user_id = ?", notificationID, userID).
 		Updates(map[string]interface{}{
 			"is_read":   true,
@@ -79,16 +81,16 @@ func (s *NotificationService) MarkAsRead(userID uint, notificationID uint) error
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("notification not found or not owned by user")
+		return apperrors.NewNotFoundError("Notification")
 	}
 
 	return nil
 }
 
 // MarkAllAsRead marks all notifications for a user as read
-func (s *NotificationService) MarkAllAsRead(userID uint) error {
+func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID string) error {
 	now := time.Now()
-	result := s.db.Model(&models.Notification{}).
+	result := s.db.WithContext(ctx).Model(&models.Notification{}).
 		Where("user_id = ? AND is_read = ?", userID, false).
 		Updates(map[string]interface{}{
 			"is_read":   true,
@@ -100,17 +102,17 @@ func (s *NotificationService) MarkAllAsRead(userID uint) error {
 }
 
 // GetUnreadCount returns the number of unread notifications for a user
-func (s *NotificationService) GetUnreadCount(userID uint) (int64, error) {
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userID string) (int64, error) {
 	var count int64
-	err := s.db.Model(&models.Notification{}).
+	err := s.db.WithContext(ctx).Model(&models.Notification{}).
 		Where("user_id = ? AND is_read = ?", userID, false).
 		Count(&count).Error
 	return count, err
 }
 
 // DeleteNotification deletes a notification (only if owned by the user)
-func (s *NotificationService) DeleteNotification(userID uint, notificationID uint) error {
-	result := s.db.Where("id = ? AND user_id = ?", notificationID, userID).
+func (s *NotificationService) DeleteNotification(ctx context.Context, userID string, notificationID string) error {
+	result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", notificationID, userID).
 		Delete(&models.Notification{})
 
 	if result.Error != nil {
@@ -118,16 +120,16 @@ func (s *NotificationService) DeleteNotification(userID uint, notificationID uin
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("notification not found or not owned by user")
+		return apperrors.NewNotFoundError("Notification")
 	}
 
 	return nil
 }
 
 // GetNotificationSettings retrieves notification settings for a user
-func (s *NotificationService) GetNotificationSettings(userID uint) (*models.NotificationSettings, error) {
+func (s *NotificationService) GetNotificationSettings(ctx context.Context, userID string) (*models.NotificationSettings, error) {
 	var settings models.NotificationSettings
-	err := s.db.Where("user_id = ?", userID).First(&settings).Error
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&settings).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Create default settings if not found
@@ -143,7 +145,7 @@ func (s *NotificationService) GetNotificationSettings(userID uint) (*models.Noti
 				CreatedAt:            time.Now(),
 				UpdatedAt:            time.Now(),
 			}
-			if err := s.db.Create(&settings).Error; err != nil {
+			if err := s.db.WithContext(ctx).Create(&settings).Error; err != nil {
 				return nil, err
 			}
 		} else {
@@ -154,11 +156,17 @@ func (s *NotificationService) GetNotificationSettings(userID uint) (*models.Noti
 }
 
 // UpdateNotificationSettings updates notification settings for a user
-func (s *NotificationService) UpdateNotificationSettings(userID uint, settings *models.NotificationSettings) error {
+func (s *NotificationService) UpdateNotificationSettings(ctx context.Context, userID string, settings *models.NotificationSettings) error {
 	settings.UserID = userID
 	settings.UpdatedAt = time.Now()
 
-	result := s.db.Where("user_id = ?", userID).Updates(settings)
+	// Select the settings columns explicitly so switching a preference off is saved too
+	result := s.db.WithContext(ctx).Model(&models.NotificationSettings{}).
+		Where("user_id = ?", userID).
+		Select("email_notifications", "push_notifications", "job_updates", "payment_notifications",
+			"deadline_reminders", "project_updates", "system_notifications",
+			"do_not_disturb_start", "do_not_disturb_end", "updated_at").
+		Updates(settings)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -166,18 +174,19 @@ func (s *NotificationService) UpdateNotificationSettings(userID uint, settings *
 	if result.RowsAffected == 0 {
 		// Create if not exists
 		settings.CreatedAt = time.Now()
-		return s.db.Create(settings).Error
+		return s.db.WithContext(ctx).Create(settings).Error
 	}
 
 	return nil
 }
 
 // CreateJobMatchNotification creates a notification for a new job match
-func (s *NotificationService) CreateJobMatchNotification(userID uint, jobID uint, jobTitle string) error {
-	actionURL := fmt.Sprintf("/student_portal/workspace/job-details/%d", jobID)
+func (s *NotificationService) CreateJobMatchNotification(ctx context.Context, userID string, jobID string, jobTitle string) error {
+	actionURL := fmt.Sprintf("/student_portal/workspace/job-details/%s", jobID)
 	actionText := "View Job"
 	
 	_, err := s.CreateNotification(
+		ctx,
 		userID,
 		"New Job Match",
 		fmt.Sprintf("A new micro-internship opportunity '%s' matches your skills", jobTitle),
@@ -193,11 +202,12 @@ func (s *NotificationService) CreateJobMatchNotification(userID uint, jobID uint
 }
 
 // CreatePaymentNotification creates a notification for payment received
-func (s *NotificationService) CreatePaymentNotification(userID uint, amount float64, projectTitle string) error {
+func (s *NotificationService) CreatePaymentNotification(ctx context.Context, userID string, amount float64, projectTitle string) error {
 	actionURL := "/student_portal/workspace/applications"
 	actionText := "View Details"
 	
 	_, err := s.CreateNotification(
+		ctx,
 		userID,
 		"Payment Received",
 		fmt.Sprintf("Payment of $%.2f has been processed for your completed project '%s'", amount, projectTitle),
@@ -213,11 +223,12 @@ func (s *NotificationService) CreatePaymentNotification(userID uint, amount floa
 }
 
 // CreateDeadlineReminder creates a notification for upcoming deadlines
-func (s *NotificationService) CreateDeadlineReminder(userID uint, projectTitle string, daysUntilDeadline int) error {
+func (s *NotificationService) CreateDeadlineReminder(ctx context.Context, userID string, projectTitle string, daysUntilDeadline int) error {
 	actionURL := "/student_portal/workspace/applications"
 	actionText := "View Project"
 	
 	_, err := s.CreateNotification(
+		ctx,
 		userID,
 		"Deadline Reminder",
 		fmt.Sprintf("Your project '%s' is due in %d days", projectTitle, daysUntilDeadline),
@@ -231,3 +242,110 @@ func (s *NotificationService) CreateDeadlineReminder(userID uint, projectTitle s
 	)
 	return err
 }
+
+// CreateModelDriftAlert notifies an administrator that a matching model's outputs have shifted
+func (s *NotificationService) CreateModelDriftAlert(ctx context.Context, userID string, metric string, psi, klDivergence float64) error {
+	actionURL := "/admin/ai/monitoring"
+	actionText := "Review Models"
+	
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		"Model Drift Detected",
+		fmt.Sprintf("The distribution of '%s' has drifted from its reference (PSI %.3f, KL %.3f)", metric, psi, klDivergence),
+		models.NotificationTypeWarning,
+		&actionURL,
+		&actionText,
+		map[string]interface{}{
+			"metric": metric,
+			"psi": psi,
+			"kl_divergence": klDivergence,
+			"category": "model_drift",
+		},
+	)
+	return err
+}
+
+// CreateInterviewNotification tells a student or employer about a change to one of their interviews
+func (s *NotificationService) CreateInterviewNotification(ctx context.Context, userID string, interviewID string, title, message string, notificationType models.NotificationType) error {
+	actionURL := fmt.Sprintf("/interviews/%s", interviewID)
+	actionText := "View Interview"
+	
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		title,
+		message,
+		notificationType,
+		&actionURL,
+		&actionText,
+		map[string]interface{}{
+			"interview_id": interviewID,
+			"category": "interview",
+		},
+	)
+	return err
+}
+
+// CreateApplicationUpdateNotification tells an applicant their application moved on
+func (s *NotificationService) CreateApplicationUpdateNotification(ctx context.Context, userID string, applicationID string, title, message string, notificationType models.NotificationType) error {
+	actionURL := "/student_portal/workspace/applications"
+	actionText := "View Application"
+	
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		title,
+		message,
+		notificationType,
+		&actionURL,
+		&actionText,
+		map[string]interface{}{
+			"application_id": applicationID,
+			"category": "application",
+		},
+	)
+	return err
+}
+
+// CreateOfferNotification tells a student or employer about a change to one of their offers
+func (s *NotificationService) CreateOfferNotification(ctx context.Context, userID string, offerID string, title, message string, notificationType models.NotificationType) error {
+	actionURL := fmt.Sprintf("/offers/%s", offerID)
+	actionText := "View Offer"
+	
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		title,
+		message,
+		notificationType,
+		&actionURL,
+		&actionText,
+		map[string]interface{}{
+			"offer_id": offerID,
+			"category": "offer",
+		},
+	)
+	return err
+}
+
+// CreateJobUpdateNotification tells an employer or hired student that a job changed status
+func (s *NotificationService) CreateJobUpdateNotification(ctx context.Context, userID string, jobID string, title, message string, notificationType models.NotificationType) error {
+	actionURL := fmt.Sprintf("/jobs/%s", jobID)
+	actionText := "View Job"
+
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		title,
+		message,
+		notificationType,
+		&actionURL,
+		&actionText,
+		map[string]interface{}{
+			"job_id":   jobID,
+			"category": "job",
+		},
+	)
+	return err
+}
@@ -0,0 +1,493 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appstatus "microbridge/backend/internal/core/applications"
+	jobstatus "microbridge/backend/internal/core/jobs"
+	"microbridge/backend/internal/core/offers"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// OfferService completes the hiring loop. Employers extend an offer on an
+// application; the student accepts, declines or counters it. Accepting fills the
+// job, accepts the application and closes every other application and offer.
+type OfferService interface {
+	CreateOffer(ctx context.Context, applicationID, employerID string, req dto.CreateOfferRequest) (*dto.OfferResponse, error)
+	GetOffer(ctx context.Context, offerID, userID string) (*dto.OfferResponse, error)
+	ListOffers(ctx context.Context, userID, applicationID string) ([]*dto.OfferResponse, error)
+	UpdateOffer(ctx context.Context, offerID, employerID string, req dto.UpdateOfferRequest) (*dto.OfferResponse, error)
+	WithdrawOffer(ctx context.Context, offerID, employerID string, req dto.OfferReasonRequest) (*dto.OfferResponse, error)
+	AcceptOffer(ctx context.Context, offerID, studentID string) (*dto.OfferResponse, error)
+	DeclineOffer(ctx context.Context, offerID, studentID string, req dto.OfferReasonRequest) (*dto.OfferResponse, error)
+	CounterOffer(ctx context.Context, offerID, studentID string, req dto.CounterOfferRequest) (*dto.OfferResponse, error)
+}
+
+type offerService struct {
+	offerRepo       repository.OfferRepository
+	applicationRepo repository.ApplicationRepository
+	jobRepo         repository.JobRepository
+	userRepo        repository.UserRepository
+	notifications   *NotificationService
+	events          *jobstatus.Emitter
+}
+
+func NewOfferService(
+	offerRepo repository.OfferRepository,
+	applicationRepo repository.ApplicationRepository,
+	jobRepo repository.JobRepository,
+	userRepo repository.UserRepository,
+	notifications *NotificationService,
+	events *jobstatus.Emitter,
+) OfferService {
+	return &offerService{
+		offerRepo:       offerRepo,
+		applicationRepo: applicationRepo,
+		jobRepo:         jobRepo,
+		userRepo:        userRepo,
+		notifications:   notifications,
+		events:          events,
+	}
+}
+
+// CreateOffer extends an offer on a reviewed or interviewed application. Making
+// an offer on a submitted application marks it as reviewed.
+func (s *offerService) CreateOffer(ctx context.Context, applicationID, employerID string, req dto.CreateOfferRequest) (*dto.OfferResponse, error) {
+	application, err := s.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.jobRepo.GetByID(ctx, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.EmployerID != employerID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to make an offer on this application", nil)
+	}
+	if job.Status != jobstatus.StatusPosted || job.HiredStudentID != nil {
+		return nil, apperrors.NewAppError(409, "This job is no longer hiring", nil)
+	}
+
+	switch application.Status {
+	case appstatus.StatusSubmitted, appstatus.StatusReviewed, appstatus.StatusInterviewed:
+	default:
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't make an offer on a %s application", application.Status), nil)
+	}
+
+	open, err := s.offerRepo.HasOpenOffer(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, apperrors.NewAppError(409, "This application already has an open offer; revise or withdraw it instead", nil)
+	}
+
+	now := time.Now().UTC()
+	pay, err := offers.ResolvePay(job.Salary, req.PayAmount, req.Currency, req.Period)
+	if err != nil {
+		return nil, err
+	}
+	startDate, endDate := req.StartDate, req.EndDate
+	if startDate == nil {
+		startDate = job.StartDate
+	}
+	if endDate == nil {
+		endDate = job.EndDate
+	}
+	if err := offers.ValidateDates(startDate, endDate); err != nil {
+		return nil, err
+	}
+	expiresAt, err := offers.ResolveExpiry(req.ExpiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status == appstatus.StatusSubmitted {
+		if err := transitionApplication(ctx, s.applicationRepo, application, appstatus.StatusReviewed, appstatus.ActorEmployer, employerID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	offer := &models.Offer{
+		ApplicationID: application.ID,
+		JobID:         job.ID,
+		EmployerID:    employerID,
+		StudentID:     application.UserID,
+		Status:        models.OfferExtended,
+		Terms:         strings.TrimSpace(req.Terms),
+		Pay:           pay,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.offerRepo.Create(ctx, offer); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, offer.StudentID, offer.ID, "Job Offer",
+		fmt.Sprintf("You've received an offer for '%s'. Please answer by %s.", job.Title, offer.ExpiresAt.Format(time.RFC1123)),
+		models.NotificationTypeSuccess)
+
+	return offerToResponse(offer), nil
+}
+
+func (s *offerService) GetOffer(ctx context.Context, offerID, userID string) (*dto.OfferResponse, error) {
+	offer, err := s.getForParticipant(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return offerToResponse(offer), nil
+}
+
+func (s *offerService) ListOffers(ctx context.Context, userID, applicationID string) ([]*dto.OfferResponse, error) {
+	list, err := s.offerRepo.ListByUser(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	responses := make([]*dto.OfferResponse, len(list))
+	for i, offer := range list {
+		s.expireIfDue(ctx, offer, now)
+		responses[i] = offerToResponse(offer)
+	}
+	return responses, nil
+}
+
+// UpdateOffer revises an open offer's terms and sends it back to the student
+// with a fresh expiry
+func (s *offerService) UpdateOffer(ctx context.Context, offerID, employerID string, req dto.UpdateOfferRequest) (*dto.OfferResponse, error) {
+	offer, err := s.getForParticipant(ctx, offerID, employerID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.EmployerID != employerID {
+		return nil, apperrors.NewAppError(403, "Only the employer can revise an offer", nil)
+	}
+	if !offers.IsOpen(offer.Status) {
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't revise a %s offer", offer.Status), nil)
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, offer.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if req.Terms != nil {
+		offer.Terms = strings.TrimSpace(*req.Terms)
+	}
+	if req.PayAmount != nil {
+		pay, err := offers.ResolvePay(job.Salary, req.PayAmount, offer.Pay.Currency, offer.Pay.Period)
+		if err != nil {
+			return nil, err
+		}
+		offer.Pay = pay
+	}
+	if req.StartDate != nil {
+		offer.StartDate = req.StartDate
+	}
+	if req.EndDate != nil {
+		offer.EndDate = req.EndDate
+	}
+	if err := offers.ValidateDates(offer.StartDate, offer.EndDate); err != nil {
+		return nil, err
+	}
+	expiresAt, err := offers.ResolveExpiry(req.ExpiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	from := offer.Status
+	offer.ExpiresAt = expiresAt
+	offer.Status = models.OfferExtended
+	offer.Revision++
+	if err := s.offerRepo.Update(ctx, offer, from); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, offer.StudentID, offer.ID, "Offer Updated",
+		fmt.Sprintf("The employer revised their offer for '%s'. Please take another look.", job.Title),
+		models.NotificationTypeInfo)
+
+	return offerToResponse(offer), nil
+}
+
+func (s *offerService) WithdrawOffer(ctx context.Context, offerID, employerID string, req dto.OfferReasonRequest) (*dto.OfferResponse, error) {
+	offer, err := s.getForParticipant(ctx, offerID, employerID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.EmployerID != employerID {
+		return nil, apperrors.NewAppError(403, "Only the employer can withdraw an offer", nil)
+	}
+	if err := s.close(ctx, offer, models.OfferWithdrawn, req.Reason); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, offer.StudentID, offer.ID, "Offer Withdrawn",
+		withReason("The employer withdrew their offer.", req.Reason),
+		models.NotificationTypeWarning)
+
+	return offerToResponse(offer), nil
+}
+
+// AcceptOffer hires the student. The job is filled and the student's application
+// accepted; the job's other applications are closed with a courtesy message and
+// its other offers withdrawn, all in one transaction.
+func (s *offerService) AcceptOffer(ctx context.Context, offerID, studentID string) (*dto.OfferResponse, error) {
+	offer, err := s.getForParticipant(ctx, offerID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.StudentID != studentID {
+		return nil, apperrors.NewAppError(403, "Only the applicant can accept an offer", nil)
+	}
+	switch offer.Status {
+	case models.OfferExtended:
+	case models.OfferCountered:
+		return nil, apperrors.NewAppError(409, "You countered this offer; wait for the employer to answer", nil)
+	default:
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't accept a %s offer", offer.Status), nil)
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, offer.JobID)
+	if err != nil {
+		return nil, err
+	}
+	application, err := s.applicationRepo.GetByID(ctx, offer.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	jobFrom := job.Status
+	job.HiredStudentID = &offer.StudentID
+	jobEntry, err := jobstatus.Transition(job, jobstatus.StatusHired, jobstatus.ActorSystem, "", "Offer accepted", now)
+	if err != nil {
+		return nil, apperrors.NewAppError(409, "This position has already been filled or closed", nil)
+	}
+	filled := repository.JobChange{Job: job, FromStatus: jobFrom, Entry: jobEntry}
+
+	hiredFrom := application.Status
+	hiredEntry, err := appstatus.Transition(application, appstatus.StatusAccepted, appstatus.ActorSystem, "", "Offer accepted", now)
+	if err != nil {
+		return nil, err
+	}
+	hired := repository.ApplicationChange{Application: application, FromStatus: hiredFrom, Entry: hiredEntry}
+
+	others, err := s.closingChanges(ctx, job, application.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	acceptance, err := s.offerRepo.Accept(ctx, offer, filled, hired, others, now)
+	if err != nil {
+		return nil, err
+	}
+	s.events.Emit(ctx, jobstatus.NewEvent(job, jobEntry))
+
+	studentName := "The applicant"
+	if student, err := s.userRepo.GetByID(ctx, studentID); err == nil && student.Name != "" {
+		studentName = student.Name
+	}
+	s.notify(ctx, studentID, offer.ID, "You're Hired!",
+		fmt.Sprintf("You accepted the offer for '%s'. Congratulations!", job.Title),
+		models.NotificationTypeSuccess)
+	s.notify(ctx, offer.EmployerID, offer.ID, "Offer Accepted",
+		fmt.Sprintf("%s accepted your offer for '%s'. The job is now filled.", studentName, job.Title),
+		models.NotificationTypeSuccess)
+	if s.notifications != nil {
+		for _, closed := range acceptance.Closed {
+			_ = s.notifications.CreateApplicationUpdateNotification(ctx, closed.UserID, closed.ID, "Position Filled",
+				closed.EmployerFeedback, models.NotificationTypeInfo)
+		}
+	}
+	for _, withdrawn := range acceptance.Withdrawn {
+		s.notify(ctx, withdrawn.StudentID, withdrawn.ID, "Offer Withdrawn",
+			fmt.Sprintf("The position '%s' has been filled, so your offer was withdrawn.", job.Title),
+			models.NotificationTypeInfo)
+	}
+
+	response := offerToResponse(offer)
+	response.ClosedApplications = len(acceptance.Closed)
+	response.WithdrawnOffers = len(acceptance.Withdrawn)
+	return response, nil
+}
+
+func (s *offerService) DeclineOffer(ctx context.Context, offerID, studentID string, req dto.OfferReasonRequest) (*dto.OfferResponse, error) {
+	offer, err := s.getForParticipant(ctx, offerID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.StudentID != studentID {
+		return nil, apperrors.NewAppError(403, "Only the applicant can decline an offer", nil)
+	}
+	if err := s.close(ctx, offer, models.OfferDeclined, req.Reason); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, offer.EmployerID, offer.ID, "Offer Declined",
+		withReason("The applicant declined your offer.", req.Reason),
+		models.NotificationTypeWarning)
+
+	return offerToResponse(offer), nil
+}
+
+// CounterOffer proposes other pay, dates or terms. The offer waits for the
+// employer to revise or withdraw it.
+func (s *offerService) CounterOffer(ctx context.Context, offerID, studentID string, req dto.CounterOfferRequest) (*dto.OfferResponse, error) {
+	offer, err := s.getForParticipant(ctx, offerID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.StudentID != studentID {
+		return nil, apperrors.NewAppError(403, "Only the applicant can counter an offer", nil)
+	}
+	if offer.Status != models.OfferExtended {
+		return nil, apperrors.NewAppError(409, fmt.Sprintf("Can't counter a %s offer", offer.Status), nil)
+	}
+
+	now := time.Now().UTC()
+	counter := models.OfferCounter{
+		PayAmount:  req.PayAmount,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Message:    strings.TrimSpace(req.Message),
+		ProposedAt: &now,
+	}
+	if err := offers.ValidateCounter(counter); err != nil {
+		return nil, err
+	}
+	startDate, endDate := offer.StartDate, offer.EndDate
+	if counter.StartDate != nil {
+		startDate = counter.StartDate
+	}
+	if counter.EndDate != nil {
+		endDate = counter.EndDate
+	}
+	if err := offers.ValidateDates(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	offer.Counter = counter
+	offer.Status = models.OfferCountered
+	if err := s.offerRepo.Update(ctx, offer, models.OfferExtended); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, offer.EmployerID, offer.ID, "Counter Offer",
+		"The applicant proposed different terms for your offer. Revise or withdraw it to answer.",
+		models.NotificationTypeInfo)
+
+	return offerToResponse(offer), nil
+}
+
+// Helper methods
+
+// closingChanges rejects the job's other open applications with a courtesy
+// message, for the acceptance to write
+func (s *offerService) closingChanges(ctx context.Context, job *models.Job, hiredApplicationID string, now time.Time) ([]repository.ApplicationChange, error) {
+	applications, err := s.applicationRepo.GetByJobAndIDs(ctx, job.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]repository.ApplicationChange, 0, len(applications))
+	for _, application := range applications {
+		if application.ID == hiredApplicationID || appstatus.IsTerminal(application.Status) {
+			continue
+		}
+		name := ""
+		if user, err := s.userRepo.GetByID(ctx, application.UserID); err == nil {
+			name = user.Name
+		}
+		from := application.Status
+		application.EmployerFeedback = appstatus.RenderFeedback(appstatus.PositionFilledFeedback,
+			appstatus.FeedbackValues(name, job.Title, job.Company))
+		entry, err := appstatus.Transition(application, appstatus.StatusRejected, appstatus.ActorSystem, "", "Position filled", now)
+		if err != nil {
+			continue
+		}
+		changes = append(changes, repository.ApplicationChange{Application: application, FromStatus: from, Entry: entry})
+	}
+	return changes, nil
+}
+
+// close ends an open offer with a decline or withdrawal
+func (s *offerService) close(ctx context.Context, offer *models.Offer, status, reason string) error {
+	if !offers.IsOpen(offer.Status) {
+		return apperrors.NewAppError(409, fmt.Sprintf("The offer is already %s", offer.Status), nil)
+	}
+	from := offer.Status
+	now := time.Now().UTC()
+	offer.Status = status
+	offer.Reason = strings.TrimSpace(reason)
+	offer.RespondedAt = &now
+	return s.offerRepo.Update(ctx, offer, from)
+}
+
+// getForParticipant loads an offer for its student or employer, marking it
+// expired if it ran out unanswered
+func (s *offerService) getForParticipant(ctx context.Context, offerID, userID string) (*models.Offer, error) {
+	offer, err := s.offerRepo.GetByID(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.StudentID != userID && offer.EmployerID != userID {
+		return nil, apperrors.NewAppError(403, "You don't have permission to access this offer", nil)
+	}
+	s.expireIfDue(ctx, offer, time.Now().UTC())
+	return offer, nil
+}
+
+// expireIfDue moves an open offer past its expiry to expired; a failed write
+// only delays it to the next read
+func (s *offerService) expireIfDue(ctx context.Context, offer *models.Offer, now time.Time) {
+	if !offers.IsExpired(offer, now) {
+		return
+	}
+	from := offer.Status
+	offer.Status = models.OfferExpired
+	_ = s.offerRepo.Update(ctx, offer, from)
+}
+
+// notify sends an offer notification; failures don't fail the offer change
+func (s *offerService) notify(ctx context.Context, userID, offerID, title, message string, notificationType models.NotificationType) {
+	if s.notifications == nil {
+		return
+	}
+	_ = s.notifications.CreateOfferNotification(ctx, userID, offerID, title, message, notificationType)
+}
+
+func offerToResponse(offer *models.Offer) *dto.OfferResponse {
+	response := &dto.OfferResponse{
+		ID:            offer.ID,
+		ApplicationID: offer.ApplicationID,
+		JobID:         offer.JobID,
+		EmployerID:    offer.EmployerID,
+		StudentID:     offer.StudentID,
+		Status:        offer.Status,
+		Terms:         offer.Terms,
+		Pay:           offer.Pay,
+		StartDate:     offer.StartDate,
+		EndDate:       offer.EndDate,
+		ExpiresAt:     offer.ExpiresAt,
+		Revision:      offer.Revision,
+		Reason:        offer.Reason,
+		RespondedAt:   offer.RespondedAt,
+		CreatedAt:     offer.CreatedAt,
+		UpdatedAt:     offer.UpdatedAt,
+	}
+	if !offer.Counter.IsEmpty() {
+		counter := offer.Counter
+		response.Counter = &counter
+	}
+	return response
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"microbridge/backend/internal/core/resume"
+	"microbridge/backend/internal/core/skills"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+const (
+	MaxResumeFileSize  = 5 << 20 // PDF uploads
+	MaxResumeTextSize  = 200000  // Pasted or extracted text
+	maxConfirmedSkills = 50
+)
+
+type ResumeService interface {
+	ParseResume(ctx context.Context, userID string, content []byte, format string) (*resume.ParsedResume, error)
+	ConfirmSkills(ctx context.Context, userID string, req dto.ConfirmResumeSkillsRequest) (models.SkillsArray, error)
+}
+
+type resumeService struct {
+	userRepo   repository.UserRepository
+	parser     *resume.Parser
+	dictionary *skills.Dictionary
+	features   FeatureInvalidator
+}
+
+func NewResumeService(userRepo repository.UserRepository, dictionary *skills.Dictionary, features FeatureInvalidator) ResumeService {
+	return &resumeService{
+		userRepo:   userRepo,
+		parser:     resume.NewParser(dictionary),
+		dictionary: dictionary,
+		features:   features,
+	}
+}
+
+// ParseResume extracts skill proposals, education and work history. Nothing is
+// saved until the student confirms the skills they want on their profile.
+func (s *resumeService) ParseResume(ctx context.Context, userID string, content []byte, format string) (*resume.ParsedResume, error) {
+	limit := MaxResumeTextSize
+	if format == resume.FormatPDF {
+		limit = MaxResumeFileSize
+	}
+	if len(content) == 0 {
+		return nil, apperrors.NewValidationError("resume content is empty")
+	}
+	if len(content) > limit {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("resume exceeds the %d byte limit", limit))
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := s.parser.Parse(content, format)
+	if err != nil {
+		switch {
+		case errors.Is(err, resume.ErrUnsupportedFormat), errors.Is(err, resume.ErrNotPDF):
+			return nil, apperrors.NewAppError(http.StatusBadRequest, err.Error(), err)
+		case errors.Is(err, resume.ErrNoPDFText):
+			return nil, apperrors.NewAppError(http.StatusUnprocessableEntity, err.Error(), err)
+		}
+		return nil, fmt.Errorf("failed to parse resume: %w", err)
+	}
+
+	existing := make(map[string]bool, len(user.Skills))
+	for _, skill := range user.Skills {
+		existing[strings.ToLower(s.dictionary.Canonical(skill.Name))] = true
+	}
+	for _, proposal := range parsed.Skills {
+		proposal.AlreadyInProfile = existing[strings.ToLower(proposal.Name)]
+	}
+
+	return parsed, nil
+}
+
+// ConfirmSkills merges the confirmed skills into the profile. Existing skills are
+// updated in place; raising a verified skill's level clears its verification.
+func (s *resumeService) ConfirmSkills(ctx context.Context, userID string, req dto.ConfirmResumeSkillsRequest) (models.SkillsArray, error) {
+	if err := s.validateConfirmRequest(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(user.Skills))
+	for i, skill := range user.Skills {
+		index[strings.ToLower(s.dictionary.Canonical(skill.Name))] = i
+	}
+
+	for _, confirmed := range req.Skills {
+		name := s.dictionary.Canonical(confirmed.Name)
+		key := strings.ToLower(name)
+
+		if i, exists := index[key]; exists {
+			skill := &user.Skills[i]
+			if confirmed.Level > skill.Level {
+				skill.Verified = false
+			}
+			skill.Name = name
+			skill.Level = confirmed.Level
+			if confirmed.Experience != "" {
+				skill.Experience = confirmed.Experience
+			}
+			continue
+		}
+
+		user.Skills = append(user.Skills, models.UserSkill{
+			Name:       name,
+			Level:      confirmed.Level,
+			Experience: confirmed.Experience,
+		})
+		index[key] = len(user.Skills) - 1
+	}
+
+	if req.ResumeText != "" {
+		user.Resume = req.ResumeText
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	invalidateUserFeatures(ctx, s.features, user.ID)
+
+	return user.Skills, nil
+}
+
+func (s *resumeService) validateConfirmRequest(req dto.ConfirmResumeSkillsRequest) error {
+	if len(req.Skills) == 0 {
+		return apperrors.NewValidationError("at least one skill is required")
+	}
+	if len(req.Skills) > maxConfirmedSkills {
+		return apperrors.NewValidationError(fmt.Sprintf("at most %d skills can be confirmed at once", maxConfirmedSkills))
+	}
+	if len(req.ResumeText) > MaxResumeTextSize {
+		return apperrors.NewValidationError("resume text is too long")
+	}
+	for _, skill := range req.Skills {
+		if strings.TrimSpace(skill.Name) == "" || len(skill.Name) > 100 {
+			return apperrors.NewValidationError("skill name must be 1-100 characters")
+		}
+		if skill.Level < 1 || skill.Level > 5 {
+			return apperrors.NewValidationError(fmt.Sprintf("skill %s level must be between 1 and 5", skill.Name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	jobstatus "microbridge/backend/internal/core/jobs"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// reviewPeriod is how long both parties have to review a completed job
+	reviewPeriod = 14 * 24 * time.Hour
+	// reviewEditWindow is how long a hidden review may still be edited or withdrawn
+	reviewEditWindow = 24 * time.Hour
+)
+
+// ReviewService handles double-blind reviews between students and employers
+type ReviewService interface {
+	CreateReview(ctx context.Context, reviewerID string, req dto.CreateReviewRequest) (*dto.ReviewResponse, error)
+	GetReview(ctx context.Context, reviewID, requesterID string) (*dto.ReviewResponse, error)
+	UpdateReview(ctx context.Context, reviewID, reviewerID string, req dto.UpdateReviewRequest) (*dto.ReviewResponse, error)
+	DeleteReview(ctx context.Context, reviewID, reviewerID string) error
+	GetUserReviews(ctx context.Context, userID string, page, limit int) (*dto.UserReviewsResponse, error)
+	GetJobReviews(ctx context.Context, jobID string) ([]dto.ReviewResponse, error)
+	CompleteJob(ctx context.Context, jobID, userID string) (*dto.JobCompletionResponse, error)
+}
+
+type reviewService struct {
+	reviewRepo repository.ReviewRepository
+	userRepo   repository.UserRepository
+	jobRepo    repository.JobRepository
+	events     *jobstatus.Emitter
+}
+
+func NewReviewService(
+	reviewRepo repository.ReviewRepository,
+	userRepo repository.UserRepository,
+	jobRepo repository.JobRepository,
+	events *jobstatus.Emitter,
+) ReviewService {
+	return &reviewService{
+		reviewRepo: reviewRepo,
+		userRepo:   userRepo,
+		jobRepo:    jobRepo,
+		events:     events,
+	}
+}
+
+// CreateReview records a review of the other party to a job in its review period.
+// Reviews stay hidden until both parties have reviewed or the period ends.
+func (s *reviewService) CreateReview(ctx context.Context, reviewerID string, req dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	if err := validateReviewContent(req.Rating, req.Comment); err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != jobstatus.StatusReviewPending {
+		return nil, apperrors.NewAppError(400, "Job is not in its review period", nil)
+	}
+
+	counterpart, ok := reviewCounterpart(job, reviewerID)
+	if !ok {
+		return nil, apperrors.NewForbiddenError("You were not part of this job")
+	}
+	if req.RevieweeID != counterpart {
+		return nil, apperrors.NewValidationError("reviewee must be the other party to the job")
+	}
+
+	existing, err := s.reviewRepo.GetByReviewerAndJob(ctx, reviewerID, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.NewAppError(409, "You have already reviewed this job", nil)
+	}
+
+	now := time.Now()
+	review := &models.Review{
+		ID:              uuid.New().String(),
+		ReviewerID:      reviewerID,
+		RevieweeID:      req.RevieweeID,
+		JobID:           req.JobID,
+		Rating:          req.Rating,
+		Comment:         strings.TrimSpace(req.Comment),
+		CategoryRatings: models.CategoryRatings(req.CategoryRatings),
+		Anonymous:       req.Anonymous,
+		IsVisible:       false,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	// Both reviews in: reveal them and close the job
+	if err := s.reviewRepo.UpdateReviewVisibility(ctx, job.ID); err != nil {
+		return nil, err
+	}
+	counterReview, err := s.reviewRepo.GetByReviewerAndJob(ctx, counterpart, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if counterReview != nil {
+		if err := transitionJob(ctx, s.jobRepo, s.events, job, jobstatus.StatusCompleted, jobstatus.ActorSystem, "", "Both parties reviewed"); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetReview(ctx, review.ID, reviewerID)
+}
+
+// GetReview returns a review; hidden reviews are only shown to their author
+func (s *reviewService) GetReview(ctx context.Context, reviewID, requesterID string) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if !review.IsVisible && review.ReviewerID != requesterID {
+		return nil, apperrors.ErrReviewNotFound
+	}
+
+	response := reviewToResponse(review)
+	return &response, nil
+}
+
+// UpdateReview edits a review while it is hidden and inside the edit window
+func (s *reviewService) UpdateReview(ctx context.Context, reviewID, reviewerID string, req dto.UpdateReviewRequest) (*dto.ReviewResponse, error) {
+	review, err := s.editableReview(ctx, reviewID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateReviewContent(req.Rating, req.Comment); err != nil {
+		return nil, err
+	}
+
+	review.Rating = req.Rating
+	review.Comment = strings.TrimSpace(req.Comment)
+	review.CategoryRatings = models.CategoryRatings(req.CategoryRatings)
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+
+	response := reviewToResponse(review)
+	return &response, nil
+}
+
+// DeleteReview withdraws a review while it is hidden and inside the edit window
+func (s *reviewService) DeleteReview(ctx context.Context, reviewID, reviewerID string) error {
+	if _, err := s.editableReview(ctx, reviewID, reviewerID); err != nil {
+		return err
+	}
+	return s.reviewRepo.Delete(ctx, reviewID)
+}
+
+// GetUserReviews returns the visible reviews a user has received
+func (s *reviewService) GetUserReviews(ctx context.Context, userID string, page, limit int) (*dto.UserReviewsResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	reviews, _, err := s.reviewRepo.GetByUserID(ctx, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.reviewRepo.GetUserReviewStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = reviewToResponse(review)
+	}
+
+	return &dto.UserReviewsResponse{
+		UserID:        user.ID,
+		UserName:      user.Name,
+		UserType:      user.UserType,
+		AverageRating: stats.AverageRating,
+		TotalReviews:  int(stats.TotalReviews),
+		Reviews:       responses,
+		RatingBreakdown: dto.RatingBreakdown{
+			FiveStar:  int(stats.FiveStarCount),
+			FourStar:  int(stats.FourStarCount),
+			ThreeStar: int(stats.ThreeStarCount),
+			TwoStar:   int(stats.TwoStarCount),
+			OneStar:   int(stats.OneStarCount),
+		},
+		Badges: []string{},
+	}, nil
+}
+
+// GetJobReviews returns the visible reviews left for a job
+func (s *reviewService) GetJobReviews(ctx context.Context, jobID string) ([]dto.ReviewResponse, error) {
+	reviews, err := s.reviewRepo.GetByJobID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		if review.IsVisible {
+			responses = append(responses, reviewToResponse(review))
+		}
+	}
+	return responses, nil
+}
+
+// CompleteJob marks in-progress or submitted work as done and opens the review period
+func (s *reviewService) CompleteJob(ctx context.Context, jobID, userID string) (*dto.JobCompletionResponse, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := reviewCounterpart(job, userID); !ok {
+		return nil, apperrors.NewForbiddenError("You were not part of this job")
+	}
+	if job.Status != jobstatus.StatusInProgress && job.Status != jobstatus.StatusSubmitted {
+		return nil, apperrors.NewAppError(400, "Job is not in a completable state", nil)
+	}
+	actor := jobstatus.ActorStudent
+	if userID == job.EmployerID {
+		actor = jobstatus.ActorEmployer
+	}
+
+	now := time.Now()
+	dueDate := now.Add(reviewPeriod)
+	job.CompletedAt = &now
+	job.ReviewDueDate = &dueDate
+	if err := transitionJob(ctx, s.jobRepo, s.events, job, jobstatus.StatusReviewPending, actor, userID, "Work completed"); err != nil {
+		return nil, err
+	}
+
+	return &dto.JobCompletionResponse{
+		JobID:          job.ID,
+		Status:         job.Status,
+		RequiresReview: true,
+		ReviewDueDate:  dueDate.Format(time.RFC3339),
+		Message:        "Job completed; both parties can now leave a review",
+	}, nil
+}
+
+// Helper methods
+
+func (s *reviewService) editableReview(ctx context.Context, reviewID, reviewerID string) (*models.Review, error) {
+	review, err := s.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.ReviewerID != reviewerID {
+		return nil, apperrors.NewForbiddenError("You can only change your own reviews")
+	}
+	if review.IsVisible {
+		return nil, apperrors.NewAppError(409, "Visible reviews can no longer be changed", nil)
+	}
+	if time.Since(review.CreatedAt) > reviewEditWindow {
+		return nil, apperrors.NewAppError(409, "The review edit window has expired", nil)
+	}
+	return review, nil
+}
+
+// reviewCounterpart returns the party the user reviews on a job, if the user took part in it
+func reviewCounterpart(job *models.Job, userID string) (string, bool) {
+	if job.HiredStudentID == nil || *job.HiredStudentID == "" {
+		return "", false
+	}
+	switch userID {
+	case job.EmployerID:
+		return *job.HiredStudentID, true
+	case *job.HiredStudentID:
+		return job.EmployerID, true
+	}
+	return "", false
+}
+
+func validateReviewContent(rating int, comment string) error {
+	if rating < 1 || rating > 5 {
+		return apperrors.NewValidationError("rating must be between 1 and 5")
+	}
+	if length := len(strings.TrimSpace(comment)); length < 10 || length > 1000 {
+		return apperrors.NewValidationError("comment must be between 10 and 1000 characters")
+	}
+	return nil
+}
+
+func reviewToResponse(review *models.Review) dto.ReviewResponse {
+	response := dto.ConvertReviewToResponse(review)
+	if review.Anonymous {
+		response.ReviewerID = ""
+		response.Reviewer = dto.UserSummary{Name: "Anonymous", UserType: review.Reviewer.UserType}
+	}
+	return response
+}
@@ -8,9 +8,12 @@ import (
 	"time"
 
 	aiModels "microbridge/backend/internal/ai/models"
-	"microbridge/backend/internal/models"
+	aiServices "microbridge/backend/internal/ai/services"
+	"microbridge/backend/internal/core/behavior"
 	"microbridge/backend/internal/repository"
 	"microbridge/backend/internal/shared/cache"
+
+	"github.com/google/uuid"
 )
 
 // UserBehaviorService handles tracking and analyzing user behavior for improved recommendations
@@ -33,7 +36,7 @@ type UserBehaviorService interface {
 	AnalyzeBehaviorTrends(ctx context.Context, userID string, days int) (*BehaviorTrends, error)
 	
 	// Integration with matching service
-	EnrichRecommendations(ctx context.Context, userID string, recommendations []*HybridMatchResult) ([]*EnrichedRecommendation, error)
+	EnrichRecommendations(ctx context.Context, userID string, recommendations []*aiServices.HybridMatchResult) ([]*EnrichedRecommendation, error)
 }
 
 // RecommendationContext provides behavioral context for AI recommendation systems
@@ -48,6 +51,7 @@ type RecommendationContext struct {
 	PreferenceSignals    map[string]float64     `json:"preference_signals"`     // Various preference indicators
 	EngagementMetrics    EngagementMetrics      `json:"engagement_metrics"`
 	TimestampedActions   []TimestampedAction    `json:"timestamped_actions"`    // Chronological action history
+	RecentSessions       []repository.UserSession `json:"recent_sessions"`     // Sessions in the last 30 days
 	LastUpdated          time.Time              `json:"last_updated"`
 }
 
@@ -88,6 +92,7 @@ type BehaviorTrends struct {
 	PreferenceShifts      []PreferenceShift   `json:"preference_shifts"`
 	EngagementEvolution   []EngagementPoint   `json:"engagement_evolution"`
 	RecommendationImpact  RecommendationImpact `json:"recommendation_impact"`
+	JourneyProgression    string              `json:"journey_progression"` // "explorer", "focused", "decisive", "inactive"
 }
 
 type ActivityTrend struct {
@@ -110,6 +115,8 @@ type PreferenceShift struct {
 	PreviousValue   float64 `json:"previous_value"`
 	CurrentValue    float64 `json:"current_value"`
 	Confidence      float64 `json:"confidence"`
+	DetectedAt      time.Time `json:"detected_at"`
+	Impact          string  `json:"impact"` // "high", "medium", "low"
 }
 
 type EngagementPoint struct {
@@ -117,6 +124,7 @@ type EngagementPoint struct {
 	EngagementScore  float64   `json:"engagement_score"`
 	SessionDuration  time.Duration `json:"session_duration"`
 	ActionsPerSession int      `json:"actions_per_session"`
+	QualityScore     float64   `json:"quality_score"`
 }
 
 type RecommendationImpact struct {
@@ -127,7 +135,7 @@ type RecommendationImpact struct {
 
 // EnrichedRecommendation extends HybridMatchResult with behavioral insights
 type EnrichedRecommendation struct {
-	*HybridMatchResult
+	*aiServices.HybridMatchResult
 	BehavioralScore      float64            `json:"behavioral_score"`
 	PersonalizationLevel string             `json:"personalization_level"` // "high", "medium", "low"
 	ReasoningContext     []string           `json:"reasoning_context"`
@@ -149,7 +157,8 @@ type userBehaviorService struct {
 	userRepo         repository.UserRepository
 	jobRepo          repository.JobRepository
 	cacheService     cache.CacheService
-	hybridMatcher    *HybridMatchingService // For integration
+	hybridMatcher    *aiServices.HybridMatchingService // For integration
+	events           *behavior.Pipeline     // Buffers tracked actions; derived updates run asynchronously
 }
 
 func NewUserBehaviorService(
@@ -157,7 +166,8 @@ func NewUserBehaviorService(
 	userRepo repository.UserRepository,
 	jobRepo repository.JobRepository,
 	cacheService cache.CacheService,
-	hybridMatcher *HybridMatchingService,
+	hybridMatcher *aiServices.HybridMatchingService,
+	events *behavior.Pipeline,
 ) UserBehaviorService {
 	return &userBehaviorService{
 		behaviorRepo:  behaviorRepo,
@@ -165,34 +175,28 @@ func NewUserBehaviorService(
 		jobRepo:       jobRepo,
 		cacheService:  cacheService,
 		hybridMatcher: hybridMatcher,
+		events:        events,
 	}
 }
 
 // TrackJobView records when a user views a job posting
 func (s *userBehaviorService) TrackJobView(ctx context.Context, userID, jobID string, timeSpent time.Duration) error {
 	action := TimestampedAction{
-		Type:      "view",
-		JobID:     jobID,
-		Data:      map[string]interface{}{"time_spent": timeSpent.Seconds()},
+		Type:  "view",
+		JobID: jobID,
+		Data: map[string]interface{}{
+			"time_spent":       timeSpent.Seconds(),
+			"engagement_score": s.calculateEngagementScore(timeSpent),
+		},
 		Timestamp: time.Now(),
 	}
 	
-	// Update behavioral context
+	// Engagement, skill interests and model feedback are updated asynchronously
+	// by the ingestion pipeline
 	if err := s.updateBehavioralContext(ctx, userID, action); err != nil {
 		return fmt.Errorf("failed to update behavioral context: %w", err)
 	}
 	
-	// Learn from engagement time
-	engagementScore := s.calculateEngagementScore(timeSpent)
-	if err := s.updateEngagementMetrics(ctx, userID, "view", engagementScore); err != nil {
-		return fmt.Errorf("failed to update engagement metrics: %w", err)
-	}
-	
-	// Update skill interests based on job skills
-	if err := s.inferSkillInterests(ctx, userID, jobID, "view", engagementScore); err != nil {
-		return fmt.Errorf("failed to infer skill interests: %w", err)
-	}
-	
 	return nil
 }
 
@@ -205,28 +209,11 @@ func (s *userBehaviorService) TrackJobApplication(ctx context.Context, userID, j
 		Timestamp: time.Now(),
 	}
 	
+	// The RL model and the exploration bandit learn from the stored action
 	if err := s.updateBehavioralContext(ctx, userID, action); err != nil {
 		return err
 	}
 	
-	// Applications are strong positive signals
-	if err := s.updateEngagementMetrics(ctx, userID, "application", 1.0); err != nil {
-		return err
-	}
-	
-	// Strong signal for skill interests
-	if err := s.inferSkillInterests(ctx, userID, jobID, "apply", 1.0); err != nil {
-		return err
-	}
-	
-	// Feed into RL system for learning
-	if s.hybridMatcher != nil {
-		if err := s.hybridMatcher.ProcessUserFeedback(ctx, userID, jobID, "", "applied", 0.9); err != nil {
-			// Log error but don't fail the tracking
-			fmt.Printf("Failed to process RL feedback: %v\n", err)
-		}
-	}
-	
 	return nil
 }
 
@@ -243,15 +230,6 @@ func (s *userBehaviorService) TrackJobSave(ctx context.Context, userID, jobID st
 		return err
 	}
 	
-	// Saves indicate moderate positive interest
-	if err := s.updateEngagementMetrics(ctx, userID, "save", 0.6); err != nil {
-		return err
-	}
-	
-	if err := s.inferSkillInterests(ctx, userID, jobID, "save", 0.6); err != nil {
-		return err
-	}
-	
 	return nil
 }
 
@@ -264,27 +242,11 @@ func (s *userBehaviorService) TrackJobDismiss(ctx context.Context, userID, jobID
 		Timestamp: time.Now(),
 	}
 	
+	// The RL model learns from the stored action as negative feedback
 	if err := s.updateBehavioralContext(ctx, userID, action); err != nil {
 		return err
 	}
 	
-	// Dismissals are negative signals
-	if err := s.updateEngagementMetrics(ctx, userID, "dismiss", -0.3); err != nil {
-		return err
-	}
-	
-	// Negative signal for skill interests
-	if err := s.inferSkillInterests(ctx, userID, jobID, "dismiss", -0.3); err != nil {
-		return err
-	}
-	
-	// Feed negative feedback into RL system
-	if s.hybridMatcher != nil {
-		if err := s.hybridMatcher.ProcessUserFeedback(ctx, userID, jobID, "", "dismissed", 0.1); err != nil {
-			fmt.Printf("Failed to process RL feedback: %v\n", err)
-		}
-	}
-	
 	return nil
 }
 
@@ -299,12 +261,7 @@ func (s *userBehaviorService) TrackSearchQuery(ctx context.Context, userID, quer
 		Timestamp: time.Now(),
 	}
 	
-	if err := s.updateBehavioralContext(ctx, userID, action); err != nil {
-		return err
-	}
-	
-	// Learn preferences from search filters
-	return s.learnFromSearchBehavior(ctx, userID, query, filters)
+	return s.updateBehavioralContext(ctx, userID, action)
 }
 
 // TrackSkillInterest records explicit skill interest signals
@@ -321,12 +278,8 @@ func (s *userBehaviorService) TrackSkillInterest(ctx context.Context, userID, sk
 		Timestamp: time.Now(),
 	}
 	
-	if err := s.updateBehavioralContext(ctx, userID, action); err != nil {
-		return err
-	}
-	
-	// Update skill interest scores
-	return s.updateSkillInterest(ctx, userID, skillName, score)
+	// Skill interest scores are updated asynchronously by the ingestion pipeline
+	return s.updateBehavioralContext(ctx, userID, action)
 }
 
 // GetUserBehaviorPattern returns the behavioral pattern for ML training
@@ -384,18 +337,33 @@ func (s *userBehaviorService) GetRecommendationContext(ctx context.Context, user
 // Private helper methods
 
 func (s *userBehaviorService) updateBehavioralContext(ctx context.Context, userID string, action TimestampedAction) error {
-	// Implementation would update the user's behavioral context in the database
-	// This is a simplified version - in production you'd want to handle this more efficiently
-	
 	// Invalidate cache
 	cacheKey := fmt.Sprintf("behavior_context:%s", userID)
 	s.cacheService.Delete(ctx, cacheKey)
 	
-	// In a real implementation, you would:
-	// 1. Store the action in a time-series database or behavioral log
-	// 2. Update aggregated behavioral metrics
-	// 3. Trigger background processing for pattern recognition
+	// Hand the action to the ingestion pipeline, which stores it in batches
+	// and updates engagement and skill interests off the request path
+	event := behavior.Event{
+		IdempotencyKey: uuid.New().String(),
+		Type:           action.Type,
+		EntityID:       action.JobID,
+		Data:           action.Data,
+		OccurredAt:     action.Timestamp,
+	}
+	if action.Type == behavior.EventSkillInterest {
+		event.EntityID, _ = action.Data["skill"].(string)
+	}
 	
+	result, err := s.events.Submit(ctx, userID, []behavior.Event{event})
+	if err != nil {
+		return err
+	}
+	if len(result.Rejected) > 0 {
+		return fmt.Errorf("invalid %s action: %s", action.Type, result.Rejected[0].Reason)
+	}
+	if result.Shed > 0 {
+		return fmt.Errorf("behavior event buffer is full")
+	}
 	return nil
 }
 
@@ -409,25 +377,6 @@ func (s *userBehaviorService) calculateEngagementScore(timeSpent time.Duration)
 	return math.Max(0, math.Min(1, score))
 }
 
-func (s *userBehaviorService) updateEngagementMetrics(ctx context.Context, userID, actionType string, score float64) error {
-	// Update engagement metrics based on user action
-	// This would involve updating running averages, trend calculations, etc.
-	return nil
-}
-
-func (s *userBehaviorService) inferSkillInterests(ctx context.Context, userID, jobID, actionType string, engagementScore float64) error {
-	// Get job details to extract skills
-	// Update skill interest scores based on engagement
-	// This would require job repository integration
-	return nil
-}
-
-func (s *userBehaviorService) learnFromSearchBehavior(ctx context.Context, userID, query string, filters map[string]interface{}) error {
-	// Analyze search query and filters to update preferences
-	// Extract signals about remote work preference, salary importance, location preference, etc.
-	return nil
-}
-
 func (s *userBehaviorService) getInteractionScore(interactionType string) float64 {
 	switch interactionType {
 	case "click", "hover":
@@ -445,12 +394,6 @@ func (s *userBehaviorService) getInteractionScore(interactionType string) float6
 	}
 }
 
-func (s *userBehaviorService) updateSkillInterest(ctx context.Context, userID, skillName string, score float64) error {
-	// Update skill interest score using exponential moving average
-	// This would involve reading current score, applying learning rate, and storing
-	return nil
-}
-
 func (s *userBehaviorService) buildRecommendationContext(ctx context.Context, userID string) (*RecommendationContext, error) {
 	// Build comprehensive behavioral context from database
 	// This would involve multiple queries to gather behavioral data
@@ -469,6 +412,12 @@ func (s *userBehaviorService) buildRecommendationContext(ctx context.Context, us
 		LastUpdated:       time.Now(),
 	}
 	
+	sessions, err := s.behaviorRepo.GetUserSessions(ctx, userID, context.LastUpdated.AddDate(0, 0, -30), context.LastUpdated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+	context.RecentSessions = sessions
+	
 	return context, nil
 }
 
@@ -596,21 +545,21 @@ func (s *userBehaviorService) LearnFromFeedback(ctx context.Context, userID, job
 	}
 	
 	// Salary importance learning
-	if job.SalaryMin > 0 || job.SalaryMax > 0 {
-		avgSalary := float64(job.SalaryMin+job.SalaryMax) / 2
+	if job.Salary.Min > 0 || job.Salary.Max > 0 {
+		avgSalary := float64(job.Salary.Min+job.Salary.Max) / 2
 		if avgSalary > 75000 { // High salary threshold
 			preferences["salary_importance"] = outcome
 		}
 	}
 	
-	// Company size preference
-	if job.CompanySize != "" {
-		preferences[fmt.Sprintf("company_size_%s", job.CompanySize)] = outcome
+	// Job type preference
+	if job.JobType != "" {
+		preferences[fmt.Sprintf("job_type_%s", job.JobType)] = outcome
 	}
 	
-	// Industry preference
-	if job.Industry != "" {
-		preferences[fmt.Sprintf("industry_%s", job.Industry)] = outcome
+	// Category preference
+	if job.Category != "" {
+		preferences[fmt.Sprintf("category_%s", job.Category)] = outcome
 	}
 	
 	// Location preference
@@ -624,7 +573,7 @@ func (s *userBehaviorService) LearnFromFeedback(ctx context.Context, userID, job
 	}
 	
 	// Store the feedback action
-	action := repository.UserAction{
+	feedback := repository.UserAction{
 		UserID:     userID,
 		ActionType: "feedback",
 		EntityID:   jobID,
@@ -637,7 +586,7 @@ func (s *userBehaviorService) LearnFromFeedback(ctx context.Context, userID, job
 		Timestamp: time.Now(),
 	}
 	
-	return s.behaviorRepo.StoreUserAction(ctx, action)
+	return s.behaviorRepo.StoreUserAction(ctx, feedback)
 }
 
 func (s *userBehaviorService) AnalyzeBehaviorTrends(ctx context.Context, userID string, days int) (*BehaviorTrends, error) {
@@ -662,21 +611,26 @@ func (s *userBehaviorService) AnalyzeBehaviorTrends(ctx context.Context, userID
 		return nil, fmt.Errorf("failed to analyze preference shifts: %w", err)
 	}
 	
-	// Analyze engagement evolution
-	engagementEvolution := s.analyzeEngagementEvolution(actions, days)
+	// Analyze engagement evolution from the user's sessions
+	now := time.Now().UTC()
+	sessions, err := s.behaviorRepo.GetUserSessions(ctx, userID, now.AddDate(0, 0, -days), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+	engagementEvolution := s.analyzeEngagementEvolution(sessions)
 	
 	return &BehaviorTrends{
 		UserID:               userID,
-		TimeRangeDays:        days,
+		TimeRange:            days,
 		ActivityTrend:        activityTrend,
 		SkillInterestChanges: skillChanges,
 		PreferenceShifts:     preferenceShifts,
 		EngagementEvolution:  engagementEvolution,
-		JourneyProgression:   s.determineJourneyProgression(actions),
+		JourneyProgression:   s.determineJourneyProgression(sessions),
 	}, nil
 }
 
-func (s *userBehaviorService) EnrichRecommendations(ctx context.Context, userID string, recommendations []*HybridMatchResult) ([]*EnrichedRecommendation, error) {
+func (s *userBehaviorService) EnrichRecommendations(ctx context.Context, userID string, recommendations []*aiServices.HybridMatchResult) ([]*EnrichedRecommendation, error) {
 	// Get behavioral context
 	context, err := s.GetRecommendationContext(ctx, userID)
 	if err != nil {
@@ -815,56 +769,45 @@ func (s *userBehaviorService) analyzePreferenceShifts(ctx context.Context, userI
 	return shifts, nil
 }
 
-func (s *userBehaviorService) analyzeEngagementEvolution(actions []repository.UserAction, days int) []EngagementPoint {
-	// Group actions by day and calculate engagement scores
-	dailyEngagement := make(map[string][]repository.UserAction)
+func (s *userBehaviorService) analyzeEngagementEvolution(sessions []repository.UserSession) []EngagementPoint {
+	// Group sessions by the day they started and average them
+	dailySessions := make(map[string][]repository.UserSession)
+	var days []string
 	
-	for _, action := range actions {
-		day := action.Timestamp.Format("2006-01-02")
-		dailyEngagement[day] = append(dailyEngagement[day], action)
+	for _, session := range sessions {
+		day := session.StartedAt.Format("2006-01-02")
+		if _, seen := dailySessions[day]; !seen {
+			days = append(days, day)
+		}
+		dailySessions[day] = append(dailySessions[day], session)
 	}
 	
-	points := []EngagementPoint{}
+	points := make([]EngagementPoint, 0, len(days))
 	
-	for day, dayActions := range dailyEngagement {
+	for _, day := range days {
 		date, _ := time.Parse("2006-01-02", day)
+		daySessions := dailySessions[day]
 		
-		// Calculate engagement metrics for the day
-		totalActions := len(dayActions)
-		engagementScore := float64(totalActions) / 10.0 // Normalize to 0-1 scale
-		if engagementScore > 1.0 {
-			engagementScore = 1.0
+		var engagement, quality float64
+		var duration time.Duration
+		var actions int
+		for _, session := range daySessions {
+			engagement += session.EngagementScore
+			duration += session.Duration()
+			actions += session.ActionCount
+			quality += session.Quality
 		}
 		
-		// Calculate session duration (simplified)
-		var sessionDuration time.Duration
-		if len(dayActions) > 0 {
-			first := dayActions[0].Timestamp
-			last := dayActions[len(dayActions)-1].Timestamp
-			sessionDuration = last.Sub(first)
-		}
-		
-		// Calculate quality score based on action types
 		qualityScore := 0.0
-		for _, action := range dayActions {
-			switch action.ActionType {
-			case "apply":
-				qualityScore += 1.0
-			case "save":
-				qualityScore += 0.6
-			case "view":
-				qualityScore += 0.3
-			case "search":
-				qualityScore += 0.1
-			}
+		if actions > 0 {
+			qualityScore = quality / float64(actions) // Average intent per action
 		}
-		qualityScore = qualityScore / float64(totalActions) // Average quality
 		
 		points = append(points, EngagementPoint{
 			Date:              date,
-			EngagementScore:   engagementScore,
-			SessionDuration:   sessionDuration,
-			ActionsPerSession: totalActions,
+			EngagementScore:   engagement / float64(len(daySessions)),
+			SessionDuration:   duration / time.Duration(len(daySessions)),
+			ActionsPerSession: actions / len(daySessions),
 			QualityScore:      qualityScore,
 		})
 	}
@@ -872,38 +815,18 @@ func (s *userBehaviorService) analyzeEngagementEvolution(actions []repository.Us
 	return points
 }
 
-func (s *userBehaviorService) determineJourneyProgression(actions []repository.UserAction) string {
-	// Analyze recent actions to determine journey stage evolution
-	recentActions := []repository.UserAction{}
-	cutoff := time.Now().AddDate(0, 0, -30) // Last 30 days
-	
-	for _, action := range actions {
-		if action.Timestamp.After(cutoff) {
-			recentActions = append(recentActions, action)
+func (s *userBehaviorService) determineJourneyProgression(sessions []repository.UserSession) string {
+	// Users without a session in the last 30 days have stalled rather than explored
+	cutoff := time.Now().AddDate(0, 0, -30)
+	for _, session := range sessions {
+		if session.EndedAt.After(cutoff) {
+			return behavior.JourneyStage(sessions, time.Now())
 		}
 	}
-	
-	if len(recentActions) == 0 {
-		return "inactive"
-	}
-	
-	// Count different action types
-	actionCounts := make(map[string]int)
-	for _, action := range recentActions {
-		actionCounts[action.ActionType]++
-	}
-	
-	// Determine progression based on action patterns
-	if actionCounts["apply"] > 5 {
-		return "decisive"
-	} else if actionCounts["save"] > 10 || actionCounts["view"] > 20 {
-		return "focused"
-	} else {
-		return "explorer"
-	}
+	return "inactive"
 }
 
-func (s *userBehaviorService) calculateBehavioralScore(rec *HybridMatchResult, context *RecommendationContext) float64 {
+func (s *userBehaviorService) calculateBehavioralScore(rec *aiServices.HybridMatchResult, context *RecommendationContext) float64 {
 	score := 0.0
 	
 	// Check if job matches user's historical interests
@@ -943,7 +866,7 @@ func (s *userBehaviorService) determinePersonalizationLevel(context *Recommendat
 	}
 }
 
-func (s *userBehaviorService) generateReasoningContext(rec *HybridMatchResult, context *RecommendationContext) []string {
+func (s *userBehaviorService) generateReasoningContext(rec *aiServices.HybridMatchResult, context *RecommendationContext) []string {
 	reasons := []string{}
 	
 	// Add reasons based on behavioral patterns
@@ -962,7 +885,7 @@ func (s *userBehaviorService) generateReasoningContext(rec *HybridMatchResult, c
 	return reasons
 }
 
-func (s *userBehaviorService) identifyConfidenceBoosters(rec *HybridMatchResult, context *RecommendationContext) []string {
+func (s *userBehaviorService) identifyConfidenceBoosters(rec *aiServices.HybridMatchResult, context *RecommendationContext) []string {
 	boosters := []string{}
 	
 	if rec.ConfidenceLevel > 0.8 {
@@ -981,17 +904,11 @@ func (s *userBehaviorService) identifyConfidenceBoosters(rec *HybridMatchResult,
 }
 
 func (s *userBehaviorService) determineUserJourneyStage(context *RecommendationContext) string {
-	// Determine journey stage based on engagement patterns
-	if context.EngagementMetrics.ApplicationRate > 0.15 {
-		return "decisive"
-	} else if len(context.SavedJobs) > 5 {
-		return "focused"
-	} else {
-		return "explorer"
-	}
+	// Determine journey stage from what the user does within their sessions
+	return behavior.JourneyStage(context.RecentSessions, time.Now())
 }
 
-func (s *userBehaviorService) predictEngagement(rec *HybridMatchResult, context *RecommendationContext) PredictedEngagement {
+func (s *userBehaviorService) predictEngagement(rec *aiServices.HybridMatchResult, context *RecommendationContext) PredictedEngagement {
 	// Predict engagement based on behavioral patterns and job characteristics
 	viewProbability := 0.5 // Base probability
 	applicationProbability := 0.1 // Base probability
@@ -21,7 +21,6 @@ type UserService interface {
 	Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error)
 	GetProfile(ctx context.Context, userID string) (*dto.UserResponse, error)
 	UpdateProfile(ctx context.Context, userID string, req dto.UpdateUserRequest) (*dto.UserResponse, error)
-	DeleteUser(ctx context.Context, userID string) error
 	VerifyEmail(ctx context.Context, token string) error
 	ForgotPassword(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
@@ -32,13 +31,15 @@ type userService struct {
 	userRepo    repository.UserRepository
 	jwtService  *jwt.Service
 	emailService EmailService
+	features    FeatureInvalidator
 }
 
-func NewUserService(userRepo repository.UserRepository, jwtService *jwt.Service, emailService EmailService) UserService {
+func NewUserService(userRepo repository.UserRepository, jwtService *jwt.Service, emailService EmailService, features FeatureInvalidator) UserService {
 	return &userService{
 		userRepo:    userRepo,
 		jwtService:  jwtService,
 		emailService: emailService,
+		features:    features,
 	}
 }
 
@@ -73,6 +74,7 @@ func (s *userService) Register(ctx context.Context, req dto.UserRegistrationRequ
 		Name:              req.Name,
 		Password:          hashedPassword,
 		UserType:          req.UserType,
+		AcquisitionSource: normalizeAcquisitionSource(req.AcquisitionSource),
 		EmailVerified:     false,
 		VerificationToken: &verificationToken,
 		IsActive:          true,
@@ -190,23 +192,11 @@ func (s *userService) UpdateProfile(ctx context.Context, userID string, req dto.
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
+	invalidateUserFeatures(ctx, s.features, user.ID)
 
 	return s.userToResponse(user), nil
 }
 
-func (s *userService) DeleteUser(ctx context.Context, userID string) error {
-	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	// Soft delete - mark as inactive instead of hard delete
-	user.IsActive = false
-	user.UpdatedAt = time.Now()
-
-	return s.userRepo.Update(ctx, user)
-}
-
 func (s *userService) VerifyEmail(ctx context.Context, token string) error {
 	user, err := s.userRepo.GetByVerificationToken(ctx, token)
 	if err != nil {
@@ -354,6 +344,23 @@ func (s *userService) validateRegistrationRequest(req dto.UserRegistrationReques
 	return nil
 }
 
+// normalizeAcquisitionSource keeps signup attribution groupable in cohort reports.
+// Only lowercase letters, digits, '_' and '-' are kept, so a source is a plain tag
+// rather than free text.
+func normalizeAcquisitionSource(source string) string {
+	source = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		}
+		return -1
+	}, strings.ToLower(strings.TrimSpace(source)))
+	if len(source) > 100 {
+		source = source[:100]
+	}
+	return source
+}
+
 func (s *userService) hashPassword(password string) (string, error) {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -378,6 +385,8 @@ func (s *userService) userToResponse(user *models.User) *dto.UserResponse {
 		Portfolio:       user.Portfolio,
 		PreferredSalary: user.PreferredSalary,
 		WorkPreference:  user.WorkPreference,
+		ResumeFileID:    user.ResumeFileID,
+		LogoFileID:      user.LogoFileID,
 		Level:           user.Level,
 		XP:              user.XP,
 		CareerCoins:     user.CareerCoins,
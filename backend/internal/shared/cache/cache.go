@@ -309,6 +309,36 @@ func (c *CacheLayer) InvalidateJobCache(ctx context.Context, jobID string) error
 	return nil
 }
 
+// CacheService stores serialized values under plain keys
+type CacheService interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// Get retrieves a raw value from the shared cache
+func (c *CacheLayer) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.L2.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("cache miss")
+	}
+	return data, nil
+}
+
+// Set stores a raw value in the shared cache
+func (c *CacheLayer) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.L2.Set(ctx, key, value, ttl).Err(); err != nil {
+		c.logger.Error().Err(err).Str("key", key).Msg("Failed to store value in L2 cache")
+		return fmt.Errorf("failed to store value in Redis: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a value from the shared cache
+func (c *CacheLayer) Delete(ctx context.Context, key string) error {
+	return c.L2.Del(ctx, key).Err()
+}
+
 // GetCacheStats returns cache statistics
 func (c *CacheLayer) GetCacheStats(ctx context.Context) map[string]interface{} {
 	// Get Redis info
@@ -0,0 +1,12 @@
+// Package csvexport holds helpers shared by the CSV exports.
+package csvexport
+
+import "strings"
+
+// Cell keeps spreadsheet programs from evaluating user-supplied text as a formula
+func Cell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
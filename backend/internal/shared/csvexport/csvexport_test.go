@@ -0,0 +1,20 @@
+package csvexport
+
+import "testing"
+
+func TestCell(t *testing.T) {
+	for value, expected := range map[string]string{
+		"":                  "",
+		"newsletter":        "newsletter",
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+1":                "'+1",
+		"-1":                "'-1",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"\tcmd":             "'\tcmd",
+		"a=b":               "a=b",
+	} {
+		if got := Cell(value); got != expected {
+			t.Errorf("Cell(%q) = %q, expected %q", value, got, expected)
+		}
+	}
+}
@@ -44,6 +44,15 @@ type Metrics struct {
 	userRetentionRate     *prometheus.GaugeVec
 	employerSatisfaction  *prometheus.GaugeVec
 	timeToFirstMatch      *prometheus.HistogramVec
+
+	// Model drift metrics
+	modelDrift          *prometheus.GaugeVec
+	modelDriftAlerts    *prometheus.CounterVec
+
+	// Behavior ingestion metrics
+	behaviorEvents      *prometheus.CounterVec
+	behaviorBufferDepth prometheus.Gauge
+	behaviorFlushes     *prometheus.HistogramVec
 }
 
 // NewMetrics creates and registers all metrics
@@ -227,6 +236,45 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"user_type", "match_quality"},
 		),
+
+		// Model drift metrics
+		modelDrift: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "microbridge_model_drift",
+				Help: "Distribution drift of model scores and features against their reference (PSI or KL divergence)",
+			},
+			[]string{"metric", "statistic"},
+		),
+		modelDriftAlerts: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "microbridge_model_drift_threshold_breaches_total",
+				Help: "Total number of drift evaluations above the warning or alert threshold",
+			},
+			[]string{"metric", "severity"},
+		),
+
+		// Behavior ingestion metrics
+		behaviorEvents: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "microbridge_behavior_events_total",
+				Help: "Total number of behavior events by ingestion outcome (accepted, duplicate, rejected, shed, stored, dropped)",
+			},
+			[]string{"outcome"},
+		),
+		behaviorBufferDepth: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "microbridge_behavior_buffer_depth",
+				Help: "Number of behavior events waiting to be flushed",
+			},
+		),
+		behaviorFlushes: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "microbridge_behavior_flush_duration_seconds",
+				Help:    "Time taken to write a batch of behavior events",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"status"},
+		),
 	}
 
 	return m
@@ -253,6 +301,11 @@ func (m *Metrics) RecordApplicationConversion(status, matchScoreRange, jobCatego
 	m.applicationConversion.WithLabelValues(status, matchScoreRange, jobCategory).Inc()
 }
 
+// RecordApplicationConversions records several job applications reaching the same stage
+func (m *Metrics) RecordApplicationConversions(status, matchScoreRange, jobCategory string, count int) {
+	m.applicationConversion.WithLabelValues(status, matchScoreRange, jobCategory).Add(float64(count))
+}
+
 // RecordUserEngagement records user engagement metrics
 func (m *Metrics) RecordUserEngagement(userType, engagementType string, score float64) {
 	m.userEngagement.WithLabelValues(userType, engagementType).Set(score)
@@ -338,6 +391,31 @@ func (m *Metrics) RecordTimeToFirstMatch(userType, matchQuality string, hours fl
 	m.timeToFirstMatch.WithLabelValues(userType, matchQuality).Observe(hours)
 }
 
+// RecordModelDrift records a drift statistic for a model score or feature
+func (m *Metrics) RecordModelDrift(metric, statistic string, value float64) {
+	m.modelDrift.WithLabelValues(metric, statistic).Set(value)
+}
+
+// RecordDriftAlert records a drift threshold breach
+func (m *Metrics) RecordDriftAlert(metric, severity string) {
+	m.modelDriftAlerts.WithLabelValues(metric, severity).Inc()
+}
+
+// RecordBehaviorEvents records behavior events reaching an ingestion outcome
+func (m *Metrics) RecordBehaviorEvents(outcome string, count int) {
+	m.behaviorEvents.WithLabelValues(outcome).Add(float64(count))
+}
+
+// RecordBehaviorBufferDepth records the number of buffered behavior events
+func (m *Metrics) RecordBehaviorBufferDepth(depth int64) {
+	m.behaviorBufferDepth.Set(float64(depth))
+}
+
+// RecordBehaviorFlush records a batch write of behavior events
+func (m *Metrics) RecordBehaviorFlush(status string, duration time.Duration) {
+	m.behaviorFlushes.WithLabelValues(status).Observe(duration.Seconds())
+}
+
 // BusinessMetricsCollector provides business-specific metrics collection
 type BusinessMetricsCollector struct {
 	metrics *Metrics
@@ -15,6 +15,14 @@ func InitMetrics() {
 	globalMetrics = NewMetrics()
 }
 
+// GetMetrics returns the global metrics instance, initializing it on first use
+func GetMetrics() *Metrics {
+	if globalMetrics == nil {
+		InitMetrics()
+	}
+	return globalMetrics
+}
+
 // RecordHTTPRequest records HTTP request using global metrics
 func RecordHTTPRequest(method, endpoint, statusCode string, duration time.Duration) {
 	if globalMetrics != nil {
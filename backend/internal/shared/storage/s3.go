@@ -0,0 +1,313 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+)
+
+// S3Config configures an S3-compatible object store
+type S3Config struct {
+	Endpoint        string // Empty means https://s3.<region>.amazonaws.com
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool
+}
+
+// S3Provider stores files in an S3-compatible bucket. Requests are signed with
+// AWS Signature Version 4, which AWS, GCS interoperability and MinIO accept.
+type S3Provider struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3Provider(cfg S3Config) (*S3Provider, error) {
+	if cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("storage: s3 provider needs a bucket and credentials")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("storage: invalid s3 endpoint %q", endpoint)
+	}
+	return &S3Provider{
+		endpoint:  parsed,
+		bucket:    cfg.Bucket,
+		region:    region,
+		accessKey: cfg.AccessKeyID,
+		secretKey: cfg.SecretAccessKey,
+		pathStyle: cfg.PathStyle,
+		client:    &http.Client{Timeout: 60 * time.Second},
+		now:       time.Now,
+	}, nil
+}
+
+func (p *S3Provider) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	sum := sha256.Sum256(data)
+	req, err := p.newRequest(ctx, http.MethodPut, key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", contentType)
+	p.sign(req, hex.EncodeToString(sum[:]))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("storage: put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	return p.check(resp, "put", key)
+}
+
+func (p *S3Provider) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := p.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	p.sign(req, emptyPayloadHash)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage: open %s: %w", key, err)
+	}
+	if err := p.check(resp, "open", key); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (p *S3Provider) Exists(ctx context.Context, key string) (bool, error) {
+	req, err := p.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return false, err
+	}
+	p.sign(req, emptyPayloadHash)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("storage: stat %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if err := p.check(resp, "stat", key); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *S3Provider) Delete(ctx context.Context, key string) error {
+	req, err := p.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	p.sign(req, emptyPayloadHash)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("storage: delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if err := p.check(resp, "delete", key); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns a presigned GET URL for the object
+func (p *S3Provider) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	return p.presign(http.MethodGet, p.objectURL(key), clampExpiry(expiry), p.now().UTC()), nil
+}
+
+func (p *S3Provider) presign(method string, target *url.URL, expiry time.Duration, now time.Time) string {
+	amzDate := now.Format(s3TimeFormat)
+	scope := p.scope(now)
+
+	query := target.Query()
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", p.accessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expiry/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonical := strings.Join([]string{
+		method,
+		target.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + target.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	signed := *target
+	signed.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" + p.signature(canonical, amzDate, now)
+	return signed.String()
+}
+
+// sign adds the Authorization header for a request whose body hashes to payloadHash
+func (p *S3Provider) sign(req *http.Request, payloadHash string) {
+	now := p.now().UTC()
+	amzDate := now.Format(s3TimeFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, p.accessKey, p.scope(now), signedHeaders, p.signature(canonical, amzDate, now)))
+}
+
+func (p *S3Provider) signature(canonicalRequest, amzDate string, now time.Time) string {
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, p.scope(now), hex.EncodeToString(hashed[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+p.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, p.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (p *S3Provider) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + p.region + "/s3/aws4_request"
+}
+
+func (p *S3Provider) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	return http.NewRequestWithContext(ctx, method, p.objectURL(key).String(), body)
+}
+
+// objectURL addresses a key path-style (endpoint/bucket/key) or virtual-hosted (bucket.endpoint/key)
+func (p *S3Provider) objectURL(key string) *url.URL {
+	target := *p.endpoint
+	base := strings.TrimSuffix(target.Path, "/")
+	if p.pathStyle {
+		target.Path = base + "/" + p.bucket + "/" + key
+	} else {
+		target.Host = p.bucket + "." + target.Host
+		target.Path = base + "/" + key
+	}
+	target.RawPath = ""
+	return &target
+}
+
+func (p *S3Provider) check(resp *http.Response, op, key string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("storage: %s %s: %s: %s", op, key, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// canonicalQuery sorts and encodes query parameters the way SigV4 expects
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but RFC 3986 unreserved characters
+func uriEncode(value string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		if b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-' || b == '_' || b == '.' || b == '~' {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+var emptyPayloadHash = func() string {
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}()
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under a key
+	ErrNotFound = errors.New("storage: object not found")
+	// ErrTooLarge is returned when an upload exceeds the configured maximum size
+	ErrTooLarge = errors.New("storage: file is too large")
+	// ErrUnsupportedType is returned when an upload's content is not an allowed type
+	ErrUnsupportedType = errors.New("storage: unsupported file type")
+	// ErrInvalidSignature is returned for download URLs that are tampered with or expired
+	ErrInvalidSignature = errors.New("storage: invalid or expired signature")
+)
+
+const (
+	// DefaultURLExpiry is how long a signed URL stays valid when no expiry is given
+	DefaultURLExpiry = 15 * time.Minute
+	// MaxURLExpiry is the longest a signed URL stays valid; S3 rejects longer presigned URLs
+	MaxURLExpiry = 7 * 24 * time.Hour
+)
+
+// Provider stores file contents under keys. Keys are content-addressed by the
+// Uploader, so writing the same key twice writes the same bytes.
+type Provider interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads the object without credentials until expiry
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// URLVerifier is implemented by providers whose signed URLs are served by the API itself
+type URLVerifier interface {
+	VerifySignedURL(key, expires, signature string, now time.Time) error
+}
+
+// Config selects and configures a provider
+type Config struct {
+	Provider string // "local", "s3" or "gcs"
+
+	// Local filesystem
+	LocalPath     string
+	DownloadURL   string // Absolute URL of the API route that serves signed local downloads
+	URLSigningKey string
+
+	// S3-compatible object stores; gcs uses its S3 interoperability API with HMAC keys
+	Bucket          string
+	Region          string
+	Endpoint        string // Empty means AWS S3 for s3 and storage.googleapis.com for gcs
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // Address buckets as endpoint/bucket instead of bucket.endpoint, as most stand-ins expect
+}
+
+// New builds the provider named in cfg
+func New(cfg Config) (Provider, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "", "local":
+		return NewLocalProvider(cfg.LocalPath, cfg.DownloadURL, cfg.URLSigningKey)
+	case "s3":
+		return NewS3Provider(S3Config{
+			Endpoint:        cfg.Endpoint,
+			Bucket:          cfg.Bucket,
+			Region:          cfg.Region,
+			AccessKeyID:     cfg.AccessKeyID,
+			SecretAccessKey: cfg.SecretAccessKey,
+			PathStyle:       cfg.PathStyle,
+		})
+	case "gcs":
+		endpoint := cfg.Endpoint
+		if endpoint == "" {
+			endpoint = "https://storage.googleapis.com"
+		}
+		return NewS3Provider(S3Config{
+			Endpoint:        endpoint,
+			Bucket:          cfg.Bucket,
+			Region:          "auto",
+			AccessKeyID:     cfg.AccessKeyID,
+			SecretAccessKey: cfg.SecretAccessKey,
+			PathStyle:       true,
+		})
+	default:
+		return nil, fmt.Errorf("storage: unknown provider %q", cfg.Provider)
+	}
+}
+
+// validKey rejects keys that could escape a provider's root or need escaping.
+// Uploader keys are lowercase hex digests under a short prefix, so they always pass.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("storage: invalid key %q", key)
+		}
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '/' || r == '.' || r == '-' || r == '_') {
+			return fmt.Errorf("storage: invalid key %q", key)
+		}
+	}
+	return nil
+}
+
+func clampExpiry(expiry time.Duration) time.Duration {
+	if expiry <= 0 {
+		return DefaultURLExpiry
+	}
+	if expiry > MaxURLExpiry {
+		return MaxURLExpiry
+	}
+	return expiry
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+func docx(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range []string{"[Content_Types].xml", "word/document.xml"} {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to build docx: %v", err)
+		}
+		w.Write([]byte("<xml/>"))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to build docx: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		filename string
+		want     string
+	}{
+		{"pdf", []byte("%PDF-1.7\n..."), "resume.pdf", TypePDF},
+		{"pdf named as image", []byte("%PDF-1.7\n..."), "logo.png", TypePDF},
+		{"png", pngHeader, "logo.png", TypePNG},
+		{"plain text", []byte("Jane Doe\nGo developer"), "resume.txt", TypePlainText},
+		{"html is not text", []byte("  <html><script>alert(1)</script>"), "resume.txt", "text/html"},
+		{"svg is not text", []byte("<svg xmlns='http://www.w3.org/2000/svg'/>"), "logo.svg", "text/html"},
+		{"zip is not docx", docx(t), "archive.zip", "application/zip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectContentType(tt.data, tt.filename); got != tt.want {
+				t.Errorf("DetectContentType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := DetectContentType(docx(t), "Resume.DOCX"); got != TypeDOCX {
+		t.Errorf("Expected a Word document, got %q", got)
+	}
+}
+
+func newLocal(t *testing.T) *LocalProvider {
+	t.Helper()
+	provider, err := NewLocalProvider(t.TempDir(), "http://localhost:8080/api/v1/files/download/", "secret")
+	if err != nil {
+		t.Fatalf("NewLocalProvider failed: %v", err)
+	}
+	return provider
+}
+
+func TestUploader_Store(t *testing.T) {
+	ctx := context.Background()
+	provider := newLocal(t)
+	uploader := NewUploader(provider, 64)
+
+	upload, err := uploader.Store(ctx, "resumes", "cv.pdf", strings.NewReader("%PDF-1.7 my resume"), Documents)
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if upload.ContentType != TypePDF || upload.Size != 18 || !strings.HasPrefix(upload.Key, "resumes/"+upload.SHA256[:2]+"/") ||
+		!strings.HasSuffix(upload.Key, upload.SHA256+".pdf") {
+		t.Errorf("Unexpected upload %+v", upload)
+	}
+
+	// Same content, same key
+	again, err := uploader.Store(ctx, "resumes", "other-name.pdf", strings.NewReader("%PDF-1.7 my resume"), Documents)
+	if err != nil || again.Key != upload.Key {
+		t.Errorf("Expected identical content to share a key, got %+v, %v", again, err)
+	}
+
+	body, err := provider.Open(ctx, upload.Key)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "%PDF-1.7 my resume" {
+		t.Errorf("Read back %q", data)
+	}
+
+	if _, err := uploader.Store(ctx, "resumes", "big.pdf", strings.NewReader("%PDF-"+strings.Repeat("x", 64)), Documents); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+	if _, err := uploader.Store(ctx, "logos", "logo.png", strings.NewReader("%PDF-1.7"), Images); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType for a PDF logo, got %v", err)
+	}
+	if _, err := uploader.Store(ctx, "resumes", "empty.pdf", strings.NewReader(""), Documents); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType for an empty file, got %v", err)
+	}
+}
+
+func TestLocalProvider_SignedURL(t *testing.T) {
+	ctx := context.Background()
+	provider := newLocal(t)
+	key := "resumes/ab/abcdef.pdf"
+
+	signed, err := provider.SignedURL(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL failed: %v", err)
+	}
+	parsed, err := url.Parse(signed)
+	if err != nil || parsed.Path != "/api/v1/files/download/"+key {
+		t.Fatalf("Unexpected signed URL %q", signed)
+	}
+	expires, signature := parsed.Query().Get("expires"), parsed.Query().Get("signature")
+
+	if err := provider.VerifySignedURL(key, expires, signature, time.Now()); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+	if err := provider.VerifySignedURL(key, expires, signature, time.Now().Add(2*time.Minute)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected an expired URL to be rejected, got %v", err)
+	}
+	if err := provider.VerifySignedURL("resumes/ab/other.pdf", expires, signature, time.Now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected a signature for another key to be rejected, got %v", err)
+	}
+	if err := provider.VerifySignedURL(key, "9999999999", signature, time.Now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected an extended expiry to be rejected, got %v", err)
+	}
+}
+
+func TestLocalProvider_RejectsUnsafeKeys(t *testing.T) {
+	ctx := context.Background()
+	provider := newLocal(t)
+	for _, key := range []string{"../etc/passwd", "/abs", "a//b", "a/./b", "Upper.pdf", "a b"} {
+		if err := provider.Put(ctx, key, []byte("x"), TypePlainText); err == nil {
+			t.Errorf("Expected key %q to be rejected", key)
+		}
+	}
+	if _, err := provider.Open(ctx, "resumes/missing.pdf"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
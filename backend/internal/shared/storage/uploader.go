@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Content types the uploader recognizes
+const (
+	TypePDF       = "application/pdf"
+	TypeDOCX      = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	TypePlainText = "text/plain"
+	TypePNG       = "image/png"
+	TypeJPEG      = "image/jpeg"
+	TypeGIF       = "image/gif"
+	TypeWebP      = "image/webp"
+)
+
+var (
+	// Documents are the types accepted for resumes and cover letters
+	Documents = []string{TypePDF, TypeDOCX, TypePlainText}
+	// Images are the types accepted for logos; SVG is left out because it can carry scripts
+	Images = []string{TypePNG, TypeJPEG, TypeGIF, TypeWebP}
+)
+
+var extensions = map[string]string{
+	TypePDF:       ".pdf",
+	TypeDOCX:      ".docx",
+	TypePlainText: ".txt",
+	TypePNG:       ".png",
+	TypeJPEG:      ".jpg",
+	TypeGIF:       ".gif",
+	TypeWebP:      ".webp",
+}
+
+// Upload describes a stored file
+type Upload struct {
+	Key         string
+	ContentType string
+	Size        int64
+	SHA256      string
+}
+
+// Uploader validates files and stores them under content-addressed keys
+type Uploader struct {
+	provider    Provider
+	maxFileSize int64
+}
+
+func NewUploader(provider Provider, maxFileSize int64) *Uploader {
+	return &Uploader{
+		provider:    provider,
+		maxFileSize: maxFileSize,
+	}
+}
+
+// Provider returns the provider files are stored with
+func (u *Uploader) Provider() Provider {
+	return u.provider
+}
+
+// MaxFileSize is the largest upload accepted, in bytes
+func (u *Uploader) MaxFileSize() int64 {
+	return u.maxFileSize
+}
+
+// Store reads a file, checks its size and sniffed content type against allowed
+// and stores it under prefix/<sha256>. The client's filename only helps tell
+// a .docx from other zip archives; the content decides the type.
+func (u *Uploader) Store(ctx context.Context, prefix, filename string, r io.Reader, allowed []string) (*Upload, error) {
+	data, err := io.ReadAll(io.LimitReader(r, u.maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > u.maxFileSize {
+		return nil, ErrTooLarge
+	}
+	if len(data) == 0 {
+		return nil, ErrUnsupportedType
+	}
+
+	contentType := DetectContentType(data, filename)
+	if !contains(allowed, contentType) {
+		return nil, ErrUnsupportedType
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	key := path.Join(prefix, digest[:2], digest+extensions[contentType])
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	// The same bytes were stored before; the key already holds them
+	exists, err := u.provider.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := u.provider.Put(ctx, key, data, contentType); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Upload{
+		Key:         key,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      digest,
+	}, nil
+}
+
+// DetectContentType sniffs data and returns one of the recognized types, or the
+// sniffed type without parameters when it isn't one of them
+func DetectContentType(data []byte, filename string) string {
+	sniffed := http.DetectContentType(data)
+	if i := strings.Index(sniffed, ";"); i >= 0 {
+		sniffed = sniffed[:i]
+	}
+
+	switch sniffed {
+	case "application/zip":
+		if strings.EqualFold(path.Ext(filename), ".docx") && isDOCX(data) {
+			return TypeDOCX
+		}
+	case TypePlainText:
+		// DetectContentType calls any text without binary bytes plain text;
+		// markup that browsers would render is not accepted as a document
+		trimmed := bytes.TrimSpace(data)
+		if bytes.HasPrefix(trimmed, []byte("<")) {
+			return "text/html"
+		}
+	}
+	return sniffed
+}
+
+// ContentTypeForKey returns the content type of a key written by an Uploader
+func ContentTypeForKey(key string) string {
+	ext := path.Ext(key)
+	for contentType, candidate := range extensions {
+		if candidate == ext {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
+
+// isDOCX checks that a zip archive is laid out like a Word document
+func isDOCX(data []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	var contentTypes, document bool
+	for _, file := range archive.File {
+		switch file.Name {
+		case "[Content_Types].xml":
+			contentTypes = true
+		case "word/document.xml":
+			document = true
+		}
+	}
+	return contentTypes && document
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
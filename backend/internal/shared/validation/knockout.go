@@ -1,4 +1,216 @@
 package validation
 
-// TODO: Implement knockout validation rules
-// This file is a placeholder for future knockout validation implementation
\ No newline at end of file
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxScreeningQuestions bounds how many questions a job can ask
+	MaxScreeningQuestions = 20
+	maxScreeningPrompt    = 500
+	maxScreeningOptions   = 20
+	maxScreeningAnswer    = 2000
+)
+
+// ScreeningResult is the outcome of checking an application's answers
+type ScreeningResult struct {
+	Answers models.ScreeningAnswers
+	// Outcome is the strongest knockout action triggered: "reject", "flag" or empty
+	Outcome string
+	// Knockouts are the prompts of the questions whose answers knocked the application out
+	Knockouts []string
+}
+
+// NormalizeScreeningQuestions validates an employer's questions and fills in
+// defaults: IDs for new questions and "reject" for knockouts without an action
+func NormalizeScreeningQuestions(questions models.ScreeningQuestions) (models.ScreeningQuestions, error) {
+	if len(questions) > MaxScreeningQuestions {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("a job can ask at most %d screening questions", MaxScreeningQuestions))
+	}
+
+	normalized := make(models.ScreeningQuestions, len(questions))
+	seen := make(map[string]bool, len(questions))
+	for i, question := range questions {
+		position := i + 1
+		question.Prompt = strings.TrimSpace(question.Prompt)
+		if question.Prompt == "" {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d needs a prompt", position))
+		}
+		if len(question.Prompt) > maxScreeningPrompt {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d is longer than %d characters", position, maxScreeningPrompt))
+		}
+
+		question.ID = strings.TrimSpace(question.ID)
+		if question.ID == "" {
+			question.ID = uuid.New().String()
+		}
+		if seen[question.ID] {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d repeats the id %q", position, question.ID))
+		}
+		seen[question.ID] = true
+
+		switch question.Type {
+		case models.ScreeningYesNo:
+			question.Options = nil
+			for j, answer := range question.KnockoutAnswers {
+				answer = strings.ToLower(strings.TrimSpace(answer))
+				if answer != "yes" && answer != "no" {
+					return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d can only knock out on yes or no", position))
+				}
+				question.KnockoutAnswers[j] = answer
+			}
+		case models.ScreeningSingleChoice:
+			options, err := normalizeOptions(question.Options, position)
+			if err != nil {
+				return nil, err
+			}
+			question.Options = options
+			for j, answer := range question.KnockoutAnswers {
+				option, ok := matchOption(options, answer)
+				if !ok {
+					return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d knocks out on %q, which is not one of its options", position, answer))
+				}
+				question.KnockoutAnswers[j] = option
+			}
+		case models.ScreeningText, models.ScreeningURL:
+			question.Options = nil
+			if len(question.KnockoutAnswers) > 0 {
+				return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d is free-form and can't have knockout answers", position))
+			}
+		default:
+			return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d has unknown type %q", position, question.Type))
+		}
+
+		if len(question.KnockoutAnswers) == 0 {
+			question.KnockoutAnswers = nil
+			question.KnockoutAction = ""
+		} else {
+			switch question.KnockoutAction {
+			case "":
+				question.KnockoutAction = models.KnockoutReject
+			case models.KnockoutReject, models.KnockoutFlag:
+			default:
+				return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d has unknown knockout action %q", position, question.KnockoutAction))
+			}
+		}
+		normalized[i] = question
+	}
+	return normalized, nil
+}
+
+// EvaluateScreening checks answers against a job's questions and reports the
+// knockouts they trigger. requireAll enforces required questions; drafts skip it.
+func EvaluateScreening(questions models.ScreeningQuestions, answers models.ScreeningAnswers, requireAll bool) (*ScreeningResult, error) {
+	byID := make(map[string]models.ScreeningQuestion, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	given := make(map[string]string, len(answers))
+	for _, answer := range answers {
+		if _, ok := byID[answer.QuestionID]; !ok {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("%q is not a screening question of this job", answer.QuestionID))
+		}
+		if _, ok := given[answer.QuestionID]; ok {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %q is answered twice", answer.QuestionID))
+		}
+		given[answer.QuestionID] = strings.TrimSpace(answer.Value)
+	}
+
+	result := &ScreeningResult{Answers: models.ScreeningAnswers{}}
+	for _, question := range questions {
+		value, answered := given[question.ID]
+		if !answered || value == "" {
+			if requireAll && question.Required {
+				return nil, apperrors.NewValidationError(fmt.Sprintf("%q is required", question.Prompt))
+			}
+			continue
+		}
+
+		value, err := normalizeAnswer(question, value)
+		if err != nil {
+			return nil, err
+		}
+
+		answer := models.ScreeningAnswer{QuestionID: question.ID, Value: value}
+		for _, knockout := range question.KnockoutAnswers {
+			if value == knockout {
+				answer.Knockout = true
+				result.Knockouts = append(result.Knockouts, question.Prompt)
+				if question.KnockoutAction == models.KnockoutReject || result.Outcome == "" {
+					result.Outcome = question.KnockoutAction
+				}
+				break
+			}
+		}
+		result.Answers = append(result.Answers, answer)
+	}
+	return result, nil
+}
+
+// normalizeAnswer checks an answer fits its question and returns it in canonical form
+func normalizeAnswer(question models.ScreeningQuestion, value string) (string, error) {
+	if len(value) > maxScreeningAnswer {
+		return "", apperrors.NewValidationError(fmt.Sprintf("the answer to %q is longer than %d characters", question.Prompt, maxScreeningAnswer))
+	}
+
+	switch question.Type {
+	case models.ScreeningYesNo:
+		switch strings.ToLower(value) {
+		case "yes", "true":
+			return "yes", nil
+		case "no", "false":
+			return "no", nil
+		}
+		return "", apperrors.NewValidationError(fmt.Sprintf("answer %q with yes or no", question.Prompt))
+	case models.ScreeningSingleChoice:
+		option, ok := matchOption(question.Options, value)
+		if !ok {
+			return "", apperrors.NewValidationError(fmt.Sprintf("answer %q with one of: %s", question.Prompt, strings.Join(question.Options, ", ")))
+		}
+		return option, nil
+	case models.ScreeningURL:
+		parsed, err := url.Parse(value)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "", apperrors.NewValidationError(fmt.Sprintf("answer %q with an http or https link", question.Prompt))
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+func normalizeOptions(options []string, position int) ([]string, error) {
+	normalized := make([]string, 0, len(options))
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[strings.ToLower(option)] {
+			continue
+		}
+		seen[strings.ToLower(option)] = true
+		normalized = append(normalized, option)
+	}
+	if len(normalized) < 2 || len(normalized) > maxScreeningOptions {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("screening question %d needs between 2 and %d distinct options", position, maxScreeningOptions))
+	}
+	return normalized, nil
+}
+
+// matchOption finds value among options, ignoring case and surrounding space
+func matchOption(options []string, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"testing"
+
+	"microbridge/backend/internal/models"
+)
+
+func screeningQuestions(t *testing.T) models.ScreeningQuestions {
+	t.Helper()
+	questions, err := NormalizeScreeningQuestions(models.ScreeningQuestions{
+		{ID: "available", Prompt: "Are you available from June 1?", Type: models.ScreeningYesNo, Required: true, KnockoutAnswers: []string{"No"}},
+		{ID: "hours", Prompt: "Hours per week?", Type: models.ScreeningSingleChoice, Options: []string{"Under 10", "10-20", "20+"}, KnockoutAnswers: []string{"under 10"}, KnockoutAction: models.KnockoutFlag},
+		{ID: "github", Prompt: "Link your GitHub", Type: models.ScreeningURL},
+		{Prompt: "Anything else?", Type: models.ScreeningText},
+	})
+	if err != nil {
+		t.Fatalf("NormalizeScreeningQuestions failed: %v", err)
+	}
+	return questions
+}
+
+func TestNormalizeScreeningQuestions(t *testing.T) {
+	questions := screeningQuestions(t)
+	if questions[0].KnockoutAnswers[0] != "no" || questions[0].KnockoutAction != models.KnockoutReject {
+		t.Errorf("Expected a lowercase knockout that rejects by default, got %+v", questions[0])
+	}
+	if questions[1].KnockoutAnswers[0] != "Under 10" {
+		t.Errorf("Expected the knockout to match the option's spelling, got %v", questions[1].KnockoutAnswers)
+	}
+	if questions[3].ID == "" {
+		t.Error("Expected an ID for a new question")
+	}
+
+	invalid := []models.ScreeningQuestions{
+		{{Prompt: "", Type: models.ScreeningYesNo}},
+		{{Prompt: "Pick", Type: models.ScreeningSingleChoice, Options: []string{"Only one"}}},
+		{{Prompt: "Pick", Type: models.ScreeningSingleChoice, Options: []string{"A", "B"}, KnockoutAnswers: []string{"C"}}},
+		{{Prompt: "Bio", Type: models.ScreeningText, KnockoutAnswers: []string{"no"}}},
+		{{Prompt: "Ready?", Type: models.ScreeningYesNo, KnockoutAnswers: []string{"maybe"}}},
+		{{Prompt: "Ready?", Type: "multiple_choice"}},
+		{{ID: "q", Prompt: "A?", Type: models.ScreeningYesNo}, {ID: "q", Prompt: "B?", Type: models.ScreeningYesNo}},
+	}
+	for i, questions := range invalid {
+		if _, err := NormalizeScreeningQuestions(questions); err == nil {
+			t.Errorf("Expected invalid question set %d to be rejected", i)
+		}
+	}
+}
+
+func TestEvaluateScreening(t *testing.T) {
+	questions := screeningQuestions(t)
+
+	tests := []struct {
+		name        string
+		answers     models.ScreeningAnswers
+		requireAll  bool
+		wantErr     bool
+		wantOutcome string
+	}{
+		{"clean", models.ScreeningAnswers{{QuestionID: "available", Value: "Yes"}, {QuestionID: "github", Value: "https://github.com/jane"}}, true, false, ""},
+		{"flagged", models.ScreeningAnswers{{QuestionID: "available", Value: "yes"}, {QuestionID: "hours", Value: "UNDER 10"}}, true, false, models.KnockoutFlag},
+		{"reject beats flag", models.ScreeningAnswers{{QuestionID: "available", Value: "no"}, {QuestionID: "hours", Value: "Under 10"}}, true, false, models.KnockoutReject},
+		{"missing required", models.ScreeningAnswers{{QuestionID: "github", Value: "https://github.com/jane"}}, true, true, ""},
+		{"drafts may skip required", nil, false, false, ""},
+		{"not a URL", models.ScreeningAnswers{{QuestionID: "available", Value: "yes"}, {QuestionID: "github", Value: "javascript:alert(1)"}}, true, true, ""},
+		{"not an option", models.ScreeningAnswers{{QuestionID: "available", Value: "yes"}, {QuestionID: "hours", Value: "40"}}, true, true, ""},
+		{"unknown question", models.ScreeningAnswers{{QuestionID: "salary", Value: "100"}}, false, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EvaluateScreening(questions, tt.answers, tt.requireAll)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EvaluateScreening failed: %v", err)
+			}
+			if result.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %q, want %q", result.Outcome, tt.wantOutcome)
+			}
+		})
+	}
+
+	result, _ := EvaluateScreening(questions, models.ScreeningAnswers{{QuestionID: "available", Value: "NO"}}, true)
+	if len(result.Answers) != 1 || result.Answers[0].Value != "no" || !result.Answers[0].Knockout || len(result.Knockouts) != 1 {
+		t.Errorf("Expected a normalized knockout answer, got %+v", result)
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApplicationBulkHandler struct {
+	bulkService services.ApplicationBulkService
+}
+
+func NewApplicationBulkHandler(bulkService services.ApplicationBulkService) *ApplicationBulkHandler {
+	return &ApplicationBulkHandler{
+		bulkService: bulkService,
+	}
+}
+
+// BulkUpdateApplications rejects, reviews or shortlists many of a job's applications.
+// Small sets are applied right away; large sets are queued and answered with 202.
+func (h *ApplicationBulkHandler) BulkUpdateApplications(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.BulkApplicationActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	result, err := h.bulkService.BulkUpdateApplications(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if result.Status == models.BulkOperationPending {
+		c.JSON(http.StatusAccepted, dto.APIResponse{
+			Success: true,
+			Data:    result,
+			Message: "Bulk update queued",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    result,
+		Message: fmt.Sprintf("Updated %d of %d applications", result.Succeeded, result.Total),
+	})
+}
+
+// GetBulkOperation reports the progress of a queued bulk update
+func (h *ApplicationBulkHandler) GetBulkOperation(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	result, err := h.bulkService.GetBulkOperation(c.Request.Context(), c.Param("id"), c.Param("operationId"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "Bulk operation retrieved successfully",
+	})
+}
+
+// ExportApplications downloads the selected applications as CSV
+func (h *ApplicationBulkHandler) ExportApplications(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.ExportApplicationsRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid request format",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	export, err := h.bulkService.ExportApplications(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("applications-%s-%s.csv", c.Param("id"), time.Now().UTC().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", export)
+}
+
+// Helper methods
+
+func (h *ApplicationBulkHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		errors := []string{appErr.Message}
+		if appErr.Details != "" {
+			errors = append(errors, appErr.Details)
+		}
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  errors,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
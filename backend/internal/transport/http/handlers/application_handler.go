@@ -140,7 +140,24 @@ func (h *ApplicationHandler) GetJobApplications(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 
-	applications, err := h.applicationService.GetJobApplications(c.Request.Context(), jobID, userID, page, limit)
+	// Filters: ?status=submitted&flagged=true&answer[<question id>]=yes
+	filters := dto.JobApplicationFilters{
+		Status:  c.Query("status"),
+		Answers: c.QueryMap("answer"),
+	}
+	if flagged := c.Query("flagged"); flagged != "" {
+		value, err := strconv.ParseBool(flagged)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "flagged must be true or false",
+			})
+			return
+		}
+		filters.Flagged = &value
+	}
+
+	applications, err := h.applicationService.GetJobApplications(c.Request.Context(), jobID, userID, filters, page, limit)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -238,14 +255,78 @@ func (h *ApplicationHandler) WithdrawApplication(c *gin.Context) {
 	})
 }
 
+// SubmitDraft submits an application the user saved as a draft
+func (h *ApplicationHandler) SubmitDraft(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.SubmitDraftRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid request format",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	application, err := h.applicationService.SubmitDraft(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    application,
+		Message: "Application submitted successfully",
+	})
+}
+
+// GetApplicationTimeline retrieves the status history of an application
+func (h *ApplicationHandler) GetApplicationTimeline(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	timeline, err := h.applicationService.GetApplicationTimeline(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    timeline,
+		Message: "Application timeline retrieved successfully",
+	})
+}
+
 // Helper methods
 
 func (h *ApplicationHandler) handleError(c *gin.Context, err error) {
 	if appErr, ok := err.(*apperrors.AppError); ok {
+		errors := []string{appErr.Message}
+		if appErr.Details != "" {
+			errors = append(errors, appErr.Details)
+		}
 		c.JSON(appErr.Code, dto.APIResponse{
 			Success: false,
 			Message: appErr.Message,
-			Errors:  []string{appErr.Message},
+			Errors:  errors,
 		})
 		return
 	}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microbridge/backend/internal/core/behavior"
+	"microbridge/backend/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BehaviorEventHandler struct {
+	pipeline *behavior.Pipeline
+}
+
+func NewBehaviorEventHandler(pipeline *behavior.Pipeline) *BehaviorEventHandler {
+	return &BehaviorEventHandler{
+		pipeline: pipeline,
+	}
+}
+
+// TrackEvents accepts a batch of behavior events for asynchronous ingestion
+func (h *BehaviorEventHandler) TrackEvents(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.TrackBehaviorEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Every event in the batch comes from the device that sent the request
+	device := behavior.DeviceFromHeaders(c.Request.Header)
+	events := make([]behavior.Event, len(req.Events))
+	for i, event := range req.Events {
+		events[i] = behavior.Event{
+			IdempotencyKey: event.IdempotencyKey,
+			Type:           event.Type,
+			EntityID:       event.EntityID,
+			Data:           event.Data,
+			SessionID:      event.SessionID,
+			OccurredAt:     event.OccurredAt,
+			Device:         device,
+		}
+	}
+
+	result, err := h.pipeline.Submit(c.Request.Context(), userID, events)
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		message := "Behavior tracking is temporarily unavailable"
+		if errors.Is(err, behavior.ErrBatchTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+			message = err.Error()
+		}
+		c.JSON(status, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response := dto.TrackBehaviorEventsResponse{
+		Accepted:   result.Accepted,
+		Duplicates: result.Duplicates,
+		Shed:       result.Shed,
+	}
+	for _, rejected := range result.Rejected {
+		response.Rejected = append(response.Rejected, dto.RejectedBehaviorEvent{
+			Index:          rejected.Index,
+			IdempotencyKey: rejected.IdempotencyKey,
+			Reason:         rejected.Reason,
+		})
+	}
+
+	// Shed events are retried by resending the batch; accepted ones come back as duplicates
+	if result.Shed > 0 {
+		response.RetryAfterSeconds = int(result.RetryAfter.Seconds())
+		c.Header("Retry-After", strconv.Itoa(response.RetryAfterSeconds))
+		c.JSON(http.StatusTooManyRequests, dto.APIResponse{
+			Success: false,
+			Data:    response,
+			Message: "Event buffer is full, retry later",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.APIResponse{
+		Success: true,
+		Data:    response,
+		Message: "Events accepted",
+	})
+}
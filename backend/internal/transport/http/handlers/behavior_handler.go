@@ -1,387 +1,265 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 	"strconv"
 	"time"
 
+	aiServices "microbridge/backend/internal/ai/services"
 	"microbridge/backend/internal/dto"
 	"microbridge/backend/internal/services"
-	"microbridge/backend/internal/shared/response"
-	"microbridge/backend/internal/transport/http/middleware"
+	apperrors "microbridge/backend/internal/shared/errors"
 
-	"github.com/gorilla/mux"
+	"github.com/gin-gonic/gin"
 )
 
+// MatchFinder ranks jobs for a user; aiServices.HybridMatchingService implements it
+type MatchFinder interface {
+	FindBestMatches(ctx context.Context, userID string, limit int) ([]*aiServices.HybridMatchResult, error)
+}
+
 type BehaviorHandler struct {
 	behaviorService services.UserBehaviorService
+	matcher         MatchFinder
 }
 
-func NewBehaviorHandler(behaviorService services.UserBehaviorService) *BehaviorHandler {
+func NewBehaviorHandler(behaviorService services.UserBehaviorService, matcher MatchFinder) *BehaviorHandler {
 	return &BehaviorHandler{
 		behaviorService: behaviorService,
+		matcher:         matcher,
 	}
 }
 
-// RegisterRoutes registers behavior tracking routes
-func (h *BehaviorHandler) RegisterRoutes(router *mux.Router, authMiddleware *middleware.AuthMiddleware) {
-	// User behavior tracking endpoints
-	behaviorRouter := router.PathPrefix("/api/v1/behavior").Subrouter()
-	behaviorRouter.Use(authMiddleware.ValidateToken)
-
-	// Action tracking
-	behaviorRouter.HandleFunc("/track/view", h.TrackJobView).Methods("POST")
-	behaviorRouter.HandleFunc("/track/application", h.TrackJobApplication).Methods("POST")
-	behaviorRouter.HandleFunc("/track/save", h.TrackJobSave).Methods("POST")
-	behaviorRouter.HandleFunc("/track/dismiss", h.TrackJobDismiss).Methods("POST")
-	behaviorRouter.HandleFunc("/track/search", h.TrackSearch).Methods("POST")
-	behaviorRouter.HandleFunc("/track/skill-interest", h.TrackSkillInterest).Methods("POST")
-
-	// Behavioral insights
-	behaviorRouter.HandleFunc("/context", h.GetRecommendationContext).Methods("GET")
-	behaviorRouter.HandleFunc("/pattern", h.GetBehaviorPattern).Methods("GET")
-	behaviorRouter.HandleFunc("/trends", h.GetBehaviorTrends).Methods("GET")
-
-	// Preference management
-	behaviorRouter.HandleFunc("/preferences", h.UpdatePreferences).Methods("PUT")
-	behaviorRouter.HandleFunc("/feedback", h.ProcessFeedback).Methods("POST")
-
-	// Enhanced recommendations
-	behaviorRouter.HandleFunc("/recommendations/enhanced", h.GetEnhancedRecommendations).Methods("GET")
+// validatable is implemented by the behavior tracking requests
+type validatable interface {
+	Validate() error
 }
 
 // TrackJobView handles job view tracking
-func (h *BehaviorHandler) TrackJobView(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
-		return
-	}
-
+func (h *BehaviorHandler) TrackJobView(c *gin.Context) {
 	var req dto.TrackJobViewRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
-		return
-	}
-
-	timeSpent := time.Duration(req.TimeSpentSeconds) * time.Second
-	err := h.behaviorService.TrackJobView(r.Context(), userID, req.JobID, timeSpent)
-	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to track job view", err)
-		return
-	}
-
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
-		Success: true,
-		Message: "Job view tracked successfully",
+	h.track(c, &req, "Job view tracked successfully", func(ctx context.Context, userID string) error {
+		timeSpent := time.Duration(req.TimeSpentSeconds) * time.Second
+		return h.behaviorService.TrackJobView(ctx, userID, req.JobID, timeSpent)
 	})
 }
 
 // TrackJobApplication handles job application tracking
-func (h *BehaviorHandler) TrackJobApplication(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
-		return
-	}
-
+func (h *BehaviorHandler) TrackJobApplication(c *gin.Context) {
 	var req dto.TrackJobActionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
-		return
-	}
-
-	err := h.behaviorService.TrackJobApplication(r.Context(), userID, req.JobID)
-	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to track job application", err)
-		return
-	}
-
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
-		Success: true,
-		Message: "Job application tracked successfully",
+	h.track(c, &req, "Job application tracked successfully", func(ctx context.Context, userID string) error {
+		return h.behaviorService.TrackJobApplication(ctx, userID, req.JobID)
 	})
 }
 
 // TrackJobSave handles job save tracking
-func (h *BehaviorHandler) TrackJobSave(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
-		return
-	}
-
+func (h *BehaviorHandler) TrackJobSave(c *gin.Context) {
 	var req dto.TrackJobActionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
-		return
-	}
-
-	err := h.behaviorService.TrackJobSave(r.Context(), userID, req.JobID)
-	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to track job save", err)
-		return
-	}
-
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
-		Success: true,
-		Message: "Job save tracked successfully",
+	h.track(c, &req, "Job save tracked successfully", func(ctx context.Context, userID string) error {
+		return h.behaviorService.TrackJobSave(ctx, userID, req.JobID)
 	})
 }
 
 // TrackJobDismiss handles job dismissal tracking
-func (h *BehaviorHandler) TrackJobDismiss(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
-		return
-	}
-
+func (h *BehaviorHandler) TrackJobDismiss(c *gin.Context) {
 	var req dto.TrackJobActionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
-		return
-	}
-
-	err := h.behaviorService.TrackJobDismiss(r.Context(), userID, req.JobID)
-	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to track job dismissal", err)
-		return
-	}
-
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
-		Success: true,
-		Message: "Job dismissal tracked successfully",
+	h.track(c, &req, "Job dismissal tracked successfully", func(ctx context.Context, userID string) error {
+		return h.behaviorService.TrackJobDismiss(ctx, userID, req.JobID)
 	})
 }
 
 // TrackSearch handles search tracking
-func (h *BehaviorHandler) TrackSearch(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
-		return
-	}
-
+func (h *BehaviorHandler) TrackSearch(c *gin.Context) {
 	var req dto.TrackSearchRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
-		return
-	}
-
-	err := h.behaviorService.TrackSearchQuery(r.Context(), userID, req.Query, req.Filters)
-	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to track search", err)
-		return
-	}
-
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
-		Success: true,
-		Message: "Search tracked successfully",
+	h.track(c, &req, "Search tracked successfully", func(ctx context.Context, userID string) error {
+		return h.behaviorService.TrackSearchQuery(ctx, userID, req.Query, req.Filters)
 	})
 }
 
 // TrackSkillInterest handles skill interest tracking
-func (h *BehaviorHandler) TrackSkillInterest(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
-		return
-	}
-
+func (h *BehaviorHandler) TrackSkillInterest(c *gin.Context) {
 	var req dto.TrackSkillInterestRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
-		return
-	}
+	h.track(c, &req, "Skill interest tracked successfully", func(ctx context.Context, userID string) error {
+		return h.behaviorService.TrackSkillInterest(ctx, userID, req.SkillName, req.InteractionType)
+	})
+}
 
-	err := h.behaviorService.TrackSkillInterest(r.Context(), userID, req.SkillName, req.InteractionType)
-	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to track skill interest", err)
-		return
-	}
+// UpdatePreferences updates user preferences
+func (h *BehaviorHandler) UpdatePreferences(c *gin.Context) {
+	var req dto.UpdatePreferencesRequest
+	h.track(c, &req, "Preferences updated successfully", func(ctx context.Context, userID string) error {
+		return h.behaviorService.UpdatePreferences(ctx, userID, req.Preferences)
+	})
+}
 
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
-		Success: true,
-		Message: "Skill interest tracked successfully",
+// ProcessFeedback handles explicit user feedback
+func (h *BehaviorHandler) ProcessFeedback(c *gin.Context) {
+	var req dto.FeedbackRequest
+	h.track(c, &req, "Feedback processed successfully", func(ctx context.Context, userID string) error {
+		return h.behaviorService.LearnFromFeedback(ctx, userID, req.JobID, req.Action, req.Outcome)
 	})
 }
 
 // GetRecommendationContext retrieves behavioral context for recommendations
-func (h *BehaviorHandler) GetRecommendationContext(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
+func (h *BehaviorHandler) GetRecommendationContext(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
 		return
 	}
 
-	context, err := h.behaviorService.GetRecommendationContext(r.Context(), userID)
+	recommendationContext, err := h.behaviorService.GetRecommendationContext(c.Request.Context(), userID)
 	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get recommendation context", err)
+		h.handleError(c, err)
 		return
 	}
 
-	response.WriteSuccessResponse(w, context)
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    recommendationContext,
+		Message: "Recommendation context retrieved successfully",
+	})
 }
 
 // GetBehaviorPattern retrieves user behavior pattern
-func (h *BehaviorHandler) GetBehaviorPattern(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
+func (h *BehaviorHandler) GetBehaviorPattern(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
 		return
 	}
 
-	pattern, err := h.behaviorService.GetUserBehaviorPattern(r.Context(), userID)
+	pattern, err := h.behaviorService.GetUserBehaviorPattern(c.Request.Context(), userID)
 	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get behavior pattern", err)
+		h.handleError(c, err)
 		return
 	}
 
-	response.WriteSuccessResponse(w, pattern)
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    pattern,
+		Message: "Behavior pattern retrieved successfully",
+	})
 }
 
-// GetBehaviorTrends retrieves behavior trend analysis
-func (h *BehaviorHandler) GetBehaviorTrends(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
+// GetBehaviorTrends retrieves behavior trend analysis.
+// Query parameter: days (1-365, default 30).
+func (h *BehaviorHandler) GetBehaviorTrends(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
 		return
 	}
 
-	// Parse days parameter
-	daysStr := r.URL.Query().Get("days")
-	days := 30 // default
-	if daysStr != "" {
-		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 && d <= 365 {
-			days = d
-		}
+	days := 30
+	if d, err := strconv.Atoi(c.Query("days")); err == nil && d > 0 && d <= 365 {
+		days = d
 	}
 
-	trends, err := h.behaviorService.AnalyzeBehaviorTrends(r.Context(), userID, days)
+	trends, err := h.behaviorService.AnalyzeBehaviorTrends(c.Request.Context(), userID, days)
 	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to analyze behavior trends", err)
+		h.handleError(c, err)
 		return
 	}
 
-	response.WriteSuccessResponse(w, trends)
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    trends,
+		Message: "Behavior trends retrieved successfully",
+	})
 }
 
-// UpdatePreferences updates user preferences
-func (h *BehaviorHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
+// GetEnhancedRecommendations ranks jobs for the user and enriches them with behavioral insights.
+// Query parameter: limit (1-50, default 10).
+func (h *BehaviorHandler) GetEnhancedRecommendations(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
 		return
 	}
 
-	var req dto.UpdatePreferencesRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
-		return
+	limit := 10
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 50 {
+		limit = l
 	}
 
-	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
+	matches, err := h.matcher.FindBestMatches(c.Request.Context(), userID, limit)
+	if err != nil {
+		h.handleError(c, err)
 		return
 	}
-
-	err := h.behaviorService.UpdatePreferences(r.Context(), userID, req.Preferences)
+	recommendations, err := h.behaviorService.EnrichRecommendations(c.Request.Context(), userID, matches)
 	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update preferences", err)
+		h.handleError(c, err)
 		return
 	}
 
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
+	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
-		Message: "Preferences updated successfully",
+		Data:    recommendations,
+		Message: "Recommendations retrieved successfully",
 	})
 }
 
-// ProcessFeedback handles explicit user feedback
-func (h *BehaviorHandler) ProcessFeedback(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
+// Helper methods
+
+// track binds and validates a tracking request, then records it for the authenticated user
+func (h *BehaviorHandler) track(c *gin.Context, req validatable, message string, record func(ctx context.Context, userID string) error) {
+	userID, ok := h.requireUser(c)
+	if !ok {
 		return
 	}
 
-	var req dto.FeedbackRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
 		return
 	}
-
 	if err := req.Validate(); err != nil {
-		response.WriteErrorResponse(w, http.StatusBadRequest, "Validation failed", err)
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  []string{err.Error()},
+		})
 		return
 	}
 
-	err := h.behaviorService.LearnFromFeedback(r.Context(), userID, req.JobID, req.Action, req.Outcome)
-	if err != nil {
-		response.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to process feedback", err)
+	if err := record(c.Request.Context(), userID); err != nil {
+		h.handleError(c, err)
 		return
 	}
 
-	response.WriteSuccessResponse(w, dto.TrackingResponse{
+	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
-		Message: "Feedback processed successfully",
+		Message: message,
 	})
 }
 
-// GetEnhancedRecommendations retrieves behaviorally enhanced recommendations
-func (h *BehaviorHandler) GetEnhancedRecommendations(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+func (h *BehaviorHandler) requireUser(c *gin.Context) (string, bool) {
+	userID := c.GetString("userID")
 	if userID == "" {
-		response.WriteErrorResponse(w, http.StatusUnauthorized, "User not authenticated", nil)
-		return
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return "", false
 	}
+	return userID, true
+}
 
-	// Parse limit parameter
-	limitStr := r.URL.Query().Get("limit")
-	limit := 10 // default
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
-			limit = l
-		}
+func (h *BehaviorHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  []string{appErr.Message},
+		})
+		return
 	}
 
-	// This would integrate with your existing matching service
-	// For now, we'll return a placeholder response
-	response.WriteSuccessResponse(w, map[string]interface{}{
-		"message": "Enhanced recommendations endpoint - to be integrated with matching service",
-		"user_id": userID,
-		"limit":   limit,
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"microbridge/backend/internal/core/behavior"
+	"microbridge/backend/internal/dto"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CohortHandler struct {
+	aggregator *behavior.CohortAggregator
+}
+
+func NewCohortHandler(aggregator *behavior.CohortAggregator) *CohortHandler {
+	return &CohortHandler{
+		aggregator: aggregator,
+	}
+}
+
+// GetCohorts reports signup-week cohorts. Query parameters: from and to
+// (YYYY-MM-DD, signup weeks), group_by ("user_type" or "acquisition_source"),
+// user_type, acquisition_source and format ("json" or "csv").
+func (h *CohortHandler) GetCohorts(c *gin.Context) {
+	query := behavior.CohortQuery{
+		GroupBy:           c.Query("group_by"),
+		UserType:          c.Query("user_type"),
+		AcquisitionSource: c.Query("acquisition_source"),
+	}
+
+	var errs []string
+	if err := parseDateQuery(c, "from", &query.From); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if err := parseDateQuery(c, "to", &query.To); err != nil {
+		errs = append(errs, err.Error())
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		errs = append(errs, "format must be json or csv")
+	}
+	switch query.GroupBy {
+	case behavior.CohortGroupNone, behavior.CohortGroupUserType, behavior.CohortGroupSource:
+	default:
+		errs = append(errs, "group_by must be user_type or acquisition_source")
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid cohort query",
+			Errors:  errs,
+		})
+		return
+	}
+
+	report, err := h.aggregator.Report(c.Request.Context(), query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if format == "csv" {
+		filename := fmt.Sprintf("cohorts-%s.csv", time.Now().UTC().Format("20060102"))
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := behavior.WriteCohortCSV(c.Writer, report); err != nil {
+			_ = c.Error(err)
+		}
+		return
+	}
+
+	response := dto.CohortReportResponse{
+		GroupBy:  report.GroupBy,
+		Cohorts:  make([]dto.CohortResponse, len(report.Rows)),
+		Withheld: report.Withheld,
+	}
+	if !report.LastRefreshed.IsZero() {
+		response.LastRefreshed = report.LastRefreshed.Format(time.RFC3339)
+	}
+	for i, row := range report.Rows {
+		response.Cohorts[i] = dto.CohortResponse{
+			CohortWeek:          row.CohortWeek.Format("2006-01-02"),
+			Group:               row.Group,
+			Size:                row.Size,
+			Retention:           row.Retention,
+			SuccessRate:         row.SuccessRate,
+			AvgDaysToSuccess:    row.AvgDaysToSuccess,
+			ActionsPerUser:      row.ActionsPerUser,
+			ApplicationsPerUser: row.ApplicationsPerUser,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    response,
+		Message: "Cohorts retrieved successfully",
+	})
+}
+
+// RefreshCohorts recomputes the trailing cohort window immediately instead of waiting for the schedule
+func (h *CohortHandler) RefreshCohorts(c *gin.Context) {
+	if err := h.aggregator.Refresh(c.Request.Context()); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohorts refreshed",
+	})
+}
+
+// Helper methods
+
+// parseDateQuery reads an optional YYYY-MM-DD query parameter into target
+func parseDateQuery(c *gin.Context, param string, target *time.Time) error {
+	value := c.Query(param)
+	if value == "" {
+		return nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return fmt.Errorf("%s must be a date formatted as YYYY-MM-DD", param)
+	}
+	*target = parsed
+	return nil
+}
+
+func (h *CohortHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  []string{appErr.Message},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
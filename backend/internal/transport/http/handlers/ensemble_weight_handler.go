@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	aiServices "microbridge/backend/internal/ai/services"
+	"microbridge/backend/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EnsembleWeightHandler struct {
+	hybridService *aiServices.HybridMatchingService
+}
+
+func NewEnsembleWeightHandler(hybridService *aiServices.HybridMatchingService) *EnsembleWeightHandler {
+	return &EnsembleWeightHandler{
+		hybridService: hybridService,
+	}
+}
+
+// GetEnsembleWeights returns the default weights and every learned segment
+func (h *EnsembleWeightHandler) GetEnsembleWeights(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    h.hybridService.GetEnsembleWeights(),
+		Message: "Ensemble weights retrieved successfully",
+	})
+}
+
+// ResetEnsembleWeights rolls ?segment= back to the defaults, or every segment when omitted
+func (h *EnsembleWeightHandler) ResetEnsembleWeights(c *gin.Context) {
+	if err := h.hybridService.ResetEnsembleWeights(c.Request.Context(), c.Query("segment")); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, aiServices.ErrUnknownSegment) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, dto.APIResponse{
+			Success: false,
+			Message: err.Error(),
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Ensemble weights reset to defaults",
+	})
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is room for the form's boundaries and headers on top of the file itself
+const multipartOverhead = 64 << 10
+
+type FileHandler struct {
+	fileService services.FileService
+}
+
+func NewFileHandler(fileService services.FileService) *FileHandler {
+	return &FileHandler{
+		fileService: fileService,
+	}
+}
+
+// UploadResume stores the student's resume and puts it on their profile
+func (h *FileHandler) UploadResume(c *gin.Context) {
+	h.uploadProfileFile(c, models.FilePurposeResume)
+}
+
+// UploadLogo stores the employer's company logo and puts it on their profile
+func (h *FileHandler) UploadLogo(c *gin.Context) {
+	h.uploadProfileFile(c, models.FilePurposeLogo)
+}
+
+// UploadApplicationFile attaches a resume or cover_letter to one of the student's applications
+func (h *FileHandler) UploadApplicationFile(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	file, header, ok := h.formFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	purpose := strings.ReplaceAll(c.Param("purpose"), "-", "_")
+	uploaded, err := h.fileService.UploadApplicationFile(c.Request.Context(), c.Param("id"), userID, purpose, header.Filename, file)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    uploaded,
+		Message: "File uploaded successfully",
+	})
+}
+
+// GetFile returns a file's details with a short-lived download URL
+func (h *FileHandler) GetFile(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	file, err := h.fileService.GetFile(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    file,
+		Message: "File retrieved successfully",
+	})
+}
+
+// Download streams a file from local storage. It needs no token: the URL's
+// signature and expiry, issued by GetFile or an upload, are the authorization.
+func (h *FileHandler) Download(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	body, contentType, err := h.fileService.OpenDownload(c.Request.Context(), key, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer body.Close()
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", "attachment")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, body)
+}
+
+// Helper methods
+
+func (h *FileHandler) uploadProfileFile(c *gin.Context, purpose string) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	file, header, ok := h.formFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	uploaded, err := h.fileService.UploadProfileFile(c.Request.Context(), userID, purpose, header.Filename, file)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    uploaded,
+		Message: "File uploaded successfully",
+	})
+}
+
+// formFile reads the "file" field of a multipart upload, refusing bodies far
+// beyond the size limit before they are buffered
+func (h *FileHandler) formFile(c *gin.Context) (multipart.File, *multipart.FileHeader, bool) {
+	maxSize := h.fileService.MaxFileSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.APIResponse{
+				Success: false,
+				Message: fmt.Sprintf("Files can be at most %d MB", maxSize>>20),
+			})
+			return nil, nil, false
+		}
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Send the file as multipart/form-data in a field named \"file\"",
+			Errors:  []string{err.Error()},
+		})
+		return nil, nil, false
+	}
+	return file, header, true
+}
+
+func (h *FileHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		messages := []string{appErr.Message}
+		if appErr.Details != "" {
+			messages = append(messages, appErr.Details)
+		}
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  messages,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"microbridge/backend/internal/core/behavior"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FunnelHandler struct {
+	funnels *behavior.FunnelService
+}
+
+func NewFunnelHandler(funnels *behavior.FunnelService) *FunnelHandler {
+	return &FunnelHandler{
+		funnels: funnels,
+	}
+}
+
+// GetEmployerFunnel returns the authenticated employer's funnel overall and per
+// job. Query parameters: from and to (YYYY-MM-DD) and job_id.
+func (h *FunnelHandler) GetEmployerFunnel(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	// The employer filter keeps other employers' jobs out even when job_id names one
+	query := behavior.FunnelQuery{EmployerID: userID, JobID: c.Query("job_id")}
+	if errs := parseFunnelWindow(c, &query); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid funnel query",
+			Errors:  errs,
+		})
+		return
+	}
+	query = h.funnels.Resolve(query)
+
+	query.Scope = repository.FunnelScopeEmployer
+	overall, err := h.funnels.Funnels(c.Request.Context(), query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	query.Scope = repository.FunnelScopeJob
+	jobs, err := h.funnels.Funnels(c.Request.Context(), query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	if query.JobID != "" && len(jobs) == 0 {
+		h.handleError(c, apperrors.NewNotFoundError("Job funnel"))
+		return
+	}
+
+	response := dto.EmployerFunnelResponse{
+		From:    query.From.Format(time.RFC3339),
+		To:      query.To.Format(time.RFC3339),
+		Overall: toFunnelResponse(behavior.BuildFunnel(repository.FunnelStats{Key: userID})),
+		Jobs:    make([]dto.FunnelResponse, len(jobs)),
+	}
+	if len(overall) > 0 {
+		response.Overall = toFunnelResponse(overall[0])
+	}
+	for i, funnel := range jobs {
+		response.Jobs[i] = toFunnelResponse(funnel)
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    response,
+		Message: "Funnel retrieved successfully",
+	})
+}
+
+// CompareFunnels sets funnels against the platform funnel. Query parameters:
+// scope ("category" by default, "employer", "job" or "platform"), from and to
+// (YYYY-MM-DD), category, employer_id and job_id.
+func (h *FunnelHandler) CompareFunnels(c *gin.Context) {
+	query := behavior.FunnelQuery{
+		Scope:      c.DefaultQuery("scope", repository.FunnelScopeCategory),
+		Category:   c.Query("category"),
+		EmployerID: c.Query("employer_id"),
+		JobID:      c.Query("job_id"),
+	}
+	errs := parseFunnelWindow(c, &query)
+	switch query.Scope {
+	case repository.FunnelScopePlatform, repository.FunnelScopeCategory, repository.FunnelScopeEmployer, repository.FunnelScopeJob:
+	default:
+		errs = append(errs, "scope must be platform, category, employer or job")
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid funnel query",
+			Errors:  errs,
+		})
+		return
+	}
+
+	comparison, err := h.funnels.Compare(c.Request.Context(), query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := dto.FunnelComparisonResponse{
+		Scope:    comparison.Scope,
+		From:     comparison.From.Format(time.RFC3339),
+		To:       comparison.To.Format(time.RFC3339),
+		Baseline: toFunnelResponse(comparison.Baseline),
+		Funnels:  make([]dto.FunnelComparisonEntry, len(comparison.Funnels)),
+	}
+	for i, funnel := range comparison.Funnels {
+		response.Funnels[i] = dto.FunnelComparisonEntry{
+			FunnelResponse:   toFunnelResponse(funnel),
+			ConversionDeltas: comparison.Deltas[i],
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    response,
+		Message: "Funnel comparison retrieved successfully",
+	})
+}
+
+// Helper methods
+
+// parseFunnelWindow reads from and to; to is exclusive, so ?to=2024-04-01 ends with March
+func parseFunnelWindow(c *gin.Context, query *behavior.FunnelQuery) []string {
+	var errs []string
+	if err := parseDateQuery(c, "from", &query.From); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if err := parseDateQuery(c, "to", &query.To); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.To.After(query.From) {
+		errs = append(errs, "to must be after from")
+	}
+	return errs
+}
+
+func toFunnelResponse(funnel behavior.Funnel) dto.FunnelResponse {
+	response := dto.FunnelResponse{
+		Key:               funnel.Key,
+		Stages:            make([]dto.FunnelStageResponse, len(funnel.Stages)),
+		OverallConversion: funnel.OverallConversion,
+	}
+	for i, stage := range funnel.Stages {
+		response.Stages[i] = dto.FunnelStageResponse{
+			Name:                      stage.Name,
+			Count:                     stage.Count,
+			ConversionRate:            stage.ConversionRate,
+			MedianSecondsFromPrevious: stage.MedianTimeFromPrevious.Seconds(),
+		}
+	}
+	return response
+}
+
+func (h *FunnelHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  []string{appErr.Message},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
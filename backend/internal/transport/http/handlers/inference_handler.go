@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	aiModels "microbridge/backend/internal/ai/models"
+	aiServices "microbridge/backend/internal/ai/services"
+	"microbridge/backend/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InferenceHandler struct {
+	batchService *aiServices.BatchInferenceService
+}
+
+func NewInferenceHandler(batchService *aiServices.BatchInferenceService) *InferenceHandler {
+	return &InferenceHandler{
+		batchService: batchService,
+	}
+}
+
+// SubmitBatch queues an asynchronous batch scoring job
+func (h *InferenceHandler) SubmitBatch(c *gin.Context) {
+	var req dto.BatchInferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	options := aiModels.BatchInferenceOptions{
+		Timeout:          time.Duration(req.TimeoutSeconds) * time.Second,
+		ParallelWorkers:  req.ParallelWorkers,
+		RetryFailedItems: req.RetryFailedItems,
+		MaxRetries:       req.MaxRetries,
+	}
+
+	var batch *aiModels.BatchInferenceRequest
+	switch {
+	case len(req.Pairs) > 0:
+		pairs := make([][2]string, len(req.Pairs))
+		for i, pair := range req.Pairs {
+			pairs[i] = [2]string{pair.UserID, pair.JobID}
+		}
+		batch = aiServices.NewPairBatchRequest(pairs, req.ModelType, options)
+	case len(req.UserIDs) > 0 && len(req.JobIDs) > 0:
+		var err error
+		if batch, err = aiServices.NewCrossBatchRequest(req.UserIDs, req.JobIDs, req.ModelType, options); err != nil {
+			h.handleError(c, err)
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Either pairs or user_ids and job_ids are required",
+		})
+		return
+	}
+
+	job, err := h.batchService.SubmitBatch(batch, c.GetString("userID"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.APIResponse{
+		Success: true,
+		Data:    job,
+		Message: "Batch inference job queued",
+	})
+}
+
+// GetBatch returns the status of a batch scoring job
+func (h *InferenceHandler) GetBatch(c *gin.Context) {
+	job, err := h.batchService.GetBatchJob(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    job,
+		Message: "Batch inference job retrieved successfully",
+	})
+}
+
+// GetBatchResults returns the scores and per-item errors of a finished batch
+func (h *InferenceHandler) GetBatchResults(c *gin.Context) {
+	result, err := h.batchService.GetBatchResult(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "Batch inference results retrieved successfully",
+	})
+}
+
+// CancelBatch stops a queued or running batch
+func (h *InferenceHandler) CancelBatch(c *gin.Context) {
+	if err := h.batchService.CancelBatch(c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Batch inference job cancelled",
+	})
+}
+
+// Helper methods
+
+func (h *InferenceHandler) handleError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, aiServices.ErrBatchJobNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, aiServices.ErrBatchNotFinished):
+		status = http.StatusConflict
+	case errors.Is(err, aiServices.ErrBatchEmpty), errors.Is(err, aiServices.ErrBatchTooLarge):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, dto.APIResponse{
+		Success: false,
+		Message: err.Error(),
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InterviewHandler struct {
+	interviewService services.InterviewService
+}
+
+func NewInterviewHandler(interviewService services.InterviewService) *InterviewHandler {
+	return &InterviewHandler{
+		interviewService: interviewService,
+	}
+}
+
+// ProposeInterview offers an applicant interview slots
+func (h *InterviewHandler) ProposeInterview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.ProposeInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	interview, err := h.interviewService.ProposeInterview(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    interview,
+		Message: "Interview proposed successfully",
+	})
+}
+
+// ListInterviews lists the user's interviews, optionally for one application
+func (h *InterviewHandler) ListInterviews(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	interviews, err := h.interviewService.ListInterviews(c.Request.Context(), userID, c.Query("application_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    interviews,
+		Message: "Interviews retrieved successfully",
+	})
+}
+
+// GetInterview retrieves an interview
+func (h *InterviewHandler) GetInterview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	interview, err := h.interviewService.GetInterview(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    interview,
+		Message: "Interview retrieved successfully",
+	})
+}
+
+// SelectSlot books one of the proposed slots
+func (h *InterviewHandler) SelectSlot(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.SelectInterviewSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	interview, err := h.interviewService.SelectSlot(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    interview,
+		Message: "Interview scheduled successfully",
+	})
+}
+
+// RescheduleInterview proposes new slots or asks for them
+func (h *InterviewHandler) RescheduleInterview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.RescheduleInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	interview, err := h.interviewService.RescheduleInterview(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    interview,
+		Message: "Interview rescheduled successfully",
+	})
+}
+
+// CancelInterview cancels an interview
+func (h *InterviewHandler) CancelInterview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.CancelInterviewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid request format",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	interview, err := h.interviewService.CancelInterview(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    interview,
+		Message: "Interview cancelled successfully",
+	})
+}
+
+// CompleteInterview records that an interview took place
+func (h *InterviewHandler) CompleteInterview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	interview, err := h.interviewService.CompleteInterview(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    interview,
+		Message: "Interview completed successfully",
+	})
+}
+
+// DownloadInvite returns the interview as an iCalendar file
+func (h *InterviewHandler) DownloadInvite(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	invite, err := h.interviewService.GetInvite(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="interview.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8; method=REQUEST", invite)
+}
+
+// Helper methods
+
+func (h *InterviewHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		errors := []string{appErr.Message}
+		if appErr.Details != "" {
+			errors = append(errors, appErr.Details)
+		}
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  errors,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	jobstatus "microbridge/backend/internal/core/jobs"
 	"microbridge/backend/internal/dto"
 	"microbridge/backend/internal/services"
 	apperrors "microbridge/backend/internal/shared/errors"
@@ -165,7 +166,7 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 		Category:        c.Query("category"),
 		Location:        c.Query("location"),
 		ExperienceLevel: c.Query("experience_level"),
-		Status:          c.DefaultQuery("status", "posted"), // Only show posted jobs by default
+		Status:          jobstatus.StatusPosted, // Drafts and closed jobs only show on the employer's own list
 		JobType:         c.Query("job_type"),
 		EmployerID:      c.Query("employer_id"),
 	}
@@ -197,7 +198,7 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 	})
 }
 
-// GetJobsByEmployer gets jobs posted by a specific employer
+// GetJobsByEmployer gets the posted jobs of a specific employer
 func (h *JobHandler) GetJobsByEmployer(c *gin.Context) {
 	employerID := c.Param("id")
 	if employerID == "" {
@@ -212,7 +213,7 @@ func (h *JobHandler) GetJobsByEmployer(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 
-	jobs, err := h.jobService.GetJobsByEmployer(c.Request.Context(), employerID, page, limit)
+	jobs, err := h.jobService.GetJobsByEmployer(c.Request.Context(), employerID, jobstatus.StatusPosted, page, limit)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -225,7 +226,28 @@ func (h *JobHandler) GetJobsByEmployer(c *gin.Context) {
 	})
 }
 
-// SearchJobs searches for jobs based on query and filters
+// ListMyJobs lists the signed-in employer's jobs in any status, optionally
+// filtered by ?status
+func (h *JobHandler) ListMyJobs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	jobs, err := h.jobService.GetJobsByEmployer(c.Request.Context(), c.GetString("userID"), c.Query("status"), page, limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    jobs,
+		Message: "Jobs retrieved successfully",
+	})
+}
+
+// SearchJobs ranks jobs matching the query and filters, with highlighted
+// excerpts and facet counts. Students signed in get results blended with their
+// match score.
 func (h *JobHandler) SearchJobs(c *gin.Context) {
 	query := c.Query("q")
 
@@ -238,7 +260,7 @@ func (h *JobHandler) SearchJobs(c *gin.Context) {
 		Category:        c.Query("category"),
 		Location:        c.Query("location"),
 		ExperienceLevel: c.Query("experience_level"),
-		Status:          c.DefaultQuery("status", "posted"),
+		Status:          jobstatus.StatusPosted,
 		JobType:         c.Query("job_type"),
 	}
 
@@ -254,7 +276,7 @@ func (h *JobHandler) SearchJobs(c *gin.Context) {
 		filters.Skills = []string{skillsStr}
 	}
 
-	jobs, err := h.jobService.SearchJobs(c.Request.Context(), query, filters, page, limit)
+	jobs, err := h.jobService.SearchJobs(c.Request.Context(), c.GetString("userID"), query, filters, page, limit)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -267,14 +289,67 @@ func (h *JobHandler) SearchJobs(c *gin.Context) {
 	})
 }
 
+// SuggestJobSkills extracts required skills and seniority from a draft posting for review
+func (h *JobHandler) SuggestJobSkills(c *gin.Context) {
+	var req dto.SuggestJobSkillsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	analysis, err := h.jobService.SuggestJobSkills(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    analysis,
+		Message: "Skill suggestions generated successfully",
+	})
+}
+
+// GetScreeningQuestions returns a job's screening questions with their knockout answers (job owner only)
+func (h *JobHandler) GetScreeningQuestions(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	questions, err := h.jobService.GetScreeningQuestions(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    questions,
+		Message: "Screening questions retrieved successfully",
+	})
+}
+
 // Helper methods
 
 func (h *JobHandler) handleError(c *gin.Context, err error) {
 	if appErr, ok := err.(*apperrors.AppError); ok {
+		errors := []string{appErr.Message}
+		if appErr.Details != "" {
+			errors = append(errors, appErr.Details)
+		}
 		c.JSON(appErr.Code, dto.APIResponse{
 			Success: false,
 			Message: appErr.Message,
-			Errors:  []string{appErr.Message},
+			Errors:  errors,
 		})
 		return
 	}
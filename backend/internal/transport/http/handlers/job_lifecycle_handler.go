@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobLifecycleHandler struct {
+	lifecycleService services.JobLifecycleService
+}
+
+func NewJobLifecycleHandler(lifecycleService services.JobLifecycleService) *JobLifecycleHandler {
+	return &JobLifecycleHandler{
+		lifecycleService: lifecycleService,
+	}
+}
+
+// TransitionJob moves a job to the next status in its lifecycle
+func (h *JobLifecycleHandler) TransitionJob(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.JobTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	timeline, err := h.lifecycleService.TransitionJob(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    timeline,
+		Message: "Job status updated successfully",
+	})
+}
+
+// OverrideJobStatus forces a job into any status, for administrators
+func (h *JobLifecycleHandler) OverrideJobStatus(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.JobStatusOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	timeline, err := h.lifecycleService.OverrideJobStatus(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    timeline,
+		Message: "Job status overridden successfully",
+	})
+}
+
+// GetJobHistory returns a job's status timeline
+func (h *JobLifecycleHandler) GetJobHistory(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	timeline, err := h.lifecycleService.GetJobHistory(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    timeline,
+		Message: "Job history retrieved successfully",
+	})
+}
+
+// Helper methods
+
+func (h *JobLifecycleHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		messages := []string{appErr.Message}
+		if appErr.Details != "" {
+			messages = append(messages, appErr.Details)
+		}
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  messages,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	aiServices "microbridge/backend/internal/ai/services"
+	"microbridge/backend/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MatchingHandler struct {
+	hybridService *aiServices.HybridMatchingService
+}
+
+func NewMatchingHandler(hybridService *aiServices.HybridMatchingService) *MatchingHandler {
+	return &MatchingHandler{
+		hybridService: hybridService,
+	}
+}
+
+// GetRecommendations ranks jobs for the authenticated user.
+// Query parameter: limit (1-50, default 10).
+func (h *MatchingHandler) GetRecommendations(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	limit := 10
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 50 {
+		limit = l
+	}
+
+	matches, err := h.hybridService.FindBestMatches(c.Request.Context(), userID, limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    matches,
+		Message: "Recommendations retrieved successfully",
+	})
+}
+
+// GetMatchScore scores the job in the path for the authenticated user
+func (h *MatchingHandler) GetMatchScore(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	match, err := h.hybridService.CalculateMatchScore(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    match,
+		Message: "Match score calculated successfully",
+	})
+}
+
+// GetMatchExplanation explains why the job in the path matches the authenticated user.
+// Query parameter: tier (free, basic or pro; default free).
+func (h *MatchingHandler) GetMatchExplanation(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	explanation, err := h.hybridService.GetMatchExplanation(c.Request.Context(), userID, c.Param("id"), c.DefaultQuery("tier", "free"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    explanation,
+		Message: "Match explanation generated successfully",
+	})
+}
+
+// GetSkillGapAnalysis compares the authenticated user's skills with the job in the path
+func (h *MatchingHandler) GetSkillGapAnalysis(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	analysis, err := h.hybridService.GetSkillGapAnalysis(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    analysis,
+		Message: "Skill gap analysis generated successfully",
+	})
+}
+
+// Helper methods
+
+func (h *MatchingHandler) handleError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	message := "Internal server error"
+	if errors.Is(err, aiServices.ErrLLMUnavailable) {
+		status = http.StatusServiceUnavailable
+		message = "Match explanations are not available"
+	}
+
+	c.JSON(status, dto.APIResponse{
+		Success: false,
+		Message: message,
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications retrieves the authenticated user's notifications, newest first.
+// Query parameters: page, limit and unread_only.
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, total, err := h.notificationService.GetUserNotifications(c.Request.Context(), userID, page, limit, c.Query("unread_only") == "true")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data: gin.H{
+			"notifications": notifications,
+			"pagination": dto.PaginationResponse{
+				Page:    page,
+				Limit:   limit,
+				Total:   total,
+				HasMore: int64(page*limit) < total,
+			},
+		},
+		Message: "Notifications retrieved successfully",
+	})
+}
+
+// GetUnreadCount returns how many of the authenticated user's notifications are unread
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	count, err := h.notificationService.GetUnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    gin.H{"unread_count": count},
+		Message: "Unread count retrieved successfully",
+	})
+}
+
+// MarkAsRead marks one of the authenticated user's notifications as read
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(c.Request.Context(), userID, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notification marked as read",
+	})
+}
+
+// MarkAllAsRead marks all of the authenticated user's notifications as read
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	if err := h.notificationService.MarkAllAsRead(c.Request.Context(), userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "All notifications marked as read",
+	})
+}
+
+// DeleteNotification deletes one of the authenticated user's notifications
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	if err := h.notificationService.DeleteNotification(c.Request.Context(), userID, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notification deleted successfully",
+	})
+}
+
+// GetNotificationSettings retrieves the authenticated user's notification settings
+func (h *NotificationHandler) GetNotificationSettings(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	settings, err := h.notificationService.GetNotificationSettings(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    settings,
+		Message: "Notification settings retrieved successfully",
+	})
+}
+
+// UpdateNotificationSettings updates the authenticated user's notification settings
+func (h *NotificationHandler) UpdateNotificationSettings(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var settings models.NotificationSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := h.notificationService.UpdateNotificationSettings(c.Request.Context(), userID, &settings); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    settings,
+		Message: "Notification settings updated successfully",
+	})
+}
+
+// SendNotification sends a notification to any user, for administrators
+func (h *NotificationHandler) SendNotification(c *gin.Context) {
+	var req dto.SendNotificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	notification, err := h.notificationService.CreateNotification(
+		c.Request.Context(),
+		req.UserID,
+		req.Title,
+		req.Message,
+		models.NotificationType(req.Type),
+		req.ActionURL,
+		req.ActionText,
+		req.Metadata,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    notification,
+		Message: "Notification sent successfully",
+	})
+}
+
+// Helper methods
+
+func (h *NotificationHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  []string{appErr.Message},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OfferHandler struct {
+	offerService services.OfferService
+}
+
+func NewOfferHandler(offerService services.OfferService) *OfferHandler {
+	return &OfferHandler{
+		offerService: offerService,
+	}
+}
+
+// CreateOffer offers an applicant the job
+func (h *OfferHandler) CreateOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.CreateOfferRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid request format",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	offer, err := h.offerService.CreateOffer(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    offer,
+		Message: "Offer sent successfully",
+	})
+}
+
+// ListOffers lists the offers the user made or received, optionally for one application
+func (h *OfferHandler) ListOffers(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	offers, err := h.offerService.ListOffers(c.Request.Context(), userID, c.Query("application_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    offers,
+		Message: "Offers retrieved successfully",
+	})
+}
+
+func (h *OfferHandler) GetOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	offer, err := h.offerService.GetOffer(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    offer,
+		Message: "Offer retrieved successfully",
+	})
+}
+
+// UpdateOffer revises an open offer
+func (h *OfferHandler) UpdateOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.UpdateOfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	offer, err := h.offerService.UpdateOffer(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    offer,
+		Message: "Offer updated successfully",
+	})
+}
+
+func (h *OfferHandler) WithdrawOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	req, ok := h.bindReason(c)
+	if !ok {
+		return
+	}
+
+	offer, err := h.offerService.WithdrawOffer(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    offer,
+		Message: "Offer withdrawn successfully",
+	})
+}
+
+// AcceptOffer accepts an offer and fills the job
+func (h *OfferHandler) AcceptOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	offer, err := h.offerService.AcceptOffer(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    offer,
+		Message: "Offer accepted successfully",
+	})
+}
+
+func (h *OfferHandler) DeclineOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	req, ok := h.bindReason(c)
+	if !ok {
+		return
+	}
+
+	offer, err := h.offerService.DeclineOffer(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    offer,
+		Message: "Offer declined successfully",
+	})
+}
+
+// CounterOffer proposes other terms to the employer
+func (h *OfferHandler) CounterOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.CounterOfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	offer, err := h.offerService.CounterOffer(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    offer,
+		Message: "Counter offer sent successfully",
+	})
+}
+
+// Helper methods
+
+// bindReason reads the optional reason body of a decline or withdrawal
+func (h *OfferHandler) bindReason(c *gin.Context) (dto.OfferReasonRequest, bool) {
+	var req dto.OfferReasonRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid request format",
+				Errors:  []string{err.Error()},
+			})
+			return req, false
+		}
+	}
+	return req, true
+}
+
+func (h *OfferHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		messages := []string{appErr.Message}
+		if appErr.Details != "" {
+			messages = append(messages, appErr.Details)
+		}
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  messages,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"microbridge/backend/internal/core/privacy"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/repository"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PrivacyHandler struct {
+	privacy *privacy.Service
+}
+
+func NewPrivacyHandler(privacy *privacy.Service) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacy: privacy,
+	}
+}
+
+// ExportMyData downloads everything held about the authenticated user.
+// Query parameter: format ("zip" by default, or "json").
+func (h *PrivacyHandler) ExportMyData(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+	h.export(c, userID, userID)
+}
+
+// EraseMyAccount erases the authenticated user's account and personal data
+func (h *PrivacyHandler) EraseMyAccount(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+	h.erase(c, userID, userID)
+}
+
+// ExportUserData downloads everything held about the user in the path, for administrators
+func (h *PrivacyHandler) ExportUserData(c *gin.Context) {
+	h.export(c, c.Param("id"), c.GetString("userID"))
+}
+
+// EraseUser erases the user in the path, for administrators
+func (h *PrivacyHandler) EraseUser(c *gin.Context) {
+	h.erase(c, c.Param("id"), c.GetString("userID"))
+}
+
+// GetDataRequests lists the exports and erasures of the user in the path
+func (h *PrivacyHandler) GetDataRequests(c *gin.Context) {
+	requests, err := h.privacy.History(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := make([]dto.DataSubjectRequestResponse, len(requests))
+	for i, request := range requests {
+		response[i] = toDataSubjectRequestResponse(request)
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    response,
+		Message: "Data requests retrieved successfully",
+	})
+}
+
+// Helper methods
+
+func (h *PrivacyHandler) export(c *gin.Context, userID, requestedBy string) {
+	format := c.DefaultQuery("format", "zip")
+	if format != "zip" && format != "json" {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid export format",
+			Errors:  []string{"format must be zip or json"},
+		})
+		return
+	}
+
+	export, err := h.privacy.Export(c.Request.Context(), userID, requestedBy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("personal-data-%s-%s.%s", userID, export.GeneratedAt.Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-store")
+	if format == "json" {
+		c.JSON(http.StatusOK, export)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+	if err := privacy.WriteArchive(c.Writer, export); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func (h *PrivacyHandler) erase(c *gin.Context, userID, requestedBy string) {
+	request, err := h.privacy.Erase(c.Request.Context(), userID, requestedBy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    toDataSubjectRequestResponse(request),
+		Message: "User data erased successfully",
+	})
+}
+
+func toDataSubjectRequestResponse(request *repository.DataSubjectRequest) dto.DataSubjectRequestResponse {
+	return dto.DataSubjectRequestResponse{
+		ID:           request.ID,
+		UserID:       request.UserID,
+		Type:         request.Type,
+		RequestedBy:  request.RequestedBy,
+		AffectedRows: request.AffectedRows,
+		CreatedAt:    request.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *PrivacyHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  []string{appErr.Message},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
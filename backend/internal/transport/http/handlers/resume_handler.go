@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"microbridge/backend/internal/core/resume"
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResumeHandler struct {
+	resumeService services.ResumeService
+}
+
+func NewResumeHandler(resumeService services.ResumeService) *ResumeHandler {
+	return &ResumeHandler{
+		resumeService: resumeService,
+	}
+}
+
+// ParseResume accepts a multipart "file" upload (PDF, Markdown or text) or JSON
+// with pasted text, and returns skill proposals without changing the profile
+func (h *ResumeHandler) ParseResume(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var content []byte
+	var format string
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Resume file is required",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+		if fileHeader.Size > services.MaxResumeFileSize {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.APIResponse{
+				Success: false,
+				Message: "Resume file is too large",
+			})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		defer file.Close()
+
+		content, err = io.ReadAll(io.LimitReader(file, services.MaxResumeFileSize+1))
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		format = c.PostForm("format")
+		if format == "" {
+			format = resume.DetectFormat(fileHeader.Filename, content)
+		}
+	} else {
+		var req dto.ParseResumeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid request format",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+		content = []byte(req.Content)
+		format = req.Format
+	}
+
+	parsed, err := h.resumeService.ParseResume(c.Request.Context(), userID, content, format)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    parsed,
+		Message: "Resume parsed; confirm the skills to add them to your profile",
+	})
+}
+
+// ConfirmResumeSkills saves the skills the student accepted from a parsed resume
+func (h *ResumeHandler) ConfirmResumeSkills(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.ConfirmResumeSkillsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	skills, err := h.resumeService.ConfirmSkills(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    skills,
+		Message: "Profile skills updated successfully",
+	})
+}
+
+// Helper methods
+
+func (h *ResumeHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		errorDetails := []string{appErr.Message}
+		if appErr.Details != "" {
+			errorDetails = []string{appErr.Details}
+		}
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  errorDetails,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewService services.ReviewService
+}
+
+func NewReviewHandler(reviewService services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// CreateReview reviews the other party to a job in its review period
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    review,
+		Message: "Review submitted successfully",
+	})
+}
+
+// GetReview retrieves a visible review, or a hidden one for its author
+func (h *ReviewHandler) GetReview(c *gin.Context) {
+	review, err := h.reviewService.GetReview(c.Request.Context(), c.Param("id"), c.GetString("userID"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    review,
+		Message: "Review retrieved successfully",
+	})
+}
+
+// UpdateReview edits the authenticated user's review before it becomes visible
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req dto.UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	review, err := h.reviewService.UpdateReview(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    review,
+		Message: "Review updated successfully",
+	})
+}
+
+// DeleteReview withdraws the authenticated user's review before it becomes visible
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(c.Request.Context(), c.Param("id"), userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Review deleted successfully",
+	})
+}
+
+// GetUserReviews retrieves the visible reviews the user in the path has received
+func (h *ReviewHandler) GetUserReviews(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	reviews, err := h.reviewService.GetUserReviews(c.Request.Context(), c.Param("id"), page, limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    reviews,
+		Message: "Reviews retrieved successfully",
+	})
+}
+
+// GetJobReviews retrieves the visible reviews left for the job in the path
+func (h *ReviewHandler) GetJobReviews(c *gin.Context) {
+	reviews, err := h.reviewService.GetJobReviews(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    reviews,
+		Message: "Job reviews retrieved successfully",
+	})
+}
+
+// CompleteJob marks the job in the path as completed and opens its review period
+func (h *ReviewHandler) CompleteJob(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	completion, err := h.reviewService.CompleteJob(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    completion,
+		Message: "Job completed successfully",
+	})
+}
+
+// Helper methods
+
+func (h *ReviewHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		c.JSON(appErr.Code, dto.APIResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  []string{appErr.Message},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Message: "Internal server error",
+		Errors:  []string{err.Error()},
+	})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	aiServices "microbridge/backend/internal/ai/services"
+	"microbridge/backend/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrainingHandler struct {
+	orchestrator *aiServices.TrainingOrchestrator
+}
+
+func NewTrainingHandler(orchestrator *aiServices.TrainingOrchestrator) *TrainingHandler {
+	return &TrainingHandler{
+		orchestrator: orchestrator,
+	}
+}
+
+// StartTraining queues a background training run
+func (h *TrainingHandler) StartTraining(c *gin.Context) {
+	var req dto.StartTrainingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	job, err := h.orchestrator.Submit(c.Request.Context(), &aiServices.TrainingRequest{
+		ModelType:       req.ModelType,
+		MaxEpochs:       req.MaxEpochs,
+		BatchSize:       req.BatchSize,
+		LearningRate:    req.LearningRate,
+		ValidationSplit: req.ValidationSplit,
+		DatasetSince:    req.DatasetSince,
+		DatasetLimit:    req.DatasetLimit,
+		CreatedBy:       c.GetString("userID"),
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.APIResponse{
+		Success: true,
+		Data:    job,
+		Message: "Training job queued",
+	})
+}
+
+// ListTrainingJobs returns training runs, optionally filtered by ?model_type=
+func (h *TrainingHandler) ListTrainingJobs(c *gin.Context) {
+	jobs := h.orchestrator.ListJobs(c.Query("model_type"))
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    jobs,
+		Message: "Training jobs retrieved successfully",
+	})
+}
+
+// GetTrainingJob returns the status and per-epoch metrics of a training run
+func (h *TrainingHandler) GetTrainingJob(c *gin.Context) {
+	job, err := h.orchestrator.GetJob(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    job,
+		Message: "Training job retrieved successfully",
+	})
+}
+
+// CancelTrainingJob stops a queued or running training run
+func (h *TrainingHandler) CancelTrainingJob(c *gin.Context) {
+	if err := h.orchestrator.Cancel(c.Request.Context(), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Training job cancelled",
+	})
+}
+
+// Helper methods
+
+func (h *TrainingHandler) handleError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, aiServices.ErrTrainingJobNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, aiServices.ErrTrainingJobFinished):
+		status = http.StatusConflict
+	case errors.Is(err, aiServices.ErrUnsupportedModelType), errors.Is(err, aiServices.ErrInvalidTrainingRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, aiServices.ErrTrainingQueueFull), errors.Is(err, aiServices.ErrTrainingOrchestratorOff):
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, dto.APIResponse{
+		Success: false,
+		Message: err.Error(),
+		Errors:  []string{err.Error()},
+	})
+}
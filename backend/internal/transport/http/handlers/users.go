@@ -286,28 +286,6 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	})
 }
 
-func (h *UserHandler) DeleteUser(c *gin.Context) {
-	userID := c.Param("id")
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, dto.APIResponse{
-			Success: false,
-			Message: "User ID is required",
-		})
-		return
-	}
-
-	err := h.userService.DeleteUser(c.Request.Context(), userID)
-	if err != nil {
-		h.handleError(c, err)
-		return
-	}
-
-	c.JSON(http.StatusOK, dto.APIResponse{
-		Success: true,
-		Message: "User deleted successfully",
-	})
-}
-
 // Helper methods
 
 func (h *UserHandler) handleError(c *gin.Context, err error) {
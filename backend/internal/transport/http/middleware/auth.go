@@ -91,6 +91,32 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
     }
 }
 
+// OptionalAuth sets the user context when a valid token is sent and otherwise
+// lets the request through anonymously, for public routes that personalise
+// their results for signed-in users
+func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        parts := strings.Split(c.GetHeader("Authorization"), " ")
+        if len(parts) != 2 || parts[0] != "Bearer" {
+            c.Next()
+            return
+        }
+
+        claims, err := m.jwtService.ValidateToken(parts[1])
+        if err != nil {
+            c.Next()
+            return
+        }
+
+        c.Set("userID", claims.UserID)
+        c.Set("userType", claims.UserType)
+        c.Set("userEmail", claims.Email)
+        c.Set("user_claims", claims)
+
+        c.Next()
+    }
+}
+
 // RequireRole checks if user has required role
 func (m *AuthMiddleware) RequireRole(requiredRole string) gin.HandlerFunc {
     return func(c *gin.Context) {
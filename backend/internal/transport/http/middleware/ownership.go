@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"microbridge/backend/internal/models"
+	apperrors "microbridge/backend/internal/shared/errors"
+)
+
+// JobLookup loads jobs for ownership checks; repository.JobRepository implements it
+type JobLookup interface {
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+}
+
+// RequireJobOwner ensures the job in the :id path parameter belongs to the authenticated employer.
+// It must run after RequireAuth.
+func (m *AuthMiddleware) RequireJobOwner(jobs JobLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUserID := c.GetString("userID")
+		if currentUserID == "" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "User ID not found in context",
+				"code":  "MISSING_USER_ID",
+			})
+			c.Abort()
+			return
+		}
+
+		job, err := jobs.GetByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			if errors.Is(err, apperrors.ErrJobNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": "Job not found",
+					"code":  "JOB_NOT_FOUND",
+				})
+			} else {
+				m.logger.Error().
+					Err(err).
+					Str("job_id", c.Param("id")).
+					Msg("Failed to load job for ownership check")
+
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to verify job ownership",
+					"code":  "INTERNAL_ERROR",
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		if job.EmployerID != currentUserID {
+			m.logger.Warn().
+				Str("current_user", currentUserID).
+				Str("job_id", job.ID).
+				Str("path", c.Request.URL.Path).
+				Msg("Unauthorized job access attempt")
+
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Access denied. You can only manage your own jobs.",
+				"code":  "ACCESS_DENIED",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,115 +1,299 @@
-﻿package routes
+package routes
 
 import (
 	"net/http"
-	
+	"time"
+
+	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
 	"microbridge/backend/internal/transport/http/handlers"
 	"microbridge/backend/internal/transport/http/middleware"
-	"gorm.io/gorm"
 )
 
-func SetupRoutes(r *gin.Engine, db *gorm.DB) {
-	// API versioning
+// Config holds the router settings that come from the server configuration
+type Config struct {
+	Environment  string
+	AllowOrigins []string
+}
+
+// Handlers groups every HTTP handler the API serves
+type Handlers struct {
+	User            *handlers.UserHandler
+	Resume          *handlers.ResumeHandler
+	Job             *handlers.JobHandler
+	JobLifecycle    *handlers.JobLifecycleHandler
+	Application     *handlers.ApplicationHandler
+	ApplicationBulk *handlers.ApplicationBulkHandler
+	Interview       *handlers.InterviewHandler
+	Offer           *handlers.OfferHandler
+	Matching        *handlers.MatchingHandler
+	Review          *handlers.ReviewHandler
+	Notification    *handlers.NotificationHandler
+	Behavior        *handlers.BehaviorHandler
+	BehaviorEvents  *handlers.BehaviorEventHandler
+	Inference       *handlers.InferenceHandler
+	Training        *handlers.TrainingHandler
+	EnsembleWeight  *handlers.EnsembleWeightHandler
+	Cohort          *handlers.CohortHandler
+	Funnel          *handlers.FunnelHandler
+	Privacy         *handlers.PrivacyHandler
+	File            *handlers.FileHandler
+}
+
+// NewRouter builds the gin engine and mounts every handler behind the auth middleware.
+// Routes that act on a job an employer owns also check ownership through jobs.
+func NewRouter(cfg Config, auth *middleware.AuthMiddleware, jobs middleware.JobLookup, h Handlers) *gin.Engine {
+	if cfg.Environment == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	r := gin.New()
+	r.Use(gin.Logger())
+	r.Use(gin.Recovery())
+
+	// Add metrics endpoint
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// CORS configuration
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowOrigins = cfg.AllowOrigins
+	corsConfig.AllowCredentials = true
+	corsConfig.AddAllowHeaders("Authorization")
+	r.Use(cors.New(corsConfig))
+
+	// Health check
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status":      "healthy",
+			"timestamp":   time.Now(),
+			"environment": cfg.Environment,
+			"version":     "v1.0.0",
+		})
+	})
+
 	api := r.Group("/api/v1")
-	
-	// Middleware
-	api.Use(middleware.Logger())
-	api.Use(middleware.ErrorHandler())
-	api.Use(middleware.CORS())
-	
-	// Initialize services
-	notificationService := services.NewNotificationService(db)
-	reviewService := services.NewReviewService(
-		repository.NewReviewRepository(db),
-		repository.NewUserRepository(db),
-		repository.NewJobRepository(db),
-		repository.NewApplicationRepository(db),
-	)
-	
-	// Initialize handlers
-	userHandler := handlers.NewUserHandler(db)
-	jobHandler := handlers.NewJobHandler(db)
-	matchingHandler := handlers.NewMatchingHandler(db)
-	applicationHandler := handlers.NewApplicationHandler(db)
-	notificationHandler := handlers.NewNotificationHandler(notificationService)
-	reviewHandler := handlers.NewReviewHandler(reviewService)
-	
-	// User routes
-	userRoutes := api.Group("/users")
+	requireAuth := auth.RequireAuth()
+
+	// Public authentication routes
+	authRoutes := api.Group("/auth")
 	{
-		userRoutes.POST("", userHandler.CreateUser)
-		userRoutes.GET("/:id", userHandler.GetUser)
-		userRoutes.PUT("/:id", userHandler.UpdateUser)
-		userRoutes.PUT("/:id/profile", userHandler.UpdateProfile)
+		authRoutes.POST("/register", h.User.Register)
+		authRoutes.POST("/login", h.User.Login)
+		authRoutes.POST("/refresh", h.User.RefreshToken)
+		authRoutes.GET("/verify-email", h.User.VerifyEmail)
+		authRoutes.POST("/forgot-password", h.User.ForgotPassword)
+		authRoutes.POST("/reset-password", h.User.ResetPassword)
 	}
-	
-	// Job routes
+
+	// Protected user routes
+	users := api.Group("/users")
+	users.Use(requireAuth)
+	{
+		users.GET("/profile", h.User.GetProfile)
+		users.PUT("/profile", h.User.UpdateProfile)
+		users.POST("/profile/resume/parse", h.Resume.ParseResume)
+		users.POST("/profile/resume/confirm", h.Resume.ConfirmResumeSkills)
+		users.POST("/profile/resume", auth.RequireRole("student"), h.File.UploadResume)
+		users.POST("/profile/logo", auth.RequireRole("employer"), h.File.UploadLogo)
+		users.GET("/:id", h.User.GetUser)
+		users.PUT("/:id", auth.RequireSelfOrAdmin(), h.User.UpdateUser)
+		users.GET("/:id/reviews", h.Review.GetUserReviews)
+
+		// Personal data export and account erasure
+		users.GET("/me/data-export", h.Privacy.ExportMyData)
+		users.DELETE("/me", h.Privacy.EraseMyAccount)
+	}
+
+	// Behavior tracking and behavioral insights
+	behaviorRoutes := api.Group("/behavior")
+	behaviorRoutes.Use(requireAuth)
+	{
+		behaviorRoutes.POST("/events", h.BehaviorEvents.TrackEvents)
+		behaviorRoutes.POST("/track/view", h.Behavior.TrackJobView)
+		behaviorRoutes.POST("/track/application", h.Behavior.TrackJobApplication)
+		behaviorRoutes.POST("/track/save", h.Behavior.TrackJobSave)
+		behaviorRoutes.POST("/track/dismiss", h.Behavior.TrackJobDismiss)
+		behaviorRoutes.POST("/track/search", h.Behavior.TrackSearch)
+		behaviorRoutes.POST("/track/skill-interest", h.Behavior.TrackSkillInterest)
+		behaviorRoutes.PUT("/preferences", h.Behavior.UpdatePreferences)
+		behaviorRoutes.POST("/feedback", h.Behavior.ProcessFeedback)
+		behaviorRoutes.GET("/context", h.Behavior.GetRecommendationContext)
+		behaviorRoutes.GET("/pattern", h.Behavior.GetBehaviorPattern)
+		behaviorRoutes.GET("/trends", h.Behavior.GetBehaviorTrends)
+		behaviorRoutes.GET("/recommendations/enhanced", h.Behavior.GetEnhancedRecommendations)
+	}
+
+	// Public job browsing
 	jobRoutes := api.Group("/jobs")
 	{
-		jobRoutes.GET("", jobHandler.GetJobs)
-		jobRoutes.POST("", jobHandler.CreateJob)
-		jobRoutes.GET("/:id", jobHandler.GetJob)
-		jobRoutes.PUT("/:id", jobHandler.UpdateJob)
-		jobRoutes.DELETE("/:id", jobHandler.DeleteJob)
+		jobRoutes.GET("", h.Job.ListJobs)
+		// Signed-in students get results ranked by how well the jobs fit them
+		jobRoutes.GET("/search", auth.OptionalAuth(), h.Job.SearchJobs)
+		jobRoutes.GET("/:id", h.Job.GetJob)
+		jobRoutes.GET("/:id/reviews", h.Review.GetJobReviews)
+	}
+	api.GET("/employers/:id/jobs", h.Job.GetJobsByEmployer)
+
+	// Either party can mark hired work as done
+	api.POST("/jobs/:id/complete", requireAuth, h.Review.CompleteJob)
+
+	// Lifecycle changes and history; the service works out which side the user is on
+	api.POST("/jobs/:id/status", requireAuth, h.JobLifecycle.TransitionJob)
+	api.GET("/jobs/:id/history", requireAuth, h.JobLifecycle.GetJobHistory)
+
+	// Employer job management
+	employerJobs := api.Group("/jobs")
+	employerJobs.Use(requireAuth)
+	employerJobs.Use(auth.RequireRole("employer"))
+	{
+		employerJobs.POST("", h.Job.CreateJob)
+		// The employer's own jobs in any status, including drafts
+		employerJobs.GET("/mine", h.Job.ListMyJobs)
+		employerJobs.POST("/skills/suggest", h.Job.SuggestJobSkills)
+
+		ownedJobs := employerJobs.Group("")
+		ownedJobs.Use(auth.RequireJobOwner(jobs))
+		{
+			ownedJobs.PUT("/:id", h.Job.UpdateJob)
+			ownedJobs.DELETE("/:id", h.Job.DeleteJob)
+			ownedJobs.GET("/:id/screening", h.Job.GetScreeningQuestions)
+			ownedJobs.GET("/:id/applications", h.Application.GetJobApplications)
+			ownedJobs.POST("/:id/applications/bulk", h.ApplicationBulk.BulkUpdateApplications)
+			ownedJobs.GET("/:id/applications/bulk/:operationId", h.ApplicationBulk.GetBulkOperation)
+			ownedJobs.POST("/:id/applications/export", h.ApplicationBulk.ExportApplications)
+		}
+	}
+
+	// Applications; the service checks the applicant or job owner on each one
+	// and moves them through the application lifecycle
+	applications := api.Group("/applications")
+	applications.Use(requireAuth)
+	{
+		applications.POST("", auth.RequireRole("student"), h.Application.SubmitApplication)
+		applications.GET("", h.Application.GetUserApplications)
+		applications.GET("/:id", h.Application.GetApplication)
+		applications.GET("/:id/timeline", h.Application.GetApplicationTimeline)
+		applications.POST("/:id/submit", auth.RequireRole("student"), h.Application.SubmitDraft)
+		applications.POST("/:id/withdraw", auth.RequireRole("student"), h.Application.WithdrawApplication)
+		applications.PUT("/:id/status", auth.RequireRole("employer"), h.Application.UpdateApplicationStatus)
+		applications.POST("/:id/interviews", auth.RequireRole("employer"), h.Interview.ProposeInterview)
+		applications.POST("/:id/offers", auth.RequireRole("employer"), h.Offer.CreateOffer)
+		applications.POST("/:id/files/:purpose", auth.RequireRole("student"), h.File.UploadApplicationFile)
+	}
+
+	// Uploaded files; the service decides who may read each one and signs a
+	// short-lived download URL. Local storage downloads are authorized by that signature.
+	files := api.Group("/files")
+	files.Use(requireAuth)
+	{
+		files.GET("/:id", h.File.GetFile)
+	}
+	api.GET("/downloads/*key", h.File.Download)
+
+	// Interviews; the service limits each one to its student and employer
+	interviews := api.Group("/interviews")
+	interviews.Use(requireAuth)
+	{
+		interviews.GET("", h.Interview.ListInterviews)
+		interviews.GET("/:id", h.Interview.GetInterview)
+		interviews.GET("/:id/invite.ics", h.Interview.DownloadInvite)
+		interviews.POST("/:id/select", auth.RequireRole("student"), h.Interview.SelectSlot)
+		interviews.POST("/:id/reschedule", h.Interview.RescheduleInterview)
+		interviews.POST("/:id/cancel", h.Interview.CancelInterview)
+		interviews.POST("/:id/complete", auth.RequireRole("employer"), h.Interview.CompleteInterview)
+	}
+
+	// Offers; the service limits each one to its student and employer
+	offers := api.Group("/offers")
+	offers.Use(requireAuth)
+	{
+		offers.GET("", h.Offer.ListOffers)
+		offers.GET("/:id", h.Offer.GetOffer)
+		offers.PUT("/:id", auth.RequireRole("employer"), h.Offer.UpdateOffer)
+		offers.POST("/:id/withdraw", auth.RequireRole("employer"), h.Offer.WithdrawOffer)
+		offers.POST("/:id/accept", auth.RequireRole("student"), h.Offer.AcceptOffer)
+		offers.POST("/:id/decline", auth.RequireRole("student"), h.Offer.DeclineOffer)
+		offers.POST("/:id/counter", auth.RequireRole("student"), h.Offer.CounterOffer)
+	}
+
+	// Double-blind reviews
+	reviews := api.Group("/reviews")
+	reviews.Use(requireAuth)
+	{
+		reviews.POST("", h.Review.CreateReview)
+		reviews.GET("/:id", h.Review.GetReview)
+		reviews.PUT("/:id", h.Review.UpdateReview)
+		reviews.DELETE("/:id", h.Review.DeleteReview)
 	}
-	
-	// Enhanced matching routes
+
+	// Job matching for the authenticated user
 	matchingRoutes := api.Group("/matching")
+	matchingRoutes.Use(requireAuth)
 	{
-		// User-centric matching endpoints
-		matchingRoutes.GET("/recommendations/:userId", matchingHandler.GetRecommendations)
-		matchingRoutes.POST("/recommendations/:userId", matchingHandler.GetRecommendationsWithFilters)
-		matchingRoutes.GET("/calculate/:userId/:jobId", matchingHandler.CalculateMatchScore)
-		matchingRoutes.GET("/similar/:jobId", matchingHandler.GetSimilarJobs)
-		
-		// Analytics and insights
-		matchingRoutes.GET("/analytics/:userId", matchingHandler.GetMatchingAnalytics)
-		matchingRoutes.GET("/insights/:userId", matchingHandler.GetUserInsights)
+		matchingRoutes.GET("/recommendations", h.Matching.GetRecommendations)
+		matchingRoutes.GET("/jobs/:id/score", h.Matching.GetMatchScore)
+		matchingRoutes.GET("/jobs/:id/explanation", h.Matching.GetMatchExplanation)
+		matchingRoutes.GET("/jobs/:id/skill-gap", h.Matching.GetSkillGapAnalysis)
 	}
-	
-	// Application routes
-	applicationRoutes := api.Group("/applications")
+
+	// In-app notifications
+	notifications := api.Group("/notifications")
+	notifications.Use(requireAuth)
 	{
-		applicationRoutes.POST("", applicationHandler.CreateApplication)
-		applicationRoutes.GET("/:id", applicationHandler.GetApplication)
-		applicationRoutes.PUT("/:id", applicationHandler.UpdateApplication)
-		applicationRoutes.GET("/user/:userId", applicationHandler.GetUserApplications)
-		applicationRoutes.GET("/job/:jobId", applicationHandler.GetJobApplications)
+		notifications.GET("", h.Notification.GetNotifications)
+		notifications.GET("/unread-count", h.Notification.GetUnreadCount)
+		notifications.PUT("/read-all", h.Notification.MarkAllAsRead)
+		notifications.PUT("/:id/read", h.Notification.MarkAsRead)
+		notifications.DELETE("/:id", h.Notification.DeleteNotification)
+		notifications.GET("/settings", h.Notification.GetNotificationSettings)
+		notifications.PUT("/settings", h.Notification.UpdateNotificationSettings)
 	}
-	
-	// Review routes
-	reviewRoutes := api.Group("/reviews")
+
+	// Employer analytics
+	employerAnalytics := api.Group("/employer/analytics")
+	employerAnalytics.Use(requireAuth)
+	employerAnalytics.Use(auth.RequireRole("employer"))
 	{
-		reviewRoutes.POST("", reviewHandler.CreateReview)
-		reviewRoutes.GET("/:userId", reviewHandler.GetUserReviews)
-		reviewRoutes.GET("/:id", reviewHandler.GetReviewByID)
-		reviewRoutes.PUT("/:id", reviewHandler.UpdateReview)
-		reviewRoutes.DELETE("/:id", reviewHandler.DeleteReview)
-		reviewRoutes.GET("/eligibility/:jobId", reviewHandler.CheckReviewEligibility)
+		employerAnalytics.GET("/funnel", h.Funnel.GetEmployerFunnel)
 	}
-	
-	// Job completion route
-	jobRoutes.POST("/:id/complete", reviewHandler.CompleteJob)
-	
-	// Notification routes
-	notificationRoutes := api.Group("/notifications")
+
+	// Admin routes
+	admin := api.Group("/admin")
+	admin.Use(requireAuth)
+	admin.Use(auth.RequireRole("admin"))
 	{
-		notificationRoutes.GET("", notificationHandler.GetNotifications)
-		notificationRoutes.GET("/unread-count", notificationHandler.GetUnreadCount)
-		notificationRoutes.PUT("/:id/read", notificationHandler.MarkAsRead)
-		notificationRoutes.PUT("/mark-all-read", notificationHandler.MarkAllAsRead)
-		notificationRoutes.DELETE("/:id", notificationHandler.DeleteNotification)
-		notificationRoutes.GET("/settings", notificationHandler.GetNotificationSettings)
-		notificationRoutes.PUT("/settings", notificationHandler.UpdateNotificationSettings)
-		notificationRoutes.POST("", notificationHandler.CreateNotification)
+		admin.GET("/users", h.User.ListUsers)
+		admin.DELETE("/users/:id", h.Privacy.EraseUser)
+		admin.GET("/users/:id/data-export", h.Privacy.ExportUserData)
+		admin.GET("/users/:id/data-requests", h.Privacy.GetDataRequests)
+		admin.POST("/notifications", h.Notification.SendNotification)
+		admin.POST("/jobs/:id/status", h.JobLifecycle.OverrideJobStatus)
+
+		// Batch scoring for digests and shortlists
+		admin.POST("/ai/batch-inference", h.Inference.SubmitBatch)
+		admin.GET("/ai/batch-inference/:id", h.Inference.GetBatch)
+		admin.GET("/ai/batch-inference/:id/results", h.Inference.GetBatchResults)
+		admin.DELETE("/ai/batch-inference/:id", h.Inference.CancelBatch)
+
+		// Model training runs
+		admin.POST("/ai/training", h.Training.StartTraining)
+		admin.GET("/ai/training", h.Training.ListTrainingJobs)
+		admin.GET("/ai/training/:id", h.Training.GetTrainingJob)
+		admin.DELETE("/ai/training/:id", h.Training.CancelTrainingJob)
+
+		// Learned ensemble weights
+		admin.GET("/ai/ensemble-weights", h.EnsembleWeight.GetEnsembleWeights)
+		admin.DELETE("/ai/ensemble-weights", h.EnsembleWeight.ResetEnsembleWeights)
+
+		// Signup cohort retention and success
+		admin.GET("/analytics/cohorts", h.Cohort.GetCohorts)
+		admin.POST("/analytics/cohorts/refresh", h.Cohort.RefreshCohorts)
+
+		// Marketplace conversion funnels against the platform baseline
+		admin.GET("/analytics/funnel", h.Funnel.CompareFunnels)
 	}
-	
-	// Health check
-	api.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status": "ok",
-			"service": "microbridge-api",
-		})
-	})
-}
\ No newline at end of file
+
+	return r
+}
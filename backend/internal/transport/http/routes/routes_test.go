@@ -0,0 +1,293 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"microbridge/backend/internal/dto"
+	"microbridge/backend/internal/models"
+	"microbridge/backend/internal/services"
+	apperrors "microbridge/backend/internal/shared/errors"
+	"microbridge/backend/internal/transport/http/handlers"
+	"microbridge/backend/internal/transport/http/middleware"
+	"microbridge/backend/pkg/jwt"
+	pkglogger "microbridge/backend/pkg/logger"
+)
+
+type fakeJobLookup struct {
+	jobs map[string]*models.Job
+}
+
+func (l *fakeJobLookup) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, ok := l.jobs[id]
+	if !ok {
+		return nil, apperrors.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// fakeApplicationService records which job and employer applications were listed for
+type fakeApplicationService struct {
+	services.ApplicationService
+	jobID      string
+	employerID string
+}
+
+func (s *fakeApplicationService) GetJobApplications(ctx context.Context, jobID string, employerID string, filters dto.JobApplicationFilters, page, limit int) (*dto.PaginatedApplicationResponse, error) {
+	s.jobID = jobID
+	s.employerID = employerID
+	return &dto.PaginatedApplicationResponse{}, nil
+}
+
+// fakeJobService records the status filters each job listing was asked for
+type fakeJobService struct {
+	services.JobService
+	listStatus     string
+	searchStatus   string
+	employerID     string
+	employerStatus string
+}
+
+func (s *fakeJobService) ListJobs(ctx context.Context, filters dto.JobFilters, page, limit int) (*dto.PaginatedJobResponse, error) {
+	s.listStatus = filters.Status
+	return &dto.PaginatedJobResponse{}, nil
+}
+
+func (s *fakeJobService) SearchJobs(ctx context.Context, searcherID, query string, filters dto.JobFilters, page, limit int) (*dto.JobSearchResponse, error) {
+	s.searchStatus = filters.Status
+	return &dto.JobSearchResponse{}, nil
+}
+
+func (s *fakeJobService) GetJobsByEmployer(ctx context.Context, employerID, status string, page, limit int) (*dto.PaginatedJobResponse, error) {
+	s.employerID = employerID
+	s.employerStatus = status
+	return &dto.PaginatedJobResponse{}, nil
+}
+
+// fakeReviewService records the reviewer of the last created review
+type fakeReviewService struct {
+	services.ReviewService
+	reviewerID string
+}
+
+func (s *fakeReviewService) CreateReview(ctx context.Context, reviewerID string, req dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	s.reviewerID = reviewerID
+	return &dto.ReviewResponse{ReviewerID: reviewerID, JobID: req.JobID, Rating: req.Rating}, nil
+}
+
+type testServer struct {
+	router       *gin.Engine
+	jwtService   *jwt.Service
+	jobs         *fakeJobService
+	applications *fakeApplicationService
+	reviews      *fakeReviewService
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	jwtService := jwt.NewJWTService("test-secret", "microbridge", time.Hour, 24*time.Hour)
+	auth := middleware.NewAuthMiddleware(jwtService, pkglogger.New("error", false))
+	jobs := &fakeJobLookup{jobs: map[string]*models.Job{
+		"job-1": {ID: "job-1", EmployerID: "employer-1"},
+	}}
+	jobService := &fakeJobService{}
+	applications := &fakeApplicationService{}
+	reviews := &fakeReviewService{}
+
+	// Handlers whose services aren't faked are never reached in these tests
+	router := NewRouter(Config{Environment: "test", AllowOrigins: []string{"http://localhost:3000"}}, auth, jobs, Handlers{
+		User:            handlers.NewUserHandler(nil),
+		Resume:          handlers.NewResumeHandler(nil),
+		Job:             handlers.NewJobHandler(jobService),
+		JobLifecycle:    handlers.NewJobLifecycleHandler(nil),
+		Application:     handlers.NewApplicationHandler(applications),
+		ApplicationBulk: handlers.NewApplicationBulkHandler(nil),
+		Interview:       handlers.NewInterviewHandler(nil),
+		Offer:           handlers.NewOfferHandler(nil),
+		Matching:        handlers.NewMatchingHandler(nil),
+		Review:          handlers.NewReviewHandler(reviews),
+		Notification:    handlers.NewNotificationHandler(nil),
+		Behavior:        handlers.NewBehaviorHandler(nil, nil),
+		BehaviorEvents:  handlers.NewBehaviorEventHandler(nil),
+		Inference:       handlers.NewInferenceHandler(nil),
+		Training:        handlers.NewTrainingHandler(nil),
+		EnsembleWeight:  handlers.NewEnsembleWeightHandler(nil),
+		Cohort:          handlers.NewCohortHandler(nil),
+		Funnel:          handlers.NewFunnelHandler(nil),
+		Privacy:         handlers.NewPrivacyHandler(nil),
+		File:            handlers.NewFileHandler(nil),
+	})
+
+	return &testServer{router: router, jwtService: jwtService, jobs: jobService, applications: applications, reviews: reviews}
+}
+
+func (s *testServer) do(t *testing.T, method, path, userID, userType, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		token, _, err := s.jwtService.GenerateTokenPair(userID, userType, userID+"@example.com")
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// concretePath fills path parameters so a registered route can be requested
+func concretePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "some-id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var publicRoutes = map[string]bool{
+	"GET /metrics":                      true,
+	"GET /health":                       true,
+	"POST /api/v1/auth/register":        true,
+	"POST /api/v1/auth/login":           true,
+	"POST /api/v1/auth/refresh":         true,
+	"GET /api/v1/auth/verify-email":     true,
+	"POST /api/v1/auth/forgot-password": true,
+	"POST /api/v1/auth/reset-password":  true,
+	"GET /api/v1/jobs":                  true,
+	"GET /api/v1/jobs/search":           true,
+	"GET /api/v1/jobs/:id":              true,
+	"GET /api/v1/jobs/:id/reviews":      true,
+	"GET /api/v1/employers/:id/jobs":    true,
+	"GET /api/v1/downloads/*key":        true,
+}
+
+func TestRouter_ProtectedRoutesRequireAuth(t *testing.T) {
+	server := newTestServer(t)
+
+	protected := 0
+	for _, route := range server.router.Routes() {
+		if publicRoutes[route.Method+" "+route.Path] {
+			continue
+		}
+		protected++
+
+		recorder := server.do(t, route.Method, concretePath(route.Path), "", "", "")
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s without a token: expected 401, got %d", route.Method, route.Path, recorder.Code)
+		}
+	}
+	if protected < 50 {
+		t.Errorf("Expected every handler to be mounted, only found %d protected routes", protected)
+	}
+}
+
+func TestRouter_AdminRoutesRejectOtherRoles(t *testing.T) {
+	server := newTestServer(t)
+
+	for _, route := range server.router.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/v1/admin/") {
+			continue
+		}
+		for _, userType := range []string{"student", "employer"} {
+			recorder := server.do(t, route.Method, concretePath(route.Path), "user-1", userType, "")
+			if recorder.Code != http.StatusForbidden {
+				t.Errorf("%s %s as %s: expected 403, got %d", route.Method, route.Path, userType, recorder.Code)
+			}
+		}
+	}
+}
+
+func TestRouter_JobRoutesRequireOwnership(t *testing.T) {
+	server := newTestServer(t)
+
+	for _, tc := range []struct {
+		method, path string
+	}{
+		{http.MethodPut, "/api/v1/jobs/job-1"},
+		{http.MethodDelete, "/api/v1/jobs/job-1"},
+		{http.MethodGet, "/api/v1/jobs/job-1/applications"},
+	} {
+		if recorder := server.do(t, tc.method, tc.path, "employer-2", "employer", "{}"); recorder.Code != http.StatusForbidden {
+			t.Errorf("%s %s by another employer: expected 403, got %d", tc.method, tc.path, recorder.Code)
+		}
+		if recorder := server.do(t, tc.method, tc.path, "employer-1", "student", "{}"); recorder.Code != http.StatusForbidden {
+			t.Errorf("%s %s by a student: expected 403, got %d", tc.method, tc.path, recorder.Code)
+		}
+	}
+
+	if recorder := server.do(t, http.MethodGet, "/api/v1/jobs/missing/applications", "employer-1", "employer", ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown job, got %d", recorder.Code)
+	}
+
+	recorder := server.do(t, http.MethodGet, "/api/v1/jobs/job-1/applications", "employer-1", "employer", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected the owner to list applications, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if server.applications.jobID != "job-1" || server.applications.employerID != "employer-1" {
+		t.Errorf("Unexpected application lookup: %+v", server.applications)
+	}
+}
+
+func TestRouter_PublicJobListingsOnlyShowPostedJobs(t *testing.T) {
+	server := newTestServer(t)
+
+	for _, path := range []string{
+		"/api/v1/jobs?status=draft",
+		"/api/v1/jobs/search?status=archived",
+		"/api/v1/employers/employer-1/jobs?status=draft",
+	} {
+		if recorder := server.do(t, http.MethodGet, path, "", "", ""); recorder.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d: %s", path, recorder.Code, recorder.Body.String())
+		}
+	}
+	if server.jobs.listStatus != "posted" || server.jobs.searchStatus != "posted" || server.jobs.employerStatus != "posted" {
+		t.Errorf("Expected public listings to be limited to posted jobs, got %+v", server.jobs)
+	}
+
+	recorder := server.do(t, http.MethodGet, "/api/v1/jobs/mine?status=draft", "employer-1", "employer", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected the employer to list their own jobs, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if server.jobs.employerID != "employer-1" || server.jobs.employerStatus != "draft" {
+		t.Errorf("Expected the caller's drafts to be listed, got %+v", server.jobs)
+	}
+
+	if recorder := server.do(t, http.MethodGet, "/api/v1/jobs/mine", "student-1", "student", ""); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected a student to be refused the employer list, got %d", recorder.Code)
+	}
+}
+
+func TestRouter_UpdateUserRequiresSelfOrAdmin(t *testing.T) {
+	server := newTestServer(t)
+
+	recorder := server.do(t, http.MethodPut, "/api/v1/users/student-2", "student-1", "student", "{}")
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected another user's profile update to be rejected, got %d", recorder.Code)
+	}
+}
+
+func TestRouter_CreateReview(t *testing.T) {
+	server := newTestServer(t)
+
+	body := `{"reviewee_id":"employer-1","job_id":"job-1","rating":5,"comment":"Clear brief and quick payment"}`
+	recorder := server.do(t, http.MethodPost, "/api/v1/reviews", "student-1", "student", body)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if server.reviews.reviewerID != "student-1" {
+		t.Errorf("Expected the token user to be the reviewer, got %q", server.reviews.reviewerID)
+	}
+}